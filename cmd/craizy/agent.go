@@ -0,0 +1,397 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// runAgentCommand handles the agent subcommand and its subcommands.
+func runAgentCommand() {
+	if len(os.Args) < 3 {
+		printAgentHelp()
+		return
+	}
+
+	subCmd := os.Args[2]
+	switch subCmd {
+	case "create":
+		runAgentCreate()
+	case "meta":
+		runAgentMeta()
+	case "restart":
+		runAgentRestart()
+	case "retry":
+		runAgentRetry()
+	case "help", "--help", "-h":
+		printAgentHelp()
+	default:
+		fmt.Printf("Unknown agent subcommand: %s\n", subCmd)
+		printAgentHelp()
+		os.Exit(exitUsage)
+	}
+}
+
+func printAgentHelp() {
+	fmt.Println("Usage: craizy agent <command> [options]")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  create   Create agents from a YAML or CSV manifest (--manifest)")
+	fmt.Println("  meta     Show an agent's metadata, or set it with key=value (key= deletes)")
+	fmt.Println("  restart  Start an agent's command again in a new session, keeping its worktree")
+	fmt.Println("  retry    Restart an exited agent, seeding it with its task and progress so far")
+	fmt.Println()
+	fmt.Println("Manifest entries have a type (an agent from AGENTS.yml), a name, and an")
+	fmt.Println("optional base branch and startup prompt; \"@name\" uses a library prompt.")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  craizy agent create --manifest team.yaml")
+	fmt.Println("  craizy agent create --manifest team.csv --quiet")
+	fmt.Println("  craizy agent meta claude-auth model=opus issue=42")
+	fmt.Println("  craizy agent restart auth --prompt")
+	fmt.Println("  craizy agent retry auth")
+}
+
+// manifestResult is the outcome of creating one manifest agent.
+type manifestResult struct {
+	entry config.ManifestAgent
+	agent *domain.Agent
+	err   error
+}
+
+func runAgentCreate() {
+	fs := flag.NewFlagSet("agent create", flag.ExitOnError)
+	manifestPath := fs.String("manifest", "", "YAML or CSV file listing agents to create (required)")
+	quiet := addQuietFlag(fs)
+
+	if err := fs.Parse(os.Args[3:]); err != nil {
+		os.Exit(exitUsage)
+	}
+	if *manifestPath == "" {
+		fmt.Println("Error: --manifest is required")
+		fmt.Println()
+		fmt.Println("Usage: craizy agent create --manifest <team.yaml|team.csv>")
+		os.Exit(exitUsage)
+	}
+
+	if readOnlyRequested() {
+		fmt.Printf("Error: creating agents is disabled in read-only mode (%s is set)\n", readOnlyEnv)
+		os.Exit(exitError)
+	}
+
+	manifest, err := config.LoadManifest(*manifestPath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Failed to get working directory: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	if !isInitialized(workDir) {
+		fmt.Println("This directory is not initialized. Run 'craizy init' first.")
+		os.Exit(exitNotInitialized)
+	}
+
+	if err := logging.Init(config.CraizyDirPath(workDir)); err == nil {
+		defer logging.Close()
+	}
+
+	agentTypes, err := config.LoadAgents(config.AgentsPath(workDir))
+	if err != nil {
+		fmt.Printf("Error: failed to load agents: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	a, err := newApp(workDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer a.Close()
+
+	// Agents over their provider's concurrency limit are queued, not failed
+	results := make([]manifestResult, 0, len(manifest.Agents))
+	for _, entry := range manifest.Agents {
+		agent, err := createManifestAgent(a, workDir, agentTypes, entry, nil)
+		if err != nil {
+			logging.Error(err, "name", entry.Name, "type", entry.Type)
+		}
+		results = append(results, manifestResult{entry: entry, agent: agent, err: err})
+	}
+
+	failed := printManifestResults(results, *quiet)
+	if failed > 0 {
+		os.Exit(exitError)
+	}
+}
+
+// runAgentMeta shows an agent's metadata, or sets the key=value pairs given.
+func runAgentMeta() {
+	const usage = "Usage: craizy agent meta [agent-id] [key=value ...]"
+	agentID, pairs := splitAgentArg(os.Args[3:])
+	if len(pairs) > 0 && strings.HasPrefix(pairs[0], "-") {
+		fmt.Println(usage)
+		os.Exit(exitUsage)
+	}
+	if len(pairs) > 0 && readOnlyRequested() {
+		fmt.Printf("Error: changing agents is disabled in read-only mode (%s is set)\n", readOnlyEnv)
+		os.Exit(exitError)
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Failed to get working directory: %v\n", err)
+		os.Exit(exitError)
+	}
+	if !isInitialized(workDir) {
+		fmt.Println("This directory is not initialized. Run 'craizy init' first.")
+		os.Exit(exitNotInitialized)
+	}
+	if err := logging.Init(config.CraizyDirPath(workDir)); err == nil {
+		defer logging.Close()
+	}
+
+	a, err := newApp(workDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer a.Close()
+	agentID = resolveAgentArg(a, agentID, usage)
+
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			fmt.Printf("Error: expected key=value, got %q\n", pair)
+			os.Exit(exitUsage)
+		}
+		if err := a.agentService.SetMetadata(agentID, key, value); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitError)
+		}
+	}
+	if len(pairs) > 0 {
+		return
+	}
+
+	agent := a.agentStore.Get(agentID)
+	if agent == nil || len(agent.Metadata) == 0 {
+		fmt.Printf("No metadata on %s\n", agentID)
+		return
+	}
+	keys := slices.Sorted(maps.Keys(agent.Metadata))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, key := range keys {
+		fmt.Fprintf(w, "%s\t%s\n", key, agent.Metadata[key])
+	}
+	w.Flush()
+}
+
+func runAgentRestart() {
+	const usage = "Usage: craizy agent restart [agent-id] [--prompt]"
+	agentID, args := splitAgentArg(os.Args[3:])
+	fs := flag.NewFlagSet("agent restart", flag.ExitOnError)
+	prompt := fs.Bool("prompt", false, "Send the agent type's startup prompt again")
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		fmt.Println(usage)
+		os.Exit(exitUsage)
+	}
+	if readOnlyRequested() {
+		fmt.Printf("Error: restarting agents is disabled in read-only mode (%s is set)\n", readOnlyEnv)
+		os.Exit(exitError)
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Failed to get working directory: %v\n", err)
+		os.Exit(exitError)
+	}
+	if !isInitialized(workDir) {
+		fmt.Println("This directory is not initialized. Run 'craizy init' first.")
+		os.Exit(exitNotInitialized)
+	}
+	if err := logging.Init(config.CraizyDirPath(workDir)); err == nil {
+		defer logging.Close()
+	}
+
+	a, err := newApp(workDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer a.Close()
+	agentID = resolveAgentArg(a, agentID, usage)
+
+	opts, err := restartOptions(workDir, a.agentStore.Get(agentID), *prompt)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	if err := a.agentService.RestartWithOptions(agentID, opts); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	fmt.Printf("Restarted %s\n", agentID)
+}
+
+func runAgentRetry() {
+	const usage = "Usage: craizy agent retry [agent-id]"
+	agentID, args := splitAgentArg(os.Args[3:])
+	fs := flag.NewFlagSet("agent retry", flag.ExitOnError)
+	if err := fs.Parse(args); err != nil || fs.NArg() > 0 {
+		fmt.Println(usage)
+		os.Exit(exitUsage)
+	}
+	if readOnlyRequested() {
+		fmt.Printf("Error: retrying agents is disabled in read-only mode (%s is set)\n", readOnlyEnv)
+		os.Exit(exitError)
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Failed to get working directory: %v\n", err)
+		os.Exit(exitError)
+	}
+	if !isInitialized(workDir) {
+		fmt.Println("This directory is not initialized. Run 'craizy init' first.")
+		os.Exit(exitNotInitialized)
+	}
+	if err := logging.Init(config.CraizyDirPath(workDir)); err == nil {
+		defer logging.Close()
+	}
+
+	a, err := newApp(workDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer a.Close()
+	agentID = resolveAgentArg(a, agentID, usage)
+
+	// The type's startup prompt stands in for the task of agents started before it was recorded
+	opts, err := restartOptions(workDir, a.agentStore.Get(agentID), true)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	if err := a.agentService.Retry(agentID, opts); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	fmt.Printf("Retried %s\n", agentID)
+}
+
+// restartOptions returns the environment and directory an agent's type in
+// AGENTS.yml starts its sessions with, and its startup prompt if withPrompt is
+// set, as the store keeps none of them. Agents whose type is gone restart
+// without them.
+func restartOptions(workDir string, agent *domain.Agent, withPrompt bool) (domain.CreateOptions, error) {
+	if agent == nil {
+		return domain.CreateOptions{}, nil
+	}
+	agentTypes, err := config.LoadAgents(config.AgentsPath(workDir))
+	if err != nil {
+		return domain.CreateOptions{}, fmt.Errorf("failed to load agents: %w", err)
+	}
+	for _, agentType := range agentTypes {
+		if !strings.EqualFold(agentType.Name, agent.AgentType) {
+			continue
+		}
+		opts := domain.CreateOptions{Env: agentType.Env, Dir: agentType.WorkDir}
+		if withPrompt {
+			opts.Prompt = agentType.Prompt
+			if name, ok := strings.CutPrefix(opts.Prompt, "@"); ok {
+				library, err := config.LoadPrompt(config.PromptsDirPath(workDir), name)
+				if err != nil {
+					return domain.CreateOptions{}, err
+				}
+				opts.Prompt = library.Body
+			}
+		}
+		return opts, nil
+	}
+	return domain.CreateOptions{}, nil
+}
+
+// createManifestAgent creates one manifest agent like the create wizard would,
+// storing metadata with it.
+func createManifestAgent(a *app, workDir string, agentTypes []config.Agent, entry config.ManifestAgent, metadata map[string]string) (*domain.Agent, error) {
+	var agentType *config.Agent
+	for i := range agentTypes {
+		if strings.EqualFold(agentTypes[i].Name, entry.Type) {
+			agentType = &agentTypes[i]
+			break
+		}
+	}
+	if agentType == nil {
+		return nil, fmt.Errorf("unknown agent type %q", entry.Type)
+	}
+
+	prompt := agentType.Prompt
+	if entry.Prompt != "" {
+		prompt = entry.Prompt
+	}
+	if name, ok := strings.CutPrefix(prompt, "@"); ok {
+		library, err := config.LoadPrompt(config.PromptsDirPath(workDir), name)
+		if err != nil {
+			return nil, err
+		}
+		prompt = library.Body
+	}
+
+	opts := domain.CreateOptions{
+		SparsePaths: agentType.SparsePaths,
+		BaseBranch:  entry.Base,
+		Prompt:      prompt,
+		Env:         agentType.Env,
+		Dir:         agentType.WorkDir,
+		Metadata:    metadata,
+	}
+	return a.agentService.CreateWithOptions(agentType.Name, entry.Name, agentType.Command, opts)
+}
+
+// printManifestResults prints a summary table of a batch create and returns
+// how many agents failed. Quiet output lists only the IDs of created agents.
+func printManifestResults(results []manifestResult, quiet bool) int {
+	var failed int
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if !quiet {
+		fmt.Fprintln(w, "NAME\tTYPE\tRESULT\tDETAIL")
+	}
+	for _, r := range results {
+		result, detail := "created", ""
+		switch {
+		case r.err != nil:
+			failed++
+			result, detail = "failed", r.err.Error()
+		case r.agent.Status == domain.AgentStatusPending:
+			result, detail = "queued", "waiting for a provider slot"
+		default:
+			detail = r.agent.ID
+		}
+		if quiet {
+			if r.agent != nil {
+				fmt.Println(r.agent.ID)
+			}
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r.entry.Name, r.entry.Type, result, detail)
+	}
+	if !quiet {
+		w.Flush()
+		fmt.Printf("\n%d created or queued, %d failed\n", len(results)-failed, failed)
+	}
+	return failed
+}
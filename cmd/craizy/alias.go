@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+// runAliasCommand handles the alias subcommand and its subcommands, which name
+// agents by role so messages and prompts needn't hard-code session IDs.
+func runAliasCommand() {
+	if len(os.Args) < 3 {
+		printAliasHelp()
+		return
+	}
+
+	subCmd := os.Args[2]
+	switch subCmd {
+	case "set":
+		runAliasSet()
+	case "list", "ls":
+		runAliasList()
+	case "rm":
+		runAliasRemove()
+	case "help", "--help", "-h":
+		printAliasHelp()
+	default:
+		fmt.Printf("Unknown alias subcommand: %s\n", subCmd)
+		printAliasHelp()
+		os.Exit(exitUsage)
+	}
+}
+
+func printAliasHelp() {
+	fmt.Println("Usage: craizy alias <command> [name] [target]")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  set     Point an alias at an agent or human, replacing any previous target")
+	fmt.Println("  list    List aliases (alias: ls)")
+	fmt.Println("  rm      Remove an alias")
+	fmt.Println()
+	fmt.Println("Messages sent to or from an alias go to its target, and 'msg list' and")
+	fmt.Println("'msg count' accept aliases, so prompts can name roles such as \"lead\"")
+	fmt.Println("and a team is rewired by moving the alias.")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  craizy alias set lead claude-003")
+	fmt.Println("  craizy msg send --from worker-001 --to lead --type completion --content \"Done\"")
+	fmt.Println("  craizy alias rm lead")
+}
+
+func runAliasSet() {
+	if len(os.Args) != 5 {
+		fmt.Println("Usage: craizy alias set <name> <agent-id|human>")
+		os.Exit(exitUsage)
+	}
+
+	svc, cleanup, err := initMsgServices()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer cleanup()
+
+	alias, err := svc.SetAlias(os.Args[3], os.Args[4])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	fmt.Printf("%s → %s\n", alias.Name, alias.Target)
+}
+
+func runAliasList() {
+	svc, cleanup, err := initMsgReader()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer cleanup()
+
+	aliases, err := svc.Aliases()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	if len(aliases) == 0 {
+		fmt.Println("No aliases. Set one with 'craizy alias set <name> <agent-id>'.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ALIAS\tTARGET\tUPDATED")
+	for _, alias := range aliases {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", alias.Name, alias.Target, alias.UpdatedAt.Format("2006-01-02 15:04"))
+	}
+	w.Flush()
+}
+
+func runAliasRemove() {
+	if len(os.Args) != 4 {
+		fmt.Println("Usage: craizy alias rm <name>")
+		os.Exit(exitUsage)
+	}
+
+	svc, cleanup, err := initMsgServices()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer cleanup()
+
+	if err := svc.RemoveAlias(os.Args[3]); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	fmt.Printf("Removed alias %s\n", os.Args[3])
+}
@@ -0,0 +1,334 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/infra"
+	"github.com/TechnicallyShaun/crAIzy/internal/infra/store"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// app bundles the stores and services shared by the TUI and CLI commands.
+type app struct {
+	workDir        string
+	project        string
+	settings       *config.Settings
+	agentStore     domain.IAgentStore
+	mergeStore     domain.IMergeStore
+	backups        domain.IBackupper // nil when ephemeral or backups are disabled
+	closeDB        func() error
+	dispatcher     *infra.EventDispatcher
+	tmux           domain.ITmuxClient
+	git            *infra.GitClient
+	agentService   *domain.AgentService
+	messageService *domain.MessageService
+	agentColors    map[string]string // AGENTS.yml colors by lowercased agent type
+}
+
+// newApp opens the database and wires stores, adapters, and services for workDir.
+// Callers must call Close when done.
+func newApp(workDir string) (*app, error) {
+	return openApp(workDir, false)
+}
+
+// openApp wires stores, adapters, and services for workDir. When ephemeral is
+// set, all state lives in memory: nothing is written to the database or event log.
+func openApp(workDir string, ephemeral bool) (*app, error) {
+	// Load optional project settings
+	settings, err := config.LoadSettings(config.SettingsPath(workDir))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load settings: %w", err)
+	}
+
+	// Initialize infrastructure
+	sessionBackend := newSessionBackend(workDir)
+	tmuxClient := infra.NewCachingTmuxClient(sessionBackend)
+	gitClient := infra.NewGitClient(workDir)
+	gitClient.SetCommitSigning(infra.CommitSigning(settings.Git.CommitSigning))
+	gitCache := infra.NewCachingGitClient(gitClient)
+
+	// Initialize stores
+	var (
+		agentStore   domain.IAgentStore
+		messageStore domain.IMessageStore
+		mergeStore   domain.IMergeStore
+		aliasStore   domain.IAliasStore
+		backups      domain.IBackupper
+		closeDB      func() error
+	)
+	if ephemeral {
+		agentStore = infra.NewMemoryAgentStore()
+		messageStore = infra.NewMemoryMessageStore()
+		mergeStore = infra.NewMemoryMergeStore()
+		aliasStore = infra.NewMemoryAliasStore()
+	} else {
+		dbPath, err := defaultDBPath()
+		if err != nil {
+			return nil, err
+		}
+		sqliteStore, err := openAgentStore(dbPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize database: %w", err)
+		}
+		if !settings.Backup.Disabled {
+			backups = store.NewBackupper(sqliteStore.DB(), store.BackupsDir(dbPath),
+				time.Duration(settings.Backup.IntervalHours)*time.Hour, settings.Backup.Keep)
+			if _, err := backups.BackupIfDue(time.Now()); err != nil {
+				logging.Error(err, "action", "periodic backup")
+			}
+		}
+		agentStore = sqliteStore
+		messageStore = store.NewSQLiteMessageStore(sqliteStore.DB())
+		mergeStore = store.NewSQLiteMergeStore(sqliteStore.DB())
+		aliasStore = store.NewSQLiteAliasStore(sqliteStore.DB())
+		closeDB = sqliteStore.Close
+	}
+
+	// Initialize event dispatcher and wire adapters
+	dispatcher := infra.NewEventDispatcher()
+	infra.WireAdapters(dispatcher, agentStore, tmuxClient, gitCache)
+	infra.WireProbeCache(dispatcher, tmuxClient, gitCache)
+	colors := agentColors(workDir)
+	infra.WireAgentColors(dispatcher, sessionBackend, colors)
+	if !ephemeral {
+		infra.WireEventLog(dispatcher, infra.NewEventLog(config.EventLogPath(workDir)))
+		infra.WireTranscriptAdapters(dispatcher, tmuxClient, workDir)
+	}
+	infra.WireMergeAdapters(dispatcher, mergeStore)
+
+	// Initialize message service
+	messageService := domain.NewMessageService(messageStore, tmuxClient, agentStore)
+	messageService.SetAliasStore(aliasStore)
+	messageService.SetHumans(settings.Messages.Humans)
+	messageService.SetNotifyHuman(settings.Messages.NotifyHuman)
+	messageService.SetEscalation(time.Duration(settings.Messages.EscalateMinutes) * time.Minute)
+	setDeliveryTransports(messageService, tmuxClient, workDir)
+
+	// Initialize agent service
+	project := filepath.Base(workDir)
+	agentService := domain.NewAgentService(tmuxClient, agentStore, dispatcher, gitCache, project, workDir)
+	agentService.SetEphemeral(ephemeral)
+	agentService.SetMessageService(messageService)
+	agentService.SetMergeStore(mergeStore)
+	agentService.SetGitHubClient(infra.NewGitHubClient(workDir))
+	agentService.SetProtectedBranches(settings.Git.ProtectedBranches)
+	agentService.SetMergeStrategy(domain.MergeStrategy(settings.Merge.Strategy))
+	agentService.SetWorktreeOptions(domain.WorktreeOptions{
+		Submodules: settings.Git.Submodules,
+		LFS:        settings.Git.LFS,
+		Caches:     worktreeCaches(settings),
+		Template:   settings.Git.TemplateWorktree,
+	})
+	agentService.SetWarmPool(warmPoolSpecs(workDir, settings))
+	agentService.SetAttentionIdle(time.Duration(settings.AttentionIdleMinutes) * time.Minute)
+	agentService.SetProviderPools(providerPools(settings))
+	agentService.SetRestartPolicies(restartPolicies(workDir))
+	agentService.SetOutputCapture(outputCaptureTypes(workDir))
+	agentService.SetBudget(domain.Budget{
+		Agent:   time.Duration(settings.Budget.AgentMinutes) * time.Minute,
+		Project: time.Duration(settings.Budget.ProjectMinutes) * time.Minute,
+	})
+	agentService.SetDevEnvironment(infra.NewDevEnvironment(settings.Environment.Direnv, settings.Environment.Devcontainer))
+	agentService.SetDiskThreshold(int64(settings.Disk.WarnGB * (1 << 30)))
+	// Surface tmux and git timeouts as a degraded banner in the TUI
+	checks := []domain.IHealthCheck{gitClient}
+	if check, ok := sessionBackend.(domain.IHealthCheck); ok {
+		checks = append(checks, check)
+	}
+	agentService.SetHealthChecks(checks...)
+	if settings.Summarizer.Command != "" {
+		agentService.SetSummarizer(infra.NewCommandSummarizer(settings.Summarizer.Command, workDir))
+	}
+
+	return &app{
+		workDir:        workDir,
+		project:        project,
+		settings:       settings,
+		agentStore:     agentStore,
+		mergeStore:     mergeStore,
+		backups:        backups,
+		closeDB:        closeDB,
+		dispatcher:     dispatcher,
+		tmux:           tmuxClient,
+		git:            gitClient,
+		agentService:   agentService,
+		messageService: messageService,
+		agentColors:    colors,
+	}, nil
+}
+
+// sessionBackendEnv selects the session backend: "tmux" (default) or "fake".
+// The fake backend runs sessions in-process, for development and tests without tmux.
+const sessionBackendEnv = "CRAIZY_SESSION_BACKEND"
+
+// newSessionBackend returns the session backend selected by sessionBackendEnv,
+// recording agent exit statuses in workDir's .craizy directory, if given.
+func newSessionBackend(workDir string) domain.ITmuxClient {
+	if os.Getenv(sessionBackendEnv) == "fake" {
+		logging.Info("using fake session backend, %s=fake", sessionBackendEnv)
+		return infra.NewFakeTmuxClient()
+	}
+	tmux := infra.NewTmuxClient()
+	if workDir != "" {
+		tmux.SetExitDir(config.ExitDirPath(workDir))
+		tmux.SetDashboardSession(domain.DashboardSessionID(filepath.Base(workDir)))
+	}
+	return tmux
+}
+
+// warmPoolSpecs resolves the configured warm pool sizes against the commands in AGENTS.yml.
+// Pool entries naming unknown agents are skipped.
+func warmPoolSpecs(workDir string, settings *config.Settings) []domain.PoolSpec {
+	if len(settings.WarmPool) == 0 {
+		return nil
+	}
+	agents, err := config.LoadAgents(config.AgentsPath(workDir))
+	if err != nil {
+		logging.Error(err, "action", "load agents for warm pool")
+		return nil
+	}
+
+	var specs []domain.PoolSpec
+	for _, agent := range agents {
+		for name, size := range settings.WarmPool {
+			if strings.EqualFold(name, agent.Name) && size > 0 {
+				specs = append(specs, domain.PoolSpec{
+					AgentType: agent.Name,
+					Command:   agent.Command,
+					Size:      size,
+					Env:       agent.Env,
+					Dir:       agent.WorkDir,
+				})
+			}
+		}
+	}
+	return specs
+}
+
+// agentColors returns the colors AGENTS.yml assigns agent types. A missing or
+// invalid file leaves agents uncolored.
+func agentColors(workDir string) map[string]string {
+	agents, err := config.LoadAgents(config.AgentsPath(workDir))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logging.Error(err, "action", "load agents for colors")
+		}
+		return nil
+	}
+	return config.AgentColors(agents)
+}
+
+// restartPolicies returns the restart policies AGENTS.yml sets agent types, with
+// the environment and directory their sessions start with. A missing or
+// invalid file leaves crashed agents exited or terminated.
+func restartPolicies(workDir string) map[string]domain.RestartPolicy {
+	agents, err := config.LoadAgents(config.AgentsPath(workDir))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logging.Error(err, "action", "load agents for restart policies")
+		}
+		return nil
+	}
+	policies := make(map[string]domain.RestartPolicy)
+	for _, agent := range agents {
+		if limit := agent.RestartLimit(); limit > 0 {
+			policies[agent.Name] = domain.RestartPolicy{
+				MaxRestarts: limit,
+				Options:     domain.CreateOptions{Env: agent.Env, Dir: agent.WorkDir},
+			}
+		}
+	}
+	return policies
+}
+
+// outputCaptureTypes returns the agent types whose printed craizy: blocks are
+// sent as messages. A missing or invalid file captures none.
+func outputCaptureTypes(workDir string) []string {
+	agents, err := config.LoadAgents(config.AgentsPath(workDir))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logging.Error(err, "action", "load agents for output capture")
+		}
+		return nil
+	}
+	var types []string
+	for _, agent := range agents {
+		if agent.CaptureMessages {
+			types = append(types, agent.Name)
+		}
+	}
+	return types
+}
+
+// setDeliveryTransports sets the message transports and delivery modes
+// AGENTS.yml chooses for agent types. File transports type a one-line notice
+// into the agent's session: after each message, or as the nudge itself in
+// nudge mode. Types without one, or a missing or invalid file, keep typed
+// delivery.
+func setDeliveryTransports(messageSvc *domain.MessageService, tmux domain.ITmuxClient, workDir string) {
+	agents, err := config.LoadAgents(config.AgentsPath(workDir))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logging.Error(err, "action", "load agents for delivery")
+		}
+		return
+	}
+	for _, agent := range agents {
+		if agent.Delivery.Mode != "" {
+			messageSvc.SetDeliveryMode(agent.Name, domain.DeliveryMode(agent.Delivery.Mode))
+		}
+		switch agent.Delivery.Transport {
+		case config.DeliveryFile:
+			transport := infra.NewFileTransport(agent.Delivery.InboxPath())
+			transport.SetNudge(tmux)
+			messageSvc.SetTransport(agent.Name, transport)
+		case config.DeliveryWebhook:
+			messageSvc.SetTransport(agent.Name, infra.NewWebhookTransport(agent.Delivery.URL))
+		}
+	}
+}
+
+// providerPools converts provider settings into scheduling pools, sorted by name.
+func providerPools(settings *config.Settings) []domain.ProviderPool {
+	var pools []domain.ProviderPool
+	for name, provider := range settings.Providers {
+		pools = append(pools, domain.ProviderPool{
+			Name:          name,
+			AgentTypes:    provider.Agents,
+			MaxConcurrent: provider.MaxConcurrent,
+			Stagger:       time.Duration(provider.StaggerSeconds) * time.Second,
+		})
+	}
+	sort.Slice(pools, func(i, j int) bool { return pools[i].Name < pools[j].Name })
+	return pools
+}
+
+// Close releases the database connection, if any.
+func (a *app) Close() {
+	if a.closeDB != nil {
+		_ = a.closeDB()
+	}
+}
+
+// worktreeCaches converts cache settings into the caches shared with new worktrees.
+func worktreeCaches(settings *config.Settings) []domain.IWorktreeCache {
+	var caches []domain.IWorktreeCache
+	for _, c := range settings.Caches {
+		switch c.Strategy {
+		case config.CacheStrategySymlink:
+			caches = append(caches, infra.NewSymlinkCache(c.Path))
+		case config.CacheStrategyHardlink:
+			caches = append(caches, infra.NewHardlinkCache(c.Path))
+		case config.CacheStrategyCommand:
+			caches = append(caches, infra.NewCommandCache(c.Command))
+		}
+	}
+	return caches
+}
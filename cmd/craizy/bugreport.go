@@ -0,0 +1,170 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/infra"
+	"github.com/TechnicallyShaun/crAIzy/internal/infra/store"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+	"github.com/TechnicallyShaun/crAIzy/internal/version"
+)
+
+const (
+	// bugreportLogFiles is how many of the most recent daily logs to include.
+	bugreportLogFiles = 3
+
+	// bugreportLogLines caps the lines taken from each log file.
+	bugreportLogLines = 2000
+
+	// bugreportEvents is how many of the most recent events to include.
+	bugreportEvents = 100
+)
+
+// runBugreportCommand handles the bugreport subcommand, writing a zip of recent
+// logs, config, schema, environment info, and events with secrets redacted.
+func runBugreportCommand() {
+	fs := flag.NewFlagSet("bugreport", flag.ExitOnError)
+	output := fs.String("o", "", "Output path (default craizy-bugreport-<time>.zip in the current directory)")
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		os.Exit(exitUsage)
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Failed to get working directory: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	if !isInitialized(workDir) {
+		fmt.Println("This directory is not initialized. Run 'craizy init' first.")
+		os.Exit(exitNotInitialized)
+	}
+
+	if err := logging.Init(config.CraizyDirPath(workDir)); err == nil {
+		defer logging.Close()
+	}
+
+	path := *output
+	if path == "" {
+		path = filepath.Join(workDir, "craizy-bugreport-"+time.Now().Format("20060102-150405")+".zip")
+	}
+
+	if err := writeBugreport(workDir, path); err != nil {
+		logging.Error(err, "path", path)
+		fmt.Printf("Error: %v\n", err)
+		os.Remove(path)
+		os.Exit(exitError)
+	}
+
+	fmt.Printf("Wrote %s\n", path)
+	fmt.Println("Secrets are redacted, but please review the contents before attaching it to an issue.")
+}
+
+// writeBugreport writes the bug report zip to path.
+func writeBugreport(workDir, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	report := infra.NewBugReport(f)
+
+	if err := report.AddText("env.txt", bugreportEnv()); err != nil {
+		return err
+	}
+	if err := report.AddText("schema.txt", bugreportSchema()); err != nil {
+		return err
+	}
+	if err := report.AddFile("settings.yml", config.SettingsPath(workDir), 0); err != nil {
+		return err
+	}
+	if err := report.AddFile("AGENTS.yml", config.AgentsPath(workDir), 0); err != nil {
+		return err
+	}
+	if err := report.AddFile("events.ndjson", config.EventLogPath(workDir), bugreportEvents); err != nil {
+		return err
+	}
+	for _, logPath := range recentLogs(config.CraizyDirPath(workDir), bugreportLogFiles) {
+		if err := report.AddFile("logs/"+filepath.Base(logPath), logPath, bugreportLogLines); err != nil {
+			return err
+		}
+	}
+
+	if err := report.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// bugreportEnv describes the craizy build, platform, tools, and terminal.
+func bugreportEnv() string {
+	info := version.Get()
+	var b strings.Builder
+	fmt.Fprintf(&b, "craizy: %s\n", info.Version)
+	fmt.Fprintf(&b, "commit: %s\n", info.Commit)
+	fmt.Fprintf(&b, "built: %s\n", info.Date)
+	fmt.Fprintf(&b, "go: %s\n", info.GoVersion)
+	fmt.Fprintf(&b, "platform: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "tmux: %s\n", toolVersion("tmux", "-V"))
+	fmt.Fprintf(&b, "git: %s\n", toolVersion("git", "--version"))
+	for _, name := range []string{"TERM", "COLORTERM", "SHELL", "NO_COLOR"} {
+		fmt.Fprintf(&b, "%s: %s\n", name, os.Getenv(name))
+	}
+	_, inTmux := os.LookupEnv("TMUX")
+	fmt.Fprintf(&b, "inside tmux: %t\n", inTmux)
+
+	var craizyVars []string
+	for _, kv := range os.Environ() {
+		if strings.HasPrefix(kv, "CRAIZY_") {
+			craizyVars = append(craizyVars, kv)
+		}
+	}
+	sort.Strings(craizyVars)
+	for _, kv := range craizyVars {
+		fmt.Fprintln(&b, kv)
+	}
+	return b.String()
+}
+
+// bugreportSchema describes the database schema, or the error that prevented it.
+func bugreportSchema() string {
+	dbPath, err := defaultDBPath()
+	if err != nil {
+		return fmt.Sprintf("error: %v\n", err)
+	}
+	agentStore, err := store.NewSQLiteAgentStore(dbPath)
+	if err != nil {
+		return fmt.Sprintf("error: failed to open database: %v\n", err)
+	}
+	defer agentStore.Close()
+
+	schema, err := store.DescribeSchema(agentStore.DB())
+	if err != nil {
+		return fmt.Sprintf("error: %v\n", err)
+	}
+	return schema
+}
+
+// recentLogs returns the newest n daily log files in dir.
+func recentLogs(dir string, n int) []string {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.log"))
+	if err != nil {
+		return nil
+	}
+	// Daily logs are named YYYY-MM-DD.log, so name order is date order
+	sort.Sort(sort.Reverse(sort.StringSlice(paths)))
+	if len(paths) > n {
+		paths = paths[:n]
+	}
+	return paths
+}
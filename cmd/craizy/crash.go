@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+	"github.com/TechnicallyShaun/crAIzy/internal/tui"
+)
+
+// errPanicked is returned by runProgram when the TUI panicked and was recovered.
+var errPanicked = errors.New("program panicked")
+
+// runProgram runs the TUI in the alternate screen with panic recovery, so
+// resizes redraw it without leaving old frames in the scrollback. On a panic
+// the terminal is restored, the stack trace is logged, and a short message
+// points at the log.
+func runProgram(m tea.Model) (err error) {
+	p := tea.NewProgram(tui.NewCrashSafe(m), tea.WithoutCatchPanics(), tea.WithFPS(tui.MaxFPS), tea.WithAltScreen())
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		_ = p.ReleaseTerminal()
+
+		var panicErr *tui.PanicError
+		if e, ok := r.(*tui.PanicError); ok {
+			panicErr = e
+		} else {
+			panicErr = &tui.PanicError{Value: r, Stack: debug.Stack()}
+		}
+		logging.Error(panicErr, "stack", string(panicErr.Stack))
+
+		fmt.Printf("crAIzy crashed unexpectedly: %v\n", panicErr.Value)
+		if path := logging.Path(); path != "" {
+			fmt.Printf("The stack trace was written to %s\n", path)
+		}
+		err = errPanicked
+	}()
+
+	_, err = p.Run()
+	return err
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/infra"
+)
+
+// runDashCommand handles the dash subcommand, starting the TUI. With --tmux the
+// TUI runs in its own tmux session and attaches to agents by switching the
+// client, so it keeps running and refreshing previews while the human works
+// in an agent's session.
+func runDashCommand() {
+	fs := flag.NewFlagSet("dash", flag.ExitOnError)
+	inTmux := fs.Bool("tmux", false, "Run the TUI in the project's craizy-dashboard-<project> tmux session and switch to agents instead of suspending it")
+	flags := addTUIFlags(fs)
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		os.Exit(exitUsage)
+	}
+	if fs.NArg() > 0 {
+		fmt.Println("Usage: craizy dash [--tmux] [--ephemeral] [--read-only] [--accessible] [--no-color]")
+		os.Exit(exitUsage)
+	}
+
+	opts := flags.options()
+	if !*inTmux {
+		runTUI(opts)
+		return
+	}
+
+	tmux := infra.NewTmuxClient()
+	if tmux.InDashboardSession() {
+		opts.SwitchClient = true
+		runTUI(opts)
+		return
+	}
+	if exitCode := openDashboardSession(tmux); exitCode != 0 {
+		os.Exit(exitCode)
+	}
+}
+
+// openDashboardSession starts the dashboard session running this command, if
+// it isn't already running, and takes the terminal to it.
+func openDashboardSession(tmux *infra.TmuxClient) int {
+	if os.Getenv(sessionBackendEnv) == "fake" {
+		fmt.Printf("Error: --tmux needs the tmux session backend, but %s=fake\n", sessionBackendEnv)
+		return exitUsage
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Failed to get working directory: %v\n", err)
+		return exitError
+	}
+	if !isInitialized(workDir) {
+		fmt.Println("This directory is not initialized. Run 'craizy init' first.")
+		return exitNotInitialized
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		fmt.Printf("Error: failed to locate craizy binary: %v\n", err)
+		return exitError
+	}
+	id := domain.DashboardSessionID(filepath.Base(workDir))
+	tmux.SetDashboardSession(id)
+	if err := tmux.StartDashboard(id, exe, os.Args[1:], workDir); err != nil {
+		fmt.Printf("Error: failed to start dashboard session: %v\n", err)
+		return exitError
+	}
+
+	cmd := tmux.DashboardAttachCmd(id)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		fmt.Printf("Error: failed to attach to %s: %v\n", id, err)
+		return exitError
+	}
+	return exitOK
+}
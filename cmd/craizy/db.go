@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/infra/store"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// openAgentStore opens the database at dbPath like store.NewSQLiteAgentStore.
+// If it's corrupt, it offers to restore the newest backup and reopen it rather
+// than leaving every command failing; without a terminal to ask on, the error
+// names the backup to restore.
+func openAgentStore(dbPath string) (*store.SQLiteAgentStore, error) {
+	agentStore, err := store.NewSQLiteAgentStore(dbPath)
+	if !errors.Is(err, store.ErrCorrupt) {
+		return agentStore, err
+	}
+
+	backup, listErr := store.LatestBackup(store.BackupsDir(dbPath))
+	if listErr != nil || backup == "" {
+		return nil, fmt.Errorf("%w, and there is no backup to restore", err)
+	}
+	taken := filepath.Base(backup)
+	if at, timeErr := store.BackupTime(backup); timeErr == nil {
+		taken = at.Format("2006-01-02 15:04")
+	}
+
+	if !isInteractive() {
+		return nil, fmt.Errorf("%w; restore a backup with 'craizy db restore %s'", err, filepath.Base(backup))
+	}
+
+	fmt.Printf("Error opening %s: %v\n", dbPath, err)
+	fmt.Printf("Restore the backup from %s? Changes since then are lost. [y/N] ", taken)
+	reader := bufio.NewReader(os.Stdin)
+	response, _ := reader.ReadString('\n')
+	response = strings.TrimSpace(strings.ToLower(response))
+	if response != "y" && response != "yes" {
+		return nil, fmt.Errorf("%w; backups are in %s", err, store.BackupsDir(dbPath))
+	}
+
+	if err := store.Restore(backup, dbPath); err != nil {
+		return nil, err
+	}
+	logging.Info("restored corrupt database from backup, backup=%s", backup)
+	fmt.Printf("Restored %s (the corrupt database was kept as %s.replaced)\n", filepath.Base(backup), filepath.Base(dbPath))
+	return store.NewSQLiteAgentStore(dbPath)
+}
+
+// runDBCommand handles the db subcommand and its subcommands, which manage
+// backups of the shared database.
+func runDBCommand() {
+	if len(os.Args) < 3 {
+		printDBHelp()
+		return
+	}
+
+	subCmd := os.Args[2]
+	switch subCmd {
+	case "backup":
+		runDBBackup()
+	case "list", "ls":
+		runDBList()
+	case "restore":
+		runDBRestore()
+	case "help", "--help", "-h":
+		printDBHelp()
+	default:
+		fmt.Printf("Unknown db subcommand: %s\n", subCmd)
+		printDBHelp()
+		os.Exit(exitUsage)
+	}
+}
+
+func printDBHelp() {
+	fmt.Println("Usage: craizy db <command> [options]")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  backup   Back up the database now")
+	fmt.Println("  list     List backups, newest first (alias: ls)")
+	fmt.Println("  restore  Replace the database with a backup (--yes skips the prompt)")
+	fmt.Println()
+	fmt.Println("Backups are kept in ~/.craizy/backups. While crAIzy runs it takes one")
+	fmt.Println("every backup.interval_hours (default 24) and keeps the newest backup.keep")
+	fmt.Println("(default 7), as set in .craizy/settings.yml. Stop the TUI before restoring.")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  craizy db list")
+	fmt.Println("  craizy db restore craizy-20260501-090000.db")
+}
+
+func runDBBackup() {
+	dbPath, err := defaultDBPath()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	agentStore, err := openAgentStore(dbPath)
+	if err != nil {
+		fmt.Printf("Error: failed to initialize database: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer agentStore.Close()
+
+	path, err := store.Backup(agentStore.DB(), store.BackupsDir(dbPath), time.Now())
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	fmt.Printf("Backed up to %s\n", path)
+}
+
+func runDBList() {
+	dbPath, err := dbFilePath()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	backups, err := store.ListBackups(store.BackupsDir(dbPath))
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	if len(backups) == 0 {
+		fmt.Println("No backups yet. Take one with 'craizy db backup'.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "BACKUP\tTAKEN\tSIZE")
+	for _, path := range backups {
+		taken := "-"
+		if at, err := store.BackupTime(path); err == nil {
+			taken = at.Format("2006-01-02 15:04")
+		}
+		size := "-"
+		if info, err := os.Stat(path); err == nil {
+			size = fmt.Sprintf("%.1f MB", float64(info.Size())/(1<<20))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", filepath.Base(path), taken, size)
+	}
+	w.Flush()
+}
+
+func runDBRestore() {
+	backup, args := splitAgentArg(os.Args[3:])
+	fs := flag.NewFlagSet("db restore", flag.ExitOnError)
+	yes := fs.Bool("yes", false, "Restore without asking for confirmation")
+	if err := fs.Parse(args); err != nil {
+		os.Exit(exitUsage)
+	}
+	if backup == "" || fs.NArg() != 0 {
+		fmt.Println("Usage: craizy db restore <file> [--yes]")
+		os.Exit(exitUsage)
+	}
+
+	dbPath, err := dbFilePath()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	// A bare name refers to a backup in the backups directory
+	if filepath.Base(backup) == backup {
+		if _, err := os.Stat(backup); err != nil {
+			backup = filepath.Join(store.BackupsDir(dbPath), backup)
+		}
+	}
+
+	if !*yes {
+		fmt.Printf("Replace %s with %s? Changes since the backup are lost. [y/N] ", dbPath, filepath.Base(backup))
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			return
+		}
+	}
+
+	if err := store.Restore(backup, dbPath); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	fmt.Printf("Restored %s (the previous database was kept as %s.replaced)\n", filepath.Base(backup), filepath.Base(dbPath))
+}
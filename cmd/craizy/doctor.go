@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// runDoctorCommand handles the doctor subcommand, which checks the environment
+// and cross-checks stored agents against the actual worktrees.
+func runDoctorCommand() {
+	exitCode := runDoctorCommandInner()
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+}
+
+func runDoctorCommandInner() int {
+	fs := flag.NewFlagSet("doctor", flag.ExitOnError)
+	fix := fs.Bool("fix", false, "Repair problems without prompting")
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return exitUsage
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Failed to get working directory: %v\n", err)
+		return exitError
+	}
+
+	if err := logging.Init(config.CraizyDirPath(workDir)); err == nil {
+		defer logging.Close()
+	}
+
+	healthy := true
+	healthy = checkBinary("tmux", "-V") && healthy
+	healthy = checkBinary("git", "--version") && healthy
+
+	fmt.Print("Checking initialization... ")
+	if !isInitialized(workDir) {
+		fmt.Println("not initialized (run 'craizy init')")
+		return exitNotInitialized
+	}
+	fmt.Println("ok")
+
+	fmt.Print("Checking database... ")
+	a, err := newApp(workDir)
+	if err != nil {
+		fmt.Printf("failed: %v\n", err)
+		return exitError
+	}
+	defer a.Close()
+	fmt.Println("ok")
+
+	healthy = checkWorktrees(a, *fix) && healthy
+	healthy = checkDiskUsage(a, *fix) && healthy
+
+	fmt.Println()
+	if !healthy {
+		fmt.Println("Problems found.")
+		return exitError
+	}
+	fmt.Println("All checks passed.")
+	return exitOK
+}
+
+// checkBinary verifies an external tool is installed and prints its version.
+func checkBinary(name string, versionArg string) bool {
+	fmt.Printf("Checking %s... ", name)
+	v := toolVersion(name, versionArg)
+	fmt.Println(v)
+	return v != "not found"
+}
+
+// checkWorktrees reports active agents whose worktree has disappeared and offers to recreate them.
+func checkWorktrees(a *app, fix bool) bool {
+	fmt.Print("Checking agent worktrees... ")
+	missing, err := a.agentService.MissingWorktrees()
+	if err != nil {
+		fmt.Printf("failed: %v\n", err)
+		return false
+	}
+	if len(missing) == 0 {
+		fmt.Println("ok")
+		return true
+	}
+
+	fmt.Printf("%d missing\n", len(missing))
+	for _, agent := range missing {
+		fmt.Printf("  - %s (%s): %s\n", agent.Name, agent.ID, agent.WorkDir)
+	}
+
+	if readOnlyRequested() {
+		fmt.Printf("  Not recreating worktrees in read-only mode (%s is set)\n", readOnlyEnv)
+		return false
+	}
+
+	if !fix {
+		fmt.Print("Recreate missing worktrees? [y/N] ")
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			return false
+		}
+	}
+
+	ok := true
+	for _, agent := range missing {
+		if err := a.agentService.RecreateWorktree(agent.ID); err != nil {
+			fmt.Printf("  Failed to recreate %s: %v\n", agent.Name, err)
+			ok = false
+			continue
+		}
+		fmt.Printf("  Recreated %s\n", agent.WorkDir)
+	}
+	return ok
+}
+
+// checkDiskUsage reports how much space each agent worktree uses and, over the
+// configured threshold, offers to remove stopped agents' worktrees.
+func checkDiskUsage(a *app, fix bool) bool {
+	fmt.Print("Checking worktree disk usage... ")
+	usage, err := a.agentService.DiskUsage()
+	if err != nil {
+		fmt.Printf("failed: %v\n", err)
+		return false
+	}
+	fmt.Println(domain.FormatBytes(usage.Total))
+	for _, wt := range usage.Worktrees {
+		owner := "no agent"
+		if wt.Agent != nil {
+			owner = fmt.Sprintf("%s, %s", wt.Agent.Name, wt.Agent.Status)
+		}
+		fmt.Printf("  - %s  %s (%s)\n", domain.FormatBytes(wt.Bytes), filepath.Base(wt.Path), owner)
+	}
+
+	if !usage.OverThreshold() {
+		return true
+	}
+	reclaimable := usage.Reclaimable()
+	fmt.Printf("  Over the %s warning threshold; %s is from stopped agents\n",
+		domain.FormatBytes(usage.Threshold), domain.FormatBytes(reclaimable))
+	if reclaimable == 0 {
+		return false
+	}
+	if readOnlyRequested() {
+		fmt.Printf("  Not cleaning up in read-only mode (%s is set)\n", readOnlyEnv)
+		return false
+	}
+
+	if !fix {
+		fmt.Print("Remove stopped agents' worktrees? [y/N] ")
+		reader := bufio.NewReader(os.Stdin)
+		response, _ := reader.ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			return false
+		}
+	}
+
+	freed, err := a.agentService.CleanupWorktrees()
+	fmt.Printf("  Freed %s\n", domain.FormatBytes(freed))
+	if err != nil {
+		fmt.Printf("  %v\n", err)
+		return false
+	}
+	return usage.Total-freed <= usage.Threshold
+}
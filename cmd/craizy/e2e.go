@@ -0,0 +1,323 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// e2eAgentCommand is the fake agent the e2e scenario runs: it commits a file
+// named after the agent to its worktree, then idles like an agent awaiting input.
+const e2eAgentCommand = `printf '%s\n' "$CRAIZY_E2E_AGENT" > "$CRAIZY_E2E_AGENT.txt" && git add . && git commit -qm "Add $CRAIZY_E2E_AGENT.txt" && echo committed; sleep 600`
+
+// e2eScenario is a run of the e2e scenario: the sandboxed project and the
+// agents its steps create.
+type e2eScenario struct {
+	timeout time.Duration
+	workDir string
+	app     *app
+	alpha   *domain.Agent
+	beta    *domain.Agent
+}
+
+// e2eStep is one step of the scenario, failing the run by returning an error.
+type e2eStep struct {
+	name string
+	run  func(*e2eScenario) error
+}
+
+// runE2ECommand handles the hidden e2e subcommand, a smoke test for
+// maintainers that drives a scripted scenario against real tmux and git in a
+// sandbox and checks the state it leaves.
+func runE2ECommand() {
+	exitCode := runE2ECommandInner()
+	if exitCode != 0 {
+		os.Exit(exitCode)
+	}
+}
+
+func runE2ECommandInner() int {
+	fs := flag.NewFlagSet("e2e", flag.ExitOnError)
+	keep := fs.Bool("keep", false, "Keep the sandbox directory for inspection")
+	timeout := fs.Duration("timeout", 15*time.Second, "How long to wait for each agent to commit")
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		return exitUsage
+	}
+
+	for _, tool := range []string{"tmux", "git"} {
+		if _, err := exec.LookPath(tool); err != nil {
+			fmt.Printf("Error: %s not found\n", tool)
+			return exitError
+		}
+	}
+
+	sandbox, err := newE2ESandbox()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+	defer func() {
+		// The sandbox's tmux server takes any sessions left behind with it
+		_ = exec.Command("tmux", "kill-server").Run()
+		if *keep {
+			fmt.Printf("Sandbox kept in %s\n", sandbox)
+			return
+		}
+		_ = os.RemoveAll(sandbox)
+	}()
+
+	s := &e2eScenario{timeout: *timeout, workDir: filepath.Join(sandbox, "e2e")}
+	defer func() {
+		if s.app != nil {
+			s.app.Close()
+		}
+	}()
+
+	for i, step := range e2eSteps {
+		fmt.Printf("[%d/%d] %s... ", i+1, len(e2eSteps), step.name)
+		if err := step.run(s); err != nil {
+			fmt.Printf("FAIL\n      %v\n", err)
+			return exitError
+		}
+		fmt.Println("ok")
+	}
+	fmt.Println()
+	fmt.Println("E2E scenario passed.")
+	return exitOK
+}
+
+// newE2ESandbox creates a temporary directory and points HOME, the tmux socket
+// directory and git's identity into it, so the scenario neither touches nor
+// depends on the user's database, tmux server or git config. It returns the
+// directory.
+func newE2ESandbox() (string, error) {
+	sandbox, err := os.MkdirTemp("", "craizy-e2e-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create sandbox: %w", err)
+	}
+	dirs := map[string]string{"HOME": "home", "TMUX_TMPDIR": "tmux"}
+	for env, dir := range dirs {
+		path := filepath.Join(sandbox, dir)
+		if err := os.Mkdir(path, 0o700); err != nil {
+			return "", fmt.Errorf("failed to create sandbox: %w", err)
+		}
+		_ = os.Setenv(env, path)
+	}
+	_ = os.Unsetenv("TMUX")
+	_ = os.Unsetenv(readOnlyEnv)
+	for _, env := range []string{"GIT_AUTHOR", "GIT_COMMITTER"} {
+		_ = os.Setenv(env+"_NAME", "crAIzy e2e")
+		_ = os.Setenv(env+"_EMAIL", "e2e@craizy.invalid")
+	}
+	return sandbox, nil
+}
+
+// e2eSteps is the scenario, run in order against one sandboxed project.
+var e2eSteps = []e2eStep{
+	{"initialize a project", func(s *e2eScenario) error {
+		// Create the repository first so init doesn't ask to
+		if err := e2eGit("init", "-q", s.workDir); err != nil {
+			return err
+		}
+		if err := runQuietly(func() error { return runInit(s.workDir) }); err != nil {
+			return err
+		}
+		if err := logging.Init(config.CraizyDirPath(s.workDir)); err != nil {
+			return err
+		}
+		a, err := newApp(s.workDir)
+		s.app = a
+		return err
+	}},
+	{"create two agents", func(s *e2eScenario) error {
+		var err error
+		if s.alpha, err = createE2EAgent(s, "alpha"); err != nil {
+			return err
+		}
+		s.beta, err = createE2EAgent(s, "beta")
+		return err
+	}},
+	{"wait for both agents to commit", func(s *e2eScenario) error {
+		for _, agent := range []*domain.Agent{s.alpha, s.beta} {
+			if err := waitForCommit(s, agent); err != nil {
+				return err
+			}
+		}
+		return nil
+	}},
+	{"exchange messages", func(s *e2eScenario) error {
+		messages := s.app.messageService
+		question, err := messages.Send(s.alpha.ID, s.beta.ID, domain.MessageTypeQuestion, "Which file did you add?", nil)
+		if err != nil {
+			return err
+		}
+		if err := expectDelivered(s, question); err != nil {
+			return err
+		}
+		answer, err := messages.Send(s.beta.ID, s.alpha.ID, domain.MessageTypeAnswer, "beta.txt", nil)
+		if err != nil {
+			return err
+		}
+		if err := expectDelivered(s, answer); err != nil {
+			return err
+		}
+		// The human has no session, so messages to them wait unread
+		report, err := messages.Send(s.alpha.ID, domain.HumanParticipantID, domain.MessageTypeInfo, "alpha.txt is ready to merge", nil)
+		if err != nil {
+			return err
+		}
+		return expectUnread(s, domain.HumanParticipantID, report.ID)
+	}},
+	{"merge alpha", func(s *e2eScenario) error {
+		result, err := s.app.agentService.MergeAgent(s.alpha.ID)
+		if err != nil {
+			return err
+		}
+		if !result.Success {
+			return fmt.Errorf("merge failed: %v", result.ConflictErr)
+		}
+		if _, err := os.Stat(filepath.Join(s.workDir, "alpha.txt")); err != nil {
+			return fmt.Errorf("merged file missing: %w", err)
+		}
+		history, err := s.app.agentService.MergeHistory(s.alpha.ID)
+		if err != nil {
+			return err
+		}
+		if len(history) != 1 || history[0].Outcome != domain.MergeOutcomeSuccess {
+			return fmt.Errorf("merge history = %d records, want one successful merge", len(history))
+		}
+		return nil
+	}},
+	{"restart beta", func(s *e2eScenario) error {
+		if err := s.app.agentService.RestartWithOptions(s.beta.ID, domain.CreateOptions{
+			Env: map[string]string{"CRAIZY_E2E_AGENT": s.beta.Name},
+		}); err != nil {
+			return err
+		}
+		if !s.app.tmux.SessionExists(s.beta.ID) {
+			return fmt.Errorf("session %s is gone after restarting", s.beta.ID)
+		}
+		commits, err := s.app.agentService.Commits(s.beta.ID)
+		if err != nil {
+			return err
+		}
+		if len(commits) == 0 {
+			return fmt.Errorf("%s lost its commits on restart", s.beta.Name)
+		}
+		return nil
+	}},
+	{"kill beta", func(s *e2eScenario) error {
+		return s.app.agentService.Kill(s.beta.ID)
+	}},
+	{"check final state", func(s *e2eScenario) error {
+		var active []string
+		for _, agent := range s.app.agentService.List() {
+			active = append(active, agent.ID)
+		}
+		if !slices.Equal(active, []string{s.alpha.ID}) {
+			return fmt.Errorf("active agents = %v, want only %s", active, s.alpha.ID)
+		}
+		if !s.app.tmux.SessionExists(s.alpha.ID) {
+			return fmt.Errorf("session %s is gone", s.alpha.ID)
+		}
+		if s.app.tmux.SessionExists(s.beta.ID) {
+			return fmt.Errorf("session %s outlived its agent", s.beta.ID)
+		}
+		if _, err := os.Stat(filepath.Join(s.workDir, "beta.txt")); !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("unmerged beta.txt reached the base branch")
+		}
+		return nil
+	}},
+}
+
+// createE2EAgent starts a fake agent named name.
+func createE2EAgent(s *e2eScenario, name string) (*domain.Agent, error) {
+	return s.app.agentService.CreateWithOptions("e2e", name, e2eAgentCommand, domain.CreateOptions{
+		Env: map[string]string{"CRAIZY_E2E_AGENT": name},
+	})
+}
+
+// waitForCommit waits up to the scenario's timeout for agent's branch to get
+// ahead of its base.
+func waitForCommit(s *e2eScenario, agent *domain.Agent) error {
+	deadline := time.Now().Add(s.timeout)
+	for {
+		commits, err := s.app.agentService.Commits(agent.ID)
+		if err == nil && len(commits) > 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			output, _ := s.app.agentService.CaptureOutput(agent.ID, 20)
+			return fmt.Errorf("%s made no commit within %s; its pane shows:\n%s", agent.Name, s.timeout, strings.TrimSpace(output))
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+// expectDelivered checks that msg was delivered to its recipient's session,
+// waiting up to the scenario's timeout for it to show in the pane.
+func expectDelivered(s *e2eScenario, msg *domain.Message) error {
+	if !msg.Read {
+		return fmt.Errorf("%s to %s was not delivered", msg.Code(), msg.To)
+	}
+	deadline := time.Now().Add(s.timeout)
+	for {
+		output, err := s.app.agentService.CaptureOutput(msg.To, 50)
+		if err == nil && strings.Contains(output, msg.Code()) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%s never showed in %s's pane", msg.Code(), msg.To)
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+// expectUnread checks that recipient's unread messages are exactly ids.
+func expectUnread(s *e2eScenario, recipient string, ids ...string) error {
+	unread, err := s.app.messageService.ListUnread(recipient)
+	if err != nil {
+		return err
+	}
+	got := make([]string, len(unread))
+	for i, msg := range unread {
+		got[i] = msg.ID
+	}
+	if !slices.Equal(got, ids) {
+		return fmt.Errorf("unread messages for %s = %v, want %v", recipient, got, ids)
+	}
+	return nil
+}
+
+// e2eGit runs git with args, returning its output in any error.
+func e2eGit(args ...string) error {
+	if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s failed: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// runQuietly runs fn with its standard output discarded, for commands like
+// runInit whose progress would interleave with the scenario's.
+func runQuietly(fn func() error) error {
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		return fn()
+	}
+	defer devNull.Close()
+	stdout := os.Stdout
+	os.Stdout = devNull
+	defer func() { os.Stdout = stdout }()
+	return fn()
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/infra"
+)
+
+// runEventsCommand handles the events subcommand, printing the persisted event log.
+func runEventsCommand() {
+	fs := flag.NewFlagSet("events", flag.ExitOnError)
+	follow := fs.Bool("follow", false, "Keep streaming new events as they are published")
+	fs.BoolVar(follow, "f", false, "Keep streaming new events (shorthand)")
+	asJSON := fs.Bool("json", false, "Print events as NDJSON")
+	eventType := fs.String("type", "", "Only show events whose type starts with this prefix (e.g. agent.)")
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		os.Exit(exitUsage)
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Failed to get working directory: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	if !isInitialized(workDir) {
+		fmt.Println("This directory is not initialized. Run 'craizy init' first.")
+		os.Exit(exitNotInitialized)
+	}
+
+	// Stop following on Ctrl+C
+	stop := make(chan struct{})
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		close(stop)
+	}()
+
+	err = infra.TailEvents(config.EventLogPath(workDir), *follow, stop, func(r infra.EventRecord) {
+		if !strings.HasPrefix(r.Type, *eventType) {
+			return
+		}
+		if *asJSON {
+			line, _ := json.Marshal(r)
+			fmt.Println(string(line))
+			return
+		}
+		fmt.Printf("%s  %-22s  %s\n", r.Time.Format(time.DateTime), r.Type, r.Data)
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// Exit codes shared by all commands so shell scripts can react to outcomes.
+const (
+	exitOK             = 0 // command succeeded
+	exitError          = 1 // command failed
+	exitUsage          = 2 // invalid flags or arguments
+	exitNotInitialized = 3 // directory has not been initialized with 'craizy init'
+	exitConflict       = 4 // a merge or rebase stopped on conflicts
+	exitTimeout        = 5 // 'craizy wait' timed out
+	exitAgentExited    = 6 // the agent exited before reaching the awaited state
+)
+
+// addQuietFlag registers --quiet and -q on fs. Quiet commands print only essential
+// identifiers, one per line, so their output can be piped into other commands.
+func addQuietFlag(fs *flag.FlagSet) *bool {
+	quiet := fs.Bool("quiet", false, "Print only essential IDs")
+	fs.BoolVar(quiet, "q", false, "Print only essential IDs (shorthand)")
+	return quiet
+}
+
+// accessibleEnv enables accessible output (plain layout, state words) for every command.
+const accessibleEnv = "CRAIZY_ACCESSIBLE"
+
+// accessibleRequested reports whether accessible output is enabled via accessibleEnv.
+func accessibleRequested() bool {
+	return os.Getenv(accessibleEnv) != ""
+}
+
+// readOnlyEnv disables commands that create, kill, merge or message agents, for
+// demos and observers who should only watch the fleet.
+const readOnlyEnv = "CRAIZY_READ_ONLY"
+
+// readOnlyRequested reports whether read-only mode is enabled via readOnlyEnv.
+func readOnlyRequested() bool {
+	return os.Getenv(readOnlyEnv) != ""
+}
+
+// humanEnv names the person running crAIzy on a shared project, e.g. "alice",
+// so the TUI shows their inbox and replies come from "human:alice".
+const humanEnv = "CRAIZY_HUMAN"
+
+// currentHuman returns the participant ID of the person named by humanEnv, or
+// the shared human inbox if it is unset.
+func currentHuman() string {
+	return domain.HumanParticipant(os.Getenv(humanEnv))
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -9,26 +10,89 @@ import (
 	"text/tabwriter"
 	"time"
 
-	tea "github.com/charmbracelet/bubbletea"
-
 	"github.com/TechnicallyShaun/crAIzy/internal/config"
 	"github.com/TechnicallyShaun/crAIzy/internal/domain"
 	"github.com/TechnicallyShaun/crAIzy/internal/infra"
 	"github.com/TechnicallyShaun/crAIzy/internal/infra/store"
 	"github.com/TechnicallyShaun/crAIzy/internal/logging"
 	"github.com/TechnicallyShaun/crAIzy/internal/tui"
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
 )
 
 func main() {
+	// Honor NO_COLOR (https://no-color.org) for every command
+	if theme.NoColorRequested() {
+		theme.DisableColor()
+	}
+
 	// Check for subcommands first (before flag parsing)
 	if len(os.Args) > 1 {
 		switch os.Args[1] {
 		case "init":
 			runInitCommand()
 			return
+		case "agent":
+			runAgentCommand()
+			return
 		case "msg":
 			runMsgCommand()
 			return
+		case "status":
+			runStatusCommand()
+			return
+		case "merges":
+			runMergesCommand()
+			return
+		case "events":
+			runEventsCommand()
+			return
+		case "doctor":
+			runDoctorCommand()
+			return
+		case "transcript":
+			runTranscriptCommand()
+			return
+		case "summarize":
+			runSummarizeCommand()
+			return
+		case "stats":
+			runStatsCommand()
+			return
+		case "wait":
+			runWaitCommand()
+			return
+		case "play":
+			runPlayCommand()
+			return
+		case "prompt":
+			runPromptCommand()
+			return
+		case "alias":
+			runAliasCommand()
+			return
+		case "db":
+			runDBCommand()
+			return
+		case "dash":
+			runDashCommand()
+			return
+		case "serve":
+			runServeCommand()
+			return
+		case "bugreport":
+			runBugreportCommand()
+			return
+		case "version", "--version":
+			runVersionCommand()
+			return
+		case "e2e":
+			// Internal: smoke test for maintainers against real tmux and git
+			runE2ECommand()
+			return
+		case "record-transcript":
+			// Internal: tmux pipes agent session output into this
+			runRecordTranscriptCommand()
+			return
 		case "help", "--help", "-h":
 			printHelp()
 			return
@@ -38,15 +102,21 @@ func main() {
 	// Parse flags for the main TUI command
 	help := flag.Bool("help", false, "Show help message")
 	flag.BoolVar(help, "h", false, "Show help message")
+	flags := addTUIFlags(flag.CommandLine)
 	flag.Parse()
 
 	if *help {
 		printHelp()
 		return
 	}
+	if flag.NArg() > 0 {
+		fmt.Printf("Unknown command: %s\n\n", flag.Arg(0))
+		printHelp()
+		os.Exit(exitUsage)
+	}
 
 	// Run the main TUI
-	runTUI()
+	runTUI(flags.options())
 }
 
 func printHelp() {
@@ -54,11 +124,43 @@ func printHelp() {
 	fmt.Println()
 	fmt.Println("Commands:")
 	fmt.Println("  init        Initialize crAIzy in the current directory")
+	fmt.Println("  agent       Agent commands (create --manifest)")
 	fmt.Println("  msg         Messaging commands (send, list, read, count)")
+	fmt.Println("  status      Show an overview of agents (--json for scripts)")
+	fmt.Println("  merges      Show merge history")
+	fmt.Println("  events      Show the event log (--follow --json to stream)")
+	fmt.Println("  doctor      Check environment and agent worktrees")
+	fmt.Println("  transcript  Export an agent's session output (--format md|txt, --messages)")
+	fmt.Println("  summarize   Summarize an agent's work with the configured summarizer")
+	fmt.Println("  stats       Show active and attached time by agent type (--json for scripts)")
+	fmt.Println("  wait        Block until an agent completes, goes idle, or exits (--until, --timeout)")
+	fmt.Println("  play        Run a playbook of prompts and wait conditions against an agent")
+	fmt.Println("  prompt      Manage the prompt library (list, show, new)")
+	fmt.Println("  alias       Name agents by role for messages (set, list, rm)")
+	fmt.Println("  db          Back up and restore the database (backup, list, restore)")
+	fmt.Println("  dash        Start the TUI (--tmux to keep it running in its own tmux session)")
+	fmt.Println("  serve       Serve agent and message operations over a local HTTP API (--addr)")
+	fmt.Println("  bugreport   Write a zip of logs, config, and events for an issue (secrets redacted)")
+	fmt.Println("  version     Show version and build information (also --version)")
 	fmt.Println("  help        Show this help message")
 	fmt.Println()
 	fmt.Println("Run 'craizy' without arguments to start the TUI.")
+	fmt.Println("Run 'craizy --ephemeral' to start the TUI with in-memory state (for demos).")
+	fmt.Println("Run 'craizy --accessible' for a plain linear layout, or '--no-color' to disable colors.")
+	fmt.Println("Run 'craizy --read-only' to watch agents without changing them (or set " + readOnlyEnv + " for every command).")
 	fmt.Println("Run 'craizy msg help' for messaging commands.")
+	fmt.Println("Set " + humanEnv + "=<name> on a shared project to read and reply as human:<name>.")
+	fmt.Println("Most commands accept --quiet (-q) to print only IDs.")
+	fmt.Println("Commands that take an agent ID let you pick an active agent when it's omitted.")
+	fmt.Println()
+	fmt.Println("Exit codes:")
+	fmt.Println("  0  success")
+	fmt.Println("  1  error")
+	fmt.Println("  2  invalid usage")
+	fmt.Println("  3  not initialized")
+	fmt.Println("  4  merge or rebase conflict")
+	fmt.Println("  5  wait timed out")
+	fmt.Println("  6  agent exited before the awaited state")
 }
 
 func runInitCommand() {
@@ -72,7 +174,7 @@ func runInitCommandInner() int {
 	workDir, err := os.Getwd()
 	if err != nil {
 		fmt.Printf("Failed to get working directory: %v\n", err)
-		return 1
+		return exitError
 	}
 
 	// Initialize logging (create .craizy dir first if needed for logging)
@@ -89,30 +191,69 @@ func runInitCommandInner() int {
 	if err := runInit(workDir); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		logging.Error(err, "command", "init")
-		return 1
+		return exitError
+	}
+	return exitOK
+}
+
+// tuiOptions are the command-line options of the main TUI.
+type tuiOptions struct {
+	Ephemeral    bool // keep all state in memory
+	Linear       bool // plain single-column rendering for screen readers
+	ReadOnly     bool // observer mode with actions that change agents disabled
+	SwitchClient bool // running in the dashboard tmux session; attach by switching clients
+}
+
+// tuiFlags are the command-line flags of the TUI, shared by craizy and craizy dash.
+type tuiFlags struct {
+	ephemeral  *bool
+	noColor    *bool
+	accessible *bool
+	readOnly   *bool
+}
+
+// addTUIFlags defines the TUI's flags on fs.
+func addTUIFlags(fs *flag.FlagSet) tuiFlags {
+	return tuiFlags{
+		ephemeral:  fs.Bool("ephemeral", false, "Keep all state in memory; nothing is written to the database"),
+		noColor:    fs.Bool("no-color", false, "Disable colors (also set by NO_COLOR)"),
+		accessible: fs.Bool("accessible", false, "Plain linear layout for screen readers (also set by "+accessibleEnv+")"),
+		readOnly:   fs.Bool("read-only", false, "Watch agents without creating, killing, merging or sending (also set by "+readOnlyEnv+")"),
+	}
+}
+
+// options returns the TUI options the parsed flags select, disabling colors if asked.
+func (f tuiFlags) options() tuiOptions {
+	opts := tuiOptions{
+		Ephemeral: *f.ephemeral,
+		Linear:    *f.accessible || accessibleRequested(),
+		ReadOnly:  *f.readOnly || readOnlyRequested(),
+	}
+	if *f.noColor || opts.Linear {
+		theme.DisableColor()
 	}
-	return 0
+	return opts
 }
 
-func runTUI() {
-	exitCode := runTUIInner()
+func runTUI(opts tuiOptions) {
+	exitCode := runTUIInner(opts)
 	if exitCode != 0 {
 		os.Exit(exitCode)
 	}
 }
 
-func runTUIInner() int {
+func runTUIInner(opts tuiOptions) int {
 	// Get working directory
 	workDir, err := os.Getwd()
 	if err != nil {
 		fmt.Printf("Failed to get working directory: %v\n", err)
-		return 1
+		return exitError
 	}
 
 	// Check if initialized
 	if !isInitialized(workDir) {
 		fmt.Println("This directory is not initialized. Run 'craizy init' first.")
-		return 1
+		return exitNotInitialized
 	}
 
 	// Detect project name (parent folder of cwd)
@@ -122,58 +263,58 @@ func runTUIInner() int {
 	logDir := config.CraizyDirPath(workDir)
 	if initErr := logging.Init(logDir); initErr != nil {
 		fmt.Printf("Failed to initialize logging: %v\n", initErr)
-		return 1
+		return exitError
 	}
 	defer logging.Close()
 	logging.Info("crAIzy starting, project=%s, workDir=%s", project, workDir)
 
-	// Create database directory
-	homeDir, err := os.UserHomeDir()
+	// Initialize stores and services
+	a, err := openApp(workDir, opts.Ephemeral)
 	if err != nil {
-		fmt.Printf("Failed to get home directory: %v\n", err)
-		return 1
-	}
-	dbDir := filepath.Join(homeDir, ".craizy")
-	if mkdirErr := os.MkdirAll(dbDir, 0o755); mkdirErr != nil {
-		fmt.Printf("Failed to create database directory: %v\n", mkdirErr)
-		return 1
-	}
-	dbPath := filepath.Join(dbDir, "craizy.db")
-
-	// Initialize infrastructure
-	tmuxClient := infra.NewTmuxClient()
-	gitClient := infra.NewGitClient(workDir)
-
-	// Initialize SQLite store
-	agentStore, err := store.NewSQLiteAgentStore(dbPath)
-	if err != nil {
-		fmt.Printf("Failed to initialize database: %v\n", err)
-		return 1
-	}
-	defer agentStore.Close()
-
-	// Initialize event dispatcher and wire adapters
-	dispatcher := infra.NewEventDispatcher()
-	infra.WireAdapters(dispatcher, agentStore, tmuxClient, gitClient)
-
-	// Initialize message store and service
-	messageStore := store.NewSQLiteMessageStore(agentStore.DB())
-	messageService := domain.NewMessageService(messageStore, tmuxClient, agentStore)
-
-	// Initialize agent service
-	agentService := domain.NewAgentService(tmuxClient, agentStore, dispatcher, gitClient, project, workDir)
-	agentService.SetMessageService(messageService)
-
-	// Reconcile any zombie sessions before starting
-	_ = agentService.Reconcile()
-
-	// Start TUI with services
-	p := tea.NewProgram(tui.NewModel(agentService, messageService))
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Alas, there's been an error: %v", err)
-		return 1
+		fmt.Printf("Error: %v\n", err)
+		return exitError
+	}
+	defer a.Close()
+	if opts.Ephemeral {
+		logging.Info("running in ephemeral mode, state will not be persisted")
+	}
+	if opts.ReadOnly {
+		logging.Info("running in read-only mode")
+	}
+	if opts.SwitchClient {
+		logging.Info("running in the %s tmux session", domain.DashboardSessionID(project))
+		a.agentService.SetSessionSwitcher(infra.NewTmuxClient())
+	}
+	human := currentHuman()
+	if err := a.messageService.ValidateHuman(human); err != nil {
+		fmt.Printf("Error: %s: %v\n", humanEnv, err)
+		return exitError
+	}
+
+	// Start TUI with services; it reconciles zombie sessions in the background
+	model := tui.NewModel(a.agentService, a.messageService)
+	progress := tui.NewProgressReporter()
+	a.agentService.SetProgressReporter(progress)
+	model.SetProgressReporter(progress)
+	model.SetLinear(opts.Linear)
+	model.SetReadOnly(opts.ReadOnly)
+	model.SetHuman(human)
+	model.SetProject(project)
+	model.SetAgentColors(a.agentColors)
+	model.SetGlyphs(statusGlyphs(a.settings.Glyphs))
+	model.SetBackups(a.backups)
+	model.SetRetryOptions(func(agent *domain.Agent) (domain.CreateOptions, error) {
+		return restartOptions(workDir, agent, true)
+	})
+	model.SetOpenCommand(a.settings.OpenCommand)
+	model.SetReminder(time.Duration(a.settings.Reminder.Minutes)*time.Minute, a.settings.Reminder.Desktop)
+	if err := runProgram(model); err != nil {
+		if !errors.Is(err, errPanicked) {
+			fmt.Printf("Alas, there's been an error: %v", err)
+		}
+		return exitError
 	}
-	return 0
+	return exitOK
 }
 
 // runMsgCommand handles the msg subcommand and its subcommands.
@@ -198,7 +339,7 @@ func runMsgCommand() {
 	default:
 		fmt.Printf("Unknown msg subcommand: %s\n", subCmd)
 		printMsgHelp()
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 }
 
@@ -213,35 +354,91 @@ func printMsgHelp() {
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  craizy msg send --from worker-001 --to lead-001 --type question --content \"Which auth library?\"")
+	fmt.Println("  craizy msg send --from worker-001 --to human --type question --content \"Is this right?\" --ref internal/auth/token.go:40-58")
+	fmt.Println("  craizy msg send --from human --to worker-001 --type assignment --content \"Start on the API\" --deliver-at 09:00")
 	fmt.Println("  craizy msg list --for worker-001")
 	fmt.Println("  craizy msg list --for human --unread")
-	fmt.Println("  craizy msg read <message-id>")
+	fmt.Println("  craizy msg list --for human --page 2")
+	fmt.Println("  craizy msg list --for human --before M-1042")
+	fmt.Println("  craizy msg list --from worker-001")
+	fmt.Println("  craizy msg read M-1042   (or any unique prefix of a message ID)")
 	fmt.Println("  craizy msg count --for human")
+	fmt.Println("  craizy msg send --from worker-001 --to human:alice --type question --content \"Can you review?\"")
+	fmt.Println("  craizy msg send --from worker-001 --to lead --type question --content \"Ready for review\"   (see 'craizy alias')")
+	fmt.Println("  craizy msg list --for human --unread --quiet")
+}
+
+// defaultDBPath returns the path to the shared database, creating its directory if needed.
+func defaultDBPath() (string, error) {
+	dbPath, err := dbFilePath()
+	if err != nil {
+		return "", err
+	}
+	if mkdirErr := os.MkdirAll(filepath.Dir(dbPath), 0o755); mkdirErr != nil {
+		return "", fmt.Errorf("failed to create database directory: %w", mkdirErr)
+	}
+	return dbPath, nil
+}
+
+// dbFilePath returns the database path like defaultDBPath without creating its directory.
+func dbFilePath() (string, error) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return filepath.Join(homeDir, ".craizy", "craizy.db"), nil
 }
 
 // initMsgServices initializes the services needed for messaging commands.
 func initMsgServices() (*domain.MessageService, func(), error) {
 	// Get database path
-	homeDir, err := os.UserHomeDir()
+	dbPath, err := defaultDBPath()
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get home directory: %w", err)
+		return nil, nil, err
 	}
-	dbDir := filepath.Join(homeDir, ".craizy")
-	if mkdirErr := os.MkdirAll(dbDir, 0o755); mkdirErr != nil {
-		return nil, nil, fmt.Errorf("failed to create database directory: %w", mkdirErr)
-	}
-	dbPath := filepath.Join(dbDir, "craizy.db")
 
 	// Initialize stores
-	agentStore, err := store.NewSQLiteAgentStore(dbPath)
+	agentStore, err := openAgentStore(dbPath)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to initialize database: %w", err)
 	}
 
 	messageStore := store.NewSQLiteMessageStore(agentStore.DB())
-	tmuxClient := infra.NewTmuxClient()
+	workDir, wdErr := os.Getwd()
+	tmuxClient := newSessionBackend(workDir)
 
 	messageSvc := domain.NewMessageService(messageStore, tmuxClient, agentStore)
+	messageSvc.SetAliasStore(store.NewSQLiteAliasStore(agentStore.DB()))
+	if wdErr == nil {
+		setDeliveryTransports(messageSvc, tmuxClient, workDir)
+	}
+
+	cleanup := func() {
+		agentStore.Close()
+	}
+
+	return messageSvc, cleanup, nil
+}
+
+// initMsgReader initializes messaging for commands that only read, which agents
+// run often. It opens the database read-only, skipping migrations, and falls
+// back to initMsgServices when it can't, e.g. before the database exists.
+func initMsgReader() (*domain.MessageService, func(), error) {
+	dbPath, err := dbFilePath()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	agentStore, err := store.OpenSQLiteAgentStoreReadOnly(dbPath)
+	if err != nil {
+		logging.Info("read-only open failed, opening the full store: %v", err)
+		return initMsgServices()
+	}
+
+	messageStore := store.NewSQLiteMessageStore(agentStore.DB())
+	workDir, _ := os.Getwd()
+	messageSvc := domain.NewMessageService(messageStore, newSessionBackend(workDir), agentStore)
+	messageSvc.SetAliasStore(store.NewSQLiteAliasStore(agentStore.DB()))
 
 	cleanup := func() {
 		agentStore.Close()
@@ -258,30 +455,59 @@ func runMsgSend() {
 	msgType := fs.String("type", "", "Message type: question, answer, assignment, completion, status, info (required)")
 	content := fs.String("content", "", "Message content (required)")
 	relatedWork := fs.String("related", "", "Related work item (optional)")
+	var refs fileRefsFlag
+	fs.Var(&refs, "ref", "File reference as path, path:line or path:start-end (repeatable)")
+	deliverAtFlag := fs.String("deliver-at", "", "Deliver at a time: HH:MM (next occurrence), \"YYYY-MM-DD HH:MM\" or RFC 3339")
+	delay := fs.Duration("delay", 0, "Deliver after a delay, e.g. 2h or 30m")
+	quiet := addQuietFlag(fs)
 
 	if err := fs.Parse(os.Args[3:]); err != nil {
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	// Validate required flags
 	if *from == "" || *to == "" || *msgType == "" || *content == "" {
 		fmt.Println("Error: --from, --to, --type, and --content are required")
 		fmt.Println()
-		fmt.Println("Usage: craizy msg send --from <sender> --to <recipient> --type <type> --content \"message\"")
-		os.Exit(1)
+		fmt.Println("Usage: craizy msg send --from <sender> --to <recipient> --type <type> --content \"message\" [--deliver-at <time> | --delay <duration>]")
+		os.Exit(exitUsage)
+	}
+
+	var deliverAt time.Time
+	switch {
+	case *deliverAtFlag != "" && *delay != 0:
+		fmt.Println("Error: use either --deliver-at or --delay, not both")
+		os.Exit(exitUsage)
+	case *deliverAtFlag != "":
+		at, err := domain.ParseDeliverAt(*deliverAtFlag, time.Now())
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitUsage)
+		}
+		deliverAt = at
+	case *delay < 0:
+		fmt.Println("Error: --delay must be positive")
+		os.Exit(exitUsage)
+	case *delay > 0:
+		deliverAt = time.Now().Add(*delay)
+	}
+
+	if readOnlyRequested() {
+		fmt.Printf("Error: sending messages is disabled in read-only mode (%s is set)\n", readOnlyEnv)
+		os.Exit(exitError)
 	}
 
 	// Validate message type
 	if !domain.IsValidMessageType(*msgType) {
 		fmt.Printf("Error: invalid message type: %s\n", *msgType)
 		fmt.Println("Valid types: question, answer, assignment, completion, status, info")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	svc, cleanup, err := initMsgServices()
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 	defer cleanup()
 
@@ -290,47 +516,116 @@ func runMsgSend() {
 		relatedWorkPtr = relatedWork
 	}
 
-	msg, err := svc.Send(*from, *to, domain.MessageType(*msgType), *content, relatedWorkPtr)
+	msg, err := svc.ScheduleWithRefs(*from, *to, domain.MessageType(*msgType), *content, relatedWorkPtr, refs, deliverAt)
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitError)
+	}
+
+	// A completion may trigger the project's summarizer; it exits quietly if none is configured
+	if msg.Type == domain.MessageTypeCompletion && !domain.IsHumanParticipant(msg.From) {
+		startBackgroundSummary(msg.From)
+	}
+
+	if *quiet {
+		fmt.Println(msg.ID)
+		return
 	}
+	if msg.DeliverAt != nil {
+		fmt.Printf("Message scheduled: %s (%s) for %s\n", msg.Code(), msg.ID, msg.DeliverAt.Format("2006-01-02 15:04"))
+		return
+	}
+	fmt.Printf("Message sent: %s (%s)\n", msg.Code(), msg.ID)
+}
 
-	fmt.Printf("Message sent: %s\n", msg.ID)
+// fileRefsFlag collects repeated --ref flags.
+type fileRefsFlag []domain.FileRef
+
+func (f *fileRefsFlag) String() string {
+	return domain.FormatFileRefs(*f)
 }
 
+func (f *fileRefsFlag) Set(value string) error {
+	ref, err := domain.ParseFileRef(value)
+	if err != nil {
+		return err
+	}
+	*f = append(*f, ref)
+	return nil
+}
+
+// msgPageSize is how many messages msg list shows at a time.
+const msgPageSize = 50
+
 func runMsgList() {
 	fs := flag.NewFlagSet("msg list", flag.ExitOnError)
-	forAgent := fs.String("for", "", "Recipient ID to list messages for (required)")
+	forAgent := fs.String("for", "", "Recipient ID to list messages for")
+	fromAgent := fs.String("from", "", "Sender ID to list sent messages for, instead of --for")
 	unreadOnly := fs.Bool("unread", false, "Show only unread messages")
+	page := fs.Int("page", 1, "Page of messages to show, newest first")
+	before := fs.String("before", "", "Show messages older than this message ID or code")
+	limit := fs.Int("limit", msgPageSize, "Messages per page")
+	quiet := addQuietFlag(fs)
 
 	if err := fs.Parse(os.Args[3:]); err != nil {
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
-	if *forAgent == "" {
-		fmt.Println("Error: --for is required")
+	if (*forAgent == "") == (*fromAgent == "") {
+		fmt.Println("Error: exactly one of --for or --from is required")
 		fmt.Println()
-		fmt.Println("Usage: craizy msg list --for <recipient> [--unread]")
-		os.Exit(1)
+		fmt.Println("Usage: craizy msg list --for <recipient> [--unread] [--page N | --before <message-id>] [--limit N]")
+		fmt.Println("       craizy msg list --from <sender> [--page N | --before <message-id>] [--limit N]")
+		os.Exit(exitUsage)
+	}
+	if *unreadOnly && *fromAgent != "" {
+		fmt.Println("Error: --unread applies to received messages, not --from")
+		os.Exit(exitUsage)
+	}
+	if *page < 1 || *limit < 1 {
+		fmt.Println("Error: --page and --limit must be at least 1")
+		os.Exit(exitUsage)
+	}
+	if *before != "" && *page > 1 {
+		fmt.Println("Error: use either --page or --before, not both")
+		os.Exit(exitUsage)
 	}
 
-	svc, cleanup, err := initMsgServices()
+	svc, cleanup, err := initMsgReader()
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 	defer cleanup()
 
+	// Sent messages page the same way, by sender instead of recipient
+	participant, list, pageOf := svc.ResolveParticipant(*forAgent), svc.List, svc.Page
+	if *fromAgent != "" {
+		participant, list, pageOf = svc.ResolveParticipant(*fromAgent), svc.ListSent, svc.SentPage
+	}
+
 	var messages []*domain.Message
-	if *unreadOnly {
-		messages, err = svc.ListUnread(*forAgent)
-	} else {
-		messages, err = svc.List(*forAgent, 0)
+	switch {
+	case *unreadOnly:
+		messages, err = svc.ListUnread(participant)
+	case *before != "":
+		var cursor int
+		if cursor, err = svc.ResolveSeq(*before); err == nil {
+			messages, err = list(participant, cursor, *limit)
+		}
+	default:
+		messages, err = pageOf(participant, *page, *limit)
 	}
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitError)
+	}
+
+	if *quiet {
+		for _, msg := range messages {
+			fmt.Println(msg.ID)
+		}
+		return
 	}
 
 	if len(messages) == 0 {
@@ -344,7 +639,11 @@ func runMsgList() {
 
 	// Print messages in table format
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "ID\tFROM\tTYPE\tTIME\tCONTENT")
+	if *fromAgent != "" {
+		fmt.Fprintln(w, "ID\tTO\tTYPE\tTIME\tCONTENT")
+	} else {
+		fmt.Fprintln(w, "ID\tFROM\tTYPE\tTIME\tCONTENT")
+	}
 
 	var unreadCount int
 	for _, msg := range messages {
@@ -358,11 +657,19 @@ func runMsgList() {
 		}
 		content = strings.ReplaceAll(content, "\n", " ")
 
+		peer := msg.From
+		if *fromAgent != "" {
+			peer = msg.To
+		}
+		sentAt := msg.CreatedAt.Format(time.DateTime)
+		if !msg.Due(time.Now()) {
+			sentAt = "due " + msg.DeliverAt.Format(time.DateTime)
+		}
 		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-			msg.ID[:8], // Show first 8 chars of ID
-			msg.From,
+			msg.Code(),
+			peer,
 			msg.Type,
-			msg.CreatedAt.Format(time.DateTime),
+			sentAt,
 			content,
 		)
 	}
@@ -373,6 +680,15 @@ func runMsgList() {
 		fmt.Printf(" (%d unread)", unreadCount)
 	}
 	fmt.Println()
+
+	// A full page suggests older messages; point at the cursor for the next one
+	if !*unreadOnly && len(messages) == *limit {
+		flagName := "for"
+		if *fromAgent != "" {
+			flagName = "from"
+		}
+		fmt.Printf("Older: craizy msg list --%s %s --before %s\n", flagName, participant, messages[len(messages)-1].Code())
+	}
 }
 
 func runMsgRead() {
@@ -380,25 +696,31 @@ func runMsgRead() {
 		fmt.Println("Error: message ID required")
 		fmt.Println()
 		fmt.Println("Usage: craizy msg read <message-id>")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
-	messageID := os.Args[3]
-
 	svc, cleanup, err := initMsgServices()
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 	defer cleanup()
 
+	// Accept any unique prefix of the ID
+	messageID, err := svc.ResolveID(os.Args[3])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+
 	msg, err := svc.Read(messageID)
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 
 	// Print message details
+	fmt.Printf("ID:      %s (%s)\n", msg.Code(), msg.ID)
 	fmt.Printf("From:    %s\n", msg.From)
 	fmt.Printf("To:      %s\n", msg.To)
 	fmt.Printf("Type:    %s\n", msg.Type)
@@ -406,6 +728,9 @@ func runMsgRead() {
 	if msg.RelatedWork != nil {
 		fmt.Printf("Related: %s\n", *msg.RelatedWork)
 	}
+	for _, ref := range msg.Refs {
+		fmt.Printf("Ref:     %s\n", ref)
+	}
 
 	fmt.Println()
 	fmt.Println("Content:")
@@ -419,34 +744,71 @@ func runMsgRead() {
 func runMsgCount() {
 	fs := flag.NewFlagSet("msg count", flag.ExitOnError)
 	forAgent := fs.String("for", "", "Recipient ID to count messages for (required)")
+	quiet := addQuietFlag(fs)
 
 	if err := fs.Parse(os.Args[3:]); err != nil {
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
 	if *forAgent == "" {
 		fmt.Println("Error: --for is required")
 		fmt.Println()
 		fmt.Println("Usage: craizy msg count --for <recipient>")
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
-	svc, cleanup, err := initMsgServices()
+	svc, cleanup, err := initMsgReader()
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 	defer cleanup()
 
-	count, err := svc.UnreadCount(*forAgent)
+	count, err := svc.UnreadCount(svc.ResolveParticipant(*forAgent))
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 
+	if *quiet {
+		fmt.Println(count)
+		return
+	}
 	if count == 1 {
 		fmt.Println("1 unread message")
 	} else {
 		fmt.Printf("%d unread messages\n", count)
 	}
 }
+
+// statusGlyphs resolves the glyph settings: the configured style, or unicode
+// when the locale and terminal support it, with any per-state overrides.
+func statusGlyphs(settings config.GlyphSettings) tui.Glyphs {
+	glyphs := tui.ASCIIGlyphs
+	switch settings.Style {
+	case config.GlyphStyleUnicode:
+		glyphs = tui.UnicodeGlyphs
+	case config.GlyphStyleASCII:
+	default:
+		if tui.UnicodeSupported(os.Getenv) {
+			glyphs = tui.UnicodeGlyphs
+		}
+	}
+
+	for _, override := range []struct {
+		glyph *string
+		value string
+	}{
+		{&glyphs.Active, settings.Active},
+		{&glyphs.Idle, settings.Idle},
+		{&glyphs.Failed, settings.Failed},
+		{&glyphs.Done, settings.Done},
+		{&glyphs.Paused, settings.Paused},
+		{&glyphs.Attention, settings.Attention},
+	} {
+		if override.value != "" {
+			*override.glyph = override.value
+		}
+	}
+	return glyphs
+}
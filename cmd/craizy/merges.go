@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/infra/store"
+)
+
+// runMergesCommand handles the merges subcommand, printing merge history.
+func runMergesCommand() {
+	fs := flag.NewFlagSet("merges", flag.ExitOnError)
+	agentID := fs.String("agent", "", "Only show merges for this agent ID")
+	limit := fs.Int("limit", 20, "Maximum number of merges to show (0 = all)")
+	quiet := addQuietFlag(fs)
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		os.Exit(exitUsage)
+	}
+
+	dbPath, err := defaultDBPath()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	agentStore, err := openAgentStore(dbPath)
+	if err != nil {
+		fmt.Printf("Error: failed to initialize database: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer agentStore.Close()
+
+	mergeStore := store.NewSQLiteMergeStore(agentStore.DB())
+
+	var records []*domain.MergeRecord
+	if *agentID != "" {
+		records, err = mergeStore.ListByAgent(*agentID)
+		if err == nil && *limit > 0 && len(records) > *limit {
+			records = records[:*limit]
+		}
+	} else {
+		records, err = mergeStore.List(*limit)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	if *quiet {
+		for _, r := range records {
+			fmt.Println(r.ID)
+		}
+		return
+	}
+
+	if len(records) == 0 {
+		fmt.Println("No merges recorded")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tAGENT\tBRANCH\tBASE\tSTRATEGY\tRESULT\tDURATION\tCONFLICTS")
+	for _, r := range records {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.CreatedAt.Format(time.DateTime),
+			r.AgentID,
+			r.Branch,
+			r.BaseBranch,
+			r.Strategy,
+			r.Outcome,
+			r.Duration.Round(time.Millisecond),
+			strings.Join(r.ConflictFiles, ", "),
+		)
+	}
+	w.Flush()
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// errNoAgentID is returned by pickAgent when there is no terminal to ask on.
+var errNoAgentID = errors.New("agent ID required")
+
+// errPickCancelled is returned by pickAgent when the user picks nothing.
+var errPickCancelled = errors.New("no agent selected")
+
+// splitAgentArg returns the agent ID at the start of args, if given, and the
+// arguments after it. The ID is optional wherever pickAgent can fill it in.
+func splitAgentArg(args []string) (string, []string) {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return "", args
+	}
+	return args[0], args[1:]
+}
+
+// isInteractive reports whether stdin and stderr are terminals, so a picker can
+// ask without corrupting piped output.
+func isInteractive() bool {
+	for _, f := range []*os.File{os.Stdin, os.Stderr} {
+		info, err := f.Stat()
+		if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// pickAgent asks the user to choose one of agents by number or by typing part
+// of its name, and returns its ID. It prompts on stderr so that stdout stays
+// clean for the command's own output.
+func pickAgent(agents []*domain.Agent) (string, error) {
+	if !isInteractive() {
+		return "", errNoAgentID
+	}
+	if len(agents) == 0 {
+		return "", errors.New("no active agents")
+	}
+	return runPicker(agents, os.Stdin, os.Stderr)
+}
+
+// runPicker narrows agents with each line read from in until one is chosen.
+func runPicker(agents []*domain.Agent, in io.Reader, out io.Writer) (string, error) {
+	reader := bufio.NewReader(in)
+	shown := agents
+	for {
+		printPickerList(out, shown)
+		fmt.Fprint(out, "Select an agent (number or filter, empty to cancel): ")
+
+		line, err := reader.ReadString('\n')
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return "", errPickCancelled
+		}
+
+		if n, convErr := strconv.Atoi(line); convErr == nil && n >= 1 && n <= len(shown) {
+			return shown[n-1].ID, nil
+		}
+		matches := domain.MatchAgents(agents, line)
+		switch len(matches) {
+		case 0:
+			fmt.Fprintf(out, "No agents match %q.\n", line)
+			shown = agents
+		case 1:
+			fmt.Fprintf(out, "Selected %s\n", matches[0].Name)
+			return matches[0].ID, nil
+		default:
+			shown = matches
+		}
+		if err != nil {
+			return "", errPickCancelled
+		}
+	}
+}
+
+// printPickerList prints agents numbered for selection.
+func printPickerList(out io.Writer, agents []*domain.Agent) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for i, agent := range agents {
+		fmt.Fprintf(w, "  %d)\t%s\t%s\t%s\n", i+1, agent.Name, agent.AgentType, agent.ID)
+	}
+	w.Flush()
+}
+
+// resolveAgentArg returns the ID of the agent an ID argument refers to, which
+// may be a unique prefix or a fragment of the agent's name. When the argument is
+// empty the user picks one of a's active agents instead. It exits on failure.
+func resolveAgentArg(a *app, agentID, usage string) string {
+	if agentID != "" {
+		resolved, err := a.agentService.ResolveAgent(agentID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitError)
+		}
+		return resolved
+	}
+	picked, err := pickAgent(a.agentService.List())
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		if errors.Is(err, errNoAgentID) {
+			fmt.Println()
+			fmt.Println(usage)
+		}
+		os.Exit(exitUsage)
+	}
+	return picked
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"regexp"
+	"strings"
+	"syscall"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// runPlayCommand handles the play subcommand, running a playbook of prompts and
+// wait conditions against a running agent.
+func runPlayCommand() {
+	const usage = "Usage: craizy play [agent-id] <playbook.yaml>"
+	var agentID, path string
+	switch args := os.Args[2:]; {
+	case len(args) == 1 && !strings.HasPrefix(args[0], "-"):
+		path = args[0]
+	case len(args) == 2 && !strings.HasPrefix(args[0], "-"):
+		agentID, path = args[0], args[1]
+	default:
+		fmt.Println(usage)
+		os.Exit(exitUsage)
+	}
+
+	if readOnlyRequested() {
+		fmt.Printf("Error: playbooks are disabled in read-only mode (%s is set)\n", readOnlyEnv)
+		os.Exit(exitError)
+	}
+
+	playbook, err := config.LoadPlaybook(path)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Failed to get working directory: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	if !isInitialized(workDir) {
+		fmt.Println("This directory is not initialized. Run 'craizy init' first.")
+		os.Exit(exitNotInitialized)
+	}
+
+	if err := logging.Init(config.CraizyDirPath(workDir)); err == nil {
+		defer logging.Close()
+	}
+
+	a, err := newApp(workDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer a.Close()
+	agentID = resolveAgentArg(a, agentID, usage)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	steps := playbookSteps(playbook)
+	err = a.agentService.RunPlaybook(ctx, agentID, steps, func(p domain.PlaybookProgress) {
+		step := steps[p.Step-1]
+		switch {
+		case !p.Done && step.Wait.IsZero():
+			fmt.Printf("[%d/%d] sending prompt\n", p.Step, p.Total)
+		case !p.Done:
+			fmt.Printf("[%d/%d] sending prompt, waiting for %s\n", p.Step, p.Total, step.Wait)
+		case p.Result != "":
+			fmt.Printf("[%d/%d] done (%s)\n", p.Step, p.Total, p.Result)
+		}
+	})
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	name := playbook.Name
+	if name == "" {
+		name = path
+	}
+	fmt.Printf("Playbook %s finished\n", name)
+}
+
+// playbookSteps converts a validated playbook file to domain steps.
+func playbookSteps(playbook *config.Playbook) []domain.PlaybookStep {
+	steps := make([]domain.PlaybookStep, 0, len(playbook.Steps))
+	for _, s := range playbook.Steps {
+		wait := domain.WaitCondition{
+			Completion: s.Wait.Completion,
+			Idle:       s.Wait.Idle,
+			Timeout:    s.Wait.Timeout,
+		}
+		if s.Wait.Match != "" {
+			wait.Match = regexp.MustCompile(s.Wait.Match) // validated by LoadPlaybook
+		}
+		steps = append(steps, domain.PlaybookStep{Send: s.Send, Wait: wait})
+	}
+	return steps
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+)
+
+// runPromptCommand handles the prompt subcommand and its subcommands, which
+// manage the prompt library in .craizy/prompts.
+func runPromptCommand() {
+	if len(os.Args) < 3 {
+		printPromptHelp()
+		return
+	}
+
+	subCmd := os.Args[2]
+	switch subCmd {
+	case "list", "ls":
+		runPromptList()
+	case "show":
+		runPromptShow()
+	case "new":
+		runPromptNew()
+	case "help", "--help", "-h":
+		printPromptHelp()
+	default:
+		fmt.Printf("Unknown prompt subcommand: %s\n", subCmd)
+		printPromptHelp()
+		os.Exit(exitUsage)
+	}
+}
+
+func printPromptHelp() {
+	fmt.Println("Usage: craizy prompt <command> [name]")
+	fmt.Println()
+	fmt.Println("Commands:")
+	fmt.Println("  list    List prompts in .craizy/prompts (alias: ls)")
+	fmt.Println("  show    Print a prompt")
+	fmt.Println("  new     Create a prompt from a starter template")
+	fmt.Println()
+	fmt.Println("Prompts are markdown files selectable when creating an agent. These")
+	fmt.Println("placeholders are filled in when the prompt is sent:")
+	fmt.Println("  {name} {branch} {base_branch} {worktree}")
+	fmt.Println("  {stack} {go_module} {go_version} {node_package} {node_version} {python_version}")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  craizy prompt new review")
+	fmt.Println("  craizy prompt show review")
+}
+
+// promptsDir returns the prompt library of the initialized project in the
+// current directory, exiting if there is none.
+func promptsDir() string {
+	workDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Failed to get working directory: %v\n", err)
+		os.Exit(exitError)
+	}
+	if !isInitialized(workDir) {
+		fmt.Println("This directory is not initialized. Run 'craizy init' first.")
+		os.Exit(exitNotInitialized)
+	}
+	return config.PromptsDirPath(workDir)
+}
+
+// promptName returns the prompt name argument of a prompt subcommand.
+func promptName(subCmd string) string {
+	if len(os.Args) != 4 {
+		fmt.Printf("Usage: craizy prompt %s <name>\n", subCmd)
+		os.Exit(exitUsage)
+	}
+	return os.Args[3]
+}
+
+func runPromptList() {
+	prompts, err := config.LoadPrompts(promptsDir())
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	if len(prompts) == 0 {
+		fmt.Println("No prompts. Create one with 'craizy prompt new <name>'.")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTITLE")
+	for _, p := range prompts {
+		fmt.Fprintf(w, "%s\t%s\n", p.Name, p.Title())
+	}
+	w.Flush()
+}
+
+func runPromptShow() {
+	name := promptName("show")
+	prompt, err := config.LoadPrompt(promptsDir(), name)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	fmt.Print(prompt.Body)
+}
+
+func runPromptNew() {
+	name := promptName("new")
+	path, err := config.NewPrompt(promptsDir(), name)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	fmt.Printf("Created %s\n", path)
+}
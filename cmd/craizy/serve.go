@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/api"
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// serveShutdownTimeout bounds how long in-flight requests may finish on shutdown.
+const serveShutdownTimeout = 5 * time.Second
+
+// runServeCommand handles the serve subcommand, serving the HTTP API until
+// interrupted.
+func runServeCommand() {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", api.DefaultAddr, "Address to listen on")
+	readOnly := fs.Bool("read-only", false, "Reject requests that create, kill or send (also set by "+readOnlyEnv+")")
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		os.Exit(exitUsage)
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Failed to get working directory: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	if !isInitialized(workDir) {
+		fmt.Println("This directory is not initialized. Run 'craizy init' first.")
+		os.Exit(exitNotInitialized)
+	}
+
+	if err := logging.Init(config.CraizyDirPath(workDir)); err == nil {
+		defer logging.Close()
+	}
+
+	a, err := newApp(workDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer a.Close()
+
+	server := api.NewServer(a.agentService, a.messageService, func(entry config.ManifestAgent, metadata map[string]string) (*domain.Agent, error) {
+		agentTypes, err := config.LoadAgents(config.AgentsPath(workDir))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load agents: %w", err)
+		}
+		return createManifestAgent(a, workDir, agentTypes, entry, metadata)
+	})
+	server.SetReadOnly(*readOnly || readOnlyRequested())
+	tokens, err := serverTokens(a.settings.Server.Tokens)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	server.SetTokens(tokens)
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	if len(tokens) == 0 && !isLoopback(listener.Addr()) {
+		// Without tokens anyone who can reach the address could control agents
+		_ = listener.Close()
+		fmt.Printf("Error: refusing to serve on %s without server.tokens in settings.yml; bind to localhost or configure tokens\n", listener.Addr())
+		os.Exit(exitUsage)
+	}
+	fmt.Printf("Serving the crAIzy API on http://%s (Ctrl+C to stop)\n", listener.Addr())
+	logging.Info("serving API, addr=%s", listener.Addr())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	httpServer := &http.Server{Handler: server.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Printf("Error: %v\n", err)
+		logging.Error(err, "command", "serve")
+		os.Exit(exitError)
+	}
+}
+
+// serverTokens reads each configured token's secret from its environment
+// variable, failing if one is unset rather than rejecting that client later.
+func serverTokens(settings []config.ServerToken) ([]api.Token, error) {
+	tokens := make([]api.Token, 0, len(settings))
+	for _, token := range settings {
+		secret := os.Getenv(token.Env)
+		if secret == "" {
+			return nil, fmt.Errorf("server token %q: %s is not set", token.Name, token.Env)
+		}
+		tokens = append(tokens, api.Token{Name: token.Name, Role: token.Role, Secret: secret})
+	}
+	return tokens, nil
+}
+
+// isLoopback reports whether addr only accepts connections from this machine.
+func isLoopback(addr net.Addr) bool {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+type typeTimeJSON struct {
+	Type                 string `json:"type"`
+	Agents               int    `json:"agents"`
+	ActiveSeconds        int64  `json:"active_seconds"`
+	AverageActiveSeconds int64  `json:"average_active_seconds"`
+	AttachedSeconds      int64  `json:"attached_seconds"`
+}
+
+// runStatsCommand handles the stats subcommand, comparing time spent across agent types.
+func runStatsCommand() {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "Print stats as JSON")
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		os.Exit(exitUsage)
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Failed to get working directory: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	if !isInitialized(workDir) {
+		fmt.Println("This directory is not initialized. Run 'craizy init' first.")
+		os.Exit(exitNotInitialized)
+	}
+
+	if err := logging.Init(config.CraizyDirPath(workDir)); err == nil {
+		defer logging.Close()
+	}
+
+	a, err := newApp(workDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer a.Close()
+
+	stats := a.agentService.TimeStats(time.Now())
+	if *asJSON {
+		printStatsJSON(stats)
+		return
+	}
+
+	if len(stats) == 0 {
+		fmt.Println("No agents recorded")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tAGENTS\tACTIVE\tAVG ACTIVE\tATTACHED")
+	for _, t := range stats {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n",
+			t.AgentType,
+			t.Agents,
+			t.Active.Round(time.Second),
+			t.AverageActive().Round(time.Second),
+			t.Attached.Round(time.Second),
+		)
+	}
+	w.Flush()
+}
+
+func printStatsJSON(stats []domain.TypeTime) {
+	out := []typeTimeJSON{}
+	for _, t := range stats {
+		out = append(out, typeTimeJSON{
+			Type:                 t.AgentType,
+			Agents:               t.Agents,
+			ActiveSeconds:        int64(t.Active.Seconds()),
+			AverageActiveSeconds: int64(t.AverageActive().Seconds()),
+			AttachedSeconds:      int64(t.Attached.Seconds()),
+		})
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(out)
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// statusJSON is the --json output of the status command.
+type statusJSON struct {
+	Project          string            `json:"project"`
+	Agents           []agentStatusJSON `json:"agents"`
+	UnreadMessages   int               `json:"unread_messages"`
+	PendingMerges    int               `json:"pending_merges"`
+	OrphanedSessions []string          `json:"orphaned_sessions"`
+	Queued           []string          `json:"queued"`
+}
+
+type agentStatusJSON struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Type            string `json:"type"`
+	Branch          string `json:"branch"`
+	BaseBranch      string `json:"base_branch"`
+	Running         bool   `json:"running"`
+	WorktreeMissing bool   `json:"worktree_missing"`
+	Ahead           int    `json:"ahead"`
+	Behind          int    `json:"behind"`
+	Summary         string `json:"summary"`
+	PRURL           string `json:"pr_url"`
+	IssueURL        string `json:"issue_url"`
+	ActiveSeconds   int64  `json:"active_seconds"`
+	AttachedSeconds int64  `json:"attached_seconds"`
+}
+
+// runStatusCommand handles the status subcommand, printing a one-shot overview of the agent fleet.
+func runStatusCommand() {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "Print status as JSON")
+	accessible := fs.Bool("accessible", false, "Print state words instead of icons (also set by "+accessibleEnv+")")
+	quiet := addQuietFlag(fs)
+
+	if err := fs.Parse(os.Args[2:]); err != nil {
+		os.Exit(exitUsage)
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Failed to get working directory: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	if !isInitialized(workDir) {
+		fmt.Println("This directory is not initialized. Run 'craizy init' first.")
+		os.Exit(exitNotInitialized)
+	}
+
+	if err := logging.Init(config.CraizyDirPath(workDir)); err == nil {
+		defer logging.Close()
+	}
+
+	a, err := newApp(workDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer a.Close()
+
+	status, err := a.agentService.Status()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	switch {
+	case *asJSON:
+		printStatusJSON(status)
+	case *quiet:
+		for _, s := range status.Agents {
+			fmt.Println(s.Agent.ID)
+		}
+	default:
+		printStatus(status, *accessible || accessibleRequested())
+	}
+}
+
+// statusIcon returns the state icon for an agent summary, or a state word when words is set.
+func statusIcon(s domain.AgentSummary, words bool) string {
+	switch {
+	case s.WorktreeMissing && words:
+		return "worktree-missing"
+	case s.WorktreeMissing:
+		return "!"
+	case !s.Running && words:
+		return "stopped"
+	case !s.Running:
+		return "✗"
+	case words:
+		return "running"
+	default:
+		return "●"
+	}
+}
+
+func printStatus(status *domain.FleetStatus, words bool) {
+	fmt.Printf("Project: %s\n\n", status.Project)
+
+	if len(status.Agents) == 0 {
+		fmt.Println("No active agents")
+	} else {
+		now := time.Now()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, s := range status.Agents {
+			format := "  %s\t%s\t%s\t%s → %s\t↑%d ↓%d\tactive %s, attached %s\n"
+			if words {
+				format = "  %s\t%s\t%s\t%s onto %s\tahead %d, behind %d\tactive %s, attached %s\n"
+			}
+			fmt.Fprintf(w, format,
+				statusIcon(s, words),
+				s.Agent.Name,
+				s.Agent.AgentType,
+				s.Agent.Branch,
+				s.Agent.BaseBranch,
+				s.Ahead,
+				s.Behind,
+				s.Agent.ActiveTime(now).Round(time.Second),
+				s.Agent.AttachedTime.Round(time.Second),
+			)
+		}
+		w.Flush()
+
+		var summaries []string
+		for _, s := range status.Agents {
+			if s.Agent.Summary != "" {
+				summaries = append(summaries, fmt.Sprintf("  %s: %s", s.Agent.Name, s.Agent.Summary))
+			}
+		}
+		if len(summaries) > 0 {
+			fmt.Println()
+			fmt.Println("Summaries:")
+			fmt.Println(strings.Join(summaries, "\n"))
+		}
+
+		var links []string
+		for _, s := range status.Agents {
+			if url := s.Agent.PRURL(); url != "" {
+				links = append(links, fmt.Sprintf("  %s PR: %s", s.Agent.Name, url))
+			}
+			if url := s.Agent.IssueURL(); url != "" {
+				links = append(links, fmt.Sprintf("  %s issue: %s", s.Agent.Name, url))
+			}
+		}
+		if len(links) > 0 {
+			fmt.Println()
+			fmt.Println("Links:")
+			fmt.Println(strings.Join(links, "\n"))
+		}
+	}
+
+	if len(status.Queued) > 0 {
+		fmt.Println()
+		fmt.Println("Queued (waiting for a provider slot):")
+		for _, agent := range status.Queued {
+			fmt.Printf("  %s (%s)\n", agent.Name, agent.AgentType)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("Unread messages: %d\n", status.UnreadMessages)
+	fmt.Printf("Pending merges:  %d\n", status.PendingMerges)
+
+	var problems []string
+	for _, s := range status.Agents {
+		if s.WorktreeMissing {
+			problems = append(problems, fmt.Sprintf("worktree missing for %s (run 'craizy doctor')", s.Agent.ID))
+		} else if !s.Running {
+			problems = append(problems, fmt.Sprintf("tmux session gone for %s", s.Agent.ID))
+		}
+	}
+	for _, session := range status.OrphanedSessions {
+		problems = append(problems, fmt.Sprintf("orphaned tmux session %s", session))
+	}
+	if len(problems) > 0 {
+		fmt.Println()
+		fmt.Println("Orphaned resources:")
+		for _, p := range problems {
+			fmt.Printf("  - %s\n", p)
+		}
+	}
+}
+
+func printStatusJSON(status *domain.FleetStatus) {
+	out := statusJSON{
+		Project:          status.Project,
+		Agents:           []agentStatusJSON{},
+		UnreadMessages:   status.UnreadMessages,
+		PendingMerges:    status.PendingMerges,
+		OrphanedSessions: status.OrphanedSessions,
+		Queued:           []string{},
+	}
+	if out.OrphanedSessions == nil {
+		out.OrphanedSessions = []string{}
+	}
+	now := time.Now()
+	for _, s := range status.Agents {
+		out.Agents = append(out.Agents, agentStatusJSON{
+			ID:              s.Agent.ID,
+			Name:            s.Agent.Name,
+			Type:            s.Agent.AgentType,
+			Branch:          s.Agent.Branch,
+			BaseBranch:      s.Agent.BaseBranch,
+			Running:         s.Running,
+			WorktreeMissing: s.WorktreeMissing,
+			Ahead:           s.Ahead,
+			Behind:          s.Behind,
+			Summary:         s.Agent.Summary,
+			PRURL:           s.Agent.PRURL(),
+			IssueURL:        s.Agent.IssueURL(),
+			ActiveSeconds:   int64(s.Agent.ActiveTime(now).Seconds()),
+			AttachedSeconds: int64(s.Agent.AttachedTime.Seconds()),
+		})
+	}
+
+	for _, agent := range status.Queued {
+		out.Queued = append(out.Queued, agent.ID)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(out)
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// runSummarizeCommand handles the summarize subcommand, running the configured
+// summarizer for an agent and storing the result on its record.
+func runSummarizeCommand() {
+	const usage = "Usage: craizy summarize [agent-id] [--auto]"
+	agentID, args := splitAgentArg(os.Args[2:])
+
+	fs := flag.NewFlagSet("summarize", flag.ExitOnError)
+	auto := fs.Bool("auto", false, "Exit quietly when no summarizer is configured (used after completion messages)")
+
+	if err := fs.Parse(args); err != nil {
+		os.Exit(exitUsage)
+	}
+
+	// Agents send completion messages from their worktree, so find the project from the agent record
+	workDir, err := os.Getwd()
+	if agentID != "" {
+		workDir, agentID, err = agentProjectRoot(agentID)
+	} else if err == nil && !isInitialized(workDir) {
+		fmt.Println("This directory is not initialized. Run 'craizy init' first.")
+		os.Exit(exitNotInitialized)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	if err := logging.Init(config.CraizyDirPath(workDir)); err == nil {
+		defer logging.Close()
+	}
+
+	a, err := newApp(workDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer a.Close()
+	agentID = resolveAgentArg(a, agentID, usage)
+
+	summary, err := a.agentService.Summarize(agentID)
+	if errors.Is(err, domain.ErrNoSummarizer) {
+		if *auto {
+			return
+		}
+		fmt.Println("No summarizer configured. Set summarizer.command in .craizy/settings.yml.")
+		os.Exit(exitError)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	if !*auto {
+		fmt.Println(summary)
+	}
+}
+
+// agentProjectRoot returns the project root and full ID of the stored agent
+// query refers to, by ID, unique prefix, or name fragment.
+func agentProjectRoot(query string) (string, string, error) {
+	dbPath, err := defaultDBPath()
+	if err != nil {
+		return "", "", err
+	}
+	agentStore, err := openAgentStore(dbPath)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to initialize database: %w", err)
+	}
+	defer agentStore.Close()
+
+	agentID, err := domain.ResolveAgentID(agentStore.List(), query)
+	if err != nil {
+		return "", "", err
+	}
+	return domain.ProjectRoot(agentStore.Get(agentID).WorkDir), agentID, nil
+}
+
+// startBackgroundSummary starts `craizy summarize --auto` for an agent without
+// waiting, so the agent's `msg send` returns while the summarizer runs.
+func startBackgroundSummary(agentID string) {
+	exe, err := os.Executable()
+	if err != nil {
+		logging.Error(err, "agentID", agentID, "action", "locate craizy binary")
+		return
+	}
+	cmd := exec.Command(exe, "summarize", agentID, "--auto")
+	if err := cmd.Start(); err != nil {
+		logging.Error(err, "agentID", agentID, "action", "start summarizer")
+		return
+	}
+	_ = cmd.Process.Release()
+}
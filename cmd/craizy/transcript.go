@@ -0,0 +1,151 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/infra"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// runTranscriptCommand handles the transcript subcommand, exporting an agent's
+// recorded session output.
+func runTranscriptCommand() {
+	const usage = "Usage: craizy transcript [agent-id] [--format md|txt] [--messages]"
+	agentID, args := splitAgentArg(os.Args[2:])
+
+	fs := flag.NewFlagSet("transcript", flag.ExitOnError)
+	format := fs.String("format", "txt", "Output format: md or txt")
+	withMessages := fs.Bool("messages", false, "Interleave messages sent to and from the agent")
+
+	if err := fs.Parse(args); err != nil {
+		os.Exit(exitUsage)
+	}
+	if *format != "md" && *format != "txt" {
+		fmt.Printf("Error: unknown format %q (want md or txt)\n", *format)
+		os.Exit(exitUsage)
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Failed to get working directory: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	if !isInitialized(workDir) {
+		fmt.Println("This directory is not initialized. Run 'craizy init' first.")
+		os.Exit(exitNotInitialized)
+	}
+
+	if err := logging.Init(config.CraizyDirPath(workDir)); err == nil {
+		defer logging.Close()
+	}
+
+	a, err := newApp(workDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer a.Close()
+	agentID = resolveAgentArg(a, agentID, usage)
+
+	lines, err := a.agentService.Transcript(agentID)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	var messages []*domain.Message
+	if *withMessages {
+		messages, err = a.messageService.Thread(agentID)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(exitError)
+		}
+	}
+
+	writeTranscript(os.Stdout, agentID, *format, lines, messages)
+}
+
+// runRecordTranscriptCommand handles the internal record-transcript subcommand that
+// tmux pipes session output into.
+func runRecordTranscriptCommand() {
+	if len(os.Args) < 3 {
+		fmt.Println("Usage: craizy record-transcript <path>")
+		os.Exit(exitUsage)
+	}
+	if err := infra.RecordTranscript(os.Stdin, os.Args[2]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+}
+
+// writeTranscript renders output lines as md or txt, placing each message before
+// the first output line recorded after it was sent. Runs of blank lines collapse to one.
+func writeTranscript(w io.Writer, agentID, format string, lines []domain.TranscriptLine, messages []*domain.Message) {
+	md := format == "md"
+	if md {
+		fmt.Fprintf(w, "# Transcript: %s\n", agentID)
+	} else {
+		fmt.Fprintf(w, "Transcript: %s\n", agentID)
+	}
+
+	var block []string
+	flush := func() {
+		for len(block) > 0 && block[len(block)-1] == "" {
+			block = block[:len(block)-1]
+		}
+		if len(block) == 0 {
+			return
+		}
+		if md {
+			fence := markdownFence(block)
+			fmt.Fprintf(w, "\n%s\n%s\n%s\n", fence, strings.Join(block, "\n"), fence)
+		} else {
+			fmt.Fprintf(w, "\n%s\n", strings.Join(block, "\n"))
+		}
+		block = nil
+	}
+	writeMessage := func(msg *domain.Message) {
+		flush()
+		header := fmt.Sprintf("%s → %s (%s, %s)", msg.From, msg.To, msg.Type, msg.CreatedAt.Format(time.DateTime))
+		if md {
+			fmt.Fprintf(w, "\n> **%s**\n>\n> %s\n", header, strings.ReplaceAll(msg.Content, "\n", "\n> "))
+		} else {
+			fmt.Fprintf(w, "\n--- message %s\n%s\n---\n", header, msg.Content)
+		}
+	}
+
+	for _, line := range lines {
+		for len(messages) > 0 && !line.Time.IsZero() && !messages[0].CreatedAt.After(line.Time) {
+			writeMessage(messages[0])
+			messages = messages[1:]
+		}
+		text := strings.TrimRight(line.Text, " \t")
+		if text == "" && (len(block) == 0 || block[len(block)-1] == "") {
+			continue
+		}
+		block = append(block, text)
+	}
+	for _, msg := range messages {
+		writeMessage(msg)
+	}
+	flush()
+}
+
+// markdownFence returns a code fence longer than any backtick run in lines.
+func markdownFence(lines []string) string {
+	fence := "```"
+	for _, line := range lines {
+		for strings.Contains(line, fence) {
+			fence += "`"
+		}
+	}
+	return fence
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/version"
+)
+
+// runVersionCommand handles the version subcommand and --version flag, printing
+// build metadata and the detected tmux and git versions.
+func runVersionCommand() {
+	info := version.Get()
+	fmt.Printf("craizy %s\n", info.Version)
+	fmt.Printf("  commit: %s\n", info.Commit)
+	fmt.Printf("  built:  %s\n", info.Date)
+	fmt.Printf("  go:     %s\n", info.GoVersion)
+	fmt.Printf("  tmux:   %s\n", toolVersion("tmux", "-V"))
+	fmt.Printf("  git:    %s\n", toolVersion("git", "--version"))
+}
+
+// toolVersion returns the version an external tool reports, or "not found".
+func toolVersion(name, versionArg string) string {
+	output, err := exec.Command(name, versionArg).Output()
+	if err != nil {
+		return "not found"
+	}
+	return strings.TrimSpace(string(output))
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// runWaitCommand handles the wait subcommand, blocking until an agent reaches a
+// state so scripts and CI can sequence work around it.
+func runWaitCommand() {
+	const usage = "Usage: craizy wait [agent-id] [--until completion|idle|exit] [--timeout 30m] [--idle 10m]"
+	agentID, args := splitAgentArg(os.Args[2:])
+
+	fs := flag.NewFlagSet("wait", flag.ExitOnError)
+	until := fs.String("until", "completion", "State to wait for: completion, idle or exit (comma separated for any of them)")
+	timeout := fs.Duration("timeout", 0, "Give up after this long, e.g. 30m (default: wait forever)")
+	idle := fs.Duration("idle", 0, "Quiet period that counts as idle (default: attention_idle_minutes)")
+	quiet := addQuietFlag(fs)
+
+	if err := fs.Parse(args); err != nil {
+		os.Exit(exitUsage)
+	}
+
+	workDir, err := os.Getwd()
+	if err != nil {
+		fmt.Printf("Failed to get working directory: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	if !isInitialized(workDir) {
+		fmt.Println("This directory is not initialized. Run 'craizy init' first.")
+		os.Exit(exitNotInitialized)
+	}
+
+	if err := logging.Init(config.CraizyDirPath(workDir)); err == nil {
+		defer logging.Close()
+	}
+
+	a, err := newApp(workDir)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	defer a.Close()
+	agentID = resolveAgentArg(a, agentID, usage)
+
+	idleFor := *idle
+	if idleFor <= 0 {
+		idleFor = a.agentService.AttentionIdle()
+	}
+	cond, err := parseWaitUntil(*until, idleFor)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitUsage)
+	}
+	cond.Timeout = *timeout
+
+	if !a.agentService.Exists(agentID) {
+		fmt.Printf("Error: agent %q not found\n", agentID)
+		os.Exit(exitError)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !*quiet {
+		fmt.Printf("Waiting for %s: %s\n", agentID, cond)
+	}
+	result, err := a.agentService.WaitFor(ctx, agentID, cond, time.Now())
+	switch {
+	case errors.Is(err, domain.ErrWaitTimeout):
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitTimeout)
+	case errors.Is(err, domain.ErrAgentExited):
+		fmt.Printf("Error: %s exited before reaching %s\n", agentID, cond)
+		os.Exit(exitAgentExited)
+	case err != nil:
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	fmt.Println(result)
+}
+
+// parseWaitUntil converts --until into a wait condition, using idle as the
+// quiet period for the idle state.
+func parseWaitUntil(until string, idle time.Duration) (domain.WaitCondition, error) {
+	var cond domain.WaitCondition
+	for _, state := range strings.Split(until, ",") {
+		switch strings.TrimSpace(state) {
+		case "completion":
+			cond.Completion = true
+		case "idle":
+			cond.Idle = idle
+		case "exit":
+			cond.Exit = true
+		default:
+			return cond, fmt.Errorf("unknown --until state %q (want completion, idle or exit)", state)
+		}
+	}
+	return cond, nil
+}
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+)
+
+// roleRank orders roles from least to most privileged.
+var roleRank = map[string]int{
+	config.RoleViewer:   1,
+	config.RoleOperator: 2,
+	config.RoleAdmin:    3,
+}
+
+// Token authenticates an API client as Name with Role. Secret is what the
+// client sends as "Authorization: Bearer <secret>".
+type Token struct {
+	Name   string
+	Role   string
+	Secret string
+}
+
+// SetTokens requires every request to carry one of tokens. With no tokens the
+// API is unauthenticated.
+func (s *Server) SetTokens(tokens []Token) {
+	s.tokens = tokens
+}
+
+// tokenKey is the request context key holding the caller's Token.
+type tokenKey struct{}
+
+// callerToken returns the token the request authenticated with, or nil if
+// the server has no tokens.
+func callerToken(r *http.Request) *Token {
+	token, _ := r.Context().Value(tokenKey{}).(*Token)
+	return token
+}
+
+// authenticate returns the token matching the request's bearer secret, or nil.
+func (s *Server) authenticate(r *http.Request) *Token {
+	secret, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || secret == "" {
+		return nil
+	}
+	for i := range s.tokens {
+		if subtle.ConstantTimeCompare([]byte(secret), []byte(s.tokens[i].Secret)) == 1 {
+			return &s.tokens[i]
+		}
+	}
+	return nil
+}
+
+// require wraps a handler that needs at least role, writing a 401 for a
+// missing or unknown token and a 403 for a token with a lesser role. Read-only
+// mode treats every caller as a viewer.
+func (s *Server) require(role string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var token *Token
+		if len(s.tokens) > 0 {
+			if token = s.authenticate(r); token == nil {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				writeError(w, http.StatusUnauthorized, errors.New("missing or unknown token"))
+				return
+			}
+			r = r.WithContext(context.WithValue(r.Context(), tokenKey{}, token))
+		}
+		if s.readOnly && roleRank[role] > roleRank[config.RoleViewer] {
+			writeError(w, http.StatusForbidden, errors.New("disabled in read-only mode"))
+			return
+		}
+		if token != nil && roleRank[token.Role] < roleRank[role] {
+			writeError(w, http.StatusForbidden, fmt.Errorf("%s needs the %s role", token.Name, role))
+			return
+		}
+		handler(w, r)
+	}
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// newAuthServer returns a test server that accepts a viewer, two operators
+// and an admin token, each named after its secret.
+func newAuthServer(t *testing.T) *Server {
+	t.Helper()
+	s, _ := newTestServer(t)
+	s.SetTokens([]Token{
+		{Name: "ci", Role: config.RoleViewer, Secret: "ci"},
+		{Name: "alice", Role: config.RoleOperator, Secret: "alice"},
+		{Name: "bob", Role: config.RoleOperator, Secret: "bob"},
+		{Name: "root", Role: config.RoleAdmin, Secret: "root"},
+	})
+	return s
+}
+
+func TestServer_Unauthorized(t *testing.T) {
+	s := newAuthServer(t)
+
+	for _, secret := range []string{"", "wrong"} {
+		if code := doAs(t, s, secret, "GET", "/agents", "", nil); code != http.StatusUnauthorized {
+			t.Errorf("GET /agents with token %q = %d, want 401", secret, code)
+		}
+		if code := doAs(t, s, secret, "POST", "/messages", `{"from":"a","to":"b","type":"question","content":"hi"}`, nil); code != http.StatusUnauthorized {
+			t.Errorf("POST /messages with token %q = %d, want 401", secret, code)
+		}
+	}
+}
+
+func TestServer_Roles(t *testing.T) {
+	s := newAuthServer(t)
+
+	if code := doAs(t, s, "ci", "GET", "/agents", "", nil); code != http.StatusOK {
+		t.Errorf("viewer GET /agents = %d, want 200", code)
+	}
+	if code := doAs(t, s, "ci", "POST", "/agents", `{"type":"claude","name":"docs"}`, nil); code != http.StatusForbidden {
+		t.Errorf("viewer POST /agents = %d, want 403", code)
+	}
+	if code := doAs(t, s, "ci", "POST", "/messages", `{"from":"human","to":"craizy-proj-claude-auth","type":"question","content":"hi"}`, nil); code != http.StatusForbidden {
+		t.Errorf("viewer POST /messages = %d, want 403", code)
+	}
+
+	var created agentJSON
+	if code := doAs(t, s, "alice", "POST", "/agents", `{"type":"claude","name":"docs"}`, &created); code != http.StatusCreated || created.Metadata[domain.MetadataCreatedBy] != "alice" {
+		t.Fatalf("operator POST /agents = %d %+v, want docs created by alice", code, created)
+	}
+	if code := doAs(t, s, "bob", "DELETE", "/agents/"+created.ID, "", nil); code != http.StatusForbidden {
+		t.Errorf("other operator DELETE = %d, want 403", code)
+	}
+	if code := doAs(t, s, "alice", "DELETE", "/agents/craizy-proj-claude-auth", "", nil); code != http.StatusForbidden {
+		t.Errorf("operator DELETE of an agent it didn't create = %d, want 403", code)
+	}
+	if code := doAs(t, s, "alice", "DELETE", "/agents/"+created.ID, "", nil); code != http.StatusNoContent {
+		t.Errorf("creator DELETE = %d, want 204", code)
+	}
+	if code := doAs(t, s, "root", "DELETE", "/agents/craizy-proj-claude-auth", "", nil); code != http.StatusNoContent {
+		t.Errorf("admin DELETE = %d, want 204", code)
+	}
+}
+
+func TestServer_ReadOnlyTokens(t *testing.T) {
+	s := newAuthServer(t)
+	s.SetReadOnly(true)
+
+	if code := doAs(t, s, "root", "DELETE", "/agents/craizy-proj-claude-auth", "", nil); code != http.StatusForbidden {
+		t.Errorf("admin DELETE in read-only mode = %d, want 403", code)
+	}
+	if code := doAs(t, s, "root", "GET", "/agents", "", nil); code != http.StatusOK {
+		t.Errorf("admin GET /agents in read-only mode = %d, want 200", code)
+	}
+}
+
+func TestServer_CreateWithoutRecordedCreator(t *testing.T) {
+	s := newAuthServer(t)
+	create := s.create
+	s.create = func(entry config.ManifestAgent, _ map[string]string) (*domain.Agent, error) {
+		return create(entry, nil)
+	}
+
+	if code := doAs(t, s, "alice", "POST", "/agents", `{"type":"claude","name":"docs"}`, nil); code != http.StatusInternalServerError {
+		t.Errorf("POST /agents without a recorded creator = %d, want 500", code)
+	}
+}
@@ -0,0 +1,382 @@
+// Package api serves crAIzy's agent and message operations over HTTP, so
+// editors, scripts and web frontends can drive agents without the TUI.
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// DefaultAddr is where craizy serve listens unless told otherwise. It is bound
+// to localhost because the API is unauthenticated until tokens are configured.
+const DefaultAddr = "127.0.0.1:7777"
+
+// DefaultOutputLines is how many lines of pane output a capture returns when
+// the request doesn't say.
+const DefaultOutputLines = 50
+
+// DefaultMessageLimit is how many messages a list returns when the request
+// doesn't say.
+const DefaultMessageLimit = 50
+
+// CreateFunc creates an agent of a type from AGENTS.yml, as a manifest entry
+// of craizy agent create would, storing metadata with it.
+type CreateFunc func(entry config.ManifestAgent, metadata map[string]string) (*domain.Agent, error)
+
+// Server handles the HTTP API. Use Handler to mount it.
+type Server struct {
+	agents   *domain.AgentService
+	messages *domain.MessageService
+	create   CreateFunc
+	readOnly bool
+	tokens   []Token
+}
+
+// NewServer creates a server for the given services, creating agents with create.
+func NewServer(agents *domain.AgentService, messages *domain.MessageService, create CreateFunc) *Server {
+	return &Server{agents: agents, messages: messages, create: create}
+}
+
+// SetReadOnly rejects requests that create, kill or send, as read-only mode
+// does in the TUI and CLI.
+func (s *Server) SetReadOnly(readOnly bool) {
+	s.readOnly = readOnly
+}
+
+// Handler returns the API's routes:
+//
+//	GET    /agents                 list active agents
+//	POST   /agents                 create an agent: {"type", "name", "base", "prompt"}
+//	GET    /agents/{id}            show an agent
+//	DELETE /agents/{id}            kill an agent; ?force=true discards uncommitted changes
+//	GET    /agents/{id}/output     capture pane output; ?lines=N
+//	GET    /messages               list messages: ?for=ID or ?from=ID, &unread=true, &before=SEQ, &limit=N
+//	POST   /messages               send a message: {"from", "to", "type", "content", "related_work", "refs"}
+//
+// With tokens set, GET routes need a viewer, POST routes an operator, and
+// DELETE an operator who created the agent or an admin.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /agents", s.require(config.RoleViewer, s.listAgents))
+	mux.HandleFunc("POST /agents", s.require(config.RoleOperator, s.createAgent))
+	mux.HandleFunc("GET /agents/{id}", s.require(config.RoleViewer, s.getAgent))
+	mux.HandleFunc("DELETE /agents/{id}", s.require(config.RoleOperator, s.killAgent))
+	mux.HandleFunc("GET /agents/{id}/output", s.require(config.RoleViewer, s.agentOutput))
+	mux.HandleFunc("GET /messages", s.require(config.RoleViewer, s.listMessages))
+	mux.HandleFunc("POST /messages", s.require(config.RoleOperator, s.sendMessage))
+	return mux
+}
+
+// agentJSON is an agent as the API returns it.
+type agentJSON struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Type       string            `json:"type"`
+	Status     string            `json:"status"`
+	Branch     string            `json:"branch"`
+	BaseBranch string            `json:"base_branch"`
+	WorkDir    string            `json:"workdir"`
+	Summary    string            `json:"summary"`
+	Metadata   map[string]string `json:"metadata"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+func toAgentJSON(agent *domain.Agent) agentJSON {
+	metadata := agent.Metadata
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	return agentJSON{
+		ID:         agent.ID,
+		Name:       agent.Name,
+		Type:       agent.AgentType,
+		Status:     string(agent.Status),
+		Branch:     agent.Branch,
+		BaseBranch: agent.BaseBranch,
+		WorkDir:    agent.WorkDir,
+		Summary:    agent.Summary,
+		Metadata:   metadata,
+		CreatedAt:  agent.CreatedAt,
+	}
+}
+
+// messageJSON is a message as the API returns it.
+type messageJSON struct {
+	ID          string     `json:"id"`
+	Code        string     `json:"code"`
+	From        string     `json:"from"`
+	To          string     `json:"to"`
+	Type        string     `json:"type"`
+	Content     string     `json:"content"`
+	RelatedWork *string    `json:"related_work"`
+	Refs        []string   `json:"refs"`
+	Read        bool       `json:"read"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeliverAt   *time.Time `json:"deliver_at"`
+}
+
+func toMessageJSON(msg *domain.Message) messageJSON {
+	refs := make([]string, len(msg.Refs))
+	for i, ref := range msg.Refs {
+		refs[i] = ref.String()
+	}
+	return messageJSON{
+		ID:          msg.ID,
+		Code:        msg.Code(),
+		From:        msg.From,
+		To:          msg.To,
+		Type:        string(msg.Type),
+		Content:     msg.Content,
+		RelatedWork: msg.RelatedWork,
+		Refs:        refs,
+		Read:        msg.Read,
+		CreatedAt:   msg.CreatedAt,
+		DeliverAt:   msg.DeliverAt,
+	}
+}
+
+func (s *Server) listAgents(w http.ResponseWriter, _ *http.Request) {
+	agents := []agentJSON{}
+	for _, agent := range s.agents.List() {
+		agents = append(agents, toAgentJSON(agent))
+	}
+	writeJSON(w, http.StatusOK, agents)
+}
+
+func (s *Server) getAgent(w http.ResponseWriter, r *http.Request) {
+	agent := s.findAgent(w, r)
+	if agent == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, toAgentJSON(agent))
+}
+
+// createRequest is the body of POST /agents.
+type createRequest struct {
+	Type   string `json:"type"`
+	Name   string `json:"name"`
+	Base   string `json:"base"`
+	Prompt string `json:"prompt"`
+}
+
+func (s *Server) createAgent(w http.ResponseWriter, r *http.Request) {
+	var req createRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.Type == "" || req.Name == "" {
+		writeError(w, http.StatusBadRequest, errors.New("type and name are required"))
+		return
+	}
+	// The creator is stored with the agent, so operators can kill their own agents
+	token := callerToken(r)
+	var metadata map[string]string
+	if token != nil {
+		metadata = map[string]string{domain.MetadataCreatedBy: token.Name}
+	}
+	agent, err := s.create(config.ManifestAgent{Type: req.Type, Name: req.Name, Base: req.Base, Prompt: req.Prompt}, metadata)
+	if err != nil {
+		logging.Error(err, "type", req.Type, "name", req.Name, "action", "api create")
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	if token != nil {
+		if stored := s.lookupAgent(agent.ID); stored == nil || stored.Metadata[domain.MetadataCreatedBy] != token.Name {
+			err := fmt.Errorf("failed to record %s as the creator of %s", token.Name, agent.ID)
+			logging.Error(err, "agentID", agent.ID, "action", "api create")
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+	}
+	writeJSON(w, http.StatusCreated, toAgentJSON(agent))
+}
+
+func (s *Server) killAgent(w http.ResponseWriter, r *http.Request) {
+	agent := s.findAgent(w, r)
+	if agent == nil {
+		return
+	}
+	if token := callerToken(r); token != nil && token.Role != config.RoleAdmin && agent.Metadata[domain.MetadataCreatedBy] != token.Name {
+		writeError(w, http.StatusForbidden, fmt.Errorf("%s didn't create %s", token.Name, agent.ID))
+		return
+	}
+	if r.URL.Query().Get("force") != "true" {
+		// Like the TUI, ask before losing work that can't be ruled out
+		if uncommitted, err := s.agents.CheckKill(agent.ID); err != nil || uncommitted {
+			writeError(w, http.StatusConflict, fmt.Errorf("%s may have uncommitted changes; retry with ?force=true to discard them", agent.ID))
+			return
+		}
+	}
+	if err := s.agents.Kill(agent.ID); err != nil {
+		logging.Error(err, "agentID", agent.ID, "action", "api kill")
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) agentOutput(w http.ResponseWriter, r *http.Request) {
+	agent := s.findAgent(w, r)
+	if agent == nil {
+		return
+	}
+	lines, ok := intParam(w, r, "lines", DefaultOutputLines)
+	if !ok {
+		return
+	}
+	output, err := s.agents.CaptureOutput(agent.ID, lines)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"id": agent.ID, "output": output})
+}
+
+func (s *Server) listMessages(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	recipient, sender := query.Get("for"), query.Get("from")
+	if (recipient == "") == (sender == "") {
+		writeError(w, http.StatusBadRequest, errors.New("exactly one of for and from is required"))
+		return
+	}
+	before, ok := intParam(w, r, "before", 0)
+	if !ok {
+		return
+	}
+	limit, ok := intParam(w, r, "limit", DefaultMessageLimit)
+	if !ok {
+		return
+	}
+
+	var messages []*domain.Message
+	var err error
+	switch {
+	case sender != "":
+		messages, err = s.messages.ListSent(s.messages.ResolveParticipant(sender), before, limit)
+	case query.Get("unread") == "true":
+		messages, err = s.messages.ListUnread(s.messages.ResolveParticipant(recipient))
+	default:
+		messages, err = s.messages.List(s.messages.ResolveParticipant(recipient), before, limit)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	out := make([]messageJSON, len(messages))
+	for i, msg := range messages {
+		out[i] = toMessageJSON(msg)
+	}
+	writeJSON(w, http.StatusOK, out)
+}
+
+// sendRequest is the body of POST /messages.
+type sendRequest struct {
+	From        string   `json:"from"`
+	To          string   `json:"to"`
+	Type        string   `json:"type"`
+	Content     string   `json:"content"`
+	RelatedWork *string  `json:"related_work"`
+	Refs        []string `json:"refs"`
+}
+
+func (s *Server) sendMessage(w http.ResponseWriter, r *http.Request) {
+	var req sendRequest
+	if !readJSON(w, r, &req) {
+		return
+	}
+	if req.From == "" || req.To == "" || req.Type == "" || req.Content == "" {
+		writeError(w, http.StatusBadRequest, errors.New("from, to, type and content are required"))
+		return
+	}
+	if !domain.IsValidMessageType(req.Type) {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid message type: %s", req.Type))
+		return
+	}
+	refs := make([]domain.FileRef, 0, len(req.Refs))
+	for _, raw := range req.Refs {
+		ref, err := domain.ParseFileRef(raw)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		refs = append(refs, ref)
+	}
+
+	msg, err := s.messages.SendWithRefs(req.From, req.To, domain.MessageType(req.Type), req.Content, req.RelatedWork, refs)
+	if err != nil {
+		logging.Error(err, "from", req.From, "to", req.To, "action", "api send")
+		writeError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toMessageJSON(msg))
+}
+
+// findAgent returns the agent named by the request path, writing a 404 if
+// there is no such active agent.
+func (s *Server) findAgent(w http.ResponseWriter, r *http.Request) *domain.Agent {
+	id := r.PathValue("id")
+	if agent := s.lookupAgent(id); agent != nil {
+		return agent
+	}
+	writeError(w, http.StatusNotFound, fmt.Errorf("agent not found: %s", id))
+	return nil
+}
+
+// lookupAgent returns the stored agent with id, or nil.
+func (s *Server) lookupAgent(id string) *domain.Agent {
+	for _, agent := range s.agents.List() {
+		if agent.ID == id {
+			return agent
+		}
+	}
+	return nil
+}
+
+// intParam reads a non-negative integer query parameter, writing a 400 if it
+// is malformed.
+func intParam(w http.ResponseWriter, r *http.Request, name string, fallback int) (int, bool) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return fallback, true
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid %s: %q", name, value))
+		return 0, false
+	}
+	return n, true
+}
+
+// readJSON decodes the request body into v, writing a 400 if it isn't valid JSON.
+func readJSON(w http.ResponseWriter, r *http.Request, v any) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return false
+	}
+	return true
+}
+
+// writeJSON writes v as the JSON response body with the given status.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logging.Error(err, "action", "api write response")
+	}
+}
+
+// writeError writes err as a JSON error body with the given status.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
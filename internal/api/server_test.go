@@ -0,0 +1,140 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/infra"
+)
+
+// newTestServer returns a server backed by in-memory stores and the fake
+// session backend, with one running agent named "auth".
+func newTestServer(t *testing.T) (*Server, *infra.FakeTmuxClient) {
+	t.Helper()
+	tmux := infra.NewFakeTmuxClient()
+	tmux.SetScript(func(string) []string { return nil })
+	store := infra.NewMemoryAgentStore()
+	dispatcher := infra.NewEventDispatcher()
+	infra.WireAdapters(dispatcher, store, tmux, nil)
+
+	agents := domain.NewAgentService(tmux, store, dispatcher, nil, "proj", t.TempDir())
+	agents.SetEphemeral(true)
+	messages := domain.NewMessageService(infra.NewMemoryMessageStore(), tmux, store)
+	agents.SetMessageService(messages)
+
+	create := func(entry config.ManifestAgent, metadata map[string]string) (*domain.Agent, error) {
+		if entry.Type != "claude" {
+			return nil, errors.New("unknown agent type " + entry.Type)
+		}
+		return agents.CreateWithOptions(entry.Type, entry.Name, "claude", domain.CreateOptions{Metadata: metadata})
+	}
+	if _, err := create(config.ManifestAgent{Type: "claude", Name: "auth"}, nil); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	return NewServer(agents, messages, create), tmux
+}
+
+// do sends a request to the server and decodes the JSON response into out, if given.
+func do(t *testing.T, s *Server, method, path, body string, out any) int {
+	t.Helper()
+	return doAs(t, s, "", method, path, body, out)
+}
+
+// doAs is do with secret sent as the bearer token, unless it is empty.
+func doAs(t *testing.T, s *Server, secret, method, path, body string, out any) int {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if secret != "" {
+		req.Header.Set("Authorization", "Bearer "+secret)
+	}
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+	if out != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+			t.Fatalf("%s %s: invalid JSON %q: %v", method, path, rec.Body.String(), err)
+		}
+	}
+	return rec.Code
+}
+
+func TestServer_Agents(t *testing.T) {
+	s, tmux := newTestServer(t)
+	const id = "craizy-proj-claude-auth"
+
+	var agents []agentJSON
+	if code := do(t, s, "GET", "/agents", "", &agents); code != http.StatusOK || len(agents) != 1 || agents[0].ID != id {
+		t.Fatalf("GET /agents = %d %+v, want auth", code, agents)
+	}
+
+	var created agentJSON
+	if code := do(t, s, "POST", "/agents", `{"type":"claude","name":"docs"}`, &created); code != http.StatusCreated || created.Name != "docs" {
+		t.Errorf("POST /agents = %d %+v, want docs created", code, created)
+	}
+	if code := do(t, s, "POST", "/agents", `{"type":"nope","name":"x"}`, nil); code != http.StatusUnprocessableEntity {
+		t.Errorf("POST /agents with an unknown type = %d, want 422", code)
+	}
+	if code := do(t, s, "POST", "/agents", `{"name":"x"}`, nil); code != http.StatusBadRequest {
+		t.Errorf("POST /agents without a type = %d, want 400", code)
+	}
+
+	_ = tmux.SendKeys(id, "All tests pass")
+	var output map[string]string
+	if code := do(t, s, "GET", "/agents/"+id+"/output?lines=10", "", &output); code != http.StatusOK || !strings.Contains(output["output"], "All tests pass") {
+		t.Errorf("GET output = %d %q, want the pane output", code, output["output"])
+	}
+	if code := do(t, s, "GET", "/agents/"+id+"/output?lines=x", "", nil); code != http.StatusBadRequest {
+		t.Errorf("GET output with bad lines = %d, want 400", code)
+	}
+
+	if code := do(t, s, "DELETE", "/agents/"+id, "", nil); code != http.StatusNoContent {
+		t.Errorf("DELETE = %d, want 204", code)
+	}
+	if code := do(t, s, "GET", "/agents/"+id, "", nil); code != http.StatusNotFound {
+		t.Errorf("GET killed agent = %d, want 404", code)
+	}
+}
+
+func TestServer_Messages(t *testing.T) {
+	s, _ := newTestServer(t)
+
+	var sent messageJSON
+	body := `{"from":"craizy-proj-claude-auth","to":"human","type":"question","content":"Which library?","refs":["go.mod:3"]}`
+	if code := do(t, s, "POST", "/messages", body, &sent); code != http.StatusCreated || sent.Code == "" || len(sent.Refs) != 1 {
+		t.Fatalf("POST /messages = %d %+v, want the message sent", code, sent)
+	}
+	if code := do(t, s, "POST", "/messages", `{"from":"a","to":"b","type":"shout","content":"hi"}`, nil); code != http.StatusBadRequest {
+		t.Errorf("POST /messages with a bad type = %d, want 400", code)
+	}
+
+	var messages []messageJSON
+	if code := do(t, s, "GET", "/messages?for=human&unread=true", "", &messages); code != http.StatusOK || len(messages) != 1 || messages[0].ID != sent.ID {
+		t.Errorf("GET /messages?for=human = %d %+v, want the question", code, messages)
+	}
+	if code := do(t, s, "GET", "/messages?from=craizy-proj-claude-auth", "", &messages); code != http.StatusOK || len(messages) != 1 {
+		t.Errorf("GET /messages?from = %d %+v, want the question", code, messages)
+	}
+	if code := do(t, s, "GET", "/messages", "", nil); code != http.StatusBadRequest {
+		t.Errorf("GET /messages without for or from = %d, want 400", code)
+	}
+}
+
+func TestServer_ReadOnly(t *testing.T) {
+	s, _ := newTestServer(t)
+	s.SetReadOnly(true)
+
+	if code := do(t, s, "POST", "/agents", `{"type":"claude","name":"docs"}`, nil); code != http.StatusForbidden {
+		t.Errorf("POST /agents = %d, want 403", code)
+	}
+	if code := do(t, s, "DELETE", "/agents/craizy-proj-claude-auth", "", nil); code != http.StatusForbidden {
+		t.Errorf("DELETE = %d, want 403", code)
+	}
+	if code := do(t, s, "GET", "/agents", "", nil); code != http.StatusOK {
+		t.Errorf("GET /agents = %d, want 200", code)
+	}
+}
@@ -1,7 +1,12 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -9,6 +14,130 @@ import (
 type Agent struct {
 	Name    string `yaml:"name"`
 	Command string `yaml:"command"`
+
+	// SparsePaths limits new worktrees for this agent to the listed directories.
+	SparsePaths []string `yaml:"sparse_paths,omitempty"`
+
+	// Prompt is sent to the agent once it starts. Placeholders such as {name},
+	// {branch}, {stack}, {go_module} and {python_version} are filled in from the
+	// agent and its worktree.
+	Prompt string `yaml:"prompt,omitempty"`
+
+	// Color marks this agent type in the agent list, preview border and tmux
+	// status bar: a hex color such as "#D08770" or an ANSI 256 color number.
+	Color string `yaml:"color,omitempty"`
+
+	// Env sets environment variables for this agent's sessions, such as API keys
+	// or a model name, without editing Command.
+	Env map[string]string `yaml:"env,omitempty"`
+
+	// WorkDir starts this agent's sessions in a directory relative to its
+	// worktree, such as "services/api", rather than the worktree root.
+	WorkDir string `yaml:"workdir,omitempty"`
+
+	// Delivery chooses how messages reach this agent's sessions. By default
+	// they're typed into the session, which some agent CLIs handle badly.
+	Delivery Delivery `yaml:"delivery,omitempty"`
+
+	// Restart set to "on-failure" relaunches this agent's sessions when they die
+	// while the agent is active, up to MaxRestarts times per agent.
+	Restart string `yaml:"restart,omitempty"`
+
+	// MaxRestarts limits automatic restarts. Defaults to DefaultMaxRestarts.
+	MaxRestarts int `yaml:"max_restarts,omitempty"`
+
+	// CaptureMessages sends fenced craizy: blocks this agent prints, such as a
+	// status update or question, as messages from it, for agent CLIs that can't
+	// run craizy msg send.
+	CaptureMessages bool `yaml:"capture_messages,omitempty"`
+}
+
+// Restart policies.
+const (
+	RestartNo        = "no"
+	RestartOnFailure = "on-failure"
+)
+
+// DefaultMaxRestarts is how many times an agent is restarted when its type
+// doesn't set max_restarts.
+const DefaultMaxRestarts = 3
+
+// RestartLimit returns how many times an agent of this type may be restarted
+// automatically, 0 unless its restart policy is on-failure.
+func (a Agent) RestartLimit() int {
+	if a.Restart != RestartOnFailure {
+		return 0
+	}
+	if a.MaxRestarts == 0 {
+		return DefaultMaxRestarts
+	}
+	return a.MaxRestarts
+}
+
+// Delivery transports.
+const (
+	DeliveryTmux    = "tmux"    // type messages into the session (default)
+	DeliveryFile    = "file"    // append messages to an inbox file and nudge the agent to read it
+	DeliveryWebhook = "webhook" // POST messages to a URL as JSON
+)
+
+// Delivery modes.
+const (
+	DeliveryContent = "content"
+	DeliveryNudge   = "nudge"
+)
+
+// DefaultInboxPath is where the file transport writes, relative to the worktree.
+// {id} is replaced with the agent's session ID.
+const DefaultInboxPath = ".craizy/inbox/{id}.md"
+
+// Delivery configures an agent type's message transport, e.g.
+//
+//	delivery:
+//	  transport: file
+//	  path: .craizy/inbox/{id}.md
+type Delivery struct {
+	Transport string `yaml:"transport,omitempty"`
+
+	// Mode is what's delivered: "content", the whole message (the default), or
+	// "nudge", a one-line notice to read unread messages with craizy msg.
+	Mode string `yaml:"mode,omitempty"`
+
+	// Path is the inbox file for the file transport, relative to the worktree,
+	// where {id} is replaced with the agent's session ID. Defaults to
+	// DefaultInboxPath.
+	Path string `yaml:"path,omitempty"`
+
+	// URL receives each message for the webhook transport.
+	URL string `yaml:"url,omitempty"`
+}
+
+// InboxPath returns the file transport's inbox path.
+func (d Delivery) InboxPath() string {
+	if d.Path == "" {
+		return DefaultInboxPath
+	}
+	return d.Path
+}
+
+func (d Delivery) validate() error {
+	if d.Mode != "" && d.Mode != DeliveryContent && d.Mode != DeliveryNudge {
+		return fmt.Errorf("unknown delivery mode %q, want %s or %s", d.Mode, DeliveryContent, DeliveryNudge)
+	}
+	switch d.Transport {
+	case "", DeliveryTmux:
+	case DeliveryFile:
+		if !filepath.IsLocal(d.InboxPath()) {
+			return fmt.Errorf("delivery path %q must be a path inside the worktree", d.Path)
+		}
+	case DeliveryWebhook:
+		if !strings.HasPrefix(d.URL, "http://") && !strings.HasPrefix(d.URL, "https://") {
+			return fmt.Errorf("delivery url %q must be an http or https URL", d.URL)
+		}
+	default:
+		return fmt.Errorf("unknown delivery transport %q, want %s, %s or %s", d.Transport, DeliveryTmux, DeliveryFile, DeliveryWebhook)
+	}
+	return nil
 }
 
 type AgentsConfig struct {
@@ -27,5 +156,55 @@ func LoadAgents(path string) ([]Agent, error) {
 		return nil, err
 	}
 
+	for _, agent := range config.Agents {
+		if agent.Color != "" && !ValidColor(agent.Color) {
+			return nil, fmt.Errorf("agent %q: invalid color %q, want a hex color like \"#D08770\" or a number from 0 to 255", agent.Name, agent.Color)
+		}
+		for name := range agent.Env {
+			if !envName.MatchString(name) {
+				return nil, fmt.Errorf("agent %q: invalid env variable name %q", agent.Name, name)
+			}
+		}
+		if agent.WorkDir != "" && !filepath.IsLocal(agent.WorkDir) {
+			return nil, fmt.Errorf("agent %q: workdir %q must be a path inside the worktree", agent.Name, agent.WorkDir)
+		}
+		if agent.Restart != "" && agent.Restart != RestartNo && agent.Restart != RestartOnFailure {
+			return nil, fmt.Errorf("agent %q: unknown restart policy %q, want %s or %s", agent.Name, agent.Restart, RestartNo, RestartOnFailure)
+		}
+		if agent.MaxRestarts < 0 {
+			return nil, fmt.Errorf("agent %q: max_restarts must not be negative", agent.Name)
+		}
+		if err := agent.Delivery.validate(); err != nil {
+			return nil, fmt.Errorf("agent %q: %w", agent.Name, err)
+		}
+	}
+
 	return config.Agents, nil
 }
+
+// envName matches environment variable names.
+var envName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// hexColor matches #rgb and #rrggbb colors.
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// ValidColor reports whether color is a hex color or an ANSI 256 color number,
+// the forms both lipgloss and tmux understand.
+func ValidColor(color string) bool {
+	if hexColor.MatchString(color) {
+		return true
+	}
+	n, err := strconv.Atoi(color)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// AgentColors maps the lowercased names of agent types with a color to it.
+func AgentColors(agents []Agent) map[string]string {
+	colors := make(map[string]string)
+	for _, agent := range agents {
+		if agent.Color != "" {
+			colors[strings.ToLower(agent.Name)] = agent.Color
+		}
+	}
+	return colors
+}
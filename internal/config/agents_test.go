@@ -0,0 +1,139 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeAgents writes an AGENTS.yml with content and returns its path.
+func writeAgents(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "AGENTS.yml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write agents: %v", err)
+	}
+	return path
+}
+
+func TestLoadAgents_Color(t *testing.T) {
+	t.Run("reads colors", func(t *testing.T) {
+		agents, err := LoadAgents(writeAgents(t, "agents:\n  - name: Claude\n    command: claude\n    color: \"#D08770\"\n  - name: Aider\n    command: aider\n    color: \"208\"\n  - name: Plain\n    command: sh\n"))
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		colors := AgentColors(agents)
+		if len(colors) != 2 || colors["claude"] != "#D08770" || colors["aider"] != "208" {
+			t.Errorf("AgentColors = %v, want claude and aider colored", colors)
+		}
+	})
+
+	t.Run("rejects invalid colors", func(t *testing.T) {
+		_, err := LoadAgents(writeAgents(t, "agents:\n  - name: Claude\n    command: claude\n    color: orange\n"))
+
+		if err == nil {
+			t.Error("expected an error for a named color")
+		}
+	})
+}
+
+func TestLoadAgents_EnvAndWorkDir(t *testing.T) {
+	t.Run("reads env and workdir", func(t *testing.T) {
+		agents, err := LoadAgents(writeAgents(t, "agents:\n  - name: Claude\n    command: claude\n    workdir: services/api\n    env:\n      ANTHROPIC_MODEL: opus\n"))
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if agents[0].WorkDir != "services/api" || agents[0].Env["ANTHROPIC_MODEL"] != "opus" {
+			t.Errorf("agent = %+v, want workdir and env read", agents[0])
+		}
+	})
+
+	for name, content := range map[string]string{
+		"bad env name":     "agents:\n  - name: Claude\n    command: claude\n    env:\n      BAD-NAME: x\n",
+		"workdir outside":  "agents:\n  - name: Claude\n    command: claude\n    workdir: ../other\n",
+		"absolute workdir": "agents:\n  - name: Claude\n    command: claude\n    workdir: /tmp\n",
+	} {
+		t.Run("rejects "+name, func(t *testing.T) {
+			if _, err := LoadAgents(writeAgents(t, content)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoadAgents_Delivery(t *testing.T) {
+	t.Run("reads delivery", func(t *testing.T) {
+		agents, err := LoadAgents(writeAgents(t, "agents:\n  - name: Claude\n    command: claude\n    delivery:\n      mode: nudge\n  - name: Aider\n    command: aider\n    delivery:\n      transport: file\n"))
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if agents[0].Delivery.Transport != "" || agents[0].Delivery.Mode != DeliveryNudge {
+			t.Errorf("Claude delivery = %+v, want nudges with the default transport", agents[0].Delivery)
+		}
+		if agents[1].Delivery.Transport != DeliveryFile || agents[1].Delivery.InboxPath() != DefaultInboxPath {
+			t.Errorf("Aider delivery = %+v, want file to the default inbox", agents[1].Delivery)
+		}
+	})
+
+	for name, content := range map[string]string{
+		"unknown transport": "agents:\n  - name: Claude\n    command: claude\n    delivery:\n      transport: pigeon\n",
+		"path outside":      "agents:\n  - name: Claude\n    command: claude\n    delivery:\n      transport: file\n      path: ../inbox.md\n",
+		"webhook no url":    "agents:\n  - name: Claude\n    command: claude\n    delivery:\n      transport: webhook\n",
+		"unknown mode":      "agents:\n  - name: Claude\n    command: claude\n    delivery:\n      mode: summary\n",
+	} {
+		t.Run("rejects "+name, func(t *testing.T) {
+			if _, err := LoadAgents(writeAgents(t, content)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestLoadAgents_Restart(t *testing.T) {
+	agents, err := LoadAgents(writeAgents(t, "agents:\n  - name: Claude\n    command: claude\n    restart: on-failure\n  - name: Aider\n    command: aider\n    restart: on-failure\n    max_restarts: 5\n  - name: Plain\n    command: sh\n"))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, want := range []int{DefaultMaxRestarts, 5, 0} {
+		if got := agents[i].RestartLimit(); got != want {
+			t.Errorf("%s RestartLimit() = %d, want %d", agents[i].Name, got, want)
+		}
+	}
+
+	for name, content := range map[string]string{
+		"unknown policy":        "agents:\n  - name: Claude\n    command: claude\n    restart: always\n",
+		"negative max_restarts": "agents:\n  - name: Claude\n    command: claude\n    restart: on-failure\n    max_restarts: -1\n",
+	} {
+		t.Run("rejects "+name, func(t *testing.T) {
+			if _, err := LoadAgents(writeAgents(t, content)); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestValidColor(t *testing.T) {
+	tests := []struct {
+		color string
+		want  bool
+	}{
+		{"#D08770", true},
+		{"#fa0", true},
+		{"0", true},
+		{"255", true},
+		{"256", false},
+		{"-1", false},
+		{"#D0877", false},
+		{"orange", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := ValidColor(tt.color); got != tt.want {
+			t.Errorf("ValidColor(%q) = %v, want %v", tt.color, got, tt.want)
+		}
+	}
+}
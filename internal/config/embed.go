@@ -21,6 +21,17 @@ func AgentsPath(workDir string) string {
 	return filepath.Join(workDir, CraizyDir, AgentsFileName)
 }
 
+// EventLogPath returns the path to the persisted NDJSON event log for a given work directory.
+func EventLogPath(workDir string) string {
+	return filepath.Join(workDir, CraizyDir, "events.log")
+}
+
+// ExitDirPath returns the directory agent sessions record their command's exit
+// status in for a given work directory.
+func ExitDirPath(workDir string) string {
+	return filepath.Join(workDir, CraizyDir, "exit")
+}
+
 // CraizyDirPath returns the path to the .craizy directory for a given work directory.
 func CraizyDirPath(workDir string) string {
 	return filepath.Join(workDir, CraizyDir)
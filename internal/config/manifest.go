@@ -0,0 +1,135 @@
+package config
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestAgent is one agent to create from a manifest.
+type ManifestAgent struct {
+	// Type is an agent name from AGENTS.yml.
+	Type string `yaml:"type"`
+
+	// Name is the session name, as typed in the create wizard.
+	Name string `yaml:"name"`
+
+	// Base is the branch to start from. Defaults to the current branch.
+	Base string `yaml:"base"`
+
+	// Prompt is the startup prompt. It may instead name a prompt in the
+	// library as "@name". Defaults to the agent type's prompt from AGENTS.yml.
+	Prompt string `yaml:"prompt"`
+}
+
+// Manifest lists agents to create in one batch, e.g.
+//
+//	agents:
+//	  - type: Claude
+//	    name: auth
+//	    base: main
+//	    prompt: "@review"
+//
+// A CSV manifest has a header row naming the same columns: type,name,base,prompt.
+type Manifest struct {
+	Agents []ManifestAgent `yaml:"agents"`
+}
+
+// manifestColumns are the CSV columns of a manifest; type and name are required.
+var manifestColumns = []string{"type", "name", "base", "prompt"}
+
+// LoadManifest reads and validates a YAML or, for .csv files, CSV manifest.
+func LoadManifest(path string) (*Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var manifest *Manifest
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		manifest, err = parseCSVManifest(f)
+	} else {
+		manifest = &Manifest{}
+		err = yaml.NewDecoder(f).Decode(manifest)
+		if errors.Is(err, io.EOF) {
+			err = nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if err := manifest.validate(); err != nil {
+		return nil, fmt.Errorf("manifest %s: %w", path, err)
+	}
+	return manifest, nil
+}
+
+// parseCSVManifest reads a CSV manifest with a header row.
+func parseCSVManifest(r io.Reader) (*Manifest, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return &Manifest{}, nil
+	}
+
+	columns := make(map[string]int)
+	for i, header := range rows[0] {
+		name := strings.ToLower(strings.TrimSpace(header))
+		known := false
+		for _, c := range manifestColumns {
+			known = known || c == name
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown column %q (want %s)", header, strings.Join(manifestColumns, ", "))
+		}
+		columns[name] = i
+	}
+
+	field := func(row []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(row) {
+			return strings.TrimSpace(row[i])
+		}
+		return ""
+	}
+	manifest := &Manifest{}
+	for _, row := range rows[1:] {
+		manifest.Agents = append(manifest.Agents, ManifestAgent{
+			Type:   field(row, "type"),
+			Name:   field(row, "name"),
+			Base:   field(row, "base"),
+			Prompt: field(row, "prompt"),
+		})
+	}
+	return manifest, nil
+}
+
+// validate checks that every agent has a type and a unique name.
+func (m *Manifest) validate() error {
+	if len(m.Agents) == 0 {
+		return fmt.Errorf("no agents listed")
+	}
+	seen := make(map[string]bool)
+	for i, agent := range m.Agents {
+		if agent.Type == "" || agent.Name == "" {
+			return fmt.Errorf("agent %d needs a type and a name", i+1)
+		}
+		if seen[agent.Name] {
+			return fmt.Errorf("agent name %q is listed twice", agent.Name)
+		}
+		seen[agent.Name] = true
+	}
+	return nil
+}
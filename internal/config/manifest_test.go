@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadManifest(t *testing.T) {
+	write := func(t *testing.T, name, data string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), name)
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatalf("failed to write manifest: %v", err)
+		}
+		return path
+	}
+
+	t.Run("reads yaml", func(t *testing.T) {
+		path := write(t, "team.yaml", "agents:\n  - type: Claude\n    name: auth\n    base: main\n    prompt: \"@review\"\n  - type: Gemini\n    name: docs\n")
+
+		manifest, err := LoadManifest(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := ManifestAgent{Type: "Claude", Name: "auth", Base: "main", Prompt: "@review"}
+		if len(manifest.Agents) != 2 || manifest.Agents[0] != want || manifest.Agents[1].Name != "docs" {
+			t.Errorf("Agents = %+v", manifest.Agents)
+		}
+	})
+
+	t.Run("reads csv with columns in any order", func(t *testing.T) {
+		path := write(t, "team.csv", "name, Type, prompt\nauth, Claude, \"Fix the login, then stop\"\ndocs, Gemini\n")
+
+		manifest, err := LoadManifest(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := ManifestAgent{Type: "Claude", Name: "auth", Prompt: "Fix the login, then stop"}
+		if len(manifest.Agents) != 2 || manifest.Agents[0] != want || manifest.Agents[1].Type != "Gemini" {
+			t.Errorf("Agents = %+v", manifest.Agents)
+		}
+	})
+
+	t.Run("invalid manifests return error", func(t *testing.T) {
+		for name, data := range map[string]string{
+			"empty.yaml":    "",
+			"noname.yaml":   "agents:\n  - type: Claude\n",
+			"dupe.yaml":     "agents:\n  - type: Claude\n    name: a\n  - type: Gemini\n    name: a\n",
+			"header.csv":    "type,name\n",
+			"badcolumn.csv": "type,name,model\nClaude,a,opus\n",
+			"notyaml.yaml":  "agents: [",
+		} {
+			if _, err := LoadManifest(write(t, name, data)); err == nil {
+				t.Errorf("expected error for %s", name)
+			}
+		}
+	})
+}
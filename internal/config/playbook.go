@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Playbook is a scripted sequence of prompts for one agent, e.g.
+//
+//	name: feature
+//	steps:
+//	  - send: Implement the feature described in TODO.md
+//	    wait:
+//	      completion: true
+//	      timeout: 1h
+//	  - send: Run the tests and fix any failures
+//	    wait:
+//	      match: "(PASS|FAIL)"
+//	      idle: 5m
+type Playbook struct {
+	Name  string         `yaml:"name"`
+	Steps []PlaybookStep `yaml:"steps"`
+}
+
+// PlaybookStep sends a prompt and then waits before the next step.
+type PlaybookStep struct {
+	// Send is the prompt to send. Placeholders like {name} and {branch} are
+	// filled in as in startup prompts.
+	Send string `yaml:"send"`
+
+	Wait PlaybookWait `yaml:"wait"`
+}
+
+// PlaybookWait lists alternative conditions ending a step; the first one met wins.
+type PlaybookWait struct {
+	// Completion waits for the agent to send a completion message.
+	Completion bool `yaml:"completion"`
+
+	// Idle waits until the agent's pane has been quiet this long, e.g. "2m".
+	Idle time.Duration `yaml:"idle"`
+
+	// Match waits for new pane output matching this regular expression.
+	Match string `yaml:"match"`
+
+	// Timeout fails the playbook if no condition is met in time. Empty waits forever.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// LoadPlaybook reads and validates a playbook file.
+func LoadPlaybook(path string) (*Playbook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var playbook Playbook
+	if err := yaml.Unmarshal(data, &playbook); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(playbook.Steps) == 0 {
+		return nil, fmt.Errorf("playbook %s has no steps", path)
+	}
+	for i, step := range playbook.Steps {
+		if err := step.validate(); err != nil {
+			return nil, fmt.Errorf("playbook %s step %d: %w", path, i+1, err)
+		}
+	}
+	return &playbook, nil
+}
+
+// validate checks that a step does something and its wait is well formed.
+func (s PlaybookStep) validate() error {
+	w := s.Wait
+	hasCondition := w.Completion || w.Idle > 0 || w.Match != ""
+	if s.Send == "" && !hasCondition {
+		return fmt.Errorf("step needs send or a wait condition")
+	}
+	if w.Timeout > 0 && !hasCondition {
+		return fmt.Errorf("wait.timeout needs completion, idle or match")
+	}
+	if w.Idle < 0 || w.Timeout < 0 {
+		return fmt.Errorf("wait durations must not be negative")
+	}
+	if w.Match != "" {
+		if _, err := regexp.Compile(w.Match); err != nil {
+			return fmt.Errorf("invalid wait.match: %w", err)
+		}
+	}
+	return nil
+}
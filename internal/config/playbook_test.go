@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadPlaybook(t *testing.T) {
+	write := func(t *testing.T, data string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "playbook.yaml")
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatalf("failed to write playbook: %v", err)
+		}
+		return path
+	}
+
+	t.Run("reads steps and durations", func(t *testing.T) {
+		path := write(t, "name: feature\nsteps:\n  - send: Implement it\n    wait:\n      completion: true\n      timeout: 1h\n  - send: Run the tests\n    wait:\n      match: \"(PASS|FAIL)\"\n      idle: 5m\n")
+
+		playbook, err := LoadPlaybook(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if playbook.Name != "feature" || len(playbook.Steps) != 2 {
+			t.Fatalf("playbook = %+v", playbook)
+		}
+		if w := playbook.Steps[0].Wait; !w.Completion || w.Timeout != time.Hour {
+			t.Errorf("step 1 wait = %+v", w)
+		}
+		if w := playbook.Steps[1].Wait; w.Match != "(PASS|FAIL)" || w.Idle != 5*time.Minute {
+			t.Errorf("step 2 wait = %+v", w)
+		}
+	})
+
+	t.Run("invalid playbooks return error", func(t *testing.T) {
+		for _, data := range []string{
+			"name: empty\n",
+			"steps:\n  - wait:\n      timeout: 5m\n",
+			"steps:\n  - send: hi\n    wait:\n      match: \"(\"\n",
+			"steps:\n  - send: hi\n    wait:\n      idle: soon\n",
+		} {
+			if _, err := LoadPlaybook(write(t, data)); err == nil {
+				t.Errorf("expected error for %q", data)
+			}
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		if _, err := LoadPlaybook(filepath.Join(t.TempDir(), "missing.yaml")); err == nil || !strings.Contains(err.Error(), "no such file") {
+			t.Errorf("got %v", err)
+		}
+	})
+}
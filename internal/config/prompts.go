@@ -0,0 +1,126 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PromptsDirName is the directory under .craizy holding the prompt library.
+const PromptsDirName = "prompts"
+
+// promptExt is the file extension of library prompts.
+const promptExt = ".md"
+
+// Prompt is a reusable startup prompt from the prompt library. The body may use
+// {var} placeholders, which are filled in when the prompt is sent to an agent.
+type Prompt struct {
+	Name string // file name without the .md extension
+	Path string
+	Body string
+}
+
+// Title returns the first non-blank line of the prompt without any leading
+// markdown heading marks, for listing prompts.
+func (p Prompt) Title() string {
+	for _, line := range strings.Split(p.Body, "\n") {
+		if line = strings.TrimSpace(strings.TrimLeft(line, "# ")); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// PromptsDirPath returns the path to the prompt library for a given work directory.
+func PromptsDirPath(workDir string) string {
+	return filepath.Join(workDir, CraizyDir, PromptsDirName)
+}
+
+// LoadPrompts reads every prompt in dir, sorted by name. A missing directory
+// is an empty library.
+func LoadPrompts(dir string) ([]Prompt, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+promptExt))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	prompts := make([]Prompt, 0, len(paths))
+	for _, path := range paths {
+		prompt, err := readPrompt(path)
+		if err != nil {
+			return nil, err
+		}
+		prompts = append(prompts, prompt)
+	}
+	return prompts, nil
+}
+
+// LoadPrompt reads the prompt called name from dir.
+func LoadPrompt(dir, name string) (Prompt, error) {
+	if err := validatePromptName(name); err != nil {
+		return Prompt{}, err
+	}
+	prompt, err := readPrompt(filepath.Join(dir, name+promptExt))
+	if errors.Is(err, os.ErrNotExist) {
+		return Prompt{}, fmt.Errorf("prompt %q not found", name)
+	}
+	return prompt, err
+}
+
+// NewPrompt creates the prompt file for name in dir with starter content and
+// returns its path. It fails if the prompt already exists.
+func NewPrompt(dir, name string) (string, error) {
+	if err := validatePromptName(name); err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	path := filepath.Join(dir, name+promptExt)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return "", fmt.Errorf("prompt %q already exists", name)
+		}
+		return "", fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(newPromptTemplate); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return path, f.Close()
+}
+
+// newPromptTemplate is the starter content of prompts created by NewPrompt.
+const newPromptTemplate = `# Describe what this prompt asks for
+
+You are {name}, working on branch {branch} (based on {base_branch}).
+The project uses {stack}.
+`
+
+// readPrompt reads the prompt file at path.
+func readPrompt(path string) (Prompt, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Prompt{}, err
+	}
+	return Prompt{
+		Name: strings.TrimSuffix(filepath.Base(path), promptExt),
+		Path: path,
+		Body: string(data),
+	}, nil
+}
+
+// validatePromptName rejects names that aren't a plain file name.
+func validatePromptName(name string) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid prompt name %q", name)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPrompts(t *testing.T) {
+	t.Run("missing directory is an empty library", func(t *testing.T) {
+		prompts, err := LoadPrompts(filepath.Join(t.TempDir(), PromptsDirName))
+
+		if err != nil || len(prompts) != 0 {
+			t.Errorf("LoadPrompts = %v, %v", prompts, err)
+		}
+	})
+
+	t.Run("creates, lists and loads prompts", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), PromptsDirName)
+		for _, name := range []string{"review", "bugfix"} {
+			if _, err := NewPrompt(dir, name); err != nil {
+				t.Fatalf("NewPrompt(%q): %v", name, err)
+			}
+		}
+		if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		prompts, err := LoadPrompts(dir)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(prompts) != 2 || prompts[0].Name != "bugfix" || prompts[1].Name != "review" {
+			t.Fatalf("LoadPrompts = %+v", prompts)
+		}
+		if prompts[0].Title() != "Describe what this prompt asks for" {
+			t.Errorf("Title = %q", prompts[0].Title())
+		}
+
+		prompt, err := LoadPrompt(dir, "review")
+		if err != nil || !strings.Contains(prompt.Body, "{branch}") {
+			t.Errorf("LoadPrompt = %+v, %v", prompt, err)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		dir := t.TempDir()
+		if _, err := NewPrompt(dir, "review"); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := NewPrompt(dir, "review"); err == nil || !strings.Contains(err.Error(), "already exists") {
+			t.Errorf("NewPrompt existing = %v", err)
+		}
+		if _, err := LoadPrompt(dir, "missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("LoadPrompt missing = %v", err)
+		}
+		for _, name := range []string{"", "../escape", ".hidden"} {
+			if _, err := NewPrompt(dir, name); err == nil {
+				t.Errorf("NewPrompt(%q) should fail", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,422 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SettingsFileName is the name of the optional project settings file.
+const SettingsFileName = "settings.yml"
+
+// Settings holds project-level crAIzy settings from .craizy/settings.yml.
+type Settings struct {
+	Git GitSettings `yaml:"git"`
+
+	// WarmPool maps an agent name from AGENTS.yml to how many idle sessions to keep
+	// pre-created, so new agents of that type start instantly.
+	WarmPool map[string]int `yaml:"warm_pool"`
+
+	// OpenCommand opens an agent worktree, e.g. "code {path}". {path} is replaced
+	// with the worktree path, which is appended if the placeholder is missing.
+	// When opening a file a message refers to, {path} is the file and {line} its
+	// line, e.g. "code -g {path}:{line}". Defaults to $VISUAL or $EDITOR.
+	OpenCommand string `yaml:"open_command"`
+
+	// AttentionIdleMinutes is how long an agent can go without output before the
+	// attention sort flags it as idle. Defaults to 10.
+	AttentionIdleMinutes int `yaml:"attention_idle_minutes"`
+
+	Reminder ReminderSettings `yaml:"reminder"`
+
+	Summarizer SummarizerSettings `yaml:"summarizer"`
+
+	Budget BudgetSettings `yaml:"budget"`
+
+	Disk DiskSettings `yaml:"disk"`
+
+	Backup BackupSettings `yaml:"backup"`
+
+	Messages MessageSettings `yaml:"messages"`
+
+	// Caches share dependency and build caches from the project root with each new
+	// agent worktree, applied in order after the worktree is checked out.
+	Caches []CacheSettings `yaml:"caches"`
+
+	Environment EnvironmentSettings `yaml:"environment"`
+
+	// Providers maps a provider name to agents that share its rate limits, e.g.
+	// two Claude agents on one API key. Agent starts over its limits are queued.
+	Providers map[string]ProviderSettings `yaml:"providers"`
+
+	Glyphs GlyphSettings `yaml:"glyphs"`
+
+	Merge MergeSettings `yaml:"merge"`
+
+	Server ServerSettings `yaml:"server"`
+}
+
+// API roles for ServerToken.Role, from least to most privileged.
+const (
+	RoleViewer   = "viewer"   // read agents, output and messages
+	RoleOperator = "operator" // also create agents, send messages and kill its own agents
+	RoleAdmin    = "admin"    // also kill any agent
+)
+
+// ServerSettings configures `craizy serve`.
+type ServerSettings struct {
+	// Tokens authenticate API clients, e.g.
+	//
+	//	server:
+	//	  tokens:
+	//	    - name: alice
+	//	      role: admin
+	//	      env: CRAIZY_TOKEN_ALICE
+	//
+	// With no tokens the API is unauthenticated and only binds to loopback.
+	Tokens []ServerToken `yaml:"tokens"`
+}
+
+// ServerToken is one API client. The secret itself is read from the Env
+// environment variable so it never lands in settings.yml.
+type ServerToken struct {
+	Name string `yaml:"name"`
+
+	// Role is viewer, operator or admin.
+	Role string `yaml:"role"`
+
+	Env string `yaml:"env"`
+}
+
+// validate checks the token has a name, a known role and an env var.
+func (t ServerToken) validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("server token needs a name")
+	}
+	switch t.Role {
+	case RoleViewer, RoleOperator, RoleAdmin:
+	default:
+		return fmt.Errorf("invalid role %q for server token %q (want viewer, operator or admin)", t.Role, t.Name)
+	}
+	if t.Env == "" {
+		return fmt.Errorf("server token %q needs an env", t.Name)
+	}
+	return nil
+}
+
+// Merge strategies for MergeSettings.Strategy.
+const (
+	MergeStrategyMerge  = "merge"  // merge commit
+	MergeStrategySquash = "squash" // one commit with the branch's changes
+	MergeStrategyRebase = "rebase" // rebase the branch and fast-forward
+)
+
+// MergeSettings configures how agent branches are merged locally.
+type MergeSettings struct {
+	// Strategy is the default offered by the merge flow: merge (the default),
+	// squash or rebase.
+	Strategy string `yaml:"strategy"`
+}
+
+// Glyph styles for GlyphSettings.Style.
+const (
+	GlyphStyleAuto    = "auto"    // unicode if the locale and terminal support it, otherwise ascii
+	GlyphStyleUnicode = "unicode" // symbols such as ● and ⏸
+	GlyphStyleASCII   = "ascii"   // plain characters for fonts without the symbols
+)
+
+// GlyphSettings chooses the symbols marking agent states in the agent list.
+type GlyphSettings struct {
+	// Style is auto (the default), unicode or ascii.
+	Style string `yaml:"style"`
+
+	// Active, Idle, Failed, Done, Paused and Attention override the style's
+	// glyph for running agents, agents gone quiet, agents whose session exited
+	// or command failed, agents whose command succeeded, paused agents and
+	// agents waiting on the human.
+	Active    string `yaml:"active"`
+	Idle      string `yaml:"idle"`
+	Failed    string `yaml:"failed"`
+	Done      string `yaml:"done"`
+	Paused    string `yaml:"paused"`
+	Attention string `yaml:"attention"`
+}
+
+// ProviderSettings caps concurrent agents for one provider.
+type ProviderSettings struct {
+	// Agents lists agent names from AGENTS.yml that share this provider.
+	Agents []string `yaml:"agents"`
+
+	// MaxConcurrent is how many of these agents may run at once. 0 is unlimited.
+	MaxConcurrent int `yaml:"max_concurrent"`
+
+	// StaggerSeconds is the minimum gap between starting two of these agents.
+	StaggerSeconds int `yaml:"stagger_seconds"`
+}
+
+// BudgetSettings limits agent active time. Agents over budget are stopped with
+// their uncommitted changes stashed, and the human is notified.
+type BudgetSettings struct {
+	// AgentMinutes is the active time allowed per agent. 0 is unlimited.
+	AgentMinutes int `yaml:"agent_minutes"`
+
+	// ProjectMinutes is the total active time allowed across every agent the
+	// project has run. Once spent, all running agents are stopped. 0 is unlimited.
+	ProjectMinutes int `yaml:"project_minutes"`
+}
+
+// EnvironmentSettings controls launching agents inside the development environment
+// their worktree declares. Environments found but not enabled are reported to the human.
+type EnvironmentSettings struct {
+	// Direnv runs `direnv allow` in worktrees with an .envrc and launches agents
+	// through `direnv exec`.
+	Direnv bool `yaml:"direnv"`
+
+	// Devcontainer runs `devcontainer up` in worktrees with a devcontainer.json and
+	// launches agents through `devcontainer exec`.
+	Devcontainer bool `yaml:"devcontainer"`
+}
+
+// Cache strategies for CacheSettings.Strategy.
+const (
+	CacheStrategySymlink  = "symlink"  // link the worktree's Path to the project root's
+	CacheStrategyHardlink = "hardlink" // clone the project root's Path with hard links
+	CacheStrategyCommand  = "command"  // run Command in the worktree
+)
+
+// CacheSettings shares one cache with new agent worktrees, e.g.
+//
+//	caches:
+//	  - strategy: symlink
+//	    path: node_modules
+//	  - strategy: hardlink
+//	    path: .ccache
+//	  - strategy: command
+//	    command: pnpm install --prefer-offline
+type CacheSettings struct {
+	// Strategy is symlink, hardlink or command.
+	Strategy string `yaml:"strategy"`
+
+	// Path is the cache directory relative to the project root, for symlink and hardlink.
+	Path string `yaml:"path"`
+
+	// Command runs in the worktree with CRAIZY_PROJECT_ROOT and CRAIZY_WORKTREE set.
+	Command string `yaml:"command"`
+}
+
+// validate checks the cache has what its strategy needs.
+func (c CacheSettings) validate() error {
+	switch c.Strategy {
+	case CacheStrategySymlink, CacheStrategyHardlink:
+		if !filepath.IsLocal(c.Path) {
+			return fmt.Errorf("invalid %s cache path %q (want a path inside the project)", c.Strategy, c.Path)
+		}
+	case CacheStrategyCommand:
+		if c.Command == "" {
+			return fmt.Errorf("command cache needs a command")
+		}
+	default:
+		return fmt.Errorf("invalid cache strategy %q (want symlink, hardlink or command)", c.Strategy)
+	}
+	return nil
+}
+
+// DiskSettings configures the warning shown when agent worktrees use too much space.
+type DiskSettings struct {
+	// WarnGB is the total size of .craizy/worktrees, in gigabytes, above which the
+	// TUI warns and offers to remove stopped agents' worktrees. 0 disables the warning.
+	WarnGB float64 `yaml:"warn_gb"`
+}
+
+// BackupSettings configures the periodic backups of the database kept in
+// ~/.craizy/backups, which a corrupt database can be restored from.
+type BackupSettings struct {
+	// IntervalHours is how often a backup is taken while crAIzy runs. Defaults to 24.
+	IntervalHours int `yaml:"interval_hours"`
+
+	// Keep is how many backups are kept, deleting the oldest. Defaults to 7.
+	Keep int `yaml:"keep"`
+
+	// Disabled turns periodic backups off.
+	Disabled bool `yaml:"disabled"`
+}
+
+// MessageSettings configures how messages between participants are handled.
+type MessageSettings struct {
+	// EscalateMinutes is how long a question to another agent can stay unread
+	// before a copy is sent to the human, so coordination doesn't stall when the
+	// recipient was killed or is stuck. 0 disables escalation.
+	EscalateMinutes int `yaml:"escalate_minutes"`
+
+	// Humans names the people on a shared project, each addressed as
+	// "human:<name>" with their own inbox and unread count. Empty accepts any
+	// name; the bare "human" is always the shared inbox.
+	Humans []string `yaml:"humans"`
+
+	// NotifyHuman is the name from Humans that notifications about agents, such
+	// as budget stops and escalated questions, go to. Defaults to the shared inbox.
+	NotifyHuman string `yaml:"notify_human"`
+}
+
+// SummarizerSettings configures automatic summaries when an agent reports completion.
+type SummarizerSettings struct {
+	// Command reads the agent's completion message and recent output on stdin and
+	// prints a short summary, e.g. `claude -p "Summarize this work in two sentences"`.
+	// Empty disables summaries.
+	Command string `yaml:"command"`
+}
+
+// ReminderSettings configures the reminder shown when agents run while the TUI goes untouched.
+type ReminderSettings struct {
+	// Minutes of no interaction with the TUI before the terminal bell rings with
+	// a summary of agent states. 0 disables the reminder.
+	Minutes int `yaml:"minutes"`
+
+	// Desktop also sends a desktop notification (notify-send or osascript).
+	Desktop bool `yaml:"desktop"`
+}
+
+// GitSettings configures how crAIzy interacts with git.
+type GitSettings struct {
+	// ProtectedBranches lists base branches that must not be merged into locally.
+	// Agents based on these branches are pushed and opened as pull requests instead.
+	ProtectedBranches []string `yaml:"protected_branches"`
+
+	// Submodules runs `git submodule update --init --recursive` in new agent worktrees.
+	Submodules bool `yaml:"submodules"`
+
+	// LFS runs `git lfs pull` in new agent worktrees.
+	LFS bool `yaml:"lfs"`
+
+	// TemplateWorktree keeps a checkout of the current branch in .craizy/template,
+	// refreshed periodically, and clones new agent worktrees from it instead of
+	// checking them out. On filesystems with reflinks (btrfs, XFS, APFS) this
+	// makes spawning agents on large repos take seconds rather than minutes.
+	TemplateWorktree bool `yaml:"template_worktree"`
+
+	// CommitSigning controls signing of merge and rebase commits crAIzy creates:
+	// "" follows the repository's git config, "always" passes -S, "never" passes --no-gpg-sign.
+	CommitSigning string `yaml:"commit_signing"`
+}
+
+// DefaultSettings returns the settings used when no settings file exists.
+func DefaultSettings() *Settings {
+	return &Settings{}
+}
+
+// SettingsPath returns the path to the settings file for a given work directory.
+func SettingsPath(workDir string) string {
+	return filepath.Join(workDir, CraizyDir, SettingsFileName)
+}
+
+// LoadSettings reads settings from path, falling back to defaults if the file doesn't exist.
+func LoadSettings(path string) (*Settings, error) {
+	settings := DefaultSettings()
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, settings); err != nil {
+		return nil, err
+	}
+
+	for name, size := range settings.WarmPool {
+		if size < 0 {
+			return nil, fmt.Errorf("invalid warm_pool size %d for %q", size, name)
+		}
+	}
+
+	if settings.Reminder.Minutes < 0 {
+		return nil, fmt.Errorf("invalid reminder.minutes %d", settings.Reminder.Minutes)
+	}
+
+	if settings.Budget.AgentMinutes < 0 {
+		return nil, fmt.Errorf("invalid budget.agent_minutes %d", settings.Budget.AgentMinutes)
+	}
+	if settings.Budget.ProjectMinutes < 0 {
+		return nil, fmt.Errorf("invalid budget.project_minutes %d", settings.Budget.ProjectMinutes)
+	}
+
+	if settings.Disk.WarnGB < 0 {
+		return nil, fmt.Errorf("invalid disk.warn_gb %g", settings.Disk.WarnGB)
+	}
+
+	if settings.Backup.IntervalHours < 0 || settings.Backup.Keep < 0 {
+		return nil, fmt.Errorf("invalid backup settings: interval_hours and keep can't be negative")
+	}
+
+	if settings.Messages.EscalateMinutes < 0 {
+		return nil, fmt.Errorf("invalid messages.escalate_minutes %d", settings.Messages.EscalateMinutes)
+	}
+	for _, name := range settings.Messages.Humans {
+		if name == "" || strings.ContainsAny(name, ": \t") {
+			return nil, fmt.Errorf("invalid messages.humans name %q", name)
+		}
+	}
+	if notify := settings.Messages.NotifyHuman; notify != "" && len(settings.Messages.Humans) > 0 && !slices.Contains(settings.Messages.Humans, notify) {
+		return nil, fmt.Errorf("messages.notify_human %q is not in messages.humans", notify)
+	}
+
+	for _, cache := range settings.Caches {
+		if err := cache.validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	providerOf := make(map[string]string)
+	for name, provider := range settings.Providers {
+		if provider.MaxConcurrent < 0 || provider.StaggerSeconds < 0 {
+			return nil, fmt.Errorf("invalid limits for provider %q", name)
+		}
+		for _, agent := range provider.Agents {
+			key := strings.ToLower(agent)
+			if other, exists := providerOf[key]; exists && other != name {
+				return nil, fmt.Errorf("agent %q is in providers %q and %q", agent, other, name)
+			}
+			providerOf[key] = name
+		}
+	}
+
+	if settings.AttentionIdleMinutes < 0 {
+		return nil, fmt.Errorf("invalid attention_idle_minutes %d", settings.AttentionIdleMinutes)
+	}
+
+	switch settings.Git.CommitSigning {
+	case "", "always", "never":
+	default:
+		return nil, fmt.Errorf("invalid git.commit_signing %q (want always or never)", settings.Git.CommitSigning)
+	}
+
+	switch settings.Merge.Strategy {
+	case "", MergeStrategyMerge, MergeStrategySquash, MergeStrategyRebase:
+	default:
+		return nil, fmt.Errorf("invalid merge.strategy %q (want merge, squash or rebase)", settings.Merge.Strategy)
+	}
+
+	switch settings.Glyphs.Style {
+	case "", GlyphStyleAuto, GlyphStyleUnicode, GlyphStyleASCII:
+	default:
+		return nil, fmt.Errorf("invalid glyphs.style %q (want auto, unicode or ascii)", settings.Glyphs.Style)
+	}
+
+	tokenNames := make(map[string]bool)
+	for _, token := range settings.Server.Tokens {
+		if err := token.validate(); err != nil {
+			return nil, err
+		}
+		if tokenNames[token.Name] {
+			return nil, fmt.Errorf("duplicate server token %q", token.Name)
+		}
+		tokenNames[token.Name] = true
+	}
+	return settings, nil
+}
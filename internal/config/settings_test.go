@@ -0,0 +1,442 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadSettings(t *testing.T) {
+	t.Run("missing file returns defaults", func(t *testing.T) {
+		settings, err := LoadSettings(filepath.Join(t.TempDir(), SettingsFileName))
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(settings.Git.ProtectedBranches) != 0 {
+			t.Errorf("ProtectedBranches = %v, want empty", settings.Git.ProtectedBranches)
+		}
+	})
+
+	t.Run("reads protected branches", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		content := "git:\n  protected_branches:\n    - main\n    - release\n"
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		settings, err := LoadSettings(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(settings.Git.ProtectedBranches) != 2 || settings.Git.ProtectedBranches[1] != "release" {
+			t.Errorf("ProtectedBranches = %v, want [main release]", settings.Git.ProtectedBranches)
+		}
+	})
+
+	t.Run("reads worktree setup", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		content := "git:\n  submodules: true\n  lfs: true\n"
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		settings, err := LoadSettings(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !settings.Git.Submodules || !settings.Git.LFS {
+			t.Errorf("Git = %+v, want submodules and lfs enabled", settings.Git)
+		}
+	})
+
+	t.Run("reads open command", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("open_command: code {path}\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		settings, err := LoadSettings(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if settings.OpenCommand != "code {path}" {
+			t.Errorf("OpenCommand = %q, want code {path}", settings.OpenCommand)
+		}
+	})
+
+	t.Run("reads message escalation", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("messages:\n  escalate_minutes: 30\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		settings, err := LoadSettings(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if settings.Messages.EscalateMinutes != 30 {
+			t.Errorf("EscalateMinutes = %d, want 30", settings.Messages.EscalateMinutes)
+		}
+	})
+
+	t.Run("negative escalation returns error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("messages:\n  escalate_minutes: -5\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		if _, err := LoadSettings(path); err == nil {
+			t.Error("expected error for negative escalate_minutes")
+		}
+	})
+
+	t.Run("reads backup settings", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("backup:\n  interval_hours: 6\n  keep: 3\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		settings, err := LoadSettings(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if settings.Backup.IntervalHours != 6 || settings.Backup.Keep != 3 {
+			t.Errorf("Backup = %+v, want every 6 hours keeping 3", settings.Backup)
+		}
+	})
+
+	t.Run("negative backup retention returns error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("backup:\n  keep: -1\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		if _, err := LoadSettings(path); err == nil {
+			t.Error("expected error for negative backup.keep")
+		}
+	})
+
+	t.Run("reads human participants", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		data := "messages:\n  humans: [alice, bob]\n  notify_human: bob\n"
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		settings, err := LoadSettings(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(settings.Messages.Humans) != 2 || settings.Messages.NotifyHuman != "bob" {
+			t.Errorf("Messages = %+v, want humans alice and bob, notifying bob", settings.Messages)
+		}
+	})
+
+	t.Run("notify human outside humans returns error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("messages:\n  humans: [alice]\n  notify_human: carol\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		if _, err := LoadSettings(path); err == nil {
+			t.Error("expected error for notify_human not in humans")
+		}
+	})
+
+	t.Run("invalid commit signing returns error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("git:\n  commit_signing: sometimes\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		if _, err := LoadSettings(path); err == nil {
+			t.Error("expected error for invalid commit_signing")
+		}
+	})
+
+	t.Run("reads glyphs", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("glyphs:\n  style: ascii\n  paused: Z\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		settings, err := LoadSettings(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if settings.Glyphs.Style != GlyphStyleASCII || settings.Glyphs.Paused != "Z" {
+			t.Errorf("Glyphs = %+v, want ascii style with paused Z", settings.Glyphs)
+		}
+	})
+
+	t.Run("invalid glyph style returns error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("glyphs:\n  style: emoji\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		if _, err := LoadSettings(path); err == nil {
+			t.Error("expected error for invalid glyphs.style")
+		}
+	})
+
+	t.Run("reads merge strategy", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("merge:\n  strategy: squash\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		settings, err := LoadSettings(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if settings.Merge.Strategy != MergeStrategySquash {
+			t.Errorf("Merge.Strategy = %q, want squash", settings.Merge.Strategy)
+		}
+	})
+
+	t.Run("invalid merge strategy returns error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("merge:\n  strategy: octopus\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		if _, err := LoadSettings(path); err == nil {
+			t.Error("expected error for invalid merge.strategy")
+		}
+	})
+
+	t.Run("reads warm pool", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("warm_pool:\n  Claude: 2\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		settings, err := LoadSettings(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if settings.WarmPool["Claude"] != 2 {
+			t.Errorf("WarmPool = %v, want Claude: 2", settings.WarmPool)
+		}
+	})
+
+	t.Run("negative warm pool returns error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("warm_pool:\n  Claude: -1\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		if _, err := LoadSettings(path); err == nil {
+			t.Error("expected error for negative warm pool size")
+		}
+	})
+
+	t.Run("reads reminder", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("reminder:\n  minutes: 20\n  desktop: true\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		settings, err := LoadSettings(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if settings.Reminder.Minutes != 20 || !settings.Reminder.Desktop {
+			t.Errorf("Reminder = %+v, want 20 minutes with desktop", settings.Reminder)
+		}
+	})
+
+	t.Run("reads budget", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("budget:\n  agent_minutes: 60\n  project_minutes: 480\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		settings, err := LoadSettings(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if settings.Budget.AgentMinutes != 60 || settings.Budget.ProjectMinutes != 480 {
+			t.Errorf("Budget = %+v, want 60 per agent and 480 per project", settings.Budget)
+		}
+	})
+
+	t.Run("negative budget returns error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("budget:\n  agent_minutes: -1\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		if _, err := LoadSettings(path); err == nil {
+			t.Error("expected error for negative budget")
+		}
+	})
+
+	t.Run("reads disk warning threshold", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("disk:\n  warn_gb: 2.5\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		settings, err := LoadSettings(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if settings.Disk.WarnGB != 2.5 {
+			t.Errorf("Disk.WarnGB = %g, want 2.5", settings.Disk.WarnGB)
+		}
+	})
+
+	t.Run("reads caches", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		data := "caches:\n  - strategy: symlink\n    path: node_modules\n  - strategy: command\n    command: pnpm install --prefer-offline\n"
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		settings, err := LoadSettings(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(settings.Caches) != 2 || settings.Caches[0].Path != "node_modules" || settings.Caches[1].Command == "" {
+			t.Errorf("Caches = %+v", settings.Caches)
+		}
+	})
+
+	t.Run("reads environment", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		data := "environment:\n  direnv: true\n  devcontainer: true\n"
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		settings, err := LoadSettings(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !settings.Environment.Direnv || !settings.Environment.Devcontainer {
+			t.Errorf("Environment = %+v", settings.Environment)
+		}
+	})
+
+	t.Run("invalid caches return error", func(t *testing.T) {
+		for _, data := range []string{
+			"caches:\n  - strategy: rsync\n    path: node_modules\n",
+			"caches:\n  - strategy: symlink\n    path: ../elsewhere\n",
+			"caches:\n  - strategy: hardlink\n",
+			"caches:\n  - strategy: command\n",
+		} {
+			path := filepath.Join(t.TempDir(), SettingsFileName)
+			if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+				t.Fatalf("failed to write settings: %v", err)
+			}
+			if _, err := LoadSettings(path); err == nil {
+				t.Errorf("expected error for %q", data)
+			}
+		}
+	})
+
+	t.Run("reads providers", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		data := "providers:\n  anthropic:\n    agents: [Claude, Opus]\n    max_concurrent: 2\n    stagger_seconds: 30\n"
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		settings, err := LoadSettings(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		got := settings.Providers["anthropic"]
+		if len(got.Agents) != 2 || got.MaxConcurrent != 2 || got.StaggerSeconds != 30 {
+			t.Errorf("Providers[anthropic] = %+v", got)
+		}
+	})
+
+	t.Run("agent in two providers returns error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		data := "providers:\n  a:\n    agents: [Claude]\n  b:\n    agents: [claude]\n"
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		if _, err := LoadSettings(path); err == nil {
+			t.Error("expected error for an agent in two providers")
+		}
+	})
+
+	t.Run("negative attention idle returns error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("attention_idle_minutes: -5\n"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		if _, err := LoadSettings(path); err == nil {
+			t.Error("expected error for negative attention_idle_minutes")
+		}
+	})
+
+	t.Run("reads server tokens", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		data := "server:\n  tokens:\n    - name: alice\n      role: admin\n      env: CRAIZY_TOKEN_ALICE\n"
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		settings, err := LoadSettings(path)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []ServerToken{{Name: "alice", Role: RoleAdmin, Env: "CRAIZY_TOKEN_ALICE"}}
+		if !reflect.DeepEqual(settings.Server.Tokens, want) {
+			t.Errorf("Server.Tokens = %+v, want %+v", settings.Server.Tokens, want)
+		}
+	})
+
+	t.Run("invalid server tokens return error", func(t *testing.T) {
+		for _, data := range []string{
+			"server:\n  tokens:\n    - name: alice\n      role: root\n      env: A\n",
+			"server:\n  tokens:\n    - role: viewer\n      env: A\n",
+			"server:\n  tokens:\n    - name: alice\n      role: viewer\n",
+			"server:\n  tokens:\n    - {name: alice, role: viewer, env: A}\n    - {name: alice, role: admin, env: B}\n",
+		} {
+			path := filepath.Join(t.TempDir(), SettingsFileName)
+			if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+				t.Fatalf("failed to write settings: %v", err)
+			}
+			if _, err := LoadSettings(path); err == nil {
+				t.Errorf("expected error for %q", data)
+			}
+		}
+	})
+
+	t.Run("invalid yaml returns error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), SettingsFileName)
+		if err := os.WriteFile(path, []byte("git: [unclosed"), 0o644); err != nil {
+			t.Fatalf("failed to write settings: %v", err)
+		}
+
+		if _, err := LoadSettings(path); err == nil {
+			t.Error("expected error for invalid yaml")
+		}
+	})
+}
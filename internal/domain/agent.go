@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -11,7 +12,11 @@ type AgentStatus string
 
 const (
 	AgentStatusPending    AgentStatus = "pending"
+	AgentStatusStarting   AgentStatus = "starting" // worktree being prepared in the background, see StartCreate
+	AgentStatusIdle       AgentStatus = "idle"     // warm pool agent waiting to be claimed
 	AgentStatusActive     AgentStatus = "active"
+	AgentStatusPaused     AgentStatus = "paused" // processes suspended, see AgentService.Pause
+	AgentStatusExited     AgentStatus = "exited" // command exited on its own, see Agent.ExitCode
 	AgentStatusTerminated AgentStatus = "terminated"
 )
 
@@ -25,9 +30,55 @@ type Agent struct {
 	WorkDir      string      // working directory
 	Status       AgentStatus // current lifecycle status
 	CreatedAt    time.Time
-	TerminatedAt *time.Time // when the agent was terminated (nil if still active)
-	Branch       string     // worktree branch name
-	BaseBranch   string     // branch it was created from
+	TerminatedAt *time.Time    // when the agent was terminated (nil if still active)
+	Branch       string        // worktree branch name
+	BaseBranch   string        // branch it was created from
+	SparsePaths  []string      // sparse-checkout directories (empty for a full checkout)
+	Summary      string        // short summary of completed work, from the summarizer
+	AttachedTime time.Duration // total time the human has spent attached to the session
+	PausedTime   time.Duration // total time the agent has been paused
+	Restarts     int           // automatic restarts after its session died, see RestartPolicy
+
+	// Metadata holds data attached by providers, hooks and features, such as a
+	// model name or PR URL, by key. See AgentService.SetMetadata.
+	Metadata map[string]string
+
+	// Env and Dir are the CreateOptions the session is started with. They
+	// aren't stored, and Env is left out of the event log, since it may hold
+	// API keys.
+	Env map[string]string `json:"-"`
+	Dir string
+}
+
+// SessionDir returns the directory the agent's session starts in: Dir within
+// its working directory, or the working directory itself.
+func (a *Agent) SessionDir() string {
+	if a.Dir == "" {
+		return a.WorkDir
+	}
+	return filepath.Join(a.WorkDir, a.Dir)
+}
+
+// CreateOptions holds optional settings for creating an agent.
+type CreateOptions struct {
+	SparsePaths []string // limit the worktree checkout to these directories
+	BaseBranch  string   // branch the agent's branch starts from; defaults to the current branch
+	Prompt      string   // startup prompt sent once the agent runs; {var} placeholders are expanded
+
+	Env map[string]string // environment variables set in the agent's session
+	Dir string            // directory within the worktree the session starts in
+
+	Metadata map[string]string // stored with the agent's record, including reserved keys
+}
+
+// ProjectRoot returns the project root for an agent working directory, which is
+// either a worktree under WorktreesDir or the project root itself.
+func ProjectRoot(workDir string) string {
+	sep := string(filepath.Separator)
+	if i := strings.Index(workDir, sep+filepath.FromSlash(WorktreesDir)+sep); i >= 0 {
+		return workDir[:i]
+	}
+	return workDir
 }
 
 // BuildSessionID creates a unique tmux session ID from the components.
@@ -35,6 +86,22 @@ func BuildSessionID(project, agentType, name string) string {
 	return "craizy-" + SanitizeName(project) + "-" + SanitizeName(agentType) + "-" + SanitizeName(name)
 }
 
+// dashboardSessionPrefix starts the tmux session IDs of dashboards run with
+// craizy dash --tmux, one per project.
+const dashboardSessionPrefix = "craizy-dashboard-"
+
+// DashboardSessionID returns the tmux session ID of a project's dashboard.
+func DashboardSessionID(project string) string {
+	return dashboardSessionPrefix + SanitizeName(project)
+}
+
+// IsDashboardSession reports whether a tmux session is a dashboard rather than
+// an agent. A project named like "dashboard-x" has agent IDs sharing the prefix
+// of another project's dashboard, so orphan scans check this first.
+func IsDashboardSession(id string) bool {
+	return strings.HasPrefix(id, dashboardSessionPrefix)
+}
+
 // SanitizeName converts a name to a tmux-safe format.
 // - Converts to lowercase
 // - Removes periods and colons
@@ -77,3 +77,29 @@ func TestBuildSessionID(t *testing.T) {
 		})
 	}
 }
+
+func TestDashboardSessionID(t *testing.T) {
+	web, api := DashboardSessionID("My Web"), DashboardSessionID("api")
+	if web != "craizy-dashboard-my-web" || web == api {
+		t.Errorf("DashboardSessionID = %q and %q, want one session per project", web, api)
+	}
+	if !IsDashboardSession(web) || IsDashboardSession(BuildSessionID("web", "claude", "auth")) {
+		t.Error("expected only dashboard sessions to be reported as dashboards")
+	}
+}
+
+func TestProjectRoot(t *testing.T) {
+	tests := []struct {
+		workDir string
+		want    string
+	}{
+		{"/work/proj/.craizy/worktrees/auth", "/work/proj"},
+		{"/work/proj/.craizy/worktrees/auth/sub", "/work/proj"},
+		{"/work/proj", "/work/proj"},
+	}
+	for _, tt := range tests {
+		if got := ProjectRoot(tt.workDir); got != tt.want {
+			t.Errorf("ProjectRoot(%q) = %q, want %q", tt.workDir, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// Alias names a participant by role, e.g. "lead" for the session ID of the
+// team's current lead agent. Messages to or from an alias go to its target, so
+// rewiring a team means moving the alias rather than editing every prompt.
+type Alias struct {
+	Name      string
+	Target    string // participant ID the alias stands for
+	UpdatedAt time.Time
+}
+
+// aliasNamePattern restricts alias names to lowercase words usable in prompts and flags.
+var aliasNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// SetAliasStore enables aliases for message senders and recipients.
+func (s *MessageService) SetAliasStore(aliases IAliasStore) {
+	s.aliases = aliases
+}
+
+// SetAlias points name at target, replacing any alias of that name. The name
+// can't shadow the human or an agent, and the target must be the human or an
+// agent rather than another alias.
+func (s *MessageService) SetAlias(name, target string) (*Alias, error) {
+	logging.Entry("name", name, "target", target)
+	if s.aliases == nil {
+		return nil, fmt.Errorf("aliases are not available")
+	}
+
+	switch {
+	case !aliasNamePattern.MatchString(name):
+		return nil, fmt.Errorf("invalid alias name %q: use lowercase letters, digits, - and _", name)
+	case name == HumanParticipantID || s.agents.Get(name) != nil:
+		return nil, fmt.Errorf("alias %q would shadow a participant of that ID", name)
+	case IsHumanParticipant(target):
+		if err := s.ValidateHuman(target); err != nil {
+			return nil, err
+		}
+	case s.agents.Get(target) == nil:
+		return nil, fmt.Errorf("alias target not found: %s", target)
+	}
+
+	alias := &Alias{Name: name, Target: target, UpdatedAt: time.Now()}
+	if err := s.aliases.Set(alias); err != nil {
+		logging.Error(err, "name", name)
+		return nil, fmt.Errorf("failed to set alias: %w", err)
+	}
+	logging.Info("alias set, name=%s, target=%s", name, target)
+	return alias, nil
+}
+
+// RemoveAlias deletes an alias.
+func (s *MessageService) RemoveAlias(name string) error {
+	logging.Entry("name", name)
+	if s.aliases == nil {
+		return fmt.Errorf("aliases are not available")
+	}
+	return s.aliases.Delete(name)
+}
+
+// Aliases returns every alias, by name.
+func (s *MessageService) Aliases() ([]*Alias, error) {
+	logging.Entry()
+	if s.aliases == nil {
+		return nil, nil
+	}
+	return s.aliases.List()
+}
+
+// ResolveParticipant returns the participant an alias stands for, or id
+// itself if it isn't an alias.
+func (s *MessageService) ResolveParticipant(id string) string {
+	if s.aliases == nil {
+		return id
+	}
+	alias, err := s.aliases.Get(id)
+	if err != nil {
+		logging.Error(err, "id", id, "action", "resolve alias")
+		return id
+	}
+	if alias == nil {
+		return id
+	}
+	return alias.Target
+}
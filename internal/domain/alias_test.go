@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"fmt"
+	"testing"
+)
+
+// mockAliasStore implements IAliasStore for testing.
+type mockAliasStore struct {
+	aliases map[string]*Alias
+}
+
+func (m *mockAliasStore) Set(alias *Alias) error {
+	m.aliases[alias.Name] = alias
+	return nil
+}
+
+func (m *mockAliasStore) Get(name string) (*Alias, error) {
+	return m.aliases[name], nil
+}
+
+func (m *mockAliasStore) Delete(name string) error {
+	if _, ok := m.aliases[name]; !ok {
+		return fmt.Errorf("alias not found: %s", name)
+	}
+	delete(m.aliases, name)
+	return nil
+}
+
+func (m *mockAliasStore) List() ([]*Alias, error) {
+	var aliases []*Alias
+	for _, alias := range m.aliases {
+		aliases = append(aliases, alias)
+	}
+	return aliases, nil
+}
+
+func TestMessageService_SetAlias(t *testing.T) {
+	agentStore := newTestStore()
+	agentStore.Add(&Agent{ID: "claude-003", Status: AgentStatusActive})
+	svc := NewMessageService(newMockMessageStore(), &mockTmuxClient{sessions: map[string]bool{}}, agentStore)
+
+	if _, err := svc.SetAlias("lead", "claude-003"); err == nil {
+		t.Error("expected error without an alias store")
+	}
+	svc.SetAliasStore(&mockAliasStore{aliases: map[string]*Alias{}})
+
+	tests := []struct {
+		name, alias, target string
+		wantErr             bool
+	}{
+		{"agent target", "lead", "claude-003", false},
+		{"human target", "reviewer", HumanParticipantID, false},
+		{"unknown target", "qa", "claude-999", true},
+		{"invalid name", "Lead Dev", "claude-003", true},
+		{"shadows human", HumanParticipantID, "claude-003", true},
+		{"shadows agent", "claude-003", HumanParticipantID, true},
+		{"alias target", "boss", "lead", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.SetAlias(tt.alias, tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SetAlias(%q, %q) error = %v, wantErr %v", tt.alias, tt.target, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMessageService_SendToAlias(t *testing.T) {
+	msgStore := newMockMessageStore()
+	agentStore := newTestStore()
+	agentStore.Add(&Agent{ID: "claude-003", Status: AgentStatusActive})
+	agentStore.Add(&Agent{ID: "claude-004", Status: AgentStatusActive})
+	tmux := &mockTmuxClient{sessions: map[string]bool{"claude-003": true, "claude-004": true}}
+	svc := NewMessageService(msgStore, tmux, agentStore)
+	svc.SetAliasStore(&mockAliasStore{aliases: map[string]*Alias{}})
+
+	if _, err := svc.SetAlias("lead", "claude-003"); err != nil {
+		t.Fatalf("SetAlias failed: %v", err)
+	}
+	msg, err := svc.Send("lead", "worker-001", MessageTypeAssignment, "Start", nil)
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if msg.From != "claude-003" {
+		t.Errorf("From = %q, want the alias target claude-003", msg.From)
+	}
+
+	// Rewiring the alias redirects later messages without changing the sender
+	if _, err := svc.SetAlias("lead", "claude-004"); err != nil {
+		t.Fatalf("SetAlias failed: %v", err)
+	}
+	msg, err = svc.Send("worker-001", "lead", MessageTypeCompletion, "Done", nil)
+	if err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if msg.To != "claude-004" {
+		t.Errorf("To = %q, want the new alias target claude-004", msg.To)
+	}
+
+	if got := svc.ResolveParticipant("worker-001"); got != "worker-001" {
+		t.Errorf("ResolveParticipant(worker-001) = %q, want it unchanged", got)
+	}
+	if err := svc.RemoveAlias("lead"); err != nil {
+		t.Fatalf("RemoveAlias failed: %v", err)
+	}
+	if got := svc.ResolveParticipant("lead"); got != "lead" {
+		t.Errorf("ResolveParticipant(lead) = %q after removal, want lead", got)
+	}
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// DefaultAttentionIdle is how long an agent can go without output before it needs attention.
+const DefaultAttentionIdle = 10 * time.Minute
+
+// AttentionReason is a reason an agent is waiting on the human.
+type AttentionReason string
+
+const (
+	AttentionQuestion AttentionReason = "question" // Unread question addressed to the human
+	AttentionConflict AttentionReason = "conflict" // Last merge attempt stopped on conflicts
+	AttentionExited   AttentionReason = "exited"   // tmux session is gone
+	AttentionIdle     AttentionReason = "idle"     // No output for longer than the idle threshold
+)
+
+// attentionWeights ranks reasons so a single question outranks any combination of lesser ones.
+var attentionWeights = map[AttentionReason]int{
+	AttentionQuestion: 8,
+	AttentionConflict: 4,
+	AttentionExited:   2,
+	AttentionIdle:     1,
+}
+
+// Attention scores how urgently an agent needs human action.
+type Attention struct {
+	Score   int
+	Reasons []AttentionReason // Most urgent first
+}
+
+// Needed reports whether the agent needs human action at all.
+func (a Attention) Needed() bool {
+	return a.Score > 0
+}
+
+// add records a reason and its weight.
+func (a *Attention) add(reason AttentionReason) {
+	a.Score += attentionWeights[reason]
+	a.Reasons = append(a.Reasons, reason)
+}
+
+// SetAttentionIdle sets how long an agent can be silent before it needs attention.
+// Zero or less restores DefaultAttentionIdle.
+func (s *AgentService) SetAttentionIdle(idle time.Duration) {
+	s.attentionIdle = idle
+}
+
+// AttentionIdle returns how long an agent can be silent before it needs attention.
+func (s *AgentService) AttentionIdle() time.Duration {
+	if s.attentionIdle <= 0 {
+		return DefaultAttentionIdle
+	}
+	return s.attentionIdle
+}
+
+// Attention scores every active agent by how urgently it needs human action, keyed by agent ID.
+func (s *AgentService) Attention() map[string]Attention {
+	logging.Entry("project", s.project)
+	idle := s.AttentionIdle()
+
+	questions := make(map[string]bool)
+	if s.messageSvc != nil {
+		for _, inbox := range s.messageSvc.humanInboxes() {
+			unread, err := s.messageSvc.ListUnread(inbox)
+			if err != nil {
+				logging.Error(err, "action", "list unread questions")
+			}
+			for _, msg := range unread {
+				if msg.Type == MessageTypeQuestion {
+					questions[msg.From] = true
+				}
+			}
+		}
+	}
+
+	scores := make(map[string]Attention)
+	for _, agent := range s.List() {
+		var a Attention
+		if questions[agent.ID] {
+			a.add(AttentionQuestion)
+		}
+		if s.lastMergeConflicted(agent.ID) {
+			a.add(AttentionConflict)
+		}
+		if !s.tmux.SessionExists(agent.ID) {
+			a.add(AttentionExited)
+		} else if agent.Status != AgentStatusPaused { // paused agents are quiet by request
+			if last, err := s.tmux.LastActivity(agent.ID); err == nil && time.Since(last) > idle {
+				a.add(AttentionIdle)
+			}
+		}
+		scores[agent.ID] = a
+	}
+
+	logging.Debug("attention scored, agents=%d", len(scores))
+	return scores
+}
+
+// lastMergeConflicted reports whether the agent's most recent merge attempt hit conflicts.
+func (s *AgentService) lastMergeConflicted(agentID string) bool {
+	if s.merges == nil {
+		return false
+	}
+	records, err := s.merges.ListByAgent(agentID)
+	if err != nil {
+		logging.Error(err, "agentID", agentID, "action", "merge history")
+		return false
+	}
+	return len(records) > 0 && records[0].Outcome == MergeOutcomeConflict
+}
+
+// SortByAttention orders agents by descending attention score, keeping the
+// existing order among agents with equal scores.
+func SortByAttention(agents []*Agent, scores map[string]Attention) {
+	sort.SliceStable(agents, func(i, j int) bool {
+		return scores[agents[i].ID].Score > scores[agents[j].ID].Score
+	})
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAgentService_Attention(t *testing.T) {
+	store := newTestStore()
+	for _, id := range []string{"asking", "conflicted", "exited", "idle", "busy"} {
+		store.Add(&Agent{ID: id, Project: "proj", Status: AgentStatusActive})
+	}
+	tmux := &mockTmuxClient{
+		sessions: map[string]bool{"asking": true, "conflicted": true, "idle": true, "busy": true},
+		activity: map[string]time.Time{"idle": time.Now().Add(-time.Hour)},
+	}
+	messages := newMockMessageStore()
+	messages.Save(NewMessage("asking", HumanParticipantID, MessageTypeQuestion, "which db?", nil))
+	messages.Save(NewMessage("busy", HumanParticipantID, MessageTypeStatus, "halfway", nil))
+	merges := &mockMergeStore{records: []*MergeRecord{{AgentID: "conflicted", Outcome: MergeOutcomeConflict}}}
+
+	svc := NewAgentService(tmux, store, &mockDispatcher{}, newMockGit(), "proj", "/tmp")
+	svc.SetMessageService(NewMessageService(messages, tmux, store))
+	svc.SetMergeStore(merges)
+
+	scores := svc.Attention()
+
+	want := map[string]AttentionReason{
+		"asking":     AttentionQuestion,
+		"conflicted": AttentionConflict,
+		"exited":     AttentionExited,
+		"idle":       AttentionIdle,
+	}
+	for id, reason := range want {
+		a := scores[id]
+		if len(a.Reasons) != 1 || a.Reasons[0] != reason {
+			t.Errorf("%s: got reasons %v, want [%s]", id, a.Reasons, reason)
+		}
+	}
+	if scores["busy"].Needed() {
+		t.Errorf("busy: got reasons %v, want none", scores["busy"].Reasons)
+	}
+
+	agents := svc.List()
+	SortByAttention(agents, scores)
+	var order []string
+	for _, a := range agents {
+		order = append(order, a.ID)
+	}
+	if order[0] != "asking" || order[1] != "conflicted" || order[2] != "exited" || order[3] != "idle" || order[4] != "busy" {
+		t.Errorf("got order %v", order)
+	}
+}
+
+func TestAgentService_AttentionIdleThreshold(t *testing.T) {
+	store := newTestStore()
+	store.Add(&Agent{ID: "agent-1", Project: "proj", Status: AgentStatusActive})
+	tmux := &mockTmuxClient{
+		sessions: map[string]bool{"agent-1": true},
+		activity: map[string]time.Time{"agent-1": time.Now().Add(-2 * time.Minute)},
+	}
+	svc := NewAgentService(tmux, store, &mockDispatcher{}, newMockGit(), "proj", "/tmp")
+
+	if svc.Attention()["agent-1"].Needed() {
+		t.Error("expected agent under the default threshold to need no attention")
+	}
+
+	svc.SetAttentionIdle(time.Minute)
+	if !svc.Attention()["agent-1"].Needed() {
+		t.Error("expected agent over the configured threshold to need attention")
+	}
+}
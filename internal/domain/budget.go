@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// Budget limits how much active time agents may use before crAIzy stops them.
+// Zero fields are unlimited.
+type Budget struct {
+	Agent   time.Duration // active time allowed per agent
+	Project time.Duration // total active time allowed across every agent the project has run
+}
+
+// Enabled reports whether any limit is set.
+func (b Budget) Enabled() bool {
+	return b.Agent > 0 || b.Project > 0
+}
+
+// BudgetScope identifies which limit an agent went over.
+type BudgetScope string
+
+const (
+	BudgetScopeAgent   BudgetScope = "agent"
+	BudgetScopeProject BudgetScope = "project"
+)
+
+// BudgetStop describes an agent stopped for exceeding a budget.
+type BudgetStop struct {
+	Agent *Agent
+	Scope BudgetScope
+	Used  time.Duration
+	Limit time.Duration
+}
+
+// SetBudget sets the active time limits enforced by EnforceBudget.
+func (s *AgentService) SetBudget(budget Budget) {
+	s.budget = budget
+}
+
+// HasBudget reports whether a budget is configured.
+func (s *AgentService) HasBudget() bool {
+	return s.budget.Enabled()
+}
+
+// EnforceBudget stops every running agent that is over budget, stashing its
+// uncommitted changes, and tells the human why. Once the project budget is spent
+// all running agents are stopped.
+func (s *AgentService) EnforceBudget(now time.Time) []BudgetStop {
+	logging.Entry("project", s.project)
+	if !s.budget.Enabled() {
+		return nil
+	}
+
+	var projectUsed time.Duration
+	for _, t := range s.TimeStats(now) {
+		projectUsed += t.Active
+	}
+	projectSpent := s.budget.Project > 0 && projectUsed >= s.budget.Project
+
+	var stops []BudgetStop
+	for _, agent := range s.List() {
+		var stop BudgetStop
+		switch {
+		case projectSpent:
+			stop = BudgetStop{Agent: agent, Scope: BudgetScopeProject, Used: projectUsed, Limit: s.budget.Project}
+		case s.budget.Agent > 0 && agent.ActiveTime(now) >= s.budget.Agent:
+			stop = BudgetStop{Agent: agent, Scope: BudgetScopeAgent, Used: agent.ActiveTime(now), Limit: s.budget.Agent}
+		default:
+			continue
+		}
+		s.stopOverBudget(stop)
+		stops = append(stops, stop)
+	}
+	return stops
+}
+
+// stopOverBudget kills an agent that went over budget and notifies the human.
+func (s *AgentService) stopOverBudget(stop BudgetStop) {
+	agent := stop.Agent
+	logging.Info("agent over budget, agentID=%s, scope=%s, used=%s, limit=%s", agent.ID, stop.Scope, stop.Used, stop.Limit)
+	if err := s.ForceKill(agent.ID, false); err != nil {
+		logging.Error(err, "agentID", agent.ID, "action", "stop over budget")
+	}
+
+	s.dispatcher.Publish(AgentBudgetExceeded{
+		AgentID:   agent.ID,
+		Scope:     stop.Scope,
+		Used:      stop.Used,
+		Limit:     stop.Limit,
+		Timestamp: time.Now(),
+	})
+
+	if s.messageSvc != nil {
+		content := fmt.Sprintf("Stopped %s: %s budget exceeded (%s used of %s).",
+			agent.Name, stop.Scope, stop.Used.Round(time.Second), stop.Limit.Round(time.Second))
+		if agent.Branch != "" {
+			content += " Uncommitted changes were stashed in " + agent.WorkDir + "."
+		}
+		if _, err := s.messageSvc.Send(agent.ID, s.messageSvc.NotifyHuman(), MessageTypeInfo, content, nil); err != nil {
+			logging.Error(err, "agentID", agent.ID, "action", "notify over budget")
+		}
+	}
+}
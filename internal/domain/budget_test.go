@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAgentService_EnforceBudget(t *testing.T) {
+	newBudgetService := func(budget Budget) (*AgentService, *mockDispatcher, *mockMessageStore) {
+		now := time.Now()
+		store := newTestStore()
+		store.Add(&Agent{ID: "long", Name: "long", Project: "proj", Status: AgentStatusActive, CreatedAt: now.Add(-3 * time.Hour)})
+		store.Add(&Agent{ID: "short", Name: "short", Project: "proj", Status: AgentStatusActive, CreatedAt: now.Add(-time.Hour)})
+		dispatcher := &mockDispatcher{}
+		messages := newMockMessageStore()
+		svc := NewAgentService(&mockTmuxClient{}, store, dispatcher, nil, "proj", "/tmp")
+		svc.SetMessageService(NewMessageService(messages, &mockTmuxClient{}, store))
+		svc.SetBudget(budget)
+		return svc, dispatcher, messages
+	}
+
+	t.Run("stops agents over the per-agent budget", func(t *testing.T) {
+		svc, dispatcher, messages := newBudgetService(Budget{Agent: 2 * time.Hour})
+
+		stops := svc.EnforceBudget(time.Now())
+
+		if len(stops) != 1 || stops[0].Agent.ID != "long" || stops[0].Scope != BudgetScopeAgent {
+			t.Fatalf("unexpected stops: %+v", stops)
+		}
+		var killed, exceeded bool
+		for _, e := range dispatcher.published {
+			switch e := e.(type) {
+			case AgentKilled:
+				killed = e.AgentID == "long"
+			case AgentBudgetExceeded:
+				exceeded = e.AgentID == "long" && e.Limit == 2*time.Hour
+			}
+		}
+		if !killed || !exceeded {
+			t.Errorf("expected kill and budget events, got %+v", dispatcher.published)
+		}
+		unread, _ := messages.ListUnread(HumanParticipantID)
+		if len(unread) != 1 || !strings.Contains(unread[0].Content, "agent budget exceeded") {
+			t.Errorf("expected the human to be notified, got %+v", unread)
+		}
+	})
+
+	t.Run("stops everything once the project budget is spent", func(t *testing.T) {
+		svc, _, _ := newBudgetService(Budget{Project: 4 * time.Hour})
+
+		stops := svc.EnforceBudget(time.Now())
+
+		if len(stops) != 2 {
+			t.Fatalf("expected both agents stopped, got %+v", stops)
+		}
+		for _, stop := range stops {
+			if stop.Scope != BudgetScopeProject {
+				t.Errorf("scope = %s, want project", stop.Scope)
+			}
+		}
+	})
+
+	t.Run("under budget", func(t *testing.T) {
+		svc, dispatcher, _ := newBudgetService(Budget{Agent: 4 * time.Hour, Project: 10 * time.Hour})
+
+		if stops := svc.EnforceBudget(time.Now()); len(stops) != 0 {
+			t.Errorf("expected no stops, got %+v", stops)
+		}
+		if len(dispatcher.published) != 0 {
+			t.Errorf("expected no events, got %+v", dispatcher.published)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// OutputCaptureLines is how much of an agent's pane CaptureOutputMessages scans.
+const OutputCaptureLines = 500
+
+// MetadataCaptureSeen holds the hashes of the output blocks in view at the
+// last scan, in order, so blocks still on screen aren't sent twice.
+const MetadataCaptureSeen = "capture.seen"
+
+// outputFence opens an output block, followed by the message type.
+const outputFence = "```craizy:"
+
+// OutputBlock is a message an agent printed for crAIzy to send, for agents
+// that can't run craizy msg send:
+//
+//	```craizy:status
+//	{"to": "human", "content": "Tests pass, starting on the docs"}
+//	```
+//
+// The body may also be plain text, sent to the human as the content.
+type OutputBlock struct {
+	Type    MessageType
+	To      string // defaults to HumanParticipantID
+	Content string
+	hash    string // identifies the block's text
+}
+
+// ParseOutputBlocks returns the complete output blocks in output, in order.
+// Blocks without a known message type or any content are skipped.
+func ParseOutputBlocks(output string) []OutputBlock {
+	var blocks []OutputBlock
+	var open bool
+	var msgType string
+	var body []string
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if rest, ok := strings.CutPrefix(trimmed, outputFence); ok {
+			open, msgType, body = true, strings.TrimSpace(rest), nil
+			continue
+		}
+		if !open {
+			continue
+		}
+		if trimmed != "```" {
+			body = append(body, trimmed)
+			continue
+		}
+		open = false
+		if block, ok := parseOutputBlock(msgType, body); ok {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+// parseOutputBlock reads the body of a block of type msgType.
+func parseOutputBlock(msgType string, body []string) (OutputBlock, bool) {
+	if !IsValidMessageType(msgType) {
+		return OutputBlock{}, false
+	}
+	text := strings.TrimSpace(strings.Join(body, "\n"))
+	sum := sha256.Sum256([]byte(msgType + "\n" + text))
+	block := OutputBlock{Type: MessageType(msgType), To: HumanParticipantID, Content: text, hash: hex.EncodeToString(sum[:8])}
+
+	// JSON wrapped by the terminal splits strings across lines, so also try it rejoined
+	var fields struct {
+		To      string `json:"to"`
+		Content string `json:"content"`
+	}
+	if json.Unmarshal([]byte(text), &fields) == nil || json.Unmarshal([]byte(strings.Join(body, "")), &fields) == nil {
+		block.Content = strings.TrimSpace(fields.Content)
+		if fields.To != "" {
+			block.To = fields.To
+		}
+	}
+	return block, block.Content != ""
+}
+
+// SetOutputCapture turns printed output blocks from agents of the given types
+// into messages, see CaptureOutputMessages.
+func (s *AgentService) SetOutputCapture(agentTypes []string) {
+	s.captureTypes = make(map[string]bool, len(agentTypes))
+	for _, agentType := range agentTypes {
+		s.captureTypes[strings.ToLower(agentType)] = true
+	}
+}
+
+// HasOutputCapture reports whether any agent type has output capture.
+func (s *AgentService) HasOutputCapture() bool {
+	return len(s.captureTypes) > 0
+}
+
+// CaptureOutputMessages scans the panes of active agents with output capture
+// for output blocks printed since the last scan and sends each as a message
+// from the agent, returning how many it sent.
+func (s *AgentService) CaptureOutputMessages() (int, error) {
+	logging.Entry("project", s.project)
+	if s.messageSvc == nil || !s.HasOutputCapture() {
+		return 0, nil
+	}
+	sent := 0
+	for _, agent := range s.List() {
+		if agent.Status != AgentStatusActive || !s.captureTypes[strings.ToLower(agent.AgentType)] {
+			continue
+		}
+		output, err := s.tmux.CapturePaneOutput(agent.ID, OutputCaptureLines)
+		if err != nil {
+			continue
+		}
+		n, err := s.sendOutputBlocks(agent, ParseOutputBlocks(output))
+		sent += n
+		if err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+// sendOutputBlocks sends the blocks printed since the last scan of agent's
+// pane and records the blocks now in view.
+func (s *AgentService) sendOutputBlocks(agent *Agent, blocks []OutputBlock) (int, error) {
+	hashes := make([]string, len(blocks))
+	for i, block := range blocks {
+		hashes[i] = block.hash
+	}
+	var seen []string
+	if value := agent.Metadata[MetadataCaptureSeen]; value != "" {
+		seen = strings.Split(value, ",")
+	}
+	if slices.Equal(seen, hashes) {
+		return 0, nil
+	}
+
+	// Record the blocks first, so a failing one isn't retried forever
+	if err := s.store.SetMetadata(agent.ID, MetadataCaptureSeen, strings.Join(hashes, ",")); err != nil {
+		logging.Error(err, "agentID", agent.ID, "action", "record output blocks")
+		return 0, fmt.Errorf("failed to record output blocks: %w", err)
+	}
+	sent := 0
+	start := newBlocksStart(seen, hashes)
+	for _, block := range blocks[start:] {
+		if _, err := s.messageSvc.Send(agent.ID, block.To, block.Type, block.Content, nil); err != nil {
+			logging.Error(err, "agentID", agent.ID, "to", block.To, "action", "send output block")
+			continue
+		}
+		sent++
+	}
+	if sent > 0 {
+		logging.Info("output blocks sent, agentID=%s, count=%d", agent.ID, sent)
+	}
+	return sent, nil
+}
+
+// newBlocksStart returns the index of the first block in view that wasn't in
+// view at the last scan. Output scrolls up, so the blocks seen then that are
+// still in view lead the current ones: the earliest run of seen that current
+// starts with marks where new output begins. Matching by position rather than
+// content sends a block printed again, and doesn't resend older blocks when
+// the last one sent leaves the view, e.g. when the screen is cleared.
+func newBlocksStart(seen, current []string) int {
+	for i := range seen {
+		n := min(len(seen)-i, len(current))
+		if slices.Equal(seen[i:i+n], current[:n]) {
+			return n
+		}
+	}
+	return 0
+}
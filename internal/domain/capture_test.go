@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseOutputBlocks(t *testing.T) {
+	output := "Working on it\n" +
+		"```craizy:status\n" +
+		"{\"content\": \"Tests pass\"}\n" +
+		"```\n" +
+		"  ```craizy:question\n" +
+		"  {\"to\": \"lead\", \"content\": \"Which database should\n" +
+		"   I use?\"}\n" +
+		"  ```\n" +
+		"```craizy:info\n" +
+		"Plain text\n" +
+		"over two lines\n" +
+		"```\n" +
+		"```craizy:gossip\n" +
+		"Unknown type\n" +
+		"```\n" +
+		"```craizy:status\n" +
+		"{\"content\": \"Still open\"}\n"
+
+	blocks := ParseOutputBlocks(output)
+
+	want := []OutputBlock{
+		{Type: MessageTypeStatus, To: HumanParticipantID, Content: "Tests pass"},
+		{Type: MessageTypeQuestion, To: "lead", Content: "Which database shouldI use?"},
+		{Type: MessageTypeInfo, To: HumanParticipantID, Content: "Plain text\nover two lines"},
+	}
+	if len(blocks) != len(want) {
+		t.Fatalf("ParseOutputBlocks = %+v, want %d blocks", blocks, len(want))
+	}
+	for i, block := range blocks {
+		if block.Type != want[i].Type || block.To != want[i].To || block.Content != want[i].Content {
+			t.Errorf("block %d = %+v, want %+v", i, block, want[i])
+		}
+	}
+}
+
+func TestAgentService_CaptureOutputMessages(t *testing.T) {
+	store := newTestStore()
+	store.Add(&Agent{ID: "aider-001", Project: "proj", AgentType: "aider", Status: AgentStatusActive})
+	tmux := &mockTmuxClient{
+		sessions:       map[string]bool{"aider-001": true},
+		capturedOutput: "```craizy:status\nHalfway\n```\n",
+	}
+	msgStore := newMockMessageStore()
+	svc := NewAgentService(tmux, store, &mockDispatcher{}, nil, "proj", "/tmp")
+	svc.SetMessageService(NewMessageService(msgStore, tmux, store))
+	svc.SetOutputCapture([]string{"Aider"})
+
+	if sent, err := svc.CaptureOutputMessages(); err != nil || sent != 1 {
+		t.Fatalf("CaptureOutputMessages() = %d, %v, want 1", sent, err)
+	}
+	unread, _ := msgStore.ListUnread(HumanParticipantID)
+	if len(unread) != 1 || unread[0].From != "aider-001" || unread[0].Type != MessageTypeStatus || unread[0].Content != "Halfway" {
+		t.Errorf("human unread = %+v, want the status from aider-001", unread)
+	}
+
+	// Blocks already sent are skipped while they stay on screen
+	if sent, _ := svc.CaptureOutputMessages(); sent != 0 {
+		t.Errorf("second scan sent %d, want 0", sent)
+	}
+	tmux.capturedOutput += "```craizy:completion\nDone\n```\n"
+	if sent, _ := svc.CaptureOutputMessages(); sent != 1 {
+		t.Errorf("scan after a new block sent %d, want 1", sent)
+	}
+}
+
+func TestAgentService_CaptureOutputMessages_ByPosition(t *testing.T) {
+	setup := func(output string) (*AgentService, *mockTmuxClient, *mockMessageStore) {
+		store := newTestStore()
+		store.Add(&Agent{ID: "aider-001", Project: "proj", AgentType: "aider", Status: AgentStatusActive})
+		tmux := &mockTmuxClient{sessions: map[string]bool{"aider-001": true}, capturedOutput: output}
+		msgStore := newMockMessageStore()
+		svc := NewAgentService(tmux, store, &mockDispatcher{}, nil, "proj", "/tmp")
+		svc.SetMessageService(NewMessageService(msgStore, tmux, store))
+		svc.SetOutputCapture([]string{"aider"})
+		return svc, tmux, msgStore
+	}
+	block := func(content string) string {
+		return "```craizy:status\n" + content + "\n```\n"
+	}
+
+	t.Run("sends a repeated identical block", func(t *testing.T) {
+		svc, tmux, _ := setup(block("Tests pass"))
+		if sent, _ := svc.CaptureOutputMessages(); sent != 1 {
+			t.Fatalf("first scan sent %d, want 1", sent)
+		}
+
+		tmux.capturedOutput += "Fixed a regression\n" + block("Tests pass")
+
+		if sent, _ := svc.CaptureOutputMessages(); sent != 1 {
+			t.Errorf("scan after the block was printed again sent %d, want 1", sent)
+		}
+		if sent, _ := svc.CaptureOutputMessages(); sent != 0 {
+			t.Errorf("scan with nothing new sent %d, want 0", sent)
+		}
+	})
+
+	t.Run("doesn't resend when the last sent block leaves the view", func(t *testing.T) {
+		svc, tmux, msgStore := setup(block("One") + block("Two") + block("Three"))
+		if sent, _ := svc.CaptureOutputMessages(); sent != 3 {
+			t.Fatalf("first scan sent %d, want 3", sent)
+		}
+
+		// Clearing the screen drops Three but keeps the history above it
+		tmux.capturedOutput = block("One") + block("Two")
+		if sent, _ := svc.CaptureOutputMessages(); sent != 0 {
+			t.Errorf("scan after Three left the view sent %d, want 0", sent)
+		}
+		tmux.capturedOutput += block("Four")
+		if sent, _ := svc.CaptureOutputMessages(); sent != 1 {
+			t.Errorf("scan after Four sent %d, want 1", sent)
+		}
+		// The window moves on past One, cutting Two's fence off
+		tmux.capturedOutput = "Two\n```\n" + block("Four") + block("Five")
+		if sent, _ := svc.CaptureOutputMessages(); sent != 1 {
+			t.Errorf("scan after Five sent %d, want 1", sent)
+		}
+		tmux.capturedOutput = "lots of output\n"
+		if sent, _ := svc.CaptureOutputMessages(); sent != 0 {
+			t.Errorf("scan with every block scrolled away sent %d, want 0", sent)
+		}
+
+		unread, _ := msgStore.ListUnread(HumanParticipantID)
+		slices.SortFunc(unread, func(a, b *Message) int { return a.Seq - b.Seq })
+		var got []string
+		for _, msg := range unread {
+			got = append(got, msg.Content)
+		}
+		if want := []string{"One", "Two", "Three", "Four", "Five"}; !slices.Equal(got, want) {
+			t.Errorf("sent %q, want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// SetTransport delivers messages for agents of agentType through transport
+// rather than typing them into the agent's session.
+func (s *MessageService) SetTransport(agentType string, transport IDeliveryTransport) {
+	logging.Entry("agentType", agentType, "transport", transport.Name())
+	if s.transports == nil {
+		s.transports = make(map[string]IDeliveryTransport)
+	}
+	s.transports[strings.ToLower(agentType)] = transport
+}
+
+// transport returns the transport for agent's type, or one typing into its
+// session.
+func (s *MessageService) transport(agent *Agent) IDeliveryTransport {
+	if transport, ok := s.transports[strings.ToLower(agent.AgentType)]; ok {
+		return transport
+	}
+	return keysTransport{tmux: s.tmux}
+}
+
+// keysTransport types messages into the agent's session.
+type keysTransport struct {
+	tmux ITmuxClient
+}
+
+func (t keysTransport) Deliver(agent *Agent, text string) error {
+	return t.tmux.SendKeys(agent.ID, text)
+}
+
+func (t keysTransport) Nudge(agent *Agent, text string) error {
+	return t.tmux.SendKeys(agent.ID, text)
+}
+
+func (keysTransport) Name() string {
+	return "tmux"
+}
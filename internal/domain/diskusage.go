@@ -0,0 +1,291 @@
+package domain
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// DiskUsageTTL is how long a measured directory size is reused before it is walked again.
+const DiskUsageTTL = 5 * time.Minute
+
+// WorktreeUsage is the disk space used by one directory under .craizy/worktrees.
+type WorktreeUsage struct {
+	Path  string
+	Bytes int64
+	Agent *Agent // stored agent using the directory, nil if none
+}
+
+// Reclaimable reports whether no running agent uses the directory.
+func (u WorktreeUsage) Reclaimable() bool {
+	return u.Agent == nil || u.Agent.Status == AgentStatusTerminated
+}
+
+// DiskUsage summarizes the disk space used by agent worktrees.
+type DiskUsage struct {
+	Worktrees []WorktreeUsage // largest first
+	Total     int64
+	Threshold int64 // warning threshold in bytes, 0 if none
+}
+
+// OverThreshold reports whether worktrees use more space than the threshold.
+func (d DiskUsage) OverThreshold() bool {
+	return d.Threshold > 0 && d.Total > d.Threshold
+}
+
+// Reclaimable returns the bytes CleanupWorktrees would free.
+func (d DiskUsage) Reclaimable() int64 {
+	var total int64
+	for _, wt := range d.Worktrees {
+		if wt.Reclaimable() {
+			total += wt.Bytes
+		}
+	}
+	return total
+}
+
+// diskCache remembers directory sizes so repeated views don't walk large worktrees.
+type diskCache struct {
+	mu      sync.Mutex
+	entries map[string]diskCacheEntry
+}
+
+type diskCacheEntry struct {
+	bytes    int64
+	measured time.Time
+}
+
+// size returns the size of the directory at path, walking it if the cached size is stale.
+func (c *diskCache) size(path string, now time.Time) (int64, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[path]
+	c.mu.Unlock()
+	if ok && now.Sub(entry.measured) < DiskUsageTTL {
+		return entry.bytes, nil
+	}
+
+	bytes, err := dirSize(path)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	if c.entries == nil {
+		c.entries = make(map[string]diskCacheEntry)
+	}
+	c.entries[path] = diskCacheEntry{bytes: bytes, measured: now}
+	c.mu.Unlock()
+	return bytes, nil
+}
+
+// cached returns the last measured size of path, if any.
+func (c *diskCache) cached(path string) (int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[path]
+	return entry.bytes, ok
+}
+
+// forget drops the cached size of path.
+func (c *diskCache) forget(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, path)
+}
+
+// dirSize returns the total size of the regular files under path, like du.
+// Files that vanish during the walk are skipped.
+func dirSize(path string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		total += info.Size()
+		return nil
+	})
+	return total, err
+}
+
+// FormatBytes formats a size for display, e.g. "1.5 GB".
+func FormatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// SetDiskThreshold sets the worktree disk usage, in bytes, above which DiskUsage
+// reports OverThreshold. 0 disables the warning.
+func (s *AgentService) SetDiskThreshold(bytes int64) {
+	s.diskThreshold = bytes
+}
+
+// HasDiskThreshold reports whether a disk usage warning threshold is configured.
+func (s *AgentService) HasDiskThreshold() bool {
+	return s.diskThreshold > 0
+}
+
+// WorktreeSize returns the disk space used by an agent's worktree, measuring it
+// if the cached size is older than DiskUsageTTL.
+func (s *AgentService) WorktreeSize(agentID string) (int64, error) {
+	logging.Entry("agentID", agentID)
+	agent := s.store.Get(agentID)
+	if agent == nil {
+		err := fmt.Errorf("agent %q not found", agentID)
+		logging.Error(err, "agentID", agentID)
+		return 0, err
+	}
+	if _, err := os.Stat(agent.WorkDir); err != nil {
+		err = fmt.Errorf("failed to measure worktree: %w", err)
+		logging.Error(err, "agentID", agentID, "workDir", agent.WorkDir)
+		return 0, err
+	}
+	size, err := s.disk.size(filepath.Clean(agent.WorkDir), time.Now())
+	if err != nil {
+		err = fmt.Errorf("failed to measure worktree: %w", err)
+		logging.Error(err, "agentID", agentID, "workDir", agent.WorkDir)
+		return 0, err
+	}
+	return size, nil
+}
+
+// CachedWorktreeSize returns an agent's last measured worktree size without walking it.
+func (s *AgentService) CachedWorktreeSize(agent *Agent) (int64, bool) {
+	return s.disk.cached(filepath.Clean(agent.WorkDir))
+}
+
+// DiskUsage measures every directory under .craizy/worktrees and matches it to
+// the agent using it. Sizes are cached for DiskUsageTTL.
+func (s *AgentService) DiskUsage() (*DiskUsage, error) {
+	logging.Entry("project", s.project)
+	root := filepath.Join(s.workDir, WorktreesDir)
+	entries, err := os.ReadDir(root)
+	if err != nil && !os.IsNotExist(err) {
+		err = fmt.Errorf("failed to read worktrees directory: %w", err)
+		logging.Error(err, "root", root)
+		return nil, err
+	}
+
+	// Prefer the running agent when a name was reused after a kill
+	owners := make(map[string]*Agent)
+	for _, agent := range s.store.List() {
+		path := filepath.Clean(agent.WorkDir)
+		if owner := owners[path]; owner == nil || owner.Status == AgentStatusTerminated {
+			owners[path] = agent
+		}
+	}
+
+	usage := &DiskUsage{Threshold: s.diskThreshold}
+	now := time.Now()
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		path := filepath.Join(root, entry.Name())
+		size, err := s.disk.size(path, now)
+		if err != nil {
+			err = fmt.Errorf("failed to measure %s: %w", path, err)
+			logging.Error(err, "path", path)
+			return nil, err
+		}
+		usage.Worktrees = append(usage.Worktrees, WorktreeUsage{Path: path, Bytes: size, Agent: owners[path]})
+		usage.Total += size
+	}
+	sort.Slice(usage.Worktrees, func(i, j int) bool {
+		return usage.Worktrees[i].Bytes > usage.Worktrees[j].Bytes
+	})
+	logging.Debug("measured worktrees, count=%d, total=%d", len(usage.Worktrees), usage.Total)
+	return usage, nil
+}
+
+// CleanupWorktrees removes the worktree directories of terminated agents and
+// directories no stored agent uses, returning the bytes freed. Uncommitted
+// changes in a git worktree are stashed first; branches are kept.
+func (s *AgentService) CleanupWorktrees() (int64, error) {
+	logging.Entry("project", s.project)
+	usage, err := s.DiskUsage()
+	if err != nil {
+		return 0, err
+	}
+
+	registered := make(map[string]bool)
+	if s.git != nil {
+		worktrees, err := s.git.ListWorktrees()
+		if err != nil {
+			logging.Error(err, "action", "list worktrees")
+			return 0, err
+		}
+		for _, wt := range worktrees {
+			registered[filepath.Clean(wt.Path)] = true
+		}
+	}
+
+	var freed int64
+	var failed []string
+	for _, wt := range usage.Worktrees {
+		if !wt.Reclaimable() {
+			continue
+		}
+		if err := s.removeWorktreeDir(wt.Path, registered[wt.Path]); err != nil {
+			logging.Error(err, "path", wt.Path, "action", "remove worktree")
+			failed = append(failed, filepath.Base(wt.Path))
+			continue
+		}
+		s.disk.forget(wt.Path)
+		freed += wt.Bytes
+	}
+	if s.git != nil {
+		if err := s.git.PruneWorktrees(); err != nil {
+			logging.Error(err, "action", "prune worktrees")
+		}
+	}
+
+	logging.Info("cleaned up worktrees, freed=%d, failed=%d", freed, len(failed))
+	if len(failed) > 0 {
+		return freed, fmt.Errorf("failed to remove %d worktree(s): %v", len(failed), failed)
+	}
+	return freed, nil
+}
+
+// removeWorktreeDir removes a worktree directory, stashing uncommitted changes
+// first if it's registered with git.
+func (s *AgentService) removeWorktreeDir(path string, registered bool) error {
+	if registered {
+		if s.hasUncommittedChanges(path) {
+			if err := s.git.Stash(path); err != nil {
+				return fmt.Errorf("failed to stash changes: %w", err)
+			}
+		}
+		_ = s.git.UnlockWorktree(path)
+		if err := s.git.RemoveWorktree(path); err == nil {
+			return nil
+		}
+	}
+	return os.RemoveAll(path)
+}
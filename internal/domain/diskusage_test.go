@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAgentService_DiskUsage(t *testing.T) {
+	newDiskService := func(t *testing.T) (*AgentService, string) {
+		t.Helper()
+		workDir := t.TempDir()
+		root := filepath.Join(workDir, WorktreesDir)
+		write := func(name string, size int) {
+			dir := filepath.Join(root, name, "src")
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "file"), make([]byte, size), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		write("running", 300)
+		write("stopped", 200)
+		write("orphan", 100)
+
+		store := newTestStore()
+		store.Add(&Agent{ID: "running", Name: "running", Project: "proj", Status: AgentStatusActive, WorkDir: filepath.Join(root, "running")})
+		store.Add(&Agent{ID: "stopped", Name: "stopped", Project: "proj", Status: AgentStatusTerminated, WorkDir: filepath.Join(root, "stopped")})
+		svc := NewAgentService(&mockTmuxClient{}, store, &mockDispatcher{}, nil, "proj", workDir)
+		return svc, root
+	}
+
+	t.Run("measures worktrees largest first", func(t *testing.T) {
+		svc, _ := newDiskService(t)
+		svc.SetDiskThreshold(500)
+
+		usage, err := svc.DiskUsage()
+
+		if err != nil {
+			t.Fatalf("DiskUsage failed: %v", err)
+		}
+		if usage.Total != 600 || len(usage.Worktrees) != 3 {
+			t.Fatalf("unexpected usage: %+v", usage)
+		}
+		if usage.Worktrees[0].Agent == nil || usage.Worktrees[0].Agent.ID != "running" {
+			t.Errorf("expected the running agent's worktree first, got %+v", usage.Worktrees[0])
+		}
+		if usage.Worktrees[2].Agent != nil {
+			t.Errorf("expected the orphan directory to have no agent, got %+v", usage.Worktrees[2].Agent)
+		}
+		if !usage.OverThreshold() || usage.Reclaimable() != 300 {
+			t.Errorf("expected over threshold with 300 reclaimable, got over=%v reclaimable=%d", usage.OverThreshold(), usage.Reclaimable())
+		}
+	})
+
+	t.Run("reuses cached sizes", func(t *testing.T) {
+		svc, root := newDiskService(t)
+		if _, err := svc.WorktreeSize("running"); err != nil {
+			t.Fatalf("WorktreeSize failed: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(root, "running", "more"), make([]byte, 50), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		size, _ := svc.WorktreeSize("running")
+
+		if size != 300 {
+			t.Errorf("expected the cached size 300, got %d", size)
+		}
+		if cached, ok := svc.CachedWorktreeSize(&Agent{WorkDir: filepath.Join(root, "running")}); !ok || cached != 300 {
+			t.Errorf("CachedWorktreeSize = %d, %v", cached, ok)
+		}
+	})
+
+	t.Run("cleanup removes only stopped and orphaned worktrees", func(t *testing.T) {
+		svc, root := newDiskService(t)
+
+		freed, err := svc.CleanupWorktrees()
+
+		if err != nil {
+			t.Fatalf("CleanupWorktrees failed: %v", err)
+		}
+		if freed != 300 {
+			t.Errorf("expected 300 bytes freed, got %d", freed)
+		}
+		for name, want := range map[string]bool{"running": true, "stopped": false, "orphan": false} {
+			_, err := os.Stat(filepath.Join(root, name))
+			if exists := err == nil; exists != want {
+				t.Errorf("%s exists = %v, want %v", name, exists, want)
+			}
+		}
+	})
+
+	t.Run("missing worktree returns error", func(t *testing.T) {
+		svc, root := newDiskService(t)
+		_ = os.RemoveAll(filepath.Join(root, "running"))
+
+		if _, err := svc.WorktreeSize("running"); err == nil || !strings.Contains(err.Error(), "measure") {
+			t.Errorf("expected measure error, got %v", err)
+		}
+	})
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		512:             "512 B",
+		1536:            "1.5 KB",
+		10 * (1 << 20):  "10.0 MB",
+		3 * (1 << 30):   "3.0 GB",
+		(1 << 40) + 100: "1.0 TB",
+	}
+	for bytes, want := range tests {
+		if got := FormatBytes(bytes); got != want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", bytes, got, want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// SetEscalation sets how long a question to another agent may stay unread
+// before EscalateUnanswered copies it to the human. 0 disables escalation.
+func (s *MessageService) SetEscalation(after time.Duration) {
+	s.escalateAfter = after
+}
+
+// HasEscalation reports whether unanswered questions are escalated.
+func (s *MessageService) HasEscalation() bool {
+	return s.escalateAfter > 0
+}
+
+// EscalateUnanswered copies questions between agents that have stayed unread
+// past the escalation timeout to the human, noting who they were for and what
+// became of them. Copies come from the original sender, so the human's reply
+// unblocks them directly. Each question is escalated once; the copies are returned.
+func (s *MessageService) EscalateUnanswered(now time.Time) ([]*Message, error) {
+	logging.Entry()
+	if !s.HasEscalation() {
+		return nil, nil
+	}
+
+	questions, err := s.store.ListUnescalatedQuestions()
+	if err != nil {
+		logging.Error(err)
+		return nil, fmt.Errorf("failed to list unanswered questions: %w", err)
+	}
+
+	var escalated []*Message
+	for _, question := range questions {
+		since := question.CreatedAt
+		if question.DeliverAt != nil {
+			since = *question.DeliverAt
+		}
+		waited := now.Sub(since)
+		if waited < s.escalateAfter {
+			continue
+		}
+
+		escalation := NewMessage(question.From, s.NotifyHuman(), MessageTypeQuestion, s.escalationContent(question, waited), question.RelatedWork)
+		escalation.Refs = question.Refs
+		if err := s.store.Save(escalation); err != nil {
+			logging.Error(err, "msgID", question.ID, "action", "escalate question")
+			continue
+		}
+		if err := s.store.MarkEscalated(question.ID); err != nil {
+			logging.Error(err, "msgID", question.ID, "action", "mark escalated")
+		}
+		logging.Info("question escalated to human, msgID=%s, escalation=%s", question.ID, escalation.ID)
+		escalated = append(escalated, escalation)
+	}
+	return escalated, nil
+}
+
+// escalationContent quotes an unanswered question with how long it waited and
+// the state of the agent it was for.
+func (s *MessageService) escalationContent(question *Message, waited time.Duration) string {
+	state := "no longer exists"
+	if s.agents != nil {
+		if agent := s.agents.Get(question.To); agent != nil {
+			state = "is " + string(agent.Status)
+		}
+	}
+	return fmt.Sprintf("Escalated: %s asked %s (%s) %s ago and it is still unread; %s %s.\n\n%s",
+		question.From, question.To, question.Code(), waited.Round(time.Minute), question.To, state, question.Content)
+}
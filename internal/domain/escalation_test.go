@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMessageService_EscalateUnanswered(t *testing.T) {
+	now := time.Now()
+	msgStore := newMockMessageStore()
+	agentStore := newTestStore()
+	agentStore.Add(&Agent{ID: "stuck-001", Status: AgentStatusTerminated})
+	svc := NewMessageService(msgStore, &mockTmuxClient{sessions: map[string]bool{}}, agentStore)
+
+	stale := &Message{ID: "stale", Seq: 1, From: "worker-001", To: "stuck-001", Type: MessageTypeQuestion, Content: "Which schema?", CreatedAt: now.Add(-time.Hour)}
+	recent := &Message{ID: "recent", Seq: 2, From: "worker-001", To: "stuck-001", Type: MessageTypeQuestion, Content: "And the index?", CreatedAt: now.Add(-time.Minute)}
+	info := &Message{ID: "info", Seq: 3, From: "worker-001", To: "stuck-001", Type: MessageTypeInfo, Content: "FYI", CreatedAt: now.Add(-time.Hour)}
+	for _, msg := range []*Message{stale, recent, info} {
+		msgStore.messages[msg.ID] = msg
+	}
+
+	if escalated, _ := svc.EscalateUnanswered(now); len(escalated) != 0 {
+		t.Fatalf("escalated %d messages with escalation disabled, want none", len(escalated))
+	}
+
+	svc.SetEscalation(30 * time.Minute)
+	escalated, err := svc.EscalateUnanswered(now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(escalated) != 1 {
+		t.Fatalf("escalated %d messages, want only the stale question", len(escalated))
+	}
+	escalation := escalated[0]
+	if escalation.From != "worker-001" || escalation.To != HumanParticipantID || escalation.Type != MessageTypeQuestion {
+		t.Errorf("escalation = %s -> %s (%s), want a question from worker-001 to the human", escalation.From, escalation.To, escalation.Type)
+	}
+	for _, want := range []string{"stuck-001", "M-1", "1h0m0s", "is terminated", "Which schema?"} {
+		if !strings.Contains(escalation.Content, want) {
+			t.Errorf("escalation content %q missing %q", escalation.Content, want)
+		}
+	}
+
+	if again, _ := svc.EscalateUnanswered(now.Add(time.Hour)); len(again) != 1 || !strings.Contains(again[0].Content, "And the index?") {
+		t.Errorf("expected only the second question escalated later, got %d", len(again))
+	}
+}
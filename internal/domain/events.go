@@ -8,6 +8,9 @@ type Event interface {
 	OccurredAt() time.Time
 }
 
+// AllEvents can be passed to Subscribe to receive every published event.
+const AllEvents = "*"
+
 // EventHandler is a function that handles domain events.
 type EventHandler func(event Event)
 
@@ -16,7 +19,7 @@ type IEventDispatcher interface {
 	// Publish sends an event to all registered handlers.
 	Publish(event Event)
 
-	// Subscribe registers a handler for a specific event type.
+	// Subscribe registers a handler for a specific event type, or AllEvents.
 	Subscribe(eventType string, handler EventHandler)
 }
 
@@ -29,6 +32,36 @@ type AgentCreated struct {
 func (e AgentCreated) EventType() string     { return "agent.created" }
 func (e AgentCreated) OccurredAt() time.Time { return e.Timestamp }
 
+// AgentQueued is published when a new agent has to wait for its provider pool.
+type AgentQueued struct {
+	Agent     *Agent
+	Reason    string
+	Timestamp time.Time
+}
+
+func (e AgentQueued) EventType() string     { return "agent.queued" }
+func (e AgentQueued) OccurredAt() time.Time { return e.Timestamp }
+
+// AgentStarting is published when a new agent's worktree starts being prepared
+// in the background. The agent is replaced by the one in AgentCreated once ready.
+type AgentStarting struct {
+	Agent     *Agent
+	Timestamp time.Time
+}
+
+func (e AgentStarting) EventType() string     { return "agent.starting" }
+func (e AgentStarting) OccurredAt() time.Time { return e.Timestamp }
+
+// AgentClaimed is published when an idle warm pool agent is handed a new identity.
+type AgentClaimed struct {
+	OldID     string
+	Agent     *Agent
+	Timestamp time.Time
+}
+
+func (e AgentClaimed) EventType() string     { return "agent.claimed" }
+func (e AgentClaimed) OccurredAt() time.Time { return e.Timestamp }
+
 // AgentKilled is published when an agent is terminated.
 type AgentKilled struct {
 	AgentID   string
@@ -38,6 +71,27 @@ type AgentKilled struct {
 func (e AgentKilled) EventType() string     { return "agent.killed" }
 func (e AgentKilled) OccurredAt() time.Time { return e.Timestamp }
 
+// AgentRestarted is published when an agent's command is started again in a
+// new session, keeping its branch and worktree.
+type AgentRestarted struct {
+	AgentID   string
+	Timestamp time.Time
+}
+
+func (e AgentRestarted) EventType() string     { return "agent.restarted" }
+func (e AgentRestarted) OccurredAt() time.Time { return e.Timestamp }
+
+// AgentExited is published when an agent's command exits on its own, rather
+// than its session being killed.
+type AgentExited struct {
+	AgentID   string
+	ExitCode  int
+	Timestamp time.Time
+}
+
+func (e AgentExited) EventType() string     { return "agent.exited" }
+func (e AgentExited) OccurredAt() time.Time { return e.Timestamp }
+
 // AgentStatusChanged is published when an agent's status changes.
 type AgentStatusChanged struct {
 	AgentID   string
@@ -48,3 +102,76 @@ type AgentStatusChanged struct {
 
 func (e AgentStatusChanged) EventType() string     { return "agent.status_changed" }
 func (e AgentStatusChanged) OccurredAt() time.Time { return e.Timestamp }
+
+// MergeCompleted is published when an agent's branch is merged into its base.
+type MergeCompleted struct {
+	Record    *MergeRecord
+	Timestamp time.Time
+}
+
+func (e MergeCompleted) EventType() string     { return "merge.completed" }
+func (e MergeCompleted) OccurredAt() time.Time { return e.Timestamp }
+
+// MergeFailed is published when a merge attempt stops on conflicts.
+type MergeFailed struct {
+	Record    *MergeRecord
+	Timestamp time.Time
+}
+
+func (e MergeFailed) EventType() string     { return "merge.failed" }
+func (e MergeFailed) OccurredAt() time.Time { return e.Timestamp }
+
+// PullRequestOpened is published when an agent's branch is pushed and opened as a pull request.
+type PullRequestOpened struct {
+	AgentID   string
+	URL       string
+	Timestamp time.Time
+}
+
+func (e PullRequestOpened) EventType() string     { return "pullrequest.opened" }
+func (e PullRequestOpened) OccurredAt() time.Time { return e.Timestamp }
+
+// AgentRetargeted is published when an agent's branch is moved onto a different base branch.
+type AgentRetargeted struct {
+	AgentID   string
+	OldBase   string
+	NewBase   string
+	Timestamp time.Time
+}
+
+func (e AgentRetargeted) EventType() string     { return "agent.retargeted" }
+func (e AgentRetargeted) OccurredAt() time.Time { return e.Timestamp }
+
+// AgentSummarized is published when the summarizer stores a summary of an agent's work.
+type AgentSummarized struct {
+	AgentID   string
+	Summary   string
+	Timestamp time.Time
+}
+
+func (e AgentSummarized) EventType() string     { return "agent.summarized" }
+func (e AgentSummarized) OccurredAt() time.Time { return e.Timestamp }
+
+// AgentMetadataChanged is published when a metadata value is set on an agent.
+// An empty Value means the key was deleted.
+type AgentMetadataChanged struct {
+	AgentID   string
+	Key       string
+	Value     string
+	Timestamp time.Time
+}
+
+func (e AgentMetadataChanged) EventType() string     { return "agent.metadata_changed" }
+func (e AgentMetadataChanged) OccurredAt() time.Time { return e.Timestamp }
+
+// AgentBudgetExceeded is published when an agent is stopped for going over its budget.
+type AgentBudgetExceeded struct {
+	AgentID   string
+	Scope     BudgetScope
+	Used      time.Duration
+	Limit     time.Duration
+	Timestamp time.Time
+}
+
+func (e AgentBudgetExceeded) EventType() string     { return "agent.budget_exceeded" }
+func (e AgentBudgetExceeded) OccurredAt() time.Time { return e.Timestamp }
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// MetadataExitCode records the exit status of an exited agent's command.
+const MetadataExitCode = "exit_code"
+
+// ExitCode returns the exit status of the agent's command, if it exited on its own.
+func (a *Agent) ExitCode() (int, bool) {
+	if a.Status != AgentStatusExited {
+		return 0, false
+	}
+	code, err := strconv.Atoi(a.Metadata[MetadataExitCode])
+	if err != nil {
+		return 0, false
+	}
+	return code, true
+}
+
+// markExited marks an agent whose session is gone exited, with its command's
+// exit status, if the command exited on its own. It reports whether it did;
+// agents whose session was killed are left for the caller.
+func (s *AgentService) markExited(agent *Agent) bool {
+	code, ok := s.tmux.ExitStatus(agent.ID)
+	if !ok {
+		return false
+	}
+	if err := s.store.SetMetadata(agent.ID, MetadataExitCode, strconv.Itoa(code)); err != nil {
+		logging.Error(err, "agentID", agent.ID, "action", "record exit code")
+	}
+	if err := s.store.UpdateStatus(agent.ID, AgentStatusExited); err != nil {
+		logging.Error(err, "agentID", agent.ID, "action", "update status")
+		return false
+	}
+	if agent.Metadata == nil {
+		agent.Metadata = make(map[string]string)
+	}
+	agent.Status, agent.Metadata[MetadataExitCode] = AgentStatusExited, strconv.Itoa(code)
+
+	s.dispatcher.Publish(AgentExited{
+		AgentID:   agent.ID,
+		ExitCode:  code,
+		Timestamp: time.Now(),
+	})
+	logging.Info("agent exited, agentID=%s, exitCode=%d", agent.ID, code)
+	return true
+}
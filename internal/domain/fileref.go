@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FileRef points a message at a file, optionally at a line or range of lines,
+// e.g. "internal/auth/token.go:40-58". Paths are relative to the sender's worktree.
+type FileRef struct {
+	Path  string
+	Start int // first line, 0 for the whole file
+	End   int // last line, equal to Start for a single line
+}
+
+// String formats the reference as path, path:line or path:start-end.
+func (r FileRef) String() string {
+	switch {
+	case r.Start == 0:
+		return r.Path
+	case r.End <= r.Start:
+		return fmt.Sprintf("%s:%d", r.Path, r.Start)
+	default:
+		return fmt.Sprintf("%s:%d-%d", r.Path, r.Start, r.End)
+	}
+}
+
+// ParseFileRef parses a reference formatted as by FileRef.String.
+func ParseFileRef(s string) (FileRef, error) {
+	s = strings.TrimSpace(s)
+	path, lines, found := strings.Cut(s, ":")
+	if path == "" {
+		return FileRef{}, fmt.Errorf("invalid file reference %q: missing path", s)
+	}
+	ref := FileRef{Path: path}
+	if !found {
+		return ref, nil
+	}
+
+	start, end, isRange := strings.Cut(lines, "-")
+	var err error
+	if ref.Start, err = strconv.Atoi(start); err != nil || ref.Start <= 0 {
+		return FileRef{}, fmt.Errorf("invalid file reference %q: bad line %q", s, start)
+	}
+	ref.End = ref.Start
+	if isRange {
+		if ref.End, err = strconv.Atoi(end); err != nil || ref.End < ref.Start {
+			return FileRef{}, fmt.Errorf("invalid file reference %q: bad line range", s)
+		}
+	}
+	return ref, nil
+}
+
+// FormatFileRefs joins references one per line, as stored.
+func FormatFileRefs(refs []FileRef) string {
+	lines := make([]string, len(refs))
+	for i, ref := range refs {
+		lines[i] = ref.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ParseFileRefs parses references stored one per line, skipping invalid ones.
+func ParseFileRefs(s string) []FileRef {
+	var refs []FileRef
+	for _, line := range strings.Split(s, "\n") {
+		if ref, err := ParseFileRef(line); err == nil {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
@@ -0,0 +1,47 @@
+package domain
+
+import "testing"
+
+func TestParseFileRef(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    FileRef
+		wantErr bool
+	}{
+		{"internal/auth/token.go", FileRef{Path: "internal/auth/token.go"}, false},
+		{"token.go:40", FileRef{Path: "token.go", Start: 40, End: 40}, false},
+		{" token.go:40-58 ", FileRef{Path: "token.go", Start: 40, End: 58}, false},
+		{"", FileRef{}, true},
+		{":12", FileRef{}, true},
+		{"token.go:0", FileRef{}, true},
+		{"token.go:58-40", FileRef{}, true},
+		{"token.go:x", FileRef{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseFileRef(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFileRef(%q) = %+v, %v, want %+v (error %v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestFileRefs_RoundTrip(t *testing.T) {
+	refs := []FileRef{{Path: "a.go"}, {Path: "b.go", Start: 3, End: 3}, {Path: "c.go", Start: 5, End: 9}}
+
+	stored := FormatFileRefs(refs)
+	if stored != "a.go\nb.go:3\nc.go:5-9" {
+		t.Errorf("FormatFileRefs = %q", stored)
+	}
+	got := ParseFileRefs(stored)
+	if len(got) != len(refs) {
+		t.Fatalf("ParseFileRefs returned %d refs, want %d", len(got), len(refs))
+	}
+	for i := range refs {
+		if got[i] != refs[i] {
+			t.Errorf("ref %d = %+v, want %+v", i, got[i], refs[i])
+		}
+	}
+	if ParseFileRefs("") != nil {
+		t.Error("expected no refs from an empty string")
+	}
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrCommitSigning is returned when git could not sign a commit crAIzy asked it to create.
+var ErrCommitSigning = errors.New("commit signing failed")
+
+// ErrNothingToCommit is returned when an agent's worktree has no changes to commit.
+var ErrNothingToCommit = errors.New("nothing to commit")
+
+// AutoStashMessage labels the stashes crAIzy creates before merges and kills.
+const AutoStashMessage = "craizy-auto-stash"
+
+// StashEntry is a stash as listed by `git stash list`. Stashes are shared by
+// the repository and all its worktrees.
+type StashEntry struct {
+	Ref       string    // e.g. stash@{0}; shifts as stashes are added and removed
+	Branch    string    // branch the changes were stashed on
+	Message   string    // stash message, AutoStashMessage for crAIzy's own
+	CreatedAt time.Time // when the stash was made
+}
+
+// Worktree describes a git worktree as reported by `git worktree list`.
+type Worktree struct {
+	Path     string // absolute path of the worktree
+	Head     string // commit checked out
+	Branch   string // branch name without refs/heads/ (empty if detached)
+	Bare     bool   // the main worktree of a bare repository
+	Locked   bool   // protected from pruning
+	Prunable bool   // directory is missing and the entry can be pruned
+	Missing  bool   // directory no longer exists on disk (even if locked)
+}
+
+// StatusTarget names a worktree to collect a WorktreeStatus for.
+type StatusTarget struct {
+	Path       string // worktree path
+	BaseBranch string // branch to count ahead/behind and changes against, if any
+}
+
+// WorktreeStatus is a snapshot of a worktree's git state for the dashboard's indicators.
+type WorktreeStatus struct {
+	Path       string
+	Branch     string // branch checked out (empty if detached)
+	Dirty      bool   // uncommitted changes, including untracked files
+	Ahead      int    // commits not yet in the base branch
+	Behind     int    // base branch commits not yet in the worktree
+	Files      int    // files changed since the worktree left its base
+	Insertions int
+	Deletions  int
+	Err        error // the first failure collecting the status, leaving the rest zero
+}
+
+// Commit is a single commit as listed by `git log --oneline`.
+type Commit struct {
+	Hash    string // abbreviated commit hash
+	Subject string // first line of the commit message
+}
+
+// WorktreeOptions controls extra setup run after an agent worktree is checked out.
+type WorktreeOptions struct {
+	Submodules bool             // run `git submodule update --init --recursive`
+	LFS        bool             // run `git lfs pull`
+	Caches     []IWorktreeCache // share dependency and build caches from the project root
+	Template   bool             // clone worktrees from a template checkout, see RefreshTemplate
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "errors"
+
+// ErrDegraded is returned when an external command timed out or was skipped
+// because its dependency stopped responding.
+var ErrDegraded = errors.New("dependency not responding")
+
+// SetHealthChecks sets the dependencies reported by Degraded.
+func (s *AgentService) SetHealthChecks(checks ...IHealthCheck) {
+	s.health = checks
+}
+
+// Degraded describes every dependency that is currently not responding.
+func (s *AgentService) Degraded() []string {
+	var problems []string
+	for _, check := range s.health {
+		if problem := check.Degraded(); problem != "" {
+			problems = append(problems, problem)
+		}
+	}
+	return problems
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// humanPrefix addresses a named human, e.g. "human:alice". The bare
+// HumanParticipantID is the shared inbox of every human on the project.
+const humanPrefix = HumanParticipantID + ":"
+
+// humanNamePattern restricts human names to lowercase words usable in flags.
+var humanNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_.-]*$`)
+
+// HumanParticipant returns the participant ID of a named human, or the shared
+// HumanParticipantID if name is empty.
+func HumanParticipant(name string) string {
+	if name == "" {
+		return HumanParticipantID
+	}
+	return humanPrefix + name
+}
+
+// IsHumanParticipant reports whether id addresses a human, shared or named.
+func IsHumanParticipant(id string) bool {
+	return id == HumanParticipantID || strings.HasPrefix(id, humanPrefix)
+}
+
+// HumanName returns the name in a named human's ID, or "" for the shared
+// HumanParticipantID and agents.
+func HumanName(id string) string {
+	name, _ := strings.CutPrefix(id, humanPrefix)
+	if name == id {
+		return ""
+	}
+	return name
+}
+
+// SetHumans lists the named humans messages may address. When none are set,
+// any well-formed name is accepted.
+func (s *MessageService) SetHumans(names []string) {
+	s.humans = names
+}
+
+// SetNotifyHuman routes notifications about agents, such as budget stops and
+// escalated questions, to a named human rather than the shared inbox.
+func (s *MessageService) SetNotifyHuman(name string) {
+	s.notifyHuman = name
+}
+
+// NotifyHuman returns the participant ID notifications about agents go to.
+func (s *MessageService) NotifyHuman() string {
+	return HumanParticipant(s.notifyHuman)
+}
+
+// humanInboxes returns the shared inbox and, if notifications go to a named
+// human, theirs: the inboxes agents reach the human in charge through.
+func (s *MessageService) humanInboxes() []string {
+	if s.notifyHuman == "" {
+		return []string{HumanParticipantID}
+	}
+	return []string{HumanParticipantID, s.NotifyHuman()}
+}
+
+// ValidateHuman checks that a human participant ID is the shared inbox or a
+// well-formed name, listed if humans are configured.
+func (s *MessageService) ValidateHuman(id string) error {
+	if id == HumanParticipantID {
+		return nil
+	}
+	name := HumanName(id)
+	if !humanNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid human participant %q: use human:<name>", id)
+	}
+	if len(s.humans) > 0 && !slices.Contains(s.humans, name) {
+		return fmt.Errorf("unknown human participant %q: add %q to messages.humans", id, name)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHumanParticipant(t *testing.T) {
+	tests := []struct {
+		id      string
+		isHuman bool
+		name    string
+	}{
+		{HumanParticipantID, true, ""},
+		{HumanParticipant("alice"), true, "alice"},
+		{"claude-001", false, ""},
+		{"humanoid", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			if got := IsHumanParticipant(tt.id); got != tt.isHuman {
+				t.Errorf("IsHumanParticipant(%q) = %v, want %v", tt.id, got, tt.isHuman)
+			}
+			if got := HumanName(tt.id); got != tt.name {
+				t.Errorf("HumanName(%q) = %q, want %q", tt.id, got, tt.name)
+			}
+		})
+	}
+}
+
+func TestMessageService_NamedHumans(t *testing.T) {
+	newService := func() (*MessageService, *mockMessageStore) {
+		msgStore := newMockMessageStore()
+		agentStore := newTestStore()
+		agentStore.Add(&Agent{ID: "stuck-001", Status: AgentStatusTerminated})
+		return NewMessageService(msgStore, &mockTmuxClient{sessions: map[string]bool{}}, agentStore), msgStore
+	}
+
+	t.Run("messages a named human without delivering", func(t *testing.T) {
+		svc, _ := newService()
+
+		msg, err := svc.Send("worker-001", "human:alice", MessageTypeQuestion, "Review?", nil)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if msg.To != "human:alice" || msg.Read {
+			t.Errorf("message to %q read=%v, want unread for human:alice", msg.To, msg.Read)
+		}
+		if count, _ := svc.UnreadCount("human:alice"); count != 1 {
+			t.Errorf("alice's unread count = %d, want 1", count)
+		}
+		if count, _ := svc.UnreadCount(HumanParticipantID); count != 0 {
+			t.Errorf("shared unread count = %d, want 0", count)
+		}
+	})
+
+	t.Run("rejects humans outside the configured list", func(t *testing.T) {
+		svc, _ := newService()
+		svc.SetHumans([]string{"alice"})
+
+		if _, err := svc.Send("worker-001", "human:bob", MessageTypeInfo, "Hi", nil); err == nil {
+			t.Error("expected error for unlisted human")
+		}
+		if _, err := svc.Send("human:Alice Smith", "worker-001", MessageTypeInfo, "Hi", nil); err == nil {
+			t.Error("expected error for malformed human name")
+		}
+		if _, err := svc.Send("worker-001", HumanParticipantID, MessageTypeInfo, "Hi", nil); err != nil {
+			t.Errorf("shared inbox rejected: %v", err)
+		}
+	})
+
+	t.Run("routes escalations to the notified human", func(t *testing.T) {
+		now := time.Now()
+		svc, msgStore := newService()
+		svc.SetNotifyHuman("alice")
+		svc.SetEscalation(time.Minute)
+		msgStore.messages["q"] = &Message{ID: "q", Seq: 1, From: "worker-001", To: "stuck-001", Type: MessageTypeQuestion, Content: "Which?", CreatedAt: now.Add(-time.Hour)}
+
+		escalated, err := svc.EscalateUnanswered(now)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(escalated) != 1 || escalated[0].To != "human:alice" {
+			t.Errorf("escalated = %v, want one copy to human:alice", escalated)
+		}
+	})
+}
@@ -1,11 +1,15 @@
 package domain
 
-import "os/exec"
+import (
+	"os/exec"
+	"time"
+)
 
 // ITmuxClient defines the interface for tmux operations.
 type ITmuxClient interface {
-	// CreateSession creates a new detached tmux session.
-	CreateSession(id, command, workDir string) error
+	// CreateSession creates a new detached tmux session running command in
+	// workDir, with env added to its environment.
+	CreateSession(id, command, workDir string, env map[string]string) error
 
 	// KillSession terminates a tmux session.
 	KillSession(id string) error
@@ -16,6 +20,10 @@ type ITmuxClient interface {
 	// AttachCmd returns an exec.Cmd that can be used to attach to a session.
 	AttachCmd(id string) *exec.Cmd
 
+	// AttachReadOnlyCmd returns an exec.Cmd that attaches to a session without
+	// forwarding keystrokes to it.
+	AttachReadOnlyCmd(id string) *exec.Cmd
+
 	// SessionExists checks if a tmux session exists.
 	SessionExists(id string) bool
 
@@ -24,6 +32,31 @@ type ITmuxClient interface {
 
 	// SendKeys sends text/commands to a tmux session.
 	SendKeys(sessionID, text string) error
+
+	// RenameSession renames a tmux session.
+	RenameSession(oldID, newID string) error
+
+	// LastActivity returns when a tmux session last produced output.
+	LastActivity(sessionID string) (time.Time, error)
+
+	// PipeOutput records everything the session prints to the transcript file at path.
+	PipeOutput(sessionID, path string) error
+
+	// PauseSession suspends every process running in a session with SIGSTOP.
+	PauseSession(id string) error
+
+	// ResumeSession continues a paused session's processes with SIGCONT.
+	ResumeSession(id string) error
+
+	// ExitStatus returns the exit status of a session's command once it has
+	// exited on its own. ok is false while it runs or if it was killed.
+	ExitStatus(id string) (code int, ok bool)
+}
+
+// ISessionSwitcher moves the tmux client the dashboard runs in between sessions.
+type ISessionSwitcher interface {
+	// SwitchClient switches the current tmux client to the session with the given ID.
+	SwitchClient(id string) error
 }
 
 // IGitClient defines the interface for git operations.
@@ -44,12 +77,27 @@ type IGitClient interface {
 	// If the branch doesn't exist, it creates it from baseBranch.
 	CreateWorktree(path, branch, baseBranch string) error
 
+	// CreateSparseWorktree creates a worktree like CreateWorktree but only checks out
+	// the given directories using cone-mode sparse checkout.
+	CreateSparseWorktree(path, branch, baseBranch string, paths []string) error
+
+	// RefreshTemplate checks out baseBranch, detached, in the template worktree at
+	// path, creating it if needed and discarding anything else in it.
+	RefreshTemplate(path, baseBranch string) error
+
+	// CloneWorktree creates a worktree like CreateWorktree but copies the files from
+	// the template worktree, checking out only those that differ from baseBranch.
+	CloneWorktree(template, path, branch, baseBranch string) error
+
 	// RemoveWorktree removes the worktree at the given path.
 	RemoveWorktree(path string) error
 
 	// DeleteBranch deletes a branch from the repository.
 	DeleteBranch(branch string) error
 
+	// RenameBranch renames a branch, including one checked out in a worktree.
+	RenameBranch(oldName, newName string) error
+
 	// HasUncommittedChanges checks if the worktree at path has uncommitted changes.
 	HasUncommittedChanges(path string) bool
 
@@ -59,17 +107,145 @@ type IGitClient interface {
 	// Stash stashes changes in the worktree at path.
 	Stash(path string) error
 
+	// Add stages every change in the worktree at path, including untracked files.
+	Add(path string) error
+
+	// Commit commits the changes staged in the worktree at path with message.
+	Commit(path, message string) error
+
 	// StashPop pops the stash in the worktree at path.
 	StashPop(path string) error
 
+	// ListStashes returns every stash in the repository, newest first.
+	ListStashes() ([]StashEntry, error)
+
+	// PopStashRef applies the stash ref to the worktree at path and drops it.
+	PopStashRef(path, ref string) error
+
+	// StashBranch creates branch from the commit the stash ref was made on,
+	// checks it out in the project root, applies the stash and drops it.
+	StashBranch(ref, branch string) error
+
+	// DropStash deletes the stash ref.
+	DropStash(ref string) error
+
 	// Merge merges the given branch into the current branch.
 	Merge(branch string) error
 
+	// Squash stages the changes of branch on the current branch and commits them
+	// as one commit with message. It commits nothing if there are no changes.
+	Squash(branch, message string) error
+
+	// Rebase rebases branch, checked out at path, onto baseBranch and
+	// fast-forwards the current branch to it. On conflict the rebase is aborted
+	// and an error is returned.
+	Rebase(path, baseBranch, branch string) error
+
 	// MergeAbort aborts an in-progress merge.
 	MergeAbort() error
 
 	// MergeConflictFiles returns the list of files with merge conflicts.
 	MergeConflictFiles() ([]string, error)
+
+	// AheadBehind returns how many commits branch is ahead of and behind baseBranch.
+	AheadBehind(branch, baseBranch string) (ahead, behind int, err error)
+
+	// CommitsAhead returns the commits on branch that are not on baseBranch, newest first.
+	CommitsAhead(branch, baseBranch string) ([]Commit, error)
+
+	// ShowCommit returns the stat summary and patch of a commit.
+	ShowCommit(hash string) (string, error)
+
+	// DiffStat returns the stat summary of the changes on branch since it left baseBranch.
+	DiffStat(branch, baseBranch string) (string, error)
+
+	// Diff returns the patch of the changes on branch since it left baseBranch,
+	// run in the worktree at path.
+	Diff(path, baseBranch, branch string) (string, error)
+
+	// BatchStatus collects branch, dirty state, ahead/behind and diff stats for
+	// many worktrees at once, in parallel. Statuses are in the order of targets.
+	BatchStatus(targets []StatusTarget) []WorktreeStatus
+
+	// Push pushes the given branch to the origin remote and sets upstream.
+	Push(branch string) error
+
+	// RebaseOnto rebases the branch checked out at path from oldBase onto newBase.
+	// On conflict the rebase is aborted and an error is returned.
+	RebaseOnto(path, newBase, oldBase string) error
+
+	// ListWorktrees returns all worktrees registered in the repository.
+	ListWorktrees() ([]Worktree, error)
+
+	// PruneWorktrees removes registrations for worktrees whose directories no longer exist.
+	PruneWorktrees() error
+
+	// LockWorktree locks the worktree at path so it cannot be pruned or removed.
+	LockWorktree(path, reason string) error
+
+	// UnlockWorktree unlocks the worktree at path.
+	UnlockWorktree(path string) error
+
+	// UpdateSubmodules initializes and updates submodules recursively in the worktree at path.
+	UpdateSubmodules(path string) error
+
+	// LFSPull fetches and checks out Git LFS objects in the worktree at path.
+	LFSPull(path string) error
+}
+
+// IGitHubClient defines the interface for GitHub operations.
+type IGitHubClient interface {
+	// IsBranchProtected checks if the branch has protection rules on the remote.
+	IsBranchProtected(branch string) (bool, error)
+
+	// CreatePullRequest opens a pull request from branch into baseBranch and returns its URL.
+	CreatePullRequest(branch, baseBranch string) (string, error)
+}
+
+// ISummarizer produces short summaries of completed agent work.
+type ISummarizer interface {
+	// Summarize returns a short summary of the given context.
+	Summarize(input string) (string, error)
+}
+
+// IWorktreeCache shares a dependency or build cache from the project root with a
+// new agent worktree, so agents don't re-download dependencies from scratch.
+type IWorktreeCache interface {
+	// Share sets up the cache in the worktree at worktree.
+	Share(projectRoot, worktree string) error
+
+	// Name describes the cache in logs.
+	Name() string
+}
+
+// IFreshGitProbe is implemented by git clients that cache
+// HasUncommittedChanges. HasUncommittedChangesFresh probes past the cache, for
+// checks guarding work that would otherwise be lost.
+type IFreshGitProbe interface {
+	HasUncommittedChangesFresh(path string) bool
+}
+
+// IDeliveryTransport hands messages to a running agent. Agent types pick one
+// in AGENTS.yml; without one, messages are typed into the agent's session.
+type IDeliveryTransport interface {
+	// Deliver hands text, a formatted message or notification, to agent.
+	Deliver(agent *Agent, text string) error
+
+	// Nudge hands agent text, a one-line notice of unread messages, as the
+	// only notification for agents in nudge delivery mode.
+	Nudge(agent *Agent, text string) error
+
+	// Name describes the transport in logs.
+	Name() string
+}
+
+// IDevEnvironment runs agents with the development environment a worktree
+// declares, such as a direnv .envrc or a dev container, so they use the same
+// toolchain as developers.
+type IDevEnvironment interface {
+	// Prepare sets up the worktree's environment and returns command adjusted to
+	// run inside it, plus warnings about environments found but not used.
+	Prepare(worktree, command string) (string, []string)
 }
 
 // IAgentStore defines the interface for agent persistence.
@@ -91,11 +267,29 @@ type IAgentStore interface {
 
 	// UpdateStatus updates the status of an agent.
 	UpdateStatus(id string, status AgentStatus) error
+
+	// UpdateBaseBranch updates the base branch an agent's work targets.
+	UpdateBaseBranch(id, baseBranch string) error
+
+	// UpdateSummary stores a short summary of an agent's completed work.
+	UpdateSummary(id, summary string) error
+
+	// AddAttachedTime adds to the total time the human has spent attached to an agent.
+	AddAttachedTime(id string, d time.Duration) error
+
+	// AddPausedTime adds to the total time an agent has spent paused.
+	AddPausedTime(id string, d time.Duration) error
+
+	// AddRestart counts an automatic restart of an agent after its session died.
+	AddRestart(id string) error
+
+	// SetMetadata sets a metadata value on an agent, deleting the key if value is empty.
+	SetMetadata(id, key, value string) error
 }
 
 // IMessageStore defines the interface for message persistence.
 type IMessageStore interface {
-	// Save stores a new message.
+	// Save stores a new message and sets its Seq.
 	Save(msg *Message) error
 
 	// MarkRead marks a message as read.
@@ -104,12 +298,89 @@ type IMessageStore interface {
 	// ListUnread returns all unread messages for a recipient.
 	ListUnread(recipientID string) ([]*Message, error)
 
-	// List returns messages for a recipient with a limit (0 = no limit).
-	List(recipientID string, limit int) ([]*Message, error)
+	// List returns up to limit messages for a recipient (0 = no limit), newest
+	// first. A nonzero before is a cursor: only messages with a lower Seq are
+	// returned, so passing the last Seq of one page fetches the next.
+	List(recipientID string, before, limit int) ([]*Message, error)
+
+	// ListSent pages through the messages a participant sent, like List.
+	ListSent(senderID string, before, limit int) ([]*Message, error)
 
 	// Get retrieves a message by ID.
 	Get(id string) (*Message, error)
 
 	// UnreadCount returns the count of unread messages for a recipient.
 	UnreadCount(recipientID string) (int, error)
+
+	// UnreadCountsByRecipient returns the count of unread messages for every
+	// recipient that has any, in one query.
+	UnreadCountsByRecipient() (map[string]int, error)
+
+	// ListThread returns messages sent to or from a participant, oldest first.
+	ListThread(participantID string) ([]*Message, error)
+
+	// ListByIDPrefix returns up to limit messages whose ID starts with prefix.
+	ListByIDPrefix(prefix string, limit int) ([]*Message, error)
+
+	// GetBySeq retrieves a message by its short sequence number.
+	GetBySeq(seq int) (*Message, error)
+
+	// ListDue returns unread scheduled messages due by now, oldest first.
+	// Recipients' listings and unread counts leave scheduled messages out
+	// until they are due.
+	ListDue(now time.Time) ([]*Message, error)
+
+	// ListUnescalatedQuestions returns unread questions from one agent to
+	// another that haven't been escalated to the human, oldest first.
+	ListUnescalatedQuestions() ([]*Message, error)
+
+	// MarkEscalated records that a message has been escalated to the human.
+	MarkEscalated(id string) error
+}
+
+// IAliasStore defines the interface for participant alias persistence.
+type IAliasStore interface {
+	// Set stores an alias, replacing any of the same name.
+	Set(alias *Alias) error
+
+	// Get retrieves an alias by name, or nil if there is none.
+	Get(name string) (*Alias, error)
+
+	// Delete removes an alias, failing if there is none of that name.
+	Delete(name string) error
+
+	// List returns every alias, by name.
+	List() ([]*Alias, error)
+}
+
+// IMergeStore defines the interface for merge history persistence.
+type IMergeStore interface {
+	// Save stores a merge record.
+	Save(record *MergeRecord) error
+
+	// ListByAgent returns merge records for an agent, newest first.
+	ListByAgent(agentID string) ([]*MergeRecord, error)
+
+	// List returns merge records across all agents with a limit (0 = no limit), newest first.
+	List(limit int) ([]*MergeRecord, error)
+}
+
+// IProgressReporter receives the stages of long-running operations, such as
+// worktree creation, merges and pushes, so a UI can show they're in progress.
+// Report must not block.
+type IProgressReporter interface {
+	Report(progress OperationProgress)
+}
+
+// IBackupper takes periodic backups of persisted state.
+type IBackupper interface {
+	// BackupIfDue takes a backup if the last is older than the backup interval,
+	// returning its path, or "" if none was due.
+	BackupIfDue(now time.Time) (string, error)
+}
+
+// IHealthCheck reports whether an external dependency, such as the tmux server, is degraded.
+type IHealthCheck interface {
+	// Degraded describes the problem, or returns "" when healthy.
+	Degraded() string
 }
@@ -0,0 +1,135 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// Match strengths for MatchAgents, strongest first.
+const (
+	matchNone = iota
+	matchSubsequence
+	matchSubstring
+	matchPrefix
+	matchExact
+)
+
+// MatchAgents returns the agents whose name or ID matches query, best matches
+// first. Matching ignores case and accepts, in order of preference, an exact
+// name or ID, a prefix, a substring, or the query's characters in order (so
+// "fxt" matches "fix-typo"). An empty query matches every agent.
+func MatchAgents(agents []*Agent, query string) []*Agent {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return agents
+	}
+
+	type scored struct {
+		agent *Agent
+		score int
+	}
+	var matches []scored
+	for _, agent := range agents {
+		score := max(matchScore(strings.ToLower(agent.Name), query), matchScore(strings.ToLower(agent.ID), query))
+		if score != matchNone {
+			matches = append(matches, scored{agent, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	result := make([]*Agent, len(matches))
+	for i, m := range matches {
+		result[i] = m.agent
+	}
+	return result
+}
+
+// matchScore rates how well query matches text.
+func matchScore(text, query string) int {
+	switch {
+	case text == query:
+		return matchExact
+	case strings.HasPrefix(text, query):
+		return matchPrefix
+	case strings.Contains(text, query):
+		return matchSubstring
+	case isSubsequence(text, query):
+		return matchSubsequence
+	}
+	return matchNone
+}
+
+// isSubsequence reports whether query's characters appear in text in order.
+func isSubsequence(text, query string) bool {
+	rest := []rune(query)
+	for _, r := range text {
+		if len(rest) > 0 && r == rest[0] {
+			rest = rest[1:]
+		}
+	}
+	return len(rest) == 0
+}
+
+// AmbiguousError is returned when an ID fragment matches more than one item.
+type AmbiguousError struct {
+	Query      string
+	Candidates []string
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("%q is ambiguous, it matches: %s", e.Query, strings.Join(e.Candidates, ", "))
+}
+
+// ResolveAgentID finds the agent query refers to: an exact ID, or else the one
+// agent whose name or ID best matches by exact name, prefix or substring. It
+// returns an *AmbiguousError listing the candidates when several match equally.
+func ResolveAgentID(agents []*Agent, query string) (string, error) {
+	q := strings.ToLower(strings.TrimSpace(query))
+	var best []*Agent
+	bestScore := matchNone
+	for _, agent := range agents {
+		if agent.ID == query {
+			return agent.ID, nil
+		}
+		score := max(matchScore(strings.ToLower(agent.Name), q), matchScore(strings.ToLower(agent.ID), q))
+		if score <= matchSubsequence || score < bestScore {
+			continue
+		}
+		if score > bestScore {
+			best, bestScore = nil, score
+		}
+		best = append(best, agent)
+	}
+
+	switch len(best) {
+	case 0:
+		return "", fmt.Errorf("agent %q not found", query)
+	case 1:
+		return best[0].ID, nil
+	}
+	candidates := make([]string, len(best))
+	for i, agent := range best {
+		candidates[i] = agent.ID
+	}
+	sort.Strings(candidates)
+	return "", &AmbiguousError{Query: query, Candidates: candidates}
+}
+
+// ResolveAgent finds the project agent query refers to, in any status; see ResolveAgentID.
+func (s *AgentService) ResolveAgent(query string) (string, error) {
+	logging.Entry("query", query)
+	var agents []*Agent
+	for _, agent := range s.store.List() {
+		if agent.Project == s.project {
+			agents = append(agents, agent)
+		}
+	}
+	id, err := ResolveAgentID(agents, query)
+	if err != nil {
+		logging.Debug("agent not resolved, query=%s, err=%v", query, err)
+	}
+	return id, err
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMatchAgents(t *testing.T) {
+	agents := []*Agent{
+		{ID: "craizy-proj-claude-fix-typo", Name: "fix-typo"},
+		{ID: "craizy-proj-codex-fix", Name: "fix"},
+		{ID: "craizy-proj-claude-docs", Name: "docs"},
+	}
+	names := func(agents []*Agent) []string {
+		var out []string
+		for _, a := range agents {
+			out = append(out, a.Name)
+		}
+		return out
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"", []string{"fix-typo", "fix", "docs"}},
+		{"fix", []string{"fix", "fix-typo"}},   // exact before prefix
+		{"TYPO", []string{"fix-typo"}},         // substring, any case
+		{"fxt", []string{"fix-typo"}},          // characters in order
+		{"craizy-proj-codex", []string{"fix"}}, // ID prefix
+		{"zzz", nil},
+	}
+	for _, tt := range tests {
+		got := names(MatchAgents(agents, tt.query))
+		if len(got) != len(tt.want) {
+			t.Errorf("MatchAgents(%q) = %v, want %v", tt.query, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("MatchAgents(%q) = %v, want %v", tt.query, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestResolveAgentID(t *testing.T) {
+	agents := []*Agent{
+		{ID: "craizy-proj-claude-fix-typo", Name: "fix-typo"},
+		{ID: "craizy-proj-codex-fix", Name: "fix"},
+		{ID: "craizy-proj-claude-docs", Name: "docs"},
+		{ID: "craizy-proj-claude-docs-api", Name: "docs-api"},
+	}
+
+	tests := []struct {
+		query     string
+		want      string
+		ambiguous bool
+	}{
+		{"craizy-proj-claude-docs", "craizy-proj-claude-docs", false}, // exact ID wins
+		{"craizy-proj-codex", "craizy-proj-codex-fix", false},         // unique ID prefix
+		{"typo", "craizy-proj-claude-fix-typo", false},                // name fragment
+		{"fix", "craizy-proj-codex-fix", false},                       // exact name beats prefixes
+		{"doc", "", true},
+		{"fxt", "", false}, // subsequences are too loose to act on
+	}
+	for _, tt := range tests {
+		got, err := ResolveAgentID(agents, tt.query)
+		var ambiguous *AmbiguousError
+		if tt.ambiguous {
+			if !errors.As(err, &ambiguous) {
+				t.Errorf("ResolveAgentID(%q) error = %v, want AmbiguousError", tt.query, err)
+			}
+			continue
+		}
+		if tt.want == "" {
+			if err == nil {
+				t.Errorf("ResolveAgentID(%q) = %q, want not found", tt.query, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ResolveAgentID(%q) = %q, %v, want %q", tt.query, got, err, tt.want)
+		}
+	}
+}
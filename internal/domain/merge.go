@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MergeStrategy represents how an agent's branch is integrated into its base.
+type MergeStrategy string
+
+const (
+	MergeStrategyMerge  MergeStrategy = "merge"  // Plain merge commit
+	MergeStrategySquash MergeStrategy = "squash" // The branch's changes as one commit on base
+	MergeStrategyRebase MergeStrategy = "rebase" // Branch rebased onto base, then fast-forwarded
+)
+
+// MergeStrategies lists the merge strategies in the order the merge flow offers them.
+var MergeStrategies = []MergeStrategy{MergeStrategyMerge, MergeStrategySquash, MergeStrategyRebase}
+
+// IsValidMergeStrategy reports whether s is a known merge strategy.
+func IsValidMergeStrategy(s string) bool {
+	for _, strategy := range MergeStrategies {
+		if string(strategy) == s {
+			return true
+		}
+	}
+	return false
+}
+
+// MergePreview describes what merging an agent's branch would land on its base.
+type MergePreview struct {
+	Branch     string
+	BaseBranch string
+	Strategy   MergeStrategy
+	Commits    []Commit // newest first
+	DiffStat   string   // `git diff --stat` of the changes
+}
+
+// MergeOutcome represents the result of a merge attempt.
+type MergeOutcome string
+
+const (
+	MergeOutcomeSuccess  MergeOutcome = "success"  // Branch landed on base
+	MergeOutcomeConflict MergeOutcome = "conflict" // Merge stopped on conflicts and was left for resolution
+)
+
+// MergeRecord is a historical entry describing one merge attempt for an agent.
+type MergeRecord struct {
+	ID            string        // Unique identifier (UUID)
+	AgentID       string        // Agent whose branch was merged
+	Branch        string        // Agent branch
+	BaseBranch    string        // Branch merged into
+	Strategy      MergeStrategy // How the merge was performed
+	Outcome       MergeOutcome  // Result of the attempt
+	ConflictFiles []string      // Files in conflict (empty on success)
+	Duration      time.Duration // Wall-clock time spent merging
+	CreatedAt     time.Time     // When the merge was attempted
+}
+
+// NewMergeRecord creates a new merge record with a generated UUID.
+func NewMergeRecord(agentID, branch, baseBranch string, strategy MergeStrategy) *MergeRecord {
+	return &MergeRecord{
+		ID:         uuid.New().String(),
+		AgentID:    agentID,
+		Branch:     branch,
+		BaseBranch: baseBranch,
+		Strategy:   strategy,
+		CreatedAt:  time.Now(),
+	}
+}
@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -41,14 +44,17 @@ func IsValidMessageType(t string) bool {
 // Message represents a message between agents or between agents and humans.
 type Message struct {
 	ID          string      // Unique identifier (UUID)
+	Seq         int         // Short sequence number assigned by the store on save; see Code
 	From        string      // Sender ID (tmux session name or "human")
 	To          string      // Recipient ID (tmux session name or "human")
 	Type        MessageType // Message type/intent
 	Content     string      // Message content
 	RelatedWork *string     // Optional work item reference
+	Refs        []FileRef   // Files or lines the message is about
 	Read        bool        // Whether the message has been read
 	CreatedAt   time.Time   // When the message was sent
 	ReadAt      *time.Time  // When the message was read (nil if unread)
+	DeliverAt   *time.Time  // When a scheduled message is due (nil to deliver on send)
 }
 
 // NewMessage creates a new message with a generated UUID.
@@ -65,5 +71,58 @@ func NewMessage(from, to string, msgType MessageType, content string, relatedWor
 	}
 }
 
-// HumanParticipantID is the reserved ID for human participants.
+// Due reports whether the message should be delivered by now: it isn't
+// scheduled, or its delivery time has come.
+func (m *Message) Due(now time.Time) bool {
+	return m.DeliverAt == nil || !m.DeliverAt.After(now)
+}
+
+// messageCodePrefix starts the short reference code of every message.
+const messageCodePrefix = "M-"
+
+// Code returns the message's short reference code, e.g. "M-1042", or its ID
+// if it hasn't been saved yet.
+func (m *Message) Code() string {
+	if m.Seq == 0 {
+		return m.ID
+	}
+	return fmt.Sprintf("%s%d", messageCodePrefix, m.Seq)
+}
+
+// ParseMessageCode returns the sequence number in a short reference code like
+// "M-1042" (any case), and whether s is one.
+func ParseMessageCode(s string) (int, bool) {
+	if len(s) <= len(messageCodePrefix) || !strings.EqualFold(s[:len(messageCodePrefix)], messageCodePrefix) {
+		return 0, false
+	}
+	seq, err := strconv.Atoi(s[len(messageCodePrefix):])
+	if err != nil || seq <= 0 {
+		return 0, false
+	}
+	return seq, true
+}
+
+// ParseDeliverAt parses when a scheduled message should be delivered, relative
+// to now: a time of day like "09:00" means its next occurrence, and a date and
+// time is taken as "2006-01-02 15:04" in now's location or as RFC 3339.
+func ParseDeliverAt(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if clock, err := time.ParseInLocation("15:04", s, now.Location()); err == nil {
+		at := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+		if !at.After(now) {
+			at = at.AddDate(0, 0, 1)
+		}
+		return at, nil
+	}
+	if at, err := time.ParseInLocation("2006-01-02 15:04", s, now.Location()); err == nil {
+		return at, nil
+	}
+	if at, err := time.Parse(time.RFC3339, s); err == nil {
+		return at, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid delivery time %q: use HH:MM, \"YYYY-MM-DD HH:MM\" or RFC 3339", s)
+}
+
+// HumanParticipantID is the reserved ID for human participants: the shared
+// inbox, or with a name, one person's (see HumanParticipant).
 const HumanParticipantID = "human"
@@ -2,6 +2,9 @@ package domain
 
 import (
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/TechnicallyShaun/crAIzy/internal/logging"
 )
@@ -11,6 +14,16 @@ type MessageService struct {
 	store  IMessageStore
 	tmux   ITmuxClient
 	agents IAgentStore
+
+	aliases       IAliasStore   // optional, see SetAliasStore
+	escalateAfter time.Duration // see SetEscalation
+	humans        []string      // named humans, see SetHumans
+	notifyHuman   string        // see SetNotifyHuman
+
+	transports map[string]IDeliveryTransport // by lowercased agent type, see SetTransport
+	modes      map[string]DeliveryMode       // by lowercased agent type, see SetDeliveryMode
+	nudged     map[string]bool               // scheduled messages DeliverDue has nudged about
+	nudgedMu   sync.Mutex
 }
 
 // NewMessageService creates a new MessageService with the given dependencies.
@@ -26,15 +39,40 @@ func NewMessageService(store IMessageStore, tmux ITmuxClient, agents IAgentStore
 // If the recipient is active (has a tmux session), the message is delivered immediately.
 // Otherwise, it is queued for delivery on startup.
 func (s *MessageService) Send(from, to string, msgType MessageType, content string, relatedWork *string) (*Message, error) {
-	logging.Entry("from", from, "to", to, "type", msgType)
+	return s.SendWithRefs(from, to, msgType, content, relatedWork, nil)
+}
+
+// SendWithRefs creates and delivers a message that points at files or lines.
+func (s *MessageService) SendWithRefs(from, to string, msgType MessageType, content string, relatedWork *string, refs []FileRef) (*Message, error) {
+	return s.ScheduleWithRefs(from, to, msgType, content, relatedWork, refs, time.Time{})
+}
+
+// ScheduleWithRefs creates a message to be delivered at deliverAt rather than
+// straight away; a zero deliverAt sends it now. Until it is due it only shows
+// in the sender's sent messages, and DeliverDue delivers it once it is.
+func (s *MessageService) ScheduleWithRefs(from, to string, msgType MessageType, content string, relatedWork *string, refs []FileRef, deliverAt time.Time) (*Message, error) {
+	logging.Entry("from", from, "to", to, "type", msgType, "refs", len(refs), "deliverAt", deliverAt)
+	from, to = s.ResolveParticipant(from), s.ResolveParticipant(to)
 
 	if !IsValidMessageType(string(msgType)) {
 		err := fmt.Errorf("invalid message type: %s", msgType)
 		logging.Error(err, "type", msgType)
 		return nil, err
 	}
+	for _, participant := range []string{from, to} {
+		if IsHumanParticipant(participant) {
+			if err := s.ValidateHuman(participant); err != nil {
+				logging.Error(err, "participant", participant)
+				return nil, err
+			}
+		}
+	}
 
 	msg := NewMessage(from, to, msgType, content, relatedWork)
+	msg.Refs = refs
+	if !deliverAt.IsZero() {
+		msg.DeliverAt = &deliverAt
+	}
 
 	// 1. Persist to DB
 	if err := s.store.Save(msg); err != nil {
@@ -42,8 +80,11 @@ func (s *MessageService) Send(from, to string, msgType MessageType, content stri
 		return nil, fmt.Errorf("failed to save message: %w", err)
 	}
 
-	// 2. If recipient is active, deliver immediately
-	if s.isActive(to) {
+	// 2. If recipient is active, deliver immediately unless scheduled for later.
+	// Nudged recipients are only told about it, and read it themselves.
+	if msg.Due(time.Now()) && s.isActive(to) && s.recipientNudges(to) {
+		s.nudge(to)
+	} else if msg.Due(time.Now()) && s.isActive(to) {
 		s.deliverToTmux(msg)
 		if err := s.store.MarkRead(msg.ID); err != nil {
 			// Log but don't fail - message is saved
@@ -62,10 +103,59 @@ func (s *MessageService) ListUnread(recipientID string) ([]*Message, error) {
 	return s.store.ListUnread(recipientID)
 }
 
-// List returns messages for a recipient with a limit (0 = no limit).
-func (s *MessageService) List(recipientID string, limit int) ([]*Message, error) {
-	logging.Entry("recipientID", recipientID, "limit", limit)
-	return s.store.List(recipientID, limit)
+// List returns up to limit messages for a recipient (0 = no limit), newest
+// first. A nonzero before is the Seq to continue below, as from the last
+// message of the previous page.
+func (s *MessageService) List(recipientID string, before, limit int) ([]*Message, error) {
+	logging.Entry("recipientID", recipientID, "before", before, "limit", limit)
+	return s.store.List(recipientID, before, limit)
+}
+
+// ListSent returns up to limit messages sent by a participant, like List.
+func (s *MessageService) ListSent(senderID string, before, limit int) ([]*Message, error) {
+	logging.Entry("senderID", senderID, "before", before, "limit", limit)
+	return s.store.ListSent(senderID, before, limit)
+}
+
+// Page returns the given 1-based page of a recipient's messages, newest first,
+// size messages to a page. It follows the cursor from page to page rather than
+// skipping rows, so a page stays stable while messages arrive.
+func (s *MessageService) Page(recipientID string, page, size int) ([]*Message, error) {
+	logging.Entry("recipientID", recipientID, "page", page, "size", size)
+	return pageMessages(s.store.List, recipientID, page, size)
+}
+
+// SentPage returns the given 1-based page of the messages a participant sent, like Page.
+func (s *MessageService) SentPage(senderID string, page, size int) ([]*Message, error) {
+	logging.Entry("senderID", senderID, "page", page, "size", size)
+	return pageMessages(s.store.ListSent, senderID, page, size)
+}
+
+// pageMessages walks list's cursor to the given page.
+func pageMessages(list func(id string, before, limit int) ([]*Message, error), id string, page, size int) ([]*Message, error) {
+	if page < 1 || size < 1 {
+		return nil, fmt.Errorf("invalid page %d of size %d", page, size)
+	}
+
+	before := 0
+	for ; page > 1; page-- {
+		skipped, err := list(id, before, size)
+		if err != nil {
+			logging.Error(err, "id", id)
+			return nil, err
+		}
+		if len(skipped) < size {
+			return nil, nil
+		}
+		before = skipped[len(skipped)-1].Seq
+	}
+	return list(id, before, size)
+}
+
+// Thread returns all messages sent to or from a participant, oldest first.
+func (s *MessageService) Thread(participantID string) ([]*Message, error) {
+	logging.Entry("participantID", participantID)
+	return s.store.ListThread(participantID)
 }
 
 // Read retrieves a message and marks it as read.
@@ -88,12 +178,74 @@ func (s *MessageService) Read(messageID string) (*Message, error) {
 	return msg, nil
 }
 
+// resolveCandidates caps how many messages ResolveID lists in an ambiguity error.
+const resolveCandidates = 10
+
+// ResolveID returns the ID of the message id refers to, which may be its short
+// code (e.g. "M-1042") or a unique prefix of the full ID. It returns an
+// *AmbiguousError when several match.
+func (s *MessageService) ResolveID(id string) (string, error) {
+	logging.Entry("id", id)
+	if msg, err := s.store.Get(id); err == nil {
+		return msg.ID, nil
+	}
+	if seq, ok := ParseMessageCode(id); ok {
+		msg, err := s.store.GetBySeq(seq)
+		if err != nil {
+			return "", fmt.Errorf("message not found: %s", id)
+		}
+		return msg.ID, nil
+	}
+
+	matches, err := s.store.ListByIDPrefix(id, resolveCandidates)
+	if err != nil {
+		logging.Error(err, "id", id)
+		return "", err
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("message not found: %s", id)
+	case 1:
+		return matches[0].ID, nil
+	}
+	candidates := make([]string, len(matches))
+	for i, msg := range matches {
+		candidates[i] = msg.ID
+	}
+	return "", &AmbiguousError{Query: id, Candidates: candidates}
+}
+
+// ResolveSeq resolves a message ID, ID prefix or code to its Seq, for use as a
+// List cursor.
+func (s *MessageService) ResolveSeq(id string) (int, error) {
+	logging.Entry("id", id)
+	if seq, ok := ParseMessageCode(id); ok {
+		return seq, nil
+	}
+	messageID, err := s.ResolveID(id)
+	if err != nil {
+		return 0, err
+	}
+	msg, err := s.store.Get(messageID)
+	if err != nil {
+		logging.Error(err, "messageID", messageID)
+		return 0, err
+	}
+	return msg.Seq, nil
+}
+
 // UnreadCount returns the count of unread messages for a recipient.
 func (s *MessageService) UnreadCount(recipientID string) (int, error) {
 	logging.Entry("recipientID", recipientID)
 	return s.store.UnreadCount(recipientID)
 }
 
+// UnreadCounts returns the count of unread messages for every recipient that has any.
+func (s *MessageService) UnreadCounts() (map[string]int, error) {
+	logging.Entry()
+	return s.store.UnreadCountsByRecipient()
+}
+
 // MarkRead marks a message as read.
 // This is exposed for startup delivery in AgentService.
 func (s *MessageService) MarkRead(messageID string) error {
@@ -101,13 +253,63 @@ func (s *MessageService) MarkRead(messageID string) error {
 	return s.store.MarkRead(messageID)
 }
 
+// DeliverDue delivers the scheduled messages that have come due to recipients
+// with a running session, returning how many it delivered. The rest stay
+// unread: the human sees them in the inbox, and stopped agents get them with
+// their queued messages on their next start. Recipients sent nudges are nudged
+// once about each message, which stays unread for them to read.
+func (s *MessageService) DeliverDue() (int, error) {
+	logging.Entry()
+	due, err := s.store.ListDue(time.Now())
+	if err != nil {
+		logging.Error(err)
+		return 0, fmt.Errorf("failed to list due messages: %w", err)
+	}
+
+	delivered := 0
+	nudged := make(map[string]bool) // recipients nudged this time
+	for _, msg := range due {
+		if !s.isActive(msg.To) {
+			continue
+		}
+		if s.recipientNudges(msg.To) {
+			// Nudged messages stay unread, so nudge about each only once
+			s.nudgedMu.Lock()
+			seen := s.nudged[msg.ID]
+			if s.nudged == nil {
+				s.nudged = make(map[string]bool)
+			}
+			s.nudged[msg.ID] = true
+			s.nudgedMu.Unlock()
+			if !seen {
+				if !nudged[msg.To] {
+					s.nudge(msg.To)
+					nudged[msg.To] = true
+				}
+				delivered++
+			}
+			continue
+		}
+		s.deliverToTmux(msg)
+		if err := s.store.MarkRead(msg.ID); err != nil {
+			logging.Error(err, "msgID", msg.ID, "action", "mark read after scheduled delivery")
+			continue
+		}
+		delivered++
+	}
+	if delivered > 0 {
+		logging.Info("scheduled messages delivered, count=%d", delivered)
+	}
+	return delivered, nil
+}
+
 // Notify sends an immediate, non-persisted notification to an agent's tmux session.
 // Use this for ephemeral messages like merge conflict instructions that don't need tracking.
 // For tracked, persistent messages, use Send() instead.
 func (s *MessageService) Notify(agentID, text string) error {
 	logging.Entry("agentID", agentID, "textLen", len(text))
 
-	if agentID == HumanParticipantID {
+	if IsHumanParticipant(agentID) {
 		return fmt.Errorf("cannot send tmux notification to human")
 	}
 
@@ -116,8 +318,9 @@ func (s *MessageService) Notify(agentID, text string) error {
 		return fmt.Errorf("agent not found: %s", agentID)
 	}
 
-	if err := s.tmux.SendKeys(agent.ID, text); err != nil {
-		logging.Error(err, "agentID", agentID, "action", "notify")
+	transport := s.transport(agent)
+	if err := transport.Deliver(agent, text); err != nil {
+		logging.Error(err, "agentID", agentID, "transport", transport.Name(), "action", "notify")
 		return fmt.Errorf("failed to send notification: %w", err)
 	}
 
@@ -128,7 +331,7 @@ func (s *MessageService) Notify(agentID, text string) error {
 // isActive checks if a recipient is active (has a running tmux session).
 func (s *MessageService) isActive(agentID string) bool {
 	// Human messages are never auto-delivered
-	if agentID == HumanParticipantID {
+	if IsHumanParticipant(agentID) {
 		return false
 	}
 
@@ -142,8 +345,11 @@ func (s *MessageService) isActive(agentID string) bool {
 
 // deliverToTmux sends a notification to the recipient's tmux session.
 func (s *MessageService) deliverToTmux(msg *Message) {
-	notification := fmt.Sprintf("\n[MESSAGE from %s (%s)]: %s\n",
-		msg.From, msg.Type, msg.Content)
+	notification := fmt.Sprintf("\n[MESSAGE %s from %s (%s)]: %s\n",
+		msg.Code(), msg.From, msg.Type, msg.Content)
+	if len(msg.Refs) > 0 {
+		notification += "See: " + strings.ReplaceAll(FormatFileRefs(msg.Refs), "\n", ", ") + "\n"
+	}
 
 	if err := s.Notify(msg.To, notification); err != nil {
 		logging.Error(err, "msgID", msg.ID, "action", "deliver to tmux")
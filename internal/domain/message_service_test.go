@@ -1,25 +1,32 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"testing"
+	"time"
 )
 
 // Mock message store
 type mockMessageStore struct {
 	messages    map[string]*Message
+	escalated   map[string]bool
 	saveErr     error
 	markReadErr error
 	getErr      error
 }
 
 func newMockMessageStore() *mockMessageStore {
-	return &mockMessageStore{messages: make(map[string]*Message)}
+	return &mockMessageStore{messages: make(map[string]*Message), escalated: make(map[string]bool)}
 }
 
 func (m *mockMessageStore) Save(msg *Message) error {
 	if m.saveErr != nil {
 		return m.saveErr
 	}
+	msg.Seq = len(m.messages) + 1
 	m.messages[msg.ID] = msg
 	return nil
 }
@@ -37,26 +44,63 @@ func (m *mockMessageStore) MarkRead(id string) error {
 func (m *mockMessageStore) ListUnread(recipientID string) ([]*Message, error) {
 	var msgs []*Message
 	for _, msg := range m.messages {
-		if msg.To == recipientID && !msg.Read {
+		if msg.To == recipientID && !msg.Read && msg.Due(time.Now()) {
 			msgs = append(msgs, msg)
 		}
 	}
 	return msgs, nil
 }
 
-func (m *mockMessageStore) List(recipientID string, limit int) ([]*Message, error) {
+func (m *mockMessageStore) ListUnescalatedQuestions() ([]*Message, error) {
 	var msgs []*Message
 	for _, msg := range m.messages {
-		if msg.To == recipientID {
+		if msg.Type == MessageTypeQuestion && !msg.Read && !m.escalated[msg.ID] &&
+			!IsHumanParticipant(msg.From) && !IsHumanParticipant(msg.To) {
 			msgs = append(msgs, msg)
-			if limit > 0 && len(msgs) >= limit {
-				break
-			}
 		}
 	}
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].Seq < msgs[j].Seq })
 	return msgs, nil
 }
 
+func (m *mockMessageStore) MarkEscalated(id string) error {
+	m.escalated[id] = true
+	return nil
+}
+
+func (m *mockMessageStore) ListDue(now time.Time) ([]*Message, error) {
+	var msgs []*Message
+	for _, msg := range m.messages {
+		if !msg.Read && msg.DeliverAt != nil && msg.Due(now) {
+			msgs = append(msgs, msg)
+		}
+	}
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].Seq < msgs[j].Seq })
+	return msgs, nil
+}
+
+func (m *mockMessageStore) List(recipientID string, before, limit int) ([]*Message, error) {
+	return m.page(func(msg *Message) bool { return msg.To == recipientID }, before, limit), nil
+}
+
+func (m *mockMessageStore) ListSent(senderID string, before, limit int) ([]*Message, error) {
+	return m.page(func(msg *Message) bool { return msg.From == senderID }, before, limit), nil
+}
+
+func (m *mockMessageStore) page(keep func(*Message) bool, before, limit int) []*Message {
+	var msgs []*Message
+	for _, msg := range m.messages {
+		if keep(msg) && (before == 0 || msg.Seq < before) {
+			msgs = append(msgs, msg)
+		}
+	}
+	sort.Slice(msgs, func(i, j int) bool { return msgs[i].Seq > msgs[j].Seq })
+	if limit > 0 && len(msgs) > limit {
+		msgs = msgs[:limit]
+	}
+	return msgs
+}
+
 func (m *mockMessageStore) Get(id string) (*Message, error) {
 	if m.getErr != nil {
 		return nil, m.getErr
@@ -68,6 +112,15 @@ func (m *mockMessageStore) Get(id string) (*Message, error) {
 	return msg, nil
 }
 
+func (m *mockMessageStore) GetBySeq(seq int) (*Message, error) {
+	for _, msg := range m.messages {
+		if msg.Seq == seq {
+			return msg, nil
+		}
+	}
+	return nil, &messageNotFoundError{id: fmt.Sprint(seq)}
+}
+
 func (m *mockMessageStore) UnreadCount(recipientID string) (int, error) {
 	count := 0
 	for _, msg := range m.messages {
@@ -78,6 +131,36 @@ func (m *mockMessageStore) UnreadCount(recipientID string) (int, error) {
 	return count, nil
 }
 
+func (m *mockMessageStore) UnreadCountsByRecipient() (map[string]int, error) {
+	counts := make(map[string]int)
+	for _, msg := range m.messages {
+		if !msg.Read {
+			counts[msg.To]++
+		}
+	}
+	return counts, nil
+}
+
+func (m *mockMessageStore) ListThread(participantID string) ([]*Message, error) {
+	var msgs []*Message
+	for _, msg := range m.messages {
+		if msg.To == participantID || msg.From == participantID {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs, nil
+}
+
+func (m *mockMessageStore) ListByIDPrefix(prefix string, limit int) ([]*Message, error) {
+	var msgs []*Message
+	for id, msg := range m.messages {
+		if strings.HasPrefix(id, prefix) && len(msgs) < limit {
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs, nil
+}
+
 type messageNotFoundError struct {
 	id string
 }
@@ -203,6 +286,50 @@ func TestMessageService_ListUnread(t *testing.T) {
 	})
 }
 
+func TestMessageService_Page(t *testing.T) {
+	msgStore := newMockMessageStore()
+	for seq := 1; seq <= 5; seq++ {
+		id := fmt.Sprintf("msg-%d", seq)
+		msgStore.messages[id] = &Message{ID: id, Seq: seq, To: "worker-001"}
+	}
+	msgStore.messages["other"] = &Message{ID: "other", Seq: 6, To: "other"}
+	msgStore.messages["sent"] = &Message{ID: "sent", Seq: 7, From: "worker-001", To: "lead"}
+	svc := NewMessageService(msgStore, nil, nil)
+
+	seqs := func(msgs []*Message) []int {
+		var out []int
+		for _, msg := range msgs {
+			out = append(out, msg.Seq)
+		}
+		return out
+	}
+	for page, want := range map[int][]int{1: {5, 4}, 2: {3, 2}, 3: {1}, 4: nil} {
+		msgs, err := svc.Page("worker-001", page, 2)
+		if err != nil {
+			t.Fatalf("Page(%d) failed: %v", page, err)
+		}
+		if fmt.Sprint(seqs(msgs)) != fmt.Sprint(want) {
+			t.Errorf("Page(%d) = %v, want %v", page, seqs(msgs), want)
+		}
+	}
+	if _, err := svc.Page("worker-001", 0, 2); err == nil {
+		t.Error("expected error for page 0")
+	}
+	sent, err := svc.SentPage("worker-001", 1, 2)
+	if err != nil || fmt.Sprint(seqs(sent)) != "[7]" {
+		t.Errorf("SentPage(worker-001) = %v, %v, want [7]", seqs(sent), err)
+	}
+
+	seq, err := svc.ResolveSeq("msg-3")
+	if err != nil || seq != 3 {
+		t.Fatalf("ResolveSeq(msg-3) = %d, %v, want 3", seq, err)
+	}
+	msgs, _ := svc.List("worker-001", seq, 0)
+	if fmt.Sprint(seqs(msgs)) != "[2 1]" {
+		t.Errorf("List before M-3 = %v, want [2 1]", seqs(msgs))
+	}
+}
+
 func TestMessageService_Read(t *testing.T) {
 	t.Run("marks message as read", func(t *testing.T) {
 		msgStore := newMockMessageStore()
@@ -225,6 +352,35 @@ func TestMessageService_Read(t *testing.T) {
 	})
 }
 
+func TestMessageService_ResolveID(t *testing.T) {
+	msgStore := newMockMessageStore()
+	msgStore.messages["3f2a01"] = &Message{ID: "3f2a01", Seq: 1041}
+	msgStore.messages["3f2b02"] = &Message{ID: "3f2b02", Seq: 1042}
+	svc := NewMessageService(msgStore, nil, nil)
+
+	if id, err := svc.ResolveID("3f2a"); err != nil || id != "3f2a01" {
+		t.Errorf("ResolveID(3f2a) = %q, %v, want 3f2a01", id, err)
+	}
+	if id, err := svc.ResolveID("3f2b02"); err != nil || id != "3f2b02" {
+		t.Errorf("ResolveID(3f2b02) = %q, %v, want 3f2b02", id, err)
+	}
+	if id, err := svc.ResolveID("m-1042"); err != nil || id != "3f2b02" {
+		t.Errorf("ResolveID(m-1042) = %q, %v, want 3f2b02", id, err)
+	}
+	if _, err := svc.ResolveID("M-7"); err == nil {
+		t.Error("expected error for unknown code")
+	}
+
+	_, err := svc.ResolveID("3f2")
+	var ambiguous *AmbiguousError
+	if !errors.As(err, &ambiguous) || len(ambiguous.Candidates) != 2 {
+		t.Errorf("ResolveID(3f2) error = %v, want AmbiguousError with 2 candidates", err)
+	}
+	if _, err := svc.ResolveID("zz"); err == nil {
+		t.Error("expected error for unknown ID")
+	}
+}
+
 func TestMessageService_UnreadCount(t *testing.T) {
 	t.Run("counts unread messages", func(t *testing.T) {
 		msgStore := newMockMessageStore()
@@ -261,3 +417,206 @@ func TestIsValidMessageType(t *testing.T) {
 		}
 	}
 }
+
+func TestParseMessageCode(t *testing.T) {
+	tests := []struct {
+		code string
+		seq  int
+		ok   bool
+	}{
+		{"M-1042", 1042, true},
+		{"m-7", 7, true},
+		{"M-", 0, false},
+		{"M-0", 0, false},
+		{"M-12x", 0, false},
+		{"3f2a", 0, false},
+	}
+	for _, tt := range tests {
+		seq, ok := ParseMessageCode(tt.code)
+		if seq != tt.seq || ok != tt.ok {
+			t.Errorf("ParseMessageCode(%q) = %d, %v, want %d, %v", tt.code, seq, ok, tt.seq, tt.ok)
+		}
+	}
+}
+
+func TestMessageService_ScheduleWithRefs(t *testing.T) {
+	msgStore := newMockMessageStore()
+	agentStore := newTestStore()
+	agentStore.Add(&Agent{ID: "recipient-001", Status: AgentStatusActive})
+	tmux := &mockTmuxClient{sessions: map[string]bool{"recipient-001": true}}
+	svc := NewMessageService(msgStore, tmux, agentStore)
+
+	later, err := svc.ScheduleWithRefs("human", "recipient-001", MessageTypeAssignment, "Start the API", nil, nil, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if later.Read || len(tmux.sentKeys) != 0 {
+		t.Error("expected a scheduled message not to be delivered before it is due")
+	}
+	if unread, _ := svc.ListUnread("recipient-001"); len(unread) != 0 {
+		t.Errorf("ListUnread = %d messages, want none before the message is due", len(unread))
+	}
+	if delivered, err := svc.DeliverDue(); err != nil || delivered != 0 {
+		t.Errorf("DeliverDue() = %d, %v, want nothing due", delivered, err)
+	}
+
+	// Once due, it is delivered to the running session and marked read
+	past := time.Now().Add(-time.Minute)
+	later.DeliverAt = &past
+	delivered, err := svc.DeliverDue()
+	if err != nil || delivered != 1 {
+		t.Fatalf("DeliverDue() = %d, %v, want 1", delivered, err)
+	}
+	if !msgStore.messages[later.ID].Read || len(tmux.sentKeys) != 1 || !strings.Contains(tmux.sentKeys[0], "Start the API") {
+		t.Errorf("expected the due message delivered and read, sent %q", tmux.sentKeys)
+	}
+
+	// Due messages for stopped agents wait for their next start
+	stopped, _ := svc.ScheduleWithRefs("human", "stopped-001", MessageTypeInfo, "Later", nil, nil, past)
+	if delivered, _ := svc.DeliverDue(); delivered != 0 || msgStore.messages[stopped.ID].Read {
+		t.Error("expected no delivery to an agent without a session")
+	}
+}
+
+// recordingTransport records what it delivers.
+type recordingTransport struct {
+	delivered []string
+	nudged    []string
+}
+
+func (r *recordingTransport) Deliver(agent *Agent, text string) error {
+	r.delivered = append(r.delivered, agent.ID+": "+text)
+	return nil
+}
+
+func (r *recordingTransport) Nudge(agent *Agent, text string) error {
+	r.nudged = append(r.nudged, agent.ID+": "+text)
+	return nil
+}
+
+func (r *recordingTransport) Name() string { return "recording" }
+
+func TestMessageService_SetTransport(t *testing.T) {
+	agentStore := newTestStore()
+	agentStore.Add(&Agent{ID: "aider-001", AgentType: "aider", Status: AgentStatusActive})
+	agentStore.Add(&Agent{ID: "claude-001", AgentType: "claude", Status: AgentStatusActive})
+	tmux := &mockTmuxClient{sessions: map[string]bool{"aider-001": true, "claude-001": true}}
+	svc := NewMessageService(newMockMessageStore(), tmux, agentStore)
+	transport := &recordingTransport{}
+	svc.SetTransport("Aider", transport)
+
+	if _, err := svc.Send("human", "aider-001", MessageTypeInfo, "Use the file inbox", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.Send("human", "claude-001", MessageTypeInfo, "Use keys", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(transport.delivered) != 1 || !strings.Contains(transport.delivered[0], "aider-001: ") || !strings.Contains(transport.delivered[0], "Use the file inbox") {
+		t.Errorf("transport delivered %q, want the aider message", transport.delivered)
+	}
+	if len(tmux.sentKeys) != 1 || !strings.Contains(tmux.sentKeys[0], "Use keys") {
+		t.Errorf("sent keys %q, want only the claude message", tmux.sentKeys)
+	}
+}
+
+func TestParseDeliverAt(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 30, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+		ok   bool
+	}{
+		{"16:00", time.Date(2026, 3, 10, 16, 0, 0, 0, time.UTC), true},
+		{"09:00", time.Date(2026, 3, 11, 9, 0, 0, 0, time.UTC), true},
+		{"14:30", time.Date(2026, 3, 11, 14, 30, 0, 0, time.UTC), true},
+		{"2026-04-01 08:15", time.Date(2026, 4, 1, 8, 15, 0, 0, time.UTC), true},
+		{"2026-04-01T08:15:00Z", time.Date(2026, 4, 1, 8, 15, 0, 0, time.UTC), true},
+		{"tomorrow", time.Time{}, false},
+		{"25:00", time.Time{}, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseDeliverAt(tt.in, now)
+		if (err == nil) != tt.ok || !got.Equal(tt.want) {
+			t.Errorf("ParseDeliverAt(%q) = %v, %v, want %v (ok %v)", tt.in, got, err, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestMessageService_Nudge(t *testing.T) {
+	setup := func() (*MessageService, *mockMessageStore, *mockTmuxClient) {
+		agentStore := newTestStore()
+		agentStore.Add(&Agent{ID: "aider-001", AgentType: "aider", Status: AgentStatusActive})
+		agentStore.Add(&Agent{ID: "claude-001", AgentType: "claude", Status: AgentStatusActive, Metadata: map[string]string{MetadataDelivery: "nudge"}})
+		agentStore.Add(&Agent{ID: "aider-002", AgentType: "aider", Status: AgentStatusActive, Metadata: map[string]string{MetadataDelivery: "content"}})
+		tmux := &mockTmuxClient{sessions: map[string]bool{"aider-001": true, "claude-001": true, "aider-002": true}}
+		msgStore := newMockMessageStore()
+		svc := NewMessageService(msgStore, tmux, agentStore)
+		svc.SetDeliveryMode("Aider", DeliveryNudge)
+		return svc, msgStore, tmux
+	}
+
+	t.Run("nudges by type or metadata and leaves messages unread", func(t *testing.T) {
+		svc, _, tmux := setup()
+
+		for _, to := range []string{"aider-001", "aider-001", "claude-001"} {
+			msg, err := svc.Send("human", to, MessageTypeInfo, "A long brief", nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if msg.Read {
+				t.Errorf("message to %s marked read, want it left unread", to)
+			}
+		}
+
+		want := []string{
+			"You have 1 new message, run `craizy msg list --for aider-001 --unread`",
+			"You have 2 new messages, run `craizy msg list --for aider-001 --unread`",
+			"You have 1 new message, run `craizy msg list --for claude-001 --unread`",
+		}
+		if strings.Join(tmux.sentKeys, "\n") != strings.Join(want, "\n") {
+			t.Errorf("sent %q, want %q", tmux.sentKeys, want)
+		}
+	})
+
+	t.Run("metadata overrides the type", func(t *testing.T) {
+		svc, _, tmux := setup()
+
+		msg, _ := svc.Send("human", "aider-002", MessageTypeInfo, "A long brief", nil)
+
+		if !msg.Read || len(tmux.sentKeys) != 1 || !strings.Contains(tmux.sentKeys[0], "A long brief") {
+			t.Errorf("sent %q, want the whole message", tmux.sentKeys)
+		}
+	})
+
+	t.Run("nudges through the transport's nudge", func(t *testing.T) {
+		svc, _, tmux := setup()
+		transport := &recordingTransport{}
+		svc.SetTransport("aider", transport)
+
+		if _, err := svc.Send("human", "aider-001", MessageTypeInfo, "A long brief", nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(transport.nudged) != 1 || len(transport.delivered) != 0 || len(tmux.sentKeys) != 0 {
+			t.Errorf("nudged %q, delivered %q, sent %q, want only one nudge", transport.nudged, transport.delivered, tmux.sentKeys)
+		}
+	})
+
+	t.Run("nudges once about a due message", func(t *testing.T) {
+		svc, msgStore, tmux := setup()
+		past := time.Now().Add(-time.Minute)
+		msg, _ := svc.ScheduleWithRefs("human", "aider-001", MessageTypeInfo, "Later", nil, nil, time.Now().Add(time.Hour))
+		msg.DeliverAt = &past
+
+		for range 2 {
+			if _, err := svc.DeliverDue(); err != nil {
+				t.Fatalf("DeliverDue: %v", err)
+			}
+		}
+
+		if len(tmux.sentKeys) != 1 || msgStore.messages[msg.ID].Read {
+			t.Errorf("sent %q, want one nudge and the message left unread", tmux.sentKeys)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// Metadata keys crAIzy itself reads. Issue links are set by hooks or with
+// `craizy agent meta <id> issue_url=...`, as crAIzy doesn't create issues.
+const (
+	MetadataPRURL     = "pr_url"     // pull request opened for the agent's branch
+	MetadataIssueURL  = "issue_url"  // issue the agent is working on
+	MetadataCreatedBy = "created_by" // API token that created the agent
+)
+
+// reservedMetadataKeys can only be set through CreateOptions.Metadata, so
+// they can be trusted for access checks.
+var reservedMetadataKeys = map[string]bool{MetadataCreatedBy: true}
+
+// PRURL returns the agent's pull request URL, or "" if none was recorded.
+func (a *Agent) PRURL() string {
+	return a.Metadata[MetadataPRURL]
+}
+
+// IssueURL returns the URL of the issue the agent works on, or "" if none was recorded.
+func (a *Agent) IssueURL() string {
+	return a.Metadata[MetadataIssueURL]
+}
+
+// metadataKeyPattern restricts metadata keys to lowercase words, such as
+// "model" or "pr_url", so hooks and scripts can name them unquoted.
+var metadataKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_.-]*$`)
+
+// SetMetadata attaches a value to an agent under key, replacing any previous
+// value; an empty value deletes the key. Metadata lets providers, hooks and
+// features record data such as a model name or container ID without a
+// schema change.
+func (s *AgentService) SetMetadata(agentID, key, value string) error {
+	logging.Entry("agentID", agentID, "key", key)
+	if !metadataKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid metadata key %q: use lowercase letters, digits, '.', '-' and '_'", key)
+	}
+	if reservedMetadataKeys[key] {
+		return fmt.Errorf("metadata key %q is reserved: it is recorded when the agent is created", key)
+	}
+	if s.store.Get(agentID) == nil {
+		return fmt.Errorf("agent not found: %s", agentID)
+	}
+
+	if err := s.store.SetMetadata(agentID, key, value); err != nil {
+		logging.Error(err, "agentID", agentID, "key", key)
+		return err
+	}
+
+	s.dispatcher.Publish(AgentMetadataChanged{
+		AgentID:   agentID,
+		Key:       key,
+		Value:     value,
+		Timestamp: time.Now(),
+	})
+	return nil
+}
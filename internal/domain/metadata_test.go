@@ -0,0 +1,44 @@
+package domain
+
+import "testing"
+
+func TestAgentService_SetMetadata(t *testing.T) {
+	store := newTestStore()
+	store.Add(&Agent{ID: "agent-1"})
+	dispatcher := &mockDispatcher{}
+	svc := NewAgentService(&mockTmuxClient{sessions: map[string]bool{}}, store, dispatcher, newMockGit(), "proj", "/tmp")
+
+	if err := svc.SetMetadata("agent-1", "pr_url", "https://example.com/pr/1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := store.Get("agent-1").Metadata["pr_url"]; got != "https://example.com/pr/1" {
+		t.Errorf("pr_url = %q", got)
+	}
+	if len(dispatcher.published) != 1 || dispatcher.published[0].EventType() != "agent.metadata_changed" {
+		t.Errorf("published %v, want one agent.metadata_changed event", dispatcher.published)
+	}
+
+	if err := svc.SetMetadata("agent-1", "PR URL", "x"); err == nil {
+		t.Error("expected error for invalid key")
+	}
+	if err := svc.SetMetadata("missing", "model", "opus"); err == nil {
+		t.Error("expected error for missing agent")
+	}
+	if err := svc.SetMetadata("agent-1", MetadataCreatedBy, "mallory"); err == nil {
+		t.Error("expected error for reserved key")
+	}
+}
+
+func TestAgentService_OpenPullRequest_RecordsURL(t *testing.T) {
+	store := newTestStore()
+	store.Add(&Agent{ID: "agent-1", Name: "auth", Branch: "craizy/auth", BaseBranch: "main"})
+	svc := NewAgentService(&mockTmuxClient{sessions: map[string]bool{}}, store, &mockDispatcher{}, newMockGit(), "proj", "/tmp")
+	svc.SetGitHubClient(&mockGitHubClient{prURL: "https://example.com/pr/7"})
+
+	if _, err := svc.OpenPullRequest("agent-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := store.Get("agent-1").PRURL(); got != "https://example.com/pr/7" {
+		t.Errorf("PRURL = %q, want the opened pull request", got)
+	}
+}
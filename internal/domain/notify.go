@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// Metadata keys holding an agent's notification rules, see NotifyRules.
+const (
+	MetadataNotifyIdle = "notify.idle" // "true" to notify when the agent goes idle
+	MetadataNotifyMute = "notify.mute" // comma-separated message types that never notify
+)
+
+// NotifyRules are an agent's notification preferences, kept in its metadata
+// so they can be set from the detail view or with `craizy agent meta`.
+type NotifyRules struct {
+	Idle  bool          // notify the human when the agent goes idle
+	Muted []MessageType // types of the agent's messages that arrive without notifying
+}
+
+// NotifyRules returns the agent's notification rules.
+func (a *Agent) NotifyRules() NotifyRules {
+	rules := NotifyRules{Idle: a.Metadata[MetadataNotifyIdle] == "true"}
+	for _, t := range strings.Split(a.Metadata[MetadataNotifyMute], ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			rules.Muted = append(rules.Muted, MessageType(t))
+		}
+	}
+	return rules
+}
+
+// Mutes reports whether messages of type t arrive without notifying.
+func (r NotifyRules) Mutes(t MessageType) bool {
+	for _, muted := range r.Muted {
+		if muted == t {
+			return true
+		}
+	}
+	return false
+}
+
+// SetNotifyRules stores an agent's notification rules in its metadata.
+func (s *AgentService) SetNotifyRules(agentID string, rules NotifyRules) error {
+	logging.Entry("agentID", agentID, "idle", rules.Idle, "muted", rules.Muted)
+	idle := ""
+	if rules.Idle {
+		idle = "true"
+	}
+	if err := s.SetMetadata(agentID, MetadataNotifyIdle, idle); err != nil {
+		return err
+	}
+	muted := make([]string, len(rules.Muted))
+	for i, t := range rules.Muted {
+		muted[i] = string(t)
+	}
+	return s.SetMetadata(agentID, MetadataNotifyMute, strings.Join(muted, ","))
+}
+
+// IdleToNotify returns the active agents whose rules ask to notify when they
+// go idle and whose sessions have been silent for AttentionIdle.
+func (s *AgentService) IdleToNotify(now time.Time) []*Agent {
+	logging.Entry("project", s.project)
+	idle := s.AttentionIdle()
+	var agents []*Agent
+	for _, agent := range s.List() {
+		if agent.Status != AgentStatusActive || !agent.NotifyRules().Idle {
+			continue
+		}
+		if last, err := s.tmux.LastActivity(agent.ID); err == nil && now.Sub(last) > idle {
+			agents = append(agents, agent)
+		}
+	}
+	return agents
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAgentService_SetNotifyRules(t *testing.T) {
+	store := newTestStore()
+	store.Add(&Agent{ID: "agent-1"})
+	svc := NewAgentService(&mockTmuxClient{sessions: map[string]bool{}}, store, &mockDispatcher{}, newMockGit(), "proj", "/tmp")
+
+	rules := NotifyRules{Idle: true, Muted: []MessageType{MessageTypeStatus, MessageTypeInfo}}
+	if err := svc.SetNotifyRules("agent-1", rules); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := store.Get("agent-1").NotifyRules()
+	if !got.Idle || !got.Mutes(MessageTypeStatus) || !got.Mutes(MessageTypeInfo) || got.Mutes(MessageTypeQuestion) {
+		t.Errorf("NotifyRules() = %+v, want %+v", got, rules)
+	}
+
+	if err := svc.SetNotifyRules("agent-1", NotifyRules{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if metadata := store.Get("agent-1").Metadata; len(metadata) != 0 {
+		t.Errorf("metadata = %v, want the rules removed", metadata)
+	}
+}
+
+func TestAgentService_IdleToNotify(t *testing.T) {
+	now := time.Now()
+	store := newTestStore()
+	store.Add(&Agent{ID: "quiet", Project: "proj", Status: AgentStatusActive, Metadata: map[string]string{MetadataNotifyIdle: "true"}})
+	store.Add(&Agent{ID: "busy", Project: "proj", Status: AgentStatusActive, Metadata: map[string]string{MetadataNotifyIdle: "true"}})
+	store.Add(&Agent{ID: "unwatched", Project: "proj", Status: AgentStatusActive})
+	tmux := &mockTmuxClient{sessions: map[string]bool{}, activity: map[string]time.Time{
+		"quiet":     now.Add(-time.Hour),
+		"busy":      now,
+		"unwatched": now.Add(-time.Hour),
+	}}
+	svc := NewAgentService(tmux, store, &mockDispatcher{}, nil, "proj", "/tmp")
+
+	agents := svc.IdleToNotify(now)
+	if len(agents) != 1 || agents[0].ID != "quiet" {
+		t.Errorf("IdleToNotify() = %v, want only quiet", agents)
+	}
+}
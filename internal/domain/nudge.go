@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// DeliveryMode is what an agent is sent when a message arrives for it.
+type DeliveryMode string
+
+const (
+	// DeliveryContent sends the whole message, the default.
+	DeliveryContent DeliveryMode = "content"
+	// DeliveryNudge sends a one-line notice of unread messages, leaving them
+	// unread for the agent to read with craizy msg, so long messages don't
+	// take over its context window.
+	DeliveryNudge DeliveryMode = "nudge"
+)
+
+// MetadataDelivery overrides an agent type's delivery mode for one agent, e.g.
+// with `craizy agent meta <agent> delivery=nudge`.
+const MetadataDelivery = "delivery"
+
+// IsValidDeliveryMode reports whether mode is a known delivery mode.
+func IsValidDeliveryMode(mode string) bool {
+	return mode == string(DeliveryContent) || mode == string(DeliveryNudge)
+}
+
+// SetDeliveryMode sets the delivery mode for agents of agentType.
+func (s *MessageService) SetDeliveryMode(agentType string, mode DeliveryMode) {
+	logging.Entry("agentType", agentType, "mode", mode)
+	if s.modes == nil {
+		s.modes = make(map[string]DeliveryMode)
+	}
+	s.modes[strings.ToLower(agentType)] = mode
+}
+
+// nudges reports whether the agent is sent nudges rather than messages: its
+// own delivery metadata decides, then its type's mode.
+func (s *MessageService) nudges(agent *Agent) bool {
+	if mode := agent.Metadata[MetadataDelivery]; IsValidDeliveryMode(mode) {
+		return DeliveryMode(mode) == DeliveryNudge
+	}
+	return s.modes[strings.ToLower(agent.AgentType)] == DeliveryNudge
+}
+
+// recipientNudges reports whether the recipient of a message is sent nudges.
+func (s *MessageService) recipientNudges(agentID string) bool {
+	agent := s.agents.Get(agentID)
+	return agent != nil && s.nudges(agent)
+}
+
+// nudge tells an agent how many unread messages it has and how to read them.
+func (s *MessageService) nudge(agentID string) {
+	count, err := s.store.UnreadCount(agentID)
+	if err != nil {
+		logging.Error(err, "agentID", agentID, "action", "count unread for nudge")
+		return
+	}
+	if count == 0 {
+		return
+	}
+	noun := "message"
+	if count > 1 {
+		noun = "messages"
+	}
+	agent := s.agents.Get(agentID)
+	if agent == nil {
+		return
+	}
+	text := fmt.Sprintf("You have %d new %s, run `craizy msg list --for %s --unread`", count, noun, agentID)
+	transport := s.transport(agent)
+	if err := transport.Nudge(agent, text); err != nil {
+		logging.Error(err, "agentID", agentID, "transport", transport.Name(), "action", "nudge")
+		return
+	}
+	logging.Info("nudge sent to agent, agentID=%s", agentID)
+}
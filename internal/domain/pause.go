@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// MetadataPausedAt records when a paused agent was paused, in RFC 3339, so the
+// pause counts toward PausedTime once it is resumed.
+const MetadataPausedAt = "paused_at"
+
+// PausedAt returns when the agent was paused, if it is paused.
+func (a *Agent) PausedAt() (time.Time, bool) {
+	at, err := time.Parse(time.RFC3339, a.Metadata[MetadataPausedAt])
+	return at, err == nil && a.Status == AgentStatusPaused
+}
+
+// Pause suspends a running agent's processes, freeing the CPU and stopping it
+// from making changes or API calls until it is resumed. Its session, worktree
+// and unsent input are kept.
+func (s *AgentService) Pause(sessionID string) error {
+	logging.Entry("sessionID", sessionID)
+	if err := s.setPaused(sessionID, AgentStatusActive, AgentStatusPaused, "pause", s.tmux.PauseSession); err != nil {
+		return err
+	}
+	if err := s.store.SetMetadata(sessionID, MetadataPausedAt, time.Now().UTC().Format(time.RFC3339)); err != nil {
+		logging.Error(err, "sessionID", sessionID, "action", "record pause time")
+	}
+	return nil
+}
+
+// Resume continues a paused agent's processes where they stopped, adding the
+// pause to its PausedTime.
+func (s *AgentService) Resume(sessionID string) error {
+	logging.Entry("sessionID", sessionID)
+	var pausedAt time.Time
+	var timed bool
+	if agent := s.store.Get(sessionID); agent != nil {
+		pausedAt, timed = agent.PausedAt()
+	}
+	if err := s.setPaused(sessionID, AgentStatusPaused, AgentStatusActive, "resume", s.tmux.ResumeSession); err != nil {
+		return err
+	}
+	if timed {
+		if err := s.store.AddPausedTime(sessionID, time.Since(pausedAt)); err != nil {
+			logging.Error(err, "sessionID", sessionID, "action", "record paused time")
+		}
+	}
+	if err := s.store.SetMetadata(sessionID, MetadataPausedAt, ""); err != nil {
+		logging.Error(err, "sessionID", sessionID, "action", "clear pause time")
+	}
+	return nil
+}
+
+// setPaused signals an agent in status from with signal, the action named by
+// verb, and records it as status to.
+func (s *AgentService) setPaused(sessionID string, from, to AgentStatus, verb string, signal func(string) error) error {
+	agent := s.store.Get(sessionID)
+	if agent == nil {
+		err := fmt.Errorf("agent %q not found", sessionID)
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+	if agent.Status != from {
+		err := fmt.Errorf("agent %q is %s, not %s", sessionID, agent.Status, from)
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+
+	if err := signal(sessionID); err != nil {
+		err = fmt.Errorf("failed to %s agent session: %w", verb, err)
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+	if err := s.store.UpdateStatus(sessionID, to); err != nil {
+		err = fmt.Errorf("failed to update agent status: %w", err)
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+
+	s.dispatcher.Publish(AgentStatusChanged{
+		AgentID:   sessionID,
+		OldStatus: from,
+		NewStatus: to,
+		Timestamp: time.Now(),
+	})
+	logging.Info("agent status changed, sessionID=%s, status=%s", sessionID, to)
+	return nil
+}
@@ -0,0 +1,87 @@
+package domain
+
+import "testing"
+
+func TestAgentService_PauseResume(t *testing.T) {
+	setup := func(status AgentStatus) (*AgentService, *testStore, *mockTmuxClient, *mockDispatcher) {
+		store := newTestStore()
+		_ = store.Add(&Agent{ID: "a", Project: "proj", Status: status})
+		tmux := &mockTmuxClient{sessions: map[string]bool{"a": true}}
+		dispatcher := &mockDispatcher{}
+		return NewAgentService(tmux, store, dispatcher, nil, "proj", "/tmp"), store, tmux, dispatcher
+	}
+
+	t.Run("pauses an active agent", func(t *testing.T) {
+		svc, store, tmux, dispatcher := setup(AgentStatusActive)
+
+		if err := svc.Pause("a"); err != nil {
+			t.Fatalf("Pause: %v", err)
+		}
+
+		if !tmux.paused["a"] {
+			t.Error("expected the session to be paused")
+		}
+		if got := store.Get("a").Status; got != AgentStatusPaused {
+			t.Errorf("status = %s, want paused", got)
+		}
+		if len(dispatcher.published) != 1 {
+			t.Fatalf("published %d events, want 1", len(dispatcher.published))
+		}
+		if e, ok := dispatcher.published[0].(AgentStatusChanged); !ok || e.NewStatus != AgentStatusPaused {
+			t.Errorf("published %#v, want a change to paused", dispatcher.published[0])
+		}
+		if _, ok := store.Get("a").PausedAt(); !ok {
+			t.Error("expected the pause time to be recorded")
+		}
+		if len(svc.List()) != 1 {
+			t.Error("expected paused agents to be listed")
+		}
+	})
+
+	t.Run("resumes a paused agent", func(t *testing.T) {
+		svc, store, tmux, _ := setup(AgentStatusActive)
+		_ = svc.Pause("a")
+
+		if err := svc.Resume("a"); err != nil {
+			t.Fatalf("Resume: %v", err)
+		}
+
+		if tmux.paused["a"] {
+			t.Error("expected the session to be resumed")
+		}
+		if _, ok := store.Get("a").Metadata[MetadataPausedAt]; ok {
+			t.Error("expected the pause time to be cleared")
+		}
+		if got := store.Get("a").Status; got != AgentStatusActive {
+			t.Errorf("status = %s, want active", got)
+		}
+	})
+
+	t.Run("rejects agents in the wrong status", func(t *testing.T) {
+		svc, _, tmux, _ := setup(AgentStatusStarting)
+
+		if err := svc.Pause("a"); err == nil {
+			t.Error("expected an error pausing a starting agent")
+		}
+		if err := svc.Resume("a"); err == nil {
+			t.Error("expected an error resuming an agent that isn't paused")
+		}
+		if err := svc.Pause("missing"); err == nil {
+			t.Error("expected an error pausing a missing agent")
+		}
+		if len(tmux.paused) != 0 {
+			t.Errorf("paused %v, want nothing", tmux.paused)
+		}
+	})
+
+	t.Run("kill resumes a paused agent first", func(t *testing.T) {
+		svc, _, tmux, _ := setup(AgentStatusActive)
+		_ = svc.Pause("a")
+
+		_ = svc.Kill("a")
+
+		if tmux.paused["a"] {
+			t.Error("expected the paused session to be continued before the kill")
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// PlaybookStep is one scripted interaction with an agent: send a prompt, then
+// wait for a condition before the next step.
+type PlaybookStep struct {
+	Send string        // prompt to send; {var} placeholders are expanded. May be empty to only wait
+	Wait WaitCondition // zero to continue straight away
+}
+
+// PlaybookProgress reports a playbook step starting or finishing.
+type PlaybookProgress struct {
+	Step   int // 1-based
+	Total  int
+	Done   bool       // false when the step starts
+	Result WaitResult // condition that ended the step's wait, when Done
+}
+
+// RunPlaybook sends each step's prompt to an agent and waits for its condition
+// before moving on. It stops at the first step that fails, returning an error
+// that names the step. progress, if not nil, is called as steps start and finish.
+func (s *AgentService) RunPlaybook(ctx context.Context, agentID string, steps []PlaybookStep, progress func(PlaybookProgress)) error {
+	logging.Entry("agentID", agentID, "steps", len(steps))
+	agent := s.store.Get(agentID)
+	if agent == nil {
+		err := fmt.Errorf("agent %q not found", agentID)
+		logging.Error(err, "agentID", agentID)
+		return err
+	}
+	if !s.tmux.SessionExists(agentID) {
+		err := fmt.Errorf("agent %q is not running", agentID)
+		logging.Error(err, "agentID", agentID)
+		return err
+	}
+	if progress == nil {
+		progress = func(PlaybookProgress) {}
+	}
+
+	vars := promptVars(agent)
+	for i, step := range steps {
+		progress(PlaybookProgress{Step: i + 1, Total: len(steps)})
+		started := time.Now()
+
+		if step.Send != "" {
+			if err := s.tmux.SendKeys(agentID, ExpandVars(step.Send, vars)); err != nil {
+				err = fmt.Errorf("step %d: failed to send prompt: %w", i+1, err)
+				logging.Error(err, "agentID", agentID)
+				return err
+			}
+		}
+
+		var result WaitResult
+		if !step.Wait.IsZero() {
+			var err error
+			result, err = s.WaitFor(ctx, agentID, step.Wait, started)
+			if err != nil {
+				err = fmt.Errorf("step %d: %w", i+1, err)
+				logging.Error(err, "agentID", agentID)
+				return err
+			}
+		}
+		progress(PlaybookProgress{Step: i + 1, Total: len(steps), Done: true, Result: result})
+	}
+
+	logging.Info("playbook finished, agentID=%s, steps=%d", agentID, len(steps))
+	return nil
+}
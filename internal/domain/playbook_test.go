@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAgentService_RunPlaybook(t *testing.T) {
+	defer func(interval time.Duration) { WaitPollInterval = interval }(WaitPollInterval)
+	WaitPollInterval = 5 * time.Millisecond
+
+	newPlaybookService := func() (*AgentService, *mockTmuxClient) {
+		store := newTestStore()
+		store.Add(&Agent{ID: "agent-1", Name: "auth", Branch: "craizy-auth", Status: AgentStatusActive, WorkDir: t.TempDir()})
+		tmux := &mockTmuxClient{
+			sessions: map[string]bool{"agent-1": true},
+			activity: map[string]time.Time{"agent-1": time.Now().Add(-time.Hour)},
+		}
+		return NewAgentService(tmux, store, &mockDispatcher{}, nil, "proj", "/tmp"), tmux
+	}
+
+	t.Run("sends each step and waits between them", func(t *testing.T) {
+		svc, tmux := newPlaybookService()
+		steps := []PlaybookStep{
+			{Send: "You are {name} on {branch}", Wait: WaitCondition{Idle: 10 * time.Millisecond}},
+			{Send: "Now write tests"},
+		}
+		var progress []PlaybookProgress
+
+		err := svc.RunPlaybook(context.Background(), "agent-1", steps, func(p PlaybookProgress) {
+			progress = append(progress, p)
+		})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{"You are auth on craizy-auth", "Now write tests"}
+		if strings.Join(tmux.sentKeys, "|") != strings.Join(want, "|") {
+			t.Errorf("sentKeys = %q, want %q", tmux.sentKeys, want)
+		}
+		if len(progress) != 4 || progress[1].Result != WaitIdle || !progress[3].Done || progress[3].Result != "" {
+			t.Errorf("progress = %+v", progress)
+		}
+	})
+
+	t.Run("stops at the failing step", func(t *testing.T) {
+		svc, tmux := newPlaybookService()
+		steps := []PlaybookStep{
+			{Send: "first"},
+			{Send: "second", Wait: WaitCondition{Completion: true, Timeout: 20 * time.Millisecond}},
+			{Send: "third"},
+		}
+		svc.SetMessageService(NewMessageService(newMockMessageStore(), tmux, newTestStore()))
+
+		err := svc.RunPlaybook(context.Background(), "agent-1", steps, nil)
+
+		if !errors.Is(err, ErrWaitTimeout) || !strings.Contains(err.Error(), "step 2") {
+			t.Errorf("got %v, want a step 2 timeout", err)
+		}
+		if len(tmux.sentKeys) != 2 {
+			t.Errorf("sentKeys = %q, want the third step skipped", tmux.sentKeys)
+		}
+	})
+
+	t.Run("agent must be running", func(t *testing.T) {
+		svc, tmux := newPlaybookService()
+		delete(tmux.sessions, "agent-1")
+
+		if err := svc.RunPlaybook(context.Background(), "agent-1", []PlaybookStep{{Send: "hi"}}, nil); err == nil {
+			t.Error("expected an error for a stopped agent")
+		}
+		if err := svc.RunPlaybook(context.Background(), "missing", []PlaybookStep{{Send: "hi"}}, nil); err == nil {
+			t.Error("expected an error for an unknown agent")
+		}
+	})
+}
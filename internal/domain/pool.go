@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"maps"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// readinessProbeLines is how many pane lines the readiness probe inspects.
+const readinessProbeLines = 20
+
+// PoolSpec configures how many idle agents of a type to keep warm.
+type PoolSpec struct {
+	AgentType string // agent name from AGENTS.yml
+	Command   string // command the pooled sessions run
+	Size      int    // number of idle agents to keep
+
+	Env map[string]string // environment variables set in pooled sessions
+	Dir string            // directory within the worktree pooled sessions start in
+}
+
+// SetWarmPool sets the warm pool sizes. FillPool creates the idle agents.
+func (s *AgentService) SetWarmPool(specs []PoolSpec) {
+	s.pool = specs
+}
+
+// FillPool pre-creates idle agents (worktree + booted CLI) until every pool is full.
+// Concurrent calls return immediately while a fill is in progress.
+func (s *AgentService) FillPool() error {
+	logging.Entry("project", s.project)
+	if !s.poolMu.TryLock() {
+		return nil
+	}
+	defer s.poolMu.Unlock()
+
+	for _, spec := range s.pool {
+		for have := len(s.idleAgents(spec.AgentType)); have < spec.Size; have++ {
+			name := "pool-" + uuid.New().String()[:8]
+			sessionID := BuildSessionID(s.project, spec.AgentType, name)
+			if _, err := s.spawn(sessionID, spec.AgentType, name, spec.Command, CreateOptions{Env: spec.Env, Dir: spec.Dir}, AgentStatusIdle); err != nil {
+				logging.Error(err, "agentType", spec.AgentType, "action", "fill pool")
+				return err
+			}
+			logging.Info("warm agent created, sessionID=%s", sessionID)
+		}
+	}
+	return nil
+}
+
+// IsReady reports whether an agent's CLI has booted, judged by its pane showing output.
+func (s *AgentService) IsReady(sessionID string) bool {
+	logging.Entry("sessionID", sessionID)
+	output, err := s.tmux.CapturePaneOutput(sessionID, readinessProbeLines)
+	return err == nil && strings.TrimSpace(output) != ""
+}
+
+// idleAgents returns the warm pool agents of a type in the current project.
+func (s *AgentService) idleAgents(agentType string) []*Agent {
+	var idle []*Agent
+	for _, agent := range s.store.List() {
+		if agent.Project == s.project && agent.Status == AgentStatusIdle && strings.EqualFold(agent.AgentType, agentType) {
+			idle = append(idle, agent)
+		}
+	}
+	return idle
+}
+
+// claimWarm hands a ready idle agent the identity of a newly requested agent,
+// adding metadata to its own. It returns nil if no suitable warm agent is available.
+func (s *AgentService) claimWarm(sessionID, agentType, name, command string, metadata map[string]string) *Agent {
+	var baseBranch string
+	if s.git != nil {
+		baseBranch, _ = s.git.CurrentBranch(s.workDir)
+	}
+
+	for _, idle := range s.idleAgents(agentType) {
+		if idle.Command != command || idle.BaseBranch != baseBranch || !s.IsReady(idle.ID) {
+			continue
+		}
+
+		claimed := *idle
+		claimed.ID = sessionID
+		claimed.Name = name
+		claimed.Status = AgentStatusActive
+		claimed.CreatedAt = time.Now()
+		if len(metadata) > 0 {
+			claimed.Metadata = maps.Clone(idle.Metadata)
+			if claimed.Metadata == nil {
+				claimed.Metadata = make(map[string]string)
+			}
+			maps.Copy(claimed.Metadata, metadata)
+		}
+
+		if s.git != nil && idle.Branch != "" {
+			if err := s.git.RenameBranch(idle.Branch, sessionID); err != nil {
+				logging.Error(err, "agentID", idle.ID, "action", "rename branch")
+				continue
+			}
+			claimed.Branch = sessionID
+		}
+
+		// Adapters rename the tmux session and replace the stored record
+		s.dispatcher.Publish(AgentClaimed{OldID: idle.ID, Agent: &claimed, Timestamp: time.Now()})
+		logging.Info("warm agent claimed, oldID=%s, sessionID=%s", idle.ID, sessionID)
+		return &claimed
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Operation names a long-running git operation reported through IProgressReporter.
+type Operation string
+
+const (
+	OperationCreate Operation = "create"
+	OperationMerge  Operation = "merge"
+	OperationPush   Operation = "push"
+)
+
+// OperationProgress reports the stage a long-running operation has reached.
+type OperationProgress struct {
+	Operation Operation
+	Subject   string // agent the operation is for
+	Stage     string // what is happening now, e.g. "creating worktree"
+	Done      bool   // the operation finished, successfully or not
+	Err       error  // why a done operation failed, for operations whose caller has moved on
+	Timestamp time.Time
+}
+
+// Key identifies the operation the progress belongs to.
+func (p OperationProgress) Key() string {
+	return fmt.Sprintf("%s:%s", p.Operation, p.Subject)
+}
+
+// SetProgressReporter sets where long git operations report their stages.
+func (s *AgentService) SetProgressReporter(reporter IProgressReporter) {
+	s.progress = reporter
+}
+
+// reportProgress reports that op on subject has reached stage.
+func (s *AgentService) reportProgress(op Operation, subject, stage string) {
+	if s.progress != nil {
+		s.progress.Report(OperationProgress{Operation: op, Subject: subject, Stage: stage, Timestamp: time.Now()})
+	}
+}
+
+// reportDone reports that op on subject has finished.
+func (s *AgentService) reportDone(op Operation, subject string) {
+	s.reportFailed(op, subject, nil)
+}
+
+// reportFailed reports that op on subject has finished with err.
+func (s *AgentService) reportFailed(op Operation, subject string, err error) {
+	if s.progress != nil {
+		s.progress.Report(OperationProgress{Operation: op, Subject: subject, Done: true, Err: err, Timestamp: time.Now()})
+	}
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "testing"
+
+type recordingReporter struct {
+	reports []OperationProgress
+}
+
+func (r *recordingReporter) Report(progress OperationProgress) {
+	r.reports = append(r.reports, progress)
+}
+
+func TestAgentService_ReportsProgress(t *testing.T) {
+	t.Run("create", func(t *testing.T) {
+		store := newTestStore()
+		svc := NewAgentService(&mockTmuxClient{sessions: map[string]bool{}}, store, &mockDispatcher{}, newMockGit(), "proj", "/tmp")
+		reporter := &recordingReporter{}
+		svc.SetProgressReporter(reporter)
+
+		if _, err := svc.Create("claude", "auth", "claude"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var stages []string
+		for _, r := range reporter.reports {
+			if r.Operation != OperationCreate || r.Subject != "auth" {
+				t.Errorf("report = %+v, want create of auth", r)
+			}
+			stages = append(stages, r.Stage)
+		}
+		if len(stages) < 3 || stages[1] != "creating worktree craizy-proj-claude-auth" {
+			t.Errorf("stages = %q", stages)
+		}
+		if last := reporter.reports[len(reporter.reports)-1]; !last.Done {
+			t.Error("expected the last report to mark the operation done")
+		}
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		store := newTestStore()
+		store.Add(&Agent{ID: "agent-1", Name: "auth", Branch: "feature", BaseBranch: "main", Status: AgentStatusActive})
+		svc := NewAgentService(&mockTmuxClient{sessions: map[string]bool{}}, store, &mockDispatcher{}, newMockGit(), "proj", "/tmp")
+		reporter := &recordingReporter{}
+		svc.SetProgressReporter(reporter)
+
+		if _, err := svc.MergeAgent("agent-1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(reporter.reports) != 2 || reporter.reports[0].Stage != "merging feature into main" || !reporter.reports[1].Done {
+			t.Errorf("reports = %+v, want merging then done", reporter.reports)
+		}
+	})
+}
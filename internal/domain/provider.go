@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// ProviderPool groups agent types that share a provider's rate limits, e.g. two
+// Claude agents on one API key. Agents over the pool's limits are queued and
+// started by StartQueued once a slot frees up.
+type ProviderPool struct {
+	Name          string
+	AgentTypes    []string      // agent names from AGENTS.yml
+	MaxConcurrent int           // running agents allowed at once (0 = unlimited)
+	Stagger       time.Duration // minimum gap between two starts
+}
+
+// has reports whether agentType belongs to the pool.
+func (p *ProviderPool) has(agentType string) bool {
+	for _, t := range p.AgentTypes {
+		if strings.EqualFold(t, agentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetProviderPools sets the provider pools used to schedule agent starts.
+func (s *AgentService) SetProviderPools(pools []ProviderPool) {
+	s.providers = pools
+}
+
+// HasProviderPools reports whether any provider pools are configured.
+func (s *AgentService) HasProviderPools() bool {
+	return len(s.providers) > 0
+}
+
+// providerPool returns the pool an agent type belongs to, or nil.
+func (s *AgentService) providerPool(agentType string) *ProviderPool {
+	for i := range s.providers {
+		if s.providers[i].has(agentType) {
+			return &s.providers[i]
+		}
+	}
+	return nil
+}
+
+// canStart reports whether the pool has a free slot and its stagger gap has passed.
+// If not, it returns why.
+func (s *AgentService) canStart(pool *ProviderPool, now time.Time) (bool, string) {
+	running := 0
+	var lastStart time.Time
+	for _, agent := range s.List() {
+		if !pool.has(agent.AgentType) {
+			continue
+		}
+		running++
+		if agent.CreatedAt.After(lastStart) {
+			lastStart = agent.CreatedAt
+		}
+	}
+	if pool.MaxConcurrent > 0 && running >= pool.MaxConcurrent {
+		return false, fmt.Sprintf("%s at capacity (%d/%d)", pool.Name, running, pool.MaxConcurrent)
+	}
+	if pool.Stagger > 0 && now.Sub(lastStart) < pool.Stagger {
+		return false, fmt.Sprintf("%s staggering starts", pool.Name)
+	}
+	return true, ""
+}
+
+// Queued returns agents waiting for a provider slot, oldest first.
+func (s *AgentService) Queued() []*Agent {
+	logging.Entry("project", s.project)
+	var queued []*Agent
+	for _, agent := range s.store.List() {
+		if agent.Project == s.project && agent.Status == AgentStatusPending {
+			queued = append(queued, agent)
+		}
+	}
+	sort.Slice(queued, func(i, j int) bool { return queued[i].CreatedAt.Before(queued[j].CreatedAt) })
+	return queued
+}
+
+// queuedInPool reports whether any agent of the pool is already waiting.
+func (s *AgentService) queuedInPool(pool *ProviderPool) bool {
+	for _, agent := range s.Queued() {
+		if pool.has(agent.AgentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// enqueue stores an agent as pending until its provider pool has room.
+func (s *AgentService) enqueue(sessionID, agentType, name, command string, opts CreateOptions, reason string) *Agent {
+	agent := &Agent{
+		ID:          sessionID,
+		Project:     s.project,
+		AgentType:   agentType,
+		Name:        name,
+		Command:     command,
+		WorkDir:     s.workDir,
+		Status:      AgentStatusPending,
+		CreatedAt:   time.Now(),
+		SparsePaths: opts.SparsePaths,
+		BaseBranch:  opts.BaseBranch,
+		Metadata:    opts.Metadata,
+	}
+
+	// Publish event - adapters will store the queued agent
+	s.dispatcher.Publish(AgentQueued{
+		Agent:     agent,
+		Reason:    reason,
+		Timestamp: time.Now(),
+	})
+
+	logging.Info("agent queued, sessionID=%s, reason=%s", sessionID, reason)
+	return agent
+}
+
+// StartQueued starts queued agents, oldest first, as their provider pools free
+// up. It returns the agents started.
+func (s *AgentService) StartQueued(now time.Time) []*Agent {
+	logging.Entry("project", s.project)
+	var started []*Agent
+	for _, queued := range s.Queued() {
+		pool := s.providerPool(queued.AgentType)
+		if pool != nil {
+			if ok, _ := s.canStart(pool, now); !ok {
+				continue
+			}
+		}
+
+		// The queued record is replaced by the one spawn stores
+		if err := s.store.Remove(queued.ID); err != nil {
+			logging.Error(err, "agentID", queued.ID, "action", "remove queued agent")
+			continue
+		}
+		opts := s.takeOptions(queued.ID)
+		opts.SparsePaths, opts.BaseBranch, opts.Metadata = queued.SparsePaths, queued.BaseBranch, queued.Metadata
+		agent, err := s.spawn(queued.ID, queued.AgentType, queued.Name, queued.Command, opts, AgentStatusActive)
+		if err != nil {
+			logging.Error(err, "agentID", queued.ID, "action", "start queued agent")
+			continue
+		}
+		s.sendStartupPrompt(agent, opts.Prompt)
+		s.deliverQueuedMessages(agent)
+		started = append(started, agent)
+		logging.Info("queued agent started, sessionID=%s", agent.ID)
+	}
+	return started
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+// storingDispatcher records events and stores created and queued agents, as the infra adapters do.
+type storingDispatcher struct {
+	mockDispatcher
+	store *testStore
+}
+
+func (d *storingDispatcher) Publish(event Event) {
+	d.mockDispatcher.Publish(event)
+	switch e := event.(type) {
+	case AgentCreated:
+		d.store.Add(e.Agent)
+	case AgentQueued:
+		d.store.Add(e.Agent)
+	}
+}
+
+func TestAgentService_ProviderPools(t *testing.T) {
+	newPoolService := func(pool ProviderPool) (*AgentService, *testStore) {
+		store := newTestStore()
+		svc := NewAgentService(&mockTmuxClient{}, store, &storingDispatcher{store: store}, nil, "proj", "/tmp")
+		svc.SetProviderPools([]ProviderPool{pool})
+		return svc, store
+	}
+
+	t.Run("queues starts over the concurrency cap", func(t *testing.T) {
+		svc, store := newPoolService(ProviderPool{Name: "anthropic", AgentTypes: []string{"Claude", "Opus"}, MaxConcurrent: 1})
+
+		first, err := svc.Create("claude", "auth", "claude")
+		if err != nil || first.Status != AgentStatusActive {
+			t.Fatalf("first agent should start: %+v, %v", first, err)
+		}
+		second, err := svc.Create("Opus", "docs", "claude --model opus")
+		if err != nil || second.Status != AgentStatusPending {
+			t.Fatalf("second agent should be queued: %+v, %v", second, err)
+		}
+		if other, _ := svc.Create("codex", "api", "codex"); other.Status != AgentStatusActive {
+			t.Error("agents outside the pool should start immediately")
+		}
+
+		if started := svc.StartQueued(time.Now()); len(started) != 0 {
+			t.Fatalf("nothing should start while the pool is full, got %+v", started)
+		}
+
+		store.UpdateStatus(first.ID, AgentStatusTerminated)
+		started := svc.StartQueued(time.Now())
+		if len(started) != 1 || started[0].ID != second.ID || started[0].Status != AgentStatusActive {
+			t.Fatalf("expected the queued agent to start, got %+v", started)
+		}
+		if queued := svc.Queued(); len(queued) != 0 {
+			t.Errorf("queue should be empty, got %+v", queued)
+		}
+	})
+
+	t.Run("staggers starts", func(t *testing.T) {
+		svc, _ := newPoolService(ProviderPool{Name: "anthropic", AgentTypes: []string{"claude"}, Stagger: time.Minute})
+
+		if a, _ := svc.Create("claude", "auth", "claude"); a.Status != AgentStatusActive {
+			t.Fatal("first agent should start")
+		}
+		b, _ := svc.Create("claude", "docs", "claude")
+		if b.Status != AgentStatusPending {
+			t.Fatal("second agent should wait for the stagger gap")
+		}
+		if started := svc.StartQueued(time.Now()); len(started) != 0 {
+			t.Fatal("nothing should start inside the stagger gap")
+		}
+		if started := svc.StartQueued(time.Now().Add(2 * time.Minute)); len(started) != 1 {
+			t.Fatalf("expected the queued agent to start after the gap, got %+v", started)
+		}
+	})
+}
@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// Restart relaunches an agent whose CLI crashed, hung or exited: it stops the
+// agent's session, if any, and starts its command again in a new one in the
+// same worktree. Unlike killing and recreating the agent, its branch,
+// worktree, uncommitted changes and record are kept.
+func (s *AgentService) Restart(sessionID string) error {
+	return s.RestartWithOptions(sessionID, CreateOptions{})
+}
+
+// RestartWithOptions restarts an agent like Restart, starting the new session
+// with the environment and directory in opts, which the store doesn't record,
+// and sending it opts.Prompt. Other options are ignored.
+func (s *AgentService) RestartWithOptions(sessionID string, opts CreateOptions) error {
+	logging.Entry("sessionID", sessionID)
+	return s.restart(sessionID, opts, true)
+}
+
+// restart implements RestartWithOptions. recordTask says whether opts.Prompt
+// may be recorded as the agent's task; Retry's prompts wrap the task, so
+// recording one would nest it in the next retry's prompt.
+func (s *AgentService) restart(sessionID string, opts CreateOptions, recordTask bool) error {
+	agent := s.store.Get(sessionID)
+	if agent == nil {
+		err := fmt.Errorf("agent %q not found", sessionID)
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+	switch agent.Status {
+	case AgentStatusActive, AgentStatusIdle, AgentStatusPaused, AgentStatusExited:
+	default:
+		err := fmt.Errorf("agent %q is %s and can't be restarted", sessionID, agent.Status)
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+
+	if info, err := os.Stat(agent.WorkDir); err != nil || !info.IsDir() {
+		err := fmt.Errorf("worktree %s is missing; recreate it before restarting", agent.WorkDir)
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+	agent.Env, agent.Dir = opts.Env, opts.Dir
+	if opts.Dir != "" {
+		if info, err := os.Stat(agent.SessionDir()); err != nil || !info.IsDir() {
+			err := fmt.Errorf("workdir %q not found in %s", opts.Dir, agent.WorkDir)
+			logging.Error(err, "sessionID", sessionID)
+			return err
+		}
+	}
+
+	if s.tmux.SessionExists(sessionID) {
+		// Stopped processes wouldn't act on the hangup until continued
+		if agent.Status == AgentStatusPaused {
+			if err := s.Resume(sessionID); err != nil {
+				return err
+			}
+		}
+		if err := s.tmux.KillSession(sessionID); err != nil {
+			err = fmt.Errorf("failed to stop agent session: %w", err)
+			logging.Error(err, "sessionID", sessionID)
+			return err
+		}
+	} else if agent.Status == AgentStatusPaused {
+		// The paused processes died with their session
+		if err := s.store.UpdateStatus(sessionID, AgentStatusActive); err != nil {
+			logging.Error(err, "sessionID", sessionID, "action", "update status")
+		}
+		if err := s.store.SetMetadata(sessionID, MetadataPausedAt, ""); err != nil {
+			logging.Error(err, "sessionID", sessionID, "action", "clear pause time")
+		}
+	} else if agent.Status == AgentStatusExited {
+		if err := s.store.UpdateStatus(sessionID, AgentStatusActive); err != nil {
+			logging.Error(err, "sessionID", sessionID, "action", "update status")
+		}
+		if err := s.store.SetMetadata(sessionID, MetadataExitCode, ""); err != nil {
+			logging.Error(err, "sessionID", sessionID, "action", "clear exit code")
+		}
+	}
+
+	if err := s.tmux.CreateSession(sessionID, agent.Command, agent.SessionDir(), agent.Env); err != nil {
+		err = fmt.Errorf("failed to start agent session: %w", err)
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+
+	s.dispatcher.Publish(AgentRestarted{
+		AgentID:   sessionID,
+		Timestamp: time.Now(),
+	})
+	logging.Info("agent restarted, sessionID=%s, dir=%s", sessionID, agent.SessionDir())
+
+	if recordTask {
+		s.sendStartupPrompt(agent, opts.Prompt)
+	} else {
+		s.sendPrompt(agent, opts.Prompt)
+	}
+	s.deliverQueuedMessages(agent)
+	return nil
+}
+
+// RestartPolicy relaunches an agent type's agents when their session dies
+// while they're active, such as after the agent CLI crashes.
+type RestartPolicy struct {
+	// MaxRestarts is how many times one agent is restarted before it's left
+	// terminated.
+	MaxRestarts int
+
+	// Options start the new sessions like RestartWithOptions.
+	Options CreateOptions
+}
+
+// SetRestartPolicies sets the restart policies of agent types by name. Agents
+// of other types are marked exited or terminated when their session dies.
+func (s *AgentService) SetRestartPolicies(policies map[string]RestartPolicy) {
+	s.restarts = make(map[string]RestartPolicy, len(policies))
+	for agentType, policy := range policies {
+		s.restarts[strings.ToLower(agentType)] = policy
+	}
+}
+
+// ExitReport lists the active agents DetectExits found without a session.
+type ExitReport struct {
+	Restarted  []*Agent // relaunched by their restart policy
+	Exited     []*Agent // their command exited on its own, see Agent.ExitCode
+	Terminated []*Agent // their session was killed, and not relaunched
+}
+
+// Empty reports whether no agents were found without a session.
+func (r *ExitReport) Empty() bool {
+	return len(r.Restarted) == 0 && len(r.Exited) == 0 && len(r.Terminated) == 0
+}
+
+// DetectExits checks the active agents for a dead session. Those whose type's
+// restart policy allows it are relaunched; the rest are marked exited, with
+// their command's exit status, or terminated if their session was killed.
+func (s *AgentService) DetectExits() (*ExitReport, error) {
+	logging.Entry("project", s.project)
+	// A hung tmux server reports every session missing; don't mistake that for exits
+	if _, err := s.tmux.ListSessions(); errors.Is(err, ErrDegraded) {
+		err = fmt.Errorf("skipping exit check: %w", err)
+		logging.Error(err)
+		return nil, err
+	}
+
+	report := &ExitReport{}
+	s.restartMu.Lock()
+	defer s.restartMu.Unlock()
+	for _, agent := range s.List() {
+		if agent.Status != AgentStatusActive {
+			continue
+		}
+		// Reread it, as it may have been restarted since listing
+		agent = s.store.Get(agent.ID)
+		if agent == nil || agent.Status != AgentStatusActive || s.tmux.SessionExists(agent.ID) {
+			continue
+		}
+		if s.restartCrashed(agent) {
+			report.Restarted = append(report.Restarted, agent)
+			continue
+		}
+		if s.markExited(agent) {
+			report.Exited = append(report.Exited, agent)
+			continue
+		}
+		if err := s.store.UpdateStatus(agent.ID, AgentStatusTerminated); err != nil {
+			logging.Error(err, "agentID", agent.ID, "action", "update status")
+			continue
+		}
+		report.Terminated = append(report.Terminated, agent)
+	}
+	return report, nil
+}
+
+// restartCrashed relaunches an active agent whose session died if its type's
+// restart policy has restarts left for it, counting the restart. Agents whose
+// command exited successfully have finished, and aren't restarted. It reports
+// whether the agent was restarted. The caller holds restartMu.
+func (s *AgentService) restartCrashed(agent *Agent) bool {
+	policy, ok := s.restarts[strings.ToLower(agent.AgentType)]
+	if !ok || agent.Status != AgentStatusActive {
+		return false
+	}
+	if code, exited := s.tmux.ExitStatus(agent.ID); exited && code == 0 {
+		return false
+	}
+	if agent.Restarts >= policy.MaxRestarts {
+		logging.Info("agent out of restarts, agentID=%s, restarts=%d", agent.ID, agent.Restarts)
+		return false
+	}
+	if err := s.store.AddRestart(agent.ID); err != nil {
+		logging.Error(err, "agentID", agent.ID, "action", "count restart")
+	}
+	if err := s.RestartWithOptions(agent.ID, policy.Options); err != nil {
+		return false
+	}
+	logging.Info("crashed agent restarted, agentID=%s, restart=%d/%d", agent.ID, agent.Restarts+1, policy.MaxRestarts)
+	return true
+}
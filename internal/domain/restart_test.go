@@ -0,0 +1,230 @@
+package domain
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAgentService_Restart(t *testing.T) {
+	setup := func(t *testing.T, status AgentStatus) (*AgentService, *testStore, *mockTmuxClient, *mockDispatcher) {
+		worktree := t.TempDir()
+		store := newTestStore()
+		_ = store.Add(&Agent{ID: "a", Project: "proj", Command: "claude", WorkDir: worktree, Branch: "a", Status: status})
+		tmux := &mockTmuxClient{sessions: map[string]bool{"a": true}, paused: make(map[string]bool)}
+		dispatcher := &mockDispatcher{}
+		return NewAgentService(tmux, store, dispatcher, nil, "proj", "/tmp"), store, tmux, dispatcher
+	}
+
+	t.Run("starts a new session in the worktree", func(t *testing.T) {
+		svc, store, tmux, dispatcher := setup(t, AgentStatusActive)
+		worktree := store.Get("a").WorkDir
+		if err := os.Mkdir(filepath.Join(worktree, "api"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		err := svc.RestartWithOptions("a", CreateOptions{Env: map[string]string{"MODEL": "opus"}, Dir: "api", Prompt: "Carry on"})
+
+		if err != nil {
+			t.Fatalf("Restart: %v", err)
+		}
+		if !tmux.sessions["a"] {
+			t.Error("expected the session to be running")
+		}
+		if tmux.createdDir != filepath.Join(worktree, "api") || tmux.createdEnv["MODEL"] != "opus" {
+			t.Errorf("session started in %q with %v, want the api directory with MODEL=opus", tmux.createdDir, tmux.createdEnv)
+		}
+		if len(tmux.sentKeys) != 1 || tmux.sentKeys[0] != "Carry on" {
+			t.Errorf("sent %q, want the startup prompt", tmux.sentKeys)
+		}
+		if store.Get("a") == nil {
+			t.Error("expected the agent to be kept")
+		}
+		if len(dispatcher.published) != 1 {
+			t.Fatalf("published %d events, want 1", len(dispatcher.published))
+		}
+		if _, ok := dispatcher.published[0].(AgentRestarted); !ok {
+			t.Errorf("published %#v, want AgentRestarted", dispatcher.published[0])
+		}
+	})
+
+	t.Run("relaunches a crashed session", func(t *testing.T) {
+		svc, _, tmux, _ := setup(t, AgentStatusActive)
+		delete(tmux.sessions, "a")
+
+		if err := svc.Restart("a"); err != nil {
+			t.Fatalf("Restart: %v", err)
+		}
+		if !tmux.sessions["a"] {
+			t.Error("expected the session to be running")
+		}
+	})
+
+	t.Run("resumes a paused agent", func(t *testing.T) {
+		svc, store, tmux, _ := setup(t, AgentStatusActive)
+		_ = svc.Pause("a")
+
+		if err := svc.Restart("a"); err != nil {
+			t.Fatalf("Restart: %v", err)
+		}
+		if tmux.paused["a"] {
+			t.Error("expected the paused session to be continued before it was stopped")
+		}
+		if got := store.Get("a").Status; got != AgentStatusActive {
+			t.Errorf("status = %s, want active", got)
+		}
+	})
+
+	t.Run("rejects agents it can't restart", func(t *testing.T) {
+		svc, store, tmux, _ := setup(t, AgentStatusStarting)
+		if err := svc.Restart("a"); err == nil {
+			t.Error("expected an error restarting a starting agent")
+		}
+		if err := svc.Restart("missing"); err == nil {
+			t.Error("expected an error restarting a missing agent")
+		}
+
+		_ = store.UpdateStatus("a", AgentStatusActive)
+		_ = os.RemoveAll(store.Get("a").WorkDir)
+		if err := svc.Restart("a"); err == nil {
+			t.Error("expected an error restarting an agent whose worktree is missing")
+		}
+		if !tmux.sessions["a"] {
+			t.Error("expected the session to be left running")
+		}
+	})
+
+	t.Run("session start failure", func(t *testing.T) {
+		svc, _, tmux, dispatcher := setup(t, AgentStatusActive)
+		tmux.createErr = errors.New("tmux failed")
+
+		if err := svc.Restart("a"); err == nil {
+			t.Error("expected an error when the session can't start")
+		}
+		if len(dispatcher.published) != 0 {
+			t.Errorf("published %d events, want 0", len(dispatcher.published))
+		}
+	})
+}
+
+func TestAgentService_DetectExits(t *testing.T) {
+	setup := func(t *testing.T) (*AgentService, *testStore, *mockTmuxClient) {
+		store := newTestStore()
+		_ = store.Add(&Agent{ID: "claude", Project: "proj", AgentType: "claude", Command: "claude", WorkDir: t.TempDir(), Status: AgentStatusActive})
+		_ = store.Add(&Agent{ID: "aider", Project: "proj", AgentType: "aider", Command: "aider", WorkDir: t.TempDir(), Status: AgentStatusActive})
+		tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+		svc := NewAgentService(tmux, store, &mockDispatcher{}, nil, "proj", "/tmp")
+		svc.SetRestartPolicies(map[string]RestartPolicy{"Claude": {MaxRestarts: 2}})
+		return svc, store, tmux
+	}
+
+	t.Run("restarts agents with a policy until out of restarts", func(t *testing.T) {
+		svc, store, tmux := setup(t)
+
+		for i := 1; i <= 2; i++ {
+			tmux.sessions["aider"] = true
+			report, err := svc.DetectExits()
+			if err != nil {
+				t.Fatalf("DetectExits: %v", err)
+			}
+			if len(report.Restarted) != 1 || report.Restarted[0].ID != "claude" || !tmux.sessions["claude"] {
+				t.Fatalf("restart %d: report %+v, want claude relaunched", i, report)
+			}
+			if got := store.Get("claude").Restarts; got != i {
+				t.Errorf("Restarts = %d, want %d", got, i)
+			}
+			delete(tmux.sessions, "claude")
+		}
+
+		report, _ := svc.DetectExits()
+		if len(report.Restarted) != 0 || len(report.Terminated) != 1 || store.Get("claude").Status != AgentStatusTerminated {
+			t.Errorf("report %+v, want claude terminated once out of restarts", report)
+		}
+	})
+
+	t.Run("marks agents whose command exited exited", func(t *testing.T) {
+		svc, store, tmux := setup(t)
+		tmux.exits = map[string]int{"claude": 0, "aider": 2}
+
+		report, err := svc.DetectExits()
+
+		if err != nil {
+			t.Fatalf("DetectExits: %v", err)
+		}
+		if len(report.Restarted) != 0 || len(report.Exited) != 2 || len(report.Terminated) != 0 {
+			t.Fatalf("report %+v, want both exited and a successful exit not restarted", report)
+		}
+		for id, want := range tmux.exits {
+			agent := store.Get(id)
+			if code, ok := agent.ExitCode(); agent.Status != AgentStatusExited || !ok || code != want {
+				t.Errorf("%s: status %s, exit code %d (%v), want exited with %d", id, agent.Status, code, ok, want)
+			}
+		}
+		if len(svc.List()) != 2 {
+			t.Errorf("listed %d agents, want exited agents listed", len(svc.List()))
+		}
+
+		if err := svc.Restart("aider"); err != nil {
+			t.Fatalf("Restart: %v", err)
+		}
+		agent := store.Get("aider")
+		if _, ok := agent.ExitCode(); agent.Status != AgentStatusActive || ok || !tmux.sessions["aider"] {
+			t.Errorf("status %s, want an exited agent active again after restart", agent.Status)
+		}
+	})
+
+	t.Run("restarts a failed exit", func(t *testing.T) {
+		svc, store, tmux := setup(t)
+		tmux.sessions["aider"] = true
+		tmux.exits = map[string]int{"claude": 1}
+
+		report, _ := svc.DetectExits()
+
+		if len(report.Restarted) != 1 || store.Get("claude").Status != AgentStatusActive {
+			t.Errorf("report %+v, want claude restarted after failing", report)
+		}
+	})
+
+	t.Run("reconcile restarts instead of terminating", func(t *testing.T) {
+		svc, store, tmux := setup(t)
+
+		report, err := svc.Reconcile()
+
+		if err != nil {
+			t.Fatalf("Reconcile: %v", err)
+		}
+		if len(report.Restarted) != 1 || report.Restarted[0] != "claude" || !tmux.sessions["claude"] {
+			t.Errorf("Restarted = %v, want claude", report.Restarted)
+		}
+		if len(report.Terminated) != 1 || report.Terminated[0] != "aider" || store.Get("aider").Status != AgentStatusTerminated {
+			t.Errorf("Terminated = %v, want aider", report.Terminated)
+		}
+	})
+
+	t.Run("reconcile marks exited agents exited", func(t *testing.T) {
+		svc, store, tmux := setup(t)
+		tmux.exits = map[string]int{"aider": 0}
+
+		report, _ := svc.Reconcile()
+
+		if len(report.Exited) != 1 || report.Exited[0] != "aider" || store.Get("aider").Status != AgentStatusExited {
+			t.Errorf("Exited = %v, want aider", report.Exited)
+		}
+		if again, _ := svc.Reconcile(); len(again.Exited) != 0 || len(again.Terminated) != 0 {
+			t.Errorf("second reconcile = %+v, want exited agents left alone", again)
+		}
+	})
+
+	t.Run("skips a degraded tmux server", func(t *testing.T) {
+		svc, store, tmux := setup(t)
+		tmux.listErr = ErrDegraded
+
+		if _, err := svc.DetectExits(); !errors.Is(err, ErrDegraded) {
+			t.Errorf("err = %v, want ErrDegraded", err)
+		}
+		if store.Get("claude").Restarts != 0 {
+			t.Error("expected no restart")
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// MetadataTask records the startup prompt an agent was first given, so Retry
+// can hand the task to a fresh session.
+const MetadataTask = "task"
+
+// retryTranscriptLines is how many trailing transcript lines seed a retry when
+// the agent has no summary.
+const retryTranscriptLines = 40
+
+// Retry relaunches an agent whose command exited or crashed in a fresh session
+// on the same branch and worktree, seeded with a prompt carrying its task and
+// its progress so far: its summary, asking the summarizer for one if there is
+// none, or else the tail of its transcript. The new session starts with the
+// environment and directory in opts; opts.Prompt is recorded as the task if
+// none was.
+func (s *AgentService) Retry(sessionID string, opts CreateOptions) error {
+	logging.Entry("sessionID", sessionID)
+	agent := s.store.Get(sessionID)
+	if agent == nil {
+		err := fmt.Errorf("agent %q not found", sessionID)
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+	if agent.Status != AgentStatusExited && (agent.Status != AgentStatusActive || s.tmux.SessionExists(sessionID)) {
+		err := fmt.Errorf("agent %q is still running; only exited or crashed agents can be retried", sessionID)
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+
+	task := agent.Metadata[MetadataTask]
+	if task == "" && strings.TrimSpace(opts.Prompt) != "" {
+		task = ExpandVars(opts.Prompt, promptVars(agent))
+		if err := s.store.SetMetadata(sessionID, MetadataTask, task); err != nil {
+			logging.Error(err, "sessionID", sessionID, "action", "record task")
+		}
+	}
+	summary := agent.Summary
+	if summary == "" && s.HasSummarizer() {
+		// Without one the transcript tail stands in
+		summary, _ = s.Summarize(sessionID)
+	}
+	var output []string
+	if summary == "" {
+		if lines, err := s.Transcript(sessionID); err == nil {
+			if len(lines) > retryTranscriptLines {
+				lines = lines[len(lines)-retryTranscriptLines:]
+			}
+			for _, line := range lines {
+				if strings.TrimSpace(line.Text) != "" {
+					output = append(output, line.Text)
+				}
+			}
+		}
+	}
+
+	opts.Prompt = RetryPrompt(agent, task, summary, output)
+	if err := s.restart(sessionID, opts, false); err != nil {
+		return err
+	}
+	logging.Info("agent retried, sessionID=%s, task=%v, summary=%v, outputLines=%d", sessionID, task != "", summary != "", len(output))
+	return nil
+}
+
+// RetryPrompt builds the prompt seeding a retried agent's new session from its
+// task, its summary and the tail of its output, leaving out any that are empty.
+func RetryPrompt(agent *Agent, task, summary string, output []string) string {
+	var b strings.Builder
+	b.WriteString("A previous session working on this branch")
+	if agent.Branch != "" {
+		b.WriteString(" (" + agent.Branch + ")")
+	}
+	if code, ok := agent.ExitCode(); ok {
+		fmt.Fprintf(&b, " exited with status %d", code)
+	} else {
+		b.WriteString(" stopped unexpectedly")
+	}
+	b.WriteString(". You're picking up where it left off; its changes are in the worktree.\n")
+	if task = strings.TrimSpace(task); task != "" {
+		b.WriteString("\nTask:\n" + task + "\n")
+	}
+	if summary = strings.TrimSpace(summary); summary != "" {
+		b.WriteString("\nProgress so far:\n" + summary + "\n")
+	}
+	if len(output) > 0 {
+		b.WriteString("\nIts last output:\n" + strings.Join(output, "\n") + "\n")
+	}
+	b.WriteString("\nCheck the worktree's state, then continue the task.")
+	return b.String()
+}
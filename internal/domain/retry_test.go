@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAgentService_Retry(t *testing.T) {
+	setup := func(t *testing.T, agent *Agent) (*AgentService, *testStore, *mockTmuxClient) {
+		agent.ID, agent.Project, agent.Name, agent.Command, agent.Branch = "a", "proj", "auth", "claude", "craizy/auth"
+		agent.WorkDir = t.TempDir()
+		store := newTestStore()
+		_ = store.Add(agent)
+		tmux := &mockTmuxClient{sessions: map[string]bool{}}
+		return NewAgentService(tmux, store, &mockDispatcher{}, nil, "proj", t.TempDir()), store, tmux
+	}
+
+	t.Run("seeds a fresh session with the task and summary", func(t *testing.T) {
+		svc, store, tmux := setup(t, &Agent{
+			Status:   AgentStatusExited,
+			Summary:  "Added the refresh endpoint",
+			Metadata: map[string]string{MetadataTask: "Add token refresh", MetadataExitCode: "1"},
+		})
+
+		if err := svc.Retry("a", CreateOptions{}); err != nil {
+			t.Fatalf("Retry: %v", err)
+		}
+		if !tmux.sessions["a"] || store.Get("a").Status != AgentStatusActive {
+			t.Error("expected the agent running again")
+		}
+		if len(tmux.sentKeys) != 1 {
+			t.Fatalf("sent %q, want one prompt", tmux.sentKeys)
+		}
+		for _, want := range []string{"(craizy/auth) exited with status 1", "Task:\nAdd token refresh", "Progress so far:\nAdded the refresh endpoint"} {
+			if !strings.Contains(tmux.sentKeys[0], want) {
+				t.Errorf("prompt %q lacks %q", tmux.sentKeys[0], want)
+			}
+		}
+		if got := store.Get("a").Metadata[MetadataTask]; got != "Add token refresh" {
+			t.Errorf("task = %q, want the original task kept", got)
+		}
+	})
+
+	t.Run("falls back to the transcript tail", func(t *testing.T) {
+		svc, _, tmux := setup(t, &Agent{Status: AgentStatusActive})
+		path := TranscriptPath(svc.workDir, "a")
+		_ = os.MkdirAll(filepath.Dir(path), 0o755)
+		var transcript strings.Builder
+		for i := range retryTranscriptLines + 5 {
+			transcript.WriteString(FormatTranscriptLine(time.Now(), "line "+string(rune('A'+i%26))))
+		}
+		transcript.WriteString(FormatTranscriptLine(time.Now(), "panic: out of memory"))
+		if err := os.WriteFile(path, []byte(transcript.String()), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := svc.Retry("a", CreateOptions{Prompt: "Fix the flaky test"}); err != nil {
+			t.Fatalf("Retry: %v", err)
+		}
+		prompt := tmux.sentKeys[0]
+		if !strings.Contains(prompt, "stopped unexpectedly") || !strings.Contains(prompt, "Task:\nFix the flaky test") {
+			t.Errorf("prompt %q, want the crash and fallback task", prompt)
+		}
+		if !strings.HasSuffix(strings.Split(prompt, "\n\nCheck")[0], "panic: out of memory") || strings.Count(prompt, "\nline ") != retryTranscriptLines-1 {
+			t.Errorf("prompt %q, want the last %d transcript lines", prompt, retryTranscriptLines)
+		}
+	})
+
+	t.Run("doesn't nest prompts on repeated retries", func(t *testing.T) {
+		for _, task := range []string{"Fix the flaky test", ""} {
+			svc, store, tmux := setup(t, &Agent{Status: AgentStatusExited, Summary: "Found the race"})
+
+			for range 2 {
+				if err := svc.Retry("a", CreateOptions{Prompt: task}); err != nil {
+					t.Fatalf("Retry: %v", err)
+				}
+				tmux.sessions["a"] = false
+				_ = store.UpdateStatus("a", AgentStatusExited)
+			}
+			if len(tmux.sentKeys) != 2 {
+				t.Fatalf("sent %q, want two prompts", tmux.sentKeys)
+			}
+			if got := strings.Count(tmux.sentKeys[1], "A previous session"); got != 1 {
+				t.Errorf("task %q: second prompt %q nests %d retry prompts", task, tmux.sentKeys[1], got)
+			}
+			if got := store.Get("a").Metadata[MetadataTask]; got != task {
+				t.Errorf("task = %q, want %q", got, task)
+			}
+		}
+	})
+
+	t.Run("refuses a running agent", func(t *testing.T) {
+		svc, _, tmux := setup(t, &Agent{Status: AgentStatusActive})
+		tmux.sessions["a"] = true
+
+		if err := svc.Retry("a", CreateOptions{}); err == nil {
+			t.Error("expected an error retrying a running agent")
+		}
+	})
+}
+
+func TestAgentService_StartupPromptRecordsTask(t *testing.T) {
+	store := newTestStore()
+	_ = store.Add(&Agent{ID: "a", Project: "proj", Command: "claude", WorkDir: t.TempDir(), Status: AgentStatusActive})
+	tmux := &mockTmuxClient{sessions: map[string]bool{"a": true}}
+	svc := NewAgentService(tmux, store, &mockDispatcher{}, nil, "proj", "/tmp")
+
+	for _, prompt := range []string{"Add token refresh", "Carry on"} {
+		if err := svc.RestartWithOptions("a", CreateOptions{Prompt: prompt}); err != nil {
+			t.Fatalf("RestartWithOptions: %v", err)
+		}
+	}
+
+	if got := store.Get("a").Metadata[MetadataTask]; got != "Add token refresh" {
+		t.Errorf("task = %q, want the first prompt", got)
+	}
+}
@@ -1,9 +1,13 @@
 package domain
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -18,15 +22,46 @@ const (
 
 // AgentService orchestrates agent operations using the tmux client and store.
 type AgentService struct {
-	tmux       ITmuxClient
-	store      IAgentStore
-	dispatcher IEventDispatcher
-	git        IGitClient
-	project    string
-	workDir    string
-	messageSvc *MessageService // Optional - set via SetMessageService
+	tmux          ITmuxClient
+	store         IAgentStore
+	dispatcher    IEventDispatcher
+	git           IGitClient
+	project       string
+	workDir       string
+	messageSvc    *MessageService          // Optional - set via SetMessageService
+	merges        IMergeStore              // Optional - set via SetMergeStore
+	github        IGitHubClient            // Optional - set via SetGitHubClient
+	protected     []string                 // Base branches that require a pull request instead of a local merge
+	wtOptions     WorktreeOptions          // Extra setup run after creating a worktree
+	pool          []PoolSpec               // Warm pool sizes per agent type
+	poolMu        sync.Mutex               // Serializes pool fills
+	ephemeral     bool                     // Store is in-memory; leave unknown tmux sessions alone
+	attentionIdle time.Duration            // Silence before an agent needs attention (0 = DefaultAttentionIdle)
+	summarizer    ISummarizer              // Optional - set via SetSummarizer
+	budget        Budget                   // Active time limits, see SetBudget
+	providers     []ProviderPool           // Concurrency limits shared by agent types
+	health        []IHealthCheck           // Dependencies reported by Degraded
+	diskThreshold int64                    // Worktree disk usage warning threshold in bytes
+	disk          diskCache                // Measured worktree sizes
+	devEnv        IDevEnvironment          // Optional - set via SetDevEnvironment
+	switcher      ISessionSwitcher         // Optional - set via SetSessionSwitcher
+	held          map[string]CreateOptions // Options of queued agents the store doesn't keep, by session ID
+	heldMu        sync.Mutex
+	progress      IProgressReporter // Optional - set via SetProgressReporter
+	starting      map[string]bool   // Agents this process is preparing, see StartCreate
+	startingMu    sync.Mutex
+	startSlots    chan struct{}            // Limits concurrent background checkouts to MaxParallelStarts
+	templateMu    sync.RWMutex             // Held for writing while the template worktree is refreshed
+	templateReady bool                     // Whether the template worktree can be cloned
+	restarts      map[string]RestartPolicy // By lowercased agent type, see SetRestartPolicies
+	restartMu     sync.Mutex               // Serializes crash restarts
+	captureTypes  map[string]bool          // Lowercased agent types with output capture, see SetOutputCapture
+	mergeStrategy MergeStrategy            // Default for MergeAgent, see SetMergeStrategy
 }
 
+// MaxParallelStarts is how many agents StartCreate prepares at once; the rest wait their turn.
+const MaxParallelStarts = 4
+
 // NewAgentService creates a new AgentService with the given dependencies.
 func NewAgentService(tmux ITmuxClient, store IAgentStore, dispatcher IEventDispatcher, git IGitClient, project, workDir string) *AgentService {
 	return &AgentService{
@@ -36,6 +71,8 @@ func NewAgentService(tmux ITmuxClient, store IAgentStore, dispatcher IEventDispa
 		git:        git,
 		project:    project,
 		workDir:    workDir,
+		starting:   make(map[string]bool),
+		startSlots: make(chan struct{}, MaxParallelStarts),
 	}
 }
 
@@ -45,17 +82,151 @@ func (s *AgentService) SetMessageService(messageSvc *MessageService) {
 	s.messageSvc = messageSvc
 }
 
+// SetMergeStore sets the store used to read merge history.
+// This is optional - if not set, MergeHistory returns no records.
+func (s *AgentService) SetMergeStore(merges IMergeStore) {
+	s.merges = merges
+}
+
+// SetGitHubClient sets the client used for remote branch protection and pull requests.
+// This is optional - if not set, only locally configured protected branches are detected.
+func (s *AgentService) SetGitHubClient(github IGitHubClient) {
+	s.github = github
+}
+
+// SetProtectedBranches sets the locally configured protected base branches.
+func (s *AgentService) SetProtectedBranches(branches []string) {
+	s.protected = branches
+}
+
+// SetWorktreeOptions sets the extra setup run after creating an agent worktree.
+func (s *AgentService) SetWorktreeOptions(opts WorktreeOptions) {
+	s.wtOptions = opts
+}
+
+// SetDevEnvironment sets how agents are launched inside their worktree's
+// development environment. If not set, commands run as configured.
+func (s *AgentService) SetDevEnvironment(env IDevEnvironment) {
+	s.devEnv = env
+}
+
+// SetEphemeral marks the service as backed by a throwaway in-memory store.
+// Reconcile then skips killing tmux sessions missing from the store, since
+// they most likely belong to a persistent crAIzy instance.
+func (s *AgentService) SetEphemeral(ephemeral bool) {
+	s.ephemeral = ephemeral
+}
+
 // Create spawns a new agent session and stores it.
 func (s *AgentService) Create(agentType, name, command string) (*Agent, error) {
-	logging.Entry("agentType", agentType, "name", name, "command", command)
+	return s.CreateWithOptions(agentType, name, command, CreateOptions{})
+}
+
+// CreateWithOptions spawns a new agent session with optional settings and stores it.
+func (s *AgentService) CreateWithOptions(agentType, name, command string, opts CreateOptions) (*Agent, error) {
+	logging.Entry("agentType", agentType, "name", name, "command", command, "sparsePaths", opts.SparsePaths, "baseBranch", opts.BaseBranch, "prompt", opts.Prompt != "")
 	sessionID := BuildSessionID(s.project, agentType, name)
 
+	if agent, handled, err := s.beginCreate(sessionID, agentType, name, command, opts); handled || err != nil {
+		return agent, err
+	}
+
+	agent, err := s.spawn(sessionID, agentType, name, command, opts, AgentStatusActive)
+	if err != nil {
+		return nil, err
+	}
+
+	s.sendStartupPrompt(agent, opts.Prompt)
+
+	// Deliver any queued messages
+	s.deliverQueuedMessages(agent)
+
+	logging.Info("agent created successfully, sessionID=%s", sessionID)
+	return agent, nil
+}
+
+// StartCreate is CreateWithOptions for callers that shouldn't wait on a
+// checkout. The agent is stored as starting and returned at once while a
+// background worker prepares its worktree and session. Failures after it
+// returns are reported through the progress reporter.
+func (s *AgentService) StartCreate(agentType, name, command string, opts CreateOptions) (*Agent, error) {
+	logging.Entry("agentType", agentType, "name", name, "command", command, "sparsePaths", opts.SparsePaths, "baseBranch", opts.BaseBranch, "prompt", opts.Prompt != "")
+	sessionID := BuildSessionID(s.project, agentType, name)
+
+	if agent, handled, err := s.beginCreate(sessionID, agentType, name, command, opts); handled || err != nil {
+		return agent, err
+	}
+
+	placeholder := &Agent{
+		ID:          sessionID,
+		Project:     s.project,
+		AgentType:   agentType,
+		Name:        name,
+		Command:     command,
+		WorkDir:     s.workDir,
+		Status:      AgentStatusStarting,
+		CreatedAt:   time.Now(),
+		SparsePaths: opts.SparsePaths,
+		BaseBranch:  opts.BaseBranch,
+		Metadata:    opts.Metadata,
+	}
+
+	s.startingMu.Lock()
+	s.starting[sessionID] = true
+	s.startingMu.Unlock()
+
+	// Publish event - adapters will store the starting agent
+	s.dispatcher.Publish(AgentStarting{
+		Agent:     placeholder,
+		Timestamp: time.Now(),
+	})
+	s.reportProgress(OperationCreate, name, "waiting to check out")
+
+	go s.finishStart(placeholder, opts)
+
+	logging.Info("agent starting in the background, sessionID=%s", sessionID)
+	return placeholder, nil
+}
+
+// finishStart prepares a starting agent's worktree and session, running at
+// most MaxParallelStarts checkouts at once.
+func (s *AgentService) finishStart(placeholder *Agent, opts CreateOptions) {
+	s.startSlots <- struct{}{}
+	defer func() { <-s.startSlots }()
+	defer func() {
+		s.startingMu.Lock()
+		delete(s.starting, placeholder.ID)
+		s.startingMu.Unlock()
+	}()
+
+	agent, err := s.spawn(placeholder.ID, placeholder.AgentType, placeholder.Name, placeholder.Command, opts, AgentStatusActive)
+	if err != nil {
+		logging.Error(err, "sessionID", placeholder.ID, "action", "start agent")
+		return
+	}
+
+	s.sendStartupPrompt(agent, opts.Prompt)
+	s.deliverQueuedMessages(agent)
+	logging.Info("agent started, sessionID=%s", agent.ID)
+}
+
+// isStarting reports whether this process is still preparing the agent's worktree.
+func (s *AgentService) isStarting(sessionID string) bool {
+	s.startingMu.Lock()
+	defer s.startingMu.Unlock()
+	return s.starting[sessionID]
+}
+
+// beginCreate validates a new agent and handles the cases that don't need a
+// new worktree: queueing behind a provider's limits and claiming a warm pool
+// agent. handled is true if the returned agent needs nothing further.
+func (s *AgentService) beginCreate(sessionID, agentType, name, command string, opts CreateOptions) (agent *Agent, handled bool, err error) {
 	// Check if an active session already exists
 	existing := s.store.Get(sessionID)
-	if existing != nil && existing.Status == AgentStatusActive {
+	if existing != nil && (existing.Status == AgentStatusActive || existing.Status == AgentStatusStarting || existing.Status == AgentStatusPaused) {
 		err := fmt.Errorf("agent session %q already exists", sessionID)
 		logging.Error(err, "sessionID", sessionID)
-		return nil, err
+		return nil, false, err
 	}
 
 	// Remove any terminated agent with same ID before creating new one
@@ -63,25 +234,68 @@ func (s *AgentService) Create(agentType, name, command string) (*Agent, error) {
 		_ = s.store.Remove(sessionID)
 	}
 
+	// Check if branch already exists
+	if s.git != nil && s.git.BranchExists(sessionID) {
+		err := fmt.Errorf("branch %q already exists", sessionID)
+		logging.Error(err, "branch", sessionID)
+		return nil, false, err
+	}
+
+	// Queue behind the provider's limits; agents already waiting go first
+	if pool := s.providerPool(agentType); pool != nil {
+		ok, reason := s.canStart(pool, time.Now())
+		if ok && s.queuedInPool(pool) {
+			ok, reason = false, pool.Name+" has agents waiting"
+		}
+		if !ok {
+			s.holdOptions(sessionID, opts)
+			return s.enqueue(sessionID, agentType, name, command, opts, reason), true, nil
+		}
+	}
+
+	// Hand over a warm agent from the pool if one is ready
+	if len(opts.SparsePaths) == 0 && opts.BaseBranch == "" {
+		if agent := s.claimWarm(sessionID, agentType, name, command, opts.Metadata); agent != nil {
+			s.sendStartupPrompt(agent, opts.Prompt)
+			s.deliverQueuedMessages(agent)
+			logging.Info("agent created from warm pool, sessionID=%s", sessionID)
+			return agent, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// spawn creates the worktree for a new agent and publishes AgentCreated with the given status.
+func (s *AgentService) spawn(sessionID, agentType, name, command string, opts CreateOptions, status AgentStatus) (_ *Agent, err error) {
+	logging.Entry("sessionID", sessionID, "status", status)
+
 	// Build branch name from session ID
 	branchName := sessionID
 
-	// Check if branch already exists
-	if s.git != nil && s.git.BranchExists(branchName) {
-		err := fmt.Errorf("branch %q already exists", branchName)
-		logging.Error(err, "branch", branchName)
-		return nil, err
-	}
+	s.reportProgress(OperationCreate, name, "starting")
+	defer func() {
+		// Drop a StartCreate placeholder first, so the failure is reported with the agent gone
+		if placeholder := s.store.Get(sessionID); err != nil && placeholder != nil && placeholder.Status == AgentStatusStarting {
+			_ = s.store.Remove(sessionID)
+		}
+		s.reportFailed(OperationCreate, name, err)
+	}()
 
-	// Get current branch as base
-	var baseBranch string
+	// Base on the requested branch, or the current one
+	baseBranch := opts.BaseBranch
 	var worktreePath string
 	if s.git != nil {
-		var err error
-		baseBranch, err = s.git.CurrentBranch(s.workDir)
-		if err != nil {
-			err = fmt.Errorf("failed to get current branch: %w", err)
-			logging.Error(err, "workDir", s.workDir)
+		if baseBranch == "" {
+			var err error
+			baseBranch, err = s.git.CurrentBranch(s.workDir)
+			if err != nil {
+				err = fmt.Errorf("failed to get current branch: %w", err)
+				logging.Error(err, "workDir", s.workDir)
+				return nil, err
+			}
+		} else if !s.git.BranchExists(baseBranch) {
+			err := fmt.Errorf("base branch %q does not exist", baseBranch)
+			logging.Error(err, "baseBranch", baseBranch)
 			return nil, err
 		}
 
@@ -89,11 +303,26 @@ func (s *AgentService) Create(agentType, name, command string) (*Agent, error) {
 		worktreePath = filepath.Join(s.workDir, WorktreesDir, SanitizeName(name))
 
 		// Create worktree with new branch
-		if err := s.git.CreateWorktree(worktreePath, branchName, baseBranch); err != nil {
+		s.reportProgress(OperationCreate, name, "creating worktree "+branchName)
+		if err := s.createWorktree(worktreePath, branchName, baseBranch, opts.SparsePaths); err != nil {
 			err = fmt.Errorf("failed to create worktree: %w", err)
 			logging.Error(err, "worktreePath", worktreePath, "branch", branchName)
 			return nil, err
 		}
+
+		s.reportProgress(OperationCreate, name, "preparing worktree")
+		if err := s.prepareWorktree(worktreePath); err != nil {
+			logging.Error(err, "worktreePath", worktreePath)
+			_ = s.git.RemoveWorktree(worktreePath)
+			_ = s.git.DeleteBranch(branchName)
+			return nil, err
+		}
+
+		if s.devEnv != nil {
+			var warnings []string
+			command, warnings = s.devEnv.Prepare(worktreePath, command)
+			s.warnDevEnvironment(sessionID, warnings)
+		}
 	}
 
 	// Set agent work directory to worktree if created, otherwise use main workDir
@@ -102,6 +331,18 @@ func (s *AgentService) Create(agentType, name, command string) (*Agent, error) {
 		agentWorkDir = worktreePath
 	}
 
+	if opts.Dir != "" {
+		if info, err := os.Stat(filepath.Join(agentWorkDir, opts.Dir)); err != nil || !info.IsDir() {
+			err := fmt.Errorf("workdir %q not found in %s", opts.Dir, agentWorkDir)
+			logging.Error(err, "sessionID", sessionID)
+			if worktreePath != "" {
+				_ = s.git.RemoveWorktree(worktreePath)
+				_ = s.git.DeleteBranch(branchName)
+			}
+			return nil, err
+		}
+	}
+
 	agent := &Agent{
 		ID:         sessionID,
 		Project:    s.project,
@@ -109,25 +350,142 @@ func (s *AgentService) Create(agentType, name, command string) (*Agent, error) {
 		Name:       name,
 		Command:    command,
 		WorkDir:    agentWorkDir,
-		Status:     AgentStatusActive,
+		Status:     status,
 		CreatedAt:  time.Now(),
 		Branch:     branchName,
 		BaseBranch: baseBranch,
+		Env:        opts.Env,
+		Dir:        opts.Dir,
+		Metadata:   opts.Metadata,
+	}
+	if worktreePath != "" {
+		agent.SparsePaths = opts.SparsePaths
+	}
+
+	// An agent from StartCreate replaces its placeholder, unless it was killed meanwhile
+	if placeholder := s.store.Get(sessionID); placeholder != nil {
+		if placeholder.Status != AgentStatusStarting {
+			if worktreePath != "" {
+				_ = s.git.RemoveWorktree(worktreePath)
+				_ = s.git.DeleteBranch(branchName)
+			}
+			err := fmt.Errorf("agent %q was stopped while starting", sessionID)
+			logging.Error(err, "sessionID", sessionID)
+			return nil, err
+		}
+		_ = s.store.Remove(sessionID)
 	}
 
 	// Publish event - adapters will create tmux session and store agent
+	s.reportProgress(OperationCreate, name, "starting session")
 	s.dispatcher.Publish(AgentCreated{
 		Agent:     agent,
 		Timestamp: time.Now(),
 	})
 
-	// Deliver any queued messages
-	s.deliverQueuedMessages(agent)
-
-	logging.Info("agent created successfully, sessionID=%s", sessionID)
 	return agent, nil
 }
 
+// createWorktree creates a full or sparse worktree depending on sparsePaths.
+// Full worktrees are cloned from the template worktree when there is one.
+func (s *AgentService) createWorktree(path, branch, baseBranch string, sparsePaths []string) error {
+	if len(sparsePaths) > 0 {
+		return s.git.CreateSparseWorktree(path, branch, baseBranch, sparsePaths)
+	}
+	if s.cloneTemplate(path, branch, baseBranch) {
+		return nil
+	}
+	return s.git.CreateWorktree(path, branch, baseBranch)
+}
+
+// prepareWorktree runs the configured submodule and LFS setup in a new worktree.
+func (s *AgentService) prepareWorktree(path string) error {
+	logging.Entry("path", path)
+	if s.wtOptions.Submodules {
+		if err := s.git.UpdateSubmodules(path); err != nil {
+			return fmt.Errorf("failed to update submodules: %w", err)
+		}
+	}
+	if s.wtOptions.LFS {
+		if err := s.git.LFSPull(path); err != nil {
+			return fmt.Errorf("failed to pull LFS objects: %w", err)
+		}
+	}
+	// A cache that can't be shared only costs the agent a slower first build
+	for _, cache := range s.wtOptions.Caches {
+		if err := cache.Share(s.workDir, path); err != nil {
+			logging.Error(err, "path", path, "cache", cache.Name())
+		}
+	}
+	return nil
+}
+
+// warnDevEnvironment tells the human about development environments an agent runs without.
+func (s *AgentService) warnDevEnvironment(agentID string, warnings []string) {
+	for _, warning := range warnings {
+		logging.Info("dev environment warning, agentID=%s: %s", agentID, warning)
+		if s.messageSvc == nil {
+			continue
+		}
+		if _, err := s.messageSvc.Send(agentID, s.messageSvc.NotifyHuman(), MessageTypeInfo, warning, nil); err != nil {
+			logging.Error(err, "agentID", agentID, "action", "notify dev environment")
+		}
+	}
+}
+
+// sendStartupPrompt expands the template variables in prompt and sends it to a
+// newly started agent. The first prompt an agent is sent is recorded as its
+// task, see Retry.
+func (s *AgentService) sendStartupPrompt(agent *Agent, prompt string) {
+	text, ok := s.sendPrompt(agent, prompt)
+	if !ok || agent.Metadata[MetadataTask] != "" {
+		return
+	}
+	if err := s.store.SetMetadata(agent.ID, MetadataTask, text); err != nil {
+		logging.Error(err, "agentID", agent.ID, "action", "record task")
+	}
+}
+
+// sendPrompt expands the template variables in prompt and sends it to an
+// agent, returning the text sent and whether there was one.
+func (s *AgentService) sendPrompt(agent *Agent, prompt string) (string, bool) {
+	if strings.TrimSpace(prompt) == "" {
+		return "", false
+	}
+	text := ExpandVars(prompt, promptVars(agent))
+	if err := s.tmux.SendKeys(agent.ID, text); err != nil {
+		logging.Error(err, "agentID", agent.ID, "action", "send startup prompt")
+		return "", false
+	}
+	logging.Info("startup prompt sent, agentID=%s", agent.ID)
+	return text, true
+}
+
+// holdOptions keeps the options of a queued agent the store doesn't record,
+// its startup prompt, environment and directory, until StartQueued starts it.
+// Held options live in memory, so they are lost if crAIzy exits first.
+func (s *AgentService) holdOptions(sessionID string, opts CreateOptions) {
+	s.heldMu.Lock()
+	defer s.heldMu.Unlock()
+	if opts.Prompt == "" && len(opts.Env) == 0 && opts.Dir == "" {
+		delete(s.held, sessionID)
+		return
+	}
+	if s.held == nil {
+		s.held = make(map[string]CreateOptions)
+	}
+	s.held[sessionID] = opts
+}
+
+// takeOptions returns and forgets a queued agent's held options.
+func (s *AgentService) takeOptions(sessionID string) CreateOptions {
+	s.heldMu.Lock()
+	defer s.heldMu.Unlock()
+	opts := s.held[sessionID]
+	delete(s.held, sessionID)
+	return opts
+}
+
 // deliverQueuedMessages delivers any unread messages to a newly created agent.
 func (s *AgentService) deliverQueuedMessages(agent *Agent) {
 	if s.messageSvc == nil {
@@ -143,36 +501,36 @@ func (s *AgentService) deliverQueuedMessages(agent *Agent) {
 	if len(messages) == 0 {
 		return
 	}
+	if s.messageSvc.nudges(agent) {
+		s.messageSvc.nudge(agent.ID)
+		return
+	}
 
-	// Header
-	header := fmt.Sprintf("\n=== %d queued messages ===\n", len(messages))
-	if err := s.tmux.SendKeys(agent.ID, header); err != nil {
-		logging.Error(err, "agentID", agent.ID, "action", "send header")
+	// One delivery, so transports that nudge the agent do it once
+	var text strings.Builder
+	fmt.Fprintf(&text, "\n=== %d queued messages ===\n", len(messages))
+	for _, msg := range messages {
+		fmt.Fprintf(&text, "[%s from %s]: %s\n", msg.Type, msg.From, msg.Content)
+	}
+	text.WriteString("=== End of queued messages ===\n\n")
+	if err := s.messageSvc.Notify(agent.ID, text.String()); err != nil {
 		return
 	}
 
-	// Deliver each message
 	for _, msg := range messages {
-		notification := fmt.Sprintf("[%s from %s]: %s\n",
-			msg.Type, msg.From, msg.Content)
-		if err := s.tmux.SendKeys(agent.ID, notification); err != nil {
-			logging.Error(err, "agentID", agent.ID, "msgID", msg.ID, "action", "deliver message")
-			continue
-		}
 		if err := s.messageSvc.MarkRead(msg.ID); err != nil {
 			logging.Error(err, "msgID", msg.ID, "action", "mark read")
 		}
 	}
-
-	// Footer
-	if err := s.tmux.SendKeys(agent.ID, "=== End of queued messages ===\n\n"); err != nil {
-		logging.Error(err, "agentID", agent.ID, "action", "send footer")
-	}
 }
 
 // Kill terminates an agent session.
 func (s *AgentService) Kill(sessionID string) error {
 	logging.Entry("sessionID", sessionID)
+	// Stopped processes wouldn't act on the hangup until continued
+	if agent := s.store.Get(sessionID); agent != nil && agent.Status == AgentStatusPaused {
+		_ = s.tmux.ResumeSession(sessionID)
+	}
 	// Publish event - adapters will kill tmux session and update status
 	s.dispatcher.Publish(AgentKilled{
 		AgentID:   sessionID,
@@ -202,20 +560,35 @@ func (s *AgentService) CheckKill(sessionID string) (hasUncommitted bool, err err
 		return false, nil
 	}
 
-	hasUncommitted = s.git.HasUncommittedChanges(agent.WorkDir)
+	hasUncommitted = s.hasUncommittedChanges(agent.WorkDir)
 	logging.Info("checked for uncommitted changes, sessionID=%s, hasUncommitted=%v", sessionID, hasUncommitted)
 	return hasUncommitted, nil
 }
 
+// hasUncommittedChanges reports whether the worktree at path has uncommitted
+// changes, bypassing any cache: every caller decides from it whether work
+// would be lost, and agents edit their worktrees without publishing events.
+func (s *AgentService) hasUncommittedChanges(path string) bool {
+	if fresh, ok := s.git.(IFreshGitProbe); ok {
+		return fresh.HasUncommittedChangesFresh(path)
+	}
+	return s.git.HasUncommittedChanges(path)
+}
+
 // ForceKill terminates an agent, optionally discarding uncommitted changes.
+// When keeping changes, the agent is left running if they can't be stashed,
+// since killing removes its worktree.
 func (s *AgentService) ForceKill(sessionID string, discardChanges bool) error {
 	logging.Entry("sessionID", sessionID, "discardChanges", discardChanges)
 	if s.git != nil && !discardChanges {
 		agent := s.store.Get(sessionID)
-		if agent != nil && agent.Branch != "" && s.git.HasUncommittedChanges(agent.WorkDir) {
+		if agent != nil && agent.Branch != "" && s.hasUncommittedChanges(agent.WorkDir) {
 			// Stash changes before killing
 			logging.Info("stashing changes before kill, sessionID=%s", sessionID)
-			_ = s.git.Stash(agent.WorkDir)
+			if err := s.git.Stash(agent.WorkDir); err != nil {
+				logging.Error(err, "sessionID", sessionID)
+				return fmt.Errorf("failed to stash changes: %w", err)
+			}
 		}
 	}
 
@@ -232,10 +605,30 @@ type MergeResult struct {
 	AgentID       string
 }
 
-// MergeAgent merges an agent's branch into the base branch.
-// If there are uncommitted changes in the main workdir, they are stashed first.
+// SetMergeStrategy sets the strategy MergeAgent uses. Defaults to MergeStrategyMerge.
+func (s *AgentService) SetMergeStrategy(strategy MergeStrategy) {
+	s.mergeStrategy = strategy
+}
+
+// MergeStrategy returns the strategy MergeAgent uses.
+func (s *AgentService) MergeStrategy() MergeStrategy {
+	if s.mergeStrategy == "" {
+		return MergeStrategyMerge
+	}
+	return s.mergeStrategy
+}
+
+// MergeAgent merges an agent's branch into the base branch with the default
+// strategy, see MergeAgentWithStrategy.
 func (s *AgentService) MergeAgent(sessionID string) (*MergeResult, error) {
-	logging.Entry("sessionID", sessionID)
+	return s.MergeAgentWithStrategy(sessionID, s.MergeStrategy())
+}
+
+// MergeAgentWithStrategy lands an agent's branch on the base branch with a
+// merge commit, as one squashed commit, or by rebasing it and fast-forwarding.
+// If there are uncommitted changes in the main workdir, they are stashed first.
+func (s *AgentService) MergeAgentWithStrategy(sessionID string, strategy MergeStrategy) (*MergeResult, error) {
+	logging.Entry("sessionID", sessionID, "strategy", strategy)
 	if s.git == nil {
 		err := fmt.Errorf("git client not available")
 		logging.Error(err)
@@ -256,10 +649,14 @@ func (s *AgentService) MergeAgent(sessionID string) (*MergeResult, error) {
 	}
 
 	result := &MergeResult{Success: false}
+	record := NewMergeRecord(agent.ID, agent.Branch, agent.BaseBranch, strategy)
+	started := time.Now()
+	defer s.reportDone(OperationMerge, agent.Name)
 
 	// Check for uncommitted changes in main workdir and stash if needed
-	if s.git.HasUncommittedChanges(s.workDir) {
+	if s.hasUncommittedChanges(s.workDir) {
 		logging.Info("stashing uncommitted changes before merge")
+		s.reportProgress(OperationMerge, agent.Name, "stashing changes")
 		if err := s.git.Stash(s.workDir); err != nil {
 			err = fmt.Errorf("failed to stash changes: %w", err)
 			logging.Error(err)
@@ -269,7 +666,21 @@ func (s *AgentService) MergeAgent(sessionID string) (*MergeResult, error) {
 	}
 
 	// Merge the agent's branch
-	if err := s.git.Merge(agent.Branch); err != nil {
+	s.reportProgress(OperationMerge, agent.Name, fmt.Sprintf("%s %s into %s", mergeVerb(strategy), agent.Branch, agent.BaseBranch))
+	if err := s.landBranch(agent, strategy); err != nil {
+		if errors.Is(err, ErrCommitSigning) {
+			// Not a conflict - undo the half-finished merge and surface the signing error
+			logging.Error(err, "branch", agent.Branch, "action", "sign merge commit")
+			// A failed rebase or squash has already cleaned up after itself
+			if strategy == MergeStrategyMerge {
+				_ = s.git.MergeAbort()
+			}
+			if result.Stashed {
+				_ = s.git.StashPop(s.workDir)
+			}
+			return nil, fmt.Errorf("failed to merge %s: %w", agent.Branch, err)
+		}
+
 		// Merge failed, likely a conflict
 		logging.Error(err, "branch", agent.Branch, "conflict", true)
 		result.ConflictErr = err
@@ -285,6 +696,11 @@ func (s *AgentService) MergeAgent(sessionID string) (*MergeResult, error) {
 		if result.Stashed {
 			_ = s.git.StashPop(s.workDir)
 		}
+
+		record.Outcome = MergeOutcomeConflict
+		record.ConflictFiles = result.ConflictFiles
+		record.Duration = time.Since(started)
+		s.dispatcher.Publish(MergeFailed{Record: record, Timestamp: time.Now()})
 		return result, nil
 	}
 
@@ -295,33 +711,382 @@ func (s *AgentService) MergeAgent(sessionID string) (*MergeResult, error) {
 		_ = s.git.StashPop(s.workDir)
 	}
 
+	record.Outcome = MergeOutcomeSuccess
+	record.Duration = time.Since(started)
+	s.dispatcher.Publish(MergeCompleted{Record: record, Timestamp: time.Now()})
+
 	logging.Info("merge completed successfully, sessionID=%s, branch=%s", sessionID, agent.Branch)
 	return result, nil
 }
 
-// List returns active agents for the current project.
+// landBranch lands an agent's branch on the current branch with strategy.
+func (s *AgentService) landBranch(agent *Agent, strategy MergeStrategy) error {
+	switch strategy {
+	case MergeStrategySquash:
+		msg := fmt.Sprintf("Squash merge branch '%s'", agent.Branch)
+		if agent.Summary != "" {
+			msg += "\n\n" + agent.Summary
+		}
+		return s.git.Squash(agent.Branch, msg)
+	case MergeStrategyRebase:
+		return s.git.Rebase(agent.WorkDir, agent.BaseBranch, agent.Branch)
+	default:
+		return s.git.Merge(agent.Branch)
+	}
+}
+
+// mergeVerb describes what a merge strategy does, for progress.
+func mergeVerb(strategy MergeStrategy) string {
+	switch strategy {
+	case MergeStrategySquash:
+		return "squashing"
+	case MergeStrategyRebase:
+		return "rebasing"
+	default:
+		return "merging"
+	}
+}
+
+// MergeHistory returns the recorded merge attempts for an agent, newest first.
+func (s *AgentService) MergeHistory(sessionID string) ([]*MergeRecord, error) {
+	logging.Entry("sessionID", sessionID)
+	if s.merges == nil {
+		return nil, nil
+	}
+	records, err := s.merges.ListByAgent(sessionID)
+	if err != nil {
+		logging.Error(err, "sessionID", sessionID)
+		return nil, err
+	}
+	return records, nil
+}
+
+// Commits returns the commits on an agent's branch that are ahead of its base, newest first.
+func (s *AgentService) Commits(sessionID string) ([]Commit, error) {
+	logging.Entry("sessionID", sessionID)
+	agent, err := s.branchAgent(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := s.git.CommitsAhead(agent.Branch, agent.BaseBranch)
+	if err != nil {
+		logging.Error(err, "sessionID", sessionID)
+		return nil, fmt.Errorf("failed to list commits: %w", err)
+	}
+	return commits, nil
+}
+
+// CommitAgentWork stages and commits every uncommitted change in an agent's
+// worktree, snapshotting its work on its branch. An empty message uses
+// WorkCommitMessage. It returns ErrNothingToCommit for a clean worktree.
+func (s *AgentService) CommitAgentWork(sessionID, message string) error {
+	logging.Entry("sessionID", sessionID)
+	agent, err := s.branchAgent(sessionID)
+	if err != nil {
+		return err
+	}
+	if !s.hasUncommittedChanges(agent.WorkDir) {
+		return fmt.Errorf("agent %q: %w", sessionID, ErrNothingToCommit)
+	}
+	if strings.TrimSpace(message) == "" {
+		message = WorkCommitMessage(agent)
+	}
+	if err := s.git.Add(agent.WorkDir); err != nil {
+		logging.Error(err, "sessionID", sessionID)
+		return fmt.Errorf("failed to stage changes: %w", err)
+	}
+	if err := s.git.Commit(agent.WorkDir, message); err != nil {
+		logging.Error(err, "sessionID", sessionID)
+		return fmt.Errorf("failed to commit changes: %w", err)
+	}
+	logging.Info("agent work committed, sessionID=%s, branch=%s", sessionID, agent.Branch)
+	return nil
+}
+
+// WorkCommitMessage is the default message for committing an agent's work.
+func WorkCommitMessage(agent *Agent) string {
+	return "WIP: " + agent.Name + " work in progress"
+}
+
+// MergePreview returns the commits and diffstat merging an agent's branch would land on its base.
+func (s *AgentService) MergePreview(sessionID string) (*MergePreview, error) {
+	logging.Entry("sessionID", sessionID)
+	commits, err := s.Commits(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	agent := s.store.Get(sessionID)
+	stat, err := s.git.DiffStat(agent.Branch, agent.BaseBranch)
+	if err != nil {
+		logging.Error(err, "sessionID", sessionID)
+		return nil, fmt.Errorf("failed to diff branch: %w", err)
+	}
+	return &MergePreview{
+		Branch:     agent.Branch,
+		BaseBranch: agent.BaseBranch,
+		Strategy:   s.MergeStrategy(),
+		Commits:    commits,
+		DiffStat:   stat,
+	}, nil
+}
+
+// BranchDiff returns the patch of everything an agent's branch changed since it
+// left its base, as merging would bring in.
+func (s *AgentService) BranchDiff(sessionID string) (string, error) {
+	logging.Entry("sessionID", sessionID)
+	agent, err := s.branchAgent(sessionID)
+	if err != nil {
+		return "", err
+	}
+	diff, err := s.git.Diff(agent.WorkDir, agent.BaseBranch, agent.Branch)
+	if err != nil {
+		logging.Error(err, "sessionID", sessionID)
+		return "", fmt.Errorf("failed to diff branch: %w", err)
+	}
+	return diff, nil
+}
+
+// CommitDiff returns the stat summary and patch of one of an agent's commits.
+func (s *AgentService) CommitDiff(sessionID, hash string) (string, error) {
+	logging.Entry("sessionID", sessionID, "hash", hash)
+	if _, err := s.branchAgent(sessionID); err != nil {
+		return "", err
+	}
+	diff, err := s.git.ShowCommit(hash)
+	if err != nil {
+		logging.Error(err, "sessionID", sessionID, "hash", hash)
+		return "", fmt.Errorf("failed to show commit: %w", err)
+	}
+	return diff, nil
+}
+
+// branchAgent returns the agent for sessionID, requiring git and an agent branch.
+func (s *AgentService) branchAgent(sessionID string) (*Agent, error) {
+	if s.git == nil {
+		return nil, fmt.Errorf("git is not available")
+	}
+	agent := s.store.Get(sessionID)
+	if agent == nil {
+		err := fmt.Errorf("agent %q not found", sessionID)
+		logging.Error(err, "sessionID", sessionID)
+		return nil, err
+	}
+	if agent.Branch == "" || agent.BaseBranch == "" {
+		return nil, fmt.Errorf("agent %q has no branch", sessionID)
+	}
+	return agent, nil
+}
+
+// IsBaseProtected checks whether an agent's base branch is protected, either through
+// local settings or remote protection rules. Protected bases should not be merged locally.
+func (s *AgentService) IsBaseProtected(sessionID string) (bool, error) {
+	logging.Entry("sessionID", sessionID)
+	agent := s.store.Get(sessionID)
+	if agent == nil {
+		err := fmt.Errorf("agent %q not found", sessionID)
+		logging.Error(err, "sessionID", sessionID)
+		return false, err
+	}
+
+	if agent.BaseBranch == "" {
+		return false, nil
+	}
+
+	for _, branch := range s.protected {
+		if branch == agent.BaseBranch {
+			logging.Info("base branch protected by local settings, branch=%s", agent.BaseBranch)
+			return true, nil
+		}
+	}
+
+	if s.github == nil {
+		return false, nil
+	}
+
+	// Remote lookup failures (no gh, no remote, no auth) are treated as unprotected
+	protected, err := s.github.IsBranchProtected(agent.BaseBranch)
+	if err != nil {
+		logging.Debug("remote protection check unavailable: %v", err)
+		return false, nil
+	}
+	return protected, nil
+}
+
+// OpenPullRequest pushes an agent's branch and opens a pull request into its base branch.
+// Returns the URL of the created pull request.
+func (s *AgentService) OpenPullRequest(sessionID string) (string, error) {
+	logging.Entry("sessionID", sessionID)
+	if s.git == nil || s.github == nil {
+		err := fmt.Errorf("git or GitHub client not available")
+		logging.Error(err)
+		return "", err
+	}
+
+	agent := s.store.Get(sessionID)
+	if agent == nil {
+		err := fmt.Errorf("agent %q not found", sessionID)
+		logging.Error(err, "sessionID", sessionID)
+		return "", err
+	}
+
+	if agent.Branch == "" || agent.BaseBranch == "" {
+		err := fmt.Errorf("agent has no branch to open a pull request for")
+		logging.Error(err, "sessionID", sessionID)
+		return "", err
+	}
+
+	defer s.reportDone(OperationPush, agent.Name)
+	s.reportProgress(OperationPush, agent.Name, "pushing "+agent.Branch)
+	if err := s.git.Push(agent.Branch); err != nil {
+		err = fmt.Errorf("failed to push branch: %w", err)
+		logging.Error(err, "branch", agent.Branch)
+		return "", err
+	}
+
+	s.reportProgress(OperationPush, agent.Name, "opening pull request")
+	url, err := s.github.CreatePullRequest(agent.Branch, agent.BaseBranch)
+	if err != nil {
+		err = fmt.Errorf("failed to create pull request: %w", err)
+		logging.Error(err, "branch", agent.Branch)
+		return "", err
+	}
+
+	s.dispatcher.Publish(PullRequestOpened{
+		AgentID:   agent.ID,
+		URL:       url,
+		Timestamp: time.Now(),
+	})
+	if err := s.SetMetadata(agent.ID, MetadataPRURL, url); err != nil {
+		logging.Error(err, "sessionID", sessionID, "action", "record pull request")
+	}
+
+	logging.Info("pull request opened, sessionID=%s, url=%s", sessionID, url)
+	return url, nil
+}
+
+// Retarget moves an agent's branch onto a different base branch by rebasing
+// the agent's commits and recording the new base in the store.
+func (s *AgentService) Retarget(sessionID, newBase string) error {
+	logging.Entry("sessionID", sessionID, "newBase", newBase)
+	if s.git == nil {
+		err := fmt.Errorf("git client not available")
+		logging.Error(err)
+		return err
+	}
+
+	agent := s.store.Get(sessionID)
+	if agent == nil {
+		err := fmt.Errorf("agent %q not found", sessionID)
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+
+	if agent.Branch == "" || agent.BaseBranch == "" {
+		err := fmt.Errorf("agent has no branch to retarget")
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+
+	if newBase == agent.BaseBranch {
+		err := fmt.Errorf("agent already targets %q", newBase)
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+
+	if !s.git.BranchExists(newBase) {
+		err := fmt.Errorf("branch %q does not exist", newBase)
+		logging.Error(err, "newBase", newBase)
+		return err
+	}
+
+	oldBase := agent.BaseBranch
+	if err := s.git.RebaseOnto(agent.WorkDir, newBase, oldBase); err != nil {
+		err = fmt.Errorf("failed to rebase onto %s: %w", newBase, err)
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+
+	if err := s.store.UpdateBaseBranch(agent.ID, newBase); err != nil {
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+
+	s.dispatcher.Publish(AgentRetargeted{
+		AgentID:   agent.ID,
+		OldBase:   oldBase,
+		NewBase:   newBase,
+		Timestamp: time.Now(),
+	})
+
+	logging.Info("agent retargeted, sessionID=%s, oldBase=%s, newBase=%s", sessionID, oldBase, newBase)
+	return nil
+}
+
+// List returns active, starting, paused and exited agents for the current
+// project. Exited agents stay listed, with their worktree, until killed.
 func (s *AgentService) List() []*Agent {
 	logging.Entry("project", s.project)
 	all := s.store.List()
 	var active []*Agent
 	for _, agent := range all {
-		if agent.Project == s.project && agent.Status == AgentStatusActive {
-			active = append(active, agent)
+		switch agent.Status {
+		case AgentStatusActive, AgentStatusStarting, AgentStatusPaused, AgentStatusExited:
+			if agent.Project == s.project {
+				active = append(active, agent)
+			}
 		}
 	}
 	logging.Debug("listed agents, count=%d", len(active))
 	return active
 }
 
+// SetSessionSwitcher makes Attach switch the tmux client the dashboard runs in
+// to the agent's session rather than suspending the TUI, for a dashboard
+// running inside tmux. This is optional.
+func (s *AgentService) SetSessionSwitcher(switcher ISessionSwitcher) {
+	s.switcher = switcher
+}
+
 // Attach returns a tea.Cmd that attaches to the given session.
-// This will suspend the TUI and take over the terminal.
+// This will suspend the TUI and take over the terminal, unless a session
+// switcher is set.
 func (s *AgentService) Attach(sessionID string) tea.Cmd {
 	logging.Entry("sessionID", sessionID)
-	cmd := s.tmux.AttachCmd(sessionID)
+	if s.switcher != nil {
+		return s.switchTo(sessionID)
+	}
+	return s.attach(sessionID, s.tmux.AttachCmd(sessionID))
+}
+
+// switchTo switches the dashboard's tmux client to the session, leaving the
+// TUI running. Nothing reports when the human switches back, so unlike
+// attach it records no attached time.
+func (s *AgentService) switchTo(sessionID string) tea.Cmd {
+	return func() tea.Msg {
+		err := s.switcher.SwitchClient(sessionID)
+		if err != nil {
+			logging.Error(err, "sessionID", sessionID)
+		}
+		return AgentDetachedMsg{SessionID: sessionID, Err: err}
+	}
+}
+
+// AttachReadOnly attaches to an agent's session without sending it any keystrokes.
+func (s *AgentService) AttachReadOnly(sessionID string) tea.Cmd {
+	logging.Entry("sessionID", sessionID)
+	return s.attach(sessionID, s.tmux.AttachReadOnlyCmd(sessionID))
+}
+
+// attach runs cmd in place of the TUI, recording the attached time when it exits.
+func (s *AgentService) attach(sessionID string, cmd *exec.Cmd) tea.Cmd {
+	attachedAt := time.Now()
 	return tea.ExecProcess(cmd, func(err error) tea.Msg {
 		if err != nil {
 			logging.Error(err, "sessionID", sessionID)
 		}
+		if err := s.store.AddAttachedTime(sessionID, time.Since(attachedAt)); err != nil {
+			logging.Error(err, "sessionID", sessionID, "action", "record attach time")
+		}
 		return AgentDetachedMsg{SessionID: sessionID, Err: err}
 	})
 }
@@ -342,46 +1107,190 @@ func (s *AgentService) CaptureOutput(sessionID string, lines int) (string, error
 	return output, err
 }
 
+// ReconcileReport summarizes what Reconcile changed.
+type ReconcileReport struct {
+	// Terminated lists agents marked terminated because their tmux session was gone.
+	Terminated []string
+	// Restarted lists agents relaunched by their restart policy because their
+	// tmux session was gone.
+	Restarted []string
+	// Exited lists agents marked exited because their command had exited.
+	Exited []string
+	// KilledSessions lists orphaned tmux sessions that were killed.
+	KilledSessions []string
+	// MissingWorktrees lists agents whose worktree has disappeared.
+	MissingWorktrees []string
+}
+
+// Empty reports whether reconcile found nothing to fix.
+func (r *ReconcileReport) Empty() bool {
+	return len(r.Terminated) == 0 && len(r.Restarted) == 0 && len(r.Exited) == 0 && len(r.KilledSessions) == 0 && len(r.MissingWorktrees) == 0
+}
+
 // Reconcile synchronizes the store with actual tmux sessions.
 // It marks agents as terminated if their tmux session no longer exists,
 // and kills orphaned tmux sessions that aren't in the store.
-func (s *AgentService) Reconcile() error {
+func (s *AgentService) Reconcile() (*ReconcileReport, error) {
 	logging.Entry("project", s.project)
+	report := &ReconcileReport{}
+
+	// A hung tmux server reports every session missing; don't mistake that for dead agents
+	if _, err := s.tmux.ListSessions(); errors.Is(err, ErrDegraded) {
+		err = fmt.Errorf("skipping reconcile: %w", err)
+		logging.Error(err)
+		return nil, err
+	}
+
 	// Get all stored agents
 	agents := s.store.List()
+	s.restartMu.Lock()
+	defer s.restartMu.Unlock()
 
 	// Check for orphaned store entries (session doesn't exist in tmux)
 	for _, agent := range agents {
-		if agent.Status == AgentStatusTerminated {
+		// Queued agents have no session until they start, nor do ones still
+		// being prepared, and exited ones have none left
+		if agent.Status == AgentStatusTerminated || agent.Status == AgentStatusPending || agent.Status == AgentStatusExited {
+			continue
+		}
+		if agent.Status == AgentStatusStarting && s.isStarting(agent.ID) {
 			continue
 		}
 		if !s.tmux.SessionExists(agent.ID) {
+			if s.restartCrashed(agent) {
+				report.Restarted = append(report.Restarted, agent.ID)
+				continue
+			}
+			if s.markExited(agent) {
+				report.Exited = append(report.Exited, agent.ID)
+				continue
+			}
 			// Mark as terminated rather than removing
 			logging.Info("marking orphaned agent as terminated, agentID=%s", agent.ID)
-			_ = s.store.UpdateStatus(agent.ID, AgentStatusTerminated)
+			if err := s.store.UpdateStatus(agent.ID, AgentStatusTerminated); err == nil {
+				report.Terminated = append(report.Terminated, agent.ID)
+			}
 		}
 	}
 
+	if s.ephemeral {
+		logging.Info("ephemeral mode, skipping orphaned session cleanup")
+		return report, nil
+	}
+
 	// Get all tmux sessions
 	sessions, err := s.tmux.ListSessions()
 	if err != nil {
 		// tmux might not be running, which is fine
 		logging.Debug("tmux list sessions failed (may not be running): %v", err)
-		return nil
+		return report, nil
 	}
 
 	// Check for orphaned tmux sessions (matches our prefix but not in store)
 	prefix := "craizy-" + SanitizeName(s.project) + "-"
 	for _, session := range sessions {
-		if strings.HasPrefix(session, prefix) {
+		if strings.HasPrefix(session, prefix) && !IsDashboardSession(session) {
 			if !s.store.Exists(session) {
 				logging.Info("killing orphaned tmux session, session=%s", session)
-				_ = s.tmux.KillSession(session)
+				if err := s.tmux.KillSession(session); err == nil {
+					report.KilledSessions = append(report.KilledSessions, session)
+				}
 			}
 		}
 	}
 
-	logging.Info("reconcile completed")
+	// Cross-check agent worktrees against git's worktree registry
+	if missing, err := s.MissingWorktrees(); err == nil {
+		for _, agent := range missing {
+			logging.Info("agent worktree is missing, agentID=%s, workDir=%s", agent.ID, agent.WorkDir)
+			report.MissingWorktrees = append(report.MissingWorktrees, agent.ID)
+		}
+	}
+
+	logging.Info("reconcile completed, terminated=%d, restarted=%d, killed=%d, missing=%d",
+		len(report.Terminated), len(report.Restarted), len(report.KilledSessions), len(report.MissingWorktrees))
+	return report, nil
+}
+
+// MissingWorktrees returns active agents in the current project whose worktree
+// is no longer registered with git or whose directory has disappeared.
+func (s *AgentService) MissingWorktrees() ([]*Agent, error) {
+	logging.Entry("project", s.project)
+	if s.git == nil {
+		return nil, nil
+	}
+
+	worktrees, err := s.git.ListWorktrees()
+	if err != nil {
+		logging.Error(err, "action", "list worktrees")
+		return nil, err
+	}
+
+	present := make(map[string]bool, len(worktrees))
+	for _, wt := range worktrees {
+		if !wt.Prunable && !wt.Missing {
+			present[filepath.Clean(wt.Path)] = true
+		}
+	}
+
+	var missing []*Agent
+	for _, agent := range s.List() {
+		if agent.Branch == "" {
+			continue
+		}
+		if !present[filepath.Clean(agent.WorkDir)] {
+			missing = append(missing, agent)
+		}
+	}
+	logging.Debug("found %d agents with missing worktrees", len(missing))
+	return missing, nil
+}
+
+// RecreateWorktree restores a missing worktree for an agent on its existing branch.
+// If the branch is gone too, it is recreated from the agent's base branch.
+func (s *AgentService) RecreateWorktree(sessionID string) error {
+	logging.Entry("sessionID", sessionID)
+	if s.git == nil {
+		err := fmt.Errorf("git client not available")
+		logging.Error(err)
+		return err
+	}
+
+	agent := s.store.Get(sessionID)
+	if agent == nil {
+		err := fmt.Errorf("agent %q not found", sessionID)
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+
+	if agent.Branch == "" {
+		err := fmt.Errorf("agent has no worktree branch")
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+
+	// Clear the stale (possibly locked) registration so the path can be reused
+	_ = s.git.UnlockWorktree(agent.WorkDir)
+	if err := s.git.PruneWorktrees(); err != nil {
+		logging.Error(err, "action", "prune worktrees")
+	}
+
+	if err := s.createWorktree(agent.WorkDir, agent.Branch, agent.BaseBranch, agent.SparsePaths); err != nil {
+		err = fmt.Errorf("failed to recreate worktree: %w", err)
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+
+	if err := s.prepareWorktree(agent.WorkDir); err != nil {
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+
+	if err := s.git.LockWorktree(agent.WorkDir, "in use by crAIzy agent "+agent.ID); err != nil {
+		logging.Error(err, "sessionID", sessionID, "action", "lock worktree")
+	}
+
+	logging.Info("worktree recreated, sessionID=%s, workDir=%s", sessionID, agent.WorkDir)
 	return nil
 }
 
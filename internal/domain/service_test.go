@@ -1,8 +1,14 @@
 package domain
 
 import (
+	"errors"
+	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 // Mock implementations
@@ -14,13 +20,20 @@ type mockTmuxClient struct {
 	listErr        error
 	capturedOutput string
 	captureErr     error
+	activity       map[string]time.Time
+	sentKeys       []string
+	paused         map[string]bool
+	exits          map[string]int    // exit statuses of sessions whose command exited
+	createdDir     string            // directory of the last session created
+	createdEnv     map[string]string // environment of the last session created
 }
 
-func (m *mockTmuxClient) CreateSession(id, command, workDir string) error {
+func (m *mockTmuxClient) CreateSession(id, command, workDir string, env map[string]string) error {
 	if m.createErr != nil {
 		return m.createErr
 	}
 	m.sessions[id] = true
+	m.createdDir, m.createdEnv = workDir, env
 	return nil
 }
 
@@ -47,6 +60,10 @@ func (m *mockTmuxClient) AttachCmd(id string) *exec.Cmd {
 	return exec.Command("echo", "attached")
 }
 
+func (m *mockTmuxClient) AttachReadOnlyCmd(id string) *exec.Cmd {
+	return exec.Command("echo", "attached", "read-only")
+}
+
 func (m *mockTmuxClient) SessionExists(id string) bool {
 	_, exists := m.sessions[id]
 	return exists
@@ -57,9 +74,45 @@ func (m *mockTmuxClient) CapturePaneOutput(sessionID string, lines int) (string,
 }
 
 func (m *mockTmuxClient) SendKeys(sessionID, text string) error {
+	m.sentKeys = append(m.sentKeys, text)
+	return nil
+}
+
+func (m *mockTmuxClient) RenameSession(oldID, newID string) error {
+	delete(m.sessions, oldID)
+	m.sessions[newID] = true
+	return nil
+}
+
+func (m *mockTmuxClient) PipeOutput(sessionID, path string) error {
+	return nil
+}
+
+func (m *mockTmuxClient) ExitStatus(id string) (int, bool) {
+	code, ok := m.exits[id]
+	return code, ok
+}
+
+func (m *mockTmuxClient) PauseSession(id string) error {
+	if m.paused == nil {
+		m.paused = make(map[string]bool)
+	}
+	m.paused[id] = true
+	return nil
+}
+
+func (m *mockTmuxClient) ResumeSession(id string) error {
+	delete(m.paused, id)
 	return nil
 }
 
+func (m *mockTmuxClient) LastActivity(sessionID string) (time.Time, error) {
+	if at, ok := m.activity[sessionID]; ok {
+		return at, nil
+	}
+	return time.Now(), nil
+}
+
 type mockDispatcher struct {
 	published []Event
 }
@@ -135,6 +188,173 @@ func TestAgentService_Create(t *testing.T) {
 			t.Errorf("status = %v, want %v", agent.Status, AgentStatusActive)
 		}
 	})
+
+	t.Run("runs worktree setup", func(t *testing.T) {
+		git := newMockGit()
+		tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+		svc := NewAgentService(tmux, newTestStore(), &mockDispatcher{}, git, "testproj", "/tmp")
+		svc.SetWorktreeOptions(WorktreeOptions{Submodules: true, LFS: true})
+
+		agent, err := svc.Create("claude", "task1", "echo hello")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []string{"submodules:" + agent.WorkDir, "lfs:" + agent.WorkDir}
+		if len(git.prepared) != 2 || git.prepared[0] != want[0] || git.prepared[1] != want[1] {
+			t.Errorf("prepared = %v, want %v", git.prepared, want)
+		}
+	})
+
+	t.Run("shares caches without failing on errors", func(t *testing.T) {
+		git := newMockGit()
+		tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+		svc := NewAgentService(tmux, newTestStore(), &mockDispatcher{}, git, "testproj", "/tmp")
+		failing := &mockCache{err: exec.ErrNotFound}
+		shared := &mockCache{}
+		svc.SetWorktreeOptions(WorktreeOptions{Caches: []IWorktreeCache{failing, shared}})
+
+		agent, err := svc.Create("claude", "task1", "echo hello")
+
+		if err != nil {
+			t.Fatalf("a failing cache should not fail create: %v", err)
+		}
+		if len(shared.shared) != 1 || shared.shared[0] != "/tmp->"+agent.WorkDir {
+			t.Errorf("shared = %v, want /tmp->%s", shared.shared, agent.WorkDir)
+		}
+	})
+
+	t.Run("launches inside the dev environment", func(t *testing.T) {
+		store := newTestStore()
+		tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+		messages := newMockMessageStore()
+		svc := NewAgentService(tmux, store, &mockDispatcher{}, newMockGit(), "testproj", "/tmp")
+		svc.SetMessageService(NewMessageService(messages, tmux, store))
+		svc.SetDevEnvironment(&mockDevEnvironment{warnings: []string{"direnv is not installed"}})
+
+		agent, err := svc.Create("claude", "task1", "echo hello")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if agent.Command != "wrapped echo hello" {
+			t.Errorf("Command = %q, want %q", agent.Command, "wrapped echo hello")
+		}
+		unread, _ := messages.ListUnread(HumanParticipantID)
+		if len(unread) != 1 || unread[0].Content != "direnv is not installed" {
+			t.Errorf("expected the human to be warned, got %+v", unread)
+		}
+	})
+
+	t.Run("sends startup prompt with variables", func(t *testing.T) {
+		tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+		svc := NewAgentService(tmux, newTestStore(), &mockDispatcher{}, newMockGit(), "testproj", "/tmp")
+		opts := CreateOptions{Prompt: "You are {name} on {branch}. Stack: {stack}"}
+
+		agent, err := svc.CreateWithOptions("claude", "task1", "claude", opts)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := "You are task1 on " + agent.Branch + ". Stack: "
+		if len(tmux.sentKeys) != 1 || tmux.sentKeys[0] != want {
+			t.Errorf("sentKeys = %q, want [%q]", tmux.sentKeys, want)
+		}
+	})
+
+	t.Run("starts from a requested base branch", func(t *testing.T) {
+		git := newMockGit()
+		git.branches["release"] = true
+		tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+		svc := NewAgentService(tmux, newTestStore(), &mockDispatcher{}, git, "testproj", "/tmp")
+
+		agent, err := svc.CreateWithOptions("claude", "task1", "claude", CreateOptions{BaseBranch: "release"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if agent.BaseBranch != "release" {
+			t.Errorf("BaseBranch = %q, want release", agent.BaseBranch)
+		}
+
+		if _, err := svc.CreateWithOptions("claude", "task2", "claude", CreateOptions{BaseBranch: "missing"}); err == nil {
+			t.Error("expected an error for a missing base branch")
+		}
+	})
+
+	t.Run("sparse worktree", func(t *testing.T) {
+		git := newMockGit()
+		tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+		svc := NewAgentService(tmux, newTestStore(), &mockDispatcher{}, git, "testproj", "/tmp")
+
+		agent, err := svc.CreateWithOptions("claude", "task1", "echo hello", CreateOptions{SparsePaths: []string{"api", "web"}})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(git.created) != 1 || git.created[0] != agent.WorkDir+":api,web" {
+			t.Errorf("created = %v, want sparse worktree with api,web", git.created)
+		}
+		if len(agent.SparsePaths) != 2 {
+			t.Errorf("SparsePaths = %v, want [api web]", agent.SparsePaths)
+		}
+	})
+
+	t.Run("starts in a workdir with environment", func(t *testing.T) {
+		workDir := t.TempDir()
+		if err := os.Mkdir(filepath.Join(workDir, "api"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		dispatcher := &mockDispatcher{}
+		svc := NewAgentService(&mockTmuxClient{sessions: make(map[string]bool)}, newTestStore(), dispatcher, nil, "testproj", workDir)
+
+		_, err := svc.CreateWithOptions("claude", "task1", "claude", CreateOptions{Env: map[string]string{"MODEL": "opus"}, Dir: "api"})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		created := dispatcher.published[0].(AgentCreated).Agent
+		if created.SessionDir() != filepath.Join(workDir, "api") {
+			t.Errorf("SessionDir() = %q, want the api directory", created.SessionDir())
+		}
+		if created.Env["MODEL"] != "opus" {
+			t.Errorf("Env = %v, want MODEL=opus", created.Env)
+		}
+	})
+
+	t.Run("missing workdir cleans up", func(t *testing.T) {
+		git := newMockGit()
+		dispatcher := &mockDispatcher{}
+		svc := NewAgentService(&mockTmuxClient{sessions: make(map[string]bool)}, newTestStore(), dispatcher, git, "testproj", t.TempDir())
+
+		if _, err := svc.CreateWithOptions("claude", "task1", "claude", CreateOptions{Dir: "missing"}); err == nil {
+			t.Fatal("expected error for a workdir missing from the worktree")
+		}
+		if git.branches["craizy-testproj-claude-task1"] {
+			t.Error("branch should be deleted after the workdir check fails")
+		}
+		if len(dispatcher.published) != 0 {
+			t.Errorf("published %d events, want 0", len(dispatcher.published))
+		}
+	})
+
+	t.Run("worktree setup failure cleans up", func(t *testing.T) {
+		git := newMockGit()
+		git.lfsErr = exec.ErrNotFound
+		tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+		dispatcher := &mockDispatcher{}
+		svc := NewAgentService(tmux, newTestStore(), dispatcher, git, "testproj", "/tmp")
+		svc.SetWorktreeOptions(WorktreeOptions{LFS: true})
+
+		if _, err := svc.Create("claude", "task1", "echo hello"); err == nil {
+			t.Fatal("expected error when LFS pull fails")
+		}
+		if git.branches["craizy-testproj-claude-task1"] {
+			t.Error("branch should be deleted after setup failure")
+		}
+		if len(dispatcher.published) != 0 {
+			t.Errorf("published %d events, want 0", len(dispatcher.published))
+		}
+	})
 }
 
 func TestAgentService_List(t *testing.T) {
@@ -161,6 +381,39 @@ func TestAgentService_List(t *testing.T) {
 	})
 }
 
+// mockSwitcher records the sessions a dashboard running in tmux switched to.
+type mockSwitcher struct {
+	switched []string
+}
+
+func (m *mockSwitcher) SwitchClient(id string) error {
+	m.switched = append(m.switched, id)
+	return nil
+}
+
+func TestAgentService_Attach(t *testing.T) {
+	t.Run("switches client when running in tmux", func(t *testing.T) {
+		store := newTestStore()
+		store.Add(&Agent{ID: "a1", Project: "proj", Status: AgentStatusActive})
+		svc := NewAgentService(&mockTmuxClient{sessions: map[string]bool{"a1": true}}, store, &mockDispatcher{}, nil, "proj", "/tmp")
+		switcher := &mockSwitcher{}
+		svc.SetSessionSwitcher(switcher)
+
+		msg := svc.Attach("a1")()
+
+		detached, ok := msg.(AgentDetachedMsg)
+		if !ok || detached.SessionID != "a1" || detached.Err != nil {
+			t.Fatalf("got %#v, want AgentDetachedMsg for a1", msg)
+		}
+		if len(switcher.switched) != 1 || switcher.switched[0] != "a1" {
+			t.Errorf("switched to %v, want [a1]", switcher.switched)
+		}
+		if agent := store.Get("a1"); agent.AttachedTime != 0 {
+			t.Errorf("attached time = %s, want none recorded", agent.AttachedTime)
+		}
+	})
+}
+
 func TestAgentService_Reconcile(t *testing.T) {
 	t.Run("mark orphaned store entries", func(t *testing.T) {
 		// Path 1: Agent in store but session doesn't exist in tmux
@@ -171,7 +424,7 @@ func TestAgentService_Reconcile(t *testing.T) {
 		dispatcher := &mockDispatcher{}
 		svc := NewAgentService(tmux, store, dispatcher, nil, "proj", "/tmp")
 
-		err := svc.Reconcile()
+		report, err := svc.Reconcile()
 
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -180,6 +433,9 @@ func TestAgentService_Reconcile(t *testing.T) {
 		if agent.Status != AgentStatusTerminated {
 			t.Errorf("status = %v, want %v", agent.Status, AgentStatusTerminated)
 		}
+		if len(report.Terminated) != 1 || report.Terminated[0] != "craizy-proj-claude-task1" {
+			t.Errorf("report.Terminated = %v, want [craizy-proj-claude-task1]", report.Terminated)
+		}
 	})
 
 	t.Run("skip terminated agents", func(t *testing.T) {
@@ -191,7 +447,7 @@ func TestAgentService_Reconcile(t *testing.T) {
 		dispatcher := &mockDispatcher{}
 		svc := NewAgentService(tmux, store, dispatcher, nil, "proj", "/tmp")
 
-		err := svc.Reconcile()
+		report, err := svc.Reconcile()
 
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -201,6 +457,24 @@ func TestAgentService_Reconcile(t *testing.T) {
 		if agent.Status != AgentStatusTerminated {
 			t.Errorf("status = %v, want %v", agent.Status, AgentStatusTerminated)
 		}
+		if !report.Empty() {
+			t.Errorf("expected empty report, got %+v", report)
+		}
+	})
+
+	t.Run("skip while tmux is not responding", func(t *testing.T) {
+		store := newTestStore()
+		store.Add(&Agent{ID: "craizy-proj-claude-task1", Project: "proj", Status: AgentStatusActive})
+
+		tmux := &mockTmuxClient{sessions: make(map[string]bool), listErr: fmt.Errorf("tmux timed out: %w", ErrDegraded)}
+		svc := NewAgentService(tmux, store, &mockDispatcher{}, nil, "proj", "/tmp")
+
+		if _, err := svc.Reconcile(); !errors.Is(err, ErrDegraded) {
+			t.Fatalf("expected ErrDegraded, got %v", err)
+		}
+		if agent := store.Get("craizy-proj-claude-task1"); agent.Status != AgentStatusActive {
+			t.Errorf("status = %v, agents must not be terminated while tmux is hung", agent.Status)
+		}
 	})
 
 	t.Run("kill orphaned tmux sessions", func(t *testing.T) {
@@ -214,7 +488,7 @@ func TestAgentService_Reconcile(t *testing.T) {
 		dispatcher := &mockDispatcher{}
 		svc := NewAgentService(tmux, store, dispatcher, nil, "proj", "/tmp")
 
-		err := svc.Reconcile()
+		report, err := svc.Reconcile()
 
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -222,6 +496,53 @@ func TestAgentService_Reconcile(t *testing.T) {
 		if tmux.SessionExists("craizy-proj-claude-orphan") {
 			t.Error("orphaned session should have been killed")
 		}
+		if len(report.KilledSessions) != 1 {
+			t.Errorf("report.KilledSessions = %v, want 1 session", report.KilledSessions)
+		}
+	})
+
+	t.Run("keep dashboard sessions", func(t *testing.T) {
+		// Another project's dashboard shares the agent prefix of a project named "dashboard"
+		store := newTestStore()
+		tmux := &mockTmuxClient{
+			sessions: map[string]bool{
+				DashboardSessionID("dashboard"): true,
+				DashboardSessionID("web"):       true,
+			},
+		}
+		svc := NewAgentService(tmux, store, &mockDispatcher{}, nil, "dashboard", "/tmp")
+
+		report, err := svc.Reconcile()
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(report.KilledSessions) != 0 {
+			t.Errorf("report.KilledSessions = %v, want the dashboards kept", report.KilledSessions)
+		}
+	})
+
+	t.Run("ephemeral keeps unknown sessions", func(t *testing.T) {
+		store := newTestStore()
+		tmux := &mockTmuxClient{
+			sessions: map[string]bool{
+				"craizy-proj-claude-persistent": true,
+			},
+		}
+		svc := NewAgentService(tmux, store, &mockDispatcher{}, nil, "proj", "/tmp")
+		svc.SetEphemeral(true)
+
+		report, err := svc.Reconcile()
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !tmux.SessionExists("craizy-proj-claude-persistent") {
+			t.Error("ephemeral reconcile should not kill sessions it doesn't own")
+		}
+		if len(report.KilledSessions) != 0 {
+			t.Errorf("report.KilledSessions = %v, want none", report.KilledSessions)
+		}
 	})
 
 	t.Run("handle tmux not running", func(t *testing.T) {
@@ -234,12 +555,15 @@ func TestAgentService_Reconcile(t *testing.T) {
 		dispatcher := &mockDispatcher{}
 		svc := NewAgentService(tmux, store, dispatcher, nil, "proj", "/tmp")
 
-		err := svc.Reconcile()
+		report, err := svc.Reconcile()
 
 		// Should return nil, not error
 		if err != nil {
 			t.Errorf("expected nil error, got %v", err)
 		}
+		if report == nil {
+			t.Error("expected a report even when tmux is not running")
+		}
 	})
 }
 
@@ -303,3 +627,957 @@ func (s *testStore) UpdateStatus(id string, status AgentStatus) error {
 	}
 	return nil
 }
+
+func TestAgentService_CommitAgentWork(t *testing.T) {
+	setup := func() (*AgentService, *mockGitClient) {
+		store := newTestStore()
+		store.Add(&Agent{ID: "agent-1", Name: "auth", WorkDir: "/wt/auth", Branch: "craizy/auth", BaseBranch: "main"})
+		git := newMockGit()
+		git.dirty["/wt/auth"] = true
+		return NewAgentService(&mockTmuxClient{sessions: map[string]bool{}}, store, &mockDispatcher{}, git, "proj", "/tmp"), git
+	}
+
+	t.Run("stages and commits the worktree", func(t *testing.T) {
+		svc, git := setup()
+
+		if err := svc.CommitAgentWork("agent-1", "Add token refresh"); err != nil {
+			t.Fatalf("CommitAgentWork: %v", err)
+		}
+		if len(git.added) != 1 || git.added[0] != "/wt/auth" {
+			t.Errorf("added %v, want the worktree staged", git.added)
+		}
+		if len(git.committed) != 1 || git.committed[0] != "/wt/auth:Add token refresh" {
+			t.Errorf("committed %v", git.committed)
+		}
+	})
+
+	t.Run("defaults the message", func(t *testing.T) {
+		svc, git := setup()
+
+		if err := svc.CommitAgentWork("agent-1", "  "); err != nil {
+			t.Fatalf("CommitAgentWork: %v", err)
+		}
+		if len(git.committed) != 1 || git.committed[0] != "/wt/auth:WIP: auth work in progress" {
+			t.Errorf("committed %v", git.committed)
+		}
+	})
+
+	t.Run("reports a clean worktree", func(t *testing.T) {
+		svc, git := setup()
+		delete(git.dirty, "/wt/auth")
+
+		if err := svc.CommitAgentWork("agent-1", ""); !errors.Is(err, ErrNothingToCommit) {
+			t.Errorf("err = %v, want ErrNothingToCommit", err)
+		}
+		if len(git.added) != 0 {
+			t.Error("expected nothing staged")
+		}
+	})
+
+	t.Run("surfaces commit failures", func(t *testing.T) {
+		svc, git := setup()
+		git.commitErr = ErrCommitSigning
+
+		if err := svc.CommitAgentWork("agent-1", ""); !errors.Is(err, ErrCommitSigning) {
+			t.Errorf("err = %v, want ErrCommitSigning", err)
+		}
+	})
+}
+
+func TestAgentService_Commits(t *testing.T) {
+	t.Run("lists branch commits", func(t *testing.T) {
+		store := newTestStore()
+		store.Add(&Agent{ID: "agent-1", Branch: "craizy/auth", BaseBranch: "main"})
+		git := newMockGit()
+		git.commits = []Commit{{Hash: "abc1234", Subject: "Add token refresh"}}
+		svc := NewAgentService(&mockTmuxClient{sessions: map[string]bool{}}, store, &mockDispatcher{}, git, "proj", "/tmp")
+
+		commits, err := svc.Commits("agent-1")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(commits) != 1 || commits[0].Hash != "abc1234" {
+			t.Errorf("got %v, want [abc1234]", commits)
+		}
+
+		diff, err := svc.CommitDiff("agent-1", "abc1234")
+		if err != nil || diff != "commit abc1234" {
+			t.Errorf("CommitDiff = %q, %v", diff, err)
+		}
+	})
+
+	t.Run("previews a merge", func(t *testing.T) {
+		store := newTestStore()
+		store.Add(&Agent{ID: "agent-1", Branch: "craizy/auth", BaseBranch: "main"})
+		git := newMockGit()
+		git.commits = []Commit{{Hash: "abc1234", Subject: "Add token refresh"}}
+		svc := NewAgentService(&mockTmuxClient{sessions: map[string]bool{}}, store, &mockDispatcher{}, git, "proj", "/tmp")
+
+		preview, err := svc.MergePreview("agent-1")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if preview.BaseBranch != "main" || preview.Strategy != MergeStrategyMerge || len(preview.Commits) != 1 || preview.DiffStat == "" {
+			t.Errorf("preview = %+v", preview)
+		}
+	})
+
+	t.Run("diffs the branch", func(t *testing.T) {
+		store := newTestStore()
+		store.Add(&Agent{ID: "agent-1", Branch: "craizy/auth", BaseBranch: "main", WorkDir: "/tmp/auth"})
+		svc := NewAgentService(&mockTmuxClient{sessions: map[string]bool{}}, store, &mockDispatcher{}, newMockGit(), "proj", "/tmp")
+
+		diff, err := svc.BranchDiff("agent-1")
+
+		if err != nil || !strings.Contains(diff, "+added") {
+			t.Errorf("BranchDiff = %q, %v", diff, err)
+		}
+		if _, err := svc.BranchDiff("missing"); err == nil {
+			t.Error("expected error for unknown agent")
+		}
+	})
+
+	t.Run("agent without branch", func(t *testing.T) {
+		store := newTestStore()
+		store.Add(&Agent{ID: "agent-1"})
+		svc := NewAgentService(&mockTmuxClient{sessions: map[string]bool{}}, store, &mockDispatcher{}, newMockGit(), "proj", "/tmp")
+
+		if _, err := svc.Commits("agent-1"); err == nil {
+			t.Error("expected error for agent without a branch")
+		}
+		if _, err := svc.Commits("missing"); err == nil {
+			t.Error("expected error for unknown agent")
+		}
+	})
+}
+
+func TestAgentService_MergeHistory(t *testing.T) {
+	t.Run("no merge store returns nothing", func(t *testing.T) {
+		store := newTestStore()
+		tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+		svc := NewAgentService(tmux, store, &mockDispatcher{}, nil, "proj", "/tmp")
+
+		records, err := svc.MergeHistory("agent-1")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(records) != 0 {
+			t.Errorf("got %d records, want 0", len(records))
+		}
+	})
+
+	t.Run("reads from merge store", func(t *testing.T) {
+		store := newTestStore()
+		tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+		svc := NewAgentService(tmux, store, &mockDispatcher{}, nil, "proj", "/tmp")
+		merges := &mockMergeStore{}
+		_ = merges.Save(&MergeRecord{ID: "m1", AgentID: "agent-1"})
+		_ = merges.Save(&MergeRecord{ID: "m2", AgentID: "agent-2"})
+		svc.SetMergeStore(merges)
+
+		records, err := svc.MergeHistory("agent-1")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(records) != 1 || records[0].ID != "m1" {
+			t.Errorf("got %v, want [m1]", records)
+		}
+	})
+}
+
+type mockMergeStore struct {
+	records []*MergeRecord
+}
+
+func (m *mockMergeStore) Save(record *MergeRecord) error {
+	m.records = append(m.records, record)
+	return nil
+}
+
+func (m *mockMergeStore) ListByAgent(agentID string) ([]*MergeRecord, error) {
+	var records []*MergeRecord
+	for _, r := range m.records {
+		if r.AgentID == agentID {
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+func (m *mockMergeStore) List(limit int) ([]*MergeRecord, error) {
+	return m.records, nil
+}
+
+type mockGitHubClient struct {
+	protected map[string]bool
+	protErr   error
+	prURL     string
+}
+
+func (m *mockGitHubClient) IsBranchProtected(branch string) (bool, error) {
+	if m.protErr != nil {
+		return false, m.protErr
+	}
+	return m.protected[branch], nil
+}
+
+func (m *mockGitHubClient) CreatePullRequest(branch, baseBranch string) (string, error) {
+	return m.prURL, nil
+}
+
+func TestAgentService_IsBaseProtected(t *testing.T) {
+	newSvc := func() (*AgentService, *testStore) {
+		store := newTestStore()
+		store.Add(&Agent{ID: "agent-1", Branch: "feature", BaseBranch: "main", Status: AgentStatusActive})
+		tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+		return NewAgentService(tmux, store, &mockDispatcher{}, nil, "proj", "/tmp"), store
+	}
+
+	t.Run("protected by local settings", func(t *testing.T) {
+		svc, _ := newSvc()
+		svc.SetProtectedBranches([]string{"main"})
+
+		protected, err := svc.IsBaseProtected("agent-1")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !protected {
+			t.Error("expected base branch to be protected")
+		}
+	})
+
+	t.Run("protected on remote", func(t *testing.T) {
+		svc, _ := newSvc()
+		svc.SetGitHubClient(&mockGitHubClient{protected: map[string]bool{"main": true}})
+
+		protected, _ := svc.IsBaseProtected("agent-1")
+
+		if !protected {
+			t.Error("expected base branch to be protected")
+		}
+	})
+
+	t.Run("remote lookup failure is unprotected", func(t *testing.T) {
+		svc, _ := newSvc()
+		svc.SetGitHubClient(&mockGitHubClient{protErr: exec.ErrNotFound})
+
+		protected, err := svc.IsBaseProtected("agent-1")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if protected {
+			t.Error("expected base branch to be unprotected")
+		}
+	})
+
+	t.Run("unknown agent", func(t *testing.T) {
+		svc, _ := newSvc()
+
+		if _, err := svc.IsBaseProtected("missing"); err == nil {
+			t.Error("expected error for unknown agent")
+		}
+	})
+}
+
+func (s *testStore) UpdateBaseBranch(id, baseBranch string) error {
+	if a, exists := s.agents[id]; exists {
+		a.BaseBranch = baseBranch
+	}
+	return nil
+}
+
+func (s *testStore) UpdateSummary(id, summary string) error {
+	if a, exists := s.agents[id]; exists {
+		a.Summary = summary
+	}
+	return nil
+}
+
+func (s *testStore) SetMetadata(id, key, value string) error {
+	if a, exists := s.agents[id]; exists {
+		if a.Metadata == nil {
+			a.Metadata = make(map[string]string)
+		}
+		a.Metadata[key] = value
+		if value == "" {
+			delete(a.Metadata, key)
+		}
+	}
+	return nil
+}
+
+func (s *testStore) AddAttachedTime(id string, d time.Duration) error {
+	if a, exists := s.agents[id]; exists {
+		a.AttachedTime += d
+	}
+	return nil
+}
+
+func (s *testStore) AddPausedTime(id string, d time.Duration) error {
+	if a, exists := s.agents[id]; exists {
+		a.PausedTime += d
+	}
+	return nil
+}
+
+func (s *testStore) AddRestart(id string) error {
+	if a, exists := s.agents[id]; exists {
+		a.Restarts++
+	}
+	return nil
+}
+
+type mockGitClient struct {
+	branches  map[string]bool
+	dirty     map[string]bool
+	rebaseErr error
+	rebased   []string
+	worktrees []Worktree
+	created   []string
+	pruned    bool
+	locked    []string
+	lfsErr    error
+	prepared  []string
+	mergeErr  error
+	squashed  []string
+	aborted   bool
+	ahead     map[string]int
+	commits   []Commit
+	stashErr  error
+	stashed   []string
+	stashes   []StashEntry
+	popped    []string
+	templates []string
+	cloned    []string
+	cloneErr  error
+	added     []string
+	committed []string
+	commitErr error
+}
+
+func newMockGit() *mockGitClient {
+	return &mockGitClient{branches: make(map[string]bool), dirty: make(map[string]bool)}
+}
+
+func (m *mockGitClient) IsRepo(path string) bool                   { return true }
+func (m *mockGitClient) Init(path string) error                    { return nil }
+func (m *mockGitClient) CurrentBranch(path string) (string, error) { return "main", nil }
+func (m *mockGitClient) BranchExists(branch string) bool           { return m.branches[branch] }
+func (m *mockGitClient) CreateWorktree(path, branch, baseBranch string) error {
+	m.branches[branch] = true
+	m.created = append(m.created, path)
+	return nil
+}
+func (m *mockGitClient) CreateSparseWorktree(path, branch, baseBranch string, paths []string) error {
+	m.branches[branch] = true
+	m.created = append(m.created, path+":"+strings.Join(paths, ","))
+	return nil
+}
+func (m *mockGitClient) RefreshTemplate(path, baseBranch string) error {
+	m.templates = append(m.templates, path+"@"+baseBranch)
+	return nil
+}
+func (m *mockGitClient) CloneWorktree(template, path, branch, baseBranch string) error {
+	if m.cloneErr != nil {
+		return m.cloneErr
+	}
+	m.branches[branch] = true
+	m.cloned = append(m.cloned, path)
+	return nil
+}
+func (m *mockGitClient) RemoveWorktree(path string) error { return nil }
+func (m *mockGitClient) DeleteBranch(branch string) error { delete(m.branches, branch); return nil }
+func (m *mockGitClient) RenameBranch(oldName, newName string) error {
+	delete(m.branches, oldName)
+	m.branches[newName] = true
+	return nil
+}
+func (m *mockGitClient) HasUncommittedChanges(path string) bool { return m.dirty[path] }
+func (m *mockGitClient) DiscardChanges(path string) error       { return nil }
+func (m *mockGitClient) Stash(path string) error {
+	if m.stashErr != nil {
+		return m.stashErr
+	}
+	m.stashed = append(m.stashed, path)
+	return nil
+}
+func (m *mockGitClient) Add(path string) error {
+	m.added = append(m.added, path)
+	return nil
+}
+func (m *mockGitClient) Commit(path, message string) error {
+	if m.commitErr != nil {
+		return m.commitErr
+	}
+	m.committed = append(m.committed, path+":"+message)
+	delete(m.dirty, path)
+	return nil
+}
+func (m *mockGitClient) ListStashes() ([]StashEntry, error) { return m.stashes, nil }
+func (m *mockGitClient) PopStashRef(path, ref string) error {
+	m.popped = append(m.popped, path+":"+ref)
+	return nil
+}
+func (m *mockGitClient) StashBranch(ref, branch string) error {
+	m.branches[branch] = true
+	return nil
+}
+func (m *mockGitClient) DropStash(ref string) error { return nil }
+func (m *mockGitClient) StashPop(path string) error { return nil }
+func (m *mockGitClient) Merge(branch string) error  { return m.mergeErr }
+func (m *mockGitClient) MergeAbort() error          { m.aborted = true; return nil }
+func (m *mockGitClient) Squash(branch, message string) error {
+	m.squashed = append(m.squashed, message)
+	return m.mergeErr
+}
+func (m *mockGitClient) Rebase(path, baseBranch, branch string) error {
+	m.rebased = append(m.rebased, path+":"+branch+"->"+baseBranch)
+	return m.rebaseErr
+}
+func (m *mockGitClient) MergeConflictFiles() ([]string, error) { return nil, nil }
+func (m *mockGitClient) Push(branch string) error              { return nil }
+func (m *mockGitClient) AheadBehind(branch, baseBranch string) (int, int, error) {
+	return m.ahead[branch], 0, nil
+}
+func (m *mockGitClient) CommitsAhead(branch, baseBranch string) ([]Commit, error) {
+	return m.commits, nil
+}
+func (m *mockGitClient) ShowCommit(hash string) (string, error) { return "commit " + hash, nil }
+func (m *mockGitClient) BatchStatus(targets []StatusTarget) []WorktreeStatus {
+	statuses := make([]WorktreeStatus, len(targets))
+	for i, target := range targets {
+		statuses[i] = WorktreeStatus{Path: target.Path, Dirty: m.dirty[target.Path]}
+		for _, wt := range m.worktrees {
+			if wt.Path == target.Path {
+				statuses[i].Branch = wt.Branch
+				statuses[i].Ahead = m.ahead[wt.Branch]
+			}
+		}
+	}
+	return statuses
+}
+func (m *mockGitClient) DiffStat(branch, baseBranch string) (string, error) {
+	return " 1 file changed, 2 insertions(+)", nil
+}
+func (m *mockGitClient) Diff(path, baseBranch, branch string) (string, error) {
+	return "diff --git a/f.txt b/f.txt\n+added\n", nil
+}
+func (m *mockGitClient) RebaseOnto(path, newBase, oldBase string) error {
+	if m.rebaseErr != nil {
+		return m.rebaseErr
+	}
+	m.rebased = append(m.rebased, path+":"+oldBase+"->"+newBase)
+	return nil
+}
+func (m *mockGitClient) ListWorktrees() ([]Worktree, error) { return m.worktrees, nil }
+func (m *mockGitClient) PruneWorktrees() error              { m.pruned = true; return nil }
+func (m *mockGitClient) LockWorktree(path, reason string) error {
+	m.locked = append(m.locked, path)
+	return nil
+}
+func (m *mockGitClient) UnlockWorktree(path string) error { return nil }
+func (m *mockGitClient) UpdateSubmodules(path string) error {
+	m.prepared = append(m.prepared, "submodules:"+path)
+	return nil
+}
+func (m *mockGitClient) LFSPull(path string) error {
+	if m.lfsErr != nil {
+		return m.lfsErr
+	}
+	m.prepared = append(m.prepared, "lfs:"+path)
+	return nil
+}
+
+func TestAgentService_ForceKill(t *testing.T) {
+	newSvc := func(git *mockGitClient) (*AgentService, *mockDispatcher) {
+		store := newTestStore()
+		store.Add(&Agent{ID: "agent-1", Branch: "feature", WorkDir: "/wt", Status: AgentStatusActive})
+		tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+		dispatcher := &mockDispatcher{}
+		return NewAgentService(tmux, store, dispatcher, git, "proj", "/tmp"), dispatcher
+	}
+
+	t.Run("stashes changes before killing", func(t *testing.T) {
+		git := newMockGit()
+		git.dirty["/wt"] = true
+		svc, dispatcher := newSvc(git)
+
+		if err := svc.ForceKill("agent-1", false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(git.stashed) != 1 || len(dispatcher.published) != 1 {
+			t.Errorf("stashed = %v, published = %d, want a stash then a kill", git.stashed, len(dispatcher.published))
+		}
+	})
+
+	t.Run("stash failure keeps the agent", func(t *testing.T) {
+		git := newMockGit()
+		git.dirty["/wt"] = true
+		git.stashErr = errors.New("stash failed")
+		svc, dispatcher := newSvc(git)
+
+		if err := svc.ForceKill("agent-1", false); err == nil {
+			t.Error("expected error when changes can't be stashed")
+		}
+		if len(dispatcher.published) != 0 {
+			t.Error("expected the agent not to be killed")
+		}
+	})
+
+	t.Run("discard skips the stash", func(t *testing.T) {
+		git := newMockGit()
+		git.dirty["/wt"] = true
+		git.stashErr = errors.New("stash failed")
+		svc, dispatcher := newSvc(git)
+
+		if err := svc.ForceKill("agent-1", true); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(dispatcher.published) != 1 {
+			t.Error("expected the agent to be killed")
+		}
+	})
+}
+
+func TestAgentService_Retarget(t *testing.T) {
+	newSvc := func(git *mockGitClient) (*AgentService, *testStore, *mockDispatcher) {
+		store := newTestStore()
+		store.Add(&Agent{ID: "agent-1", Branch: "feature", BaseBranch: "main", WorkDir: "/wt", Status: AgentStatusActive})
+		tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+		dispatcher := &mockDispatcher{}
+		return NewAgentService(tmux, store, dispatcher, git, "proj", "/tmp"), store, dispatcher
+	}
+
+	t.Run("rebases and updates base branch", func(t *testing.T) {
+		git := newMockGit()
+		git.branches["release"] = true
+		svc, store, dispatcher := newSvc(git)
+
+		err := svc.Retarget("agent-1", "release")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if store.Get("agent-1").BaseBranch != "release" {
+			t.Errorf("BaseBranch = %q, want release", store.Get("agent-1").BaseBranch)
+		}
+		if len(git.rebased) != 1 || git.rebased[0] != "/wt:main->release" {
+			t.Errorf("rebased = %v, want [/wt:main->release]", git.rebased)
+		}
+		event, ok := dispatcher.published[0].(AgentRetargeted)
+		if !ok {
+			t.Fatalf("wrong event type: %T", dispatcher.published[0])
+		}
+		if event.OldBase != "main" || event.NewBase != "release" {
+			t.Errorf("event = %+v, want main -> release", event)
+		}
+	})
+
+	t.Run("unknown base branch", func(t *testing.T) {
+		svc, _, _ := newSvc(newMockGit())
+
+		if err := svc.Retarget("agent-1", "missing"); err == nil {
+			t.Error("expected error for missing base branch")
+		}
+	})
+
+	t.Run("rebase failure keeps base branch", func(t *testing.T) {
+		git := newMockGit()
+		git.branches["release"] = true
+		git.rebaseErr = exec.ErrNotFound
+		svc, store, _ := newSvc(git)
+
+		if err := svc.Retarget("agent-1", "release"); err == nil {
+			t.Fatal("expected error when rebase fails")
+		}
+		if store.Get("agent-1").BaseBranch != "main" {
+			t.Errorf("BaseBranch = %q, want main", store.Get("agent-1").BaseBranch)
+		}
+	})
+
+	t.Run("same base branch", func(t *testing.T) {
+		git := newMockGit()
+		git.branches["main"] = true
+		svc, _, _ := newSvc(git)
+
+		if err := svc.Retarget("agent-1", "main"); err == nil {
+			t.Error("expected error when retargeting to current base")
+		}
+	})
+}
+
+func TestAgentService_MissingWorktrees(t *testing.T) {
+	store := newTestStore()
+	store.Add(&Agent{ID: "present", Branch: "a", WorkDir: "/wt/a", Project: "proj", Status: AgentStatusActive})
+	store.Add(&Agent{ID: "gone", Branch: "b", WorkDir: "/wt/b", Project: "proj", Status: AgentStatusActive})
+	store.Add(&Agent{ID: "stale", Branch: "c", WorkDir: "/wt/c", Project: "proj", Status: AgentStatusActive})
+	store.Add(&Agent{ID: "locked", Branch: "d", WorkDir: "/wt/d", Project: "proj", Status: AgentStatusActive})
+	store.Add(&Agent{ID: "plain", WorkDir: "/tmp", Project: "proj", Status: AgentStatusActive})
+	git := newMockGit()
+	git.worktrees = []Worktree{
+		{Path: "/repo", Branch: "main"},
+		{Path: "/wt/a", Branch: "a"},
+		{Path: "/wt/c", Branch: "c", Prunable: true},
+		{Path: "/wt/d", Branch: "d", Locked: true, Missing: true},
+	}
+	tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+	svc := NewAgentService(tmux, store, &mockDispatcher{}, git, "proj", "/tmp")
+
+	missing, err := svc.MissingWorktrees()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ids := map[string]bool{}
+	for _, a := range missing {
+		ids[a.ID] = true
+	}
+	if len(missing) != 3 || !ids["gone"] || !ids["stale"] || !ids["locked"] {
+		t.Errorf("missing = %v, want [gone stale locked]", ids)
+	}
+}
+
+func TestAgentService_RecreateWorktree(t *testing.T) {
+	store := newTestStore()
+	store.Add(&Agent{ID: "agent-1", Branch: "feature", BaseBranch: "main", WorkDir: "/wt/a", Project: "proj", Status: AgentStatusActive})
+	store.Add(&Agent{ID: "plain", WorkDir: "/tmp", Project: "proj", Status: AgentStatusActive})
+	git := newMockGit()
+	tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+	svc := NewAgentService(tmux, store, &mockDispatcher{}, git, "proj", "/tmp")
+
+	t.Run("prunes and recreates", func(t *testing.T) {
+		if err := svc.RecreateWorktree("agent-1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !git.pruned {
+			t.Error("expected stale worktrees to be pruned")
+		}
+		if len(git.created) != 1 || git.created[0] != "/wt/a" {
+			t.Errorf("created = %v, want [/wt/a]", git.created)
+		}
+		if len(git.locked) != 1 || git.locked[0] != "/wt/a" {
+			t.Errorf("locked = %v, want [/wt/a]", git.locked)
+		}
+	})
+
+	t.Run("agent without worktree", func(t *testing.T) {
+		if err := svc.RecreateWorktree("plain"); err == nil {
+			t.Error("expected error for agent without branch")
+		}
+	})
+
+	t.Run("unknown agent", func(t *testing.T) {
+		if err := svc.RecreateWorktree("missing"); err == nil {
+			t.Error("expected error for unknown agent")
+		}
+	})
+}
+
+func TestAgentService_MergeAgent_SigningFailure(t *testing.T) {
+	store := newTestStore()
+	store.Add(&Agent{ID: "agent-1", Branch: "feature", BaseBranch: "main", Status: AgentStatusActive})
+	git := newMockGit()
+	git.mergeErr = fmt.Errorf("%w: gpg failed to sign the data", ErrCommitSigning)
+	tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+	dispatcher := &mockDispatcher{}
+	svc := NewAgentService(tmux, store, dispatcher, git, "proj", "/tmp")
+
+	result, err := svc.MergeAgent("agent-1")
+
+	if !errors.Is(err, ErrCommitSigning) {
+		t.Fatalf("err = %v, want ErrCommitSigning", err)
+	}
+	if result != nil {
+		t.Errorf("result = %+v, want nil", result)
+	}
+	if !git.aborted {
+		t.Error("expected the unsigned merge to be aborted")
+	}
+	if len(dispatcher.published) != 0 {
+		t.Errorf("published %d events, want 0 (not a conflict)", len(dispatcher.published))
+	}
+}
+
+func TestAgentService_MergeAgentWithStrategy(t *testing.T) {
+	setup := func() (*AgentService, *mockGitClient, *mockDispatcher) {
+		store := newTestStore()
+		store.Add(&Agent{ID: "agent-1", Branch: "feature", BaseBranch: "main", WorkDir: "/wt/feature", Summary: "Added token refresh", Status: AgentStatusActive})
+		git := newMockGit()
+		dispatcher := &mockDispatcher{}
+		return NewAgentService(&mockTmuxClient{sessions: make(map[string]bool)}, store, dispatcher, git, "proj", "/tmp"), git, dispatcher
+	}
+
+	t.Run("squash", func(t *testing.T) {
+		svc, git, dispatcher := setup()
+
+		result, err := svc.MergeAgentWithStrategy("agent-1", MergeStrategySquash)
+
+		if err != nil || !result.Success {
+			t.Fatalf("MergeAgentWithStrategy = %+v, %v, want success", result, err)
+		}
+		if len(git.squashed) != 1 || git.squashed[0] != "Squash merge branch 'feature'\n\nAdded token refresh" {
+			t.Errorf("squashed %q, want one commit with the summary", git.squashed)
+		}
+		completed, ok := dispatcher.published[0].(MergeCompleted)
+		if !ok || completed.Record.Strategy != MergeStrategySquash {
+			t.Errorf("published %#v, want a squash merge recorded", dispatcher.published[0])
+		}
+	})
+
+	t.Run("rebase", func(t *testing.T) {
+		svc, git, _ := setup()
+
+		if _, err := svc.MergeAgentWithStrategy("agent-1", MergeStrategyRebase); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(git.rebased) != 1 || git.rebased[0] != "/wt/feature:feature->main" {
+			t.Errorf("rebased %v, want feature rebased onto main in its worktree", git.rebased)
+		}
+	})
+
+	t.Run("default from settings", func(t *testing.T) {
+		svc, git, _ := setup()
+		svc.SetMergeStrategy(MergeStrategyRebase)
+
+		if _, err := svc.MergeAgent("agent-1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(git.rebased) != 1 {
+			t.Errorf("rebased %v, want MergeAgent to use the default strategy", git.rebased)
+		}
+	})
+}
+
+func TestAgentService_Status(t *testing.T) {
+	store := newTestStore()
+	store.Add(&Agent{ID: "craizy-proj-claude-a", Project: "proj", Branch: "a", BaseBranch: "main", WorkDir: "/wt/a", Status: AgentStatusActive})
+	store.Add(&Agent{ID: "craizy-proj-claude-b", Project: "proj", Branch: "b", BaseBranch: "main", WorkDir: "/wt/b", Status: AgentStatusActive})
+	git := newMockGit()
+	git.ahead = map[string]int{"a": 3}
+	git.dirty["/wt/b"] = true
+	git.worktrees = []Worktree{{Path: "/wt/a", Branch: "a"}, {Path: "/wt/b", Branch: "b"}}
+	tmux := &mockTmuxClient{sessions: map[string]bool{
+		"craizy-proj-claude-a":     true,
+		"craizy-proj-claude-ghost": true,
+	}}
+	svc := NewAgentService(tmux, store, &mockDispatcher{}, git, "proj", "/tmp")
+
+	status, err := svc.Status()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(status.Agents) != 2 {
+		t.Fatalf("got %d agents, want 2", len(status.Agents))
+	}
+	if status.PendingMerges != 1 {
+		t.Errorf("PendingMerges = %d, want 1", status.PendingMerges)
+	}
+	running, dirty := map[string]bool{}, map[string]bool{}
+	for _, s := range status.Agents {
+		running[s.Agent.ID] = s.Running
+		dirty[s.Agent.ID] = s.Dirty
+	}
+	if dirty["craizy-proj-claude-a"] || !dirty["craizy-proj-claude-b"] {
+		t.Errorf("dirty = %v, want only b dirty", dirty)
+	}
+	if !running["craizy-proj-claude-a"] || running["craizy-proj-claude-b"] {
+		t.Errorf("running = %v, want only a running", running)
+	}
+	if len(status.OrphanedSessions) != 1 || status.OrphanedSessions[0] != "craizy-proj-claude-ghost" {
+		t.Errorf("OrphanedSessions = %v, want [craizy-proj-claude-ghost]", status.OrphanedSessions)
+	}
+	if store.Get("craizy-proj-claude-b").Status != AgentStatusActive {
+		t.Error("Status must not modify stored agents")
+	}
+	if !tmux.sessions["craizy-proj-claude-ghost"] {
+		t.Error("Status must not kill orphaned sessions")
+	}
+}
+
+func TestAgentService_WarmPool(t *testing.T) {
+	// newSvc wires a dispatcher that applies created/claimed events to the store,
+	// standing in for the infra adapters.
+	newSvc := func() (*AgentService, *testStore, *mockTmuxClient, *mockGitClient) {
+		store := newTestStore()
+		tmux := &mockTmuxClient{sessions: make(map[string]bool), capturedOutput: "ready>"}
+		git := newMockGit()
+		dispatcher := &applyingDispatcher{store: store, tmux: tmux}
+		svc := NewAgentService(tmux, store, dispatcher, git, "proj", "/tmp")
+		svc.SetWarmPool([]PoolSpec{{AgentType: "Claude", Command: "claude", Size: 2}})
+		return svc, store, tmux, git
+	}
+
+	t.Run("fills pool with idle agents", func(t *testing.T) {
+		svc, store, _, _ := newSvc()
+
+		if err := svc.FillPool(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if idle := svc.idleAgents("claude"); len(idle) != 2 {
+			t.Errorf("got %d idle agents, want 2", len(idle))
+		}
+		if len(svc.List()) != 0 {
+			t.Error("idle agents should not be listed as active")
+		}
+
+		// A second fill is a no-op
+		_ = svc.FillPool()
+		if len(store.List()) != 2 {
+			t.Errorf("got %d stored agents, want 2", len(store.List()))
+		}
+	})
+
+	t.Run("create claims a ready warm agent", func(t *testing.T) {
+		svc, store, tmux, git := newSvc()
+		_ = svc.FillPool()
+
+		agent, err := svc.Create("Claude", "task1", "claude")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if agent.ID != "craizy-proj-claude-task1" || agent.Status != AgentStatusActive {
+			t.Errorf("agent = %+v, want active craizy-proj-claude-task1", agent)
+		}
+		if !strings.Contains(agent.WorkDir, "pool-") {
+			t.Errorf("WorkDir = %q, want the warm agent's worktree", agent.WorkDir)
+		}
+		if !tmux.sessions[agent.ID] || !git.branches[agent.ID] {
+			t.Error("session and branch should be renamed to the new ID")
+		}
+		if len(svc.idleAgents("claude")) != 1 || store.Get(agent.ID) == nil {
+			t.Error("claimed agent should replace one idle record")
+		}
+	})
+
+	t.Run("skips warm agents that are not ready", func(t *testing.T) {
+		svc, _, tmux, _ := newSvc()
+		_ = svc.FillPool()
+		tmux.capturedOutput = "  \n"
+
+		agent, err := svc.Create("Claude", "task1", "claude")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.Contains(agent.WorkDir, "pool-") {
+			t.Error("agent should be created fresh when no warm agent is ready")
+		}
+	})
+}
+
+// applyingDispatcher applies agent lifecycle events directly to a store and tmux mock.
+type applyingDispatcher struct {
+	store *testStore
+	tmux  *mockTmuxClient
+}
+
+func (d *applyingDispatcher) Publish(event Event) {
+	switch e := event.(type) {
+	case AgentCreated:
+		d.tmux.sessions[e.Agent.ID] = true
+		_ = d.store.Add(e.Agent)
+	case AgentClaimed:
+		_ = d.tmux.RenameSession(e.OldID, e.Agent.ID)
+		_ = d.store.Remove(e.OldID)
+		_ = d.store.Add(e.Agent)
+	}
+}
+
+func (d *applyingDispatcher) Subscribe(eventType string, handler EventHandler) {}
+
+type mockCache struct {
+	err    error
+	shared []string
+}
+
+func (m *mockCache) Share(projectRoot, worktree string) error {
+	m.shared = append(m.shared, projectRoot+"->"+worktree)
+	return m.err
+}
+
+func (m *mockCache) Name() string { return "mock" }
+
+type mockDevEnvironment struct {
+	warnings []string
+}
+
+func (m *mockDevEnvironment) Prepare(worktree, command string) (string, []string) {
+	return "wrapped " + command, m.warnings
+}
+
+// doneReporter passes on progress reports that finish an operation.
+type doneReporter chan OperationProgress
+
+func (r doneReporter) Report(progress OperationProgress) {
+	if progress.Done {
+		r <- progress
+	}
+}
+
+func TestAgentService_StartCreate(t *testing.T) {
+	t.Run("starts in the background", func(t *testing.T) {
+		store := newTestStore()
+		dispatcher := &mockDispatcher{}
+		svc := NewAgentService(&mockTmuxClient{sessions: map[string]bool{}}, store, dispatcher, newMockGit(), "proj", "/tmp")
+		done := make(doneReporter, 1)
+		svc.SetProgressReporter(done)
+
+		agent, err := svc.StartCreate("claude", "auth", "claude", CreateOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if agent.Status != AgentStatusStarting || agent.Branch != "" {
+			t.Errorf("agent = %+v, want a starting placeholder without a branch", agent)
+		}
+		if _, ok := dispatcher.published[0].(AgentStarting); !ok {
+			t.Errorf("published %T first, want AgentStarting", dispatcher.published[0])
+		}
+
+		if p := <-done; p.Err != nil {
+			t.Fatalf("unexpected error: %v", p.Err)
+		}
+		created, ok := dispatcher.published[len(dispatcher.published)-1].(AgentCreated)
+		if !ok || created.Agent.Status != AgentStatusActive || created.Agent.Branch != agent.ID {
+			t.Errorf("published %+v last, want the active agent created", dispatcher.published[len(dispatcher.published)-1])
+		}
+	})
+
+	t.Run("killed while starting", func(t *testing.T) {
+		store := newTestStore()
+		git := newMockGit()
+		svc := NewAgentService(&mockTmuxClient{sessions: map[string]bool{}}, store, &mockDispatcher{}, git, "proj", "/tmp")
+		done := make(doneReporter, 1)
+		svc.SetProgressReporter(done)
+
+		// Hold every slot so the worker waits
+		for range MaxParallelStarts {
+			svc.startSlots <- struct{}{}
+		}
+		agent, err := svc.StartCreate("claude", "auth", "claude", CreateOptions{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		store.Add(&Agent{ID: agent.ID, Status: AgentStatusTerminated})
+		for range MaxParallelStarts {
+			<-svc.startSlots
+		}
+
+		if p := <-done; p.Err == nil {
+			t.Error("expected the start to fail once the agent was killed")
+		}
+		if got := store.Get(agent.ID); got.Status != AgentStatusTerminated {
+			t.Errorf("status = %v, want the agent left terminated", got.Status)
+		}
+		if git.branches[agent.ID] {
+			t.Error("expected the new branch to be deleted")
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// Stack is the language and runtime information detected in a worktree.
+// Fields are empty when not detected.
+type Stack struct {
+	GoModule      string // module path from go.mod
+	GoVersion     string // go directive from go.mod
+	NodePackage   string // name from package.json
+	NodeVersion   string // engines.node from package.json, or .nvmrc
+	PythonVersion string // .python-version, or requires-python from pyproject.toml
+}
+
+// requiresPythonPattern matches the requires-python line in pyproject.toml.
+var requiresPythonPattern = regexp.MustCompile(`(?m)^\s*requires-python\s*=\s*["']([^"']+)["']`)
+
+// ProbeStack detects the stack of the project in dir from its manifest files.
+// Missing or unreadable files are skipped.
+func ProbeStack(dir string) Stack {
+	logging.Entry("dir", dir)
+	var stack Stack
+	probeGoMod(filepath.Join(dir, "go.mod"), &stack)
+	probePackageJSON(filepath.Join(dir, "package.json"), &stack)
+	if stack.NodeVersion == "" {
+		stack.NodeVersion = readFirstLine(filepath.Join(dir, ".nvmrc"))
+	}
+	stack.PythonVersion = readFirstLine(filepath.Join(dir, ".python-version"))
+	if stack.PythonVersion == "" {
+		if data, err := os.ReadFile(filepath.Join(dir, "pyproject.toml")); err == nil {
+			if m := requiresPythonPattern.FindSubmatch(data); m != nil {
+				stack.PythonVersion = string(m[1])
+			}
+		}
+	}
+	return stack
+}
+
+// probeGoMod reads the module path and go version from a go.mod file.
+func probeGoMod(path string, stack *Stack) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[0] {
+		case "module":
+			stack.GoModule = strings.Trim(fields[1], `"`)
+		case "go":
+			stack.GoVersion = fields[1]
+		}
+	}
+}
+
+// probePackageJSON reads the package name and node engine from a package.json file.
+func probePackageJSON(path string, stack *Stack) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	var pkg struct {
+		Name    string            `json:"name"`
+		Engines map[string]string `json:"engines"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		logging.Debug("unreadable package.json, path=%s, err=%v", path, err)
+		return
+	}
+	stack.NodePackage = pkg.Name
+	stack.NodeVersion = pkg.Engines["node"]
+}
+
+// readFirstLine returns the first non-blank line of the file at path, or "".
+func readFirstLine(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+// IsEmpty reports whether nothing was detected.
+func (s Stack) IsEmpty() bool {
+	return s == Stack{}
+}
+
+// String describes the stack for display, e.g. "Go 1.24 (example.com/app), Python 3.12".
+func (s Stack) String() string {
+	var parts []string
+	if s.GoModule != "" || s.GoVersion != "" {
+		parts = append(parts, describeRuntime("Go", s.GoVersion, s.GoModule))
+	}
+	if s.NodePackage != "" || s.NodeVersion != "" {
+		parts = append(parts, describeRuntime("Node", s.NodeVersion, s.NodePackage))
+	}
+	if s.PythonVersion != "" {
+		parts = append(parts, describeRuntime("Python", s.PythonVersion, ""))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeRuntime formats one runtime, e.g. "Go 1.24 (example.com/app)".
+func describeRuntime(name, version, project string) string {
+	if version != "" {
+		name += " " + version
+	}
+	if project != "" {
+		name += " (" + project + ")"
+	}
+	return name
+}
+
+// Vars returns the stack as prompt template variables. Every variable is
+// present, empty if not detected, so unused placeholders expand to nothing.
+func (s Stack) Vars() map[string]string {
+	return map[string]string{
+		"stack":          s.String(),
+		"go_module":      s.GoModule,
+		"go_version":     s.GoVersion,
+		"node_package":   s.NodePackage,
+		"node_version":   s.NodeVersion,
+		"python_version": s.PythonVersion,
+	}
+}
+
+// ExpandVars replaces each {name} in text with vars[name]. Placeholders without
+// a variable are left as written.
+func ExpandVars(text string, vars map[string]string) string {
+	pairs := make([]string, 0, 2*len(vars))
+	for name, value := range vars {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
+// Stack probes an agent's worktree for its language and runtime.
+func (s *AgentService) Stack(agentID string) (Stack, error) {
+	logging.Entry("agentID", agentID)
+	agent := s.store.Get(agentID)
+	if agent == nil {
+		err := fmt.Errorf("agent %q not found", agentID)
+		logging.Error(err, "agentID", agentID)
+		return Stack{}, err
+	}
+	return ProbeStack(agent.WorkDir), nil
+}
+
+// promptVars returns the template variables available to an agent's startup
+// prompt: the agent's identity plus its worktree's stack.
+func promptVars(agent *Agent) map[string]string {
+	vars := ProbeStack(agent.WorkDir).Vars()
+	vars["name"] = agent.Name
+	vars["branch"] = agent.Branch
+	vars["base_branch"] = agent.BaseBranch
+	vars["worktree"] = agent.WorkDir
+	return vars
+}
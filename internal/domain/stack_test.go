@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProbeStack(t *testing.T) {
+	write := func(t *testing.T, dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("detects go, node and python", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, "go.mod", "module example.com/app\n\ngo 1.24\n\nrequire golang.org/x/text v0.1.0\n")
+		write(t, dir, "package.json", `{"name": "web", "engines": {"node": ">=20"}}`)
+		write(t, dir, ".python-version", "3.12\n")
+
+		stack := ProbeStack(dir)
+
+		want := Stack{GoModule: "example.com/app", GoVersion: "1.24", NodePackage: "web", NodeVersion: ">=20", PythonVersion: "3.12"}
+		if stack != want {
+			t.Errorf("ProbeStack = %+v, want %+v", stack, want)
+		}
+		if got := stack.String(); got != "Go 1.24 (example.com/app), Node >=20 (web), Python 3.12" {
+			t.Errorf("String = %q", got)
+		}
+	})
+
+	t.Run("falls back to nvmrc and pyproject", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, "package.json", `{"name": "web"}`)
+		write(t, dir, ".nvmrc", "\n22\n")
+		write(t, dir, "pyproject.toml", "[project]\nname = \"tool\"\nrequires-python = \">=3.11\"\n")
+
+		stack := ProbeStack(dir)
+
+		if stack.NodeVersion != "22" || stack.PythonVersion != ">=3.11" {
+			t.Errorf("ProbeStack = %+v", stack)
+		}
+	})
+
+	t.Run("empty for plain directories", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, "package.json", "not json")
+
+		stack := ProbeStack(dir)
+
+		if !stack.IsEmpty() || stack.String() != "" {
+			t.Errorf("ProbeStack = %+v", stack)
+		}
+	})
+}
+
+func TestExpandVars(t *testing.T) {
+	vars := Stack{GoModule: "example.com/app"}.Vars()
+	vars["name"] = "auth"
+
+	got := ExpandVars("{name}: work on {go_module} with {python_version}{unknown}", vars)
+
+	if want := "auth: work on example.com/app with {unknown}"; got != want {
+		t.Errorf("ExpandVars = %q, want %q", got, want)
+	}
+}
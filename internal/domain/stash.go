@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// Stashes returns the stashes crAIzy created before merges and kills, newest first.
+func (s *AgentService) Stashes() ([]StashEntry, error) {
+	logging.Entry()
+	if s.git == nil {
+		return nil, fmt.Errorf("git is not available")
+	}
+	all, err := s.git.ListStashes()
+	if err != nil {
+		logging.Error(err)
+		return nil, fmt.Errorf("failed to list stashes: %w", err)
+	}
+	var stashes []StashEntry
+	for _, stash := range all {
+		if stash.Message == AutoStashMessage {
+			stashes = append(stashes, stash)
+		}
+	}
+	return stashes, nil
+}
+
+// PopStash applies a crAIzy stash where it came from, the worktree of the
+// running agent on its branch or else the project root, and drops it.
+// It returns the path the changes were applied to.
+func (s *AgentService) PopStash(ref string) (string, error) {
+	logging.Entry("ref", ref)
+	stash, err := s.autoStash(ref)
+	if err != nil {
+		return "", err
+	}
+
+	path := s.workDir
+	for _, agent := range s.store.List() {
+		if agent.Branch != "" && agent.Branch == stash.Branch && agent.Status != AgentStatusTerminated {
+			path = agent.WorkDir
+			break
+		}
+	}
+	if err := s.git.PopStashRef(path, ref); err != nil {
+		logging.Error(err, "ref", ref, "path", path)
+		return "", fmt.Errorf("failed to pop stash: %w", err)
+	}
+	logging.Info("stash popped, ref=%s, path=%s", ref, path)
+	return path, nil
+}
+
+// StashToBranch applies a crAIzy stash to a new branch checked out in the project root.
+func (s *AgentService) StashToBranch(ref, branch string) error {
+	logging.Entry("ref", ref, "branch", branch)
+	if _, err := s.autoStash(ref); err != nil {
+		return err
+	}
+	if s.git.BranchExists(branch) {
+		err := fmt.Errorf("branch %q already exists", branch)
+		logging.Error(err, "ref", ref)
+		return err
+	}
+	if err := s.git.StashBranch(ref, branch); err != nil {
+		logging.Error(err, "ref", ref, "branch", branch)
+		return fmt.Errorf("failed to apply stash to branch: %w", err)
+	}
+	return nil
+}
+
+// DropStash deletes a crAIzy stash.
+func (s *AgentService) DropStash(ref string) error {
+	logging.Entry("ref", ref)
+	if _, err := s.autoStash(ref); err != nil {
+		return err
+	}
+	if err := s.git.DropStash(ref); err != nil {
+		logging.Error(err, "ref", ref)
+		return fmt.Errorf("failed to drop stash: %w", err)
+	}
+	return nil
+}
+
+// autoStash looks up ref among crAIzy's stashes, so the user's own are never touched.
+func (s *AgentService) autoStash(ref string) (StashEntry, error) {
+	stashes, err := s.Stashes()
+	if err != nil {
+		return StashEntry{}, err
+	}
+	for _, stash := range stashes {
+		if stash.Ref == ref {
+			return stash, nil
+		}
+	}
+	err = fmt.Errorf("stash %q not found", ref)
+	logging.Error(err)
+	return StashEntry{}, err
+}
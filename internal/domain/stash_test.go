@@ -0,0 +1,67 @@
+package domain
+
+import "testing"
+
+func TestAgentService_Stashes(t *testing.T) {
+	newSvc := func() (*AgentService, *mockGitClient) {
+		store := newTestStore()
+		store.Add(&Agent{ID: "agent-1", Branch: "craizy/auth", WorkDir: "/wt/auth", Status: AgentStatusActive})
+		git := newMockGit()
+		git.stashes = []StashEntry{
+			{Ref: "stash@{0}", Branch: "craizy/auth", Message: AutoStashMessage},
+			{Ref: "stash@{1}", Branch: "main", Message: "my own work"},
+			{Ref: "stash@{2}", Branch: "main", Message: AutoStashMessage},
+		}
+		tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+		return NewAgentService(tmux, store, &mockDispatcher{}, git, "proj", "/repo"), git
+	}
+
+	t.Run("lists only crAIzy stashes", func(t *testing.T) {
+		svc, _ := newSvc()
+
+		stashes, err := svc.Stashes()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(stashes) != 2 || stashes[0].Ref != "stash@{0}" || stashes[1].Ref != "stash@{2}" {
+			t.Errorf("Stashes() = %+v, want stash@{0} and stash@{2}", stashes)
+		}
+	})
+
+	t.Run("pops into the agent worktree on the stash branch", func(t *testing.T) {
+		svc, git := newSvc()
+
+		path, err := svc.PopStash("stash@{0}")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if path != "/wt/auth" || git.popped[0] != "/wt/auth:stash@{0}" {
+			t.Errorf("popped = %v into %q, want the agent worktree", git.popped, path)
+		}
+	})
+
+	t.Run("pops other stashes into the project root", func(t *testing.T) {
+		svc, _ := newSvc()
+
+		if path, err := svc.PopStash("stash@{2}"); err != nil || path != "/repo" {
+			t.Errorf("PopStash() = %q, %v, want /repo", path, err)
+		}
+	})
+
+	t.Run("leaves the user's own stashes alone", func(t *testing.T) {
+		svc, _ := newSvc()
+
+		if err := svc.DropStash("stash@{1}"); err == nil {
+			t.Error("expected error dropping a stash crAIzy didn't create")
+		}
+	})
+
+	t.Run("refuses an existing branch", func(t *testing.T) {
+		svc, git := newSvc()
+		git.branches["rescued"] = true
+
+		if err := svc.StashToBranch("stash@{0}", "rescued"); err == nil {
+			t.Error("expected error for an existing branch")
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"strings"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// AgentSummary describes the live state of a single agent.
+type AgentSummary struct {
+	Agent           *Agent
+	Running         bool // tmux session exists
+	WorktreeMissing bool // worktree directory or registration has disappeared
+	Ahead           int  // commits on the agent branch not yet in its base
+	Behind          int  // commits on the base not yet in the agent branch
+	Dirty           bool // uncommitted changes in the worktree
+}
+
+// FleetStatus is a point-in-time overview of all agents in a project.
+type FleetStatus struct {
+	Project          string
+	Agents           []AgentSummary
+	UnreadMessages   int      // unread messages addressed to the human
+	PendingMerges    int      // agents with commits not yet merged into their base
+	OrphanedSessions []string // tmux sessions for this project with no stored agent
+	Queued           []*Agent // agents waiting for a provider slot, oldest first
+}
+
+// Status collects a read-only overview of the project's agents. Unlike Reconcile,
+// it reports problems without fixing them.
+func (s *AgentService) Status() (*FleetStatus, error) {
+	logging.Entry("project", s.project)
+	status := &FleetStatus{Project: s.project}
+
+	missing := make(map[string]bool)
+	if agents, err := s.MissingWorktrees(); err == nil {
+		for _, agent := range agents {
+			missing[agent.ID] = true
+		}
+	}
+
+	gitStatus := s.GitStatus()
+	for _, agent := range s.List() {
+		git := gitStatus[agent.ID]
+		summary := AgentSummary{
+			Agent:           agent,
+			Running:         s.tmux.SessionExists(agent.ID),
+			WorktreeMissing: missing[agent.ID],
+			Ahead:           git.Ahead,
+			Behind:          git.Behind,
+			Dirty:           git.Dirty,
+		}
+		if summary.Ahead > 0 {
+			status.PendingMerges++
+		}
+		status.Agents = append(status.Agents, summary)
+	}
+
+	status.Queued = s.Queued()
+
+	if s.messageSvc != nil {
+		for _, inbox := range s.messageSvc.humanInboxes() {
+			count, err := s.messageSvc.UnreadCount(inbox)
+			if err != nil {
+				logging.Error(err, "action", "unread count")
+			}
+			status.UnreadMessages += count
+		}
+	}
+
+	// tmux might not be running, in which case there are no orphans
+	if sessions, err := s.tmux.ListSessions(); err == nil {
+		prefix := "craizy-" + SanitizeName(s.project) + "-"
+		for _, session := range sessions {
+			if strings.HasPrefix(session, prefix) && !IsDashboardSession(session) && !s.store.Exists(session) {
+				status.OrphanedSessions = append(status.OrphanedSessions, session)
+			}
+		}
+	}
+
+	logging.Debug("status collected, agents=%d, pendingMerges=%d", len(status.Agents), status.PendingMerges)
+	return status, nil
+}
+
+// GitStatus collects the git state of every listed agent's worktree in one
+// batch, keyed by agent ID. Agents without a worktree of their own, and those
+// whose worktree is missing, are left out.
+func (s *AgentService) GitStatus() map[string]WorktreeStatus {
+	logging.Entry("project", s.project)
+	statuses := make(map[string]WorktreeStatus)
+	if s.git == nil {
+		return statuses
+	}
+
+	var agents []*Agent
+	var targets []StatusTarget
+	for _, agent := range s.List() {
+		if agent.Branch == "" {
+			continue
+		}
+		agents = append(agents, agent)
+		targets = append(targets, StatusTarget{Path: agent.WorkDir, BaseBranch: agent.BaseBranch})
+	}
+	for i, status := range s.git.BatchStatus(targets) {
+		if status.Err != nil {
+			continue
+		}
+		statuses[agents[i].ID] = status
+	}
+	logging.Debug("git status collected, worktrees=%d", len(statuses))
+	return statuses
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+const (
+	// summaryTranscriptLines is how many trailing transcript lines the summarizer sees.
+	summaryTranscriptLines = 200
+
+	// maxSummaryLength caps stored summaries so a chatty summarizer can't flood reports.
+	maxSummaryLength = 1000
+)
+
+// ErrNoSummarizer is returned by Summarize when no summarizer is configured.
+var ErrNoSummarizer = errors.New("no summarizer configured")
+
+// SetSummarizer sets the summarizer run when an agent reports completion.
+// This is optional - if not set, Summarize returns ErrNoSummarizer.
+func (s *AgentService) SetSummarizer(summarizer ISummarizer) {
+	s.summarizer = summarizer
+}
+
+// HasSummarizer reports whether a summarizer is configured.
+func (s *AgentService) HasSummarizer() bool {
+	return s.summarizer != nil
+}
+
+// Summarize asks the summarizer for a short summary of an agent's work, built from
+// its latest completion message and the tail of its transcript, and stores it on
+// the agent record.
+func (s *AgentService) Summarize(agentID string) (string, error) {
+	logging.Entry("agentID", agentID)
+	if s.summarizer == nil {
+		return "", ErrNoSummarizer
+	}
+
+	agent := s.store.Get(agentID)
+	if agent == nil {
+		err := fmt.Errorf("agent %q not found", agentID)
+		logging.Error(err, "agentID", agentID)
+		return "", err
+	}
+
+	summary, err := s.summarizer.Summarize(s.summaryInput(agent))
+	if err != nil {
+		err = fmt.Errorf("failed to summarize agent: %w", err)
+		logging.Error(err, "agentID", agentID)
+		return "", err
+	}
+	summary = strings.TrimSpace(summary)
+	if len(summary) > maxSummaryLength {
+		summary = strings.TrimSpace(summary[:maxSummaryLength]) + "…"
+	}
+	if summary == "" {
+		err := fmt.Errorf("summarizer returned an empty summary")
+		logging.Error(err, "agentID", agentID)
+		return "", err
+	}
+
+	if err := s.store.UpdateSummary(agentID, summary); err != nil {
+		logging.Error(err, "agentID", agentID)
+		return "", err
+	}
+
+	s.dispatcher.Publish(AgentSummarized{
+		AgentID:   agentID,
+		Summary:   summary,
+		Timestamp: time.Now(),
+	})
+	logging.Info("agent summarized, agentID=%s", agentID)
+	return summary, nil
+}
+
+// summaryInput describes the agent, its completion message and recent output for the summarizer.
+func (s *AgentService) summaryInput(agent *Agent) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Agent: %s (%s)\n", agent.Name, agent.AgentType)
+	if agent.Branch != "" {
+		fmt.Fprintf(&b, "Branch: %s onto %s\n", agent.Branch, agent.BaseBranch)
+	}
+
+	if s.messageSvc != nil {
+		thread, err := s.messageSvc.Thread(agent.ID)
+		if err != nil {
+			logging.Error(err, "agentID", agent.ID, "action", "message thread")
+		}
+		for i := len(thread) - 1; i >= 0; i-- {
+			if thread[i].From == agent.ID && thread[i].Type == MessageTypeCompletion {
+				fmt.Fprintf(&b, "\nCompletion message:\n%s\n", thread[i].Content)
+				break
+			}
+		}
+	}
+
+	// The transcript is optional; it's missing for ephemeral runs and older agents
+	if lines, err := s.Transcript(agent.ID); err == nil {
+		if len(lines) > summaryTranscriptLines {
+			lines = lines[len(lines)-summaryTranscriptLines:]
+		}
+		b.WriteString("\nRecent output:\n")
+		for _, line := range lines {
+			if strings.TrimSpace(line.Text) != "" {
+				b.WriteString(line.Text + "\n")
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+type mockSummarizer struct {
+	input   string
+	summary string
+	err     error
+}
+
+func (m *mockSummarizer) Summarize(input string) (string, error) {
+	m.input = input
+	return m.summary, m.err
+}
+
+func TestAgentService_Summarize(t *testing.T) {
+	newSummaryService := func(t *testing.T, summarizer ISummarizer) (*AgentService, *testStore, *mockDispatcher) {
+		workDir := t.TempDir()
+		store := newTestStore()
+		store.Add(&Agent{ID: "agent-1", Name: "auth", AgentType: "claude", Branch: "craizy/auth", BaseBranch: "main"})
+		messages := newMockMessageStore()
+		messages.Save(NewMessage("agent-1", HumanParticipantID, MessageTypeCompletion, "Token refresh done", nil))
+
+		transcript := TranscriptPath(workDir, "agent-1")
+		os.MkdirAll(filepath.Dir(transcript), 0o755)
+		os.WriteFile(transcript, []byte(FormatTranscriptLine(time.Now(), "all tests pass")), 0o644)
+
+		dispatcher := &mockDispatcher{}
+		tmux := &mockTmuxClient{sessions: map[string]bool{}}
+		svc := NewAgentService(tmux, store, dispatcher, newMockGit(), "proj", workDir)
+		svc.SetMessageService(NewMessageService(messages, tmux, store))
+		if summarizer != nil {
+			svc.SetSummarizer(summarizer)
+		}
+		return svc, store, dispatcher
+	}
+
+	t.Run("stores summary built from completion and transcript", func(t *testing.T) {
+		summarizer := &mockSummarizer{summary: "  Added token refresh.\n"}
+		svc, store, dispatcher := newSummaryService(t, summarizer)
+
+		summary, err := svc.Summarize("agent-1")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if summary != "Added token refresh." || store.Get("agent-1").Summary != summary {
+			t.Errorf("summary = %q, stored %q", summary, store.Get("agent-1").Summary)
+		}
+		for _, want := range []string{"auth (claude)", "Token refresh done", "all tests pass"} {
+			if !strings.Contains(summarizer.input, want) {
+				t.Errorf("summarizer input missing %q:\n%s", want, summarizer.input)
+			}
+		}
+		if len(dispatcher.published) != 1 || dispatcher.published[0].EventType() != "agent.summarized" {
+			t.Errorf("expected agent.summarized event, got %v", dispatcher.published)
+		}
+	})
+
+	t.Run("no summarizer", func(t *testing.T) {
+		svc, _, _ := newSummaryService(t, nil)
+		if _, err := svc.Summarize("agent-1"); !errors.Is(err, ErrNoSummarizer) {
+			t.Errorf("got %v, want ErrNoSummarizer", err)
+		}
+	})
+
+	t.Run("summarizer error", func(t *testing.T) {
+		svc, store, _ := newSummaryService(t, &mockSummarizer{err: errors.New("boom")})
+		if _, err := svc.Summarize("agent-1"); err == nil {
+			t.Error("expected error")
+		}
+		if store.Get("agent-1").Summary != "" {
+			t.Error("summary should not be stored on error")
+		}
+	})
+
+	t.Run("unknown agent", func(t *testing.T) {
+		svc, _, _ := newSummaryService(t, &mockSummarizer{summary: "x"})
+		if _, err := svc.Summarize("missing"); err == nil {
+			t.Error("expected error for unknown agent")
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// TemplateDir is the directory under .craizy holding the template worktree new
+// agent worktrees are cloned from.
+const TemplateDir = ".craizy/template"
+
+// RefreshTemplate brings the template worktree up to date with the project's
+// current branch, creating it on first use. It does nothing unless templates
+// are enabled in the worktree options, and returns immediately if a refresh is
+// already running.
+func (s *AgentService) RefreshTemplate() error {
+	logging.Entry("project", s.project)
+	if !s.wtOptions.Template || s.git == nil {
+		return nil
+	}
+	if !s.templateMu.TryLock() {
+		return nil
+	}
+	defer s.templateMu.Unlock()
+
+	baseBranch, err := s.git.CurrentBranch(s.workDir)
+	if err != nil {
+		logging.Error(err, "action", "get current branch")
+		return fmt.Errorf("failed to get current branch: %w", err)
+	}
+
+	// A half-refreshed template would be cloned with the wrong files
+	s.templateReady = false
+	if err := s.git.RefreshTemplate(filepath.Join(s.workDir, TemplateDir), baseBranch); err != nil {
+		logging.Error(err, "baseBranch", baseBranch)
+		return fmt.Errorf("failed to refresh template worktree: %w", err)
+	}
+	s.templateReady = true
+	logging.Info("template worktree refreshed, baseBranch=%s", baseBranch)
+	return nil
+}
+
+// cloneTemplate creates a worktree from the template, reporting whether it did.
+// It leaves nothing behind on failure, so the caller can fall back to a checkout.
+// Clones don't wait on a refresh in progress.
+func (s *AgentService) cloneTemplate(path, branch, baseBranch string) bool {
+	if !s.wtOptions.Template || !s.templateMu.TryRLock() {
+		return false
+	}
+	defer s.templateMu.RUnlock()
+	if !s.templateReady {
+		return false
+	}
+
+	existed := s.git.BranchExists(branch)
+	if err := s.git.CloneWorktree(filepath.Join(s.workDir, TemplateDir), path, branch, baseBranch); err != nil {
+		logging.Error(err, "path", path, "action", "clone template worktree")
+		_ = s.git.RemoveWorktree(path)
+		if !existed {
+			_ = s.git.DeleteBranch(branch)
+		}
+		return false
+	}
+	return true
+}
+
+// HasTemplate reports whether new worktrees are cloned from a template worktree.
+func (s *AgentService) HasTemplate() bool {
+	return s.wtOptions.Template && s.git != nil
+}
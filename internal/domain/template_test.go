@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAgentService_Template(t *testing.T) {
+	newService := func(git *mockGitClient, template bool) *AgentService {
+		svc := NewAgentService(&mockTmuxClient{sessions: map[string]bool{}}, newTestStore(), &mockDispatcher{}, git, "proj", "/tmp")
+		svc.SetWorktreeOptions(WorktreeOptions{Template: template})
+		return svc
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		git := newMockGit()
+		svc := newService(git, false)
+		if err := svc.RefreshTemplate(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := svc.Create("claude", "auth", "claude"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(git.templates) != 0 || len(git.cloned) != 0 {
+			t.Errorf("templates = %v, cloned = %v, want none", git.templates, git.cloned)
+		}
+	})
+
+	t.Run("not refreshed yet", func(t *testing.T) {
+		git := newMockGit()
+		svc := newService(git, true)
+		if _, err := svc.Create("claude", "auth", "claude"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(git.cloned) != 0 || len(git.created) != 1 {
+			t.Errorf("cloned = %v, created = %v, want a checkout", git.cloned, git.created)
+		}
+	})
+
+	t.Run("clones once refreshed", func(t *testing.T) {
+		git := newMockGit()
+		svc := newService(git, true)
+		if err := svc.RefreshTemplate(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(git.templates) != 1 || git.templates[0] != "/tmp/.craizy/template@main" {
+			t.Errorf("templates = %v, want /tmp/.craizy/template@main", git.templates)
+		}
+		if _, err := svc.Create("claude", "auth", "claude"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(git.cloned) != 1 || len(git.created) != 0 {
+			t.Errorf("cloned = %v, created = %v, want a clone", git.cloned, git.created)
+		}
+	})
+
+	t.Run("falls back to a checkout", func(t *testing.T) {
+		git := newMockGit()
+		git.cloneErr = errors.New("cp: unsupported")
+		svc := newService(git, true)
+		_ = svc.RefreshTemplate()
+		if _, err := svc.Create("claude", "auth", "claude"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(git.created) != 1 {
+			t.Errorf("created = %v, want a checkout after the clone failed", git.created)
+		}
+	})
+}
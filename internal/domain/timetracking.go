@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"sort"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// ActiveTime returns the wall-clock time from creation to termination (or now,
+// if still running) minus time spent paused, including any pause under way.
+func (a *Agent) ActiveTime(now time.Time) time.Duration {
+	end := now
+	if a.TerminatedAt != nil {
+		end = *a.TerminatedAt
+	}
+	active := end.Sub(a.CreatedAt) - a.PausedTime
+	if pausedAt, ok := a.PausedAt(); ok {
+		active -= end.Sub(pausedAt)
+	}
+	if active < 0 {
+		return 0
+	}
+	return active
+}
+
+// TypeTime totals tracked time for all agents of one type.
+type TypeTime struct {
+	AgentType string
+	Agents    int
+	Active    time.Duration // summed ActiveTime
+	Attached  time.Duration // summed time the human spent attached
+}
+
+// AverageActive returns the mean active time per agent.
+func (t TypeTime) AverageActive() time.Duration {
+	if t.Agents == 0 {
+		return 0
+	}
+	return t.Active / time.Duration(t.Agents)
+}
+
+// TimeStats totals active and attached time by agent type for every agent the
+// project has run, including terminated ones, sorted by type. Pool agents that
+// were never claimed, queued agents and ones still starting are skipped since
+// they did no work.
+func (s *AgentService) TimeStats(now time.Time) []TypeTime {
+	logging.Entry("project", s.project)
+	byType := make(map[string]*TypeTime)
+	for _, agent := range s.store.List() {
+		if agent.Project != s.project || agent.Status == AgentStatusIdle || agent.Status == AgentStatusPending || agent.Status == AgentStatusStarting {
+			continue
+		}
+		t, ok := byType[agent.AgentType]
+		if !ok {
+			t = &TypeTime{AgentType: agent.AgentType}
+			byType[agent.AgentType] = t
+		}
+		t.Agents++
+		t.Active += agent.ActiveTime(now)
+		t.Attached += agent.AttachedTime
+	}
+
+	stats := make([]TypeTime, 0, len(byType))
+	for _, t := range byType {
+		stats = append(stats, *t)
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].AgentType < stats[j].AgentType })
+	return stats
+}
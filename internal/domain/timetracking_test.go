@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAgent_ActiveTime(t *testing.T) {
+	created := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	now := created.Add(3 * time.Hour)
+
+	t.Run("running", func(t *testing.T) {
+		a := &Agent{CreatedAt: created}
+		if got := a.ActiveTime(now); got != 3*time.Hour {
+			t.Errorf("got %s, want 3h", got)
+		}
+	})
+
+	t.Run("terminated", func(t *testing.T) {
+		terminated := created.Add(time.Hour)
+		a := &Agent{CreatedAt: created, TerminatedAt: &terminated}
+		if got := a.ActiveTime(now); got != time.Hour {
+			t.Errorf("got %s, want 1h", got)
+		}
+	})
+
+	t.Run("minus paused time", func(t *testing.T) {
+		a := &Agent{CreatedAt: created, PausedTime: 2 * time.Hour}
+		if got := a.ActiveTime(now); got != time.Hour {
+			t.Errorf("got %s, want 1h", got)
+		}
+	})
+
+	t.Run("minus the pause under way", func(t *testing.T) {
+		a := &Agent{
+			CreatedAt:  created,
+			Status:     AgentStatusPaused,
+			PausedTime: time.Hour,
+			Metadata:   map[string]string{MetadataPausedAt: created.Add(2 * time.Hour).Format(time.RFC3339)},
+		}
+		if got := a.ActiveTime(now); got != time.Hour {
+			t.Errorf("got %s, want 1h", got)
+		}
+	})
+}
+
+func TestAgentService_TimeStats(t *testing.T) {
+	now := time.Now()
+	terminated := now.Add(-time.Hour)
+	store := newTestStore()
+	store.Add(&Agent{ID: "a", Project: "proj", AgentType: "claude", Status: AgentStatusActive,
+		CreatedAt: now.Add(-2 * time.Hour), AttachedTime: 10 * time.Minute})
+	store.Add(&Agent{ID: "b", Project: "proj", AgentType: "claude", Status: AgentStatusTerminated,
+		CreatedAt: now.Add(-5 * time.Hour), TerminatedAt: &terminated, AttachedTime: 5 * time.Minute})
+	store.Add(&Agent{ID: "c", Project: "proj", AgentType: "codex", Status: AgentStatusActive,
+		CreatedAt: now.Add(-time.Hour)})
+	store.Add(&Agent{ID: "pool", Project: "proj", AgentType: "codex", Status: AgentStatusIdle,
+		CreatedAt: now.Add(-time.Hour)})
+	store.Add(&Agent{ID: "other", Project: "other", AgentType: "claude", Status: AgentStatusActive,
+		CreatedAt: now.Add(-time.Hour)})
+
+	svc := NewAgentService(&mockTmuxClient{}, store, &mockDispatcher{}, nil, "proj", "/tmp")
+	stats := svc.TimeStats(now)
+
+	if len(stats) != 2 {
+		t.Fatalf("got %d types, want 2: %+v", len(stats), stats)
+	}
+	claude := stats[0]
+	if claude.AgentType != "claude" || claude.Agents != 2 {
+		t.Fatalf("unexpected first entry: %+v", claude)
+	}
+	if claude.Active != 6*time.Hour || claude.AverageActive() != 3*time.Hour {
+		t.Errorf("active = %s (avg %s), want 6h (avg 3h)", claude.Active, claude.AverageActive())
+	}
+	if claude.Attached != 15*time.Minute {
+		t.Errorf("attached = %s, want 15m", claude.Attached)
+	}
+	if codex := stats[1]; codex.AgentType != "codex" || codex.Agents != 1 {
+		t.Errorf("unclaimed pool agents should be skipped: %+v", codex)
+	}
+}
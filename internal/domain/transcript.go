@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// TranscriptsDir is the directory under the project root where session output is recorded.
+const TranscriptsDir = ".craizy/transcripts"
+
+// TranscriptPath returns the recorded output file for an agent.
+func TranscriptPath(workDir, agentID string) string {
+	return filepath.Join(workDir, TranscriptsDir, agentID+".log")
+}
+
+// TranscriptLine is one recorded line of session output.
+type TranscriptLine struct {
+	Time time.Time // when the line was recorded (zero if unknown)
+	Text string    // line text with terminal escape codes removed
+}
+
+// FormatTranscriptLine formats a recorded line as "<RFC3339 time>\t<raw text>\n".
+func FormatTranscriptLine(t time.Time, text string) string {
+	return t.Format(time.RFC3339Nano) + "\t" + text + "\n"
+}
+
+// ParseTranscript reads recorded output, stripping terminal escape codes. Lines
+// without a timestamp (e.g. recorded by plain cat) take the previous line's time.
+func ParseTranscript(r io.Reader) ([]TranscriptLine, error) {
+	var lines []TranscriptLine
+	var last time.Time
+
+	reader := bufio.NewReader(r)
+	for {
+		raw, err := reader.ReadString('\n')
+		if raw != "" {
+			raw = strings.TrimSuffix(raw, "\n")
+			if stamp, text, ok := strings.Cut(raw, "\t"); ok {
+				if t, perr := time.Parse(time.RFC3339Nano, stamp); perr == nil {
+					last = t
+					raw = text
+				}
+			}
+			lines = append(lines, TranscriptLine{Time: last, Text: StripANSI(raw)})
+		}
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// ansiPattern matches CSI and OSC escape sequences and other two-byte escapes.
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// StripANSI removes terminal escape codes and control characters from s. Text
+// overwritten by a carriage return is dropped, as a terminal would show it.
+func StripANSI(s string) string {
+	s = ansiPattern.ReplaceAllString(s, "")
+	s = strings.TrimRight(s, "\r")
+	if i := strings.LastIndex(s, "\r"); i >= 0 {
+		s = s[i+1:]
+	}
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// Transcript returns the recorded output of an agent's session. It works for
+// terminated agents too, as long as the recording is still on disk.
+func (s *AgentService) Transcript(agentID string) ([]TranscriptLine, error) {
+	logging.Entry("agentID", agentID)
+	f, err := os.Open(TranscriptPath(s.workDir, agentID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = fmt.Errorf("no transcript recorded for %q", agentID)
+		}
+		logging.Error(err, "agentID", agentID)
+		return nil, err
+	}
+	defer f.Close()
+
+	lines, err := ParseTranscript(f)
+	if err != nil {
+		err = fmt.Errorf("failed to read transcript: %w", err)
+		logging.Error(err, "agentID", agentID)
+		return nil, err
+	}
+	return lines, nil
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStripANSI(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "hello", "hello"},
+		{"color", "\x1b[31mred\x1b[0m text", "red text"},
+		{"cursor movement", "\x1b[2K\x1b[1Gdone", "done"},
+		{"osc title", "\x1b]0;title\x07after", "after"},
+		{"carriage return overwrite", "50%\r100%", "100%"},
+		{"trailing carriage return", "line\r", "line"},
+		{"control characters", "a\x08b\tc", "ab\tc"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripANSI(tt.in); got != tt.want {
+				t.Errorf("StripANSI(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTranscript(t *testing.T) {
+	stamp := time.Date(2026, 10, 17, 9, 30, 0, 0, time.UTC)
+	input := FormatTranscriptLine(stamp, "\x1b[32mok\x1b[0m\r") +
+		"untimed tab\tline\n" +
+		FormatTranscriptLine(stamp.Add(time.Second), "last")
+
+	lines, err := ParseTranscript(strings.NewReader(input))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3", len(lines))
+	}
+	if lines[0].Text != "ok" || !lines[0].Time.Equal(stamp) {
+		t.Errorf("line 0 = %+v", lines[0])
+	}
+	if lines[1].Text != "untimed tab\tline" || !lines[1].Time.Equal(stamp) {
+		t.Errorf("untimed line should keep its text and inherit the previous time, got %+v", lines[1])
+	}
+	if lines[2].Text != "last" || !lines[2].Time.Equal(stamp.Add(time.Second)) {
+		t.Errorf("line 2 = %+v", lines[2])
+	}
+}
@@ -0,0 +1,184 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// waitCaptureLines is how much pane output Match conditions search.
+const waitCaptureLines = 200
+
+// WaitPollInterval is how often WaitFor checks an agent. Tests shorten it.
+var WaitPollInterval = 2 * time.Second
+
+// ErrWaitTimeout is returned by WaitFor when the timeout passes first.
+var ErrWaitTimeout = errors.New("timed out waiting for agent")
+
+// ErrAgentExited is returned by WaitFor when the agent's session ends first,
+// unless the condition waits for exit.
+var ErrAgentExited = errors.New("agent exited")
+
+// WaitCondition describes what WaitFor waits for. The set conditions are
+// alternatives: the first one met ends the wait.
+type WaitCondition struct {
+	Completion bool           // the agent sends a completion message
+	Idle       time.Duration  // the agent produces no output for this long
+	Match      *regexp.Regexp // new pane output matches
+	Exit       bool           // the agent's session ends
+	Timeout    time.Duration  // 0 waits until ctx is done
+}
+
+// IsZero reports whether no condition is set.
+func (c WaitCondition) IsZero() bool {
+	return !c.Completion && c.Idle == 0 && c.Match == nil && !c.Exit
+}
+
+// String describes the condition for progress output.
+func (c WaitCondition) String() string {
+	var parts []string
+	if c.Completion {
+		parts = append(parts, "completion message")
+	}
+	if c.Idle > 0 {
+		parts = append(parts, "idle for "+c.Idle.String())
+	}
+	if c.Match != nil {
+		parts = append(parts, "output matching /"+c.Match.String()+"/")
+	}
+	if c.Exit {
+		parts = append(parts, "exit")
+	}
+	return strings.Join(parts, " or ")
+}
+
+// WaitResult is the condition that ended a wait.
+type WaitResult string
+
+const (
+	WaitCompleted WaitResult = "completion"
+	WaitIdle      WaitResult = "idle"
+	WaitMatched   WaitResult = "match"
+	WaitExited    WaitResult = "exit"
+)
+
+// WaitFor blocks until an agent meets cond, polling every WaitPollInterval.
+// Only activity after since counts, so a completion message or output from
+// before a prompt was sent doesn't end the wait early.
+func (s *AgentService) WaitFor(ctx context.Context, agentID string, cond WaitCondition, since time.Time) (WaitResult, error) {
+	logging.Entry("agentID", agentID, "condition", cond.String(), "timeout", cond.Timeout)
+	if cond.IsZero() {
+		return "", fmt.Errorf("no wait condition given")
+	}
+	if cond.Completion && s.messageSvc == nil {
+		return "", fmt.Errorf("message service not available")
+	}
+	if cond.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cond.Timeout)
+		defer cancel()
+	}
+
+	var baseline string
+	if cond.Match != nil {
+		baseline, _ = s.tmux.CapturePaneOutput(agentID, waitCaptureLines)
+	}
+
+	ticker := time.NewTicker(WaitPollInterval)
+	defer ticker.Stop()
+	for {
+		result, err := s.checkWait(agentID, cond, since, baseline)
+		if err != nil || result != "" {
+			if result != "" {
+				logging.Info("wait finished, agentID=%s, result=%s", agentID, result)
+			}
+			return result, err
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return "", fmt.Errorf("%w after %s", ErrWaitTimeout, cond.Timeout)
+			}
+			return "", ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkWait returns the condition an agent meets now, or "" if none.
+func (s *AgentService) checkWait(agentID string, cond WaitCondition, since time.Time, baseline string) (WaitResult, error) {
+	if cond.Completion && s.completedSince(agentID, since) {
+		return WaitCompleted, nil
+	}
+	if !s.tmux.SessionExists(agentID) {
+		if cond.Exit {
+			return WaitExited, nil
+		}
+		return "", ErrAgentExited
+	}
+	if cond.Idle > 0 {
+		if last, err := s.tmux.LastActivity(agentID); err == nil {
+			if last.Before(since) {
+				last = since
+			}
+			if time.Since(last) >= cond.Idle {
+				return WaitIdle, nil
+			}
+		}
+	}
+	if cond.Match != nil {
+		output, err := s.tmux.CapturePaneOutput(agentID, waitCaptureLines)
+		if err == nil && cond.Match.MatchString(newOutput(baseline, output)) {
+			return WaitMatched, nil
+		}
+	}
+	return "", nil
+}
+
+// completedSince reports whether the agent has sent a completion message since since.
+func (s *AgentService) completedSince(agentID string, since time.Time) bool {
+	messages, err := s.messageSvc.Thread(agentID)
+	if err != nil {
+		logging.Error(err, "agentID", agentID, "action", "list messages")
+		return false
+	}
+	for _, msg := range messages {
+		if msg.From == agentID && msg.Type == MessageTypeCompletion && !msg.CreatedAt.Before(since) {
+			return true
+		}
+	}
+	return false
+}
+
+// newOutput returns the part of a pane capture that wasn't in an earlier
+// capture. The pane scrolls, so the new capture starts with some tail of the
+// old one; everything after the longest such overlap is new.
+func newOutput(before, after string) string {
+	old := strings.Split(strings.TrimRight(before, "\n"), "\n")
+	cur := strings.Split(strings.TrimRight(after, "\n"), "\n")
+	for k := min(len(old), len(cur)); k > 0; k-- {
+		if equalLines(old[len(old)-k:], cur[:k]) {
+			return strings.Join(cur[k:], "\n")
+		}
+	}
+	return after
+}
+
+// equalLines reports whether two line slices are identical.
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAgentService_WaitFor(t *testing.T) {
+	defer func(interval time.Duration) { WaitPollInterval = interval }(WaitPollInterval)
+	WaitPollInterval = 5 * time.Millisecond
+
+	newWaitService := func() (*AgentService, *mockTmuxClient, *mockMessageStore) {
+		store := newTestStore()
+		store.Add(&Agent{ID: "agent-1", Name: "agent-1", Status: AgentStatusActive})
+		tmux := &mockTmuxClient{sessions: map[string]bool{"agent-1": true}, activity: make(map[string]time.Time)}
+		messages := newMockMessageStore()
+		svc := NewAgentService(tmux, store, &mockDispatcher{}, nil, "proj", "/tmp")
+		svc.SetMessageService(NewMessageService(messages, tmux, store))
+		return svc, tmux, messages
+	}
+
+	t.Run("completion message since the wait began", func(t *testing.T) {
+		svc, _, messages := newWaitService()
+		since := time.Now()
+		old := NewMessage("agent-1", HumanParticipantID, MessageTypeCompletion, "old work", nil)
+		old.CreatedAt = since.Add(-time.Minute)
+		_ = messages.Save(old)
+
+		_, err := svc.WaitFor(context.Background(), "agent-1", WaitCondition{Completion: true, Timeout: 30 * time.Millisecond}, since)
+		if !errors.Is(err, ErrWaitTimeout) {
+			t.Fatalf("an earlier completion should not count, got %v", err)
+		}
+
+		_ = messages.Save(NewMessage("agent-1", HumanParticipantID, MessageTypeCompletion, "done", nil))
+		result, err := svc.WaitFor(context.Background(), "agent-1", WaitCondition{Completion: true}, since)
+		if err != nil || result != WaitCompleted {
+			t.Errorf("WaitFor = %q, %v, want completion", result, err)
+		}
+	})
+
+	t.Run("idle measured from when the wait began", func(t *testing.T) {
+		svc, tmux, _ := newWaitService()
+		tmux.activity["agent-1"] = time.Now().Add(-time.Hour)
+
+		started := time.Now()
+		result, err := svc.WaitFor(context.Background(), "agent-1", WaitCondition{Idle: 20 * time.Millisecond}, started)
+
+		if err != nil || result != WaitIdle {
+			t.Fatalf("WaitFor = %q, %v, want idle", result, err)
+		}
+		if elapsed := time.Since(started); elapsed < 20*time.Millisecond {
+			t.Errorf("returned after %s, before the idle period", elapsed)
+		}
+	})
+
+	t.Run("agent exits", func(t *testing.T) {
+		svc, tmux, _ := newWaitService()
+		delete(tmux.sessions, "agent-1")
+
+		_, err := svc.WaitFor(context.Background(), "agent-1", WaitCondition{Idle: time.Hour}, time.Now())
+
+		if !errors.Is(err, ErrAgentExited) {
+			t.Errorf("got %v, want ErrAgentExited", err)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		svc, _, _ := newWaitService()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := svc.WaitFor(ctx, "agent-1", WaitCondition{Idle: time.Hour}, time.Now())
+
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("got %v, want context.Canceled", err)
+		}
+	})
+
+	t.Run("requires a condition", func(t *testing.T) {
+		svc, _, _ := newWaitService()
+		if _, err := svc.WaitFor(context.Background(), "agent-1", WaitCondition{Timeout: time.Second}, time.Now()); err == nil {
+			t.Error("expected an error without a condition")
+		}
+	})
+}
+
+func TestWaitCondition_String(t *testing.T) {
+	cond := WaitCondition{Completion: true, Idle: 2 * time.Minute, Match: regexp.MustCompile("PASS")}
+	if got := cond.String(); got != "completion message or idle for 2m0s or output matching /PASS/" {
+		t.Errorf("String = %q", got)
+	}
+}
+
+func TestNewOutput(t *testing.T) {
+	tests := []struct {
+		name, before, after, want string
+	}{
+		{"unchanged", "a\nb\n", "a\nb\n", ""},
+		{"appended", "a\nb", "a\nb\nc", "c"},
+		{"scrolled", "a\nb\nc", "b\nc\nd\ne", "d\ne"},
+		{"no overlap", "a\nb", "x\ny", "x\ny"},
+		{"empty before", "", "PASS", "PASS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newOutput(tt.before, tt.after); strings.TrimSpace(got) != tt.want {
+				t.Errorf("newOutput(%q, %q) = %q, want %q", tt.before, tt.after, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAgentService_WaitFor_Exit(t *testing.T) {
+	store := newTestStore()
+	store.Add(&Agent{ID: "agent-1", Name: "agent-1", Status: AgentStatusActive})
+	tmux := &mockTmuxClient{sessions: make(map[string]bool)}
+	svc := NewAgentService(tmux, store, &mockDispatcher{}, nil, "proj", "/tmp")
+
+	result, err := svc.WaitFor(context.Background(), "agent-1", WaitCondition{Idle: time.Hour, Exit: true}, time.Now())
+
+	if err != nil || result != WaitExited {
+		t.Errorf("WaitFor = %q, %v, want exit", result, err)
+	}
+}
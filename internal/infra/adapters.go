@@ -1,6 +1,8 @@
 package infra
 
 import (
+	"os"
+
 	"github.com/TechnicallyShaun/crAIzy/internal/domain"
 	"github.com/TechnicallyShaun/crAIzy/internal/logging"
 )
@@ -15,7 +17,7 @@ func WireAdapters(dispatcher domain.IEventDispatcher, store domain.IAgentStore,
 		logging.Info("handling agent.created event, agentID=%s", event.Agent.ID)
 
 		// Create tmux session first
-		if err := tmux.CreateSession(event.Agent.ID, event.Agent.Command, event.Agent.WorkDir); err != nil {
+		if err := tmux.CreateSession(event.Agent.ID, event.Agent.Command, event.Agent.SessionDir(), event.Agent.Env); err != nil {
 			logging.Error(err, "agentID", event.Agent.ID, "action", "tmux.CreateSession")
 			// Clean up worktree if tmux creation failed
 			if git != nil && event.Agent.Branch != "" {
@@ -36,10 +38,52 @@ func WireAdapters(dispatcher domain.IEventDispatcher, store domain.IAgentStore,
 				_ = git.RemoveWorktree(event.Agent.WorkDir)
 				_ = git.DeleteBranch(event.Agent.Branch)
 			}
+			return
+		}
+
+		// Lock the worktree so an external prune can't remove it while the agent runs
+		if git != nil && event.Agent.Branch != "" {
+			if err := git.LockWorktree(event.Agent.WorkDir, "in use by crAIzy agent "+event.Agent.ID); err != nil {
+				logging.Error(err, "agentID", event.Agent.ID, "action", "git.LockWorktree")
+			}
 		}
 		logging.Info("agent.created event handled successfully, agentID=%s", event.Agent.ID)
 	})
 
+	// Handle agent queued - store it until its provider pool has room
+	dispatcher.Subscribe("agent.queued", func(e domain.Event) {
+		event := e.(domain.AgentQueued)
+		logging.Info("handling agent.queued event, agentID=%s", event.Agent.ID)
+		if err := store.Add(event.Agent); err != nil {
+			logging.Error(err, "agentID", event.Agent.ID, "action", "store.Add")
+		}
+	})
+
+	// Handle agent starting - store it so it's listed while its worktree is prepared
+	dispatcher.Subscribe("agent.starting", func(e domain.Event) {
+		event := e.(domain.AgentStarting)
+		logging.Info("handling agent.starting event, agentID=%s", event.Agent.ID)
+		if err := store.Add(event.Agent); err != nil {
+			logging.Error(err, "agentID", event.Agent.ID, "action", "store.Add")
+		}
+	})
+
+	// Handle warm pool agent claimed - rename tmux session and replace the stored record
+	dispatcher.Subscribe("agent.claimed", func(e domain.Event) {
+		event := e.(domain.AgentClaimed)
+		logging.Info("handling agent.claimed event, oldID=%s, agentID=%s", event.OldID, event.Agent.ID)
+
+		if err := tmux.RenameSession(event.OldID, event.Agent.ID); err != nil {
+			logging.Error(err, "agentID", event.OldID, "action", "tmux.RenameSession")
+		}
+		if err := store.Remove(event.OldID); err != nil {
+			logging.Error(err, "agentID", event.OldID, "action", "store.Remove")
+		}
+		if err := store.Add(event.Agent); err != nil {
+			logging.Error(err, "agentID", event.Agent.ID, "action", "store.Add")
+		}
+	})
+
 	// Handle agent killed - kill tmux, clean up git, and update status
 	dispatcher.Subscribe("agent.killed", func(e domain.Event) {
 		event := e.(domain.AgentKilled)
@@ -54,6 +98,9 @@ func WireAdapters(dispatcher domain.IEventDispatcher, store domain.IAgentStore,
 		if agent != nil && git != nil && agent.Branch != "" {
 			// Remove worktree and delete branch
 			logging.Info("cleaning up git worktree and branch, branch=%s", agent.Branch)
+			if err := git.UnlockWorktree(agent.WorkDir); err != nil {
+				logging.Error(err, "workDir", agent.WorkDir, "action", "git.UnlockWorktree")
+			}
 			if err := git.RemoveWorktree(agent.WorkDir); err != nil {
 				logging.Error(err, "workDir", agent.WorkDir, "action", "git.RemoveWorktree")
 			}
@@ -68,3 +115,52 @@ func WireAdapters(dispatcher domain.IEventDispatcher, store domain.IAgentStore,
 		logging.Info("agent.killed event handled successfully, agentID=%s", event.AgentID)
 	})
 }
+
+// WireMergeAdapters connects merge events to the merge history store.
+func WireMergeAdapters(dispatcher domain.IEventDispatcher, merges domain.IMergeStore) {
+	logging.Entry()
+
+	record := func(r *domain.MergeRecord) {
+		if err := merges.Save(r); err != nil {
+			logging.Error(err, "agentID", r.AgentID, "action", "merges.Save")
+		}
+	}
+
+	dispatcher.Subscribe("merge.completed", func(e domain.Event) {
+		event := e.(domain.MergeCompleted)
+		logging.Info("handling merge.completed event, agentID=%s", event.Record.AgentID)
+		record(event.Record)
+	})
+
+	dispatcher.Subscribe("merge.failed", func(e domain.Event) {
+		event := e.(domain.MergeFailed)
+		logging.Info("handling merge.failed event, agentID=%s", event.Record.AgentID)
+		record(event.Record)
+	})
+}
+
+// WireTranscriptAdapters records the output of every new agent session under workDir,
+// keeping the recording with the agent when a warm pool session is claimed.
+func WireTranscriptAdapters(dispatcher domain.IEventDispatcher, tmux domain.ITmuxClient, workDir string) {
+	logging.Entry("workDir", workDir)
+
+	dispatcher.Subscribe("agent.created", func(e domain.Event) {
+		event := e.(domain.AgentCreated)
+		// Session creation may have failed in an earlier handler
+		if !tmux.SessionExists(event.Agent.ID) {
+			return
+		}
+		if err := tmux.PipeOutput(event.Agent.ID, domain.TranscriptPath(workDir, event.Agent.ID)); err != nil {
+			logging.Error(err, "agentID", event.Agent.ID, "action", "tmux.PipeOutput")
+		}
+	})
+
+	dispatcher.Subscribe("agent.claimed", func(e domain.Event) {
+		event := e.(domain.AgentClaimed)
+		// The recorder keeps its open file across the rename
+		oldPath := domain.TranscriptPath(workDir, event.OldID)
+		if err := os.Rename(oldPath, domain.TranscriptPath(workDir, event.Agent.ID)); err != nil && !os.IsNotExist(err) {
+			logging.Error(err, "agentID", event.Agent.ID, "action", "rename transcript")
+		}
+	})
+}
@@ -2,7 +2,9 @@ package infra
 
 import (
 	"errors"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -22,7 +24,7 @@ func newMockTmux() *mockTmuxClient {
 	return &mockTmuxClient{sessions: make(map[string]bool)}
 }
 
-func (m *mockTmuxClient) CreateSession(id, command, workDir string) error {
+func (m *mockTmuxClient) CreateSession(id, command, workDir string, env map[string]string) error {
 	m.createCallCount++
 	if m.createErr != nil {
 		return m.createErr
@@ -52,6 +54,10 @@ func (m *mockTmuxClient) AttachCmd(id string) *exec.Cmd {
 	return exec.Command("echo", "attach", id)
 }
 
+func (m *mockTmuxClient) AttachReadOnlyCmd(id string) *exec.Cmd {
+	return exec.Command("echo", "attach", "-r", id)
+}
+
 func (m *mockTmuxClient) SessionExists(id string) bool {
 	return m.sessions[id]
 }
@@ -64,6 +70,32 @@ func (m *mockTmuxClient) SendKeys(sessionID, text string) error {
 	return nil
 }
 
+func (m *mockTmuxClient) RenameSession(oldID, newID string) error {
+	delete(m.sessions, oldID)
+	m.sessions[newID] = true
+	return nil
+}
+
+func (m *mockTmuxClient) PipeOutput(sessionID, path string) error {
+	return nil
+}
+
+func (m *mockTmuxClient) ExitStatus(id string) (int, bool) {
+	return 0, false
+}
+
+func (m *mockTmuxClient) PauseSession(id string) error {
+	return nil
+}
+
+func (m *mockTmuxClient) ResumeSession(id string) error {
+	return nil
+}
+
+func (m *mockTmuxClient) LastActivity(sessionID string) (time.Time, error) {
+	return time.Now(), nil
+}
+
 func TestWireAdapters_AgentCreated(t *testing.T) {
 	t.Run("creates tmux session and stores agent", func(t *testing.T) {
 		dispatcher := NewEventDispatcher()
@@ -160,3 +192,88 @@ func TestWireAdapters_AgentKilled(t *testing.T) {
 		}
 	})
 }
+
+func TestWireMergeAdapters(t *testing.T) {
+	t.Run("records completed and failed merges", func(t *testing.T) {
+		dispatcher := NewEventDispatcher()
+		merges := NewMemoryMergeStore()
+
+		WireMergeAdapters(dispatcher, merges)
+
+		dispatcher.Publish(domain.MergeCompleted{
+			Record:    &domain.MergeRecord{ID: "m1", AgentID: "test-agent", Outcome: domain.MergeOutcomeSuccess, CreatedAt: time.Now()},
+			Timestamp: time.Now(),
+		})
+		dispatcher.Publish(domain.MergeFailed{
+			Record:    &domain.MergeRecord{ID: "m2", AgentID: "test-agent", Outcome: domain.MergeOutcomeConflict, CreatedAt: time.Now()},
+			Timestamp: time.Now(),
+		})
+
+		records, _ := merges.ListByAgent("test-agent")
+		if len(records) != 2 {
+			t.Errorf("got %d merge records, want 2", len(records))
+		}
+	})
+}
+
+func TestWireAdapters_WorktreeLocking(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	git := NewGitClient(repoDir)
+	baseBranch, _ := git.CurrentBranch(repoDir)
+	worktreePath := filepath.Join(repoDir, ".craizy", "worktrees", "agent")
+	if err := git.CreateWorktree(worktreePath, "agent-branch", baseBranch); err != nil {
+		t.Fatalf("failed to create worktree: %v", err)
+	}
+
+	dispatcher := NewEventDispatcher()
+	store := NewMemoryAgentStore()
+	tmux := newMockTmux()
+	WireAdapters(dispatcher, store, tmux, git)
+
+	dispatcher.Publish(domain.AgentCreated{
+		Agent: &domain.Agent{
+			ID:        "test-agent",
+			Branch:    "agent-branch",
+			WorkDir:   worktreePath,
+			Status:    domain.AgentStatusActive,
+			CreatedAt: time.Now(),
+		},
+		Timestamp: time.Now(),
+	})
+
+	worktrees, _ := git.ListWorktrees()
+	if len(worktrees) != 2 || !worktrees[1].Locked {
+		t.Fatalf("worktree should be locked after creation, got %+v", worktrees)
+	}
+
+	dispatcher.Publish(domain.AgentKilled{AgentID: "test-agent", Timestamp: time.Now()})
+
+	if _, err := os.Stat(worktreePath); !os.IsNotExist(err) {
+		t.Error("worktree should be removed after kill")
+	}
+}
+
+func TestWireAdapters_AgentClaimed(t *testing.T) {
+	dispatcher := NewEventDispatcher()
+	store := NewMemoryAgentStore()
+	tmux := newMockTmux()
+	WireAdapters(dispatcher, store, tmux, nil)
+
+	_ = store.Add(&domain.Agent{ID: "pool-agent", Status: domain.AgentStatusIdle, CreatedAt: time.Now()})
+	tmux.sessions["pool-agent"] = true
+
+	dispatcher.Publish(domain.AgentClaimed{
+		OldID:     "pool-agent",
+		Agent:     &domain.Agent{ID: "task-agent", Status: domain.AgentStatusActive, CreatedAt: time.Now()},
+		Timestamp: time.Now(),
+	})
+
+	if store.Exists("pool-agent") || !store.Exists("task-agent") {
+		t.Error("stored record should be replaced with the claimed agent")
+	}
+	if tmux.sessions["pool-agent"] || !tmux.sessions["task-agent"] {
+		t.Error("tmux session should be renamed")
+	}
+}
@@ -0,0 +1,36 @@
+package infra
+
+import (
+	"strings"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// sessionColorer is implemented by session backends whose status bars can be
+// colored per session, such as TmuxClient.
+type sessionColorer interface {
+	SetSessionColor(id, color string) error
+}
+
+// WireAgentColors colors the status bar of new agent sessions by agent type,
+// from colors keyed by lowercased type name. Wire it after WireAdapters so the
+// session exists. Backends without status bars are left alone.
+func WireAgentColors(dispatcher domain.IEventDispatcher, tmux domain.ITmuxClient, colors map[string]string) {
+	logging.Entry("colors", len(colors))
+
+	colorer, ok := tmux.(sessionColorer)
+	if !ok || len(colors) == 0 {
+		return
+	}
+	dispatcher.Subscribe("agent.created", func(e domain.Event) {
+		agent := e.(domain.AgentCreated).Agent
+		color, ok := colors[strings.ToLower(agent.AgentType)]
+		if !ok {
+			return
+		}
+		if err := colorer.SetSessionColor(agent.ID, color); err != nil {
+			logging.Error(err, "agentID", agent.ID, "action", "color session")
+		}
+	})
+}
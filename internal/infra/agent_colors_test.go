@@ -0,0 +1,36 @@
+package infra
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// colorTmux is a mockTmuxClient that records session colors.
+type colorTmux struct {
+	*mockTmuxClient
+	colors map[string]string
+}
+
+func (c *colorTmux) SetSessionColor(id, color string) error {
+	c.colors[id] = color
+	return nil
+}
+
+func TestWireAgentColors(t *testing.T) {
+	dispatcher := NewEventDispatcher()
+	tmux := &colorTmux{mockTmuxClient: newMockTmux(), colors: make(map[string]string)}
+	WireAgentColors(dispatcher, tmux, map[string]string{"claude": "#D08770"})
+
+	for _, agent := range []*domain.Agent{
+		{ID: "craizy-proj-claude-a", AgentType: "Claude"},
+		{ID: "craizy-proj-aider-b", AgentType: "Aider"},
+	} {
+		dispatcher.Publish(domain.AgentCreated{Agent: agent, Timestamp: time.Now()})
+	}
+
+	if len(tmux.colors) != 1 || tmux.colors["craizy-proj-claude-a"] != "#D08770" {
+		t.Errorf("session colors = %v, want only the Claude session colored", tmux.colors)
+	}
+}
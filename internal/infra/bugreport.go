@@ -0,0 +1,104 @@
+package infra
+
+import (
+	"archive/zip"
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// redactPatterns match secrets that may appear in config, logs, and events.
+var redactPatterns = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	// Authorization: Bearer <token>
+	{regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/-]+=*`), "${1}<redacted>"},
+	// KEY=value and key: value where the name looks secret
+	{regexp.MustCompile(`(?i)\b([A-Za-z0-9_-]*(?:key|token|secret|password|passwd|credential|auth)[A-Za-z0-9_-]*)(["']?\s*[=:]\s*)(["']?)[^\s"',;]+`), "${1}${2}${3}<redacted>"},
+	// --token value
+	{regexp.MustCompile(`(?i)(--[A-Za-z0-9-]*(?:key|token|secret|password)[A-Za-z0-9-]*\s+)[^\s-]\S*`), "${1}<redacted>"},
+	// Provider API keys and tokens with well-known prefixes
+	{regexp.MustCompile(`\b(?:sk-[A-Za-z0-9_-]{16,}|gh[pousr]_[A-Za-z0-9]{20,}|github_pat_[A-Za-z0-9_]{20,}|xox[abpr]-[A-Za-z0-9-]{10,}|AKIA[0-9A-Z]{16})`), "<redacted>"},
+	// Credentials embedded in URLs
+	{regexp.MustCompile(`(://[^/\s:@]+:)[^/\s@]+@`), "${1}<redacted>@"},
+}
+
+// Redact replaces secret-looking values in text with <redacted>.
+func Redact(text string) string {
+	for _, p := range redactPatterns {
+		text = p.re.ReplaceAllString(text, p.replacement)
+	}
+	return text
+}
+
+// BugReport writes a zip of diagnostic files, redacting secrets from each.
+type BugReport struct {
+	zw *zip.Writer
+}
+
+// NewBugReport creates a bug report that writes its zip to w.
+func NewBugReport(w io.Writer) *BugReport {
+	return &BugReport{zw: zip.NewWriter(w)}
+}
+
+// AddText adds a file with the given contents.
+func (b *BugReport) AddText(name, text string) error {
+	f, err := b.zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: time.Now()})
+	if err != nil {
+		return fmt.Errorf("failed to add %s to bug report: %w", name, err)
+	}
+	if _, err := io.WriteString(f, Redact(text)); err != nil {
+		return fmt.Errorf("failed to write %s to bug report: %w", name, err)
+	}
+	return nil
+}
+
+// AddFile adds the last maxLines lines of the file at path (0 = all lines).
+// Missing files are skipped.
+func (b *BugReport) AddFile(name, path string, maxLines int) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer f.Close()
+
+	lines, err := tailLines(f, maxLines)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return b.AddText(name, strings.Join(lines, ""))
+}
+
+// Close finishes the zip.
+func (b *BugReport) Close() error {
+	return b.zw.Close()
+}
+
+// tailLines returns the last max lines of r, each with its newline (0 = all lines).
+func tailLines(r io.Reader, max int) ([]string, error) {
+	var lines []string
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			lines = append(lines, line)
+			if max > 0 && len(lines) > max {
+				lines = lines[1:]
+			}
+		}
+		if err == io.EOF {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// SummarizerTimeout bounds how long a summarizer command may run.
+const SummarizerTimeout = 2 * time.Minute
+
+// CommandSummarizer implements domain.ISummarizer by running a shell command that
+// reads the agent context on stdin and prints a summary on stdout. The command can
+// be a script or a non-interactive agent CLI, e.g. `claude -p "Summarize this in two sentences"`.
+type CommandSummarizer struct {
+	command string
+	dir     string
+}
+
+// NewCommandSummarizer creates a summarizer that runs command in dir.
+func NewCommandSummarizer(command, dir string) *CommandSummarizer {
+	return &CommandSummarizer{command: command, dir: dir}
+}
+
+// Summarize runs the command with input on stdin and returns its trimmed output.
+// Command: sh -c {command}
+func (c *CommandSummarizer) Summarize(input string) (string, error) {
+	logging.Entry("command", c.command, "inputLen", len(input))
+	ctx, cancel := context.WithTimeout(context.Background(), SummarizerTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", c.command)
+	cmd.Dir = c.dir
+	cmd.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		err = fmt.Errorf("summarizer command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		logging.Error(err, "command", c.command)
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,25 @@
+package infra
+
+import "testing"
+
+func TestCommandSummarizer(t *testing.T) {
+	t.Run("returns trimmed output", func(t *testing.T) {
+		s := NewCommandSummarizer("tr a-z A-Z", t.TempDir())
+
+		summary, err := s.Summarize("done\n")
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if summary != "DONE" {
+			t.Errorf("got %q, want DONE", summary)
+		}
+	})
+
+	t.Run("failing command", func(t *testing.T) {
+		s := NewCommandSummarizer("echo nope >&2; exit 1", t.TempDir())
+		if _, err := s.Summarize(""); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
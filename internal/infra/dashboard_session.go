@@ -0,0 +1,71 @@
+package infra
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// SwitchClient switches the tmux client this process runs in to a session.
+// Command: tmux switch-client -t {id}
+func (t *TmuxClient) SwitchClient(id string) error {
+	logging.Entry("id", id)
+	if output, err := t.watchdog.CombinedOutput(exec.Command("tmux", "switch-client", "-t", id)); err != nil {
+		err = fmt.Errorf("tmux switch-client failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "id", id)
+		return err
+	}
+	return nil
+}
+
+// InDashboardSession reports whether this process runs inside a dashboard's
+// tmux session.
+func (t *TmuxClient) InDashboardSession() bool {
+	if os.Getenv("TMUX") == "" {
+		return false
+	}
+	output, err := t.watchdog.Output(exec.Command("tmux", "display-message", "-p", "#{session_name}"))
+	if err != nil {
+		logging.Error(err, "action", "read current tmux session")
+		return false
+	}
+	return domain.IsDashboardSession(strings.TrimSpace(string(output)))
+}
+
+// StartDashboard starts the dashboard session id running the craizy binary at
+// exe with args in workDir, unless the session is already running. The
+// session's window takes its name from the dashboard's terminal title.
+func (t *TmuxClient) StartDashboard(id, exe string, args []string, workDir string) error {
+	logging.Entry("id", id, "args", args, "workDir", workDir)
+	if t.SessionExists(id) {
+		logging.Info("dashboard session already running, id=%s", id)
+		return nil
+	}
+	words := []string{shellQuote(exe)}
+	for _, arg := range args {
+		words = append(words, shellQuote(arg))
+	}
+	if err := t.CreateSession(id, strings.Join(words, " "), workDir, nil); err != nil {
+		return err
+	}
+	// Name the window after the pane title, which the dashboard sets to its
+	// project and counts, so they show in tmux's window switcher
+	cmd := exec.Command("tmux", "set-option", "-w", "-t", id, "automatic-rename-format", "#{pane_title}")
+	if output, err := t.watchdog.CombinedOutput(cmd); err != nil {
+		logging.Error(fmt.Errorf("tmux set-option failed: %w: %s", err, strings.TrimSpace(string(output))), "id", id)
+	}
+	return nil
+}
+
+// DashboardAttachCmd returns a command that takes the terminal to the dashboard
+// session id: switching the client when run inside tmux, attaching otherwise.
+func (t *TmuxClient) DashboardAttachCmd(id string) *exec.Cmd {
+	if os.Getenv("TMUX") != "" {
+		return exec.Command("tmux", "switch-client", "-t", id)
+	}
+	return exec.Command("tmux", "attach", "-t", id)
+}
@@ -0,0 +1,138 @@
+package infra
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// WebhookTimeout bounds how long a webhook may take to accept a message.
+const WebhookTimeout = 10 * time.Second
+
+// FileTransport implements domain.IDeliveryTransport by appending messages to an
+// inbox file in the agent's worktree, for agents that treat text typed into
+// their session as part of the prompt they're writing.
+type FileTransport struct {
+	path  string             // relative to the worktree, {id} is the session ID
+	nudge domain.ITmuxClient // optional, see SetNudge
+}
+
+// NewFileTransport creates a transport appending to path in each worktree.
+func NewFileTransport(path string) *FileTransport {
+	return &FileTransport{path: path}
+}
+
+// SetNudge types a one-line notice pointing at the inbox into the agent's
+// session after each delivery, and types nudges there instead of appending them.
+func (t *FileTransport) SetNudge(tmux domain.ITmuxClient) {
+	t.nudge = tmux
+}
+
+// Name describes the transport in logs.
+func (t *FileTransport) Name() string {
+	return "file " + t.path
+}
+
+// Deliver appends text to the agent's inbox file, creating it if needed, and
+// nudges the agent.
+func (t *FileTransport) Deliver(agent *domain.Agent, text string) error {
+	logging.Entry("agentID", agent.ID, "path", t.path)
+	path, err := t.appendInbox(agent, text)
+	if err != nil {
+		return err
+	}
+	if t.nudge != nil {
+		if err := t.nudge.SendKeys(agent.ID, "New message in "+path); err != nil {
+			return fmt.Errorf("failed to nudge agent: %w", err)
+		}
+	}
+	return nil
+}
+
+// Nudge types text into the agent's session, so it isn't told about the
+// notice as well, or appends it to the inbox without a session to type into.
+func (t *FileTransport) Nudge(agent *domain.Agent, text string) error {
+	logging.Entry("agentID", agent.ID, "path", t.path)
+	if t.nudge != nil {
+		return t.nudge.SendKeys(agent.ID, text)
+	}
+	_, err := t.appendInbox(agent, text)
+	return err
+}
+
+// appendInbox appends text to the agent's inbox file, creating it if needed,
+// and returns the file's path.
+func (t *FileTransport) appendInbox(agent *domain.Agent, text string) (string, error) {
+	path := filepath.Join(agent.WorkDir, strings.ReplaceAll(t.path, "{id}", agent.ID))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return "", fmt.Errorf("failed to open inbox: %w", err)
+	}
+	defer f.Close()
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	if _, err := f.WriteString(text); err != nil {
+		return "", fmt.Errorf("failed to write inbox: %w", err)
+	}
+	return path, nil
+}
+
+// WebhookTransport implements domain.IDeliveryTransport by POSTing each message
+// to a URL as JSON, for agents with an API rather than a terminal.
+type WebhookTransport struct {
+	url    string
+	client *http.Client
+}
+
+// webhookMessage is the body POSTed by WebhookTransport.
+type webhookMessage struct {
+	Agent string `json:"agent"`
+	Name  string `json:"name"`
+	Text  string `json:"text"`
+}
+
+// NewWebhookTransport creates a transport POSTing to url.
+func NewWebhookTransport(url string) *WebhookTransport {
+	return &WebhookTransport{url: url, client: &http.Client{Timeout: WebhookTimeout}}
+}
+
+// Name describes the transport in logs.
+func (t *WebhookTransport) Name() string {
+	return "webhook " + t.url
+}
+
+// Nudge POSTs text to the webhook like a message, as it is the only way to
+// reach the agent.
+func (t *WebhookTransport) Nudge(agent *domain.Agent, text string) error {
+	return t.Deliver(agent, text)
+}
+
+// Deliver POSTs text to the webhook, failing unless it responds with 2xx.
+func (t *WebhookTransport) Deliver(agent *domain.Agent, text string) error {
+	logging.Entry("agentID", agent.ID, "url", t.url)
+	body, err := json.Marshal(webhookMessage{Agent: agent.ID, Name: agent.Name, Text: text})
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+	resp, err := t.client.Post(t.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to post message: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+	return nil
+}
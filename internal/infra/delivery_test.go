@@ -0,0 +1,99 @@
+package infra
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestFileTransport_Deliver(t *testing.T) {
+	agent := &domain.Agent{ID: "craizy-proj-aider-alpha", WorkDir: t.TempDir()}
+	tmux := NewFakeTmuxClient()
+	if err := tmux.CreateSession(agent.ID, "aider", agent.WorkDir, nil); err != nil {
+		t.Fatal(err)
+	}
+	transport := NewFileTransport(".craizy/inbox/{id}.md")
+	transport.SetNudge(tmux)
+
+	if err := transport.Deliver(agent, "first"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := transport.Deliver(agent, "second\n"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	inbox := filepath.Join(agent.WorkDir, ".craizy", "inbox", agent.ID+".md")
+	data, err := os.ReadFile(inbox)
+	if err != nil {
+		t.Fatalf("failed to read inbox: %v", err)
+	}
+	if string(data) != "first\nsecond\n" {
+		t.Errorf("inbox = %q, want both messages appended", data)
+	}
+	pane, _ := tmux.CapturePaneOutput(agent.ID, 0)
+	if strings.Count(pane, "New message in "+inbox) != 2 || strings.Contains(pane, "first") {
+		t.Errorf("pane = %q, want a nudge per message and no message text", pane)
+	}
+}
+
+func TestFileTransport_Nudge(t *testing.T) {
+	agent := &domain.Agent{ID: "craizy-proj-aider-alpha", WorkDir: t.TempDir()}
+	inbox := filepath.Join(agent.WorkDir, "inbox.md")
+	tmux := NewFakeTmuxClient()
+	if err := tmux.CreateSession(agent.ID, "aider", agent.WorkDir, nil); err != nil {
+		t.Fatal(err)
+	}
+	transport := NewFileTransport("inbox.md")
+	transport.SetNudge(tmux)
+
+	if err := transport.Nudge(agent, "You have 1 new message"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pane, _ := tmux.CapturePaneOutput(agent.ID, 0)
+	if strings.Count(pane, "You have 1 new message") != 1 || strings.Contains(pane, "New message in") {
+		t.Errorf("pane = %q, want the nudge typed once", pane)
+	}
+	if _, err := os.Stat(inbox); !os.IsNotExist(err) {
+		t.Errorf("expected no inbox file for a nudge, got %v", err)
+	}
+
+	// Without a session to type into, the nudge goes to the inbox
+	if err := NewFileTransport("inbox.md").Nudge(agent, "You have 2 new messages"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if data, _ := os.ReadFile(inbox); string(data) != "You have 2 new messages\n" {
+		t.Errorf("inbox = %q, want the nudge", data)
+	}
+}
+
+func TestWebhookTransport_Deliver(t *testing.T) {
+	var got webhookMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode body: %v", err)
+		}
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	agent := &domain.Agent{ID: "craizy-proj-api-alpha", Name: "alpha"}
+
+	if err := NewWebhookTransport(server.URL+"/inbox").Deliver(agent, "hello"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Agent != agent.ID || got.Name != "alpha" || got.Text != "hello" {
+		t.Errorf("posted %+v, want the agent and text", got)
+	}
+
+	if err := NewWebhookTransport(server.URL+"/down").Deliver(agent, "hello"); err == nil {
+		t.Error("expected an error for a failed response")
+	}
+}
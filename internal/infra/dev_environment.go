@@ -0,0 +1,141 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// DevEnvironmentTimeout bounds setting up a worktree's environment, which for a
+// dev container can include building its image.
+const DevEnvironmentTimeout = 10 * time.Minute
+
+// devcontainerConfigs are the locations the dev container CLI reads its config from.
+var devcontainerConfigs = []string{
+	filepath.Join(".devcontainer", "devcontainer.json"),
+	".devcontainer.json",
+}
+
+// DevEnvironment implements domain.IDevEnvironment for direnv and dev containers.
+// When enabled, agents in a worktree with an .envrc are launched through
+// `direnv exec`, and agents in a worktree with a devcontainer.json through
+// `devcontainer exec` after `devcontainer up`. Anything detected but disabled or
+// not installed is reported as a warning and the agent runs as configured.
+type DevEnvironment struct {
+	direnv       bool
+	devcontainer bool
+}
+
+// NewDevEnvironment creates a dev environment that uses direnv and dev containers as enabled.
+func NewDevEnvironment(direnv, devcontainer bool) *DevEnvironment {
+	return &DevEnvironment{direnv: direnv, devcontainer: devcontainer}
+}
+
+// Prepare sets up the worktree's environment and returns command adjusted to run
+// inside it. A dev container takes precedence over direnv, since the container
+// provides its own environment.
+func (e *DevEnvironment) Prepare(worktree, command string) (string, []string) {
+	logging.Entry("worktree", worktree, "command", command)
+	var warnings []string
+
+	if findDevcontainer(worktree) != "" {
+		wrapped, warning := e.prepareDevcontainer(worktree, command)
+		if warning == "" {
+			return wrapped, nil
+		}
+		warnings = append(warnings, warning)
+	}
+
+	if fileExists(filepath.Join(worktree, ".envrc")) {
+		wrapped, warning := e.prepareDirenv(worktree, command)
+		if warning == "" {
+			return wrapped, warnings
+		}
+		warnings = append(warnings, warning)
+	}
+	return command, warnings
+}
+
+// prepareDevcontainer starts the worktree's dev container and returns command
+// wrapped to run inside it, or a warning if it can't.
+// Command: devcontainer up --workspace-folder {worktree}
+func (e *DevEnvironment) prepareDevcontainer(worktree, command string) (string, string) {
+	if !e.devcontainer {
+		return "", "Found a devcontainer.json in " + worktree + " but the agent runs on the host. Set environment.devcontainer in .craizy/settings.yml to run agents inside it."
+	}
+	if _, err := exec.LookPath("devcontainer"); err != nil {
+		return "", "Found a devcontainer.json in " + worktree + " but the devcontainer CLI is not installed, so the agent runs on the host."
+	}
+	if err := runSetup(worktree, "devcontainer", "up", "--workspace-folder", worktree); err != nil {
+		logging.Error(err, "worktree", worktree, "action", "devcontainer up")
+		return "", fmt.Sprintf("Could not start the dev container for %s, so the agent runs on the host: %v", worktree, err)
+	}
+	logging.Info("dev container started, worktree=%s", worktree)
+	return "devcontainer exec --workspace-folder " + shellQuote(worktree) + " " + innerCommand(command), ""
+}
+
+// prepareDirenv allows the worktree's .envrc and returns command wrapped to load
+// it, or a warning if it can't.
+// Command: direnv allow {worktree}
+func (e *DevEnvironment) prepareDirenv(worktree, command string) (string, string) {
+	if !e.direnv {
+		return "", "Found an .envrc in " + worktree + " but the agent runs without it. Set environment.direnv in .craizy/settings.yml to load it with direnv."
+	}
+	if _, err := exec.LookPath("direnv"); err != nil {
+		return "", "Found an .envrc in " + worktree + " but direnv is not installed, so the agent runs without it."
+	}
+	if err := runSetup(worktree, "direnv", "allow", worktree); err != nil {
+		logging.Error(err, "worktree", worktree, "action", "direnv allow")
+		return "", fmt.Sprintf("Could not allow the .envrc in %s, so the agent runs without it: %v", worktree, err)
+	}
+	logging.Info("direnv allowed, worktree=%s", worktree)
+	return "direnv exec " + shellQuote(worktree) + " " + innerCommand(command), ""
+}
+
+// innerCommand returns command as arguments for an exec wrapper, starting the
+// user's shell if command is empty.
+func innerCommand(command string) string {
+	if command == "" {
+		command = `exec "${SHELL:-sh}"`
+	}
+	return "sh -c " + shellQuote(command)
+}
+
+// runSetup runs a setup command in dir, including its output in any error.
+func runSetup(dir, name string, args ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), DevEnvironmentTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", name, err, strings.TrimSpace(output.String()))
+	}
+	return nil
+}
+
+// findDevcontainer returns the worktree's dev container config, or "".
+func findDevcontainer(worktree string) string {
+	for _, name := range devcontainerConfigs {
+		if path := filepath.Join(worktree, name); fileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// fileExists reports whether path is an existing regular file.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
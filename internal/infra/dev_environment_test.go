@@ -0,0 +1,115 @@
+package infra
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeTool installs a script named name on PATH that records its arguments in log.
+func fakeTool(t *testing.T, bin, name, log string, exitCode int) {
+	t.Helper()
+	script := "#!/bin/sh\necho \"" + name + " $*\" >> " + shellQuote(log) + "\nexit " + string(rune('0'+exitCode)) + "\n"
+	if err := os.WriteFile(filepath.Join(bin, name), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDevEnvironment_Prepare(t *testing.T) {
+	newWorktree := func(t *testing.T, files ...string) string {
+		t.Helper()
+		dir := t.TempDir()
+		for _, name := range files {
+			path := filepath.Join(dir, name)
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return dir
+	}
+	withTools := func(t *testing.T, exitCode int, names ...string) string {
+		t.Helper()
+		bin := t.TempDir()
+		log := filepath.Join(bin, "calls.log")
+		for _, name := range names {
+			fakeTool(t, bin, name, log, exitCode)
+		}
+		t.Setenv("PATH", bin+":/usr/bin:/bin")
+		return log
+	}
+
+	t.Run("leaves plain worktrees alone", func(t *testing.T) {
+		worktree := newWorktree(t)
+		command, warnings := NewDevEnvironment(true, true).Prepare(worktree, "claude")
+		if command != "claude" || len(warnings) != 0 {
+			t.Errorf("got %q, %v", command, warnings)
+		}
+	})
+
+	t.Run("wraps command with direnv", func(t *testing.T) {
+		worktree := newWorktree(t, ".envrc")
+		log := withTools(t, 0, "direnv")
+
+		command, warnings := NewDevEnvironment(true, false).Prepare(worktree, "claude --verbose")
+
+		want := "direnv exec " + shellQuote(worktree) + " sh -c 'claude --verbose'"
+		if command != want || len(warnings) != 0 {
+			t.Errorf("got %q, %v, want %q", command, warnings, want)
+		}
+		calls, _ := os.ReadFile(log)
+		if !strings.Contains(string(calls), "direnv allow "+worktree) {
+			t.Errorf("expected direnv allow, got %q", calls)
+		}
+	})
+
+	t.Run("warns when direnv is disabled", func(t *testing.T) {
+		worktree := newWorktree(t, ".envrc")
+		command, warnings := NewDevEnvironment(false, false).Prepare(worktree, "claude")
+		if command != "claude" || len(warnings) != 1 || !strings.Contains(warnings[0], "environment.direnv") {
+			t.Errorf("got %q, %v", command, warnings)
+		}
+	})
+
+	t.Run("warns when direnv is missing", func(t *testing.T) {
+		worktree := newWorktree(t, ".envrc")
+		withTools(t, 0)
+		command, warnings := NewDevEnvironment(true, false).Prepare(worktree, "claude")
+		if command != "claude" || len(warnings) != 1 || !strings.Contains(warnings[0], "not installed") {
+			t.Errorf("got %q, %v", command, warnings)
+		}
+	})
+
+	t.Run("runs inside the dev container in preference to direnv", func(t *testing.T) {
+		worktree := newWorktree(t, ".envrc", ".devcontainer/devcontainer.json")
+		log := withTools(t, 0, "direnv", "devcontainer")
+
+		command, warnings := NewDevEnvironment(true, true).Prepare(worktree, "")
+
+		if !strings.HasPrefix(command, "devcontainer exec --workspace-folder "+shellQuote(worktree)) || len(warnings) != 0 {
+			t.Errorf("got %q, %v", command, warnings)
+		}
+		calls, _ := os.ReadFile(log)
+		if !strings.Contains(string(calls), "devcontainer up --workspace-folder "+worktree) || strings.Contains(string(calls), "direnv") {
+			t.Errorf("expected only devcontainer up, got %q", calls)
+		}
+	})
+
+	t.Run("falls back to direnv when the container fails to start", func(t *testing.T) {
+		worktree := newWorktree(t, ".envrc", ".devcontainer.json")
+		bin := t.TempDir()
+		log := filepath.Join(bin, "calls.log")
+		fakeTool(t, bin, "devcontainer", log, 1)
+		fakeTool(t, bin, "direnv", log, 0)
+		t.Setenv("PATH", bin+":/usr/bin:/bin")
+
+		command, warnings := NewDevEnvironment(true, true).Prepare(worktree, "claude")
+
+		if !strings.HasPrefix(command, "direnv exec") || len(warnings) != 1 || !strings.Contains(warnings[0], "dev container") {
+			t.Errorf("got %q, %v", command, warnings)
+		}
+	})
+}
@@ -19,10 +19,12 @@ func NewEventDispatcher() *EventDispatcher {
 	}
 }
 
-// Publish sends an event to all registered handlers for that event type.
+// Publish sends an event to all registered handlers for that event type,
+// followed by any handlers subscribed to domain.AllEvents.
 func (d *EventDispatcher) Publish(event domain.Event) {
 	d.mu.RLock()
-	handlers := d.handlers[event.EventType()]
+	handlers := append([]domain.EventHandler{}, d.handlers[event.EventType()]...)
+	handlers = append(handlers, d.handlers[domain.AllEvents]...)
 	d.mu.RUnlock()
 
 	for _, handler := range handlers {
@@ -101,3 +101,19 @@ func TestEventDispatcher_Concurrency(t *testing.T) {
 	wg.Wait()
 	// Test passes if no race condition panics
 }
+
+func TestEventDispatcher_SubscribeAll(t *testing.T) {
+	dispatcher := NewEventDispatcher()
+	var received []string
+
+	dispatcher.Subscribe(domain.AllEvents, func(e domain.Event) {
+		received = append(received, e.EventType())
+	})
+
+	dispatcher.Publish(testEvent{eventType: "a.one", timestamp: time.Now()})
+	dispatcher.Publish(testEvent{eventType: "b.two", timestamp: time.Now()})
+
+	if len(received) != 2 || received[0] != "a.one" || received[1] != "b.two" {
+		t.Errorf("received = %v, want [a.one b.two]", received)
+	}
+}
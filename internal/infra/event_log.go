@@ -0,0 +1,125 @@
+package infra
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// followPollInterval is how often TailEvents checks the log for new lines when following.
+const followPollInterval = 500 * time.Millisecond
+
+// EventRecord is a single line of the NDJSON event log.
+type EventRecord struct {
+	Type string          `json:"type"`
+	Time time.Time       `json:"time"`
+	Data json.RawMessage `json:"data"`
+}
+
+// EventLog appends domain events to an NDJSON file so other processes can follow them.
+type EventLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewEventLog creates an EventLog writing to path.
+func NewEventLog(path string) *EventLog {
+	return &EventLog{path: path}
+}
+
+// Append writes event as a single JSON line.
+func (l *EventLog) Append(event domain.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+	line, err := json.Marshal(EventRecord{Type: event.EventType(), Time: event.OccurredAt(), Data: data})
+	if err != nil {
+		return fmt.Errorf("failed to encode event record: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open event log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write event log: %w", err)
+	}
+	return nil
+}
+
+// WireEventLog persists every published event to log.
+func WireEventLog(dispatcher domain.IEventDispatcher, log *EventLog) {
+	logging.Entry("path", log.path)
+	dispatcher.Subscribe(domain.AllEvents, func(e domain.Event) {
+		if err := log.Append(e); err != nil {
+			logging.Error(err, "eventType", e.EventType(), "action", "eventLog.Append")
+		}
+	})
+}
+
+// TailEvents calls fn for each record in the event log at path. If follow is set it keeps
+// polling for new records until stop is closed; otherwise it returns at end of file.
+// A missing log file is treated as empty.
+func TailEvents(path string, follow bool, stop <-chan struct{}, fn func(EventRecord)) error {
+	f, err := os.Open(path)
+	for errors.Is(err, os.ErrNotExist) && follow {
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(followPollInterval):
+		}
+		f, err = os.Open(path)
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open event log: %w", err)
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+	var partial string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read event log: %w", err)
+		}
+
+		// Keep incomplete lines until the writer finishes them
+		partial += line
+		if strings.HasSuffix(partial, "\n") {
+			var record EventRecord
+			if jsonErr := json.Unmarshal([]byte(partial), &record); jsonErr == nil {
+				fn(record)
+			}
+			partial = ""
+		}
+
+		if errors.Is(err, io.EOF) {
+			if !follow {
+				return nil
+			}
+			select {
+			case <-stop:
+				return nil
+			case <-time.After(followPollInterval):
+			}
+		}
+	}
+}
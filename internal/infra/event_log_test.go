@@ -0,0 +1,82 @@
+package infra
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestEventLog_AppendAndTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+	dispatcher := NewEventDispatcher()
+	WireEventLog(dispatcher, NewEventLog(path))
+
+	dispatcher.Publish(domain.AgentKilled{AgentID: "agent-1", Timestamp: time.Now()})
+	dispatcher.Publish(domain.AgentRetargeted{AgentID: "agent-1", OldBase: "main", NewBase: "release", Timestamp: time.Now()})
+
+	var records []EventRecord
+	if err := TailEvents(path, false, nil, func(r EventRecord) {
+		records = append(records, r)
+	}); err != nil {
+		t.Fatalf("TailEvents should not return error: %v", err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	if records[0].Type != "agent.killed" || records[1].Type != "agent.retargeted" {
+		t.Errorf("types = %s, %s", records[0].Type, records[1].Type)
+	}
+
+	var data domain.AgentRetargeted
+	if err := json.Unmarshal(records[1].Data, &data); err != nil {
+		t.Fatalf("failed to decode data: %v", err)
+	}
+	if data.NewBase != "release" {
+		t.Errorf("NewBase = %q, want release", data.NewBase)
+	}
+}
+
+func TestTailEvents_Follow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.log")
+	log := NewEventLog(path)
+	stop := make(chan struct{})
+	received := make(chan EventRecord, 1)
+
+	done := make(chan error)
+	go func() {
+		done <- TailEvents(path, true, stop, func(r EventRecord) {
+			received <- r
+		})
+	}()
+
+	// The log doesn't exist yet; following waits for it
+	_ = log.Append(domain.AgentKilled{AgentID: "late", Timestamp: time.Now()})
+
+	select {
+	case r := <-received:
+		if r.Type != "agent.killed" {
+			t.Errorf("Type = %q, want agent.killed", r.Type)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("followed event not received")
+	}
+
+	close(stop)
+	if err := <-done; err != nil {
+		t.Errorf("TailEvents should not return error: %v", err)
+	}
+}
+
+func TestTailEvents_MissingFile(t *testing.T) {
+	called := false
+	err := TailEvents(filepath.Join(t.TempDir(), "missing.log"), false, nil, func(EventRecord) {
+		called = true
+	})
+	if err != nil || called {
+		t.Errorf("missing log should be empty, err=%v called=%v", err, called)
+	}
+}
@@ -0,0 +1,293 @@
+package infra
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// FakeScript returns the lines a fake session prints for its command.
+type FakeScript func(command string) []string
+
+// DefaultFakeScript prints a short banner so previews have something to show.
+func DefaultFakeScript(command string) []string {
+	return []string{
+		"$ " + command,
+		"fake session started (no tmux)",
+		"waiting for input...",
+	}
+}
+
+// FakeTmuxClient implements ITmuxClient entirely in-process. Each session is a
+// fake pane fed by a goroutine that prints scripted output, so the services and
+// TUI can run without tmux installed. Sessions only live as long as the process.
+type FakeTmuxClient struct {
+	mu       sync.Mutex
+	panes    map[string]*fakePane
+	script   FakeScript
+	interval time.Duration
+}
+
+// fakePane holds the output of one fake session.
+type fakePane struct {
+	command  string
+	workDir  string
+	lines    []string
+	activity time.Time // when the pane last printed a line
+	record   *os.File  // transcript file set by PipeOutput
+	stop     chan struct{}
+	resumed  chan struct{} // closed on resume; nil unless paused
+}
+
+// print appends lines to the pane and its transcript. Callers hold the client lock.
+func (p *fakePane) print(lines ...string) {
+	p.lines = append(p.lines, lines...)
+	p.activity = time.Now()
+	if p.record != nil {
+		for _, line := range lines {
+			_, _ = io.WriteString(p.record, domain.FormatTranscriptLine(p.activity, line))
+		}
+	}
+}
+
+// NewFakeTmuxClient creates a FakeTmuxClient using DefaultFakeScript.
+func NewFakeTmuxClient() *FakeTmuxClient {
+	return &FakeTmuxClient{
+		panes:    make(map[string]*fakePane),
+		script:   DefaultFakeScript,
+		interval: 50 * time.Millisecond,
+	}
+}
+
+// SetScript sets the script used for sessions created afterwards.
+func (t *FakeTmuxClient) SetScript(script FakeScript) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.script = script
+}
+
+// SetLineInterval sets the delay between scripted output lines.
+func (t *FakeTmuxClient) SetLineInterval(interval time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.interval = interval
+}
+
+// CreateSession starts a fake pane that prints the scripted output for command.
+// env is ignored, as fake sessions run no processes.
+func (t *FakeTmuxClient) CreateSession(id, command, workDir string, env map[string]string) error {
+	logging.Entry("id", id, "command", command, "workDir", workDir)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, exists := t.panes[id]; exists {
+		return fmt.Errorf("duplicate session: %s", id)
+	}
+
+	pane := &fakePane{command: command, workDir: workDir, activity: time.Now(), stop: make(chan struct{})}
+	t.panes[id] = pane
+	go t.run(pane, t.script(command), t.interval)
+
+	logging.Info("fake session created, id=%s", id)
+	return nil
+}
+
+// run appends scripted lines to the pane until the script ends or the session is killed.
+func (t *FakeTmuxClient) run(pane *fakePane, lines []string, interval time.Duration) {
+	for _, line := range lines {
+		select {
+		case <-pane.stop:
+			return
+		case <-time.After(interval):
+		}
+		t.mu.Lock()
+		resumed := pane.resumed
+		t.mu.Unlock()
+		if resumed != nil {
+			select {
+			case <-pane.stop:
+				return
+			case <-resumed:
+			}
+		}
+		t.mu.Lock()
+		pane.print(line)
+		t.mu.Unlock()
+	}
+}
+
+// KillSession stops and removes a fake session.
+func (t *FakeTmuxClient) KillSession(id string) error {
+	logging.Entry("id", id)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pane, exists := t.panes[id]
+	if !exists {
+		return fmt.Errorf("can't find session: %s", id)
+	}
+	close(pane.stop)
+	if pane.record != nil {
+		_ = pane.record.Close()
+	}
+	delete(t.panes, id)
+	logging.Info("fake session killed, id=%s", id)
+	return nil
+}
+
+// PauseSession holds back a fake pane's scripted output until it is resumed.
+func (t *FakeTmuxClient) PauseSession(id string) error {
+	logging.Entry("id", id)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pane, exists := t.panes[id]
+	if !exists {
+		return fmt.Errorf("can't find session: %s", id)
+	}
+	if pane.resumed == nil {
+		pane.resumed = make(chan struct{})
+	}
+	return nil
+}
+
+// ResumeSession lets a paused fake pane print again.
+func (t *FakeTmuxClient) ResumeSession(id string) error {
+	logging.Entry("id", id)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pane, exists := t.panes[id]
+	if !exists {
+		return fmt.Errorf("can't find session: %s", id)
+	}
+	if pane.resumed != nil {
+		close(pane.resumed)
+		pane.resumed = nil
+	}
+	return nil
+}
+
+// RenameSession renames a fake session.
+func (t *FakeTmuxClient) RenameSession(oldID, newID string) error {
+	logging.Entry("oldID", oldID, "newID", newID)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pane, exists := t.panes[oldID]
+	if !exists {
+		return fmt.Errorf("can't find session: %s", oldID)
+	}
+	if _, taken := t.panes[newID]; taken {
+		return fmt.Errorf("duplicate session: %s", newID)
+	}
+	delete(t.panes, oldID)
+	t.panes[newID] = pane
+	return nil
+}
+
+// ListSessions returns all fake session names, sorted.
+func (t *FakeTmuxClient) ListSessions() ([]string, error) {
+	logging.Entry()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sessions := make([]string, 0, len(t.panes))
+	for id := range t.panes {
+		sessions = append(sessions, id)
+	}
+	sort.Strings(sessions)
+	return sessions, nil
+}
+
+// AttachCmd returns a command that prints the pane's output and waits for Enter,
+// standing in for a real tmux attach.
+func (t *FakeTmuxClient) AttachCmd(id string) *exec.Cmd {
+	logging.Entry("id", id)
+	output, _ := t.CapturePaneOutput(id, 0)
+	return exec.Command("sh", "-c", `printf '%s\n' "$1"; echo "[fake session $2 - press Enter to detach]"; read _`,
+		"sh", output, id)
+}
+
+// AttachReadOnlyCmd returns the same command as AttachCmd; fake sessions take no input.
+func (t *FakeTmuxClient) AttachReadOnlyCmd(id string) *exec.Cmd {
+	return t.AttachCmd(id)
+}
+
+// ExitStatus always reports no status, as fake sessions never exit on their own.
+func (t *FakeTmuxClient) ExitStatus(id string) (code int, ok bool) {
+	return 0, false
+}
+
+// SessionExists checks if a fake session exists.
+func (t *FakeTmuxClient) SessionExists(id string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, exists := t.panes[id]
+	return exists
+}
+
+// CapturePaneOutput returns the last N lines of a fake pane (0 = all).
+func (t *FakeTmuxClient) CapturePaneOutput(sessionID string, lines int) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pane, exists := t.panes[sessionID]
+	if !exists {
+		return "", fmt.Errorf("can't find session: %s", sessionID)
+	}
+	out := pane.lines
+	if lines > 0 && len(out) > lines {
+		out = out[len(out)-lines:]
+	}
+	return strings.Join(out, "\n"), nil
+}
+
+// SendKeys echoes text into the fake pane as if it had been typed and submitted.
+func (t *FakeTmuxClient) SendKeys(sessionID, text string) error {
+	logging.Entry("sessionID", sessionID, "textLen", len(text))
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pane, exists := t.panes[sessionID]
+	if !exists {
+		return fmt.Errorf("can't find session: %s", sessionID)
+	}
+	pane.print(strings.Split(text, "\n")...)
+	return nil
+}
+
+// LastActivity returns when the fake pane last printed a line.
+func (t *FakeTmuxClient) LastActivity(sessionID string) (time.Time, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pane, exists := t.panes[sessionID]
+	if !exists {
+		return time.Time{}, fmt.Errorf("can't find session: %s", sessionID)
+	}
+	return pane.activity, nil
+}
+
+// PipeOutput records everything the fake pane prints from now on to path.
+func (t *FakeTmuxClient) PipeOutput(sessionID, path string) error {
+	logging.Entry("sessionID", sessionID, "path", path)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	pane, exists := t.panes[sessionID]
+	if !exists {
+		return fmt.Errorf("can't find session: %s", sessionID)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create transcripts directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open transcript: %w", err)
+	}
+	if pane.record != nil {
+		_ = pane.record.Close()
+	}
+	pane.record = f
+	return nil
+}
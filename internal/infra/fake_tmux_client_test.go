@@ -0,0 +1,162 @@
+package infra
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+var _ domain.ITmuxClient = (*FakeTmuxClient)(nil)
+
+// waitForOutput polls a fake pane until it contains want or the deadline passes.
+func waitForOutput(t *testing.T, tmux *FakeTmuxClient, id, want string) string {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for {
+		out, _ := tmux.CapturePaneOutput(id, 0)
+		if strings.Contains(out, want) || time.Now().After(deadline) {
+			return out
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestFakeTmuxClient(t *testing.T) {
+	t.Run("scripted output", func(t *testing.T) {
+		tmux := NewFakeTmuxClient()
+		tmux.SetLineInterval(time.Millisecond)
+		tmux.SetScript(func(command string) []string {
+			return []string{"hello from " + command, "done"}
+		})
+
+		if err := tmux.CreateSession("s1", "claude", "/tmp", nil); err != nil {
+			t.Fatalf("CreateSession failed: %v", err)
+		}
+
+		out := waitForOutput(t, tmux, "s1", "done")
+		if out != "hello from claude\ndone" {
+			t.Errorf("output = %q", out)
+		}
+		last, _ := tmux.CapturePaneOutput("s1", 1)
+		if last != "done" {
+			t.Errorf("last line = %q, want done", last)
+		}
+	})
+
+	t.Run("session lifecycle", func(t *testing.T) {
+		tmux := NewFakeTmuxClient()
+		_ = tmux.CreateSession("s1", "", "/tmp", nil)
+
+		if err := tmux.CreateSession("s1", "", "/tmp", nil); err == nil {
+			t.Error("expected error creating duplicate session")
+		}
+		if err := tmux.RenameSession("s1", "s2"); err != nil {
+			t.Fatalf("RenameSession failed: %v", err)
+		}
+		sessions, _ := tmux.ListSessions()
+		if len(sessions) != 1 || sessions[0] != "s2" {
+			t.Errorf("sessions = %v, want [s2]", sessions)
+		}
+		if err := tmux.KillSession("s2"); err != nil {
+			t.Fatalf("KillSession failed: %v", err)
+		}
+		if tmux.SessionExists("s2") {
+			t.Error("session should be gone after kill")
+		}
+	})
+
+	t.Run("send keys echoes into pane", func(t *testing.T) {
+		tmux := NewFakeTmuxClient()
+		tmux.SetScript(func(string) []string { return nil })
+		_ = tmux.CreateSession("s1", "", "/tmp", nil)
+
+		_ = tmux.SendKeys("s1", "do the thing")
+
+		out, _ := tmux.CapturePaneOutput("s1", 10)
+		if out != "do the thing" {
+			t.Errorf("output = %q, want sent text", out)
+		}
+		if err := tmux.SendKeys("missing", "x"); err == nil {
+			t.Error("expected error sending to missing session")
+		}
+	})
+
+	t.Run("pause holds back output until resumed", func(t *testing.T) {
+		tmux := NewFakeTmuxClient()
+		tmux.SetLineInterval(time.Millisecond)
+		tmux.SetScript(func(string) []string { return []string{"one", "two"} })
+		_ = tmux.CreateSession("s1", "", "/tmp", nil)
+
+		if err := tmux.PauseSession("s1"); err != nil {
+			t.Fatalf("PauseSession failed: %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+		if out, _ := tmux.CapturePaneOutput("s1", 0); out != "" {
+			t.Errorf("output while paused = %q, want none", out)
+		}
+
+		if err := tmux.ResumeSession("s1"); err != nil {
+			t.Fatalf("ResumeSession failed: %v", err)
+		}
+		if out := waitForOutput(t, tmux, "s1", "two"); out != "one\ntwo" {
+			t.Errorf("output after resume = %q", out)
+		}
+	})
+}
+
+func TestFakeTmuxClient_WithAgentService(t *testing.T) {
+	tmux := NewFakeTmuxClient()
+	tmux.SetLineInterval(time.Millisecond)
+	store := NewMemoryAgentStore()
+	dispatcher := NewEventDispatcher()
+	WireAdapters(dispatcher, store, tmux, nil)
+	svc := domain.NewAgentService(tmux, store, dispatcher, nil, "proj", t.TempDir())
+
+	agent, err := svc.Create("claude", "task", "claude")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if !tmux.SessionExists(agent.ID) {
+		t.Fatal("agent session should exist in fake backend")
+	}
+	if out := waitForOutput(t, tmux, agent.ID, "fake session started"); !strings.Contains(out, "fake session started") {
+		t.Errorf("preview output = %q", out)
+	}
+
+	if err := svc.Kill(agent.ID); err != nil {
+		t.Fatalf("Kill failed: %v", err)
+	}
+	if tmux.SessionExists(agent.ID) {
+		t.Error("session should be killed")
+	}
+}
+
+func TestFakeTmuxClient_PipeOutput(t *testing.T) {
+	tmux := NewFakeTmuxClient()
+	tmux.SetScript(func(string) []string { return nil })
+	if err := tmux.CreateSession("s1", "agent", t.TempDir(), nil); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+	path := domain.TranscriptPath(t.TempDir(), "s1")
+
+	if err := tmux.PipeOutput("s1", path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = tmux.SendKeys("s1", "hello")
+	_ = tmux.KillSession("s1")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read transcript: %v", err)
+	}
+	if !strings.HasSuffix(string(data), "\thello\n") {
+		t.Errorf("transcript = %q, want a recorded hello line", data)
+	}
+
+	if err := tmux.PipeOutput("missing", path); err == nil {
+		t.Error("expected error for unknown session")
+	}
+}
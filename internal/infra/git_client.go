@@ -1,29 +1,94 @@
 package infra
 
 import (
+	"fmt"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
 	"github.com/TechnicallyShaun/crAIzy/internal/logging"
 )
 
+// statusConcurrency caps how many worktrees BatchStatus inspects at once.
+const statusConcurrency = 8
+
+// CommitSigning controls whether commits crAIzy creates are signed.
+type CommitSigning string
+
+const (
+	// CommitSigningRepo follows the repository's commit.gpgsign configuration.
+	CommitSigningRepo CommitSigning = ""
+	// CommitSigningAlways passes -S to force signing.
+	CommitSigningAlways CommitSigning = "always"
+	// CommitSigningNever passes --no-gpg-sign to disable signing.
+	CommitSigningNever CommitSigning = "never"
+)
+
 // GitClient implements domain.IGitClient using git commands.
 type GitClient struct {
 	// repoRoot is the root directory of the git repository.
 	repoRoot string
+	// signing controls -S / --no-gpg-sign on merges and rebases.
+	signing CommitSigning
+	// watchdog bounds local git commands; network bounds pushes and fetches.
+	watchdog *Watchdog
+	network  *Watchdog
 }
 
 // NewGitClient creates a new GitClient for the given repository root.
 func NewGitClient(repoRoot string) *GitClient {
-	return &GitClient{repoRoot: repoRoot}
+	return &GitClient{
+		repoRoot: repoRoot,
+		watchdog: NewWatchdog("git", GitTimeout),
+		network:  NewWatchdog("git (network)", GitNetworkTimeout),
+	}
+}
+
+// Degraded describes the problem if git commands are timing out, or returns "".
+func (g *GitClient) Degraded() string {
+	if problem := g.watchdog.Degraded(); problem != "" {
+		return problem
+	}
+	return g.network.Degraded()
+}
+
+// SetCommitSigning sets how merge, rebase and agent work commits are signed.
+func (g *GitClient) SetCommitSigning(signing CommitSigning) {
+	g.signing = signing
+}
+
+// signArgs returns the git flags for the configured signing mode.
+func (g *GitClient) signArgs() []string {
+	switch g.signing {
+	case CommitSigningAlways:
+		return []string{"-S"}
+	case CommitSigningNever:
+		return []string{"--no-gpg-sign"}
+	}
+	return nil
+}
+
+// signingError wraps err with domain.ErrCommitSigning if git's output shows signing failed.
+func signingError(err error, output []byte) error {
+	text := strings.TrimSpace(string(output))
+	lower := strings.ToLower(text)
+	if strings.Contains(lower, "failed to sign") || strings.Contains(lower, "couldn't load public key") {
+		return fmt.Errorf("%w (check user.signingkey and gpg.format): %s", domain.ErrCommitSigning, text)
+	}
+	return fmt.Errorf("%w: %s", err, text)
 }
 
 // IsRepo checks if the given path is inside a git repository.
 func (g *GitClient) IsRepo(path string) bool {
 	logging.Entry("path", path)
 	cmd := exec.Command("git", "-C", path, "rev-parse", "--git-dir")
-	result := cmd.Run() == nil
+	result := g.watchdog.Run(cmd) == nil
 	logging.Debug("IsRepo result=%v", result)
 	return result
 }
@@ -32,7 +97,7 @@ func (g *GitClient) IsRepo(path string) bool {
 func (g *GitClient) Init(path string) error {
 	logging.Entry("path", path)
 	cmd := exec.Command("git", "init", path)
-	if err := cmd.Run(); err != nil {
+	if err := g.watchdog.Run(cmd); err != nil {
 		logging.Error(err, "path", path)
 		return err
 	}
@@ -44,7 +109,7 @@ func (g *GitClient) Init(path string) error {
 func (g *GitClient) CurrentBranch(path string) (string, error) {
 	logging.Entry("path", path)
 	cmd := exec.Command("git", "-C", path, "rev-parse", "--abbrev-ref", "HEAD")
-	output, err := cmd.Output()
+	output, err := g.watchdog.Output(cmd)
 	if err != nil {
 		logging.Error(err, "path", path)
 		return "", err
@@ -58,7 +123,7 @@ func (g *GitClient) CurrentBranch(path string) (string, error) {
 func (g *GitClient) BranchExists(branch string) bool {
 	logging.Entry("branch", branch)
 	cmd := exec.Command("git", "-C", g.repoRoot, "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
-	exists := cmd.Run() == nil
+	exists := g.watchdog.Run(cmd) == nil
 	logging.Debug("branch exists=%v", exists)
 	return exists
 }
@@ -67,33 +132,167 @@ func (g *GitClient) BranchExists(branch string) bool {
 // If the branch doesn't exist, it creates it from baseBranch.
 func (g *GitClient) CreateWorktree(path, branch, baseBranch string) error {
 	logging.Entry("path", path, "branch", branch, "baseBranch", baseBranch)
-	// Make path absolute if it isn't already
+	_, err := g.addWorktree(path, branch, baseBranch, false)
+	return err
+}
+
+// CreateSparseWorktree creates a worktree without checking out files, restricts it to
+// the given directories with cone-mode sparse checkout, then checks out the branch.
+func (g *GitClient) CreateSparseWorktree(path, branch, baseBranch string, paths []string) error {
+	logging.Entry("path", path, "branch", branch, "baseBranch", baseBranch, "paths", paths)
+	absPath, err := g.addWorktree(path, branch, baseBranch, true)
+	if err != nil {
+		return err
+	}
+
+	args := append([]string{"-C", absPath, "sparse-checkout", "set", "--cone", "--"}, paths...)
+	if output, err := g.watchdog.CombinedOutput(exec.Command("git", args...)); err != nil {
+		err = fmt.Errorf("git sparse-checkout set failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "absPath", absPath)
+		return err
+	}
+
+	if output, err := g.watchdog.CombinedOutput(exec.Command("git", "-C", absPath, "checkout", branch)); err != nil {
+		err = fmt.Errorf("git checkout failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "absPath", absPath, "branch", branch)
+		return err
+	}
+	logging.Info("sparse worktree created, path=%s, branch=%s, paths=%v", absPath, branch, paths)
+	return nil
+}
+
+// RefreshTemplate checks out baseBranch, detached, in the template worktree at
+// path, creating it if needed and discarding anything else in it.
+func (g *GitClient) RefreshTemplate(path, baseBranch string) error {
+	logging.Entry("path", path, "baseBranch", baseBranch)
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		logging.Error(err, "path", path)
 		return err
 	}
 
+	if _, err := os.Stat(absPath); os.IsNotExist(err) {
+		cmd := exec.Command("git", "-C", g.repoRoot, "worktree", "add", "--detach", absPath, baseBranch)
+		if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+			err = fmt.Errorf("git worktree add failed: %w: %s", err, strings.TrimSpace(string(output)))
+			logging.Error(err, "absPath", absPath, "baseBranch", baseBranch)
+			return err
+		}
+		logging.Info("template worktree created, path=%s, baseBranch=%s", absPath, baseBranch)
+		return nil
+	}
+
+	for _, args := range [][]string{
+		{"checkout", "--quiet", "--force", "--detach", baseBranch},
+		{"clean", "-ffdxq"},
+	} {
+		cmd := exec.Command("git", append([]string{"-C", absPath}, args...)...)
+		if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+			err = fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(string(output)))
+			logging.Error(err, "absPath", absPath, "baseBranch", baseBranch)
+			return err
+		}
+	}
+	logging.Info("template worktree refreshed, path=%s, baseBranch=%s", absPath, baseBranch)
+	return nil
+}
+
+// CloneWorktree creates a worktree without checking out files, copies the
+// template's files into it with reflinks where the filesystem supports them,
+// then checks out only the files that differ from the branch. On a
+// copy-on-write filesystem this takes seconds where a checkout of a large
+// repository takes minutes.
+func (g *GitClient) CloneWorktree(template, path, branch, baseBranch string) error {
+	logging.Entry("template", template, "path", path, "branch", branch, "baseBranch", baseBranch)
+	entries, err := os.ReadDir(template)
+	if err != nil {
+		logging.Error(err, "template", template)
+		return fmt.Errorf("failed to read template worktree: %w", err)
+	}
+
+	absPath, err := g.addWorktree(path, branch, baseBranch, true)
+	if err != nil {
+		return err
+	}
+
+	// Everything but the template's .git file, which points at its own metadata
+	var sources []string
+	for _, entry := range entries {
+		if entry.Name() != ".git" {
+			sources = append(sources, filepath.Join(template, entry.Name()))
+		}
+	}
+	if len(sources) > 0 {
+		args := append(reflinkCopyArgs(), append(sources, absPath+string(filepath.Separator))...)
+		// Copying a large tree can take as long as a fetch, so it gets the network timeout
+		if output, err := g.network.CombinedOutput(exec.Command("cp", args...)); err != nil {
+			err = fmt.Errorf("copying template failed: %w: %s", err, strings.TrimSpace(string(output)))
+			logging.Error(err, "absPath", absPath)
+			return err
+		}
+	}
+
+	// Index the branch against the copied files, then fix up whatever differs
+	for _, args := range [][]string{
+		{"reset", "--quiet"},
+		{"checkout", "--quiet", "--", "."},
+		{"clean", "-fdq"},
+	} {
+		cmd := exec.Command("git", append([]string{"-C", absPath}, args...)...)
+		if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+			err = fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(string(output)))
+			logging.Error(err, "absPath", absPath, "branch", branch)
+			return err
+		}
+	}
+	logging.Info("worktree cloned from template, path=%s, branch=%s", absPath, branch)
+	return nil
+}
+
+// reflinkCopyArgs returns cp flags that copy directories recursively, keeping
+// attributes, and share file data with reflinks where the filesystem allows.
+func reflinkCopyArgs() []string {
+	if runtime.GOOS == "darwin" {
+		return []string{"-Rpc"}
+	}
+	return []string{"-a", "--reflink=auto"}
+}
+
+// addWorktree runs `git worktree add`, reusing branch if it exists or creating it from baseBranch.
+// It returns the absolute worktree path.
+func (g *GitClient) addWorktree(path, branch, baseBranch string, noCheckout bool) (string, error) {
+	// Make path absolute if it isn't already
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		logging.Error(err, "path", path)
+		return "", err
+	}
+
+	args := []string{"-C", g.repoRoot, "worktree", "add"}
+	if noCheckout {
+		args = append(args, "--no-checkout")
+	}
+
 	// Check if branch already exists
 	if g.BranchExists(branch) {
 		// Use existing branch
-		cmd := exec.Command("git", "-C", g.repoRoot, "worktree", "add", absPath, branch)
-		if err := cmd.Run(); err != nil {
+		cmd := exec.Command("git", append(args, absPath, branch)...)
+		if err := g.watchdog.Run(cmd); err != nil {
 			logging.Error(err, "absPath", absPath, "branch", branch)
-			return err
+			return "", err
 		}
 		logging.Info("worktree created with existing branch, path=%s, branch=%s", absPath, branch)
-		return nil
+		return absPath, nil
 	}
 
 	// Create new branch from baseBranch
-	cmd := exec.Command("git", "-C", g.repoRoot, "worktree", "add", "-b", branch, absPath, baseBranch)
-	if err := cmd.Run(); err != nil {
+	cmd := exec.Command("git", append(args, "-b", branch, absPath, baseBranch)...)
+	if err := g.watchdog.Run(cmd); err != nil {
 		logging.Error(err, "absPath", absPath, "branch", branch, "baseBranch", baseBranch)
-		return err
+		return "", err
 	}
 	logging.Info("worktree created with new branch, path=%s, branch=%s", absPath, branch)
-	return nil
+	return absPath, nil
 }
 
 // RemoveWorktree removes the worktree at the given path.
@@ -106,7 +305,7 @@ func (g *GitClient) RemoveWorktree(path string) error {
 	}
 
 	cmd := exec.Command("git", "-C", g.repoRoot, "worktree", "remove", "--force", absPath)
-	if err := cmd.Run(); err != nil {
+	if err := g.watchdog.Run(cmd); err != nil {
 		logging.Error(err, "absPath", absPath)
 		return err
 	}
@@ -118,7 +317,7 @@ func (g *GitClient) RemoveWorktree(path string) error {
 func (g *GitClient) DeleteBranch(branch string) error {
 	logging.Entry("branch", branch)
 	cmd := exec.Command("git", "-C", g.repoRoot, "branch", "-D", branch)
-	if err := cmd.Run(); err != nil {
+	if err := g.watchdog.Run(cmd); err != nil {
 		logging.Error(err, "branch", branch)
 		return err
 	}
@@ -126,12 +325,26 @@ func (g *GitClient) DeleteBranch(branch string) error {
 	return nil
 }
 
+// RenameBranch renames a branch. Worktrees that have it checked out follow the rename.
+// Command: git branch -m {oldName} {newName}
+func (g *GitClient) RenameBranch(oldName, newName string) error {
+	logging.Entry("oldName", oldName, "newName", newName)
+	cmd := exec.Command("git", "-C", g.repoRoot, "branch", "-m", oldName, newName)
+	if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+		err = fmt.Errorf("git branch -m failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "oldName", oldName, "newName", newName)
+		return err
+	}
+	logging.Info("branch renamed, oldName=%s, newName=%s", oldName, newName)
+	return nil
+}
+
 // HasUncommittedChanges checks if the worktree at path has uncommitted changes.
 func (g *GitClient) HasUncommittedChanges(path string) bool {
 	logging.Entry("path", path)
 	// Check for staged or unstaged changes
 	cmd := exec.Command("git", "-C", path, "status", "--porcelain")
-	output, err := cmd.Output()
+	output, err := g.watchdog.Output(cmd)
 	if err != nil {
 		logging.Error(err, "path", path)
 		return false
@@ -146,14 +359,14 @@ func (g *GitClient) DiscardChanges(path string) error {
 	logging.Entry("path", path)
 	// Reset staged changes
 	cmd := exec.Command("git", "-C", path, "reset", "--hard", "HEAD")
-	if err := cmd.Run(); err != nil {
+	if err := g.watchdog.Run(cmd); err != nil {
 		logging.Error(err, "path", path, "action", "reset")
 		return err
 	}
 
 	// Clean untracked files
 	cmd = exec.Command("git", "-C", path, "clean", "-fd")
-	if err := cmd.Run(); err != nil {
+	if err := g.watchdog.Run(cmd); err != nil {
 		logging.Error(err, "path", path, "action", "clean")
 		return err
 	}
@@ -164,8 +377,8 @@ func (g *GitClient) DiscardChanges(path string) error {
 // Stash stashes changes in the worktree at path.
 func (g *GitClient) Stash(path string) error {
 	logging.Entry("path", path)
-	cmd := exec.Command("git", "-C", path, "stash", "push", "-u", "-m", "craizy-auto-stash")
-	if err := cmd.Run(); err != nil {
+	cmd := exec.Command("git", "-C", path, "stash", "push", "-u", "-m", domain.AutoStashMessage)
+	if err := g.watchdog.Run(cmd); err != nil {
 		logging.Error(err, "path", path)
 		return err
 	}
@@ -173,11 +386,38 @@ func (g *GitClient) Stash(path string) error {
 	return nil
 }
 
+// Add stages every change in the worktree at path, including untracked files.
+func (g *GitClient) Add(path string) error {
+	logging.Entry("path", path)
+	cmd := exec.Command("git", "-C", path, "add", "-A")
+	if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+		err = fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "path", path)
+		return err
+	}
+	return nil
+}
+
+// Commit commits the changes staged in the worktree at path with message,
+// signed per the configured signing mode.
+func (g *GitClient) Commit(path, message string) error {
+	logging.Entry("path", path)
+	args := append([]string{"-C", path, "commit"}, g.signArgs()...)
+	cmd := exec.Command("git", append(args, "-m", message)...)
+	if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+		err = signingError(err, output)
+		logging.Error(err, "path", path)
+		return err
+	}
+	logging.Info("changes committed, path=%s", path)
+	return nil
+}
+
 // StashPop pops the stash in the worktree at path.
 func (g *GitClient) StashPop(path string) error {
 	logging.Entry("path", path)
 	cmd := exec.Command("git", "-C", path, "stash", "pop")
-	if err := cmd.Run(); err != nil {
+	if err := g.watchdog.Run(cmd); err != nil {
 		logging.Error(err, "path", path)
 		return err
 	}
@@ -185,11 +425,90 @@ func (g *GitClient) StashPop(path string) error {
 	return nil
 }
 
+// ListStashes returns every stash in the repository, newest first.
+// Command: git stash list --format=%gd%x09%ct%x09%gs
+func (g *GitClient) ListStashes() ([]domain.StashEntry, error) {
+	logging.Entry()
+	cmd := exec.Command("git", "-C", g.repoRoot, "stash", "list", "--format=%gd%x09%ct%x09%gs")
+	output, err := g.watchdog.CombinedOutput(cmd)
+	if err != nil {
+		err = fmt.Errorf("git stash list failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err)
+		return nil, err
+	}
+	return parseStashList(string(output)), nil
+}
+
+// parseStashList parses tab separated ref/time/subject lines from git stash list.
+// Subjects look like "On main: message" or "WIP on main: abc123 subject".
+func parseStashList(output string) []domain.StashEntry {
+	var stashes []domain.StashEntry
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		entry := domain.StashEntry{Ref: fields[0], Message: fields[2]}
+		if unix, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+			entry.CreatedAt = time.Unix(unix, 0)
+		}
+		subject := strings.TrimPrefix(strings.TrimPrefix(fields[2], "WIP on "), "On ")
+		if branch, message, ok := strings.Cut(subject, ": "); ok {
+			entry.Branch, entry.Message = branch, message
+		}
+		stashes = append(stashes, entry)
+	}
+	return stashes
+}
+
+// PopStashRef applies the stash ref to the worktree at path and drops it.
+func (g *GitClient) PopStashRef(path, ref string) error {
+	logging.Entry("path", path, "ref", ref)
+	cmd := exec.Command("git", "-C", path, "stash", "pop", ref)
+	if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+		err = fmt.Errorf("git stash pop failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "path", path, "ref", ref)
+		return err
+	}
+	logging.Info("stash popped, path=%s, ref=%s", path, ref)
+	return nil
+}
+
+// StashBranch creates branch from the commit the stash ref was made on,
+// checks it out in the project root, applies the stash and drops it.
+func (g *GitClient) StashBranch(ref, branch string) error {
+	logging.Entry("ref", ref, "branch", branch)
+	cmd := exec.Command("git", "-C", g.repoRoot, "stash", "branch", branch, ref)
+	if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+		err = fmt.Errorf("git stash branch failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "ref", ref, "branch", branch)
+		return err
+	}
+	logging.Info("stash applied to new branch, ref=%s, branch=%s", ref, branch)
+	return nil
+}
+
+// DropStash deletes the stash ref.
+func (g *GitClient) DropStash(ref string) error {
+	logging.Entry("ref", ref)
+	cmd := exec.Command("git", "-C", g.repoRoot, "stash", "drop", ref)
+	if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+		err = fmt.Errorf("git stash drop failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "ref", ref)
+		return err
+	}
+	logging.Info("stash dropped, ref=%s", ref)
+	return nil
+}
+
 // Merge merges the given branch into the current branch.
+// Signing follows the configured CommitSigning mode.
 func (g *GitClient) Merge(branch string) error {
 	logging.Entry("branch", branch)
-	cmd := exec.Command("git", "-C", g.repoRoot, "merge", branch, "--no-edit")
-	if err := cmd.Run(); err != nil {
+	args := append([]string{"-C", g.repoRoot, "merge"}, g.signArgs()...)
+	cmd := exec.Command("git", append(args, branch, "--no-edit")...)
+	if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+		err = signingError(err, output)
 		logging.Error(err, "branch", branch)
 		return err
 	}
@@ -197,13 +516,75 @@ func (g *GitClient) Merge(branch string) error {
 	return nil
 }
 
-// MergeAbort aborts an in-progress merge.
+// Squash stages the changes of branch on the current branch and commits them
+// as one commit with message.
+// Command: git merge --squash {branch} && git commit -m {message}
+func (g *GitClient) Squash(branch, message string) error {
+	logging.Entry("branch", branch)
+	cmd := exec.Command("git", "-C", g.repoRoot, "merge", "--squash", branch)
+	if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+		err = fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "branch", branch)
+		return err
+	}
+	// An empty squash leaves nothing staged, and git commit would fail
+	if g.watchdog.Run(exec.Command("git", "-C", g.repoRoot, "diff", "--cached", "--quiet")) == nil {
+		logging.Info("nothing to squash, branch=%s", branch)
+		return nil
+	}
+	args := append([]string{"-C", g.repoRoot, "commit"}, g.signArgs()...)
+	cmd = exec.Command("git", append(args, "-m", message)...)
+	if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+		err = signingError(err, output)
+		// A squash leaves no MERGE_HEAD for MergeAbort, so unstage it here
+		if resetErr := g.watchdog.Run(exec.Command("git", "-C", g.repoRoot, "reset", "--merge")); resetErr != nil {
+			logging.Error(resetErr, "branch", branch)
+		}
+		logging.Error(err, "branch", branch)
+		return err
+	}
+	logging.Info("branch squashed, branch=%s", branch)
+	return nil
+}
+
+// Rebase rebases branch, checked out at path, onto baseBranch and fast-forwards
+// the current branch to it. On conflict the rebase is aborted.
+// Command: git -C {path} rebase {baseBranch} && git merge --ff-only {branch}
+func (g *GitClient) Rebase(path, baseBranch, branch string) error {
+	logging.Entry("path", path, "baseBranch", baseBranch, "branch", branch)
+	args := append([]string{"-C", path, "rebase"}, g.signArgs()...)
+	cmd := exec.Command("git", append(args, "--autostash", baseBranch)...)
+	if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+		_ = g.watchdog.Run(exec.Command("git", "-C", path, "rebase", "--abort"))
+		err = fmt.Errorf("git rebase failed: %w", signingError(err, output))
+		logging.Error(err, "path", path)
+		return err
+	}
+	cmd = exec.Command("git", "-C", g.repoRoot, "merge", "--ff-only", branch)
+	if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+		err = fmt.Errorf("fast-forward failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "branch", branch)
+		return err
+	}
+	logging.Info("branch rebased and fast-forwarded, branch=%s, baseBranch=%s", branch, baseBranch)
+	return nil
+}
+
+// MergeAbort aborts an in-progress merge. A squash merge leaves no merge to
+// abort, so its conflicts are reset instead.
 func (g *GitClient) MergeAbort() error {
 	logging.Entry()
 	cmd := exec.Command("git", "-C", g.repoRoot, "merge", "--abort")
-	if err := cmd.Run(); err != nil {
-		logging.Error(err)
-		return err
+	if err := g.watchdog.Run(cmd); err != nil {
+		files, filesErr := g.MergeConflictFiles()
+		if filesErr != nil || len(files) == 0 {
+			logging.Error(err)
+			return err
+		}
+		if err := g.watchdog.Run(exec.Command("git", "-C", g.repoRoot, "reset", "--merge")); err != nil {
+			logging.Error(err)
+			return err
+		}
 	}
 	logging.Info("merge aborted")
 	return nil
@@ -213,7 +594,7 @@ func (g *GitClient) MergeAbort() error {
 func (g *GitClient) MergeConflictFiles() ([]string, error) {
 	logging.Entry()
 	cmd := exec.Command("git", "-C", g.repoRoot, "diff", "--name-only", "--diff-filter=U")
-	output, err := cmd.Output()
+	output, err := g.watchdog.Output(cmd)
 	if err != nil {
 		logging.Error(err)
 		return nil, err
@@ -228,3 +609,360 @@ func (g *GitClient) MergeConflictFiles() ([]string, error) {
 	logging.Debug("conflict files=%v", files)
 	return files, nil
 }
+
+// AheadBehind returns how many commits branch is ahead of and behind baseBranch.
+// Command: git rev-list --left-right --count {baseBranch}...{branch}
+func (g *GitClient) AheadBehind(branch, baseBranch string) (int, int, error) {
+	logging.Entry("branch", branch, "baseBranch", baseBranch)
+	cmd := exec.Command("git", "-C", g.repoRoot, "rev-list", "--left-right", "--count", baseBranch+"..."+branch)
+	output, err := g.watchdog.Output(cmd)
+	if err != nil {
+		logging.Error(err, "branch", branch, "baseBranch", baseBranch)
+		return 0, 0, err
+	}
+
+	var behind, ahead int
+	if _, err := fmt.Sscanf(strings.TrimSpace(string(output)), "%d %d", &behind, &ahead); err != nil {
+		logging.Error(err, "output", string(output))
+		return 0, 0, err
+	}
+	return ahead, behind, nil
+}
+
+// CommitsAhead returns the commits on branch that are not on baseBranch, newest first.
+// Command: git log --format=%h%x09%s {baseBranch}..{branch}
+func (g *GitClient) CommitsAhead(branch, baseBranch string) ([]domain.Commit, error) {
+	logging.Entry("branch", branch, "baseBranch", baseBranch)
+	cmd := exec.Command("git", "-C", g.repoRoot, "log", "--format=%h%x09%s", baseBranch+".."+branch)
+	output, err := g.watchdog.CombinedOutput(cmd)
+	if err != nil {
+		err = fmt.Errorf("git log failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "branch", branch, "baseBranch", baseBranch)
+		return nil, err
+	}
+	return parseCommitLog(string(output)), nil
+}
+
+// parseCommitLog parses tab separated hash/subject lines from git log.
+func parseCommitLog(output string) []domain.Commit {
+	var commits []domain.Commit
+	for _, line := range strings.Split(output, "\n") {
+		if line == "" {
+			continue
+		}
+		hash, subject, _ := strings.Cut(line, "\t")
+		commits = append(commits, domain.Commit{Hash: hash, Subject: subject})
+	}
+	return commits
+}
+
+// DiffStat returns the stat summary of the changes on branch since it left baseBranch.
+// Command: git diff --stat --no-color {baseBranch}...{branch}
+func (g *GitClient) DiffStat(branch, baseBranch string) (string, error) {
+	logging.Entry("branch", branch, "baseBranch", baseBranch)
+	cmd := exec.Command("git", "-C", g.repoRoot, "diff", "--stat", "--no-color", baseBranch+"..."+branch)
+	output, err := g.watchdog.CombinedOutput(cmd)
+	if err != nil {
+		err = fmt.Errorf("git diff failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "branch", branch, "baseBranch", baseBranch)
+		return "", err
+	}
+	return strings.TrimRight(string(output), "\n"), nil
+}
+
+// Diff returns the patch of the changes on branch since it left baseBranch,
+// run in the worktree at path.
+// Command: git -C {path} diff --no-color {baseBranch}...{branch}
+func (g *GitClient) Diff(path, baseBranch, branch string) (string, error) {
+	logging.Entry("path", path, "baseBranch", baseBranch, "branch", branch)
+	cmd := exec.Command("git", "-C", path, "diff", "--no-color", baseBranch+"..."+branch)
+	output, err := g.watchdog.CombinedOutput(cmd)
+	if err != nil {
+		err = fmt.Errorf("git diff failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "path", path, "baseBranch", baseBranch, "branch", branch)
+		return "", err
+	}
+	return string(output), nil
+}
+
+// BatchStatus collects the state of every target worktree in parallel, running
+// at most statusConcurrency at once, with three git commands per worktree.
+func (g *GitClient) BatchStatus(targets []domain.StatusTarget) []domain.WorktreeStatus {
+	logging.Entry("worktrees", len(targets))
+	statuses := make([]domain.WorktreeStatus, len(targets))
+	slots := make(chan struct{}, statusConcurrency)
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+			statuses[i] = g.worktreeStatus(target)
+		}()
+	}
+	wg.Wait()
+	return statuses
+}
+
+// worktreeStatus collects one worktree's state with git status, rev-list and diff --shortstat.
+func (g *GitClient) worktreeStatus(target domain.StatusTarget) domain.WorktreeStatus {
+	status := domain.WorktreeStatus{Path: target.Path}
+	fail := func(err error) domain.WorktreeStatus {
+		logging.Error(err, "path", target.Path)
+		return domain.WorktreeStatus{Path: target.Path, Err: err}
+	}
+
+	output, err := g.watchdog.Output(exec.Command("git", "-C", target.Path, "status", "--porcelain=v2", "--branch"))
+	if err != nil {
+		return fail(fmt.Errorf("git status failed: %w", err))
+	}
+	status.Branch, status.Dirty = parseStatusV2(string(output))
+	if target.BaseBranch == "" {
+		return status
+	}
+
+	output, err = g.watchdog.Output(exec.Command("git", "-C", target.Path, "rev-list", "--left-right", "--count", target.BaseBranch+"...HEAD"))
+	if err != nil {
+		return fail(fmt.Errorf("git rev-list failed: %w", err))
+	}
+	if _, err := fmt.Sscanf(strings.TrimSpace(string(output)), "%d %d", &status.Behind, &status.Ahead); err != nil {
+		return fail(fmt.Errorf("failed to parse ahead/behind %q: %w", output, err))
+	}
+
+	// Against the merge base, so uncommitted changes count too
+	output, err = g.watchdog.Output(exec.Command("git", "-C", target.Path, "diff", "--shortstat", "--merge-base", target.BaseBranch))
+	if err != nil {
+		return fail(fmt.Errorf("git diff failed: %w", err))
+	}
+	status.Files, status.Insertions, status.Deletions = parseShortStat(string(output))
+	return status
+}
+
+// parseStatusV2 reads the branch and whether anything is modified or untracked
+// from `git status --porcelain=v2 --branch`.
+func parseStatusV2(output string) (branch string, dirty bool) {
+	for _, line := range strings.Split(output, "\n") {
+		switch {
+		case strings.HasPrefix(line, "# branch.head "):
+			if head := strings.TrimPrefix(line, "# branch.head "); head != "(detached)" {
+				branch = head
+			}
+		case line != "" && !strings.HasPrefix(line, "#"):
+			dirty = true
+		}
+	}
+	return branch, dirty
+}
+
+// parseShortStat reads the counts from `git diff --shortstat`, e.g.
+// " 3 files changed, 10 insertions(+), 2 deletions(-)". Missing counts are zero.
+func parseShortStat(output string) (files, insertions, deletions int) {
+	for _, part := range strings.Split(strings.TrimSpace(output), ",") {
+		var n int
+		var word string
+		if _, err := fmt.Sscanf(strings.TrimSpace(part), "%d %s", &n, &word); err != nil {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(word, "file"):
+			files = n
+		case strings.HasPrefix(word, "insertion"):
+			insertions = n
+		case strings.HasPrefix(word, "deletion"):
+			deletions = n
+		}
+	}
+	return files, insertions, deletions
+}
+
+// ShowCommit returns the stat summary and patch of a commit.
+// Command: git show --stat --patch --no-color {hash}
+func (g *GitClient) ShowCommit(hash string) (string, error) {
+	logging.Entry("hash", hash)
+	cmd := exec.Command("git", "-C", g.repoRoot, "show", "--stat", "--patch", "--no-color", hash)
+	output, err := g.watchdog.CombinedOutput(cmd)
+	if err != nil {
+		err = fmt.Errorf("git show failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "hash", hash)
+		return "", err
+	}
+	return string(output), nil
+}
+
+// Push pushes the given branch to the origin remote and sets upstream.
+func (g *GitClient) Push(branch string) error {
+	logging.Entry("branch", branch)
+	cmd := exec.Command("git", "-C", g.repoRoot, "push", "-u", "origin", branch)
+	if output, err := g.network.CombinedOutput(cmd); err != nil {
+		err = fmt.Errorf("git push failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "branch", branch)
+		return err
+	}
+	logging.Info("branch pushed, branch=%s", branch)
+	return nil
+}
+
+// RebaseOnto rebases the branch checked out at path from oldBase onto newBase.
+// Uncommitted changes are autostashed. On conflict the rebase is aborted.
+func (g *GitClient) RebaseOnto(path, newBase, oldBase string) error {
+	logging.Entry("path", path, "newBase", newBase, "oldBase", oldBase)
+	args := append([]string{"-C", path, "rebase"}, g.signArgs()...)
+	cmd := exec.Command("git", append(args, "--autostash", "--onto", newBase, oldBase)...)
+	if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+		_ = g.watchdog.Run(exec.Command("git", "-C", path, "rebase", "--abort"))
+		err = fmt.Errorf("git rebase failed: %w", signingError(err, output))
+		logging.Error(err, "path", path)
+		return err
+	}
+	logging.Info("branch rebased, path=%s, newBase=%s", path, newBase)
+	return nil
+}
+
+// ListWorktrees returns all worktrees registered in the repository.
+// Command: git worktree list --porcelain
+func (g *GitClient) ListWorktrees() ([]domain.Worktree, error) {
+	logging.Entry()
+	cmd := exec.Command("git", "-C", g.repoRoot, "worktree", "list", "--porcelain")
+	output, err := g.watchdog.Output(cmd)
+	if err != nil {
+		logging.Error(err)
+		return nil, err
+	}
+	worktrees := parseWorktreeList(string(output))
+	for i := range worktrees {
+		// Locked worktrees are never reported prunable, so check the directory directly
+		if _, err := os.Stat(worktrees[i].Path); os.IsNotExist(err) {
+			worktrees[i].Missing = true
+		}
+	}
+	logging.Debug("listed %d worktrees", len(worktrees))
+	return worktrees, nil
+}
+
+// parseWorktreeList parses `git worktree list --porcelain` output.
+// Each worktree is a block of "key value" lines separated by a blank line.
+func parseWorktreeList(output string) []domain.Worktree {
+	var worktrees []domain.Worktree
+	var current *domain.Worktree
+
+	flush := func() {
+		if current != nil {
+			worktrees = append(worktrees, *current)
+			current = nil
+		}
+	}
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			flush()
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		if key == "worktree" {
+			flush()
+			current = &domain.Worktree{Path: value}
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		switch key {
+		case "HEAD":
+			current.Head = value
+		case "branch":
+			current.Branch = strings.TrimPrefix(value, "refs/heads/")
+		case "bare":
+			current.Bare = true
+		case "locked":
+			current.Locked = true
+		case "prunable":
+			current.Prunable = true
+		}
+	}
+	flush()
+
+	return worktrees
+}
+
+// LockWorktree locks the worktree at path so `git worktree prune` and
+// `git worktree remove` leave it alone while an agent is using it.
+func (g *GitClient) LockWorktree(path, reason string) error {
+	logging.Entry("path", path, "reason", reason)
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		logging.Error(err, "path", path)
+		return err
+	}
+
+	cmd := exec.Command("git", "-C", g.repoRoot, "worktree", "lock", "--reason", reason, absPath)
+	if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+		err = fmt.Errorf("git worktree lock failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "absPath", absPath)
+		return err
+	}
+	logging.Info("worktree locked, path=%s", absPath)
+	return nil
+}
+
+// UnlockWorktree unlocks the worktree at path.
+func (g *GitClient) UnlockWorktree(path string) error {
+	logging.Entry("path", path)
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		logging.Error(err, "path", path)
+		return err
+	}
+
+	cmd := exec.Command("git", "-C", g.repoRoot, "worktree", "unlock", absPath)
+	if output, err := g.watchdog.CombinedOutput(cmd); err != nil {
+		err = fmt.Errorf("git worktree unlock failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "absPath", absPath)
+		return err
+	}
+	logging.Info("worktree unlocked, path=%s", absPath)
+	return nil
+}
+
+// UpdateSubmodules initializes and updates submodules recursively in the worktree at path.
+// Command: git -C {path} submodule update --init --recursive
+func (g *GitClient) UpdateSubmodules(path string) error {
+	logging.Entry("path", path)
+	cmd := exec.Command("git", "-C", path, "submodule", "update", "--init", "--recursive")
+	if output, err := g.network.CombinedOutput(cmd); err != nil {
+		err = fmt.Errorf("git submodule update failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "path", path)
+		return err
+	}
+	logging.Info("submodules updated, path=%s", path)
+	return nil
+}
+
+// LFSPull fetches and checks out Git LFS objects in the worktree at path.
+// Command: git -C {path} lfs pull
+func (g *GitClient) LFSPull(path string) error {
+	logging.Entry("path", path)
+	cmd := exec.Command("git", "-C", path, "lfs", "pull")
+	if output, err := g.network.CombinedOutput(cmd); err != nil {
+		err = fmt.Errorf("git lfs pull failed (is git-lfs installed?): %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "path", path)
+		return err
+	}
+	logging.Info("LFS objects pulled, path=%s", path)
+	return nil
+}
+
+// PruneWorktrees removes registrations for worktrees whose directories no longer exist.
+func (g *GitClient) PruneWorktrees() error {
+	logging.Entry()
+	cmd := exec.Command("git", "-C", g.repoRoot, "worktree", "prune")
+	if err := g.watchdog.Run(cmd); err != nil {
+		logging.Error(err)
+		return err
+	}
+	logging.Info("worktrees pruned")
+	return nil
+}
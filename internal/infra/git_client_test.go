@@ -1,10 +1,14 @@
 package infra
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
 )
 
 // setupTestRepo creates a temporary git repository for testing.
@@ -316,6 +320,53 @@ func TestGitClient_StashPop(t *testing.T) {
 	}
 }
 
+func TestGitClient_ListStashes(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	client := NewGitClient(repoDir)
+	branch, _ := client.CurrentBranch(repoDir)
+
+	readmeFile := filepath.Join(repoDir, "README.md")
+	_ = os.WriteFile(readmeFile, []byte("# Modified for stash list"), 0o644)
+	_ = client.Stash(repoDir)
+
+	stashes, err := client.ListStashes()
+	if err != nil {
+		t.Fatalf("ListStashes should not return error: %v", err)
+	}
+	if len(stashes) != 1 {
+		t.Fatalf("got %d stashes, want 1", len(stashes))
+	}
+	if stashes[0].Ref != "stash@{0}" || stashes[0].Branch != branch || stashes[0].Message != domain.AutoStashMessage {
+		t.Errorf("stash = %+v", stashes[0])
+	}
+
+	if err := client.StashBranch("stash@{0}", "rescued"); err != nil {
+		t.Fatalf("StashBranch should not return error: %v", err)
+	}
+	if !client.BranchExists("rescued") || !client.HasUncommittedChanges(repoDir) {
+		t.Error("StashBranch should check out the new branch with the stashed changes")
+	}
+	if stashes, _ = client.ListStashes(); len(stashes) != 0 {
+		t.Errorf("StashBranch should drop the stash, got %+v", stashes)
+	}
+}
+
+func TestParseStashList(t *testing.T) {
+	stashes := parseStashList("stash@{0}\t1700000000\tOn craizy/auth: craizy-auto-stash\nstash@{1}\t1600000000\tWIP on main: abc1234 Fix login\n")
+
+	if len(stashes) != 2 {
+		t.Fatalf("got %d stashes, want 2", len(stashes))
+	}
+	if stashes[0].Branch != "craizy/auth" || stashes[0].Message != "craizy-auto-stash" || stashes[0].CreatedAt.Unix() != 1700000000 {
+		t.Errorf("stash = %+v", stashes[0])
+	}
+	if stashes[1].Ref != "stash@{1}" || stashes[1].Branch != "main" || stashes[1].Message != "abc1234 Fix login" {
+		t.Errorf("stash = %+v", stashes[1])
+	}
+}
+
 func TestGitClient_Merge(t *testing.T) {
 	repoDir, cleanup := setupTestRepo(t)
 	defer cleanup()
@@ -388,3 +439,661 @@ func TestGitClient_MergeAbort(t *testing.T) {
 		t.Errorf("MergeAbort should not return error: %v", err)
 	}
 }
+
+func TestGitClient_Push(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	// Create a bare repository to act as origin
+	remoteDir, err := os.MkdirTemp("", "git-remote-*")
+	if err != nil {
+		t.Fatalf("failed to create remote dir: %v", err)
+	}
+	defer os.RemoveAll(remoteDir)
+	if err := exec.Command("git", "init", "--bare", remoteDir).Run(); err != nil {
+		t.Fatalf("failed to init bare repo: %v", err)
+	}
+	if err := exec.Command("git", "-C", repoDir, "remote", "add", "origin", remoteDir).Run(); err != nil {
+		t.Fatalf("failed to add remote: %v", err)
+	}
+
+	client := NewGitClient(repoDir)
+	branch, _ := client.CurrentBranch(repoDir)
+
+	if err := client.Push(branch); err != nil {
+		t.Fatalf("Push should not return error: %v", err)
+	}
+
+	// Verify the branch exists on the remote
+	if err := exec.Command("git", "-C", remoteDir, "show-ref", "--verify", "refs/heads/"+branch).Run(); err != nil {
+		t.Errorf("branch %s should exist on remote", branch)
+	}
+
+	// Pushing an unknown branch should fail with output in the error
+	if err := client.Push("does-not-exist"); err == nil {
+		t.Error("Push should fail for a nonexistent branch")
+	}
+}
+
+func TestGitClient_RebaseOnto(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	client := NewGitClient(repoDir)
+	mainBranch, _ := client.CurrentBranch(repoDir)
+
+	// Create a release branch with its own commit
+	_ = exec.Command("git", "-C", repoDir, "branch", "release").Run()
+	_ = exec.Command("git", "-C", repoDir, "checkout", "-q", "release").Run()
+	_ = os.WriteFile(filepath.Join(repoDir, "release.txt"), []byte("release"), 0o644)
+	_ = exec.Command("git", "-C", repoDir, "add", ".").Run()
+	_ = exec.Command("git", "-C", repoDir, "commit", "-q", "-m", "release commit").Run()
+	_ = exec.Command("git", "-C", repoDir, "checkout", "-q", mainBranch).Run()
+
+	// Create an agent worktree based on main with one commit
+	worktreePath := filepath.Join(repoDir, ".craizy", "worktrees", "agent")
+	if err := client.CreateWorktree(worktreePath, "agent-branch", mainBranch); err != nil {
+		t.Fatalf("failed to create worktree: %v", err)
+	}
+	_ = os.WriteFile(filepath.Join(worktreePath, "agent.txt"), []byte("agent"), 0o644)
+	_ = exec.Command("git", "-C", worktreePath, "add", ".").Run()
+	_ = exec.Command("git", "-C", worktreePath, "commit", "-q", "-m", "agent commit").Run()
+
+	if err := client.RebaseOnto(worktreePath, "release", mainBranch); err != nil {
+		t.Fatalf("RebaseOnto should not return error: %v", err)
+	}
+
+	// The agent branch should now contain the release commit
+	if _, err := os.Stat(filepath.Join(worktreePath, "release.txt")); err != nil {
+		t.Error("worktree should contain release.txt after rebase")
+	}
+	if _, err := os.Stat(filepath.Join(worktreePath, "agent.txt")); err != nil {
+		t.Error("worktree should keep agent.txt after rebase")
+	}
+}
+
+func TestGitClient_AddCommit(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	client := NewGitClient(repoDir)
+	_ = os.WriteFile(filepath.Join(repoDir, "new.txt"), []byte("new"), 0o644)
+
+	if err := client.Add(repoDir); err != nil {
+		t.Fatalf("Add should not return error: %v", err)
+	}
+	if err := client.Commit(repoDir, "Snapshot work"); err != nil {
+		t.Fatalf("Commit should not return error: %v", err)
+	}
+
+	subject, _ := exec.Command("git", "-C", repoDir, "log", "--format=%s", "-1").Output()
+	if strings.TrimSpace(string(subject)) != "Snapshot work" {
+		t.Errorf("last commit = %q, want Snapshot work", subject)
+	}
+	if client.HasUncommittedChanges(repoDir) {
+		t.Error("expected a clean worktree after committing the untracked file")
+	}
+	if err := client.Commit(repoDir, "Nothing"); err == nil {
+		t.Error("expected an error committing nothing")
+	}
+}
+
+func TestGitClient_Squash(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	client := NewGitClient(repoDir)
+	baseBranch, _ := client.CurrentBranch(repoDir)
+	_ = exec.Command("git", "-C", repoDir, "checkout", "-q", "-b", "feature-branch").Run()
+	for _, name := range []string{"one.txt", "two.txt"} {
+		_ = os.WriteFile(filepath.Join(repoDir, name), []byte(name), 0o644)
+		_ = exec.Command("git", "-C", repoDir, "add", ".").Run()
+		_ = exec.Command("git", "-C", repoDir, "commit", "-q", "-m", "Add "+name).Run()
+	}
+	_ = exec.Command("git", "-C", repoDir, "checkout", "-q", baseBranch).Run()
+
+	if err := client.Squash("feature-branch", "Squash merge branch 'feature-branch'"); err != nil {
+		t.Fatalf("Squash should not return error: %v", err)
+	}
+
+	subject, _ := exec.Command("git", "-C", repoDir, "log", "--format=%s", "-1").Output()
+	parents, _ := exec.Command("git", "-C", repoDir, "log", "--format=%P", "-1").Output()
+	if strings.TrimSpace(string(subject)) != "Squash merge branch 'feature-branch'" || len(strings.Fields(string(parents))) != 1 {
+		t.Errorf("last commit = %q with parents %q, want the squash commit with one parent", subject, parents)
+	}
+	for _, name := range []string{"one.txt", "two.txt"} {
+		if _, err := os.Stat(filepath.Join(repoDir, name)); err != nil {
+			t.Errorf("expected %s after the squash", name)
+		}
+	}
+
+	// Squashing it again has nothing to commit
+	if err := client.Squash("feature-branch", "Again"); err != nil {
+		t.Errorf("empty Squash should not return error: %v", err)
+	}
+}
+
+func TestGitClient_SquashAbort(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	client := NewGitClient(repoDir)
+	baseBranch, _ := client.CurrentBranch(repoDir)
+	readmeFile := filepath.Join(repoDir, "README.md")
+	_ = exec.Command("git", "-C", repoDir, "checkout", "-q", "-b", "conflict-branch").Run()
+	_ = os.WriteFile(readmeFile, []byte("# Feature version"), 0o644)
+	_ = exec.Command("git", "-C", repoDir, "commit", "-q", "-am", "Feature change").Run()
+	_ = exec.Command("git", "-C", repoDir, "checkout", "-q", baseBranch).Run()
+	_ = os.WriteFile(readmeFile, []byte("# Base version"), 0o644)
+	_ = exec.Command("git", "-C", repoDir, "commit", "-q", "-am", "Base change").Run()
+
+	if err := client.Squash("conflict-branch", "Squash"); err == nil {
+		t.Fatal("expected a conflict")
+	}
+	if err := client.MergeAbort(); err != nil {
+		t.Fatalf("MergeAbort should not return error: %v", err)
+	}
+	if client.HasUncommittedChanges(repoDir) {
+		t.Error("expected the squash conflict to be reset")
+	}
+}
+
+func TestGitClient_Rebase(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	client := NewGitClient(repoDir)
+	mainBranch, _ := client.CurrentBranch(repoDir)
+	worktreePath := filepath.Join(repoDir, ".craizy", "worktrees", "agent")
+	if err := client.CreateWorktree(worktreePath, "agent-branch", mainBranch); err != nil {
+		t.Fatalf("failed to create worktree: %v", err)
+	}
+	_ = os.WriteFile(filepath.Join(worktreePath, "agent.txt"), []byte("agent"), 0o644)
+	_ = exec.Command("git", "-C", worktreePath, "add", ".").Run()
+	_ = exec.Command("git", "-C", worktreePath, "commit", "-q", "-m", "agent commit").Run()
+	// Move main on, so the agent branch needs rebasing
+	_ = os.WriteFile(filepath.Join(repoDir, "main.txt"), []byte("main"), 0o644)
+	_ = exec.Command("git", "-C", repoDir, "add", "main.txt").Run()
+	_ = exec.Command("git", "-C", repoDir, "commit", "-q", "-m", "main commit").Run()
+
+	if err := client.Rebase(worktreePath, mainBranch, "agent-branch"); err != nil {
+		t.Fatalf("Rebase should not return error: %v", err)
+	}
+
+	out, _ := exec.Command("git", "-C", repoDir, "log", "--format=%s", mainBranch).Output()
+	if !strings.HasPrefix(string(out), "agent commit\nmain commit\n") {
+		t.Errorf("log = %q, want the agent commit on top of main without a merge commit", out)
+	}
+}
+
+func TestParseWorktreeList(t *testing.T) {
+	output := `worktree /repo
+HEAD 1111111111111111111111111111111111111111
+branch refs/heads/main
+
+worktree /repo/.craizy/worktrees/agent
+HEAD 2222222222222222222222222222222222222222
+branch refs/heads/agent-branch
+locked
+
+worktree /tmp/detached
+HEAD 3333333333333333333333333333333333333333
+detached
+prunable gitdir file points to non-existent location
+`
+
+	worktrees := parseWorktreeList(output)
+
+	if len(worktrees) != 3 {
+		t.Fatalf("got %d worktrees, want 3", len(worktrees))
+	}
+	if worktrees[0].Path != "/repo" || worktrees[0].Branch != "main" {
+		t.Errorf("worktrees[0] = %+v", worktrees[0])
+	}
+	if worktrees[1].Branch != "agent-branch" || !worktrees[1].Locked {
+		t.Errorf("worktrees[1] = %+v, want locked agent-branch", worktrees[1])
+	}
+	if worktrees[2].Branch != "" || !worktrees[2].Prunable {
+		t.Errorf("worktrees[2] = %+v, want detached prunable", worktrees[2])
+	}
+}
+
+func TestGitClient_ListWorktrees(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	client := NewGitClient(repoDir)
+	baseBranch, _ := client.CurrentBranch(repoDir)
+
+	worktreePath := filepath.Join(repoDir, ".craizy", "worktrees", "agent")
+	if err := client.CreateWorktree(worktreePath, "agent-branch", baseBranch); err != nil {
+		t.Fatalf("failed to create worktree: %v", err)
+	}
+
+	worktrees, err := client.ListWorktrees()
+	if err != nil {
+		t.Fatalf("ListWorktrees should not return error: %v", err)
+	}
+	if len(worktrees) != 2 {
+		t.Fatalf("got %d worktrees, want 2", len(worktrees))
+	}
+	if worktrees[1].Branch != "agent-branch" {
+		t.Errorf("worktree branch = %q, want agent-branch", worktrees[1].Branch)
+	}
+
+	// Deleting the directory leaves a prunable registration until pruned
+	_ = os.RemoveAll(worktreePath)
+	worktrees, _ = client.ListWorktrees()
+	if len(worktrees) != 2 || !worktrees[1].Prunable {
+		t.Errorf("removed worktree should be prunable, got %+v", worktrees)
+	}
+
+	if err := client.PruneWorktrees(); err != nil {
+		t.Fatalf("PruneWorktrees should not return error: %v", err)
+	}
+	worktrees, _ = client.ListWorktrees()
+	if len(worktrees) != 1 {
+		t.Errorf("got %d worktrees after prune, want 1", len(worktrees))
+	}
+}
+
+func TestGitClient_LockWorktree(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	client := NewGitClient(repoDir)
+	baseBranch, _ := client.CurrentBranch(repoDir)
+
+	worktreePath := filepath.Join(repoDir, ".craizy", "worktrees", "agent")
+	if err := client.CreateWorktree(worktreePath, "agent-branch", baseBranch); err != nil {
+		t.Fatalf("failed to create worktree: %v", err)
+	}
+
+	if err := client.LockWorktree(worktreePath, "in use"); err != nil {
+		t.Fatalf("LockWorktree should not return error: %v", err)
+	}
+
+	// A locked worktree survives prune even when its directory is gone
+	_ = os.Rename(worktreePath, worktreePath+".moved")
+	_ = client.PruneWorktrees()
+	worktrees, _ := client.ListWorktrees()
+	if len(worktrees) != 2 || !worktrees[1].Locked || !worktrees[1].Missing {
+		t.Fatalf("locked worktree should survive prune and be reported missing, got %+v", worktrees)
+	}
+	_ = os.Rename(worktreePath+".moved", worktreePath)
+
+	if err := client.UnlockWorktree(worktreePath); err != nil {
+		t.Fatalf("UnlockWorktree should not return error: %v", err)
+	}
+	worktrees, _ = client.ListWorktrees()
+	if worktrees[1].Locked {
+		t.Error("worktree should be unlocked")
+	}
+}
+
+func TestGitClient_UpdateSubmodules(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	client := NewGitClient(repoDir)
+
+	// A repository without submodules is a no-op
+	if err := client.UpdateSubmodules(repoDir); err != nil {
+		t.Errorf("UpdateSubmodules should not return error: %v", err)
+	}
+
+	if err := client.UpdateSubmodules(filepath.Join(repoDir, "missing")); err == nil {
+		t.Error("UpdateSubmodules should fail for a nonexistent path")
+	}
+}
+
+func TestGitClient_CreateSparseWorktree(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	client := NewGitClient(repoDir)
+	baseBranch, _ := client.CurrentBranch(repoDir)
+
+	// Add two top-level directories so the checkout can be narrowed to one
+	for _, dir := range []string{"service-a", "service-b"} {
+		_ = os.MkdirAll(filepath.Join(repoDir, dir), 0o755)
+		_ = os.WriteFile(filepath.Join(repoDir, dir, "main.go"), []byte("package main"), 0o644)
+	}
+	_ = exec.Command("git", "-C", repoDir, "add", ".").Run()
+	_ = exec.Command("git", "-C", repoDir, "commit", "-q", "-m", "add services").Run()
+
+	worktreePath := filepath.Join(repoDir, ".craizy", "worktrees", "sparse")
+	err := client.CreateSparseWorktree(worktreePath, "sparse-branch", baseBranch, []string{"service-a"})
+	if err != nil {
+		t.Fatalf("CreateSparseWorktree should not return error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(worktreePath, "service-a", "main.go")); err != nil {
+		t.Error("sparse worktree should contain service-a")
+	}
+	if _, err := os.Stat(filepath.Join(worktreePath, "service-b")); !os.IsNotExist(err) {
+		t.Error("sparse worktree should not contain service-b")
+	}
+
+	worktreeBranch, _ := client.CurrentBranch(worktreePath)
+	if worktreeBranch != "sparse-branch" {
+		t.Errorf("Worktree should be on sparse-branch, got: %s", worktreeBranch)
+	}
+}
+
+func TestGitClient_CloneWorktree(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	client := NewGitClient(repoDir)
+	baseBranch, _ := client.CurrentBranch(repoDir)
+	commit := func(msg string) {
+		_ = exec.Command("git", "-C", repoDir, "add", "-A").Run()
+		_ = exec.Command("git", "-C", repoDir, "commit", "-q", "-m", msg).Run()
+	}
+	_ = os.WriteFile(filepath.Join(repoDir, "gone.txt"), []byte("gone"), 0o644)
+	commit("add gone.txt")
+
+	templatePath := filepath.Join(repoDir, ".craizy", "template")
+	if err := client.RefreshTemplate(templatePath, baseBranch); err != nil {
+		t.Fatalf("RefreshTemplate should not return error: %v", err)
+	}
+
+	// Move the base on so the template is stale
+	_ = os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("# Changed"), 0o644)
+	_ = os.WriteFile(filepath.Join(repoDir, "new.txt"), []byte("new"), 0o644)
+	_ = os.Remove(filepath.Join(repoDir, "gone.txt"))
+	commit("change files")
+
+	worktreePath := filepath.Join(repoDir, ".craizy", "worktrees", "cloned")
+	if err := client.CloneWorktree(templatePath, worktreePath, "cloned-branch", baseBranch); err != nil {
+		t.Fatalf("CloneWorktree should not return error: %v", err)
+	}
+
+	if data, _ := os.ReadFile(filepath.Join(worktreePath, "README.md")); string(data) != "# Changed" {
+		t.Errorf("README.md = %q, want the base branch's content", data)
+	}
+	if _, err := os.Stat(filepath.Join(worktreePath, "new.txt")); err != nil {
+		t.Error("cloned worktree should contain new.txt")
+	}
+	if _, err := os.Stat(filepath.Join(worktreePath, "gone.txt")); !os.IsNotExist(err) {
+		t.Error("cloned worktree should not contain gone.txt")
+	}
+	if client.HasUncommittedChanges(worktreePath) {
+		t.Error("cloned worktree should be clean")
+	}
+	if branch, _ := client.CurrentBranch(worktreePath); branch != "cloned-branch" {
+		t.Errorf("Worktree should be on cloned-branch, got: %s", branch)
+	}
+
+	// Refreshing catches the template up
+	if err := client.RefreshTemplate(templatePath, baseBranch); err != nil {
+		t.Fatalf("RefreshTemplate should not return error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(templatePath, "new.txt")); err != nil {
+		t.Error("refreshed template should contain new.txt")
+	}
+}
+
+func TestGitClient_MergeSigning(t *testing.T) {
+	// setupDiverged creates a feature branch and a base commit so merging needs a merge commit.
+	setupDiverged := func(t *testing.T) (string, func()) {
+		repoDir, cleanup := setupTestRepo(t)
+		baseBranch, _ := NewGitClient(repoDir).CurrentBranch(repoDir)
+		_ = exec.Command("git", "-C", repoDir, "checkout", "-q", "-b", "feature").Run()
+		_ = os.WriteFile(filepath.Join(repoDir, "feature.txt"), []byte("feature"), 0o644)
+		_ = exec.Command("git", "-C", repoDir, "add", ".").Run()
+		_ = exec.Command("git", "-C", repoDir, "commit", "-q", "-m", "feature").Run()
+		_ = exec.Command("git", "-C", repoDir, "checkout", "-q", baseBranch).Run()
+		_ = os.WriteFile(filepath.Join(repoDir, "base.txt"), []byte("base"), 0o644)
+		_ = exec.Command("git", "-C", repoDir, "add", ".").Run()
+		_ = exec.Command("git", "-C", repoDir, "commit", "-q", "-m", "base").Run()
+
+		// Make any signing attempt fail
+		_ = exec.Command("git", "-C", repoDir, "config", "gpg.program", "false").Run()
+		return repoDir, cleanup
+	}
+
+	t.Run("always surfaces signing failure", func(t *testing.T) {
+		repoDir, cleanup := setupDiverged(t)
+		defer cleanup()
+
+		client := NewGitClient(repoDir)
+		client.SetCommitSigning(CommitSigningAlways)
+
+		err := client.Merge("feature")
+		if !errors.Is(err, domain.ErrCommitSigning) {
+			t.Fatalf("Merge error = %v, want ErrCommitSigning", err)
+		}
+		_ = client.MergeAbort()
+	})
+
+	t.Run("failed squash commit is unstaged", func(t *testing.T) {
+		repoDir, cleanup := setupDiverged(t)
+		defer cleanup()
+
+		client := NewGitClient(repoDir)
+		client.SetCommitSigning(CommitSigningAlways)
+
+		err := client.Squash("feature", "Squash feature")
+		if !errors.Is(err, domain.ErrCommitSigning) {
+			t.Fatalf("Squash error = %v, want ErrCommitSigning", err)
+		}
+		if client.HasUncommittedChanges(repoDir) {
+			t.Error("expected the failed squash to leave nothing staged")
+		}
+		if _, err := os.Stat(filepath.Join(repoDir, "feature.txt")); !os.IsNotExist(err) {
+			t.Error("expected feature.txt to be removed with the failed squash")
+		}
+	})
+
+	t.Run("never overrides repo config", func(t *testing.T) {
+		repoDir, cleanup := setupDiverged(t)
+		defer cleanup()
+		_ = exec.Command("git", "-C", repoDir, "config", "commit.gpgsign", "true").Run()
+
+		client := NewGitClient(repoDir)
+		client.SetCommitSigning(CommitSigningNever)
+
+		if err := client.Merge("feature"); err != nil {
+			t.Errorf("Merge should not return error: %v", err)
+		}
+	})
+
+	t.Run("repo config is respected by default", func(t *testing.T) {
+		repoDir, cleanup := setupDiverged(t)
+		defer cleanup()
+		_ = exec.Command("git", "-C", repoDir, "config", "commit.gpgsign", "true").Run()
+
+		client := NewGitClient(repoDir)
+
+		err := client.Merge("feature")
+		if !errors.Is(err, domain.ErrCommitSigning) {
+			t.Fatalf("Merge error = %v, want ErrCommitSigning", err)
+		}
+		_ = client.MergeAbort()
+	})
+}
+
+func TestGitClient_AheadBehind(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	client := NewGitClient(repoDir)
+	baseBranch, _ := client.CurrentBranch(repoDir)
+
+	// Two commits on feature, one on base
+	_ = exec.Command("git", "-C", repoDir, "checkout", "-q", "-b", "feature").Run()
+	for _, name := range []string{"a.txt", "b.txt"} {
+		_ = os.WriteFile(filepath.Join(repoDir, name), []byte(name), 0o644)
+		_ = exec.Command("git", "-C", repoDir, "add", ".").Run()
+		_ = exec.Command("git", "-C", repoDir, "commit", "-q", "-m", name).Run()
+	}
+	_ = exec.Command("git", "-C", repoDir, "checkout", "-q", baseBranch).Run()
+	_ = os.WriteFile(filepath.Join(repoDir, "base.txt"), []byte("base"), 0o644)
+	_ = exec.Command("git", "-C", repoDir, "add", ".").Run()
+	_ = exec.Command("git", "-C", repoDir, "commit", "-q", "-m", "base").Run()
+
+	ahead, behind, err := client.AheadBehind("feature", baseBranch)
+	if err != nil {
+		t.Fatalf("AheadBehind should not return error: %v", err)
+	}
+	if ahead != 2 || behind != 1 {
+		t.Errorf("AheadBehind = (%d, %d), want (2, 1)", ahead, behind)
+	}
+
+	if _, _, err := client.AheadBehind("missing", baseBranch); err == nil {
+		t.Error("AheadBehind should fail for a nonexistent branch")
+	}
+}
+
+func TestGitClient_RenameBranch(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	client := NewGitClient(repoDir)
+	baseBranch, _ := client.CurrentBranch(repoDir)
+
+	worktreePath := filepath.Join(repoDir, ".craizy", "worktrees", "pool")
+	if err := client.CreateWorktree(worktreePath, "pool-branch", baseBranch); err != nil {
+		t.Fatalf("failed to create worktree: %v", err)
+	}
+
+	if err := client.RenameBranch("pool-branch", "task-branch"); err != nil {
+		t.Fatalf("RenameBranch should not return error: %v", err)
+	}
+
+	if client.BranchExists("pool-branch") || !client.BranchExists("task-branch") {
+		t.Error("branch should be renamed")
+	}
+	if branch, _ := client.CurrentBranch(worktreePath); branch != "task-branch" {
+		t.Errorf("worktree should follow the rename, got %s", branch)
+	}
+}
+
+func TestGitClient_CommitsAhead(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	client := NewGitClient(repoDir)
+	baseBranch, _ := client.CurrentBranch(repoDir)
+
+	_ = exec.Command("git", "-C", repoDir, "checkout", "-q", "-b", "feature").Run()
+	for _, name := range []string{"a.txt", "b.txt"} {
+		_ = os.WriteFile(filepath.Join(repoDir, name), []byte(name), 0o644)
+		_ = exec.Command("git", "-C", repoDir, "add", ".").Run()
+		_ = exec.Command("git", "-C", repoDir, "commit", "-q", "-m", "add "+name).Run()
+	}
+
+	commits, err := client.CommitsAhead("feature", baseBranch)
+	if err != nil {
+		t.Fatalf("CommitsAhead should not return error: %v", err)
+	}
+	if len(commits) != 2 || commits[0].Subject != "add b.txt" || commits[1].Subject != "add a.txt" {
+		t.Fatalf("commits = %+v, want [add b.txt, add a.txt]", commits)
+	}
+
+	diff, err := client.ShowCommit(commits[0].Hash)
+	if err != nil {
+		t.Fatalf("ShowCommit should not return error: %v", err)
+	}
+	if !strings.Contains(diff, "b.txt") || !strings.Contains(diff, "+b.txt") {
+		t.Errorf("diff should include stat and patch for b.txt, got:\n%s", diff)
+	}
+
+	if _, err := client.CommitsAhead("missing", baseBranch); err == nil {
+		t.Error("CommitsAhead should fail for a nonexistent branch")
+	}
+}
+
+func TestGitClient_DiffStat(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	client := NewGitClient(repoDir)
+	base, _ := client.CurrentBranch(repoDir)
+	_ = exec.Command("git", "-C", repoDir, "checkout", "-b", "feature").Run()
+	_ = os.WriteFile(filepath.Join(repoDir, "feature.txt"), []byte("one\ntwo\n"), 0o644)
+	_ = exec.Command("git", "-C", repoDir, "add", ".").Run()
+	_ = exec.Command("git", "-C", repoDir, "commit", "-m", "Add feature").Run()
+
+	stat, err := client.DiffStat("feature", base)
+	if err != nil {
+		t.Fatalf("DiffStat should not return error: %v", err)
+	}
+	if !strings.Contains(stat, "feature.txt") || !strings.Contains(stat, "1 file changed, 2 insertions(+)") {
+		t.Errorf("DiffStat = %q", stat)
+	}
+
+	diff, err := client.Diff(repoDir, base, "feature")
+	if err != nil {
+		t.Fatalf("Diff should not return error: %v", err)
+	}
+	if !strings.Contains(diff, "+++ b/feature.txt") || !strings.Contains(diff, "+two") {
+		t.Errorf("Diff = %q, want the patch adding feature.txt", diff)
+	}
+}
+
+func TestGitClient_BatchStatus(t *testing.T) {
+	repoDir, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	client := NewGitClient(repoDir)
+	baseBranch, _ := client.CurrentBranch(repoDir)
+
+	clean := filepath.Join(repoDir, ".craizy", "worktrees", "clean")
+	busy := filepath.Join(repoDir, ".craizy", "worktrees", "busy")
+	_ = client.CreateWorktree(clean, "clean-branch", baseBranch)
+	_ = client.CreateWorktree(busy, "busy-branch", baseBranch)
+
+	// Commit one file on busy and leave another uncommitted
+	_ = os.WriteFile(filepath.Join(busy, "feature.txt"), []byte("one\ntwo\n"), 0o644)
+	_ = exec.Command("git", "-C", busy, "add", ".").Run()
+	_ = exec.Command("git", "-C", busy, "commit", "-q", "-m", "feature").Run()
+	_ = os.WriteFile(filepath.Join(busy, "README.md"), []byte("# Changed"), 0o644)
+
+	statuses := client.BatchStatus([]domain.StatusTarget{
+		{Path: clean, BaseBranch: baseBranch},
+		{Path: busy, BaseBranch: baseBranch},
+		{Path: filepath.Join(repoDir, "missing"), BaseBranch: baseBranch},
+	})
+
+	if len(statuses) != 3 {
+		t.Fatalf("got %d statuses, want 3", len(statuses))
+	}
+	if got := statuses[0]; got.Err != nil || got.Branch != "clean-branch" || got.Dirty || got.Ahead != 0 || got.Files != 0 {
+		t.Errorf("clean status = %+v", got)
+	}
+	want := domain.WorktreeStatus{Path: busy, Branch: "busy-branch", Dirty: true, Ahead: 1, Files: 2, Insertions: 3, Deletions: 1}
+	if got := statuses[1]; got != want {
+		t.Errorf("busy status = %+v, want %+v", got, want)
+	}
+	if statuses[2].Err == nil {
+		t.Error("expected an error for a missing worktree")
+	}
+}
+
+func TestParseShortStat(t *testing.T) {
+	files, insertions, deletions := parseShortStat(" 3 files changed, 10 insertions(+), 2 deletions(-)\n")
+	if files != 3 || insertions != 10 || deletions != 2 {
+		t.Errorf("got %d, %d, %d, want 3, 10, 2", files, insertions, deletions)
+	}
+	files, insertions, deletions = parseShortStat(" 1 file changed, 1 deletion(-)\n")
+	if files != 1 || insertions != 0 || deletions != 1 {
+		t.Errorf("got %d, %d, %d, want 1, 0, 1", files, insertions, deletions)
+	}
+	if files, _, _ := parseShortStat(""); files != 0 {
+		t.Errorf("files = %d, want 0 for no changes", files)
+	}
+}
+
+func TestParseCommitLog(t *testing.T) {
+	commits := parseCommitLog("abc1234\tFix login\ndef5678\tAdd tests: part\t2\n")
+
+	if len(commits) != 2 {
+		t.Fatalf("got %d commits, want 2", len(commits))
+	}
+	if commits[1].Hash != "def5678" || commits[1].Subject != "Add tests: part\t2" {
+		t.Errorf("commit = %+v", commits[1])
+	}
+}
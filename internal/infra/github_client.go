@@ -0,0 +1,56 @@
+package infra
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// GitHubClient implements domain.IGitHubClient using the gh CLI.
+type GitHubClient struct {
+	// repoRoot is the root directory of the git repository.
+	repoRoot string
+}
+
+// NewGitHubClient creates a new GitHubClient for the given repository root.
+func NewGitHubClient(repoRoot string) *GitHubClient {
+	return &GitHubClient{repoRoot: repoRoot}
+}
+
+// IsBranchProtected checks if the branch has protection rules on GitHub.
+// Command: gh api repos/{owner}/{repo}/branches/{branch} --jq .protected
+func (g *GitHubClient) IsBranchProtected(branch string) (bool, error) {
+	logging.Entry("branch", branch)
+	cmd := exec.Command("gh", "api", "repos/{owner}/{repo}/branches/"+branch, "--jq", ".protected")
+	cmd.Dir = g.repoRoot
+	output, err := cmd.Output()
+	if err != nil {
+		logging.Debug("gh branch lookup failed (gh missing or no remote?): %v", err)
+		return false, err
+	}
+	protected := strings.TrimSpace(string(output)) == "true"
+	logging.Debug("branch protected=%v, branch=%s", protected, branch)
+	return protected, nil
+}
+
+// CreatePullRequest opens a pull request from branch into baseBranch and returns its URL.
+// Command: gh pr create --base {baseBranch} --head {branch} --fill
+func (g *GitHubClient) CreatePullRequest(branch, baseBranch string) (string, error) {
+	logging.Entry("branch", branch, "baseBranch", baseBranch)
+	cmd := exec.Command("gh", "pr", "create", "--base", baseBranch, "--head", branch, "--fill")
+	cmd.Dir = g.repoRoot
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		err = fmt.Errorf("gh pr create failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "branch", branch)
+		return "", err
+	}
+
+	// gh prints the PR URL as the last line of output
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	url := strings.TrimSpace(lines[len(lines)-1])
+	logging.Info("pull request created, branch=%s, url=%s", branch, url)
+	return url, nil
+}
@@ -0,0 +1,63 @@
+package infra
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// MemoryAliasStore implements IAliasStore with an in-memory map.
+type MemoryAliasStore struct {
+	aliases map[string]domain.Alias
+	mu      sync.RWMutex
+}
+
+// NewMemoryAliasStore creates a new in-memory alias store.
+func NewMemoryAliasStore() *MemoryAliasStore {
+	return &MemoryAliasStore{aliases: make(map[string]domain.Alias)}
+}
+
+// Set stores an alias, replacing any of the same name.
+func (s *MemoryAliasStore) Set(alias *domain.Alias) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.aliases[alias.Name] = *alias
+	return nil
+}
+
+// Get retrieves an alias by name, or nil if there is none.
+func (s *MemoryAliasStore) Get(name string) (*domain.Alias, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	alias, ok := s.aliases[name]
+	if !ok {
+		return nil, nil
+	}
+	return &alias, nil
+}
+
+// Delete removes an alias, failing if there is none of that name.
+func (s *MemoryAliasStore) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.aliases[name]; !ok {
+		return fmt.Errorf("alias not found: %s", name)
+	}
+	delete(s.aliases, name)
+	return nil
+}
+
+// List returns every alias, by name.
+func (s *MemoryAliasStore) List() ([]*domain.Alias, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	aliases := make([]*domain.Alias, 0, len(s.aliases))
+	for _, alias := range s.aliases {
+		alias := alias
+		aliases = append(aliases, &alias)
+	}
+	sort.Slice(aliases, func(i, j int) bool { return aliases[i].Name < aliases[j].Name })
+	return aliases, nil
+}
@@ -0,0 +1,62 @@
+package infra
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// MemoryMergeStore implements IMergeStore with an in-memory slice.
+type MemoryMergeStore struct {
+	records []*domain.MergeRecord
+	mu      sync.RWMutex
+}
+
+// NewMemoryMergeStore creates a new in-memory merge store.
+func NewMemoryMergeStore() *MemoryMergeStore {
+	return &MemoryMergeStore{}
+}
+
+// Save stores a merge record.
+func (s *MemoryMergeStore) Save(record *domain.MergeRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, record)
+	return nil
+}
+
+// ListByAgent returns merge records for an agent, newest first.
+func (s *MemoryMergeStore) ListByAgent(agentID string) ([]*domain.MergeRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var records []*domain.MergeRecord
+	for _, r := range s.records {
+		if r.AgentID == agentID {
+			records = append(records, r)
+		}
+	}
+	sortMergesNewestFirst(records)
+	return records, nil
+}
+
+// List returns merge records across all agents with a limit (0 = no limit), newest first.
+func (s *MemoryMergeStore) List(limit int) ([]*domain.MergeRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	records := make([]*domain.MergeRecord, len(s.records))
+	copy(records, s.records)
+	sortMergesNewestFirst(records)
+	if limit > 0 && len(records) > limit {
+		records = records[:limit]
+	}
+	return records, nil
+}
+
+func sortMergesNewestFirst(records []*domain.MergeRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].CreatedAt.After(records[j].CreatedAt)
+	})
+}
@@ -0,0 +1,213 @@
+package infra
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// MemoryMessageStore implements IMessageStore with an in-memory map.
+// It mirrors SQLiteMessageStore's behavior and backs --ephemeral mode and tests.
+type MemoryMessageStore struct {
+	messages  map[string]*domain.Message
+	escalated map[string]bool // IDs of messages escalated to the human
+	lastSeq   int
+	mu        sync.RWMutex
+}
+
+// NewMemoryMessageStore creates a new in-memory message store.
+func NewMemoryMessageStore() *MemoryMessageStore {
+	return &MemoryMessageStore{
+		messages:  make(map[string]*domain.Message),
+		escalated: make(map[string]bool),
+	}
+}
+
+// Save stores a new message and assigns its Seq.
+func (s *MemoryMessageStore) Save(msg *domain.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, exists := s.messages[msg.ID]; exists {
+		return fmt.Errorf("failed to insert message: duplicate id %s", msg.ID)
+	}
+	s.lastSeq++
+	msg.Seq = s.lastSeq
+	s.messages[msg.ID] = copyMessage(msg)
+	return nil
+}
+
+// MarkRead marks a message as read.
+func (s *MemoryMessageStore) MarkRead(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if msg, exists := s.messages[id]; exists {
+		now := time.Now()
+		msg.Read = true
+		msg.ReadAt = &now
+	}
+	return nil
+}
+
+// ListUnread returns all unread messages for a recipient, oldest first.
+func (s *MemoryMessageStore) ListUnread(recipientID string) ([]*domain.Message, error) {
+	messages := s.filter(func(m *domain.Message) bool {
+		return m.To == recipientID && !m.Read && m.Due(time.Now())
+	})
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].CreatedAt.Before(messages[j].CreatedAt)
+	})
+	return messages, nil
+}
+
+// List returns up to limit messages for a recipient (0 = no limit), newest
+// first, starting below the before cursor if it is nonzero.
+func (s *MemoryMessageStore) List(recipientID string, before, limit int) ([]*domain.Message, error) {
+	now := time.Now()
+	return s.page(func(m *domain.Message) bool { return m.To == recipientID && m.Due(now) }, before, limit), nil
+}
+
+// ListSent returns up to limit messages sent by a participant (0 = no limit),
+// newest first, starting below the before cursor if it is nonzero.
+func (s *MemoryMessageStore) ListSent(senderID string, before, limit int) ([]*domain.Message, error) {
+	return s.page(func(m *domain.Message) bool { return m.From == senderID }, before, limit), nil
+}
+
+// page returns up to limit of the messages matching keep, newest first,
+// starting below the before cursor if it is nonzero.
+func (s *MemoryMessageStore) page(keep func(*domain.Message) bool, before, limit int) []*domain.Message {
+	messages := s.filter(func(m *domain.Message) bool {
+		return keep(m) && (before == 0 || m.Seq < before)
+	})
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].Seq > messages[j].Seq
+	})
+	if limit > 0 && len(messages) > limit {
+		messages = messages[:limit]
+	}
+	return messages
+}
+
+// Get retrieves a message by ID.
+func (s *MemoryMessageStore) Get(id string) (*domain.Message, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	msg, exists := s.messages[id]
+	if !exists {
+		return nil, fmt.Errorf("message not found: %s", id)
+	}
+	return copyMessage(msg), nil
+}
+
+// GetBySeq retrieves a message by its short sequence number.
+func (s *MemoryMessageStore) GetBySeq(seq int) (*domain.Message, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, msg := range s.messages {
+		if msg.Seq == seq {
+			return copyMessage(msg), nil
+		}
+	}
+	return nil, fmt.Errorf("message not found: M-%d", seq)
+}
+
+// UnreadCount returns the count of unread messages for a recipient.
+func (s *MemoryMessageStore) UnreadCount(recipientID string) (int, error) {
+	unread, _ := s.ListUnread(recipientID)
+	return len(unread), nil
+}
+
+// UnreadCountsByRecipient returns the count of unread messages for every recipient that has any.
+func (s *MemoryMessageStore) UnreadCountsByRecipient() (map[string]int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	now := time.Now()
+	counts := make(map[string]int)
+	for _, msg := range s.messages {
+		if !msg.Read && msg.Due(now) {
+			counts[msg.To]++
+		}
+	}
+	return counts, nil
+}
+
+// ListDue returns unread scheduled messages due by now, oldest first.
+func (s *MemoryMessageStore) ListDue(now time.Time) ([]*domain.Message, error) {
+	messages := s.filter(func(m *domain.Message) bool {
+		return !m.Read && m.DeliverAt != nil && m.Due(now)
+	})
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].Seq < messages[j].Seq
+	})
+	return messages, nil
+}
+
+// ListUnescalatedQuestions returns unread questions from one agent to another
+// that haven't been escalated to the human, oldest first.
+func (s *MemoryMessageStore) ListUnescalatedQuestions() ([]*domain.Message, error) {
+	messages := s.filter(func(m *domain.Message) bool {
+		return m.Type == domain.MessageTypeQuestion && !m.Read && !s.escalated[m.ID] &&
+			!domain.IsHumanParticipant(m.From) && !domain.IsHumanParticipant(m.To)
+	})
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].Seq < messages[j].Seq
+	})
+	return messages, nil
+}
+
+// MarkEscalated records that a message has been escalated to the human.
+func (s *MemoryMessageStore) MarkEscalated(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.escalated[id] = true
+	return nil
+}
+
+// ListThread returns messages sent to or from a participant, oldest first.
+func (s *MemoryMessageStore) ListThread(participantID string) ([]*domain.Message, error) {
+	messages := s.filter(func(m *domain.Message) bool {
+		return m.To == participantID || m.From == participantID
+	})
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].CreatedAt.Before(messages[j].CreatedAt)
+	})
+	return messages, nil
+}
+
+// ListByIDPrefix returns up to limit messages whose ID starts with prefix.
+func (s *MemoryMessageStore) ListByIDPrefix(prefix string, limit int) ([]*domain.Message, error) {
+	messages := s.filter(func(m *domain.Message) bool {
+		return strings.HasPrefix(m.ID, prefix)
+	})
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].CreatedAt.After(messages[j].CreatedAt)
+	})
+	if limit > 0 && len(messages) > limit {
+		messages = messages[:limit]
+	}
+	return messages, nil
+}
+
+// filter returns copies of the messages matching keep.
+func (s *MemoryMessageStore) filter(keep func(*domain.Message) bool) []*domain.Message {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var messages []*domain.Message
+	for _, msg := range s.messages {
+		if keep(msg) {
+			messages = append(messages, copyMessage(msg))
+		}
+	}
+	return messages
+}
+
+// copyMessage returns a copy so callers can't mutate stored state.
+func copyMessage(msg *domain.Message) *domain.Message {
+	c := *msg
+	c.Refs = append([]domain.FileRef(nil), msg.Refs...)
+	return &c
+}
@@ -0,0 +1,166 @@
+package infra
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/infra/storetest"
+)
+
+var (
+	_ domain.IAgentStore   = (*MemoryAgentStore)(nil)
+	_ domain.IMessageStore = (*MemoryMessageStore)(nil)
+	_ domain.IMergeStore   = (*MemoryMergeStore)(nil)
+	_ domain.IAliasStore   = (*MemoryAliasStore)(nil)
+)
+
+func TestMemoryMessageStore(t *testing.T) {
+	now := time.Now()
+	newStore := func() *MemoryMessageStore {
+		store := NewMemoryMessageStore()
+		_ = store.Save(&domain.Message{ID: "m1", From: "a", To: "lead", Content: "first", CreatedAt: now.Add(-2 * time.Minute)})
+		_ = store.Save(&domain.Message{ID: "m2", From: "b", To: "lead", Content: "second", CreatedAt: now.Add(-time.Minute)})
+		_ = store.Save(&domain.Message{ID: "m3", From: "a", To: "other", Content: "elsewhere", CreatedAt: now})
+		return store
+	}
+
+	t.Run("get", func(t *testing.T) {
+		store := newStore()
+
+		msg, err := store.Get("m1")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if msg.Content != "first" {
+			t.Errorf("Content = %q, want first", msg.Content)
+		}
+		if _, err := store.Get("missing"); err == nil {
+			t.Error("expected error for missing message")
+		}
+	})
+
+	t.Run("duplicate save fails", func(t *testing.T) {
+		store := newStore()
+
+		if err := store.Save(&domain.Message{ID: "m1"}); err == nil {
+			t.Error("expected error saving duplicate ID")
+		}
+	})
+
+	t.Run("list newest first with limit", func(t *testing.T) {
+		store := newStore()
+
+		msgs, _ := store.List("lead", 0, 0)
+		if len(msgs) != 2 || msgs[0].ID != "m2" {
+			t.Errorf("List = %v, want [m2 m1]", msgs)
+		}
+		msgs, _ = store.List("lead", 0, 1)
+		if len(msgs) != 1 {
+			t.Errorf("List with limit returned %d, want 1", len(msgs))
+		}
+		msgs, _ = store.List("lead", msgs[0].Seq, 1)
+		if len(msgs) != 1 || msgs[0].ID != "m1" {
+			t.Errorf("List before cursor = %v, want [m1]", msgs)
+		}
+	})
+
+	t.Run("list sent newest first", func(t *testing.T) {
+		store := newStore()
+
+		msgs, _ := store.ListSent("a", 0, 0)
+		if len(msgs) != 2 || msgs[0].ID != "m3" || msgs[1].ID != "m1" {
+			t.Errorf("ListSent(a) = %v, want [m3 m1]", msgs)
+		}
+		msgs, _ = store.ListSent("a", 3, 0)
+		if len(msgs) != 1 || msgs[0].ID != "m1" {
+			t.Errorf("ListSent(a) before cursor = %v, want [m1]", msgs)
+		}
+	})
+
+	t.Run("assigns seqs", func(t *testing.T) {
+		store := newStore()
+
+		msg, err := store.GetBySeq(2)
+		if err != nil || msg.ID != "m2" {
+			t.Errorf("GetBySeq(2) = %v, %v, want m2", msg, err)
+		}
+	})
+
+	t.Run("list by ID prefix", func(t *testing.T) {
+		store := newStore()
+
+		msgs, _ := store.ListByIDPrefix("m", 2)
+		if len(msgs) != 2 || msgs[0].ID != "m3" {
+			t.Errorf("ListByIDPrefix(m) = %v, want [m3 m2]", msgs)
+		}
+		msgs, _ = store.ListByIDPrefix("m2", 0)
+		if len(msgs) != 1 || msgs[0].ID != "m2" {
+			t.Errorf("ListByIDPrefix(m2) = %v, want [m2]", msgs)
+		}
+	})
+
+	t.Run("mark read updates unread", func(t *testing.T) {
+		store := newStore()
+
+		unread, _ := store.ListUnread("lead")
+		if len(unread) != 2 || unread[0].ID != "m1" {
+			t.Fatalf("ListUnread = %v, want [m1 m2]", unread)
+		}
+
+		_ = store.MarkRead("m1")
+
+		count, _ := store.UnreadCount("lead")
+		if count != 1 {
+			t.Errorf("UnreadCount = %d, want 1", count)
+		}
+		msg, _ := store.Get("m1")
+		if !msg.Read || msg.ReadAt == nil {
+			t.Error("message should be marked read with ReadAt set")
+		}
+	})
+
+	t.Run("scheduled messages wait until due", func(t *testing.T) {
+		store := newStore()
+		later := now.Add(time.Hour)
+		_ = store.Save(&domain.Message{ID: "m4", From: "a", To: "lead", Content: "later", CreatedAt: now, DeliverAt: &later})
+
+		if count, _ := store.UnreadCount("lead"); count != 2 {
+			t.Errorf("UnreadCount = %d, want 2 before the scheduled message is due", count)
+		}
+		if due, _ := store.ListDue(now); len(due) != 0 {
+			t.Errorf("ListDue(now) = %v, want none", due)
+		}
+		if due, _ := store.ListDue(later); len(due) != 1 || due[0].ID != "m4" {
+			t.Errorf("ListDue(later) = %v, want [m4]", due)
+		}
+	})
+
+	t.Run("unread counts by recipient", func(t *testing.T) {
+		store := newStore()
+		_ = store.MarkRead("m3")
+
+		counts, _ := store.UnreadCountsByRecipient()
+		if len(counts) != 1 || counts["lead"] != 2 {
+			t.Errorf("UnreadCountsByRecipient = %v, want map[lead:2]", counts)
+		}
+	})
+
+	t.Run("returns copies", func(t *testing.T) {
+		store := newStore()
+
+		msg, _ := store.Get("m1")
+		msg.Content = "changed"
+
+		stored, _ := store.Get("m1")
+		if stored.Content != "first" {
+			t.Error("mutating a returned message should not change the store")
+		}
+	})
+}
+
+func TestMemoryMessageStore_Contract(t *testing.T) {
+	storetest.TestMessageStore(t, func(t *testing.T) domain.IMessageStore {
+		return NewMemoryMessageStore()
+	})
+}
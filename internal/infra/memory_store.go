@@ -1,13 +1,17 @@
 package infra
 
 import (
+	"fmt"
+	"maps"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/TechnicallyShaun/crAIzy/internal/domain"
 )
 
 // MemoryAgentStore implements IAgentStore with an in-memory map.
-// This is suitable for MVP; a persistent store can be added later.
+// It mirrors SQLiteAgentStore's behavior and backs --ephemeral mode and tests.
 type MemoryAgentStore struct {
 	agents map[string]*domain.Agent
 	mu     sync.RWMutex
@@ -20,11 +24,17 @@ func NewMemoryAgentStore() *MemoryAgentStore {
 	}
 }
 
-// Add stores a new agent.
+// Add stores a new agent. Like the SQLite store, adding a duplicate ID fails
+// and the agent's Env and Dir aren't kept.
 func (s *MemoryAgentStore) Add(agent *domain.Agent) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	s.agents[agent.ID] = agent
+	if _, exists := s.agents[agent.ID]; exists {
+		return fmt.Errorf("failed to insert agent: duplicate id %s", agent.ID)
+	}
+	stored := copyAgent(agent)
+	stored.Env, stored.Dir = nil, ""
+	s.agents[agent.ID] = stored
 	return nil
 }
 
@@ -36,15 +46,18 @@ func (s *MemoryAgentStore) Remove(id string) error {
 	return nil
 }
 
-// List returns all stored agents.
+// List returns all stored agents, newest first.
 func (s *MemoryAgentStore) List() []*domain.Agent {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
 	agents := make([]*domain.Agent, 0, len(s.agents))
 	for _, agent := range s.agents {
-		agents = append(agents, agent)
+		agents = append(agents, copyAgent(agent))
 	}
+	sort.SliceStable(agents, func(i, j int) bool {
+		return agents[i].CreatedAt.After(agents[j].CreatedAt)
+	})
 	return agents
 }
 
@@ -52,7 +65,10 @@ func (s *MemoryAgentStore) List() []*domain.Agent {
 func (s *MemoryAgentStore) Get(id string) *domain.Agent {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return s.agents[id]
+	if agent, exists := s.agents[id]; exists {
+		return copyAgent(agent)
+	}
+	return nil
 }
 
 // Exists checks if an agent with the given ID exists.
@@ -63,12 +79,99 @@ func (s *MemoryAgentStore) Exists(id string) bool {
 	return exists
 }
 
-// UpdateStatus updates the status of an agent.
+// UpdateStatus updates the status of an agent, recording when it was terminated.
 func (s *MemoryAgentStore) UpdateStatus(id string, status domain.AgentStatus) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	if agent, exists := s.agents[id]; exists {
 		agent.Status = status
+		agent.TerminatedAt = nil
+		if status == domain.AgentStatusTerminated {
+			now := time.Now()
+			agent.TerminatedAt = &now
+		}
+	}
+	return nil
+}
+
+// UpdateBaseBranch updates the base branch of an agent.
+func (s *MemoryAgentStore) UpdateBaseBranch(id, baseBranch string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if agent, exists := s.agents[id]; exists {
+		agent.BaseBranch = baseBranch
+	}
+	return nil
+}
+
+// UpdateSummary stores a short summary of an agent's completed work.
+func (s *MemoryAgentStore) UpdateSummary(id, summary string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if agent, exists := s.agents[id]; exists {
+		agent.Summary = summary
 	}
 	return nil
 }
+
+// AddAttachedTime adds to the total time the human has spent attached to an agent.
+func (s *MemoryAgentStore) AddAttachedTime(id string, d time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if agent, exists := s.agents[id]; exists {
+		agent.AttachedTime += d
+	}
+	return nil
+}
+
+// AddPausedTime adds to the total time an agent has spent paused.
+func (s *MemoryAgentStore) AddPausedTime(id string, d time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if agent, exists := s.agents[id]; exists {
+		agent.PausedTime += d
+	}
+	return nil
+}
+
+// AddRestart counts an automatic restart of an agent after its session died.
+func (s *MemoryAgentStore) AddRestart(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if agent, exists := s.agents[id]; exists {
+		agent.Restarts++
+	}
+	return nil
+}
+
+// SetMetadata sets a metadata value on an agent, deleting the key if value is empty.
+func (s *MemoryAgentStore) SetMetadata(id, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	agent, exists := s.agents[id]
+	if !exists {
+		return nil
+	}
+	if value == "" {
+		delete(agent.Metadata, key)
+		return nil
+	}
+	if agent.Metadata == nil {
+		agent.Metadata = make(map[string]string)
+	}
+	agent.Metadata[key] = value
+	return nil
+}
+
+// copyAgent returns a copy so callers can't mutate stored state, matching the
+// value semantics of the SQLite store.
+func copyAgent(agent *domain.Agent) *domain.Agent {
+	c := *agent
+	if agent.SparsePaths != nil {
+		c.SparsePaths = append([]string(nil), agent.SparsePaths...)
+	}
+	if agent.Metadata != nil {
+		c.Metadata = maps.Clone(agent.Metadata)
+	}
+	return &c
+}
@@ -3,8 +3,10 @@ package infra
 import (
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/infra/storetest"
 )
 
 func TestMemoryAgentStore_CRUD(t *testing.T) {
@@ -89,6 +91,64 @@ func TestMemoryAgentStore_CRUD(t *testing.T) {
 		}
 	})
 
+	t.Run("terminated sets terminated at", func(t *testing.T) {
+		store := NewMemoryAgentStore()
+		store.Add(&domain.Agent{ID: "test-1", Status: domain.AgentStatusActive})
+
+		_ = store.UpdateStatus("test-1", domain.AgentStatusTerminated)
+
+		if store.Get("test-1").TerminatedAt == nil {
+			t.Error("TerminatedAt should be set")
+		}
+	})
+
+	t.Run("duplicate add fails", func(t *testing.T) {
+		store := NewMemoryAgentStore()
+		store.Add(&domain.Agent{ID: "test-1"})
+
+		if err := store.Add(&domain.Agent{ID: "test-1"}); err == nil {
+			t.Error("expected error adding duplicate ID")
+		}
+	})
+
+	t.Run("list newest first", func(t *testing.T) {
+		store := NewMemoryAgentStore()
+		now := time.Now()
+		store.Add(&domain.Agent{ID: "old", CreatedAt: now.Add(-time.Hour)})
+		store.Add(&domain.Agent{ID: "new", CreatedAt: now})
+
+		agents := store.List()
+
+		if agents[0].ID != "new" {
+			t.Errorf("first agent = %s, want new", agents[0].ID)
+		}
+	})
+
+	t.Run("get returns copy", func(t *testing.T) {
+		store := NewMemoryAgentStore()
+		store.Add(&domain.Agent{ID: "test-1", Name: "orig"})
+
+		store.Get("test-1").Name = "changed"
+
+		if store.Get("test-1").Name != "orig" {
+			t.Error("mutating a returned agent should not change the store")
+		}
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		store := NewMemoryAgentStore()
+		store.Add(&domain.Agent{ID: "test-1"})
+
+		store.SetMetadata("test-1", "model", "opus")
+		store.SetMetadata("test-1", "issue", "42")
+		store.SetMetadata("test-1", "issue", "")
+		store.Get("test-1").Metadata["model"] = "changed"
+
+		if got := store.Get("test-1").Metadata; len(got) != 1 || got["model"] != "opus" {
+			t.Errorf("Metadata = %v, want only model=opus", got)
+		}
+	})
+
 	t.Run("update status nonexistent", func(t *testing.T) {
 		store := NewMemoryAgentStore()
 
@@ -129,3 +189,9 @@ func TestMemoryAgentStore_Concurrency(t *testing.T) {
 
 	// Test passes if no race condition panics
 }
+
+func TestMemoryAgentStore_Contract(t *testing.T) {
+	storetest.TestAgentStore(t, func(t *testing.T) domain.IAgentStore {
+		return NewMemoryAgentStore()
+	})
+}
@@ -0,0 +1,272 @@
+package infra
+
+import (
+	"sync"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// ProbeCacheTTL bounds how long a cached probe is trusted. Sessions also exit on
+// their own and agents edit their worktrees, both without an event, so the
+// cache only saves the repeated probes of one refresh rather than replacing them.
+const ProbeCacheTTL = 3 * time.Second
+
+// CachingTmuxClient wraps a tmux client, caching SessionExists by agent ID so the
+// status, attention and message delivery checks of one refresh share a probe.
+// Calls through it that start, stop or rename a session invalidate it, as do the
+// domain events wired by WireProbeCache.
+type CachingTmuxClient struct {
+	domain.ITmuxClient
+
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]probeEntry
+	git     *CachingGitClient // see SetGitCache
+}
+
+// probeEntry is one cached probe result.
+type probeEntry struct {
+	result bool // the session exists, or the worktree has uncommitted changes
+	at     time.Time
+}
+
+// NewCachingTmuxClient wraps tmux with a ProbeCacheTTL cache.
+func NewCachingTmuxClient(tmux domain.ITmuxClient) *CachingTmuxClient {
+	return &CachingTmuxClient{
+		ITmuxClient: tmux,
+		ttl:         ProbeCacheTTL,
+		now:         time.Now,
+		entries:     make(map[string]probeEntry),
+	}
+}
+
+// SessionExists returns the cached result for id, probing tmux if there is none
+// or it has expired.
+func (c *CachingTmuxClient) SessionExists(id string) bool {
+	now := c.now()
+	c.mu.Lock()
+	entry, ok := c.entries[id]
+	c.mu.Unlock()
+	if ok && now.Sub(entry.at) < c.ttl {
+		return entry.result
+	}
+
+	exists := c.ITmuxClient.SessionExists(id)
+	c.mu.Lock()
+	c.entries[id] = probeEntry{result: exists, at: now}
+	c.mu.Unlock()
+	return exists
+}
+
+// Invalidate drops the cached probes for the given agent IDs.
+func (c *CachingTmuxClient) Invalidate(ids ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range ids {
+		delete(c.entries, id)
+	}
+}
+
+// CreateSession starts a session like the wrapped client and invalidates its probe.
+func (c *CachingTmuxClient) CreateSession(id, command, workDir string, env map[string]string) error {
+	defer c.Invalidate(id)
+	return c.ITmuxClient.CreateSession(id, command, workDir, env)
+}
+
+// KillSession stops a session like the wrapped client and invalidates its probe.
+func (c *CachingTmuxClient) KillSession(id string) error {
+	defer c.Invalidate(id)
+	return c.ITmuxClient.KillSession(id)
+}
+
+// RenameSession renames a session like the wrapped client and invalidates both names.
+func (c *CachingTmuxClient) RenameSession(oldID, newID string) error {
+	defer c.Invalidate(oldID, newID)
+	return c.ITmuxClient.RenameSession(oldID, newID)
+}
+
+// SetGitCache drops git's cached uncommitted changes probes whenever keys are
+// sent to a session, as an agent given a message or prompt goes on to edit
+// its worktree.
+func (c *CachingTmuxClient) SetGitCache(git *CachingGitClient) {
+	c.git = git
+}
+
+// SendKeys delivers text like the wrapped client. A failed delivery suggests
+// the session is gone, so it invalidates the session's probe.
+func (c *CachingTmuxClient) SendKeys(sessionID, text string) error {
+	err := c.ITmuxClient.SendKeys(sessionID, text)
+	if err != nil {
+		c.Invalidate(sessionID)
+	}
+	if c.git != nil {
+		c.git.InvalidateAll()
+	}
+	return err
+}
+
+// CachingGitClient wraps a git client, caching HasUncommittedChanges by
+// worktree path for callers that only display it. Calls through it that change
+// a worktree invalidate its probe, and ones that change the repository
+// invalidate every probe, as do the domain events wired by WireProbeCache.
+//
+// Agents edit their worktrees without publishing events, so a cached probe can
+// miss changes made within the TTL. Checks deciding whether work would be lost
+// must use HasUncommittedChangesFresh, as the domain does.
+type CachingGitClient struct {
+	domain.IGitClient
+
+	ttl     time.Duration
+	now     func() time.Time
+	mu      sync.Mutex
+	entries map[string]probeEntry
+}
+
+// NewCachingGitClient wraps git with a ProbeCacheTTL cache.
+func NewCachingGitClient(git domain.IGitClient) *CachingGitClient {
+	return &CachingGitClient{
+		IGitClient: git,
+		ttl:        ProbeCacheTTL,
+		now:        time.Now,
+		entries:    make(map[string]probeEntry),
+	}
+}
+
+// HasUncommittedChanges returns the cached result for path, probing git if
+// there is none or it has expired.
+func (c *CachingGitClient) HasUncommittedChanges(path string) bool {
+	now := c.now()
+	c.mu.Lock()
+	entry, ok := c.entries[path]
+	c.mu.Unlock()
+	if ok && now.Sub(entry.at) < c.ttl {
+		return entry.result
+	}
+
+	dirty := c.IGitClient.HasUncommittedChanges(path)
+	c.mu.Lock()
+	c.entries[path] = probeEntry{result: dirty, at: now}
+	c.mu.Unlock()
+	return dirty
+}
+
+// HasUncommittedChangesFresh probes git past the cache, refreshing the cached
+// result for path.
+func (c *CachingGitClient) HasUncommittedChangesFresh(path string) bool {
+	now := c.now()
+	dirty := c.IGitClient.HasUncommittedChanges(path)
+	c.mu.Lock()
+	c.entries[path] = probeEntry{result: dirty, at: now}
+	c.mu.Unlock()
+	return dirty
+}
+
+// Invalidate drops the cached probes for the given worktree paths.
+func (c *CachingGitClient) Invalidate(paths ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, path := range paths {
+		delete(c.entries, path)
+	}
+}
+
+// InvalidateAll drops every cached probe.
+func (c *CachingGitClient) InvalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+// RemoveWorktree removes a worktree like the wrapped client and invalidates its probe.
+func (c *CachingGitClient) RemoveWorktree(path string) error {
+	defer c.Invalidate(path)
+	return c.IGitClient.RemoveWorktree(path)
+}
+
+// DiscardChanges discards changes like the wrapped client and invalidates the probe.
+func (c *CachingGitClient) DiscardChanges(path string) error {
+	defer c.Invalidate(path)
+	return c.IGitClient.DiscardChanges(path)
+}
+
+// Stash stashes changes like the wrapped client and invalidates the probe.
+func (c *CachingGitClient) Stash(path string) error {
+	defer c.Invalidate(path)
+	return c.IGitClient.Stash(path)
+}
+
+// StashPop restores stashed changes like the wrapped client and invalidates the probe.
+func (c *CachingGitClient) StashPop(path string) error {
+	defer c.Invalidate(path)
+	return c.IGitClient.StashPop(path)
+}
+
+// PopStashRef restores a stash like the wrapped client and invalidates the probe.
+func (c *CachingGitClient) PopStashRef(path, ref string) error {
+	defer c.Invalidate(path)
+	return c.IGitClient.PopStashRef(path, ref)
+}
+
+// Add stages changes like the wrapped client and invalidates the probe.
+func (c *CachingGitClient) Add(path string) error {
+	defer c.Invalidate(path)
+	return c.IGitClient.Add(path)
+}
+
+// Commit commits like the wrapped client and invalidates the probe.
+func (c *CachingGitClient) Commit(path, message string) error {
+	defer c.Invalidate(path)
+	return c.IGitClient.Commit(path, message)
+}
+
+// Rebase rebases like the wrapped client and invalidates the probe.
+func (c *CachingGitClient) Rebase(path, baseBranch, branch string) error {
+	defer c.Invalidate(path)
+	return c.IGitClient.Rebase(path, baseBranch, branch)
+}
+
+// Merge merges like the wrapped client and invalidates every probe.
+func (c *CachingGitClient) Merge(branch string) error {
+	defer c.InvalidateAll()
+	return c.IGitClient.Merge(branch)
+}
+
+// Squash squash-merges like the wrapped client and invalidates every probe.
+func (c *CachingGitClient) Squash(branch, message string) error {
+	defer c.InvalidateAll()
+	return c.IGitClient.Squash(branch, message)
+}
+
+// WireProbeCache invalidates cached probes for agents whose sessions domain
+// events report as started, stopped, restarted or renamed, and uncommitted
+// changes probes when agents are killed or merged or are sent keys. Wire it
+// after WireAdapters so the adapters have acted on the event first.
+func WireProbeCache(dispatcher domain.IEventDispatcher, tmux *CachingTmuxClient, git *CachingGitClient) {
+	logging.Entry()
+	tmux.SetGitCache(git)
+	for _, eventType := range []string{"agent.killed", "merge.completed", "merge.failed"} {
+		dispatcher.Subscribe(eventType, func(domain.Event) {
+			git.InvalidateAll()
+		})
+	}
+
+	dispatcher.Subscribe("agent.created", func(e domain.Event) {
+		tmux.Invalidate(e.(domain.AgentCreated).Agent.ID)
+	})
+	dispatcher.Subscribe("agent.claimed", func(e domain.Event) {
+		event := e.(domain.AgentClaimed)
+		tmux.Invalidate(event.OldID, event.Agent.ID)
+	})
+	dispatcher.Subscribe("agent.killed", func(e domain.Event) {
+		tmux.Invalidate(e.(domain.AgentKilled).AgentID)
+	})
+	dispatcher.Subscribe("agent.restarted", func(e domain.Event) {
+		tmux.Invalidate(e.(domain.AgentRestarted).AgentID)
+	})
+	dispatcher.Subscribe("agent.status_changed", func(e domain.Event) {
+		tmux.Invalidate(e.(domain.AgentStatusChanged).AgentID)
+	})
+}
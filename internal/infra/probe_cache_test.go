@@ -0,0 +1,165 @@
+package infra
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestCachingTmuxClient(t *testing.T) {
+	newCache := func() (*CachingTmuxClient, *mockTmuxClient, *time.Time) {
+		mock := newMockTmux()
+		mock.sessions["agent"] = true
+		now := time.Now()
+		cache := NewCachingTmuxClient(mock)
+		cache.now = func() time.Time { return now }
+		return cache, mock, &now
+	}
+
+	t.Run("caches until the TTL passes", func(t *testing.T) {
+		cache, mock, now := newCache()
+		if !cache.SessionExists("agent") {
+			t.Fatal("expected the session to exist")
+		}
+		// The session exits without an event
+		delete(mock.sessions, "agent")
+		if !cache.SessionExists("agent") {
+			t.Error("expected the cached probe within the TTL")
+		}
+		*now = now.Add(ProbeCacheTTL)
+		if cache.SessionExists("agent") {
+			t.Error("expected a fresh probe once the TTL passed")
+		}
+	})
+
+	t.Run("calls through it invalidate", func(t *testing.T) {
+		cache, _, _ := newCache()
+		cache.SessionExists("agent")
+		_ = cache.KillSession("agent")
+		if cache.SessionExists("agent") {
+			t.Error("expected the killed session to be probed again")
+		}
+		_ = cache.CreateSession("agent", "claude", "/tmp", nil)
+		if !cache.SessionExists("agent") {
+			t.Error("expected the created session to be probed again")
+		}
+	})
+
+	t.Run("events invalidate", func(t *testing.T) {
+		cache, mock, _ := newCache()
+		dispatcher := NewEventDispatcher()
+		WireProbeCache(dispatcher, cache, NewCachingGitClient(&statusGit{}))
+
+		cache.SessionExists("agent")
+		delete(mock.sessions, "agent")
+		dispatcher.Publish(domain.AgentKilled{AgentID: "agent", Timestamp: time.Now()})
+		if cache.SessionExists("agent") {
+			t.Error("expected agent.killed to invalidate the probe")
+		}
+
+		cache.SessionExists("renamed")
+		mock.sessions["renamed"] = true
+		dispatcher.Publish(domain.AgentClaimed{OldID: "pool", Agent: &domain.Agent{ID: "renamed"}, Timestamp: time.Now()})
+		if !cache.SessionExists("renamed") {
+			t.Error("expected agent.claimed to invalidate the new ID's probe")
+		}
+	})
+}
+
+// statusGit reports uncommitted changes for the paths in dirty, which commits
+// clear; other git calls aren't expected.
+type statusGit struct {
+	domain.IGitClient
+	dirty map[string]bool
+}
+
+func (g *statusGit) HasUncommittedChanges(path string) bool {
+	return g.dirty[path]
+}
+
+func (g *statusGit) Commit(path, _ string) error {
+	delete(g.dirty, path)
+	return nil
+}
+
+func TestCachingGitClient(t *testing.T) {
+	newCache := func() (*CachingGitClient, *statusGit, *time.Time) {
+		git := &statusGit{dirty: map[string]bool{"/wt/agent": true}}
+		now := time.Now()
+		cache := NewCachingGitClient(git)
+		cache.now = func() time.Time { return now }
+		return cache, git, &now
+	}
+
+	t.Run("caches until the TTL passes", func(t *testing.T) {
+		cache, git, now := newCache()
+		if !cache.HasUncommittedChanges("/wt/agent") {
+			t.Fatal("expected uncommitted changes")
+		}
+		// The agent commits without an event
+		delete(git.dirty, "/wt/agent")
+		if !cache.HasUncommittedChanges("/wt/agent") {
+			t.Error("expected the cached probe within the TTL")
+		}
+		*now = now.Add(ProbeCacheTTL)
+		if cache.HasUncommittedChanges("/wt/agent") {
+			t.Error("expected a fresh probe once the TTL passed")
+		}
+	})
+
+	t.Run("calls through it invalidate", func(t *testing.T) {
+		cache, _, _ := newCache()
+		cache.HasUncommittedChanges("/wt/agent")
+		_ = cache.Commit("/wt/agent", "WIP")
+		if cache.HasUncommittedChanges("/wt/agent") {
+			t.Error("expected the committed worktree to be probed again")
+		}
+	})
+
+	t.Run("kills, merges and messages invalidate", func(t *testing.T) {
+		cache, git, _ := newCache()
+		tmux := NewCachingTmuxClient(newMockTmux())
+		dispatcher := NewEventDispatcher()
+		WireProbeCache(dispatcher, tmux, cache)
+
+		for _, invalidate := range []func(){
+			func() { dispatcher.Publish(domain.AgentKilled{AgentID: "agent", Timestamp: time.Now()}) },
+			func() {
+				dispatcher.Publish(domain.MergeCompleted{Record: &domain.MergeRecord{}, Timestamp: time.Now()})
+			},
+			func() { _ = tmux.SendKeys("agent", "Please add tests") },
+		} {
+			git.dirty["/wt/agent"] = true
+			cache.HasUncommittedChanges("/wt/agent")
+			delete(git.dirty, "/wt/agent")
+			invalidate()
+			if cache.HasUncommittedChanges("/wt/agent") {
+				t.Error("expected the probe to be invalidated")
+			}
+		}
+	})
+}
+
+func TestCachingGitClient_KillChecksAreFresh(t *testing.T) {
+	repo, cleanup := setupTestRepo(t)
+	defer cleanup()
+	git := NewCachingGitClient(NewGitClient(repo))
+	store := NewMemoryAgentStore()
+	_ = store.Add(&domain.Agent{ID: "craizy-proj-claude-auth", Branch: "craizy/auth", WorkDir: repo, Status: domain.AgentStatusActive})
+	svc := domain.NewAgentService(NewFakeTmuxClient(), store, NewEventDispatcher(), git, "proj", repo)
+
+	if git.HasUncommittedChanges(repo) {
+		t.Fatal("expected a clean worktree")
+	}
+	// The agent edits its worktree within the TTL, without an event
+	if err := os.WriteFile(filepath.Join(repo, "auth.go"), []byte("package auth\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if uncommitted, err := svc.CheckKill("craizy-proj-claude-auth"); err != nil || !uncommitted {
+		t.Errorf("CheckKill = %v, %v, want the new file to refuse the kill", uncommitted, err)
+	}
+}
@@ -0,0 +1,210 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// Backups are taken at most once per DefaultBackupInterval, keeping the newest
+// DefaultBackupRetention, so a corrupt database loses at most a day of state.
+const (
+	DefaultBackupInterval  = 24 * time.Hour
+	DefaultBackupRetention = 7
+)
+
+// backupTimeFormat names backup files by when they were taken, so they sort by age.
+const backupTimeFormat = "20060102-150405"
+
+// BackupsDir returns the directory backups of the database at dbPath are kept in.
+func BackupsDir(dbPath string) string {
+	return filepath.Join(filepath.Dir(dbPath), "backups")
+}
+
+// Backup snapshots db into a new file in dir with VACUUM INTO, which copies a
+// consistent view even while other connections write. It returns the file's path.
+func Backup(db *sql.DB, dir string, now time.Time) (string, error) {
+	logging.Entry("dir", dir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create backups directory: %w", err)
+	}
+	path := filepath.Join(dir, "craizy-"+now.Format(backupTimeFormat)+".db")
+	if _, err := os.Stat(path); err == nil {
+		return "", fmt.Errorf("a backup was already taken this second: %s", path)
+	}
+	if _, err := db.Exec("VACUUM INTO ?", path); err != nil {
+		logging.Error(err, "path", path)
+		return "", fmt.Errorf("failed to back up database: %w", err)
+	}
+	logging.Info("database backed up, path=%s", path)
+	return path, nil
+}
+
+// ListBackups returns the backup files in dir, newest first. A missing
+// directory has no backups.
+func ListBackups(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+	var backups []string
+	for _, entry := range entries {
+		if name := entry.Name(); !entry.IsDir() && strings.HasPrefix(name, "craizy-") && strings.HasSuffix(name, ".db") {
+			backups = append(backups, filepath.Join(dir, name))
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(backups)))
+	return backups, nil
+}
+
+// LatestBackup returns the newest backup in dir, or "" if there is none.
+func LatestBackup(dir string) (string, error) {
+	backups, err := ListBackups(dir)
+	if err != nil || len(backups) == 0 {
+		return "", err
+	}
+	return backups[0], nil
+}
+
+// BackupTime returns when a backup was taken, from its file name.
+func BackupTime(path string) (time.Time, error) {
+	stamp := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "craizy-"), ".db")
+	return time.ParseInLocation(backupTimeFormat, stamp, time.Local)
+}
+
+// Backupper takes periodic backups of a database, implementing domain.IBackupper.
+type Backupper struct {
+	db       *sql.DB
+	dir      string
+	interval time.Duration
+	keep     int
+}
+
+// NewBackupper backs db up into dir every interval, keeping the newest keep
+// backups. Zero values fall back to DefaultBackupInterval and DefaultBackupRetention.
+func NewBackupper(db *sql.DB, dir string, interval time.Duration, keep int) *Backupper {
+	if interval <= 0 {
+		interval = DefaultBackupInterval
+	}
+	if keep <= 0 {
+		keep = DefaultBackupRetention
+	}
+	return &Backupper{db: db, dir: dir, interval: interval, keep: keep}
+}
+
+// BackupIfDue takes a backup if the newest is older than the interval, see BackupIfDue.
+func (b *Backupper) BackupIfDue(now time.Time) (string, error) {
+	return BackupIfDue(b.db, b.dir, b.interval, b.keep, now)
+}
+
+// BackupIfDue takes a backup if the newest in dir is older than interval, then
+// deletes all but the newest keep. It returns the new backup's path, or "" if
+// none was due.
+func BackupIfDue(db *sql.DB, dir string, interval time.Duration, keep int, now time.Time) (string, error) {
+	latest, err := LatestBackup(dir)
+	if err != nil {
+		return "", err
+	}
+	if latest != "" {
+		if taken, err := BackupTime(latest); err == nil && now.Sub(taken) < interval {
+			return "", nil
+		}
+	}
+
+	path, err := Backup(db, dir, now)
+	if err != nil {
+		return "", err
+	}
+	return path, PruneBackups(dir, keep)
+}
+
+// PruneBackups deletes all but the newest keep backups in dir.
+func PruneBackups(dir string, keep int) error {
+	backups, err := ListBackups(dir)
+	if err != nil {
+		return err
+	}
+	for _, path := range backups[min(keep, len(backups)):] {
+		if err := os.Remove(path); err != nil {
+			logging.Error(err, "path", path, "action", "prune backup")
+			return fmt.Errorf("failed to remove old backup: %w", err)
+		}
+	}
+	return nil
+}
+
+// Restore replaces the database at dbPath with a copy of backupPath, after
+// checking the backup's integrity. The replaced database is kept beside it with
+// a ".replaced" suffix, and its write-ahead log is discarded. Close every
+// connection to dbPath first.
+func Restore(backupPath, dbPath string) error {
+	logging.Entry("backup", backupPath, "dbPath", dbPath)
+	if err := checkBackup(backupPath); err != nil {
+		return err
+	}
+
+	tmpPath := dbPath + ".restoring"
+	if err := copyFile(backupPath, tmpPath); err != nil {
+		return fmt.Errorf("failed to copy backup: %w", err)
+	}
+	if _, err := os.Stat(dbPath); err == nil {
+		if err := os.Rename(dbPath, dbPath+".replaced"); err != nil {
+			os.Remove(tmpPath)
+			return fmt.Errorf("failed to move database aside: %w", err)
+		}
+	}
+	for _, suffix := range []string{"-wal", "-shm"} {
+		if err := os.Remove(dbPath + suffix); err != nil && !os.IsNotExist(err) {
+			logging.Error(err, "path", dbPath+suffix, "action", "remove journal")
+		}
+	}
+	if err := os.Rename(tmpPath, dbPath); err != nil {
+		return fmt.Errorf("failed to restore database: %w", err)
+	}
+	logging.Info("database restored, backup=%s, dbPath=%s", backupPath, dbPath)
+	return nil
+}
+
+// checkBackup opens a backup read-only and checks its integrity.
+func checkBackup(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("backup not found: %w", err)
+	}
+	db, err := sql.Open("sqlite", path+"?_pragma=query_only(true)")
+	if err != nil {
+		return fmt.Errorf("failed to open backup: %w", err)
+	}
+	defer db.Close()
+	if err := CheckIntegrity(db); err != nil {
+		return fmt.Errorf("backup %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// copyFile copies src to dst, replacing dst.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
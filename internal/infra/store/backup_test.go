@@ -0,0 +1,82 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestNewSQLiteAgentStore_Corrupt(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "craizy.db")
+	if err := os.WriteFile(dbPath, []byte("definitely not a sqlite database, just some text that is long enough"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	_, err := NewSQLiteAgentStore(dbPath)
+
+	if !errors.Is(err, ErrCorrupt) {
+		t.Fatalf("err = %v, want ErrCorrupt", err)
+	}
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "craizy.db")
+	backups := BackupsDir(dbPath)
+
+	agentStore, err := NewSQLiteAgentStore(dbPath)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	if err := agentStore.Add(&domain.Agent{ID: "backed-up", Project: "p", Status: domain.AgentStatusActive, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("failed to add agent: %v", err)
+	}
+
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.Local)
+	path, err := BackupIfDue(agentStore.DB(), backups, DefaultBackupInterval, 2, now)
+	if err != nil || path == "" {
+		t.Fatalf("BackupIfDue = %q, %v, want a first backup", path, err)
+	}
+	if taken, err := BackupTime(path); err != nil || !taken.Equal(now) {
+		t.Errorf("BackupTime = %v, %v, want %v", taken, err, now)
+	}
+	if path, _ := BackupIfDue(agentStore.DB(), backups, DefaultBackupInterval, 2, now.Add(time.Hour)); path != "" {
+		t.Errorf("backed up again after an hour: %s", path)
+	}
+	for day := 1; day <= 3; day++ {
+		if _, err := BackupIfDue(agentStore.DB(), backups, DefaultBackupInterval, 2, now.AddDate(0, 0, day)); err != nil {
+			t.Fatalf("BackupIfDue failed: %v", err)
+		}
+	}
+	list, _ := ListBackups(backups)
+	if len(list) != 2 {
+		t.Fatalf("kept %d backups, want 2", len(list))
+	}
+
+	_ = agentStore.Remove("backed-up")
+	agentStore.Close()
+	if err := os.WriteFile(dbPath, []byte("corrupted"), 0o644); err != nil {
+		t.Fatalf("failed to corrupt database: %v", err)
+	}
+
+	latest, _ := LatestBackup(backups)
+	if err := Restore(latest, dbPath); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if _, err := os.Stat(dbPath + ".replaced"); err != nil {
+		t.Errorf("replaced database not kept: %v", err)
+	}
+
+	restored, err := NewSQLiteAgentStore(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open restored database: %v", err)
+	}
+	defer restored.Close()
+	if !restored.Exists("backed-up") {
+		t.Error("restored database is missing the backed up agent")
+	}
+}
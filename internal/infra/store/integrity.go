@@ -0,0 +1,48 @@
+package store
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// ErrCorrupt is returned when the database fails its integrity check, or isn't
+// a database at all. Restoring a backup (see LatestBackup) recovers from it.
+var ErrCorrupt = errors.New("database is corrupt")
+
+// CheckIntegrity runs SQLite's quick check, the fast form of integrity_check
+// that skips matching indexes against their tables, so it can run on every
+// open. Corruption is reported as ErrCorrupt; other failures, such as a locked
+// database, are returned as they are.
+func CheckIntegrity(db *sql.DB) error {
+	var result string
+	if err := db.QueryRow("PRAGMA quick_check(1)").Scan(&result); err != nil {
+		if isCorruption(err) {
+			return fmt.Errorf("%w: %v", ErrCorrupt, err)
+		}
+		return fmt.Errorf("failed to check database integrity: %w", err)
+	}
+	if result != "ok" {
+		logging.Info("database integrity check failed: %s", result)
+		return fmt.Errorf("%w: %s", ErrCorrupt, result)
+	}
+	return nil
+}
+
+// isCorruption reports whether err is SQLite finding a malformed database.
+func isCorruption(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	switch sqliteErr.Code() & 0xff {
+	case sqlite3.SQLITE_CORRUPT, sqlite3.SQLITE_NOTADB:
+		return true
+	}
+	return false
+}
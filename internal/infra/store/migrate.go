@@ -11,8 +11,18 @@ import (
 //go:embed migrations/*.sql
 var migrations embed.FS
 
-// Migrate runs all embedded SQL migrations in order.
+// SchemaVersion is recorded in the database's user_version once Migrate has
+// brought it up to date, so later opens skip the migrations. Bump it whenever
+// a migration is added.
+const SchemaVersion = 8
+
+// Migrate runs all embedded SQL migrations in order, unless the database
+// already records SchemaVersion.
 func Migrate(db *sql.DB) error {
+	if version, err := userVersion(db); err == nil && version >= SchemaVersion {
+		return nil
+	}
+
 	entries, err := migrations.ReadDir("migrations")
 	if err != nil {
 		return fmt.Errorf("failed to read migrations directory: %w", err)
@@ -42,10 +52,26 @@ func Migrate(db *sql.DB) error {
 	if err := migrateGitColumns(db); err != nil {
 		return fmt.Errorf("failed to migrate git columns: %w", err)
 	}
+	if err := migrateAgentColumns(db); err != nil {
+		return fmt.Errorf("failed to migrate agent columns: %w", err)
+	}
+	if err := migrateMessageColumns(db); err != nil {
+		return fmt.Errorf("failed to migrate message columns: %w", err)
+	}
 
+	if _, err := db.Exec(fmt.Sprintf("PRAGMA user_version = %d", SchemaVersion)); err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
 	return nil
 }
 
+// userVersion returns the schema version recorded in the database, 0 if none.
+func userVersion(db *sql.DB) (int, error) {
+	var version int
+	err := db.QueryRow("PRAGMA user_version").Scan(&version)
+	return version, err
+}
+
 // migrateGitColumns adds the branch and base_branch columns if they don't exist.
 func migrateGitColumns(db *sql.DB) error {
 	// Check if columns exist
@@ -88,3 +114,160 @@ func migrateGitColumns(db *sql.DB) error {
 
 	return nil
 }
+
+// agentColumnMigrations lists columns added to the agents table after its creation.
+var agentColumnMigrations = []struct {
+	name       string
+	definition string
+}{
+	{"sparse_paths", "TEXT DEFAULT ''"},
+	{"summary", "TEXT DEFAULT ''"},
+	{"attached_ms", "INTEGER DEFAULT 0"},
+	{"paused_ms", "INTEGER DEFAULT 0"},
+	{"restarts", "INTEGER DEFAULT 0"},
+}
+
+// migrateAgentColumns adds any missing columns from agentColumnMigrations.
+func migrateAgentColumns(db *sql.DB) error {
+	existing, err := tableColumns(db, "agents")
+	if err != nil {
+		return err
+	}
+
+	for _, col := range agentColumnMigrations {
+		if existing[col.name] {
+			continue
+		}
+		if _, err := db.Exec("ALTER TABLE agents ADD COLUMN " + col.name + " " + col.definition); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateMessageColumns adds the seq column behind short message codes, numbering
+// existing messages in insertion order, and the refs column for file references.
+func migrateMessageColumns(db *sql.DB) error {
+	existing, err := tableColumns(db, "messages")
+	if err != nil {
+		return err
+	}
+	if !existing["refs"] {
+		if _, err := db.Exec("ALTER TABLE messages ADD COLUMN refs TEXT DEFAULT ''"); err != nil {
+			return err
+		}
+	}
+	if !existing["deliver_at"] {
+		// Unix seconds a scheduled message becomes due, NULL to deliver on send
+		if _, err := db.Exec("ALTER TABLE messages ADD COLUMN deliver_at INTEGER"); err != nil {
+			return err
+		}
+	}
+	if !existing["escalated"] {
+		if _, err := db.Exec("ALTER TABLE messages ADD COLUMN escalated BOOLEAN DEFAULT FALSE"); err != nil {
+			return err
+		}
+	}
+	if !existing["seq"] {
+		if _, err := db.Exec("ALTER TABLE messages ADD COLUMN seq INTEGER"); err != nil {
+			return err
+		}
+		if _, err := db.Exec("UPDATE messages SET seq = rowid WHERE seq IS NULL"); err != nil {
+			return err
+		}
+	}
+	if _, err := db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_messages_seq ON messages(seq)"); err != nil {
+		return err
+	}
+	// Pages of a participant's received and sent messages are read newest first by seq
+	if _, err := db.Exec("CREATE INDEX IF NOT EXISTS idx_messages_to_seq ON messages(to_agent, seq)"); err != nil {
+		return err
+	}
+	_, err = db.Exec("CREATE INDEX IF NOT EXISTS idx_messages_from_seq ON messages(from_agent, seq)")
+	return err
+}
+
+// tableColumns returns the set of lowercase column names for a table.
+func tableColumns(db *sql.DB, table string) (map[string]bool, error) {
+	rows, err := db.Query("PRAGMA table_info(" + table + ")")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns := make(map[string]bool)
+	for rows.Next() {
+		var cid int
+		var name, ctype string
+		var notnull, pk int
+		var dfltValue interface{}
+		if err := rows.Scan(&cid, &name, &ctype, &notnull, &dfltValue, &pk); err != nil {
+			continue
+		}
+		columns[strings.ToLower(name)] = true
+	}
+	return columns, rows.Err()
+}
+
+// DescribeSchema summarizes the database schema for bug reports: the latest
+// embedded migration and the columns of each table.
+func DescribeSchema(db *sql.DB) (string, error) {
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return "", fmt.Errorf("failed to read migrations directory: %w", err)
+	}
+	latest := ""
+	for _, entry := range entries {
+		if name := strings.TrimSuffix(entry.Name(), ".sql"); name > latest {
+			latest = name
+		}
+	}
+
+	rows, err := db.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name")
+	if err != nil {
+		return "", fmt.Errorf("failed to list tables: %w", err)
+	}
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return "", fmt.Errorf("failed to list tables: %w", err)
+		}
+		tables = append(tables, name)
+	}
+	rows.Close()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "migration: %s\n", latest)
+	for _, table := range tables {
+		columns, err := orderedColumns(db, table)
+		if err != nil {
+			return "", fmt.Errorf("failed to describe table %s: %w", table, err)
+		}
+		fmt.Fprintf(&b, "%s: %s\n", table, strings.Join(columns, ", "))
+	}
+	return b.String(), nil
+}
+
+// orderedColumns returns a table's columns as "name TYPE" in declaration order.
+func orderedColumns(db *sql.DB, table string) ([]string, error) {
+	rows, err := db.Query("PRAGMA table_info(" + table + ")")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var cid int
+		var name, ctype string
+		var notnull, pk int
+		var dfltValue interface{}
+		if err := rows.Scan(&cid, &name, &ctype, &notnull, &dfltValue, &pk); err != nil {
+			return nil, err
+		}
+		columns = append(columns, name+" "+ctype)
+	}
+	return columns, rows.Err()
+}
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS merges (
+    id TEXT PRIMARY KEY,
+    agent_id TEXT NOT NULL,
+    branch TEXT NOT NULL,
+    base_branch TEXT NOT NULL,
+    strategy TEXT NOT NULL,
+    outcome TEXT NOT NULL,
+    conflict_files TEXT DEFAULT '',
+    duration_ms INTEGER NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_merges_agent ON merges(agent_id, created_at);
@@ -0,0 +1,5 @@
+CREATE TABLE IF NOT EXISTS aliases (
+    name TEXT PRIMARY KEY,
+    target TEXT NOT NULL,
+    updated_at DATETIME NOT NULL
+);
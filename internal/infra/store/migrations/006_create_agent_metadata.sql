@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS agent_metadata (
+    agent_id TEXT NOT NULL,
+    key TEXT NOT NULL,
+    value TEXT NOT NULL,
+    PRIMARY KEY (agent_id, key)
+);
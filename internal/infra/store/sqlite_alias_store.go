@@ -0,0 +1,87 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// SQLiteAliasStore implements IAliasStore with SQLite persistence.
+type SQLiteAliasStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteAliasStore creates a new SQLite-backed alias store.
+// It uses an existing database connection (migrations are run by agent store init).
+func NewSQLiteAliasStore(db *sql.DB) *SQLiteAliasStore {
+	logging.Entry()
+	return &SQLiteAliasStore{db: db}
+}
+
+// Set stores an alias, replacing any of the same name.
+func (s *SQLiteAliasStore) Set(alias *domain.Alias) error {
+	logging.Entry("name", alias.Name, "target", alias.Target)
+	_, err := s.db.Exec(`
+		INSERT INTO aliases (name, target, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET target = excluded.target, updated_at = excluded.updated_at
+	`, alias.Name, alias.Target, alias.UpdatedAt)
+	if err != nil {
+		logging.Error(err, "name", alias.Name)
+		return fmt.Errorf("failed to save alias: %w", err)
+	}
+	return nil
+}
+
+// Get retrieves an alias by name, or nil if there is none.
+func (s *SQLiteAliasStore) Get(name string) (*domain.Alias, error) {
+	alias := &domain.Alias{}
+	err := s.db.QueryRow(`
+		SELECT name, target, updated_at FROM aliases WHERE name = ?
+	`, name).Scan(&alias.Name, &alias.Target, &alias.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		logging.Error(err, "name", name)
+		return nil, fmt.Errorf("failed to get alias: %w", err)
+	}
+	return alias, nil
+}
+
+// Delete removes an alias, failing if there is none of that name.
+func (s *SQLiteAliasStore) Delete(name string) error {
+	logging.Entry("name", name)
+	result, err := s.db.Exec(`DELETE FROM aliases WHERE name = ?`, name)
+	if err != nil {
+		logging.Error(err, "name", name)
+		return fmt.Errorf("failed to delete alias: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("alias not found: %s", name)
+	}
+	return nil
+}
+
+// List returns every alias, by name.
+func (s *SQLiteAliasStore) List() ([]*domain.Alias, error) {
+	logging.Entry()
+	rows, err := s.db.Query(`SELECT name, target, updated_at FROM aliases ORDER BY name`)
+	if err != nil {
+		logging.Error(err)
+		return nil, fmt.Errorf("failed to list aliases: %w", err)
+	}
+	defer rows.Close()
+
+	var aliases []*domain.Alias
+	for rows.Next() {
+		alias := &domain.Alias{}
+		if err := rows.Scan(&alias.Name, &alias.Target, &alias.UpdatedAt); err != nil {
+			logging.Error(err, "action", "scan alias row")
+			return nil, fmt.Errorf("failed to scan alias: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+	return aliases, rows.Err()
+}
@@ -0,0 +1,76 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func createTestAliasStore(t *testing.T) (*SQLiteAliasStore, func()) {
+	t.Helper()
+
+	tmpDir, err := os.MkdirTemp("", "craizy-alias-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+
+	dbPath := filepath.Join(tmpDir, "test.db")
+	agentStore, err := NewSQLiteAgentStore(dbPath)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		t.Fatalf("failed to create agent store: %v", err)
+	}
+
+	cleanup := func() {
+		agentStore.Close()
+		os.RemoveAll(tmpDir)
+	}
+
+	return NewSQLiteAliasStore(agentStore.DB()), cleanup
+}
+
+func TestSQLiteAliasStore(t *testing.T) {
+	store, cleanup := createTestAliasStore(t)
+	defer cleanup()
+
+	if alias, err := store.Get("lead"); err != nil || alias != nil {
+		t.Fatalf("Get(missing) = %v, %v, want nil, nil", alias, err)
+	}
+
+	now := time.Now()
+	for _, alias := range []*domain.Alias{
+		{Name: "lead", Target: "claude-001", UpdatedAt: now},
+		{Name: "docs", Target: "claude-002", UpdatedAt: now},
+		{Name: "lead", Target: "claude-003", UpdatedAt: now},
+	} {
+		if err := store.Set(alias); err != nil {
+			t.Fatalf("Set(%s) failed: %v", alias.Name, err)
+		}
+	}
+
+	alias, err := store.Get("lead")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if alias.Target != "claude-003" {
+		t.Errorf("Target = %q, want the replacement claude-003", alias.Target)
+	}
+
+	aliases, err := store.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(aliases) != 2 || aliases[0].Name != "docs" || aliases[1].Name != "lead" {
+		t.Errorf("List = %v, want docs and lead by name", aliases)
+	}
+
+	if err := store.Delete("lead"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := store.Delete("lead"); err == nil {
+		t.Error("expected error deleting a missing alias")
+	}
+}
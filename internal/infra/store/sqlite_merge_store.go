@@ -0,0 +1,113 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// SQLiteMergeStore implements IMergeStore with SQLite persistence.
+type SQLiteMergeStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteMergeStore creates a new SQLite-backed merge store.
+// It uses an existing database connection (migrations are run by agent store init).
+func NewSQLiteMergeStore(db *sql.DB) *SQLiteMergeStore {
+	logging.Entry()
+	return &SQLiteMergeStore{db: db}
+}
+
+// Save stores a merge record.
+func (s *SQLiteMergeStore) Save(record *domain.MergeRecord) error {
+	logging.Entry("mergeID", record.ID, "agentID", record.AgentID)
+	_, err := s.db.Exec(`
+		INSERT INTO merges (id, agent_id, branch, base_branch, strategy, outcome, conflict_files, duration_ms, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`, record.ID, record.AgentID, record.Branch, record.BaseBranch, string(record.Strategy),
+		string(record.Outcome), strings.Join(record.ConflictFiles, "\n"),
+		record.Duration.Milliseconds(), record.CreatedAt)
+	if err != nil {
+		logging.Error(err, "mergeID", record.ID)
+		return fmt.Errorf("failed to insert merge record: %w", err)
+	}
+	logging.Info("merge record saved, mergeID=%s, outcome=%s", record.ID, record.Outcome)
+	return nil
+}
+
+// ListByAgent returns merge records for an agent, newest first.
+func (s *SQLiteMergeStore) ListByAgent(agentID string) ([]*domain.MergeRecord, error) {
+	logging.Entry("agentID", agentID)
+	rows, err := s.db.Query(`
+		SELECT id, agent_id, branch, base_branch, strategy, outcome, conflict_files, duration_ms, created_at
+		FROM merges
+		WHERE agent_id = ?
+		ORDER BY created_at DESC
+	`, agentID)
+	if err != nil {
+		logging.Error(err, "agentID", agentID)
+		return nil, fmt.Errorf("failed to list merges: %w", err)
+	}
+	defer rows.Close()
+
+	return s.scanMerges(rows)
+}
+
+// List returns merge records across all agents with a limit (0 = no limit), newest first.
+func (s *SQLiteMergeStore) List(limit int) ([]*domain.MergeRecord, error) {
+	logging.Entry("limit", limit)
+
+	query := `
+		SELECT id, agent_id, branch, base_branch, strategy, outcome, conflict_files, duration_ms, created_at
+		FROM merges
+		ORDER BY created_at DESC
+	`
+	var args []interface{}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		logging.Error(err)
+		return nil, fmt.Errorf("failed to list merges: %w", err)
+	}
+	defer rows.Close()
+
+	return s.scanMerges(rows)
+}
+
+// scanMerges scans rows into a slice of MergeRecord pointers.
+func (s *SQLiteMergeStore) scanMerges(rows *sql.Rows) ([]*domain.MergeRecord, error) {
+	var records []*domain.MergeRecord
+	for rows.Next() {
+		record := &domain.MergeRecord{}
+		var strategy, outcome string
+		var conflictFiles sql.NullString
+		var durationMs int64
+
+		err := rows.Scan(
+			&record.ID, &record.AgentID, &record.Branch, &record.BaseBranch,
+			&strategy, &outcome, &conflictFiles, &durationMs, &record.CreatedAt,
+		)
+		if err != nil {
+			logging.Error(err, "action", "scan merge row")
+			continue
+		}
+
+		record.Strategy = domain.MergeStrategy(strategy)
+		record.Outcome = domain.MergeOutcome(outcome)
+		record.Duration = time.Duration(durationMs) * time.Millisecond
+		if conflictFiles.Valid && conflictFiles.String != "" {
+			record.ConflictFiles = strings.Split(conflictFiles.String, "\n")
+		}
+
+		records = append(records, record)
+	}
+	return records, nil
+}
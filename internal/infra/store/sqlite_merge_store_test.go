@@ -0,0 +1,127 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func createTestMergeStore(t *testing.T) (*SQLiteMergeStore, func()) {
+	t.Helper()
+
+	tmpDir, err := os.MkdirTemp("", "craizy-merge-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+
+	dbPath := filepath.Join(tmpDir, "test.db")
+	agentStore, err := NewSQLiteAgentStore(dbPath)
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		t.Fatalf("failed to create agent store: %v", err)
+	}
+
+	mergeStore := NewSQLiteMergeStore(agentStore.DB())
+
+	cleanup := func() {
+		agentStore.Close()
+		os.RemoveAll(tmpDir)
+	}
+
+	return mergeStore, cleanup
+}
+
+func TestSQLiteMergeStore_SaveAndListByAgent(t *testing.T) {
+	store, cleanup := createTestMergeStore(t)
+	defer cleanup()
+
+	record := &domain.MergeRecord{
+		ID:            "merge-001",
+		AgentID:       "agent-1",
+		Branch:        "craizy-proj-claude-task",
+		BaseBranch:    "main",
+		Strategy:      domain.MergeStrategyMerge,
+		Outcome:       domain.MergeOutcomeConflict,
+		ConflictFiles: []string{"a.go", "b.go"},
+		Duration:      1500 * time.Millisecond,
+		CreatedAt:     time.Now(),
+	}
+
+	if err := store.Save(record); err != nil {
+		t.Fatalf("failed to save merge: %v", err)
+	}
+
+	records, err := store.ListByAgent("agent-1")
+	if err != nil {
+		t.Fatalf("failed to list merges: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+
+	got := records[0]
+	if got.Outcome != domain.MergeOutcomeConflict {
+		t.Errorf("Outcome = %q, want %q", got.Outcome, domain.MergeOutcomeConflict)
+	}
+	if got.Strategy != domain.MergeStrategyMerge {
+		t.Errorf("Strategy = %q, want %q", got.Strategy, domain.MergeStrategyMerge)
+	}
+	if len(got.ConflictFiles) != 2 || got.ConflictFiles[1] != "b.go" {
+		t.Errorf("ConflictFiles = %v, want [a.go b.go]", got.ConflictFiles)
+	}
+	if got.Duration != 1500*time.Millisecond {
+		t.Errorf("Duration = %v, want 1.5s", got.Duration)
+	}
+}
+
+func TestSQLiteMergeStore_List(t *testing.T) {
+	store, cleanup := createTestMergeStore(t)
+	defer cleanup()
+
+	now := time.Now()
+	for i, agentID := range []string{"agent-1", "agent-2", "agent-1"} {
+		_ = store.Save(&domain.MergeRecord{
+			ID:        "merge-" + string(rune('a'+i)),
+			AgentID:   agentID,
+			Strategy:  domain.MergeStrategyMerge,
+			Outcome:   domain.MergeOutcomeSuccess,
+			CreatedAt: now.Add(time.Duration(i) * time.Second),
+		})
+	}
+
+	t.Run("newest first", func(t *testing.T) {
+		records, err := store.List(0)
+		if err != nil {
+			t.Fatalf("failed to list merges: %v", err)
+		}
+		if len(records) != 3 {
+			t.Fatalf("got %d records, want 3", len(records))
+		}
+		if records[0].ID != "merge-c" {
+			t.Errorf("first record = %q, want merge-c", records[0].ID)
+		}
+	})
+
+	t.Run("respects limit", func(t *testing.T) {
+		records, err := store.List(2)
+		if err != nil {
+			t.Fatalf("failed to list merges: %v", err)
+		}
+		if len(records) != 2 {
+			t.Errorf("got %d records, want 2", len(records))
+		}
+	})
+
+	t.Run("filters by agent", func(t *testing.T) {
+		records, err := store.ListByAgent("agent-2")
+		if err != nil {
+			t.Fatalf("failed to list merges: %v", err)
+		}
+		if len(records) != 1 {
+			t.Errorf("got %d records, want 1", len(records))
+		}
+	})
+}
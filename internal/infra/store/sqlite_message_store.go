@@ -9,6 +9,13 @@ import (
 	"github.com/TechnicallyShaun/crAIzy/internal/logging"
 )
 
+// messageColumns lists the columns scanMessage reads, in order.
+const messageColumns = "id, seq, from_agent, to_agent, type, content, related_work, refs, read, created_at, read_at, deliver_at"
+
+// deliveredCond excludes scheduled messages that aren't due yet, given the
+// current Unix time. deliver_at holds Unix seconds so it compares as a number.
+const deliveredCond = "(deliver_at IS NULL OR deliver_at <= ?)"
+
 // SQLiteMessageStore implements IMessageStore with SQLite persistence.
 type SQLiteMessageStore struct {
 	db *sql.DB
@@ -21,19 +28,21 @@ func NewSQLiteMessageStore(db *sql.DB) *SQLiteMessageStore {
 	return &SQLiteMessageStore{db: db}
 }
 
-// Save stores a new message.
+// Save stores a new message and assigns its Seq.
 func (s *SQLiteMessageStore) Save(msg *domain.Message) error {
 	logging.Entry("msgID", msg.ID)
-	_, err := s.db.Exec(`
-		INSERT INTO messages (id, from_agent, to_agent, type, content, related_work, read, created_at, read_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	// The next seq is taken in the same statement so concurrent senders can't share one
+	err := s.db.QueryRow(`
+		INSERT INTO messages (id, seq, from_agent, to_agent, type, content, related_work, refs, read, created_at, read_at, deliver_at)
+		VALUES (?, (SELECT COALESCE(MAX(seq), 0) + 1 FROM messages), ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		RETURNING seq
 	`, msg.ID, msg.From, msg.To, string(msg.Type), msg.Content, msg.RelatedWork,
-		msg.Read, msg.CreatedAt, msg.ReadAt)
+		domain.FormatFileRefs(msg.Refs), msg.Read, msg.CreatedAt, msg.ReadAt, unixOrNil(msg.DeliverAt)).Scan(&msg.Seq)
 	if err != nil {
 		logging.Error(err, "msgID", msg.ID)
 		return fmt.Errorf("failed to insert message: %w", err)
 	}
-	logging.Info("message saved, msgID=%s", msg.ID)
+	logging.Info("message saved, msgID=%s, code=%s", msg.ID, msg.Code())
 	return nil
 }
 
@@ -52,15 +61,15 @@ func (s *SQLiteMessageStore) MarkRead(id string) error {
 	return nil
 }
 
-// ListUnread returns all unread messages for a recipient.
+// ListUnread returns all unread messages for a recipient that are due.
 func (s *SQLiteMessageStore) ListUnread(recipientID string) ([]*domain.Message, error) {
 	logging.Entry("recipientID", recipientID)
 	rows, err := s.db.Query(`
-		SELECT id, from_agent, to_agent, type, content, related_work, read, created_at, read_at
+		SELECT `+messageColumns+`
 		FROM messages
-		WHERE to_agent = ? AND read = FALSE
+		WHERE to_agent = ? AND read = FALSE AND `+deliveredCond+`
 		ORDER BY created_at ASC
-	`, recipientID)
+	`, recipientID, time.Now().Unix())
 	if err != nil {
 		logging.Error(err, "recipientID", recipientID)
 		return nil, fmt.Errorf("failed to list unread messages: %w", err)
@@ -70,35 +79,48 @@ func (s *SQLiteMessageStore) ListUnread(recipientID string) ([]*domain.Message,
 	return s.scanMessages(rows)
 }
 
-// List returns messages for a recipient with a limit (0 = no limit).
-func (s *SQLiteMessageStore) List(recipientID string, limit int) ([]*domain.Message, error) {
-	logging.Entry("recipientID", recipientID, "limit", limit)
+// List returns up to limit messages for a recipient (0 = no limit), newest
+// first, starting below the before cursor if it is nonzero. Scheduled messages
+// appear once due.
+func (s *SQLiteMessageStore) List(recipientID string, before, limit int) ([]*domain.Message, error) {
+	logging.Entry("recipientID", recipientID, "before", before, "limit", limit)
+	return s.listPage("to_agent", recipientID, before, limit)
+}
 
-	var query string
-	var args []interface{}
+// ListSent returns up to limit messages sent by a participant (0 = no limit),
+// newest first, starting below the before cursor if it is nonzero. Scheduled
+// messages appear straight away.
+func (s *SQLiteMessageStore) ListSent(senderID string, before, limit int) ([]*domain.Message, error) {
+	logging.Entry("senderID", senderID, "before", before, "limit", limit)
+	return s.listPage("from_agent", senderID, before, limit)
+}
 
+// listPage returns a page of the messages whose column (to_agent or
+// from_agent) matches participantID, newest first.
+func (s *SQLiteMessageStore) listPage(column, participantID string, before, limit int) ([]*domain.Message, error) {
+	query := `
+		SELECT ` + messageColumns + `
+		FROM messages
+		WHERE ` + column + ` = ?
+	`
+	args := []interface{}{participantID}
+	if column == "to_agent" {
+		query += " AND " + deliveredCond
+		args = append(args, time.Now().Unix())
+	}
+	if before > 0 {
+		query += " AND seq < ?"
+		args = append(args, before)
+	}
+	query += " ORDER BY seq DESC"
 	if limit > 0 {
-		query = `
-			SELECT id, from_agent, to_agent, type, content, related_work, read, created_at, read_at
-			FROM messages
-			WHERE to_agent = ?
-			ORDER BY created_at DESC
-			LIMIT ?
-		`
-		args = []interface{}{recipientID, limit}
-	} else {
-		query = `
-			SELECT id, from_agent, to_agent, type, content, related_work, read, created_at, read_at
-			FROM messages
-			WHERE to_agent = ?
-			ORDER BY created_at DESC
-		`
-		args = []interface{}{recipientID}
+		query += " LIMIT ?"
+		args = append(args, limit)
 	}
 
 	rows, err := s.db.Query(query, args...)
 	if err != nil {
-		logging.Error(err, "recipientID", recipientID)
+		logging.Error(err, column, participantID)
 		return nil, fmt.Errorf("failed to list messages: %w", err)
 	}
 	defer rows.Close()
@@ -106,48 +128,102 @@ func (s *SQLiteMessageStore) List(recipientID string, limit int) ([]*domain.Mess
 	return s.scanMessages(rows)
 }
 
+// ListThread returns messages sent to or from a participant, oldest first.
+func (s *SQLiteMessageStore) ListThread(participantID string) ([]*domain.Message, error) {
+	logging.Entry("participantID", participantID)
+	rows, err := s.db.Query(`
+		SELECT `+messageColumns+`
+		FROM messages
+		WHERE to_agent = ? OR from_agent = ?
+		ORDER BY created_at ASC
+	`, participantID, participantID)
+	if err != nil {
+		logging.Error(err, "participantID", participantID)
+		return nil, fmt.Errorf("failed to list message thread: %w", err)
+	}
+	defer rows.Close()
+
+	return s.scanMessages(rows)
+}
+
+// ListByIDPrefix returns up to limit messages whose ID starts with prefix,
+// newest first. A limit of 0 returns all of them.
+func (s *SQLiteMessageStore) ListByIDPrefix(prefix string, limit int) ([]*domain.Message, error) {
+	logging.Entry("prefix", prefix, "limit", limit)
+	if limit <= 0 {
+		limit = -1 // no limit in SQLite
+	}
+	rows, err := s.db.Query(`
+		SELECT `+messageColumns+`
+		FROM messages
+		WHERE substr(id, 1, length(?)) = ?
+		ORDER BY created_at DESC
+		LIMIT ?
+	`, prefix, prefix, limit)
+	if err != nil {
+		logging.Error(err, "prefix", prefix)
+		return nil, fmt.Errorf("failed to find messages: %w", err)
+	}
+	defer rows.Close()
+
+	return s.scanMessages(rows)
+}
+
 // Get retrieves a message by ID.
 func (s *SQLiteMessageStore) Get(id string) (*domain.Message, error) {
 	logging.Entry("id", id)
-	msg := &domain.Message{}
-	var msgType string
-	var relatedWork sql.NullString
-	var readAt sql.NullTime
+	return s.getWhere("id = ?", id, id)
+}
 
-	err := s.db.QueryRow(`
-		SELECT id, from_agent, to_agent, type, content, related_work, read, created_at, read_at
-		FROM messages WHERE id = ?
-	`, id).Scan(
-		&msg.ID, &msg.From, &msg.To, &msgType, &msg.Content,
-		&relatedWork, &msg.Read, &msg.CreatedAt, &readAt,
-	)
+// GetBySeq retrieves a message by its short sequence number.
+func (s *SQLiteMessageStore) GetBySeq(seq int) (*domain.Message, error) {
+	logging.Entry("seq", seq)
+	return s.getWhere("seq = ?", seq, fmt.Sprintf("M-%d", seq))
+}
+
+// getWhere retrieves the message matching a single-argument condition; ref
+// names it in errors.
+func (s *SQLiteMessageStore) getWhere(cond string, arg interface{}, ref string) (*domain.Message, error) {
+	msg, err := scanMessage(s.db.QueryRow(`
+		SELECT `+messageColumns+`
+		FROM messages WHERE `+cond, arg))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			logging.Debug("message not found, id=%s", id)
-			return nil, fmt.Errorf("message not found: %s", id)
+			logging.Debug("message not found, ref=%s", ref)
+			return nil, fmt.Errorf("message not found: %s", ref)
 		}
-		logging.Error(err, "id", id)
+		logging.Error(err, "ref", ref)
 		return nil, fmt.Errorf("failed to get message: %w", err)
 	}
+	return msg, nil
+}
 
-	msg.Type = domain.MessageType(msgType)
-	if relatedWork.Valid {
-		msg.RelatedWork = &relatedWork.String
-	}
-	if readAt.Valid {
-		msg.ReadAt = &readAt.Time
+// ListDue returns unread scheduled messages whose delivery time has come by
+// now, oldest first.
+func (s *SQLiteMessageStore) ListDue(now time.Time) ([]*domain.Message, error) {
+	logging.Entry()
+	rows, err := s.db.Query(`
+		SELECT `+messageColumns+`
+		FROM messages
+		WHERE read = FALSE AND deliver_at IS NOT NULL AND deliver_at <= ?
+		ORDER BY seq ASC
+	`, now.Unix())
+	if err != nil {
+		logging.Error(err)
+		return nil, fmt.Errorf("failed to list due messages: %w", err)
 	}
+	defer rows.Close()
 
-	return msg, nil
+	return s.scanMessages(rows)
 }
 
-// UnreadCount returns the count of unread messages for a recipient.
+// UnreadCount returns the count of unread messages for a recipient that are due.
 func (s *SQLiteMessageStore) UnreadCount(recipientID string) (int, error) {
 	logging.Entry("recipientID", recipientID)
 	var count int
 	err := s.db.QueryRow(`
-		SELECT COUNT(*) FROM messages WHERE to_agent = ? AND read = FALSE
-	`, recipientID).Scan(&count)
+		SELECT COUNT(*) FROM messages WHERE to_agent = ? AND read = FALSE AND `+deliveredCond+`
+	`, recipientID, time.Now().Unix()).Scan(&count)
 	if err != nil {
 		logging.Error(err, "recipientID", recipientID)
 		return 0, fmt.Errorf("failed to count unread messages: %w", err)
@@ -155,33 +231,113 @@ func (s *SQLiteMessageStore) UnreadCount(recipientID string) (int, error) {
 	return count, nil
 }
 
+// UnreadCountsByRecipient returns the count of unread, due messages for every recipient that has any.
+func (s *SQLiteMessageStore) UnreadCountsByRecipient() (map[string]int, error) {
+	logging.Entry()
+	rows, err := s.db.Query(`
+		SELECT to_agent, COUNT(*) FROM messages WHERE read = FALSE AND `+deliveredCond+` GROUP BY to_agent
+	`, time.Now().Unix())
+	if err != nil {
+		logging.Error(err)
+		return nil, fmt.Errorf("failed to count unread messages: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var recipient string
+		var count int
+		if err := rows.Scan(&recipient, &count); err != nil {
+			logging.Error(err)
+			return nil, fmt.Errorf("failed to scan unread count: %w", err)
+		}
+		counts[recipient] = count
+	}
+	return counts, rows.Err()
+}
+
+// ListUnescalatedQuestions returns unread questions from one agent to another
+// that haven't been escalated to the human, oldest first. Named humans count
+// as humans, not agents.
+func (s *SQLiteMessageStore) ListUnescalatedQuestions() ([]*domain.Message, error) {
+	logging.Entry()
+	rows, err := s.db.Query(`
+		SELECT `+messageColumns+`
+		FROM messages
+		WHERE type = ? AND read = FALSE AND escalated = FALSE
+			AND from_agent != ? AND from_agent NOT LIKE ? AND to_agent != ? AND to_agent NOT LIKE ?
+		ORDER BY seq ASC
+	`, string(domain.MessageTypeQuestion),
+		domain.HumanParticipantID, domain.HumanParticipant("%"),
+		domain.HumanParticipantID, domain.HumanParticipant("%"))
+	if err != nil {
+		logging.Error(err)
+		return nil, fmt.Errorf("failed to list unescalated questions: %w", err)
+	}
+	defer rows.Close()
+
+	return s.scanMessages(rows)
+}
+
+// MarkEscalated records that a message has been escalated to the human.
+func (s *SQLiteMessageStore) MarkEscalated(id string) error {
+	logging.Entry("id", id)
+	if _, err := s.db.Exec(`UPDATE messages SET escalated = TRUE WHERE id = ?`, id); err != nil {
+		logging.Error(err, "id", id)
+		return fmt.Errorf("failed to mark message as escalated: %w", err)
+	}
+	return nil
+}
+
 // scanMessages scans rows into a slice of Message pointers.
 func (s *SQLiteMessageStore) scanMessages(rows *sql.Rows) ([]*domain.Message, error) {
 	var messages []*domain.Message
 	for rows.Next() {
-		msg := &domain.Message{}
-		var msgType string
-		var relatedWork sql.NullString
-		var readAt sql.NullTime
-
-		err := rows.Scan(
-			&msg.ID, &msg.From, &msg.To, &msgType, &msg.Content,
-			&relatedWork, &msg.Read, &msg.CreatedAt, &readAt,
-		)
+		msg, err := scanMessage(rows)
 		if err != nil {
 			logging.Error(err, "action", "scan message row")
 			continue
 		}
-
-		msg.Type = domain.MessageType(msgType)
-		if relatedWork.Valid {
-			msg.RelatedWork = &relatedWork.String
-		}
-		if readAt.Valid {
-			msg.ReadAt = &readAt.Time
-		}
-
 		messages = append(messages, msg)
 	}
 	return messages, nil
 }
+
+// scanMessage scans one row of messageColumns.
+func scanMessage(row interface{ Scan(dest ...any) error }) (*domain.Message, error) {
+	msg := &domain.Message{}
+	var msgType string
+	var relatedWork, refs sql.NullString
+	var readAt sql.NullTime
+	var deliverAt sql.NullInt64
+
+	err := row.Scan(
+		&msg.ID, &msg.Seq, &msg.From, &msg.To, &msgType, &msg.Content,
+		&relatedWork, &refs, &msg.Read, &msg.CreatedAt, &readAt, &deliverAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	msg.Type = domain.MessageType(msgType)
+	if relatedWork.Valid {
+		msg.RelatedWork = &relatedWork.String
+	}
+	if readAt.Valid {
+		msg.ReadAt = &readAt.Time
+	}
+	if deliverAt.Valid {
+		at := time.Unix(deliverAt.Int64, 0)
+		msg.DeliverAt = &at
+	}
+	msg.Refs = domain.ParseFileRefs(refs.String)
+	return msg, nil
+}
+
+// unixOrNil stores an optional time as Unix seconds, or NULL.
+func unixOrNil(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.Unix()
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/infra/storetest"
 )
 
 func createTestMessageStore(t *testing.T) (*SQLiteMessageStore, func()) {
@@ -184,7 +185,7 @@ func TestSQLiteMessageStore_List(t *testing.T) {
 	}
 
 	t.Run("list all messages for recipient", func(t *testing.T) {
-		msgs, err := store.List("recipient-001", 0)
+		msgs, err := store.List("recipient-001", 0, 0)
 		if err != nil {
 			t.Fatalf("failed to list: %v", err)
 		}
@@ -194,7 +195,7 @@ func TestSQLiteMessageStore_List(t *testing.T) {
 	})
 
 	t.Run("list with limit", func(t *testing.T) {
-		msgs, err := store.List("recipient-001", 2)
+		msgs, err := store.List("recipient-001", 0, 2)
 		if err != nil {
 			t.Fatalf("failed to list: %v", err)
 		}
@@ -202,6 +203,78 @@ func TestSQLiteMessageStore_List(t *testing.T) {
 			t.Errorf("expected 2 messages, got %d", len(msgs))
 		}
 	})
+
+	t.Run("list pages by cursor", func(t *testing.T) {
+		first, err := store.List("recipient-001", 0, 2)
+		if err != nil {
+			t.Fatalf("failed to list: %v", err)
+		}
+		if len(first) != 2 || first[0].ID != "msg-3" || first[1].ID != "msg-2" {
+			t.Fatalf("expected msg-3, msg-2 on the first page, got %v", first)
+		}
+
+		next, err := store.List("recipient-001", first[1].Seq, 2)
+		if err != nil {
+			t.Fatalf("failed to list: %v", err)
+		}
+		if len(next) != 1 || next[0].ID != "msg-1" {
+			t.Errorf("expected only msg-1 on the next page, got %v", next)
+		}
+	})
+}
+
+func TestSQLiteMessageStore_ListSent(t *testing.T) {
+	store, cleanup := createTestMessageStore(t)
+	defer cleanup()
+
+	now := time.Now()
+	messages := []*domain.Message{
+		{ID: "msg-1", From: "worker-001", To: "lead", Type: domain.MessageTypeInfo, Content: "first", CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "msg-2", From: "lead", To: "worker-001", Type: domain.MessageTypeInfo, Content: "reply", CreatedAt: now.Add(-1 * time.Hour)},
+		{ID: "msg-3", From: "worker-001", To: "human", Type: domain.MessageTypeQuestion, Content: "second", CreatedAt: now},
+	}
+	for _, msg := range messages {
+		_ = store.Save(msg)
+	}
+
+	sent, err := store.ListSent("worker-001", 0, 0)
+	if err != nil {
+		t.Fatalf("failed to list sent: %v", err)
+	}
+	if len(sent) != 2 || sent[0].ID != "msg-3" || sent[1].ID != "msg-1" {
+		t.Fatalf("expected msg-3, msg-1, got %v", sent)
+	}
+
+	older, err := store.ListSent("worker-001", sent[0].Seq, 1)
+	if err != nil {
+		t.Fatalf("failed to list sent: %v", err)
+	}
+	if len(older) != 1 || older[0].ID != "msg-1" {
+		t.Errorf("expected only msg-1 before the cursor, got %v", older)
+	}
+}
+
+func TestSQLiteMessageStore_ListThread(t *testing.T) {
+	store, cleanup := createTestMessageStore(t)
+	defer cleanup()
+
+	now := time.Now()
+	messages := []*domain.Message{
+		{ID: "msg-2", From: "agent-001", To: "human", Type: domain.MessageTypeQuestion, Content: "which db?", CreatedAt: now.Add(-1 * time.Hour)},
+		{ID: "msg-1", From: "human", To: "agent-001", Type: domain.MessageTypeAssignment, Content: "add auth", CreatedAt: now.Add(-2 * time.Hour)},
+		{ID: "msg-3", From: "human", To: "agent-002", Type: domain.MessageTypeInfo, Content: "other", CreatedAt: now},
+	}
+	for _, msg := range messages {
+		_ = store.Save(msg)
+	}
+
+	msgs, err := store.ListThread("agent-001")
+	if err != nil {
+		t.Fatalf("failed to list thread: %v", err)
+	}
+	if len(msgs) != 2 || msgs[0].ID != "msg-1" || msgs[1].ID != "msg-2" {
+		t.Errorf("expected [msg-1 msg-2] oldest first, got %d messages", len(msgs))
+	}
 }
 
 func TestSQLiteMessageStore_Get(t *testing.T) {
@@ -231,6 +304,82 @@ func TestSQLiteMessageStore_Get(t *testing.T) {
 	}
 }
 
+func TestSQLiteMessageStore_ListByIDPrefix(t *testing.T) {
+	store, cleanup := createTestMessageStore(t)
+	defer cleanup()
+
+	now := time.Now()
+	for i, id := range []string{"3f2a01", "3f2b02", "9c0d03"} {
+		_ = store.Save(&domain.Message{ID: id, From: "a", To: "b", Type: domain.MessageTypeQuestion, CreatedAt: now.Add(time.Duration(i) * time.Second)})
+	}
+
+	msgs, err := store.ListByIDPrefix("3f2", 10)
+	if err != nil {
+		t.Fatalf("ListByIDPrefix failed: %v", err)
+	}
+	if len(msgs) != 2 || msgs[0].ID != "3f2b02" || msgs[1].ID != "3f2a01" {
+		t.Errorf("expected [3f2b02 3f2a01] newest first, got %d messages", len(msgs))
+	}
+
+	msgs, _ = store.ListByIDPrefix("3f2a", 10)
+	if len(msgs) != 1 || msgs[0].ID != "3f2a01" {
+		t.Errorf("expected [3f2a01], got %d messages", len(msgs))
+	}
+
+	// LIKE wildcards in the prefix are literal
+	msgs, _ = store.ListByIDPrefix("%", 10)
+	if len(msgs) != 0 {
+		t.Errorf("expected no messages for %q, got %d", "%", len(msgs))
+	}
+}
+
+func TestSQLiteMessageStore_Seq(t *testing.T) {
+	store, cleanup := createTestMessageStore(t)
+	defer cleanup()
+
+	first := domain.NewMessage("a", "b", domain.MessageTypeInfo, "one", nil)
+	second := domain.NewMessage("a", "b", domain.MessageTypeInfo, "two", nil)
+	if err := store.Save(first); err != nil {
+		t.Fatalf("failed to save message: %v", err)
+	}
+	if err := store.Save(second); err != nil {
+		t.Fatalf("failed to save message: %v", err)
+	}
+	if first.Seq != 1 || second.Seq != 2 {
+		t.Errorf("expected seqs 1 and 2, got %d and %d", first.Seq, second.Seq)
+	}
+
+	retrieved, err := store.GetBySeq(2)
+	if err != nil {
+		t.Fatalf("GetBySeq failed: %v", err)
+	}
+	if retrieved.ID != second.ID || retrieved.Code() != "M-2" {
+		t.Errorf("expected %s (M-2), got %s (%s)", second.ID, retrieved.ID, retrieved.Code())
+	}
+	if _, err := store.GetBySeq(3); err == nil {
+		t.Error("expected error for unknown seq")
+	}
+}
+
+func TestSQLiteMessageStore_Refs(t *testing.T) {
+	store, cleanup := createTestMessageStore(t)
+	defer cleanup()
+
+	msg := domain.NewMessage("worker-001", "human", domain.MessageTypeQuestion, "Is this right?", nil)
+	msg.Refs = []domain.FileRef{{Path: "auth/token.go", Start: 40, End: 58}, {Path: "README.md"}}
+	if err := store.Save(msg); err != nil {
+		t.Fatalf("failed to save message: %v", err)
+	}
+
+	retrieved, err := store.Get(msg.ID)
+	if err != nil {
+		t.Fatalf("failed to get message: %v", err)
+	}
+	if len(retrieved.Refs) != 2 || retrieved.Refs[0] != msg.Refs[0] || retrieved.Refs[1] != msg.Refs[1] {
+		t.Errorf("expected refs %v, got %v", msg.Refs, retrieved.Refs)
+	}
+}
+
 func TestSQLiteMessageStore_GetNonExistent(t *testing.T) {
 	store, cleanup := createTestMessageStore(t)
 	defer cleanup()
@@ -265,6 +414,113 @@ func TestSQLiteMessageStore_UnreadCount(t *testing.T) {
 	}
 }
 
+func TestSQLiteMessageStore_UnreadCountsByRecipient(t *testing.T) {
+	store, cleanup := createTestMessageStore(t)
+	defer cleanup()
+
+	messages := []*domain.Message{
+		{ID: "msg-1", From: "sender", To: "recipient-001", Type: domain.MessageTypeInfo, Content: "msg1", Read: false, CreatedAt: time.Now()},
+		{ID: "msg-2", From: "sender", To: "recipient-001", Type: domain.MessageTypeInfo, Content: "msg2", Read: true, CreatedAt: time.Now()},
+		{ID: "msg-3", From: "sender", To: "recipient-001", Type: domain.MessageTypeInfo, Content: "msg3", Read: false, CreatedAt: time.Now()},
+		{ID: "msg-4", From: "sender", To: "recipient-002", Type: domain.MessageTypeInfo, Content: "msg4", Read: false, CreatedAt: time.Now()},
+		{ID: "msg-5", From: "sender", To: "recipient-003", Type: domain.MessageTypeInfo, Content: "msg5", Read: true, CreatedAt: time.Now()},
+	}
+
+	for _, msg := range messages {
+		_ = store.Save(msg)
+	}
+
+	counts, err := store.UnreadCountsByRecipient()
+	if err != nil {
+		t.Fatalf("failed to count unread: %v", err)
+	}
+	if len(counts) != 2 || counts["recipient-001"] != 2 || counts["recipient-002"] != 1 {
+		t.Errorf("expected 2 for recipient-001 and 1 for recipient-002, got %v", counts)
+	}
+}
+
+func TestSQLiteMessageStore_Scheduled(t *testing.T) {
+	store, cleanup := createTestMessageStore(t)
+	defer cleanup()
+
+	now := time.Now()
+	future, past := now.Add(time.Hour), now.Add(-time.Minute)
+	messages := []*domain.Message{
+		{ID: "msg-1", From: "human", To: "worker-001", Type: domain.MessageTypeInfo, Content: "now", CreatedAt: now},
+		{ID: "msg-2", From: "human", To: "worker-001", Type: domain.MessageTypeInfo, Content: "due", CreatedAt: now, DeliverAt: &past},
+		{ID: "msg-3", From: "human", To: "worker-001", Type: domain.MessageTypeInfo, Content: "later", CreatedAt: now, DeliverAt: &future},
+	}
+	for _, msg := range messages {
+		if err := store.Save(msg); err != nil {
+			t.Fatalf("failed to save: %v", err)
+		}
+	}
+
+	got, err := store.Get("msg-3")
+	if err != nil || got.DeliverAt == nil || got.DeliverAt.Unix() != future.Unix() {
+		t.Fatalf("Get(msg-3) = %+v, %v, want DeliverAt kept", got, err)
+	}
+
+	unread, _ := store.ListUnread("worker-001")
+	if len(unread) != 2 {
+		t.Errorf("expected the 2 due messages unread, got %d", len(unread))
+	}
+	if count, _ := store.UnreadCount("worker-001"); count != 2 {
+		t.Errorf("expected an unread count of 2, got %d", count)
+	}
+	if counts, _ := store.UnreadCountsByRecipient(); counts["worker-001"] != 2 {
+		t.Errorf("expected 2 unread for worker-001, got %v", counts)
+	}
+	if received, _ := store.List("worker-001", 0, 0); len(received) != 2 {
+		t.Errorf("expected the recipient to list 2 messages, got %d", len(received))
+	}
+	if sent, _ := store.ListSent("human", 0, 0); len(sent) != 3 {
+		t.Errorf("expected the sender to list all 3 messages, got %d", len(sent))
+	}
+
+	due, err := store.ListDue(now)
+	if err != nil {
+		t.Fatalf("failed to list due: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != "msg-2" {
+		t.Errorf("expected only msg-2 due, got %v", due)
+	}
+}
+
+func TestSQLiteMessageStore_ListUnescalatedQuestions(t *testing.T) {
+	store, cleanup := createTestMessageStore(t)
+	defer cleanup()
+
+	now := time.Now()
+	messages := []*domain.Message{
+		{ID: "msg-1", From: "worker-001", To: "worker-002", Type: domain.MessageTypeQuestion, Content: "pending", CreatedAt: now},
+		{ID: "msg-2", From: "worker-001", To: "worker-002", Type: domain.MessageTypeQuestion, Content: "delivered", Read: true, CreatedAt: now},
+		{ID: "msg-3", From: "worker-001", To: "worker-002", Type: domain.MessageTypeInfo, Content: "not a question", CreatedAt: now},
+		{ID: "msg-4", From: "worker-001", To: domain.HumanParticipantID, Type: domain.MessageTypeQuestion, Content: "for the human", CreatedAt: now},
+		{ID: "msg-5", From: "worker-003", To: "worker-002", Type: domain.MessageTypeQuestion, Content: "also pending", CreatedAt: now},
+		{ID: "msg-6", From: "worker-001", To: domain.HumanParticipant("alice"), Type: domain.MessageTypeQuestion, Content: "for alice", CreatedAt: now},
+	}
+	for _, msg := range messages {
+		_ = store.Save(msg)
+	}
+
+	questions, err := store.ListUnescalatedQuestions()
+	if err != nil {
+		t.Fatalf("failed to list questions: %v", err)
+	}
+	if len(questions) != 2 || questions[0].ID != "msg-1" || questions[1].ID != "msg-5" {
+		t.Fatalf("expected msg-1 and msg-5, got %v", questions)
+	}
+
+	if err := store.MarkEscalated("msg-1"); err != nil {
+		t.Fatalf("failed to mark escalated: %v", err)
+	}
+	questions, _ = store.ListUnescalatedQuestions()
+	if len(questions) != 1 || questions[0].ID != "msg-5" {
+		t.Errorf("expected only msg-5 after escalating msg-1, got %v", questions)
+	}
+}
+
 func TestSQLiteMessageStore_Persistence(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "craizy-msg-persist-test-*")
 	if err != nil {
@@ -309,3 +565,11 @@ func TestSQLiteMessageStore_Persistence(t *testing.T) {
 		t.Errorf("expected Content %q, got %q", "Progress update", retrieved.Content)
 	}
 }
+
+func TestSQLiteMessageStore_Contract(t *testing.T) {
+	storetest.TestMessageStore(t, func(t *testing.T) domain.IMessageStore {
+		store, cleanup := createTestMessageStore(t)
+		t.Cleanup(cleanup)
+		return store
+	})
+}
@@ -2,7 +2,10 @@ package store
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	_ "modernc.org/sqlite"
@@ -17,7 +20,8 @@ type SQLiteAgentStore struct {
 }
 
 // NewSQLiteAgentStore creates a new SQLite-backed agent store.
-// It opens the database with WAL mode and runs migrations.
+// It opens the database with WAL mode, checks its integrity and runs
+// migrations. A corrupt database returns an ErrCorrupt error.
 func NewSQLiteAgentStore(dbPath string) (*SQLiteAgentStore, error) {
 	logging.Entry("dbPath", dbPath)
 	db, err := sql.Open("sqlite", dbPath+"?_pragma=journal_mode(WAL)")
@@ -26,6 +30,12 @@ func NewSQLiteAgentStore(dbPath string) (*SQLiteAgentStore, error) {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
+	if err := CheckIntegrity(db); err != nil {
+		logging.Error(err, "dbPath", dbPath)
+		db.Close()
+		return nil, err
+	}
+
 	// Run migrations
 	if err := Migrate(db); err != nil {
 		logging.Error(err, "action", "migrate")
@@ -37,6 +47,43 @@ func NewSQLiteAgentStore(dbPath string) (*SQLiteAgentStore, error) {
 	return &SQLiteAgentStore{db: db}, nil
 }
 
+// ErrSchemaOutdated is returned by OpenSQLiteAgentStoreReadOnly when the
+// database has to be migrated before it can be read.
+var ErrSchemaOutdated = errors.New("database schema is out of date")
+
+// OpenSQLiteAgentStoreReadOnly opens an existing database for queries only, for
+// short-lived commands that just read, such as `craizy msg count`. Unlike
+// NewSQLiteAgentStore it skips migrations and holds a single connection, and
+// writes fail. It returns an fs.ErrNotExist error if the database doesn't exist
+// yet and ErrSchemaOutdated if it needs migrating.
+func OpenSQLiteAgentStoreReadOnly(dbPath string) (*SQLiteAgentStore, error) {
+	logging.Entry("dbPath", dbPath)
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil, err
+	}
+	// WAL mode is persistent, so only the read-only and busy pragmas are needed
+	db, err := sql.Open("sqlite", dbPath+"?_pragma=query_only(true)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		logging.Error(err, "dbPath", dbPath)
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	version, err := userVersion(db)
+	if err != nil {
+		db.Close()
+		logging.Error(err, "dbPath", dbPath)
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if version < SchemaVersion {
+		db.Close()
+		return nil, ErrSchemaOutdated
+	}
+
+	logging.Info("SQLite store opened read-only, dbPath=%s", dbPath)
+	return &SQLiteAgentStore{db: db}, nil
+}
+
 // Close closes the database connection.
 func (s *SQLiteAgentStore) Close() error {
 	logging.Entry()
@@ -49,18 +96,85 @@ func (s *SQLiteAgentStore) DB() *sql.DB {
 	return s.db
 }
 
+// agentColumns lists the agents table columns in the order scanAgent reads them.
+const agentColumns = `id, project, agent_type, name, command, work_dir, status, created_at, terminated_at,
+	branch, base_branch, sparse_paths, summary, attached_ms, paused_ms, restarts`
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanAgent reads a single agent row selected with agentColumns.
+func scanAgent(row rowScanner) (*domain.Agent, error) {
+	agent := &domain.Agent{}
+	var status string
+	var terminatedAt sql.NullTime
+	var branch, baseBranch, sparsePaths, summary sql.NullString
+	var attachedMs, pausedMs, restarts sql.NullInt64
+	err := row.Scan(
+		&agent.ID, &agent.Project, &agent.AgentType, &agent.Name,
+		&agent.Command, &agent.WorkDir, &status, &agent.CreatedAt, &terminatedAt,
+		&branch, &baseBranch, &sparsePaths, &summary, &attachedMs, &pausedMs, &restarts,
+	)
+	if err != nil {
+		return nil, err
+	}
+	agent.Status = domain.AgentStatus(status)
+	if terminatedAt.Valid {
+		agent.TerminatedAt = &terminatedAt.Time
+	}
+	agent.Branch = branch.String
+	agent.BaseBranch = baseBranch.String
+	agent.Summary = summary.String
+	agent.AttachedTime = time.Duration(attachedMs.Int64) * time.Millisecond
+	agent.PausedTime = time.Duration(pausedMs.Int64) * time.Millisecond
+	agent.Restarts = int(restarts.Int64)
+	if sparsePaths.String != "" {
+		agent.SparsePaths = strings.Split(sparsePaths.String, "\n")
+	}
+	return agent, nil
+}
+
 // Add stores a new agent.
 func (s *SQLiteAgentStore) Add(agent *domain.Agent) error {
 	logging.Entry("agentID", agent.ID)
-	_, err := s.db.Exec(`
-		INSERT INTO agents (id, project, agent_type, name, command, work_dir, status, created_at, terminated_at, branch, base_branch)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	// The agent and its metadata are stored together, so a creator recorded in
+	// metadata can't be missing from a stored agent
+	tx, err := s.db.Begin()
+	if err != nil {
+		logging.Error(err, "agentID", agent.ID)
+		return fmt.Errorf("failed to insert agent: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	_, err = tx.Exec(`
+		INSERT INTO agents (`+agentColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`, agent.ID, agent.Project, agent.AgentType, agent.Name, agent.Command, agent.WorkDir,
-		string(agent.Status), agent.CreatedAt, agent.TerminatedAt, agent.Branch, agent.BaseBranch)
+		string(agent.Status), agent.CreatedAt, agent.TerminatedAt, agent.Branch, agent.BaseBranch,
+		strings.Join(agent.SparsePaths, "\n"), agent.Summary,
+		agent.AttachedTime.Milliseconds(), agent.PausedTime.Milliseconds(), agent.Restarts)
 	if err != nil {
 		logging.Error(err, "agentID", agent.ID)
 		return fmt.Errorf("failed to insert agent: %w", err)
 	}
+	for key, value := range agent.Metadata {
+		if value == "" {
+			continue
+		}
+		if _, err := tx.Exec(`
+			INSERT INTO agent_metadata (agent_id, key, value) VALUES (?, ?, ?)
+			ON CONFLICT(agent_id, key) DO UPDATE SET value = excluded.value
+		`, agent.ID, key, value); err != nil {
+			logging.Error(err, "agentID", agent.ID, "key", key)
+			return fmt.Errorf("failed to insert agent metadata: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		logging.Error(err, "agentID", agent.ID)
+		return fmt.Errorf("failed to insert agent: %w", err)
+	}
 	logging.Info("agent added to store, agentID=%s", agent.ID)
 	return nil
 }
@@ -73,6 +187,9 @@ func (s *SQLiteAgentStore) Remove(id string) error {
 		logging.Error(err, "id", id)
 		return fmt.Errorf("failed to delete agent: %w", err)
 	}
+	if _, err := s.db.Exec("DELETE FROM agent_metadata WHERE agent_id = ?", id); err != nil {
+		logging.Error(err, "id", id, "action", "delete metadata")
+	}
 	logging.Info("agent removed from store, id=%s", id)
 	return nil
 }
@@ -80,11 +197,7 @@ func (s *SQLiteAgentStore) Remove(id string) error {
 // List returns all stored agents.
 func (s *SQLiteAgentStore) List() []*domain.Agent {
 	logging.Entry()
-	rows, err := s.db.Query(`
-		SELECT id, project, agent_type, name, command, work_dir, status, created_at, terminated_at, branch, base_branch
-		FROM agents
-		ORDER BY created_at DESC
-	`)
+	rows, err := s.db.Query(`SELECT ` + agentColumns + ` FROM agents ORDER BY created_at DESC`)
 	if err != nil {
 		logging.Error(err)
 		return nil
@@ -93,31 +206,21 @@ func (s *SQLiteAgentStore) List() []*domain.Agent {
 
 	var agents []*domain.Agent
 	for rows.Next() {
-		agent := &domain.Agent{}
-		var status string
-		var terminatedAt sql.NullTime
-		var branch, baseBranch sql.NullString
-		err := rows.Scan(
-			&agent.ID, &agent.Project, &agent.AgentType, &agent.Name,
-			&agent.Command, &agent.WorkDir, &status, &agent.CreatedAt, &terminatedAt,
-			&branch, &baseBranch,
-		)
+		agent, err := scanAgent(rows)
 		if err != nil {
 			logging.Error(err, "action", "scan row")
 			continue
 		}
-		agent.Status = domain.AgentStatus(status)
-		if terminatedAt.Valid {
-			agent.TerminatedAt = &terminatedAt.Time
-		}
-		if branch.Valid {
-			agent.Branch = branch.String
-		}
-		if baseBranch.Valid {
-			agent.BaseBranch = baseBranch.String
-		}
 		agents = append(agents, agent)
 	}
+
+	metadata, err := s.loadMetadata("")
+	if err != nil {
+		logging.Error(err, "action", "load metadata")
+	}
+	for _, agent := range agents {
+		agent.Metadata = metadata[agent.ID]
+	}
 	logging.Debug("listed %d agents from store", len(agents))
 	return agents
 }
@@ -125,33 +228,62 @@ func (s *SQLiteAgentStore) List() []*domain.Agent {
 // Get retrieves an agent by ID.
 func (s *SQLiteAgentStore) Get(id string) *domain.Agent {
 	logging.Entry("id", id)
-	agent := &domain.Agent{}
-	var status string
-	var terminatedAt sql.NullTime
-	var branch, baseBranch sql.NullString
-	err := s.db.QueryRow(`
-		SELECT id, project, agent_type, name, command, work_dir, status, created_at, terminated_at, branch, base_branch
-		FROM agents WHERE id = ?
-	`, id).Scan(
-		&agent.ID, &agent.Project, &agent.AgentType, &agent.Name,
-		&agent.Command, &agent.WorkDir, &status, &agent.CreatedAt, &terminatedAt,
-		&branch, &baseBranch,
-	)
+	agent, err := scanAgent(s.db.QueryRow(`SELECT `+agentColumns+` FROM agents WHERE id = ?`, id))
 	if err != nil {
 		logging.Debug("agent not found, id=%s", id)
 		return nil
 	}
-	agent.Status = domain.AgentStatus(status)
-	if terminatedAt.Valid {
-		agent.TerminatedAt = &terminatedAt.Time
+	metadata, err := s.loadMetadata(id)
+	if err != nil {
+		logging.Error(err, "id", id, "action", "load metadata")
+	}
+	agent.Metadata = metadata[id]
+	return agent
+}
+
+// loadMetadata returns the metadata of one agent, or every agent if id is
+// empty, by agent ID.
+func (s *SQLiteAgentStore) loadMetadata(id string) (map[string]map[string]string, error) {
+	rows, err := s.db.Query(`
+		SELECT agent_id, key, value FROM agent_metadata WHERE ? = '' OR agent_id = ?
+	`, id, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load agent metadata: %w", err)
 	}
-	if branch.Valid {
-		agent.Branch = branch.String
+	defer rows.Close()
+
+	metadata := make(map[string]map[string]string)
+	for rows.Next() {
+		var agentID, key, value string
+		if err := rows.Scan(&agentID, &key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan agent metadata: %w", err)
+		}
+		if metadata[agentID] == nil {
+			metadata[agentID] = make(map[string]string)
+		}
+		metadata[agentID][key] = value
 	}
-	if baseBranch.Valid {
-		agent.BaseBranch = baseBranch.String
+	return metadata, rows.Err()
+}
+
+// SetMetadata sets a metadata value on an agent, deleting the key if value is empty.
+func (s *SQLiteAgentStore) SetMetadata(id, key, value string) error {
+	logging.Entry("id", id, "key", key)
+	var err error
+	if value == "" {
+		_, err = s.db.Exec("DELETE FROM agent_metadata WHERE agent_id = ? AND key = ?", id, key)
+	} else {
+		_, err = s.db.Exec(`
+			INSERT INTO agent_metadata (agent_id, key, value) VALUES (?, ?, ?)
+			ON CONFLICT(agent_id, key) DO UPDATE SET value = excluded.value
+		`, id, key, value)
 	}
-	return agent
+	if err != nil {
+		logging.Error(err, "id", id, "key", key)
+		return fmt.Errorf("failed to update agent metadata: %w", err)
+	}
+	logging.Info("agent metadata updated, id=%s, key=%s", id, key)
+	return nil
 }
 
 // Exists checks if an agent with the given ID exists.
@@ -185,3 +317,63 @@ func (s *SQLiteAgentStore) UpdateStatus(id string, status domain.AgentStatus) er
 	logging.Info("agent status updated, id=%s, status=%s", id, status)
 	return nil
 }
+
+// UpdateBaseBranch updates the base branch of an agent.
+func (s *SQLiteAgentStore) UpdateBaseBranch(id, baseBranch string) error {
+	logging.Entry("id", id, "baseBranch", baseBranch)
+	_, err := s.db.Exec("UPDATE agents SET base_branch = ? WHERE id = ?", baseBranch, id)
+	if err != nil {
+		logging.Error(err, "id", id, "baseBranch", baseBranch)
+		return fmt.Errorf("failed to update agent base branch: %w", err)
+	}
+	logging.Info("agent base branch updated, id=%s, baseBranch=%s", id, baseBranch)
+	return nil
+}
+
+// UpdateSummary stores a short summary of an agent's completed work.
+func (s *SQLiteAgentStore) UpdateSummary(id, summary string) error {
+	logging.Entry("id", id, "summaryLen", len(summary))
+	_, err := s.db.Exec("UPDATE agents SET summary = ? WHERE id = ?", summary, id)
+	if err != nil {
+		logging.Error(err, "id", id)
+		return fmt.Errorf("failed to update agent summary: %w", err)
+	}
+	logging.Info("agent summary updated, id=%s", id)
+	return nil
+}
+
+// AddPausedTime adds to the total time an agent has spent paused.
+func (s *SQLiteAgentStore) AddPausedTime(id string, d time.Duration) error {
+	logging.Entry("id", id, "duration", d)
+	_, err := s.db.Exec("UPDATE agents SET paused_ms = COALESCE(paused_ms, 0) + ? WHERE id = ?", d.Milliseconds(), id)
+	if err != nil {
+		logging.Error(err, "id", id)
+		return fmt.Errorf("failed to update agent paused time: %w", err)
+	}
+	logging.Info("agent paused time updated, id=%s, added=%s", id, d)
+	return nil
+}
+
+// AddRestart counts an automatic restart of an agent after its session died.
+func (s *SQLiteAgentStore) AddRestart(id string) error {
+	logging.Entry("id", id)
+	_, err := s.db.Exec("UPDATE agents SET restarts = COALESCE(restarts, 0) + 1 WHERE id = ?", id)
+	if err != nil {
+		logging.Error(err, "id", id)
+		return fmt.Errorf("failed to update agent restarts: %w", err)
+	}
+	logging.Info("agent restart counted, id=%s", id)
+	return nil
+}
+
+// AddAttachedTime adds to the total time the human has spent attached to an agent.
+func (s *SQLiteAgentStore) AddAttachedTime(id string, d time.Duration) error {
+	logging.Entry("id", id, "duration", d)
+	_, err := s.db.Exec("UPDATE agents SET attached_ms = COALESCE(attached_ms, 0) + ? WHERE id = ?", d.Milliseconds(), id)
+	if err != nil {
+		logging.Error(err, "id", id)
+		return fmt.Errorf("failed to update agent attached time: %w", err)
+	}
+	logging.Info("agent attached time updated, id=%s, added=%s", id, d)
+	return nil
+}
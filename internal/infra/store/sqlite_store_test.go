@@ -1,12 +1,17 @@
 package store
 
 import (
+	"database/sql"
+	"errors"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/infra/storetest"
 )
 
 func createTestStore(t *testing.T) (*SQLiteAgentStore, func()) {
@@ -304,3 +309,299 @@ func TestSQLiteAgentStore_Persistence(t *testing.T) {
 		t.Errorf("expected Name 'persist', got %q", retrieved.Name)
 	}
 }
+
+func TestOpenSQLiteAgentStoreReadOnly(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "readonly.db")
+
+	if _, err := OpenSQLiteAgentStoreReadOnly(dbPath); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("err = %v, want fs.ErrNotExist before the database exists", err)
+	}
+
+	// A database created before schema versions were recorded
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	_, _ = db.Exec("CREATE TABLE notes (body TEXT)")
+	db.Close()
+	if _, err := OpenSQLiteAgentStoreReadOnly(dbPath); !errors.Is(err, ErrSchemaOutdated) {
+		t.Errorf("err = %v, want ErrSchemaOutdated before migrating", err)
+	}
+
+	writer, err := NewSQLiteAgentStore(dbPath)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	_ = writer.Add(&domain.Agent{ID: "agent-1", Project: "test", Status: domain.AgentStatusActive, CreatedAt: time.Now()})
+	writer.Close()
+
+	reader, err := OpenSQLiteAgentStoreReadOnly(dbPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer reader.Close()
+	if reader.Get("agent-1") == nil {
+		t.Error("expected to read the stored agent")
+	}
+	if err := reader.Add(&domain.Agent{ID: "agent-2", CreatedAt: time.Now()}); err == nil {
+		t.Error("expected writes to fail")
+	}
+}
+
+func TestSQLiteAgentStore_UpdateBaseBranch(t *testing.T) {
+	store, cleanup := createTestStore(t)
+	defer cleanup()
+
+	agent := &domain.Agent{
+		ID:         "test-agent",
+		Project:    "test",
+		AgentType:  "claude",
+		Name:       "worker",
+		Command:    "echo",
+		WorkDir:    "/tmp",
+		Status:     domain.AgentStatusActive,
+		CreatedAt:  time.Now(),
+		Branch:     "feature",
+		BaseBranch: "main",
+	}
+	if err := store.Add(agent); err != nil {
+		t.Fatalf("failed to add agent: %v", err)
+	}
+
+	if err := store.UpdateBaseBranch(agent.ID, "release"); err != nil {
+		t.Fatalf("failed to update base branch: %v", err)
+	}
+
+	retrieved := store.Get(agent.ID)
+	if retrieved.BaseBranch != "release" {
+		t.Errorf("BaseBranch = %q, want release", retrieved.BaseBranch)
+	}
+}
+
+func TestSQLiteAgentStore_UpdateSummary(t *testing.T) {
+	store, cleanup := createTestStore(t)
+	defer cleanup()
+
+	agent := &domain.Agent{
+		ID:        "test-agent",
+		Project:   "test",
+		AgentType: "claude",
+		Name:      "worker",
+		Command:   "echo",
+		WorkDir:   "/tmp",
+		Status:    domain.AgentStatusActive,
+		CreatedAt: time.Now(),
+	}
+	if err := store.Add(agent); err != nil {
+		t.Fatalf("failed to add agent: %v", err)
+	}
+
+	if err := store.UpdateSummary(agent.ID, "Added token refresh"); err != nil {
+		t.Fatalf("failed to update summary: %v", err)
+	}
+
+	retrieved := store.Get(agent.ID)
+	if retrieved.Summary != "Added token refresh" {
+		t.Errorf("Summary = %q, want %q", retrieved.Summary, "Added token refresh")
+	}
+}
+
+func TestSQLiteAgentStore_Metadata(t *testing.T) {
+	store, cleanup := createTestStore(t)
+	defer cleanup()
+
+	agent := &domain.Agent{
+		ID:        "test-agent",
+		Project:   "test",
+		Status:    domain.AgentStatusActive,
+		CreatedAt: time.Now(),
+		Metadata:  map[string]string{"model": "opus"},
+	}
+	if err := store.Add(agent); err != nil {
+		t.Fatalf("failed to add agent: %v", err)
+	}
+	if err := store.Add(&domain.Agent{ID: "other-agent", Project: "test", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("failed to add agent: %v", err)
+	}
+
+	if err := store.SetMetadata(agent.ID, "issue", "42"); err != nil {
+		t.Fatalf("failed to set metadata: %v", err)
+	}
+	if err := store.SetMetadata(agent.ID, "model", "sonnet"); err != nil {
+		t.Fatalf("failed to replace metadata: %v", err)
+	}
+
+	got := store.Get(agent.ID).Metadata
+	if len(got) != 2 || got["model"] != "sonnet" || got["issue"] != "42" {
+		t.Errorf("Metadata = %v, want model=sonnet and issue=42", got)
+	}
+	for _, listed := range store.List() {
+		if listed.ID == "other-agent" && len(listed.Metadata) != 0 {
+			t.Errorf("other agent Metadata = %v, want none", listed.Metadata)
+		}
+		if listed.ID == agent.ID && listed.Metadata["issue"] != "42" {
+			t.Errorf("listed Metadata = %v, want issue=42", listed.Metadata)
+		}
+	}
+
+	if err := store.SetMetadata(agent.ID, "issue", ""); err != nil {
+		t.Fatalf("failed to delete metadata: %v", err)
+	}
+	if _, ok := store.Get(agent.ID).Metadata["issue"]; ok {
+		t.Error("issue should be deleted")
+	}
+
+	// Removing the agent drops its metadata, so a new agent of that ID starts clean
+	if err := store.Remove(agent.ID); err != nil {
+		t.Fatalf("failed to remove agent: %v", err)
+	}
+	agent.Metadata = nil
+	if err := store.Add(agent); err != nil {
+		t.Fatalf("failed to re-add agent: %v", err)
+	}
+	if got := store.Get(agent.ID).Metadata; len(got) != 0 {
+		t.Errorf("Metadata after re-add = %v, want none", got)
+	}
+}
+
+func TestSQLiteAgentStore_AddAttachedTime(t *testing.T) {
+	store, cleanup := createTestStore(t)
+	defer cleanup()
+
+	agent := &domain.Agent{
+		ID:        "test-agent",
+		Project:   "test",
+		Status:    domain.AgentStatusActive,
+		CreatedAt: time.Now(),
+	}
+	if err := store.Add(agent); err != nil {
+		t.Fatalf("failed to add agent: %v", err)
+	}
+
+	for _, d := range []time.Duration{90 * time.Second, 30 * time.Second} {
+		if err := store.AddAttachedTime(agent.ID, d); err != nil {
+			t.Fatalf("failed to add attached time: %v", err)
+		}
+	}
+
+	retrieved := store.Get(agent.ID)
+	if retrieved.AttachedTime != 2*time.Minute {
+		t.Errorf("AttachedTime = %s, want 2m", retrieved.AttachedTime)
+	}
+}
+
+func TestSQLiteAgentStore_AddPausedTime(t *testing.T) {
+	store, cleanup := createTestStore(t)
+	defer cleanup()
+
+	agent := &domain.Agent{
+		ID:        "test-agent",
+		Project:   "test",
+		Status:    domain.AgentStatusActive,
+		CreatedAt: time.Now(),
+	}
+	if err := store.Add(agent); err != nil {
+		t.Fatalf("failed to add agent: %v", err)
+	}
+
+	for _, d := range []time.Duration{90 * time.Second, 30 * time.Second} {
+		if err := store.AddPausedTime(agent.ID, d); err != nil {
+			t.Fatalf("failed to add paused time: %v", err)
+		}
+	}
+
+	retrieved := store.Get(agent.ID)
+	if retrieved.PausedTime != 2*time.Minute {
+		t.Errorf("PausedTime = %s, want 2m", retrieved.PausedTime)
+	}
+}
+
+func TestSQLiteAgentStore_SparsePaths(t *testing.T) {
+	store, cleanup := createTestStore(t)
+	defer cleanup()
+
+	agent := &domain.Agent{
+		ID:          "test-agent",
+		Project:     "test",
+		Status:      domain.AgentStatusActive,
+		CreatedAt:   time.Now(),
+		SparsePaths: []string{"services/api", "libs/shared"},
+	}
+	if err := store.Add(agent); err != nil {
+		t.Fatalf("failed to add agent: %v", err)
+	}
+
+	retrieved := store.Get(agent.ID)
+	if len(retrieved.SparsePaths) != 2 || retrieved.SparsePaths[1] != "libs/shared" {
+		t.Errorf("SparsePaths = %v, want [services/api libs/shared]", retrieved.SparsePaths)
+	}
+
+	// Full checkouts round-trip as nil
+	full := &domain.Agent{ID: "full-agent", Status: domain.AgentStatusActive, CreatedAt: time.Now()}
+	_ = store.Add(full)
+	if paths := store.Get(full.ID).SparsePaths; paths != nil {
+		t.Errorf("SparsePaths = %v, want nil", paths)
+	}
+}
+
+func TestMigrate_AddsRestartsToV7Database(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "test.db")
+	store, err := NewSQLiteAgentStore(dbPath)
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+	// Roll the database back to schema version 7, from before restarts were counted
+	for _, stmt := range []string{"ALTER TABLE agents DROP COLUMN restarts", "PRAGMA user_version = 7"} {
+		if _, err := store.DB().Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+	store.Close()
+
+	store, err = NewSQLiteAgentStore(dbPath)
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	defer store.Close()
+
+	agent := &domain.Agent{ID: "agent-1", Status: domain.AgentStatusActive, CreatedAt: time.Now()}
+	if err := store.Add(agent); err != nil {
+		t.Fatalf("Add after migrating: %v", err)
+	}
+	if err := store.AddRestart(agent.ID); err != nil {
+		t.Fatalf("AddRestart after migrating: %v", err)
+	}
+	if agents := store.List(); len(agents) != 1 || agents[0].Restarts != 1 {
+		t.Errorf("List = %+v, want the agent with one restart", agents)
+	}
+	if version, _ := userVersion(store.DB()); version != SchemaVersion {
+		t.Errorf("user_version = %d, want %d", version, SchemaVersion)
+	}
+}
+
+func TestDescribeSchema(t *testing.T) {
+	store, cleanup := createTestStore(t)
+	defer cleanup()
+
+	schema, err := DescribeSchema(store.DB())
+	if err != nil {
+		t.Fatalf("DescribeSchema failed: %v", err)
+	}
+	if !strings.Contains(schema, "migration: 006_create_agent_metadata\n") {
+		t.Errorf("expected latest migration in schema, got:\n%s", schema)
+	}
+	if !strings.Contains(schema, "agents: id TEXT") || !strings.Contains(schema, "attached_ms INTEGER") {
+		t.Errorf("expected agents columns in schema, got:\n%s", schema)
+	}
+	if !strings.Contains(schema, "\nmessages: ") {
+		t.Errorf("expected messages table in schema, got:\n%s", schema)
+	}
+}
+
+func TestSQLiteAgentStore_Contract(t *testing.T) {
+	storetest.TestAgentStore(t, func(t *testing.T) domain.IAgentStore {
+		store, cleanup := createTestStore(t)
+		t.Cleanup(cleanup)
+		return store
+	})
+}
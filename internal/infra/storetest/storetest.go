@@ -0,0 +1,546 @@
+// Package storetest checks that store implementations behave alike. Each
+// backend's tests run the same suites against it, so the memory store backing
+// --ephemeral mode, SQLite and any later backend can't quietly drift apart.
+package storetest
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// TestAgentStore runs the IAgentStore contract against stores made by
+// newStore, which is called for a fresh, empty store in every subtest.
+func TestAgentStore(t *testing.T, newStore func(t *testing.T) domain.IAgentStore) {
+	base := time.Now().Truncate(time.Second)
+	agent := func(id string, age time.Duration) *domain.Agent {
+		return &domain.Agent{
+			ID:         id,
+			Project:    "api",
+			AgentType:  "claude",
+			Name:       id,
+			Command:    "claude",
+			WorkDir:    "/work/" + id,
+			Status:     domain.AgentStatusActive,
+			CreatedAt:  base.Add(-age),
+			Branch:     "craizy/" + id,
+			BaseBranch: "main",
+		}
+	}
+
+	t.Run("add and get", func(t *testing.T) {
+		store := newStore(t)
+		want := agent("auth", 0)
+		want.SparsePaths = []string{"internal/auth", "docs"}
+		want.Summary = "Added token refresh"
+		want.Metadata = map[string]string{"model": "opus"}
+		want.Env = map[string]string{"API_KEY": "secret"}
+		want.Dir = "services/auth"
+		if err := store.Add(want); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+
+		got := store.Get("auth")
+		if got == nil {
+			t.Fatal("Get returned nil")
+		}
+		if got.ID != want.ID || got.Project != want.Project || got.AgentType != want.AgentType ||
+			got.Name != want.Name || got.Command != want.Command || got.WorkDir != want.WorkDir ||
+			got.Status != want.Status || got.Branch != want.Branch || got.BaseBranch != want.BaseBranch ||
+			got.Summary != want.Summary {
+			t.Errorf("Get = %+v, want %+v", got, want)
+		}
+		if !got.CreatedAt.Equal(want.CreatedAt) {
+			t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, want.CreatedAt)
+		}
+		if got.TerminatedAt != nil {
+			t.Errorf("TerminatedAt = %v, want nil", got.TerminatedAt)
+		}
+		if !slices.Equal(got.SparsePaths, want.SparsePaths) {
+			t.Errorf("SparsePaths = %v, want %v", got.SparsePaths, want.SparsePaths)
+		}
+		if !maps.Equal(got.Metadata, want.Metadata) {
+			t.Errorf("Metadata = %v, want %v", got.Metadata, want.Metadata)
+		}
+		if got.Env != nil || got.Dir != "" {
+			t.Errorf("Env and Dir stored, got %v and %q", got.Env, got.Dir)
+		}
+	})
+
+	t.Run("add duplicate", func(t *testing.T) {
+		store := newStore(t)
+		if err := store.Add(agent("auth", 0)); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		if err := store.Add(agent("auth", 0)); err == nil {
+			t.Error("expected an error adding a duplicate ID")
+		}
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		store := newStore(t)
+		if got := store.Get("missing"); got != nil {
+			t.Errorf("Get = %+v, want nil", got)
+		}
+		if store.Exists("missing") {
+			t.Error("Exists = true for a missing agent")
+		}
+	})
+
+	t.Run("list newest first", func(t *testing.T) {
+		store := newStore(t)
+		if got := store.List(); len(got) != 0 {
+			t.Errorf("List = %d agents, want none", len(got))
+		}
+		for _, a := range []*domain.Agent{agent("middle", time.Minute), agent("newest", 0), agent("oldest", time.Hour)} {
+			if err := store.Add(a); err != nil {
+				t.Fatalf("Add failed: %v", err)
+			}
+		}
+
+		var ids []string
+		for _, a := range store.List() {
+			ids = append(ids, a.ID)
+		}
+		if want := []string{"newest", "middle", "oldest"}; !slices.Equal(ids, want) {
+			t.Errorf("List = %v, want %v", ids, want)
+		}
+	})
+
+	t.Run("returns copies", func(t *testing.T) {
+		store := newStore(t)
+		if err := store.Add(agent("auth", 0)); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+
+		got := store.Get("auth")
+		got.Status = domain.AgentStatusPaused
+		got.Metadata = map[string]string{"model": "changed"}
+		store.List()[0].Summary = "changed"
+
+		if got := store.Get("auth"); got.Status != domain.AgentStatusActive || got.Summary != "" || len(got.Metadata) != 0 {
+			t.Errorf("changing a returned agent changed the store: %+v", got)
+		}
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		store := newStore(t)
+		if err := store.Add(agent("auth", 0)); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		if err := store.SetMetadata("auth", "model", "opus"); err != nil {
+			t.Fatalf("SetMetadata failed: %v", err)
+		}
+
+		if err := store.Remove("auth"); err != nil {
+			t.Fatalf("Remove failed: %v", err)
+		}
+		if store.Exists("auth") || store.Get("auth") != nil || len(store.List()) != 0 {
+			t.Error("agent still stored after Remove")
+		}
+		if err := store.Remove("auth"); err != nil {
+			t.Errorf("removing a missing agent failed: %v", err)
+		}
+
+		// A new agent reusing the ID starts without the old one's metadata
+		if err := store.Add(agent("auth", 0)); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		if got := store.Get("auth").Metadata; len(got) != 0 {
+			t.Errorf("Metadata = %v, want none after Remove", got)
+		}
+	})
+
+	t.Run("update status", func(t *testing.T) {
+		store := newStore(t)
+		if err := store.Add(agent("auth", 0)); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+
+		if err := store.UpdateStatus("auth", domain.AgentStatusTerminated); err != nil {
+			t.Fatalf("UpdateStatus failed: %v", err)
+		}
+		got := store.Get("auth")
+		if got.Status != domain.AgentStatusTerminated || got.TerminatedAt == nil {
+			t.Errorf("got status %q, terminated at %v, want terminated with a time", got.Status, got.TerminatedAt)
+		}
+
+		if err := store.UpdateStatus("auth", domain.AgentStatusActive); err != nil {
+			t.Fatalf("UpdateStatus failed: %v", err)
+		}
+		got = store.Get("auth")
+		if got.Status != domain.AgentStatusActive || got.TerminatedAt != nil {
+			t.Errorf("got status %q, terminated at %v, want active with no time", got.Status, got.TerminatedAt)
+		}
+	})
+
+	t.Run("update fields", func(t *testing.T) {
+		store := newStore(t)
+		if err := store.Add(agent("auth", 0)); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+
+		if err := store.UpdateBaseBranch("auth", "develop"); err != nil {
+			t.Fatalf("UpdateBaseBranch failed: %v", err)
+		}
+		if err := store.UpdateSummary("auth", "Added token refresh"); err != nil {
+			t.Fatalf("UpdateSummary failed: %v", err)
+		}
+		for _, d := range []time.Duration{1500 * time.Millisecond, 2 * time.Second} {
+			if err := store.AddAttachedTime("auth", d); err != nil {
+				t.Fatalf("AddAttachedTime failed: %v", err)
+			}
+			if err := store.AddPausedTime("auth", d*2); err != nil {
+				t.Fatalf("AddPausedTime failed: %v", err)
+			}
+			if err := store.AddRestart("auth"); err != nil {
+				t.Fatalf("AddRestart failed: %v", err)
+			}
+		}
+
+		got := store.Get("auth")
+		if got.BaseBranch != "develop" || got.Summary != "Added token refresh" {
+			t.Errorf("got base branch %q, summary %q", got.BaseBranch, got.Summary)
+		}
+		if got.AttachedTime != 3500*time.Millisecond || got.PausedTime != 7*time.Second {
+			t.Errorf("got attached %s, paused %s, want 3.5s and 7s", got.AttachedTime, got.PausedTime)
+		}
+		if got.Restarts != 2 {
+			t.Errorf("Restarts = %d, want 2", got.Restarts)
+		}
+	})
+
+	t.Run("update missing", func(t *testing.T) {
+		store := newStore(t)
+		for name, err := range map[string]error{
+			"UpdateStatus":     store.UpdateStatus("missing", domain.AgentStatusPaused),
+			"UpdateBaseBranch": store.UpdateBaseBranch("missing", "develop"),
+			"UpdateSummary":    store.UpdateSummary("missing", "summary"),
+			"AddAttachedTime":  store.AddAttachedTime("missing", time.Second),
+			"AddPausedTime":    store.AddPausedTime("missing", time.Second),
+			"AddRestart":       store.AddRestart("missing"),
+		} {
+			if err != nil {
+				t.Errorf("%s of a missing agent failed: %v", name, err)
+			}
+		}
+		if store.Exists("missing") {
+			t.Error("updating a missing agent created it")
+		}
+	})
+
+	t.Run("metadata", func(t *testing.T) {
+		store := newStore(t)
+		if err := store.Add(agent("auth", 0)); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+		if err := store.Add(agent("docs", time.Minute)); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+
+		for _, kv := range [][2]string{{"model", "opus"}, {"pr", "https://example.com/pr/1"}, {"model", "sonnet"}, {"pr", ""}} {
+			if err := store.SetMetadata("auth", kv[0], kv[1]); err != nil {
+				t.Fatalf("SetMetadata(%q, %q) failed: %v", kv[0], kv[1], err)
+			}
+		}
+
+		want := map[string]string{"model": "sonnet"}
+		if got := store.Get("auth").Metadata; !maps.Equal(got, want) {
+			t.Errorf("Get metadata = %v, want %v", got, want)
+		}
+		for _, a := range store.List() {
+			if a.ID == "auth" && !maps.Equal(a.Metadata, want) {
+				t.Errorf("List metadata = %v, want %v", a.Metadata, want)
+			}
+			if a.ID == "docs" && len(a.Metadata) != 0 {
+				t.Errorf("another agent's metadata = %v, want none", a.Metadata)
+			}
+		}
+	})
+}
+
+// TestMessageStore runs the IMessageStore contract against stores made by
+// newStore, which is called for a fresh, empty store in every subtest.
+func TestMessageStore(t *testing.T, newStore func(t *testing.T) domain.IMessageStore) {
+	base := time.Now().Truncate(time.Second)
+	// save stores messages sent a minute apart, the first an hour ago
+	save := func(t *testing.T, store domain.IMessageStore, messages ...*domain.Message) {
+		t.Helper()
+		for i, msg := range messages {
+			if msg.Type == "" {
+				msg.Type = domain.MessageTypeInfo
+			}
+			msg.CreatedAt = base.Add(-time.Hour + time.Duration(i)*time.Minute)
+			if err := store.Save(msg); err != nil {
+				t.Fatalf("Save(%s) failed: %v", msg.ID, err)
+			}
+		}
+	}
+	ids := func(messages []*domain.Message) []string {
+		var ids []string
+		for _, msg := range messages {
+			ids = append(ids, msg.ID)
+		}
+		return ids
+	}
+	later := base.Add(time.Hour)
+	earlier := base.Add(-time.Minute)
+
+	t.Run("save and get", func(t *testing.T) {
+		store := newStore(t)
+		related := "TICKET-1"
+		want := &domain.Message{
+			ID: "m1", From: "auth", To: "docs", Type: domain.MessageTypeQuestion, Content: "Which version?",
+			RelatedWork: &related,
+			Refs:        []domain.FileRef{{Path: "go.mod"}, {Path: "main.go", Start: 4, End: 9}},
+			DeliverAt:   &later,
+		}
+		save(t, store, want)
+		if want.Seq <= 0 {
+			t.Errorf("Seq = %d, want one assigned", want.Seq)
+		}
+
+		got, err := store.Get("m1")
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if got.Seq != want.Seq || got.From != want.From || got.To != want.To || got.Type != want.Type ||
+			got.Content != want.Content || got.Read {
+			t.Errorf("Get = %+v, want %+v", got, want)
+		}
+		if !got.CreatedAt.Equal(want.CreatedAt) {
+			t.Errorf("CreatedAt = %v, want %v", got.CreatedAt, want.CreatedAt)
+		}
+		if got.RelatedWork == nil || *got.RelatedWork != related {
+			t.Errorf("RelatedWork = %v, want %q", got.RelatedWork, related)
+		}
+		if !slices.Equal(got.Refs, want.Refs) {
+			t.Errorf("Refs = %v, want %v", got.Refs, want.Refs)
+		}
+		if got.DeliverAt == nil || !got.DeliverAt.Equal(later) {
+			t.Errorf("DeliverAt = %v, want %v", got.DeliverAt, later)
+		}
+		if got.ReadAt != nil {
+			t.Errorf("ReadAt = %v, want nil", got.ReadAt)
+		}
+
+		bySeq, err := store.GetBySeq(want.Seq)
+		if err != nil || bySeq.ID != "m1" {
+			t.Errorf("GetBySeq = %v, %v, want m1", bySeq, err)
+		}
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		store := newStore(t)
+		if _, err := store.Get("missing"); err == nil {
+			t.Error("expected an error getting a missing message")
+		}
+		if _, err := store.GetBySeq(42); err == nil {
+			t.Error("expected an error getting a missing sequence number")
+		}
+	})
+
+	t.Run("sequence numbers", func(t *testing.T) {
+		store := newStore(t)
+		m1, m2 := &domain.Message{ID: "m1", From: "a", To: "b"}, &domain.Message{ID: "m2", From: "a", To: "b"}
+		save(t, store, m1, m2)
+		if m2.Seq <= m1.Seq {
+			t.Errorf("Seq went from %d to %d, want it increasing", m1.Seq, m2.Seq)
+		}
+		if err := store.Save(&domain.Message{ID: "m1", From: "a", To: "b", CreatedAt: base}); err == nil {
+			t.Error("expected an error saving a duplicate ID")
+		}
+	})
+
+	t.Run("mark read", func(t *testing.T) {
+		store := newStore(t)
+		save(t, store,
+			&domain.Message{ID: "m1", From: "auth", To: "lead"},
+			&domain.Message{ID: "m2", From: "docs", To: "lead"},
+			&domain.Message{ID: "m3", From: "auth", To: "docs"},
+		)
+
+		if err := store.MarkRead("m1"); err != nil {
+			t.Fatalf("MarkRead failed: %v", err)
+		}
+		got, _ := store.Get("m1")
+		if !got.Read || got.ReadAt == nil {
+			t.Errorf("got read %v at %v, want read with a time", got.Read, got.ReadAt)
+		}
+		if err := store.MarkRead("missing"); err != nil {
+			t.Errorf("marking a missing message read failed: %v", err)
+		}
+
+		unread, _ := store.ListUnread("lead")
+		if got := ids(unread); !slices.Equal(got, []string{"m2"}) {
+			t.Errorf("ListUnread = %v, want [m2]", got)
+		}
+		if n, _ := store.UnreadCount("lead"); n != 1 {
+			t.Errorf("UnreadCount = %d, want 1", n)
+		}
+		counts, _ := store.UnreadCountsByRecipient()
+		if want := map[string]int{"lead": 1, "docs": 1}; !maps.Equal(counts, want) {
+			t.Errorf("UnreadCountsByRecipient = %v, want %v", counts, want)
+		}
+	})
+
+	t.Run("unread oldest first", func(t *testing.T) {
+		store := newStore(t)
+		save(t, store,
+			&domain.Message{ID: "m1", From: "auth", To: "lead"},
+			&domain.Message{ID: "m2", From: "docs", To: "lead"},
+			&domain.Message{ID: "m3", From: "docs", To: "lead"},
+		)
+		unread, _ := store.ListUnread("lead")
+		if got := ids(unread); !slices.Equal(got, []string{"m1", "m2", "m3"}) {
+			t.Errorf("ListUnread = %v, want [m1 m2 m3]", got)
+		}
+		if unread, _ := store.ListUnread("nobody"); len(unread) != 0 {
+			t.Errorf("ListUnread = %v, want none", ids(unread))
+		}
+	})
+
+	t.Run("list pages newest first", func(t *testing.T) {
+		store := newStore(t)
+		var messages []*domain.Message
+		for _, id := range []string{"m1", "m2", "m3", "m4", "m5"} {
+			messages = append(messages, &domain.Message{ID: id, From: "auth", To: "lead"})
+		}
+		messages = append(messages, &domain.Message{ID: "other", From: "lead", To: "auth"})
+		save(t, store, messages...)
+
+		all, _ := store.List("lead", 0, 0)
+		if got := ids(all); !slices.Equal(got, []string{"m5", "m4", "m3", "m2", "m1"}) {
+			t.Errorf("List = %v, want newest first", got)
+		}
+		page, _ := store.List("lead", 0, 2)
+		if got := ids(page); !slices.Equal(got, []string{"m5", "m4"}) {
+			t.Fatalf("first page = %v, want [m5 m4]", got)
+		}
+		page, _ = store.List("lead", page[1].Seq, 2)
+		if got := ids(page); !slices.Equal(got, []string{"m3", "m2"}) {
+			t.Errorf("second page = %v, want [m3 m2]", got)
+		}
+
+		sent, _ := store.ListSent("auth", 0, 3)
+		if got := ids(sent); !slices.Equal(got, []string{"m5", "m4", "m3"}) {
+			t.Errorf("ListSent = %v, want [m5 m4 m3]", got)
+		}
+	})
+
+	t.Run("scheduled messages", func(t *testing.T) {
+		store := newStore(t)
+		save(t, store,
+			&domain.Message{ID: "due", From: "auth", To: "lead", DeliverAt: &earlier},
+			&domain.Message{ID: "pending", From: "auth", To: "lead", DeliverAt: &later},
+			&domain.Message{ID: "now", From: "auth", To: "lead"},
+		)
+
+		unread, _ := store.ListUnread("lead")
+		if got := ids(unread); !slices.Equal(got, []string{"due", "now"}) {
+			t.Errorf("ListUnread = %v, want the pending message left out", got)
+		}
+		listed, _ := store.List("lead", 0, 0)
+		if got := ids(listed); !slices.Equal(got, []string{"now", "due"}) {
+			t.Errorf("List = %v, want the pending message left out", got)
+		}
+		if n, _ := store.UnreadCount("lead"); n != 2 {
+			t.Errorf("UnreadCount = %d, want 2", n)
+		}
+		if counts, _ := store.UnreadCountsByRecipient(); counts["lead"] != 2 {
+			t.Errorf("UnreadCountsByRecipient = %v, want 2 for lead", counts)
+		}
+		sent, _ := store.ListSent("auth", 0, 0)
+		if got := ids(sent); !slices.Equal(got, []string{"now", "pending", "due"}) {
+			t.Errorf("ListSent = %v, want the pending message included", got)
+		}
+
+		due, _ := store.ListDue(base)
+		if got := ids(due); !slices.Equal(got, []string{"due"}) {
+			t.Errorf("ListDue = %v, want [due]", got)
+		}
+		due, _ = store.ListDue(later)
+		if got := ids(due); !slices.Equal(got, []string{"due", "pending"}) {
+			t.Errorf("ListDue later = %v, want [due pending]", got)
+		}
+		_ = store.MarkRead("due")
+		due, _ = store.ListDue(later)
+		if got := ids(due); !slices.Equal(got, []string{"pending"}) {
+			t.Errorf("ListDue after reading = %v, want [pending]", got)
+		}
+	})
+
+	t.Run("thread", func(t *testing.T) {
+		store := newStore(t)
+		save(t, store,
+			&domain.Message{ID: "m1", From: "auth", To: "lead"},
+			&domain.Message{ID: "m2", From: "lead", To: "auth"},
+			&domain.Message{ID: "m3", From: "docs", To: "lead"},
+			&domain.Message{ID: "m4", From: "auth", To: "docs"},
+		)
+		thread, _ := store.ListThread("auth")
+		if got := ids(thread); !slices.Equal(got, []string{"m1", "m2", "m4"}) {
+			t.Errorf("ListThread = %v, want [m1 m2 m4]", got)
+		}
+	})
+
+	t.Run("id prefix", func(t *testing.T) {
+		store := newStore(t)
+		save(t, store,
+			&domain.Message{ID: "abc-1", From: "a", To: "b"},
+			&domain.Message{ID: "abc-2", From: "a", To: "b"},
+			&domain.Message{ID: "abd-3", From: "a", To: "b"},
+		)
+		found, _ := store.ListByIDPrefix("abc", 0)
+		if got := ids(found); !slices.Equal(got, []string{"abc-2", "abc-1"}) {
+			t.Errorf("ListByIDPrefix = %v, want [abc-2 abc-1]", got)
+		}
+		found, _ = store.ListByIDPrefix("ab", 1)
+		if got := ids(found); !slices.Equal(got, []string{"abd-3"}) {
+			t.Errorf("ListByIDPrefix with a limit = %v, want [abd-3]", got)
+		}
+	})
+
+	t.Run("unescalated questions", func(t *testing.T) {
+		store := newStore(t)
+		save(t, store,
+			&domain.Message{ID: "q1", From: "auth", To: "docs", Type: domain.MessageTypeQuestion},
+			&domain.Message{ID: "info", From: "auth", To: "docs"},
+			&domain.Message{ID: "to-human", From: "auth", To: domain.HumanParticipantID, Type: domain.MessageTypeQuestion},
+			&domain.Message{ID: "from-named", From: domain.HumanParticipant("sam"), To: "docs", Type: domain.MessageTypeQuestion},
+			&domain.Message{ID: "q2", From: "docs", To: "auth", Type: domain.MessageTypeQuestion},
+			&domain.Message{ID: "answered", From: "docs", To: "auth", Type: domain.MessageTypeQuestion},
+		)
+		_ = store.MarkRead("answered")
+
+		questions, _ := store.ListUnescalatedQuestions()
+		if got := ids(questions); !slices.Equal(got, []string{"q1", "q2"}) {
+			t.Errorf("ListUnescalatedQuestions = %v, want [q1 q2]", got)
+		}
+		if err := store.MarkEscalated("q1"); err != nil {
+			t.Fatalf("MarkEscalated failed: %v", err)
+		}
+		questions, _ = store.ListUnescalatedQuestions()
+		if got := ids(questions); !slices.Equal(got, []string{"q2"}) {
+			t.Errorf("ListUnescalatedQuestions after escalating = %v, want [q2]", got)
+		}
+	})
+
+	t.Run("returns copies", func(t *testing.T) {
+		store := newStore(t)
+		save(t, store, &domain.Message{ID: "m1", From: "a", To: "b", Refs: []domain.FileRef{{Path: "go.mod"}}})
+
+		got, _ := store.Get("m1")
+		got.Content = "changed"
+		got.Refs[0].Path = "changed"
+		listed, _ := store.List("b", 0, 0)
+		listed[0].Read = true
+
+		if got, _ := store.Get("m1"); got.Content != "" || got.Refs[0].Path != "go.mod" || got.Read {
+			t.Errorf("changing a returned message changed the store: %+v", got)
+		}
+	})
+}
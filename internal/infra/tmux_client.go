@@ -2,46 +2,144 @@ package infra
 
 import (
 	"fmt"
+	"maps"
+	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/TechnicallyShaun/crAIzy/internal/logging"
 	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
 )
 
 // TmuxClient implements ITmuxClient using real tmux commands.
-type TmuxClient struct{}
+// Commands run under a watchdog so a hung tmux server can't block callers.
+type TmuxClient struct {
+	watchdog  *Watchdog
+	exitDir   string // where session shells record their command's exit status, see SetExitDir
+	dashboard string // dashboard session DashboardKey returns to, see SetDashboardSession
+}
 
 // NewTmuxClient creates a new TmuxClient.
 func NewTmuxClient() *TmuxClient {
-	return &TmuxClient{}
+	return &TmuxClient{
+		watchdog: NewWatchdog("tmux", TmuxTimeout),
+	}
+}
+
+// SetExitDir sets the directory session shells record their command's exit
+// status in, for ExitStatus, such as the project's .craizy/exit. It is created
+// accessible only to the user. Without one no statuses are recorded.
+func (t *TmuxClient) SetExitDir(dir string) {
+	t.exitDir = dir
+}
+
+// SetDashboardSession sets the dashboard session DashboardKey switches to from
+// the sessions this client creates, such as the project's craizy dash --tmux
+// session. Without one the key detaches the client.
+func (t *TmuxClient) SetDashboardSession(id string) {
+	t.dashboard = id
+}
+
+// Degraded describes the problem if tmux commands are timing out, or returns "".
+func (t *TmuxClient) Degraded() string {
+	return t.watchdog.Degraded()
 }
 
 // CreateSession creates a new detached tmux session with a custom status bar.
-// Command: tmux new-session -d -s {id} -c {workDir} {command}
-func (t *TmuxClient) CreateSession(id, command, workDir string) error {
-	logging.Entry("id", id, "command", command, "workDir", workDir)
+// env is set in the session's environment, so the command starts with it.
+// Command: tmux new-session -d -s {id} -c {workDir} -e {name=value}... {command}
+func (t *TmuxClient) CreateSession(id, command, workDir string, env map[string]string) error {
+	logging.Entry("id", id, "command", command, "workDir", workDir, "env", len(env))
 	args := []string{"new-session", "-d", "-s", id, "-c", workDir}
+	for _, name := range slices.Sorted(maps.Keys(env)) {
+		args = append(args, "-e", name+"="+env[name])
+	}
 	if command != "" {
-		args = append(args, command)
+		if t.exitDir != "" {
+			if err := os.MkdirAll(t.exitDir, 0o700); err != nil {
+				logging.Error(err, "id", id, "action", "create exit dir")
+			}
+			// A status left by an earlier session with this ID would read as this one's
+			_ = os.Remove(t.exitPath(id))
+		}
+		args = append(args, shellCommand(command, t.exitDir, id))
 	}
 	cmd := exec.Command("tmux", args...)
-	if err := cmd.Run(); err != nil {
+	if err := t.watchdog.Run(cmd); err != nil {
 		logging.Error(err, "id", id)
 		return err
 	}
 
 	// Configure custom status bar for this session
-	t.configureStatusBar(id)
+	t.configureStatusBar(id, t.bindDashboardKey())
 	logging.Info("tmux session created, id=%s", id)
 	return nil
 }
 
+// shellCommand keeps the pane's shell running command as a child rather than
+// replacing itself with it, as shells do with a lone command, so PauseSession
+// can stop the agent without stopping the pane's own process. The shell
+// records the command's status in exitDir, under the session's current name
+// as it may have been renamed since, for ExitStatus, and exits with it. A
+// killed session's shell dies before recording it. Without an exitDir the
+// status isn't recorded.
+func shellCommand(command, exitDir, id string) string {
+	if exitDir == "" {
+		return command + "\nexit $?"
+	}
+	return command + "\nstatus=$?\n" +
+		"id=$(tmux display-message -p -t \"$TMUX_PANE\" '#{session_name}' 2>/dev/null) || id=" + shellQuote(id) + "\n" +
+		"mkdir -p " + shellQuote(exitDir) + " && echo $status > " + shellQuote(exitDir) + "/\"$id\"\n" +
+		"exit $status"
+}
+
+// exitPath returns the file the shell of session id records its command's
+// exit status in.
+func (t *TmuxClient) exitPath(id string) string {
+	return filepath.Join(t.exitDir, id)
+}
+
+// ExitStatus returns the exit status of the command session id ran, once it
+// exited on its own. ok is false while it runs, and if the session was killed
+// or never ran a command.
+func (t *TmuxClient) ExitStatus(id string) (code int, ok bool) {
+	logging.Entry("id", id)
+	if t.exitDir == "" {
+		return 0, false
+	}
+	data, err := os.ReadFile(t.exitPath(id))
+	if err != nil {
+		return 0, false
+	}
+	code, err = strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		err = fmt.Errorf("failed to parse exit status %q: %w", strings.TrimSpace(string(data)), err)
+		logging.Error(err, "id", id)
+		return 0, false
+	}
+	return code, true
+}
+
+// StatusInterval is how often, in seconds, tmux redraws agent status bars and
+// so reruns their branch and unread message snippets.
+const StatusInterval = 15
+
 // configureStatusBar sets up tmux session options including mouse support
 // and a custom status bar. Uses Nord-inspired colors from the theme package.
-func (t *TmuxClient) configureStatusBar(sessionID string) {
+// The bar shows the agent's instance name, the branch checked out in its pane
+// and its unread messages, so a human attached to the session keeps context.
+// The dashboard key hint is left out when dashboardKey is false.
+func (t *TmuxClient) configureStatusBar(sessionID string, dashboardKey bool) {
 	ts := theme.TmuxStatusBar
+	dashboardHint := ""
+	if dashboardKey {
+		dashboardHint = fmt.Sprintf("#[fg=%s]Dashboard: Ctrl+B, %s #[fg=%s]│ ", ts.MutedColor, strings.ToUpper(DashboardKey), ts.SeparatorColor)
+	}
 
 	// Session configuration using theme colors
 	setOptions := [][]string{
@@ -49,31 +147,120 @@ func (t *TmuxClient) configureStatusBar(sessionID string) {
 		{"-t", sessionID, "mouse", "on"},
 		// Status bar colors
 		{"-t", sessionID, "status-style", fmt.Sprintf("bg=%s,fg=%s", ts.Background, ts.Foreground)},
-		// Left side: crAIzy branding + session info
-		{"-t", sessionID, "status-left", fmt.Sprintf("#[fg=%s,bold] crAIzy #[fg=%s]│ #[fg=%s]#{session_name} ", ts.BrandColor, ts.SeparatorColor, ts.AccentColor)},
-		{"-t", sessionID, "status-left-length", "50"},
-		// Right side: detach hint + time
-		{"-t", sessionID, "status-right", fmt.Sprintf("#[fg=%s]Detach: Ctrl+B, D #[fg=%s]│ #[fg=%s]%%H:%%M ", ts.MutedColor, ts.SeparatorColor, ts.AccentColor)},
-		{"-t", sessionID, "status-right-length", "40"},
+		{"-t", sessionID, "status-interval", strconv.Itoa(StatusInterval)},
+		// Left side: crAIzy branding + instance name + branch
+		{"-t", sessionID, "status-left", statusLeft(ts.AccentColor)},
+		{"-t", sessionID, "status-left-length", "80"},
+		// Right side: unread messages + message command hint + dashboard hint + time
+		{"-t", sessionID, "status-right", fmt.Sprintf("#[fg=%s,bold]%s#[fg=%s,nobold]craizy msg help #[fg=%s]│ %s#[fg=%s]%%H:%%M ", ts.BrandColor, unreadSnippet(), ts.MutedColor, ts.SeparatorColor, dashboardHint, ts.AccentColor)},
+		{"-t", sessionID, "status-right-length", "100"},
 		// Center the window list
 		{"-t", sessionID, "status-justify", "center"},
 		// Window styling
 		{"-t", sessionID, "window-status-format", fmt.Sprintf("#[fg=%s] #W ", ts.MutedColor)},
 		{"-t", sessionID, "window-status-current-format", fmt.Sprintf("#[fg=%s,bold] #W ", ts.AccentColor)},
 	}
+	if t.dashboard != "" {
+		setOptions = append(setOptions, []string{"-t", sessionID, dashboardSessionOption, t.dashboard})
+	}
 
 	for _, opt := range setOptions {
 		args := append([]string{"set-option"}, opt...)
-		_ = exec.Command("tmux", args...).Run()
+		_ = t.watchdog.Run(exec.Command("tmux", args...))
 	}
 }
 
+// statusLeft is the left of the status bar, with the session name in nameColor.
+func statusLeft(nameColor string) string {
+	ts := theme.TmuxStatusBar
+	return fmt.Sprintf("#[fg=%s,bold] crAIzy #[fg=%s]│ #[fg=%s]#{session_name} #[fg=%s]%s ", ts.BrandColor, ts.SeparatorColor, nameColor, ts.MutedColor, branchSnippet)
+}
+
+// SetSessionColor shows the session name in color, a hex color or ANSI 256
+// color number, so sessions of different agent types are told apart.
+func (t *TmuxClient) SetSessionColor(id, color string) error {
+	logging.Entry("id", id, "color", color)
+	if _, err := strconv.Atoi(color); err == nil {
+		color = "colour" + color
+	}
+	cmd := exec.Command("tmux", "set-option", "-t", id, "status-left", statusLeft(color+",bold"))
+	if err := t.watchdog.Run(cmd); err != nil {
+		err = fmt.Errorf("failed to set session color: %w", err)
+		logging.Error(err, "id", id, "color", color)
+		return err
+	}
+	return nil
+}
+
+// DashboardKey is the key that, after the tmux prefix, takes a human in an
+// agent session back to the dashboard.
+const DashboardKey = "g"
+
+// bindDashboardKey binds prefix+DashboardKey in crAIzy sessions to switch to the
+// session's project dashboard if one is running, and otherwise to detach,
+// returning to the dashboard that attached the session. Key bindings are server-wide, so
+// the key does nothing in sessions crAIzy didn't create, and a binding the
+// user already has for it is kept. It reports whether the key is crAIzy's.
+func (t *TmuxClient) bindDashboardKey() bool {
+	out, err := t.watchdog.Output(exec.Command("tmux", "list-keys", "-T", "prefix", DashboardKey))
+	if err == nil && isUserBinding(string(out)) {
+		logging.Debug("prefix %s is already bound, keeping it: %s", DashboardKey, strings.TrimSpace(string(out)))
+		return false
+	}
+	args := []string{"bind-key", DashboardKey, "if-shell", "-F", dashboardKeyCondition, dashboardKeyCommand}
+	if err := t.watchdog.Run(exec.Command("tmux", args...)); err != nil {
+		logging.Debug("failed to bind dashboard key: %v", err)
+		return false
+	}
+	return true
+}
+
+// isUserBinding reports whether tmux list-keys output shows a binding crAIzy
+// didn't make. Without a binding tmux fails rather than printing nothing.
+func isUserBinding(listKeys string) bool {
+	return strings.TrimSpace(listKeys) != "" && !strings.Contains(listKeys, dashboardKeyCondition)
+}
+
+// dashboardKeyCondition limits DashboardKey to crAIzy's sessions.
+const dashboardKeyCondition = "#{m:craizy-*,#{session_name}}"
+
+// dashboardSessionOption is the tmux session option holding the dashboard
+// session DashboardKey switches to, as each project has its own.
+const dashboardSessionOption = "@craizy-dashboard"
+
+// dashboardKeyCommand is the tmux command bound to DashboardKey. tmux doesn't
+// expand formats in targets, so run-shell expands the option for a nested tmux.
+const dashboardKeyCommand = `run-shell "tmux switch-client -c '#{client_name}' -t '=#{` + dashboardSessionOption + `}' 2>/dev/null || tmux detach-client -t '#{client_name}'"`
+
+// branchSnippet shows the branch checked out in the pane's directory, or
+// nothing outside a git repository.
+const branchSnippet = "#(git -C '#{pane_current_path}' branch --show-current 2>/dev/null | sed 's/^/⎇ /')"
+
+// unreadSnippet returns a status bar snippet showing the session's unread
+// message count via craizy msg count, or nothing when there are none or the
+// craizy binary can't be located.
+func unreadSnippet() string {
+	exe, err := os.Executable()
+	if err != nil {
+		logging.Debug("can't locate craizy binary, status bar won't show unread messages: %v", err)
+		return ""
+	}
+	return unreadCommand(exe)
+}
+
+// unreadCommand formats the unread message snippet for the craizy binary at exe.
+// tmux expands #{session_name}, the agent's ID, before running it with sh.
+func unreadCommand(exe string) string {
+	count := shellQuote(exe) + " msg count --for '#{session_name}' --quiet 2>/dev/null"
+	return "#(n=$(" + count + "); [ \"${n:-0}\" -gt 0 ] 2>/dev/null && echo \"✉ $n │ \")"
+}
+
 // KillSession terminates a tmux session.
 // Command: tmux kill-session -t {id}
 func (t *TmuxClient) KillSession(id string) error {
 	logging.Entry("id", id)
 	cmd := exec.Command("tmux", "kill-session", "-t", id)
-	if err := cmd.Run(); err != nil {
+	if err := t.watchdog.Run(cmd); err != nil {
 		logging.Error(err, "id", id)
 		return err
 	}
@@ -81,12 +268,30 @@ func (t *TmuxClient) KillSession(id string) error {
 	return nil
 }
 
+// RenameSession renames a tmux session.
+// Command: tmux rename-session -t {oldID} {newID}
+func (t *TmuxClient) RenameSession(oldID, newID string) error {
+	logging.Entry("oldID", oldID, "newID", newID)
+	cmd := exec.Command("tmux", "rename-session", "-t", oldID, newID)
+	if err := t.watchdog.Run(cmd); err != nil {
+		logging.Error(err, "oldID", oldID, "newID", newID)
+		return err
+	}
+	// The shell records its status under the new name, so drop any stale one
+	if t.exitDir != "" {
+		_ = os.Remove(t.exitPath(newID))
+	}
+	logging.Info("tmux session renamed, oldID=%s, newID=%s", oldID, newID)
+	return nil
+}
+
 // ListSessions returns all tmux session names.
 // Command: tmux list-sessions -F "#{session_name}"
 func (t *TmuxClient) ListSessions() ([]string, error) {
 	logging.Entry()
-	cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}")
-	output, err := cmd.Output()
+	output, err := t.watchdog.Retry(func() *exec.Cmd {
+		return exec.Command("tmux", "list-sessions", "-F", "#{session_name}")
+	})
 	if err != nil {
 		logging.Error(err)
 		return nil, err
@@ -108,15 +313,36 @@ func (t *TmuxClient) ListSessions() ([]string, error) {
 // This command can be passed to tea.ExecProcess for proper terminal handling.
 func (t *TmuxClient) AttachCmd(id string) *exec.Cmd {
 	logging.Entry("id", id)
-	return exec.Command("tmux", "attach", "-t", id)
+	return attachCommand("attach", "-t", id)
+}
+
+// AttachReadOnlyCmd returns an exec.Cmd that attaches to a session as a read-only client.
+// Command: tmux attach -r -t {id}
+func (t *TmuxClient) AttachReadOnlyCmd(id string) *exec.Cmd {
+	logging.Entry("id", id)
+	return attachCommand("attach", "-r", "-t", id)
+}
+
+// attachCommand returns a tmux command attaching a client. TMUX is cleared from
+// its environment so that a dashboard running inside tmux can nest the client.
+func attachCommand(args ...string) *exec.Cmd {
+	cmd := exec.Command("tmux", args...)
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, "TMUX=") {
+			cmd.Env = append(cmd.Env, kv)
+		}
+	}
+	return cmd
 }
 
 // SessionExists checks if a tmux session exists.
 // Command: tmux has-session -t {id}
 func (t *TmuxClient) SessionExists(id string) bool {
 	logging.Entry("id", id)
-	cmd := exec.Command("tmux", "has-session", "-t", id)
-	exists := cmd.Run() == nil
+	_, err := t.watchdog.Retry(func() *exec.Cmd {
+		return exec.Command("tmux", "has-session", "-t", id)
+	})
+	exists := err == nil
 	logging.Debug("session exists=%v, id=%s", exists, id)
 	return exists
 }
@@ -127,8 +353,9 @@ func (t *TmuxClient) SessionExists(id string) bool {
 func (t *TmuxClient) CapturePaneOutput(sessionID string, lines int) (string, error) {
 	logging.Entry("sessionID", sessionID, "lines", lines)
 	startLine := "-" + strconv.Itoa(lines)
-	cmd := exec.Command("tmux", "capture-pane", "-t", sessionID, "-p", "-S", startLine)
-	output, err := cmd.Output()
+	output, err := t.watchdog.Retry(func() *exec.Cmd {
+		return exec.Command("tmux", "capture-pane", "-t", sessionID, "-p", "-S", startLine)
+	})
 	if err != nil {
 		logging.Error(err, "sessionID", sessionID)
 	}
@@ -144,14 +371,14 @@ func (t *TmuxClient) SendKeys(sessionID, text string) error {
 
 	// Step 1: Send text literally (no key interpretation)
 	cmdText := exec.Command("tmux", "send-keys", "-l", "-t", sessionID, text)
-	if err := cmdText.Run(); err != nil {
+	if err := t.watchdog.Run(cmdText); err != nil {
 		logging.Error(err, "sessionID", sessionID, "step", "send text")
 		return err
 	}
 
 	// Step 2: Send Enter separately to submit
 	cmdEnter := exec.Command("tmux", "send-keys", "-t", sessionID, "C-m")
-	if err := cmdEnter.Run(); err != nil {
+	if err := t.watchdog.Run(cmdEnter); err != nil {
 		logging.Error(err, "sessionID", sessionID, "step", "send enter")
 		return err
 	}
@@ -159,3 +386,113 @@ func (t *TmuxClient) SendKeys(sessionID, text string) error {
 	logging.Info("keys sent to tmux session, id=%s", sessionID)
 	return nil
 }
+
+// LastActivity returns when the session's window last produced output.
+// Command: tmux display-message -p -t {id} "#{window_activity}"
+func (t *TmuxClient) LastActivity(sessionID string) (time.Time, error) {
+	logging.Entry("sessionID", sessionID)
+	output, err := t.watchdog.Retry(func() *exec.Cmd {
+		return exec.Command("tmux", "display-message", "-p", "-t", sessionID, "#{window_activity}")
+	})
+	if err != nil {
+		logging.Error(err, "sessionID", sessionID)
+		return time.Time{}, err
+	}
+	seconds, err := strconv.ParseInt(strings.TrimSpace(string(output)), 10, 64)
+	if err != nil {
+		err = fmt.Errorf("failed to parse window activity %q: %w", strings.TrimSpace(string(output)), err)
+		logging.Error(err, "sessionID", sessionID)
+		return time.Time{}, err
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+// PauseSession stops the processes running under the session's pane shell,
+// the agent and any children, with SIGSTOP.
+func (t *TmuxClient) PauseSession(id string) error {
+	logging.Entry("id", id)
+	return t.signalSession(id, syscall.SIGSTOP)
+}
+
+// ResumeSession continues the processes PauseSession stopped with SIGCONT.
+func (t *TmuxClient) ResumeSession(id string) error {
+	logging.Entry("id", id)
+	return t.signalSession(id, syscall.SIGCONT)
+}
+
+// signalSession sends sig to every process in the terminal session of the
+// session's pane except the pane's own process, the shell CreateSession keeps.
+// tmux continues a pane process that stops, and with it its process group, so
+// stopping the shell would undo the pause.
+// Commands: tmux display-message -p -t {id} "#{pane_pid}"; pgrep -s {pane_pid}
+func (t *TmuxClient) signalSession(id string, sig syscall.Signal) error {
+	output, err := t.watchdog.Retry(func() *exec.Cmd {
+		return exec.Command("tmux", "display-message", "-p", "-t", id, "#{pane_pid}")
+	})
+	if err != nil {
+		err = fmt.Errorf("failed to find pane process: %w", err)
+		logging.Error(err, "id", id)
+		return err
+	}
+	panePID, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	if err != nil {
+		err = fmt.Errorf("failed to parse pane process %q: %w", strings.TrimSpace(string(output)), err)
+		logging.Error(err, "id", id)
+		return err
+	}
+
+	// pgrep exits 1 when it matches nothing, which the check below reports
+	output, _ = t.watchdog.Output(exec.Command("pgrep", "-s", strconv.Itoa(panePID)))
+	var signaled int
+	for _, field := range strings.Fields(string(output)) {
+		pid, err := strconv.Atoi(field)
+		if err != nil || pid == panePID {
+			continue
+		}
+		if err := syscall.Kill(pid, sig); err == nil {
+			signaled++
+		}
+	}
+	if signaled == 0 {
+		err := fmt.Errorf("no processes to signal under the pane of session %s; sessions started before pausing was supported must be restarted", id)
+		logging.Error(err, "id", id)
+		return err
+	}
+	return nil
+}
+
+// PipeOutput records everything the session prints to path, one timestamped line
+// at a time, by piping the pane through `craizy record-transcript`.
+// Command: tmux pipe-pane -o -t {id} "exec {craizy} record-transcript {path}"
+func (t *TmuxClient) PipeOutput(sessionID, path string) error {
+	logging.Entry("sessionID", sessionID, "path", path)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		err = fmt.Errorf("failed to create transcripts directory: %w", err)
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+	cmd := exec.Command("tmux", "pipe-pane", "-o", "-t", sessionID, recorderCommand(path))
+	if output, err := t.watchdog.CombinedOutput(cmd); err != nil {
+		err = fmt.Errorf("tmux pipe-pane failed: %w: %s", err, strings.TrimSpace(string(output)))
+		logging.Error(err, "sessionID", sessionID)
+		return err
+	}
+	logging.Info("recording tmux session output, id=%s, path=%s", sessionID, path)
+	return nil
+}
+
+// recorderCommand returns the shell command tmux pipes pane output into. It falls
+// back to appending raw, untimestamped output if the craizy binary can't be located.
+func recorderCommand(path string) string {
+	exe, err := os.Executable()
+	if err != nil {
+		logging.Debug("can't locate craizy binary, recording without timestamps: %v", err)
+		return "cat >> " + shellQuote(path)
+	}
+	return "exec " + shellQuote(exe) + " record-transcript " + shellQuote(path)
+}
+
+// shellQuote single-quotes s for use in a shell command.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,89 @@
+package infra
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnreadCommand(t *testing.T) {
+	// run expands the snippet as tmux would for session agent-1 and runs it
+	// with a fake craizy printing count.
+	run := func(t *testing.T, count string) string {
+		t.Helper()
+		exe := filepath.Join(t.TempDir(), "craizy")
+		script := "#!/bin/sh\n[ \"$*\" = 'msg count --for agent-1 --quiet' ] || exit 1\necho " + count + "\n"
+		if err := os.WriteFile(exe, []byte(script), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		snippet := unreadCommand(exe)
+		if !strings.HasPrefix(snippet, "#(") || !strings.HasSuffix(snippet, ")") {
+			t.Fatalf("snippet %q is not a tmux shell command", snippet)
+		}
+		command := strings.ReplaceAll(snippet[2:len(snippet)-1], "#{session_name}", "agent-1")
+		out, _ := exec.Command("sh", "-c", command).Output()
+		return string(out)
+	}
+
+	if got := run(t, "3"); got != "✉ 3 │ \n" {
+		t.Errorf("3 unread: got %q", got)
+	}
+	if got := run(t, "0"); got != "" {
+		t.Errorf("none unread: got %q, want nothing", got)
+	}
+	if got := run(t, "'Error: no database'"); got != "" {
+		t.Errorf("failed count: got %q, want nothing", got)
+	}
+}
+
+func TestShellCommand_RecordsExitStatus(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "exit")
+	client := &TmuxClient{exitDir: dir}
+
+	// Outside tmux the shell records the status under the ID it started with
+	cmd := exec.Command("sh", "-c", shellCommand("sh -c 'exit 3'", dir, "agent-1"))
+	cmd.Env = append(os.Environ(), "TMUX_PANE=", "TMUX=")
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Fatalf("shell exited with %v, want the command's status 3", err)
+	}
+	if code, ok := client.ExitStatus("agent-1"); !ok || code != 3 {
+		t.Errorf("ExitStatus = %d, %v, want 3", code, ok)
+	}
+	if _, ok := client.ExitStatus("agent-2"); ok {
+		t.Error("expected no status for a session that didn't exit")
+	}
+}
+
+func TestShellCommand_WithoutExitDir(t *testing.T) {
+	client := &TmuxClient{}
+
+	cmd := exec.Command("sh", "-c", shellCommand("sh -c 'exit 3'", "", "agent-1"))
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Fatalf("shell exited with %v, want the command's status 3", err)
+	}
+	if _, ok := client.ExitStatus("agent-1"); ok {
+		t.Error("expected no status without an exit dir")
+	}
+}
+
+func TestIsUserBinding(t *testing.T) {
+	ours := `bind-key -T prefix g if-shell -F "` + dashboardKeyCondition + `" "` + dashboardKeyCommand + `"`
+	if isUserBinding(ours) {
+		t.Error("expected crAIzy's own binding to be rebound")
+	}
+	if !isUserBinding("bind-key -T prefix g display-message hi\n") {
+		t.Error("expected the user's binding to be kept")
+	}
+	if isUserBinding("") {
+		t.Error("expected no binding to be bound")
+	}
+}
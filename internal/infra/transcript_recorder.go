@@ -0,0 +1,39 @@
+package infra
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// RecordTranscript appends each line read from r to the transcript file at path,
+// prefixed with the time it arrived. It runs until r is closed, which for a tmux
+// pipe-pane recorder is when the session ends.
+func RecordTranscript(r io.Reader, path string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open transcript: %w", err)
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			if _, werr := io.WriteString(f, domain.FormatTranscriptLine(time.Now(), strings.TrimSuffix(line, "\n"))); werr != nil {
+				return fmt.Errorf("failed to write transcript: %w", werr)
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read session output: %w", err)
+		}
+	}
+}
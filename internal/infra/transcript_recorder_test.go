@@ -0,0 +1,43 @@
+package infra
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestRecordTranscript(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agent.log")
+
+	if err := RecordTranscript(strings.NewReader("first\nsecond"), path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// A second recorder appends rather than truncating
+	if err := RecordTranscript(strings.NewReader("third\n"), path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open transcript: %v", err)
+	}
+	defer f.Close()
+	lines, err := domain.ParseTranscript(f)
+	if err != nil {
+		t.Fatalf("failed to parse transcript: %v", err)
+	}
+
+	var texts []string
+	for _, line := range lines {
+		texts = append(texts, line.Text)
+		if line.Time.IsZero() {
+			t.Errorf("line %q has no timestamp", line.Text)
+		}
+	}
+	if got := strings.Join(texts, ","); got != "first,second,third" {
+		t.Errorf("got lines %q, want first,second,third", got)
+	}
+}
@@ -0,0 +1,171 @@
+package infra
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+const (
+	// TmuxTimeout bounds a single tmux command.
+	TmuxTimeout = 10 * time.Second
+
+	// GitTimeout bounds a single local git command.
+	GitTimeout = 2 * time.Minute
+
+	// GitNetworkTimeout bounds git commands that fetch or push, such as git lfs pull.
+	GitNetworkTimeout = 10 * time.Minute
+
+	// watchdogThreshold is how many consecutive timeouts open the circuit.
+	watchdogThreshold = 3
+
+	// watchdogCooldown is how long an open circuit skips commands before trying again.
+	watchdogCooldown = 30 * time.Second
+
+	// watchdogWaitDelay is how long to wait for output after a timed-out command is killed.
+	watchdogWaitDelay = time.Second
+)
+
+// Watchdog runs external commands with a timeout, so a hung tmux server or git
+// process can't block the caller forever. After repeated timeouts it opens a
+// circuit breaker and fails commands immediately until a cooldown passes.
+// Errors from timeouts and skipped commands wrap domain.ErrDegraded.
+type Watchdog struct {
+	name     string
+	timeout  time.Duration
+	cooldown time.Duration
+
+	mu        sync.Mutex
+	timeouts  int       // consecutive timeouts
+	openUntil time.Time // commands are skipped until then
+}
+
+// NewWatchdog creates a watchdog for commands of the named tool.
+func NewWatchdog(name string, timeout time.Duration) *Watchdog {
+	return &Watchdog{name: name, timeout: timeout, cooldown: watchdogCooldown}
+}
+
+// Run runs cmd like cmd.Run.
+func (w *Watchdog) Run(cmd *exec.Cmd) error {
+	return w.exec(cmd)
+}
+
+// Output runs cmd like cmd.Output, including stderr in any *exec.ExitError.
+func (w *Watchdog) Output(cmd *exec.Cmd) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	if cmd.Stderr == nil {
+		cmd.Stderr = &stderr
+	}
+	err := w.exec(cmd)
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exitErr.Stderr = stderr.Bytes()
+	}
+	return stdout.Bytes(), err
+}
+
+// CombinedOutput runs cmd like cmd.CombinedOutput.
+func (w *Watchdog) CombinedOutput(cmd *exec.Cmd) ([]byte, error) {
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	err := w.exec(cmd)
+	return output.Bytes(), err
+}
+
+// Retry runs the command built by build like Output, building it afresh and trying
+// again once if it times out. Only use it for commands that are safe to repeat.
+func (w *Watchdog) Retry(build func() *exec.Cmd) ([]byte, error) {
+	output, err := w.Output(build())
+	if errors.Is(err, domain.ErrDegraded) && !w.open() {
+		logging.Info("retrying timed out %s command", w.name)
+		output, err = w.Output(build())
+	}
+	return output, err
+}
+
+// Degraded describes the problem if recent commands timed out, or returns "".
+func (w *Watchdog) Degraded() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if wait := time.Until(w.openUntil); wait > 0 {
+		return fmt.Sprintf("%s not responding, retrying in %s", w.name, wait.Round(time.Second))
+	}
+	if w.timeouts > 0 {
+		return fmt.Sprintf("%s slow to respond (%d timed out)", w.name, w.timeouts)
+	}
+	return ""
+}
+
+// open reports whether the circuit is open.
+func (w *Watchdog) open() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return time.Now().Before(w.openUntil)
+}
+
+// exec runs cmd, killing it if it outlives the timeout.
+func (w *Watchdog) exec(cmd *exec.Cmd) error {
+	if w.open() {
+		return fmt.Errorf("%s is not responding, skipped %q: %w", w.name, commandLine(cmd), domain.ErrDegraded)
+	}
+	if cmd.WaitDelay == 0 {
+		cmd.WaitDelay = watchdogWaitDelay
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	timer := time.NewTimer(w.timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		w.recordSuccess()
+		return err
+	case <-timer.C:
+		_ = cmd.Process.Kill()
+		<-done
+		w.recordTimeout()
+		err := fmt.Errorf("%q timed out after %s: %w", commandLine(cmd), w.timeout, domain.ErrDegraded)
+		logging.Error(err, "tool", w.name)
+		return err
+	}
+}
+
+// recordSuccess closes the circuit after a command finishes in time.
+func (w *Watchdog) recordSuccess() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timeouts > 0 {
+		logging.Info("%s responding again after %d timeouts", w.name, w.timeouts)
+	}
+	w.timeouts = 0
+	w.openUntil = time.Time{}
+}
+
+// recordTimeout counts a timeout, opening the circuit once the threshold is reached.
+func (w *Watchdog) recordTimeout() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.timeouts++
+	if w.timeouts >= watchdogThreshold {
+		w.openUntil = time.Now().Add(w.cooldown)
+		logging.Info("%s circuit open after %d timeouts, cooldown=%s", w.name, w.timeouts, w.cooldown)
+	}
+}
+
+// commandLine returns cmd's arguments for error messages.
+func commandLine(cmd *exec.Cmd) string {
+	return strings.Join(cmd.Args, " ")
+}
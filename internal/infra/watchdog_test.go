@@ -0,0 +1,85 @@
+package infra
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestWatchdog(t *testing.T) {
+	t.Run("kills commands that outlive the timeout", func(t *testing.T) {
+		w := NewWatchdog("sleep", 50*time.Millisecond)
+
+		start := time.Now()
+		err := w.Run(exec.Command("sleep", "5"))
+
+		if !errors.Is(err, domain.ErrDegraded) {
+			t.Fatalf("expected ErrDegraded, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("command was not killed, took %s", elapsed)
+		}
+		if w.Degraded() == "" {
+			t.Error("expected a degraded description after a timeout")
+		}
+	})
+
+	t.Run("opens the circuit after repeated timeouts", func(t *testing.T) {
+		w := NewWatchdog("sleep", 20*time.Millisecond)
+		for i := 0; i < watchdogThreshold; i++ {
+			_ = w.Run(exec.Command("sleep", "5"))
+		}
+
+		start := time.Now()
+		err := w.Run(exec.Command("true"))
+
+		if !errors.Is(err, domain.ErrDegraded) || time.Since(start) > 10*time.Millisecond {
+			t.Fatalf("expected an immediate ErrDegraded while open, got %v", err)
+		}
+		if !strings.Contains(w.Degraded(), "not responding") {
+			t.Errorf("Degraded() = %q", w.Degraded())
+		}
+	})
+
+	t.Run("closes the circuit after the cooldown", func(t *testing.T) {
+		w := NewWatchdog("sleep", 20*time.Millisecond)
+		w.cooldown = 10 * time.Millisecond
+		for i := 0; i < watchdogThreshold; i++ {
+			_ = w.Run(exec.Command("sleep", "5"))
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		if err := w.Run(exec.Command("true")); err != nil {
+			t.Fatalf("expected the command to run after the cooldown, got %v", err)
+		}
+		if w.Degraded() != "" {
+			t.Errorf("expected healthy after a success, got %q", w.Degraded())
+		}
+	})
+
+	t.Run("output and exit errors", func(t *testing.T) {
+		w := NewWatchdog("sh", time.Second)
+
+		output, err := w.Output(exec.Command("sh", "-c", "echo out; echo err >&2; exit 3"))
+
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+			t.Fatalf("expected exit code 3, got %v", err)
+		}
+		if string(output) != "out\n" || string(exitErr.Stderr) != "err\n" {
+			t.Errorf("stdout = %q, stderr = %q", output, exitErr.Stderr)
+		}
+		if w.Degraded() != "" {
+			t.Error("a failing command that finishes in time is not degraded")
+		}
+
+		combined, _ := w.CombinedOutput(exec.Command("sh", "-c", "echo out; echo err >&2"))
+		if string(combined) != "out\nerr\n" {
+			t.Errorf("combined = %q", combined)
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// CacheCommandTimeout bounds how long a cache command, such as a dependency install, may run.
+const CacheCommandTimeout = 10 * time.Minute
+
+// SymlinkCache implements domain.IWorktreeCache by linking a directory in the
+// worktree to the same directory in the project root. Every agent then shares one
+// copy, e.g. of node_modules or a ccache directory.
+type SymlinkCache struct {
+	path string // relative to the project root
+}
+
+// NewSymlinkCache creates a cache that symlinks path into new worktrees.
+func NewSymlinkCache(path string) *SymlinkCache {
+	return &SymlinkCache{path: path}
+}
+
+// Name describes the cache in logs.
+func (c *SymlinkCache) Name() string {
+	return "symlink " + c.path
+}
+
+// Share links worktree/path to projectRoot/path. It does nothing if the project
+// root has no such directory or the worktree already has one.
+func (c *SymlinkCache) Share(projectRoot, worktree string) error {
+	logging.Entry("path", c.path, "worktree", worktree)
+	src, dst, ok := cachePaths(projectRoot, worktree, c.path)
+	if !ok {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(dst), err)
+	}
+	if err := os.Symlink(src, dst); err != nil {
+		return fmt.Errorf("failed to link %s: %w", c.path, err)
+	}
+	logging.Info("cache linked, path=%s, worktree=%s", c.path, worktree)
+	return nil
+}
+
+// HardlinkCache implements domain.IWorktreeCache by cloning a directory from the
+// project root into the worktree with hard links. The clone takes no extra space
+// until files are replaced, and unlike a symlink each agent can add or remove
+// files independently. Files are copied when hard links aren't possible, such as
+// across filesystems.
+type HardlinkCache struct {
+	path string // relative to the project root
+}
+
+// NewHardlinkCache creates a cache that hard-link clones path into new worktrees.
+func NewHardlinkCache(path string) *HardlinkCache {
+	return &HardlinkCache{path: path}
+}
+
+// Name describes the cache in logs.
+func (c *HardlinkCache) Name() string {
+	return "hardlink " + c.path
+}
+
+// Share clones projectRoot/path to worktree/path. It does nothing if the project
+// root has no such directory or the worktree already has one.
+func (c *HardlinkCache) Share(projectRoot, worktree string) error {
+	logging.Entry("path", c.path, "worktree", worktree)
+	src, dst, ok := cachePaths(projectRoot, worktree, c.path)
+	if !ok {
+		return nil
+	}
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, 0o755)
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.Type().IsRegular():
+			if err := os.Link(path, target); err == nil {
+				return nil
+			}
+			return copyFile(path, target)
+		}
+		return nil // sockets, devices and the like aren't worth sharing
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clone %s: %w", c.path, err)
+	}
+	logging.Info("cache cloned, path=%s, worktree=%s", c.path, worktree)
+	return nil
+}
+
+// CommandCache implements domain.IWorktreeCache by running a shell command in
+// the worktree, for caches that need a tool to set up, e.g. `pnpm install
+// --prefer-offline` against a shared pnpm store. The command gets the project
+// root in CRAIZY_PROJECT_ROOT and the worktree in CRAIZY_WORKTREE.
+type CommandCache struct {
+	command string
+}
+
+// NewCommandCache creates a cache that runs command in new worktrees.
+func NewCommandCache(command string) *CommandCache {
+	return &CommandCache{command: command}
+}
+
+// Name describes the cache in logs.
+func (c *CommandCache) Name() string {
+	return "command " + c.command
+}
+
+// Share runs the command in worktree.
+// Command: sh -c {command}
+func (c *CommandCache) Share(projectRoot, worktree string) error {
+	logging.Entry("command", c.command, "worktree", worktree)
+	ctx, cancel := context.WithTimeout(context.Background(), CacheCommandTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", c.command)
+	cmd.Dir = worktree
+	cmd.Env = append(os.Environ(), "CRAIZY_PROJECT_ROOT="+projectRoot, "CRAIZY_WORKTREE="+worktree)
+	var output bytes.Buffer
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("cache command failed: %w: %s", err, strings.TrimSpace(output.String()))
+	}
+	logging.Info("cache command finished, command=%s, worktree=%s", c.command, worktree)
+	return nil
+}
+
+// cachePaths returns the source and destination of a shared cache directory,
+// and whether there is anything to share.
+func cachePaths(projectRoot, worktree, path string) (src, dst string, ok bool) {
+	src = filepath.Join(projectRoot, path)
+	dst = filepath.Join(worktree, path)
+	if info, err := os.Stat(src); err != nil || !info.IsDir() {
+		logging.Debug("no cache to share, path=%s", src)
+		return "", "", false
+	}
+	if _, err := os.Lstat(dst); err == nil {
+		logging.Debug("worktree already has cache, path=%s", dst)
+		return "", "", false
+	}
+	return src, dst, true
+}
+
+// copyFile copies the regular file at src to dst, keeping its permissions.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,94 @@
+package infra
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newCacheDirs returns a project root with a node_modules cache and an empty worktree.
+func newCacheDirs(t *testing.T) (root, worktree string) {
+	t.Helper()
+	root = t.TempDir()
+	worktree = t.TempDir()
+	pkg := filepath.Join(root, "node_modules", "left-pad")
+	if err := os.MkdirAll(pkg, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(pkg, "index.js"), []byte("module.exports = pad"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("left-pad", filepath.Join(root, "node_modules", "pad")); err != nil {
+		t.Fatal(err)
+	}
+	return root, worktree
+}
+
+func TestSymlinkCache_Share(t *testing.T) {
+	root, worktree := newCacheDirs(t)
+
+	if err := NewSymlinkCache("node_modules").Share(root, worktree); err != nil {
+		t.Fatalf("Share failed: %v", err)
+	}
+
+	link, err := os.Readlink(filepath.Join(worktree, "node_modules"))
+	if err != nil || link != filepath.Join(root, "node_modules") {
+		t.Errorf("expected symlink to project node_modules, got %q (%v)", link, err)
+	}
+
+	t.Run("skips missing source", func(t *testing.T) {
+		if err := NewSymlinkCache("vendor").Share(root, worktree); err != nil {
+			t.Errorf("expected no error for missing cache, got %v", err)
+		}
+		if _, err := os.Lstat(filepath.Join(worktree, "vendor")); !os.IsNotExist(err) {
+			t.Error("expected nothing created for missing cache")
+		}
+	})
+}
+
+func TestHardlinkCache_Share(t *testing.T) {
+	root, worktree := newCacheDirs(t)
+
+	if err := NewHardlinkCache("node_modules").Share(root, worktree); err != nil {
+		t.Fatalf("Share failed: %v", err)
+	}
+
+	src, _ := os.Stat(filepath.Join(root, "node_modules", "left-pad", "index.js"))
+	dst, err := os.Stat(filepath.Join(worktree, "node_modules", "left-pad", "index.js"))
+	if err != nil {
+		t.Fatalf("expected cloned file: %v", err)
+	}
+	if !os.SameFile(src, dst) {
+		t.Error("expected the clone to hard link the original file")
+	}
+	if link, err := os.Readlink(filepath.Join(worktree, "node_modules", "pad")); err != nil || link != "left-pad" {
+		t.Errorf("expected symlink to be recreated, got %q (%v)", link, err)
+	}
+
+	t.Run("keeps existing directory", func(t *testing.T) {
+		if err := NewHardlinkCache("node_modules").Share(root, worktree); err != nil {
+			t.Errorf("expected second share to be a no-op, got %v", err)
+		}
+	})
+}
+
+func TestCommandCache_Share(t *testing.T) {
+	root, worktree := newCacheDirs(t)
+
+	cache := NewCommandCache(`echo "$CRAIZY_PROJECT_ROOT" > from.txt`)
+	if err := cache.Share(root, worktree); err != nil {
+		t.Fatalf("Share failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(worktree, "from.txt"))
+	if err != nil || strings.TrimSpace(string(data)) != root {
+		t.Errorf("expected command to run in the worktree with the project root, got %q (%v)", data, err)
+	}
+
+	t.Run("failure includes output", func(t *testing.T) {
+		err := NewCommandCache("echo no store >&2; exit 1").Share(root, worktree)
+		if err == nil || !strings.Contains(err.Error(), "no store") {
+			t.Errorf("expected error with command output, got %v", err)
+		}
+	})
+}
@@ -45,6 +45,14 @@ func Init(logDir string) error {
 	return initErr
 }
 
+// Path returns the path of today's log file, or "" if logging is not initialized.
+func Path() string {
+	if defaultLogger == nil {
+		return ""
+	}
+	return filepath.Join(defaultLogger.logDir, time.Now().Format("2006-01-02")+".log")
+}
+
 // Close closes the default logger's file handle.
 func Close() {
 	if defaultLogger != nil {
@@ -37,6 +37,26 @@ func TestInit(t *testing.T) {
 	}
 }
 
+func TestPath(t *testing.T) {
+	once = sync.Once{}
+	defaultLogger = nil
+
+	if got := Path(); got != "" {
+		t.Errorf("Path() before Init = %q, want empty", got)
+	}
+
+	logDir := filepath.Join(t.TempDir(), ".craizy")
+	if err := Init(logDir); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+	defer Close()
+
+	want := filepath.Join(logDir, time.Now().Format("2006-01-02")+".log")
+	if got := Path(); got != want {
+		t.Errorf("Path() = %q, want %q", got, want)
+	}
+}
+
 func TestEntry(t *testing.T) {
 	tmpDir := t.TempDir()
 	logDir := filepath.Join(tmpDir, ".craizy")
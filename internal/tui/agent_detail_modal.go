@@ -0,0 +1,266 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// maxDetailMerges limits how many merge history rows the detail view renders.
+const maxDetailMerges = 5
+
+// maxDetailValueWidth wraps long values, like summaries, in the detail view.
+const maxDetailValueWidth = 60
+
+// detailNow returns the time active durations are measured to. Tests replace it.
+var detailNow = time.Now
+
+// AgentDetailModel is a modal that shows details and history for a single agent.
+type AgentDetailModel struct {
+	agent  *domain.Agent
+	merges []*domain.MergeRecord
+	disk   string // worktree size, filled in by WorktreeSizeMsg
+	stack  string // detected languages and runtimes, filled in by WorktreeStackMsg
+	width  int
+	height int
+}
+
+// WorktreeSizeMsg reports the measured size of an agent's worktree.
+type WorktreeSizeMsg struct {
+	AgentID string
+	Bytes   int64
+	Err     error
+}
+
+// NewAgentDetailModal creates a new agent detail modal.
+func NewAgentDetailModal(agent *domain.Agent, merges []*domain.MergeRecord, width, height int) AgentDetailModel {
+	return AgentDetailModel{
+		agent:  agent,
+		merges: merges,
+		width:  width,
+		height: height,
+	}
+}
+
+func (m AgentDetailModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m AgentDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case WorktreeSizeMsg:
+		if msg.AgentID == m.agent.ID && msg.Err == nil {
+			m.disk = domain.FormatBytes(msg.Bytes)
+		}
+		return m, nil
+	case WorktreeStackMsg:
+		if msg.AgentID == m.agent.ID && msg.Err == nil {
+			m.stack = msg.Stack.String()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "enter", "i":
+			return m, func() tea.Msg {
+				return CloseModalMsg{}
+			}
+		case "l":
+			if m.agent.Branch == "" {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return OpenCommitLogMsg{Agent: m.agent}
+			}
+		case "r":
+			if m.agent.Branch == "" {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return OpenRetargetMsg{Agent: m.agent}
+			}
+		case "n", "m":
+			rules := m.agent.NotifyRules()
+			if msg.String() == "n" {
+				rules.Idle = !rules.Idle
+			} else {
+				rules.Muted = toggleMuted(rules.Muted, domain.MessageTypeStatus)
+			}
+			m.agent = withNotifyRules(m.agent, rules)
+			request := NotifyRulesRequestMsg{AgentID: m.agent.ID, Rules: rules}
+			return m, func() tea.Msg { return request }
+		case "o", "O":
+			url := m.agent.PRURL()
+			if msg.String() == "O" {
+				url = m.agent.IssueURL()
+			}
+			if url == "" {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return OpenURLMsg{URL: url}
+			}
+		}
+	}
+	return m, nil
+}
+
+// toggleMuted mutes messages of type t, or unmutes them if they were muted.
+func toggleMuted(muted []domain.MessageType, t domain.MessageType) []domain.MessageType {
+	toggled := make([]domain.MessageType, 0, len(muted)+1)
+	for _, m := range muted {
+		if m != t {
+			toggled = append(toggled, m)
+		}
+	}
+	if len(toggled) == len(muted) {
+		toggled = append(toggled, t)
+	}
+	return toggled
+}
+
+// withNotifyRules returns a copy of agent showing rules, so the detail view
+// reflects a change before the agent list refreshes.
+func withNotifyRules(agent *domain.Agent, rules domain.NotifyRules) *domain.Agent {
+	updated := *agent
+	updated.Metadata = maps.Clone(agent.Metadata)
+	if updated.Metadata == nil {
+		updated.Metadata = make(map[string]string)
+	}
+	updated.Metadata[domain.MetadataNotifyIdle] = ""
+	if rules.Idle {
+		updated.Metadata[domain.MetadataNotifyIdle] = "true"
+	}
+	muted := make([]string, len(rules.Muted))
+	for i, t := range rules.Muted {
+		muted[i] = string(t)
+	}
+	updated.Metadata[domain.MetadataNotifyMute] = strings.Join(muted, ",")
+	return &updated
+}
+
+// KeyHints returns the detail view's keys; commits and retarget need a branch,
+// and the open keys a recorded pull request or issue.
+func (m AgentDetailModel) KeyHints() []keyBinding {
+	var bindings []keyBinding
+	if m.agent.Branch != "" {
+		bindings = append(bindings, keyBinding{key: "l", desc: "commits"}, keyBinding{key: "r", desc: "retarget"})
+	}
+	rules := m.agent.NotifyRules()
+	idle, status := "notify idle", "mute status"
+	if rules.Idle {
+		idle = "don't notify idle"
+	}
+	if rules.Mutes(domain.MessageTypeStatus) {
+		status = "unmute status"
+	}
+	bindings = append(bindings, keyBinding{key: "n", desc: idle}, keyBinding{key: "m", desc: status})
+	if m.agent.PRURL() != "" {
+		bindings = append(bindings, keyBinding{key: "o", desc: "open PR"})
+	}
+	if m.agent.IssueURL() != "" {
+		bindings = append(bindings, keyBinding{key: "O", desc: "open issue"})
+	}
+	return append(bindings, keyBinding{key: "esc", desc: "close"})
+}
+
+func (m AgentDetailModel) View() string {
+	title := theme.ModalTitle.Render("Agent: " + m.agent.Name)
+
+	labelStyle := theme.TextMuted.Width(10)
+	row := func(label, value string) string {
+		if value == "" {
+			value = "-"
+		}
+		style := theme.TextNormal
+		if lipgloss.Width(value) > maxDetailValueWidth {
+			style = style.Width(maxDetailValueWidth)
+		}
+		return lipgloss.JoinHorizontal(lipgloss.Top, labelStyle.Render(label), style.Render(value))
+	}
+
+	rows := []string{
+		row("Type", m.agent.AgentType),
+		row("Session", m.agent.ID),
+		row("Status", string(m.agent.Status)),
+		row("Branch", m.agent.Branch),
+		row("Base", m.agent.BaseBranch),
+		row("Worktree", m.agent.WorkDir),
+		row("Disk", m.disk),
+		row("Stack", m.stack),
+		row("Sparse", strings.Join(m.agent.SparsePaths, ", ")),
+		row("Created", m.agent.CreatedAt.Format(time.DateTime)),
+		row("Active", m.agent.ActiveTime(detailNow()).Round(time.Second).String()),
+		row("Attached", m.agent.AttachedTime.Round(time.Second).String()),
+		row("Summary", m.agent.Summary),
+	}
+	if code, ok := m.agent.ExitCode(); ok {
+		rows = append(rows, row("Exit code", strconv.Itoa(code)))
+	}
+	if m.agent.Restarts > 0 {
+		rows = append(rows, row("Restarts", strconv.Itoa(m.agent.Restarts)))
+	}
+	if rules := notifyRulesSummary(m.agent.NotifyRules()); rules != "" {
+		rows = append(rows, row("Notify", rules))
+	}
+	// Links only show once recorded, as most agents have none
+	if url := m.agent.PRURL(); url != "" {
+		rows = append(rows, row("PR", url))
+	}
+	if url := m.agent.IssueURL(); url != "" {
+		rows = append(rows, row("Issue", url))
+	}
+	info := lipgloss.JoinVertical(lipgloss.Left, rows...)
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		info,
+		"",
+		theme.SideMenuTitle.Render("Merge History"),
+		m.renderMerges(),
+	)
+
+	box := theme.ModalBorder.
+		Padding(1, 3).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// renderMerges renders the most recent merge attempts, one per line.
+func (m AgentDetailModel) renderMerges() string {
+	if len(m.merges) == 0 {
+		return theme.SideMenuEmpty.Render("No merges yet")
+	}
+
+	merges := m.merges
+	if len(merges) > maxDetailMerges {
+		merges = merges[:maxDetailMerges]
+	}
+
+	lines := make([]string, 0, len(merges))
+	for _, r := range merges {
+		outcome := theme.TextSuccess.Render(string(r.Outcome))
+		if r.Outcome != domain.MergeOutcomeSuccess {
+			outcome = theme.TextError.Render(string(r.Outcome))
+		}
+		line := fmt.Sprintf("%s  %s → %s  %s  %s",
+			r.CreatedAt.Format(time.DateTime), r.Branch, r.BaseBranch, r.Strategy, outcome)
+		if len(r.ConflictFiles) > 0 {
+			line += theme.TextMuted.Render(" (" + strings.Join(r.ConflictFiles, ", ") + ")")
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -15,6 +15,7 @@ type AgentItem struct {
 func (i AgentItem) Title() string       { return i.agent.Name }
 func (i AgentItem) Description() string { return i.agent.Command }
 func (i AgentItem) FilterValue() string { return i.agent.Name }
+func (i AgentItem) color() string       { return i.agent.Color }
 
 type AgentSelectorModel struct {
 	list   list.Model
@@ -30,7 +31,7 @@ func NewAgentSelector(agents []config.Agent, width, height int) AgentSelectorMod
 
 	// Adjust dimensions for the list
 	// Modal usually has some padding/border, let's give the list some room
-	l := list.New(items, list.NewDefaultDelegate(), width, height)
+	l := list.New(items, newColorDelegate(), width, height)
 	l.Title = "Select an Agent"
 	l.SetShowHelp(false)
 	l.SetFilteringEnabled(false)    // Simple selection for now
@@ -72,6 +73,11 @@ func (m AgentSelectorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// KeyHints returns the agent selector's keys.
+func (m AgentSelectorModel) KeyHints() []keyBinding {
+	return []keyBinding{{key: "↑/↓", desc: "select"}, {key: "/", desc: "filter"}, {key: "enter", desc: "choose"}, {key: "esc", desc: "cancel"}}
+}
+
 func (m AgentSelectorModel) View() string {
 	return lipgloss.NewStyle().
 		Margin(1, 2).
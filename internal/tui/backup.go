@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// BackupCheckInterval is how often the dashboard checks whether a database
+// backup is due. The backup interval itself is set by the backupper.
+const BackupCheckInterval = 15 * time.Minute
+
+// backupTickMsg triggers a backup check.
+type backupTickMsg struct{}
+
+// SetBackups enables periodic backups while the dashboard runs. Nil disables them.
+func (m *Model) SetBackups(backups domain.IBackupper) {
+	m.backups = backups
+}
+
+// pollBackup returns a command that ticks the backup check, if backups are enabled.
+func (m Model) pollBackup() tea.Cmd {
+	if m.backups == nil {
+		return nil
+	}
+	return tea.Tick(BackupCheckInterval, func(time.Time) tea.Msg {
+		return backupTickMsg{}
+	})
+}
+
+// backupIfDue returns a command that takes a backup if one is due.
+func (m Model) backupIfDue() tea.Cmd {
+	backups := m.backups
+	return func() tea.Msg {
+		if _, err := backups.BackupIfDue(time.Now()); err != nil {
+			logging.Error(err, "action", "periodic backup")
+		}
+		return nil
+	}
+}
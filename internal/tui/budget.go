@@ -0,0 +1,49 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// BudgetCheckInterval is how often running agents are checked against the budget.
+const BudgetCheckInterval = time.Minute
+
+// budgetTickMsg triggers a budget check.
+type budgetTickMsg struct{}
+
+// BudgetEnforcedMsg reports agents stopped by a budget check.
+type BudgetEnforcedMsg struct {
+	Stops []domain.BudgetStop
+}
+
+// pollBudget returns a command that ticks the budget check, if a budget is
+// configured. Read-only dashboards leave enforcement to the main dashboard.
+func (m Model) pollBudget() tea.Cmd {
+	if m.agentService == nil || m.readOnly || !m.agentService.HasBudget() {
+		return nil
+	}
+	return tea.Tick(BudgetCheckInterval, func(time.Time) tea.Msg {
+		return budgetTickMsg{}
+	})
+}
+
+// enforceBudget returns a command that stops agents that are over budget.
+func (m Model) enforceBudget() tea.Cmd {
+	return func() tea.Msg {
+		return BudgetEnforcedMsg{Stops: m.agentService.EnforceBudget(time.Now())}
+	}
+}
+
+// budgetToast describes the agents a budget check stopped.
+func budgetToast(stops []domain.BudgetStop) string {
+	names := make([]string, 0, len(stops))
+	for _, stop := range stops {
+		names = append(names, stop.Agent.Name)
+	}
+	return fmt.Sprintf("Budget exceeded (%s): stopped %s, changes stashed", stops[0].Scope, strings.Join(names, ", "))
+}
@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// OutputCaptureInterval is how often agents with output capture are scanned
+// for printed messages.
+const OutputCaptureInterval = 10 * time.Second
+
+// outputCaptureTickMsg triggers an output capture scan.
+type outputCaptureTickMsg struct{}
+
+// pollOutputCapture returns a command that ticks the output capture scan, if
+// any agent type has output capture. Read-only dashboards leave it to the main
+// dashboard.
+func (m Model) pollOutputCapture() tea.Cmd {
+	if m.agentService == nil || m.readOnly || !m.agentService.HasOutputCapture() {
+		return nil
+	}
+	return tea.Tick(OutputCaptureInterval, func(time.Time) tea.Msg {
+		return outputCaptureTickMsg{}
+	})
+}
+
+// captureOutputMessages returns a command that sends the messages agents
+// printed. Ones for the human arrive in the inbox, whose next check announces
+// them.
+func (m Model) captureOutputMessages() tea.Cmd {
+	return func() tea.Msg {
+		_, _ = m.agentService.CaptureOutputMessages()
+		return nil
+	}
+}
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// CatchUpLines is how much scrollback the preview captures after returning
+// from an attached session, so the lines agents printed while the human was
+// away can be found and flagged even when they overflow the preview.
+const CatchUpLines = 500
+
+// catchUpMatchLines is how many trailing lines of the output last seen locate
+// it in newer output.
+const catchUpMatchLines = 3
+
+// startCatchUp freezes the output last seen of every agent but the one being
+// attached to, whose output the human is about to watch. Previews of those
+// agents flag what's new since until the human moves on from them.
+func (m *Model) startCatchUp(attachedID string) {
+	m.catchUp = maps.Clone(m.seenOutput)
+	delete(m.catchUp, attachedID)
+}
+
+// catchUpDone drops an agent's catch-up once the human moves on from its preview.
+func (m *Model) catchUpDone(agentID string) {
+	delete(m.catchUp, agentID)
+}
+
+// previewLines is how many lines to capture for an agent's preview: the
+// visible lines, or CatchUpLines while catching up on it.
+func (m Model) previewLines(agentID string) int {
+	if m.contentArea.Scrolling() {
+		return ScrollbackLines
+	}
+	if _, ok := m.catchUp[agentID]; ok {
+		return max(CatchUpLines, m.contentArea.AvailableLines())
+	}
+	return m.contentArea.AvailableLines()
+}
+
+// showPreview shows an agent's captured output, flagging the lines new since
+// the output seen before the last attach, and records it as seen.
+func (m *Model) showPreview(agentID, content string) {
+	newLines := 0
+	if seen, ok := m.catchUp[agentID]; ok {
+		newLines = newLineCount(seen, content)
+	}
+	m.contentArea.SetPreview(content)
+	m.contentArea.SetNewLines(newLines)
+	m.contentArea.SetBorderColor(m.agentColor(agentID))
+	if m.seenOutput == nil {
+		m.seenOutput = make(map[string]string)
+	}
+	m.seenOutput[agentID] = content
+}
+
+// newLineCount returns how many trailing lines of output appeared after seen,
+// output captured from the same pane earlier. It returns 0 if nothing was seen,
+// and every line if seen's last lines can't be found, e.g. after the pane was
+// cleared or more was printed than was captured.
+func newLineCount(seen, output string) int {
+	seenLines := outputLines(seen)
+	if len(seenLines) == 0 {
+		return 0
+	}
+	lines := outputLines(output)
+	tail := seenLines[max(len(seenLines)-catchUpMatchLines, 0):]
+	for end := len(lines); end >= len(tail); end-- {
+		if slices.Equal(lines[end-len(tail):end], tail) {
+			return len(lines) - end
+		}
+	}
+	return len(lines)
+}
+
+// outputLines splits captured pane output into lines, dropping the blank
+// lines below the last output.
+func outputLines(s string) []string {
+	s = strings.TrimRight(s, "\n ")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
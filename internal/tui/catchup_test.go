@@ -0,0 +1,64 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNewLineCount(t *testing.T) {
+	tests := []struct {
+		name   string
+		seen   string
+		output string
+		want   int
+	}{
+		{"nothing seen", "", "a\nb", 0},
+		{"unchanged", "a\nb\nc\n\n", "a\nb\nc\n\n\n", 0},
+		{"lines added", "a\nb\nc", "a\nb\nc\nd\ne", 2},
+		{"scrolled past the seen head", "a\nb\nc\nd", "b\nc\nd\ne", 1},
+		{"repeated tail matches the latest", "x\nok", "x\nok\ny\nx\nok\nz", 1},
+		{"seen output gone", "a\nb", "c\nd\ne", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newLineCount(tt.seen, tt.output); got != tt.want {
+				t.Errorf("newLineCount() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestModel_CatchUp(t *testing.T) {
+	m := NewModel(nil, nil)
+	m.contentArea.SetSize(60, 12)
+	m.showPreview("other", "one\ntwo")
+	m.showPreview("attached", "hello")
+
+	m.startCatchUp("attached")
+	if got := m.previewLines("other"); got != CatchUpLines {
+		t.Errorf("previewLines(other) = %d, want %d", got, CatchUpLines)
+	}
+	if got := m.previewLines("attached"); got != m.contentArea.AvailableLines() {
+		t.Errorf("previewLines(attached) = %d, want the visible lines", got)
+	}
+
+	m.showPreview("other", "one\ntwo\nthree\nfour")
+	if m.contentArea.newLines != 2 {
+		t.Fatalf("newLines = %d, want 2", m.contentArea.newLines)
+	}
+	view := m.contentArea.renderPreview()
+	if !strings.Contains(view, "2 new lines while you were away") || !strings.Contains(view, "▌ three") {
+		t.Errorf("preview doesn't flag new lines:\n%s", view)
+	}
+
+	m.showPreview("attached", "hello\nworld")
+	if m.contentArea.newLines != 0 {
+		t.Errorf("attached agent flagged %d new lines, want none", m.contentArea.newLines)
+	}
+
+	m.catchUpDone("other")
+	m.showPreview("other", "one\ntwo\nthree\nfour\nfive")
+	if m.contentArea.newLines != 0 {
+		t.Errorf("newLines after catching up = %d, want 0", m.contentArea.newLines)
+	}
+}
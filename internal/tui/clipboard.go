@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"io"
+	"os"
+
+	"github.com/atotto/clipboard"
+	osc52 "github.com/aymanbagabas/go-osc52/v2"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// clipboardWriter copies text to the system clipboard. Tests replace it.
+var clipboardWriter = writeClipboard
+
+// writeClipboard copies text using the native clipboard tool, falling back to an
+// OSC52 escape sequence when none is available or when running inside tmux or
+// over SSH, where the native clipboard usually isn't the user's.
+func writeClipboard(text string) error {
+	if os.Getenv("TMUX") == "" && os.Getenv("SSH_TTY") == "" && !clipboard.Unsupported {
+		err := clipboard.WriteAll(text)
+		if err == nil {
+			return nil
+		}
+		logging.Debug("native clipboard failed, falling back to OSC52: %v", err)
+	}
+	return writeOSC52(os.Stderr, text)
+}
+
+// writeOSC52 asks the terminal to set its clipboard, wrapping the sequence for tmux.
+func writeOSC52(out io.Writer, text string) error {
+	seq := osc52.New(text)
+	if os.Getenv("TMUX") != "" {
+		seq = seq.Tmux()
+	}
+	_, err := seq.WriteTo(out)
+	return err
+}
+
+// copyToClipboard returns a command that copies text and reports the result.
+func copyToClipboard(label, text string) tea.Cmd {
+	return func() tea.Msg {
+		return CopyResultMsg{Label: label, Text: text, Err: clipboardWriter(text)}
+	}
+}
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestWriteOSC52(t *testing.T) {
+	t.Run("plain terminal", func(t *testing.T) {
+		t.Setenv("TMUX", "")
+		var buf bytes.Buffer
+
+		if err := writeOSC52(&buf, "craizy/auth"); err != nil {
+			t.Fatalf("writeOSC52 failed: %v", err)
+		}
+		if !strings.HasPrefix(buf.String(), "\x1b]52;c;") {
+			t.Errorf("sequence = %q, want OSC52", buf.String())
+		}
+	})
+
+	t.Run("wrapped for tmux", func(t *testing.T) {
+		t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+		var buf bytes.Buffer
+
+		_ = writeOSC52(&buf, "craizy/auth")
+
+		if !strings.HasPrefix(buf.String(), "\x1bPtmux;") {
+			t.Errorf("sequence = %q, want tmux passthrough", buf.String())
+		}
+	})
+}
+
+func TestCopyMenuModal(t *testing.T) {
+	agent := &domain.Agent{ID: "craizy-proj-claude-auth", Name: "auth", Branch: "craizy/auth"}
+
+	t.Run("skips empty values", func(t *testing.T) {
+		m := NewCopyMenuModal(agent, "", 80, 24)
+
+		if len(m.items) != 2 {
+			t.Errorf("items = %d, want branch and session ID only", len(m.items))
+		}
+	})
+
+	t.Run("key requests copy", func(t *testing.T) {
+		m := NewCopyMenuModal(agent, "output", 80, 24)
+
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b")})
+
+		msg, ok := cmd().(CopyRequestMsg)
+		if !ok || msg.Text != "craizy/auth" {
+			t.Errorf("got %v, want CopyRequestMsg for branch", msg)
+		}
+	})
+}
+
+func TestModel_Update_Copy(t *testing.T) {
+	var copied string
+	previous := clipboardWriter
+	clipboardWriter = func(text string) error {
+		copied = text
+		return nil
+	}
+	defer func() { clipboardWriter = previous }()
+
+	m := NewModel(nil, nil)
+	m.modal.Open(NewInfoModal("x", "y", 80, 24))
+
+	newModel, cmd := m.Update(CopyRequestMsg{Label: "branch", Text: "craizy/auth"})
+	model := newModel.(Model)
+	if model.modal.IsOpen() {
+		t.Error("copy menu should close after choosing")
+	}
+
+	result := cmd().(CopyResultMsg)
+	if copied != "craizy/auth" {
+		t.Errorf("copied %q, want craizy/auth", copied)
+	}
+
+	newModel, _ = model.Update(result)
+	model = newModel.(Model)
+	if model.toast.text != "Copied branch: craizy/auth" {
+		t.Errorf("toast = %q", model.toast.text)
+	}
+}
@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// typeIcon marks an agent type's color before list titles.
+const typeIcon = "●"
+
+// SetAgentColors sets the colors AGENTS.yml assigns agent types, keyed by
+// lowercased type name, used for list icons and the preview border.
+func (m *Model) SetAgentColors(colors map[string]string) {
+	m.agentColors = colors
+	m.sideMenu.SetColors(colors)
+}
+
+// agentColor returns the color of the agent's type, or "" if it has none.
+func (m Model) agentColor(agentID string) string {
+	for _, agent := range m.sideMenu.agents {
+		if agent.ID == agentID {
+			return typeColor(m.agentColors, agent)
+		}
+	}
+	return ""
+}
+
+// typeColor looks up an agent's type in colors.
+func typeColor(colors map[string]string, agent *domain.Agent) string {
+	return colors[strings.ToLower(agent.AgentType)]
+}
+
+// coloredItem is a list item shown with its agent type's color.
+type coloredItem interface {
+	color() string
+}
+
+// colorDelegate renders list items like the default delegate, with a typeIcon
+// in the item's color taking the place of the title's left padding or border.
+type colorDelegate struct {
+	list.DefaultDelegate
+}
+
+func newColorDelegate() colorDelegate {
+	return colorDelegate{DefaultDelegate: list.NewDefaultDelegate()}
+}
+
+func (d colorDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	if c, ok := item.(coloredItem); ok && c.color() != "" {
+		icon := lipgloss.Border{Left: typeIcon}
+		color := lipgloss.Color(c.color())
+		s := &d.Styles
+		s.NormalTitle = s.NormalTitle.PaddingLeft(1).Border(icon, false, false, false, true).BorderForeground(color)
+		s.SelectedTitle = s.SelectedTitle.Border(icon, false, false, false, true).BorderForeground(color)
+		s.DimmedTitle = s.DimmedTitle.PaddingLeft(1).Border(icon, false, false, false, true).BorderForeground(color)
+	}
+	d.DefaultDelegate.Render(w, m, index, item)
+}
@@ -0,0 +1,40 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestSideMenu_TypeColors(t *testing.T) {
+	menu := NewSideMenu()
+	menu.SetSize(30, 10)
+	menu.SetColors(map[string]string{"claude": "#D08770"})
+	menu, _ = menu.Update(AgentsUpdatedMsg{Agents: []*domain.Agent{
+		{ID: "a", Name: "alpha", AgentType: "Claude", Status: domain.AgentStatusActive},
+		{ID: "b", Name: "beta", AgentType: "Aider", Status: domain.AgentStatusActive},
+	}})
+
+	view := menu.View()
+
+	if !strings.Contains(view, typeIcon+" alpha") {
+		t.Errorf("expected a type icon before the colored agent, got:\n%s", view)
+	}
+	if strings.Contains(view, typeIcon+" beta") {
+		t.Errorf("expected no type icon before the uncolored agent, got:\n%s", view)
+	}
+}
+
+func TestModel_AgentColor(t *testing.T) {
+	m := NewModel(nil, nil)
+	m.SetAgentColors(map[string]string{"claude": "#D08770"})
+	m.sideMenu.agents = []*domain.Agent{{ID: "a", AgentType: "Claude"}, {ID: "b", AgentType: "Aider"}}
+
+	if got := m.agentColor("a"); got != "#D08770" {
+		t.Errorf("agentColor(a) = %q, want #D08770", got)
+	}
+	if got := m.agentColor("b"); got != "" {
+		t.Errorf("agentColor(b) = %q, want none", got)
+	}
+}
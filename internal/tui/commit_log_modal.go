@@ -0,0 +1,180 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// CommitLogModel is a modal listing the commits an agent's branch is ahead of its base,
+// with drill-down into a single commit's diff.
+type CommitLogModel struct {
+	agent    *domain.Agent
+	commits  []domain.Commit
+	selected int
+	loading  bool
+	err      error
+
+	// diff is non-nil while a commit's diff is shown
+	diff     *viewport.Model
+	diffHash string
+
+	width  int
+	height int
+}
+
+// NewCommitLogModal creates a commit log modal that waits for a CommitLogLoadedMsg.
+func NewCommitLogModal(agent *domain.Agent, width, height int) CommitLogModel {
+	return CommitLogModel{
+		agent:   agent,
+		loading: true,
+		width:   width,
+		height:  height,
+	}
+}
+
+func (m CommitLogModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m CommitLogModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if m.diff != nil {
+			vp := *m.diff
+			vp.Width, vp.Height = m.diffWidth(), m.diffHeight()
+			m.diff = &vp
+		}
+		return m, nil
+
+	case CommitLogLoadedMsg:
+		m.loading = false
+		m.commits = msg.Commits
+		m.err = msg.Err
+		m.selected = 0
+		return m, nil
+
+	case CommitDiffLoadedMsg:
+		content := msg.Diff
+		if msg.Err != nil {
+			content = theme.TextError.Render(msg.Err.Error())
+		}
+		vp := viewport.New(m.diffWidth(), m.diffHeight())
+		vp.SetContent(content)
+		m.diff = &vp
+		m.diffHash = msg.Hash
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.diff != nil {
+			return m.updateDiff(msg)
+		}
+		switch msg.String() {
+		case "esc", "l", "q":
+			return m, func() tea.Msg {
+				return CloseModalMsg{}
+			}
+		case "up", "k":
+			if m.selected > 0 {
+				m.selected--
+			}
+		case "down", "j":
+			if m.selected < len(m.commits)-1 {
+				m.selected++
+			}
+		case "enter":
+			if len(m.commits) == 0 {
+				return m, nil
+			}
+			hash := m.commits[m.selected].Hash
+			agentID := m.agent.ID
+			return m, func() tea.Msg {
+				return CommitDiffRequestMsg{AgentID: agentID, Hash: hash}
+			}
+		}
+	}
+	return m, nil
+}
+
+// updateDiff handles keys while a commit's diff is shown, returning to the list on esc.
+func (m CommitLogModel) updateDiff(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.diff = nil
+		m.diffHash = ""
+		return m, nil
+	}
+	vp, cmd := m.diff.Update(msg)
+	m.diff = &vp
+	return m, cmd
+}
+
+// diffWidth returns the diff viewport width, leaving room for the modal border and padding.
+func (m CommitLogModel) diffWidth() int {
+	return max(m.width-10, 20)
+}
+
+// diffHeight returns the diff viewport height, leaving room for the title, hint and border.
+func (m CommitLogModel) diffHeight() int {
+	return max(m.height-10, 5)
+}
+
+// KeyHints returns the keys for the commit list or, when shown, a commit's diff.
+func (m CommitLogModel) KeyHints() []keyBinding {
+	if m.diff != nil {
+		return []keyBinding{{key: "↑/↓", desc: "scroll"}, {key: "esc", desc: "back"}}
+	}
+	return []keyBinding{{key: "↑/↓", desc: "select"}, {key: "enter", desc: "show diff"}, {key: "esc", desc: "close"}}
+}
+
+func (m CommitLogModel) View() string {
+	var content string
+	if m.diff != nil {
+		content = lipgloss.JoinVertical(lipgloss.Left,
+			theme.ModalTitle.Render("Commit "+m.diffHash),
+			"",
+			m.diff.View(),
+		)
+	} else {
+		content = lipgloss.JoinVertical(lipgloss.Left,
+			theme.ModalTitle.Render("Commits: "+m.agent.Name),
+			theme.TextMuted.Render(m.agent.Branch+" ahead of "+m.agent.BaseBranch),
+			"",
+			m.renderCommits(),
+		)
+	}
+
+	box := theme.ModalBorder.
+		Padding(1, 3).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// renderCommits renders one line per commit, highlighting the selection.
+func (m CommitLogModel) renderCommits() string {
+	switch {
+	case m.loading:
+		return theme.SideMenuEmpty.Render("Loading commits…")
+	case m.err != nil:
+		return theme.TextError.Render(m.err.Error())
+	case len(m.commits) == 0:
+		return theme.SideMenuEmpty.Render("No commits ahead of " + m.agent.BaseBranch)
+	}
+
+	lines := make([]string, 0, len(m.commits))
+	for i, c := range m.commits {
+		line := "  " + theme.TextMuted.Render(c.Hash) + "  " + theme.TextNormal.Render(c.Subject)
+		if i == m.selected {
+			line = theme.TextSuccess.Render("› " + c.Hash + "  " + c.Subject)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
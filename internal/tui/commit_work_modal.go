@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// CommitWorkModel is a modal that asks for the message to commit an agent's
+// uncommitted work with.
+type CommitWorkModel struct {
+	textInput textinput.Model
+	agent     *domain.Agent
+	width     int
+	height    int
+}
+
+// NewCommitWorkModal creates a new commit modal for the given agent, with the
+// default message filled in.
+func NewCommitWorkModal(agent *domain.Agent, width, height int) CommitWorkModel {
+	ti := textinput.New()
+	ti.Placeholder = "Commit message"
+	ti.Focus()
+	ti.CharLimit = 200
+	ti.Width = 40
+	ti.SetValue(domain.WorkCommitMessage(agent))
+
+	return CommitWorkModel{
+		textInput: ti,
+		agent:     agent,
+		width:     width,
+		height:    height,
+	}
+}
+
+func (m CommitWorkModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m CommitWorkModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEnter:
+			return m, func() tea.Msg {
+				return CommitWorkConfirmedMsg{
+					AgentID:   m.agent.ID,
+					AgentName: m.agent.Name,
+					Message:   m.textInput.Value(),
+				}
+			}
+		case tea.KeyEsc:
+			return m, func() tea.Msg {
+				return CloseModalMsg{}
+			}
+		}
+	}
+
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// KeyHints returns the commit input's keys.
+func (m CommitWorkModel) KeyHints() []keyBinding {
+	return []keyBinding{{key: "enter", desc: "commit"}, {key: "esc", desc: "cancel"}}
+}
+
+func (m CommitWorkModel) View() string {
+	title := theme.ModalTitle.Render("Commit " + m.agent.Name + "'s work")
+	branch := theme.TextMuted.Render("Stages every change on " + m.agent.Branch)
+
+	box := theme.ModalBorder.
+		Padding(1, 2).
+		Render(
+			lipgloss.JoinVertical(lipgloss.Center,
+				title,
+				branch,
+				"",
+				m.textInput.View(),
+			),
+		)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
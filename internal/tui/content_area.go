@@ -1,17 +1,18 @@
 package tui
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	figure "github.com/common-nighthawk/go-figure"
 
 	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+	"github.com/TechnicallyShaun/crAIzy/internal/version"
 )
 
-const version = "v0.1.0"
-
 // generateLogo creates the ASCII art logo using go-figure.
 // Returns the logo with normalized whitespace for consistent alignment.
 func generateLogo() string {
@@ -54,6 +55,19 @@ type ContentAreaModel struct {
 	width          int
 	height         int
 	previewContent string
+	newLines       int    // trailing preview lines printed while the human was away
+	borderColor    string // the previewed agent type's color, or "" for the theme's
+
+	// Branch diff shown in place of the preview, see ShowDiff
+	diff      viewport.Model
+	diffOpen  bool
+	diffTitle string
+	diffText  string
+
+	// Pane history scrolled in place of the live preview, see StartScroll
+	scroll        viewport.Model
+	scrolling     bool
+	scrollPending bool // page up from the bottom once the history arrives
 }
 
 func NewContentArea() ContentAreaModel {
@@ -71,11 +85,134 @@ func (m ContentAreaModel) Update(_ tea.Msg) (ContentAreaModel, tea.Cmd) {
 func (m *ContentAreaModel) SetSize(w, h int) {
 	m.width = w
 	m.height = h
+	if m.diffOpen {
+		m.layoutDiff()
+	}
+	if m.scrolling {
+		m.layoutScroll()
+	}
+}
+
+// ShowDiff shows a patch under title in place of the preview, scrolled with
+// ScrollDiff, until CloseDiff.
+func (m *ContentAreaModel) ShowDiff(title, diff string) {
+	m.diff = viewport.New(0, 0)
+	m.diffOpen = true
+	m.diffTitle = title
+	m.diffText = diff
+	m.layoutDiff()
+}
+
+// CloseDiff goes back to the preview.
+func (m *ContentAreaModel) CloseDiff() {
+	m.diffOpen = false
+	m.diffText = ""
+}
+
+// DiffOpen reports whether a diff is shown in place of the preview.
+func (m ContentAreaModel) DiffOpen() bool {
+	return m.diffOpen
+}
+
+// ScrollDiff scrolls the diff by the viewport's keys.
+func (m *ContentAreaModel) ScrollDiff(msg tea.KeyMsg) tea.Cmd {
+	var cmd tea.Cmd
+	m.diff, cmd = m.diff.Update(msg)
+	return cmd
+}
+
+// layoutDiff sizes the diff under its title and colors it, cutting lines to
+// the width so long ones don't wrap out of step with the scrolling.
+func (m *ContentAreaModel) layoutDiff() {
+	m.diff.Width = m.availableWidth()
+	m.diff.Height = max(m.AvailableLines()-1, 1)
+	m.diff.SetContent(colorDiff(m.diffText, m.availableWidth()))
+}
+
+// StartScroll swaps the live preview for a scrollable view of the pane's
+// history, a page up from the bottom once SetPreview gives the history.
+func (m *ContentAreaModel) StartScroll() {
+	m.scroll = viewport.New(0, 0)
+	m.scrolling = true
+	m.scrollPending = true
+	m.layoutScroll()
+}
+
+// StopScroll goes back to the live preview.
+func (m *ContentAreaModel) StopScroll() {
+	m.scrolling = false
+}
+
+// Scrolling reports whether the pane's history is shown in place of the
+// live preview.
+func (m ContentAreaModel) Scrolling() bool {
+	return m.scrolling
+}
+
+// ScrollPreview scrolls the history by the viewport's keys, or to its top or
+// bottom with g and G.
+func (m *ContentAreaModel) ScrollPreview(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "g", "home":
+		m.scroll.GotoTop()
+		return nil
+	case "G", "end":
+		m.scroll.GotoBottom()
+		return nil
+	}
+	var cmd tea.Cmd
+	m.scroll, cmd = m.scroll.Update(msg)
+	return cmd
+}
+
+// layoutScroll sizes the history under its position line and refreshes it,
+// following new output while scrolled to the bottom and otherwise keeping
+// the place.
+func (m *ContentAreaModel) layoutScroll() {
+	follow := m.scroll.AtBottom()
+	m.scroll.Width = m.availableWidth()
+	m.scroll.Height = max(m.AvailableLines()-1, 1)
+	lines := outputLines(m.previewContent)
+	for i, line := range lines {
+		lines[i] = truncateLine(line, m.availableWidth())
+	}
+	m.scroll.SetContent(strings.Join(lines, "\n"))
+	switch {
+	case m.scrollPending && len(lines) > 0:
+		m.scrollPending = false
+		m.scroll.GotoBottom()
+		m.scroll.PageUp()
+	case follow:
+		m.scroll.GotoBottom()
+	}
+}
+
+// scrollPosition describes where the history is scrolled to.
+func (m ContentAreaModel) scrollPosition() string {
+	if m.scroll.AtBottom() {
+		return "latest"
+	}
+	return fmt.Sprintf("%d%%", int(m.scroll.ScrollPercent()*100))
 }
 
 // SetPreview updates the preview content to display.
 func (m *ContentAreaModel) SetPreview(content string) {
 	m.previewContent = content
+	m.newLines = 0
+	if m.scrolling {
+		m.layoutScroll()
+	}
+}
+
+// SetNewLines flags the last n lines of the preview as printed while the human
+// was attached elsewhere.
+func (m *ContentAreaModel) SetNewLines(n int) {
+	m.newLines = n
+}
+
+// SetBorderColor colors the preview border, or restores the theme's border if color is "".
+func (m *ContentAreaModel) SetBorderColor(color string) {
+	m.borderColor = color
 }
 
 // AvailableLines returns the number of lines available for preview content.
@@ -92,6 +229,19 @@ func (m ContentAreaModel) View() string {
 	borderStyle := theme.BorderNormal.
 		Width(m.width - 2).
 		Height(m.height - 2)
+	if m.borderColor != "" {
+		borderStyle = borderStyle.BorderForeground(lipgloss.Color(m.borderColor))
+	}
+
+	if m.diffOpen {
+		title := theme.ContentTitle.Render(truncateLine(m.diffTitle, m.availableWidth()))
+		return borderStyle.Render(lipgloss.JoinVertical(lipgloss.Left, title, m.diff.View()))
+	}
+
+	if m.scrolling {
+		title := theme.ContentTitle.Render(truncateLine("History, "+m.scrollPosition(), m.availableWidth()))
+		return borderStyle.Render(lipgloss.JoinVertical(lipgloss.Left, title, m.scroll.View()))
+	}
 
 	if m.previewContent == "" {
 		return borderStyle.Render(m.renderEmptyState())
@@ -145,7 +295,7 @@ func (m ContentAreaModel) renderEmptyState() string {
 	}
 	logo := logoStyle.Render(strings.Join(paddedLogo, "\n"))
 
-	ver := versionStyle.Render(version)
+	ver := versionStyle.Render(version.Version)
 
 	// Calculate vertical spacing
 	contentLines := strings.Count(tagline, "\n") + 1 +
@@ -196,6 +346,9 @@ func truncateLine(line string, maxWidth int) string {
 
 // renderPreview renders the tmux pane output.
 func (m ContentAreaModel) renderPreview() string {
+	if m.newLines > 0 {
+		return m.renderCatchUp()
+	}
 	lines := strings.Split(m.previewContent, "\n")
 	availableLines := m.AvailableLines()
 	availableWidth := m.availableWidth()
@@ -212,3 +365,31 @@ func (m ContentAreaModel) renderPreview() string {
 
 	return strings.Join(lines, "\n")
 }
+
+// renderCatchUp renders the pane output under a count of the lines printed
+// while the human was away, marking those lines in a gutter.
+func (m ContentAreaModel) renderCatchUp() string {
+	lines := outputLines(m.previewContent)
+	availableLines := m.AvailableLines() - 1
+	availableWidth := m.availableWidth() - 2
+	firstNew := len(lines) - m.newLines
+
+	start := max(len(lines)-availableLines, 0)
+	out := []string{theme.ContentNewLine.Render(truncateLine(newLinesSummary(m.newLines), m.availableWidth()))}
+	for i := start; i < len(lines); i++ {
+		gutter := "  "
+		if i >= firstNew {
+			gutter = theme.ContentNewLine.Render("▌ ")
+		}
+		out = append(out, gutter+truncateLine(lines[i], availableWidth))
+	}
+	return strings.Join(out, "\n")
+}
+
+// newLinesSummary describes how many lines were printed while the human was away.
+func newLinesSummary(n int) string {
+	if n == 1 {
+		return "1 new line while you were away"
+	}
+	return fmt.Sprintf("%d new lines while you were away", n)
+}
@@ -3,6 +3,8 @@ package tui
 import (
 	"strings"
 	"testing"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/version"
 )
 
 func TestContentAreaModel_AvailableLines(t *testing.T) {
@@ -68,7 +70,7 @@ func TestContentAreaModel_View(t *testing.T) {
 		view := m.View()
 
 		// Should contain welcome message elements
-		if !strings.Contains(view, "crAIzy") && !strings.Contains(view, "v0.1.0") {
+		if !strings.Contains(view, "crAIzy") && !strings.Contains(view, version.Version) {
 			t.Error("empty state should show branded content")
 		}
 	})
@@ -236,8 +238,8 @@ func TestContentAreaModel_renderEmptyState(t *testing.T) {
 
 		emptyState := m.renderEmptyState()
 
-		if !strings.Contains(emptyState, version) {
-			t.Errorf("empty state should contain version %s", version)
+		if !strings.Contains(emptyState, version.Version) {
+			t.Errorf("empty state should contain version %s", version.Version)
 		}
 	})
 
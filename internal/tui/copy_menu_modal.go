@@ -0,0 +1,105 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// copyItem is one value that can be copied from the copy menu.
+type copyItem struct {
+	key   string
+	label string
+	value string
+}
+
+// CopyMenuModel is a modal that copies one of the selected agent's values to the clipboard.
+type CopyMenuModel struct {
+	agentName string
+	items     []copyItem
+	width     int
+	height    int
+}
+
+// NewCopyMenuModal creates a copy menu for the agent and its current preview output.
+func NewCopyMenuModal(agent *domain.Agent, preview string, width, height int) CopyMenuModel {
+	candidates := []copyItem{
+		{key: "b", label: "branch", value: agent.Branch},
+		{key: "w", label: "worktree path", value: agent.WorkDir},
+		{key: "s", label: "session ID", value: agent.ID},
+		{key: "p", label: "preview output", value: preview},
+	}
+	var items []copyItem
+	for _, item := range candidates {
+		if item.value != "" {
+			items = append(items, item)
+		}
+	}
+	return CopyMenuModel{
+		agentName: agent.Name,
+		items:     items,
+		width:     width,
+		height:    height,
+	}
+}
+
+func (m CopyMenuModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m CopyMenuModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width, m.height = size.Width, size.Height
+		return m, nil
+	}
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		if msg.String() == "esc" || msg.String() == "y" {
+			return m, func() tea.Msg {
+				return CloseModalMsg{}
+			}
+		}
+		for _, item := range m.items {
+			if msg.String() == item.key {
+				item := item
+				return m, func() tea.Msg {
+					return CopyRequestMsg{Label: item.label, Text: item.value}
+				}
+			}
+		}
+	}
+	return m, nil
+}
+
+// KeyHints returns the copy menu's keys; the items list their own.
+func (m CopyMenuModel) KeyHints() []keyBinding {
+	return []keyBinding{{key: "esc", desc: "close"}}
+}
+
+func (m CopyMenuModel) View() string {
+	title := theme.ModalTitle.Render("Copy from " + m.agentName)
+
+	keyStyle := theme.QuickCommandKey.Width(3)
+	labelStyle := theme.TextNormal.Width(16)
+	lines := make([]string, 0, len(m.items))
+	for _, item := range m.items {
+		value := truncateLine(item.value, 40)
+		if item.label == "preview output" {
+			value = "(last captured output)"
+		}
+		lines = append(lines, keyStyle.Render(item.key)+labelStyle.Render(item.label)+theme.TextMuted.Render(value))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		lipgloss.JoinVertical(lipgloss.Left, lines...),
+	)
+
+	box := theme.ModalBorder.
+		Padding(1, 3).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
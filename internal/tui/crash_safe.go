@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// PanicError carries a recovered panic value together with the stack of the
+// goroutine that panicked.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// commandPanicMsg reports a panic recovered inside a command goroutine.
+type commandPanicMsg struct {
+	err *PanicError
+}
+
+// crashSafeModel wraps a model so that panics inside commands, which run on
+// their own goroutines, are re-raised on the program's event loop where the
+// caller can recover them and restore the terminal.
+type crashSafeModel struct {
+	model tea.Model
+}
+
+// NewCrashSafe wraps m for use with tea.WithoutCatchPanics. Panics in Update,
+// View or any command surface as a *PanicError panic from Program.Run.
+func NewCrashSafe(m tea.Model) tea.Model {
+	return crashSafeModel{model: m}
+}
+
+func (m crashSafeModel) Init() tea.Cmd {
+	return safeCmd(m.model.Init())
+}
+
+func (m crashSafeModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(commandPanicMsg); ok {
+		panic(msg.err)
+	}
+	model, cmd := m.model.Update(msg)
+	m.model = model
+	return m, safeCmd(cmd)
+}
+
+func (m crashSafeModel) View() string {
+	return m.model.View()
+}
+
+// safeCmd wraps cmd so a panic is returned as a commandPanicMsg instead of
+// crashing the process. Batched commands are wrapped individually.
+func safeCmd(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() (msg tea.Msg) {
+		defer func() {
+			if r := recover(); r != nil {
+				msg = commandPanicMsg{err: &PanicError{Value: r, Stack: debug.Stack()}}
+			}
+		}()
+
+		msg = cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			wrapped := make(tea.BatchMsg, len(batch))
+			for i, c := range batch {
+				wrapped[i] = safeCmd(c)
+			}
+			return wrapped
+		}
+		return msg
+	}
+}
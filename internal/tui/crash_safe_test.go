@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSafeCmd(t *testing.T) {
+	t.Run("converts panic to message", func(t *testing.T) {
+		cmd := safeCmd(func() tea.Msg { panic("boom") })
+
+		msg, ok := cmd().(commandPanicMsg)
+		if !ok {
+			t.Fatal("expected commandPanicMsg")
+		}
+		if msg.err.Value != "boom" {
+			t.Errorf("Value = %v, want boom", msg.err.Value)
+		}
+		if len(msg.err.Stack) == 0 {
+			t.Error("expected a stack trace")
+		}
+	})
+
+	t.Run("wraps batched commands", func(t *testing.T) {
+		cmd := safeCmd(tea.Batch(
+			func() tea.Msg { return CloseModalMsg{} },
+			func() tea.Msg { panic("boom") },
+		))
+
+		batch, ok := cmd().(tea.BatchMsg)
+		if !ok {
+			t.Fatal("expected BatchMsg")
+		}
+		if _, ok := batch[1]().(commandPanicMsg); !ok {
+			t.Error("batched command panic should be recovered")
+		}
+	})
+
+	t.Run("nil command", func(t *testing.T) {
+		if safeCmd(nil) != nil {
+			t.Error("nil command should stay nil")
+		}
+	})
+}
+
+func TestCrashSafeModel_Update(t *testing.T) {
+	m := NewCrashSafe(NewModel(nil, nil))
+	panicErr := &PanicError{Value: "boom"}
+
+	defer func() {
+		if r := recover(); r != panicErr {
+			t.Errorf("recovered %v, want the command's PanicError", r)
+		}
+	}()
+	m.Update(commandPanicMsg{err: panicErr})
+	t.Error("Update should re-panic on the event loop")
+}
@@ -1,11 +1,13 @@
 package tui
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
@@ -16,53 +18,180 @@ import (
 // PreviewPollInterval is how often to poll for preview updates.
 const PreviewPollInterval = 2 * time.Second
 
+// MaxFPS caps how often the dashboard redraws, lowered from Bubble Tea's
+// default of 60 to send fewer frames over slow SSH links.
+const MaxFPS = 30
+
+// AttentionRefreshInterval is how often attention is re-scored while sorting by it,
+// so agents that go idle move up without another event.
+const AttentionRefreshInterval = 30 * time.Second
+
 type Model struct {
 	width          int
 	height         int
 	sideMenu       SideMenuModel
 	contentArea    ContentAreaModel
 	quickCommands  QuickCommandsModel
+	toast          ToastModel
+	progress       ProgressModel
+	progressEvents <-chan domain.OperationProgress // see SetProgressReporter
 	modal          Modal
 	agentService   *domain.AgentService
 	messageService *domain.MessageService
 	isPortedIn     bool
+	linear         bool
+	openCommand    string
+	attentionSeq   int
+	degraded       []string // unresponsive dependencies, see pollHealth
+	readOnly       bool     // observer mode, see SetReadOnly
+	diskWarning    bool     // worktrees are over the disk threshold with space to reclaim
+	inboxSeq       int      // highest unread message seq seen, see updateInbox
+	inboxPrimed    bool     // the inbox has been checked once
+	human          string   // participant whose inbox this is, see SetHuman
+	project        string   // project named in the terminal title, see SetProject
+	title          string   // terminal title last set, see updateTitle
+	titleActive    int      // active agents counted in the title
+	titleUnread    int      // unread messages counted in the title
+	backups        domain.IBackupper
+	retryOptions   RetryOptionsFunc  // see SetRetryOptions
+	seenOutput     map[string]string // preview output last shown per agent
+	agentColors    map[string]string // agent type colors, see SetAgentColors
+	catchUp        map[string]string // output seen before the last attach, see startCatchUp
+	idleNotified   map[string]bool   // idle agents already notified about, see updateIdleNotify
+	focus          bool              // selected agent's preview fills the screen, see toggleFocus
+
+	// Idle reminder, see SetReminder
+	reminderAfter   time.Duration
+	reminderDesktop bool
+	lastInteraction time.Time
+	reminded        bool
 }
 
 func NewModel(agentService *domain.AgentService, messageService *domain.MessageService) Model {
+	sideMenu := NewSideMenu()
+	sideMenu.SetLoading(true)
 	return Model{
-		sideMenu:       NewSideMenu(),
+		sideMenu:       sideMenu,
 		contentArea:    NewContentArea(),
 		quickCommands:  NewQuickCommands(),
+		progress:       NewProgress(),
 		modal:          NewModal(),
 		agentService:   agentService,
 		messageService: messageService,
+		human:          domain.HumanParticipantID,
 	}
 }
 
+// SetLinear switches the dashboard to plain, single-column text with explicit
+// state words, for screen readers and basic terminals.
+func (m *Model) SetLinear(linear bool) {
+	m.linear = linear
+}
+
+// SetOpenCommand sets the command template used to open agent worktrees.
+func (m *Model) SetOpenCommand(template string) {
+	m.openCommand = template
+}
+
 func (m Model) Init() tea.Cmd {
-	// Send initial agents update to populate the list
+	// Show stored agents immediately; reconcile against tmux in the background
 	return tea.Batch(
 		m.sideMenu.Init(),
 		m.contentArea.Init(),
 		m.quickCommands.Init(),
 		m.modal.Init(),
 		m.refreshAgents(),
+		m.reconcile(),
+		m.pollReminder(),
+		m.pollBudget(),
+		m.pollQueue(),
+		m.pollTemplate(),
+		m.refreshTemplate(),
+		m.loadGitStatus(),
+		m.pollGitStatus(),
+		m.pollHealth(),
+		m.checkDisk(),
+		m.checkInbox(),
+		m.deliverDue(),
+		m.pollDelivery(),
+		m.pollEscalation(),
+		m.pollBackup(),
+		m.pollIdleNotify(),
+		m.pollExits(),
+		m.pollOutputCapture(),
+		m.waitForProgress(),
 	)
 }
 
+// reconcile returns a command that syncs the store with tmux off the startup path.
+func (m Model) reconcile() tea.Cmd {
+	return func() tea.Msg {
+		if m.agentService == nil || m.readOnly {
+			return ReconcileDoneMsg{Report: &domain.ReconcileReport{}}
+		}
+		report, err := m.agentService.Reconcile()
+		return ReconcileDoneMsg{Report: report, Err: err}
+	}
+}
+
+// reconcileSummary describes a reconcile report for a toast, or "" if nothing changed.
+func reconcileSummary(report *domain.ReconcileReport) string {
+	if report == nil || report.Empty() {
+		return ""
+	}
+	var parts []string
+	if n := len(report.Terminated); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d stopped agent(s) cleaned up", n))
+	}
+	if n := len(report.Restarted); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d crashed agent(s) restarted", n))
+	}
+	if n := len(report.Exited); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d agent(s) exited", n))
+	}
+	if n := len(report.KilledSessions); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d orphaned session(s) killed", n))
+	}
+	if n := len(report.MissingWorktrees); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d worktree(s) missing - run 'craizy doctor'", n))
+	}
+	return "Reconciled: " + strings.Join(parts, ", ")
+}
+
+// fillPool returns a command that tops up the warm agent pool in the background.
+func (m Model) fillPool() tea.Cmd {
+	return func() tea.Msg {
+		if m.agentService != nil && !m.readOnly {
+			_ = m.agentService.FillPool()
+		}
+		return nil
+	}
+}
+
 // refreshAgents returns a command that sends an AgentsUpdatedMsg with current agents.
 func (m Model) refreshAgents() tea.Cmd {
 	return func() tea.Msg {
 		if m.agentService == nil {
 			return AgentsUpdatedMsg{Agents: []*domain.Agent{}}
 		}
-		return AgentsUpdatedMsg{Agents: m.agentService.List()}
+		msg := AgentsUpdatedMsg{Agents: m.agentService.List()}
+		if m.sideMenu.AttentionSort() {
+			msg.Attention = m.agentService.Attention()
+		}
+		return msg
 	}
 }
 
+// pollAttention returns a command that ticks the attention refresh loop identified by seq.
+func (m Model) pollAttention(seq int) tea.Cmd {
+	return tea.Tick(AttentionRefreshInterval, func(time.Time) tea.Msg {
+		return attentionTickMsg{seq: seq}
+	})
+}
+
 // pollPreview returns a command that ticks for preview polling.
 func (m Model) pollPreview() tea.Cmd {
-	return tea.Tick(PreviewPollInterval, func(t time.Time) tea.Msg {
+	return tea.Tick(m.previewInterval(), func(t time.Time) tea.Msg {
 		return PreviewTickMsg(t)
 	})
 }
@@ -74,17 +203,28 @@ func (m Model) capturePreview() tea.Cmd {
 		return nil
 	}
 	sessionID := agent.ID
-	lines := m.contentArea.AvailableLines()
+	lines := m.previewLines(sessionID)
 	return func() tea.Msg {
 		content, _ := m.agentService.CaptureOutput(sessionID, lines)
-		return PreviewUpdatedMsg{SessionID: sessionID, Content: content}
+		return PreviewUpdatedMsg{SessionID: sessionID, Content: content, Lines: lines}
 	}
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	if _, ok := msg.(tea.KeyMsg); ok {
+		m.noteInteraction()
+	}
+
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// Handled ahead of any open modal, which layout resizes too
+		m.width = msg.Width
+		m.height = msg.Height
+		m.layout()
+		return m, nil
+
 	case PreviewTickMsg:
 		// Skip capture if ported into a session, but continue polling
 		if m.isPortedIn {
@@ -94,12 +234,194 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, tea.Batch(m.capturePreview(), m.pollPreview())
 
 	case PreviewUpdatedMsg:
+		// A shallow capture taken before scrolling began would cut the history short
+		if m.contentArea.Scrolling() && msg.Lines < ScrollbackLines {
+			return m, nil
+		}
 		// Update content area with new preview
-		m.contentArea.SetPreview(msg.Content)
+		m.showPreview(msg.SessionID, msg.Content)
+		return m, nil
+
+	case ReconcileDoneMsg:
+		m.sideMenu.SetLoading(false)
+		// The pool is filled only after reconcile so new pool sessions aren't mistaken for orphans
+		cmds = append(cmds, m.refreshAgents(), m.fillPool())
+		if msg.Err != nil {
+			cmds = append(cmds, m.toast.Show("Reconcile failed: "+msg.Err.Error()))
+		} else if text := reconcileSummary(msg.Report); text != "" {
+			cmds = append(cmds, m.toast.Show(text))
+		}
+		return m, tea.Batch(cmds...)
+
+	case reminderTickMsg:
+		if m.reminderDue(time.Now()) {
+			m.reminded = true
+			return m, tea.Batch(m.remind(), m.pollReminder())
+		}
+		return m, m.pollReminder()
+
+	case healthTickMsg:
+		m.degraded = m.agentService.Degraded()
+		return m, m.pollHealth()
+
+	case queueTickMsg:
+		return m, tea.Batch(m.startQueued(), m.pollQueue())
+
+	case templateTickMsg:
+		return m, tea.Batch(m.refreshTemplate(), m.pollTemplate())
+
+	case gitStatusTickMsg:
+		// Skip while ported into a session, like the preview
+		if m.isPortedIn {
+			return m, m.pollGitStatus()
+		}
+		return m, tea.Batch(m.loadGitStatus(), m.pollGitStatus())
+
+	case GitStatusLoadedMsg:
+		m.sideMenu, _ = m.sideMenu.Update(msg)
+		return m, nil
+
+	case BranchDiffLoadedMsg:
+		return m, m.showBranchDiff(msg)
+
+	case QueuedStartedMsg:
+		if len(msg.Agents) == 0 {
+			return m, nil
+		}
+		return m, tea.Batch(m.refreshAgents(), m.toast.Show(queuedStartedToast(msg.Agents)))
+
+	case budgetTickMsg:
+		return m, tea.Batch(m.enforceBudget(), m.pollBudget())
+
+	case BudgetEnforcedMsg:
+		if len(msg.Stops) == 0 {
+			return m, nil
+		}
+		return m, tea.Batch(m.refreshAgents(), m.toast.Show(budgetToast(msg.Stops)))
+
+	case diskTickMsg:
+		return m, m.checkDisk()
+
+	case DiskCheckedMsg:
+		m.diskWarning = msg.Err == nil && msg.Usage.OverThreshold() && msg.Usage.Reclaimable() > 0
+		m.quickCommands.SetCleanup(m.diskWarning)
+		if msg.Err == nil && msg.Usage.OverThreshold() {
+			return m, tea.Batch(m.toast.Show(diskToast(msg.Usage)), m.pollDisk())
+		}
+		return m, m.pollDisk()
+
+	case inboxTickMsg:
+		return m, m.checkInbox()
+
+	case deliveryTickMsg:
+		return m, tea.Batch(m.deliverDue(), m.pollDelivery())
+
+	case escalationTickMsg:
+		return m, tea.Batch(m.escalateUnanswered(), m.pollEscalation())
+
+	case backupTickMsg:
+		return m, tea.Batch(m.backupIfDue(), m.pollBackup())
+
+	case idleNotifyTickMsg:
+		return m, tea.Batch(m.checkIdleNotify(), m.pollIdleNotify())
+
+	case IdleCheckedMsg:
+		return m, m.updateIdleNotify(msg)
+
+	case exitTickMsg:
+		return m, tea.Batch(m.detectExits(), m.pollExits())
+
+	case outputCaptureTickMsg:
+		return m, tea.Batch(m.captureOutputMessages(), m.pollOutputCapture())
+
+	case ExitsCheckedMsg:
+		return m, m.updateExits(msg)
+
+	case NotifyRulesRequestMsg:
+		if m.readOnly {
+			return m, m.readOnlyNotice("change notifications")
+		}
+		return m, m.saveNotifyRules(msg)
+
+	case NotifyRulesSavedMsg:
+		if msg.Err != nil {
+			return m, m.toast.Show("Failed to save notifications: " + msg.Err.Error())
+		}
+		return m, m.refreshAgents()
+
+	case ScheduledDeliveredMsg:
+		// The next inbox check picks up the badges of delivered messages
+		return m, nil
+
+	case InboxCheckedMsg:
+		return m, tea.Batch(m.updateInbox(msg), m.pollInbox())
+
+	case WorktreesCleanedMsg:
+		m.diskWarning = false
+		m.quickCommands.SetCleanup(false)
+		return m, m.toast.Show(cleanedToast(msg))
+
+	case attentionTickMsg:
+		if msg.seq != m.attentionSeq || !m.sideMenu.AttentionSort() {
+			return m, nil
+		}
+		return m, tea.Batch(m.refreshAgents(), m.pollAttention(msg.seq))
+
+	case ProgressMsg:
+		cmds = append(cmds, m.progress.Track(msg.Progress), m.waitForProgress())
+		// Agents created in the background appear or disappear once done
+		if msg.Progress.Done && msg.Progress.Operation == domain.OperationCreate {
+			cmds = append(cmds, m.refreshAgents())
+			if msg.Progress.Err != nil {
+				cmds = append(cmds, m.toast.Show("Create failed: "+msg.Progress.Err.Error()))
+			}
+		}
+		return m, tea.Batch(cmds...)
+
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.progress, cmd = m.progress.Update(msg)
+		return m, cmd
+
+	case ShowToastMsg:
+		return m, m.toast.Show(msg.Text)
+
+	case toastExpiredMsg:
+		m.toast.Expire(msg.seq)
+		return m, nil
+
+	case CopyRequestMsg:
+		m.modal.Close()
+		return m, copyToClipboard(msg.Label, msg.Text)
+
+	case CopyResultMsg:
+		if msg.Err != nil {
+			return m, m.toast.Show("Copy failed: " + msg.Err.Error())
+		}
+		text := msg.Text
+		if strings.Contains(text, "\n") || len(text) > 40 {
+			text = fmt.Sprintf("%d characters", len(text))
+		}
+		return m, m.toast.Show(fmt.Sprintf("Copied %s: %s", msg.Label, text))
+
+	case OpenFinishedMsg:
+		if msg.Err != nil {
+			return m, m.toast.Show("Open failed: " + msg.Err.Error())
+		}
 		return m, nil
 
 	case CloseModalMsg:
-		_ = msg // Suppress unused variable error
+		// Ask before throwing away anything typed into the modal
+		if input, ok := m.modal.Top().(unsavedInput); ok && input.HasUnsavedInput() {
+			m.modal.Open(NewDiscardConfirmModal(m.width, m.height))
+			return m, nil
+		}
+		m.modal.Close()
+		return m, nil
+
+	case DiscardConfirmedMsg:
+		// Close the confirmation and the modal it was guarding
+		m.modal.Close()
 		m.modal.Close()
 		return m, nil
 
@@ -110,16 +432,135 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if m.agentService != nil {
 			discardChanges := msg.Choice == KillConfirmDiscard
-			_ = m.agentService.ForceKill(msg.SessionID, discardChanges)
+			if err := m.agentService.ForceKill(msg.SessionID, discardChanges); err != nil {
+				return m, m.toast.Show("Kill failed: " + err.Error())
+			}
 		}
 		return m, m.refreshAgents()
 
 	case MergeResultMsg:
+		if errors.Is(msg.ConflictErr, domain.ErrCommitSigning) {
+			m.modal.Open(NewErrorModal("Merge Failed", msg.ConflictErr, m.width, m.height))
+			return m, nil
+		}
 		// Show merge result modal
 		modal := NewMergeResultModal(msg.AgentName, msg.AgentID, msg.Success, msg.Stashed, msg.ConflictErr, msg.ConflictFiles, msg.BaseBranch, m.width, m.height)
 		m.modal.Open(modal)
+		if msg.Success {
+			// The merge moved the branch the template tracks
+			return m, m.refreshTemplate()
+		}
+		return m, nil
+
+	case MergeProtectionCheckedMsg:
+		if msg.Protected {
+			modal := NewProtectedBranchModal(msg.AgentID, msg.AgentName, msg.BaseBranch, m.width, m.height)
+			m.modal.Open(modal)
+			return m, nil
+		}
+		return m, m.loadMergePreview(msg.AgentID, msg.AgentName)
+
+	case ProtectedMergeResultMsg:
+		m.modal.Close()
+		switch msg.Choice {
+		case ProtectedMergePullRequest:
+			return m, m.openPullRequest(msg.AgentID, msg.AgentName)
+		case ProtectedMergeLocal:
+			return m, m.loadMergePreview(msg.AgentID, msg.AgentName)
+		}
+		return m, nil
+
+	case MergePreviewLoadedMsg:
+		if msg.Err != nil {
+			m.modal.Open(NewErrorModal("Merge Failed", msg.Err, m.width, m.height))
+			return m, nil
+		}
+		m.modal.Open(NewMergeConfirmModal(msg.AgentID, msg.AgentName, msg.Preview, m.width, m.height))
 		return m, nil
 
+	case MergeConfirmedMsg:
+		m.modal.Close()
+		return m, m.mergeAgent(msg.AgentID, msg.AgentName, msg.Strategy)
+
+	case PullRequestResultMsg:
+		if msg.Err != nil {
+			m.modal.Open(NewErrorModal("Pull Request Failed", msg.Err, m.width, m.height))
+			return m, nil
+		}
+		m.modal.Open(NewInfoModal("Pull Request Opened", msg.AgentName+"\n"+msg.URL, m.width, m.height))
+		return m, nil
+
+	case OpenRetargetMsg:
+		if m.readOnly {
+			return m, m.readOnlyNotice("retarget")
+		}
+		m.modal.Open(NewRetargetModal(msg.Agent, m.width, m.height))
+		return m, nil
+
+	case OpenCommitLogMsg:
+		m.modal.Open(NewCommitLogModal(msg.Agent, m.width, m.height))
+		if m.agentService == nil {
+			return m, nil
+		}
+		agentID := msg.Agent.ID
+		return m, func() tea.Msg {
+			commits, err := m.agentService.Commits(agentID)
+			return CommitLogLoadedMsg{Commits: commits, Err: err}
+		}
+
+	case CommitDiffRequestMsg:
+		if m.agentService == nil {
+			return m, nil
+		}
+		return m, func() tea.Msg {
+			diff, err := m.agentService.CommitDiff(msg.AgentID, msg.Hash)
+			return CommitDiffLoadedMsg{Hash: msg.Hash, Diff: diff, Err: err}
+		}
+
+	case RetargetConfirmedMsg:
+		m.modal.CloseAll()
+		if m.agentService == nil {
+			return m, nil
+		}
+		return m, func() tea.Msg {
+			err := m.agentService.Retarget(msg.AgentID, msg.NewBase)
+			return RetargetResultMsg{AgentName: msg.AgentName, NewBase: msg.NewBase, Err: err}
+		}
+
+	case RetargetResultMsg:
+		if msg.Err != nil {
+			m.modal.Open(NewErrorModal("Retarget Failed", msg.Err, m.width, m.height))
+			return m, nil
+		}
+		m.modal.Open(NewInfoModal("Agent Retargeted", msg.AgentName+" now targets "+msg.NewBase, m.width, m.height))
+		return m, m.refreshAgents()
+
+	case CommitWorkConfirmedMsg:
+		m.modal.CloseAll()
+		if m.agentService == nil {
+			return m, nil
+		}
+		return m, func() tea.Msg {
+			err := m.agentService.CommitAgentWork(msg.AgentID, msg.Message)
+			return CommitWorkResultMsg{AgentName: msg.AgentName, Err: err}
+		}
+
+	case RetryDoneMsg:
+		if msg.Err != nil {
+			return m, m.toast.Show("Retry failed: " + msg.Err.Error())
+		}
+		return m, tea.Batch(m.toast.Show("Retried "+msg.AgentName), m.refreshAgents())
+
+	case CommitWorkResultMsg:
+		switch {
+		case errors.Is(msg.Err, domain.ErrNothingToCommit):
+			return m, m.toast.Show(msg.AgentName + " has nothing to commit")
+		case msg.Err != nil:
+			m.modal.Open(NewErrorModal("Commit Failed", msg.Err, m.width, m.height))
+			return m, nil
+		}
+		return m, tea.Batch(m.toast.Show("Committed "+msg.AgentName+"'s work"), m.loadGitStatus())
+
 	case MergeConflictResultMsg:
 		// Close the modal first
 		m.modal.Close()
@@ -136,23 +577,75 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case StashActionMsg:
+		if m.readOnly {
+			return m, m.readOnlyNotice("stash changes")
+		}
+		return m, m.runStashAction(msg)
+
+	case StashActionDoneMsg:
+		if msg.Err != nil {
+			return m, tea.Batch(m.toast.Show("Stash failed: "+msg.Err.Error()), m.loadStashes())
+		}
+		return m, tea.Batch(m.toast.Show(msg.Result), m.loadStashes())
+
+	case MessageOpenedMsg:
+		if m.readOnly || m.messageService == nil {
+			return m, nil
+		}
+		_ = m.messageService.MarkRead(msg.MessageID)
+		return m, m.checkInbox()
+
+	case InboxPageRequestMsg:
+		return m, m.loadInboxPage(msg)
+
+	case OpenFileRefMsg:
+		return m, m.openFileRef(msg.From, msg.Ref)
+
+	case OpenURLMsg:
+		return m, m.openURL(msg.URL)
+
+	case ReplyRequestMsg:
+		if m.readOnly {
+			return m, m.readOnlyNotice("reply")
+		}
+		editor := NewReplyEditor(msg.Message, m.width, m.height)
+		m.modal.Open(editor)
+		return m, editor.Init()
+
+	case ReplySubmittedMsg:
+		m.modal.Close()
+		return m, m.sendReply(msg)
+
+	case ReplySentMsg:
+		if msg.Err != nil {
+			return m, m.toast.Show("Reply failed: " + msg.Err.Error())
+		}
+		return m, tea.Batch(m.toast.Show(fmt.Sprintf("Reply %s sent to %s", msg.Message.Code(), msg.Message.To)), m.checkInbox())
+
 	case AgentSelectedMsg:
 		// Transition to name input step
-		nameInput := NewNameInput(msg.Agent, m.width, m.height)
+		var prompts []config.Prompt
+		if workDir, err := os.Getwd(); err == nil {
+			prompts, _ = config.LoadPrompts(config.PromptsDirPath(workDir))
+		}
+		nameInput := NewNameInput(msg.Agent, prompts, m.width, m.height)
 		m.modal.Open(nameInput)
 		return m, nil
 
 	case AgentCreatedMsg:
-		m.modal.Close()
-		// Create the agent using the service
-		if m.agentService != nil {
-			_, err := m.agentService.Create(msg.Agent.Name, msg.CustomName, msg.Agent.Command)
-			if err != nil {
-				// TODO: Show error to user
-				return m, nil
-			}
+		m.modal.CloseAll()
+		return m, m.createAgent(msg)
+
+	case AgentCreateDoneMsg:
+		cmds = append(cmds, m.refreshAgents(), m.fillPool())
+		switch {
+		case msg.Err != nil:
+			cmds = append(cmds, m.toast.Show("Create failed: "+msg.Err.Error()))
+		case msg.Agent != nil && msg.Agent.Status == domain.AgentStatusPending:
+			cmds = append(cmds, m.toast.Show(msg.Agent.Name+" queued: waiting for a provider slot"))
 		}
-		return m, m.refreshAgents()
+		return m, tea.Batch(cmds...)
 
 	case AgentsUpdatedMsg:
 		// Update the side menu with new agents
@@ -160,19 +653,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.sideMenu, cmd = m.sideMenu.Update(msg)
 		cmds = append(cmds, cmd)
 		// Update quick commands based on selection state
-		m.quickCommands.SetAgentSelected(m.sideMenu.HasAgents())
+		m.quickCommands.SetSelectedAgent(m.sideMenu.SelectedAgent())
+		m.titleActive = activeAgents(msg.Agents)
+		cmds = append(cmds, m.updateTitle())
 
 		// Start polling if agents exist, clear preview if none
 		if len(msg.Agents) > 0 {
 			cmds = append(cmds, m.capturePreview(), m.pollPreview())
 		} else {
+			m.contentArea.CloseDiff()
+			m.contentArea.StopScroll()
 			m.contentArea.SetPreview("")
+			m.contentArea.SetBorderColor("")
 		}
 		return m, tea.Batch(cmds...)
 
 	case domain.AgentDetachedMsg:
-		// Returned from tmux session, resume normal operation
+		// Returned from tmux session, resume normal operation; the preview
+		// captures deeper to flag what agents printed meanwhile
 		m.isPortedIn = false
+		m.noteInteraction()
 		return m, tea.Batch(m.refreshAgents(), m.capturePreview(), m.pollPreview())
 	}
 
@@ -183,36 +683,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 
 	switch msg := msg.(type) {
-	case tea.WindowSizeMsg:
-		m.width = msg.Width
-		m.height = msg.Height
-		m.modal.SetSize(m.width, m.height)
-
-		// Calculate dimensions
-		bottomHeight := 5 // 3 lines text + 2 border
-		mainHeight := m.height - bottomHeight
-		if mainHeight < 0 {
-			mainHeight = 0
-		}
-
-		sideWidth := int(float64(m.width) * 0.25)
-		contentWidth := m.width - sideWidth
-
-		m.sideMenu.SetSize(sideWidth, mainHeight)
-		m.contentArea.SetSize(contentWidth, mainHeight)
-		m.quickCommands.SetSize(m.width, 3)
-
 	case tea.KeyMsg:
 		// Don't process keys if modal is open
 		if m.modal.IsOpen() {
 			break
 		}
+		if m.contentArea.DiffOpen() {
+			return m, m.updateDiff(msg)
+		}
+		if m.contentArea.Scrolling() {
+			return m, m.updateScroll(msg)
+		}
 
 		switch msg.String() {
 		case "q", "ctrl+c":
 			return m, tea.Quit
 
 		case "n":
+			if m.readOnly {
+				return m, m.readOnlyNotice("new agent")
+			}
 			// Load agents from .craizy/AGENTS.yml
 			workDir, err := os.Getwd()
 			if err == nil {
@@ -227,16 +717,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "enter":
 			// Attach to selected agent
 			if agent := m.sideMenu.SelectedAgent(); agent != nil && m.agentService != nil {
+				if agent.Status == domain.AgentStatusStarting {
+					return m, m.toast.Show(agent.Name + " is still starting")
+				}
 				m.isPortedIn = true
+				m.startCatchUp(agent.ID)
+				if m.readOnly {
+					return m, m.agentService.AttachReadOnly(agent.ID)
+				}
 				return m, m.agentService.Attach(agent.ID)
 			}
 
 		case "k":
 			// Kill selected agent
+			if m.readOnly {
+				return m, m.readOnlyNotice("kill")
+			}
 			if agent := m.sideMenu.SelectedAgent(); agent != nil && m.agentService != nil {
-				// Check for uncommitted changes
+				// Check for uncommitted changes, asking whenever they can't be ruled out
 				hasUncommitted, err := m.agentService.CheckKill(agent.ID)
-				if err == nil && hasUncommitted {
+				if err != nil || hasUncommitted {
 					// Show confirmation modal
 					modal := NewKillConfirmModal(agent.ID, agent.Name, m.width, m.height)
 					m.modal.Open(modal)
@@ -247,41 +747,139 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, m.refreshAgents()
 			}
 
-		case "m":
-			// Merge selected agent's branch
+		case "p":
+			// Pause or resume the selected agent's processes
+			if m.readOnly {
+				return m, m.readOnlyNotice("pause")
+			}
 			if agent := m.sideMenu.SelectedAgent(); agent != nil && m.agentService != nil {
-				agentName := agent.Name
-				agentID := agent.ID
-				return m, func() tea.Msg {
-					result, err := m.agentService.MergeAgent(agentID)
-					if err != nil {
-						return MergeResultMsg{
-							AgentName:   agentName,
-							AgentID:     agentID,
-							Success:     false,
-							ConflictErr: err,
-						}
-					}
-					return MergeResultMsg{
-						AgentName:     agentName,
-						AgentID:       result.AgentID,
-						Success:       result.Success,
-						Stashed:       result.Stashed,
-						ConflictErr:   result.ConflictErr,
-						ConflictFiles: result.ConflictFiles,
-						BaseBranch:    result.BaseBranch,
-					}
+				return m, m.togglePause(agent)
+			}
+
+		case "i":
+			// Show details for selected agent
+			if agent := m.sideMenu.SelectedAgent(); agent != nil && m.agentService != nil {
+				merges, _ := m.agentService.MergeHistory(agent.ID)
+				modal := NewAgentDetailModal(agent, merges, m.width, m.height)
+				if bytes, ok := m.agentService.CachedWorktreeSize(agent); ok {
+					modal.disk = domain.FormatBytes(bytes)
+				}
+				m.modal.Open(modal)
+				return m, tea.Batch(m.measureWorktree(agent), m.probeStack(agent))
+			}
+
+		case "c":
+			// Remove stopped agents' worktrees when over the disk threshold
+			if m.readOnly {
+				return m, m.readOnlyNotice("clean up")
+			}
+			if m.diskWarning {
+				m.diskWarning = false
+				return m, tea.Batch(m.toast.Show("Cleaning up stopped agents' worktrees..."), m.cleanupWorktrees())
+			}
+
+		case "s":
+			// Toggle sorting agents that need human action first
+			m.sideMenu.SetAttentionSort(!m.sideMenu.AttentionSort())
+			if !m.sideMenu.AttentionSort() {
+				return m, m.refreshAgents()
+			}
+			m.attentionSeq++
+			return m, tea.Batch(m.refreshAgents(), m.pollAttention(m.attentionSeq))
+
+		case "o":
+			// Open the selected agent's worktree in the editor
+			if agent := m.sideMenu.SelectedAgent(); agent != nil && agent.WorkDir != "" {
+				return m, m.openWorktree(agent.WorkDir)
+			}
+
+		case "l":
+			// List the selected agent's branch commits
+			if agent := m.sideMenu.SelectedAgent(); agent != nil && agent.Branch != "" {
+				return m.Update(OpenCommitLogMsg{Agent: agent})
+			}
+
+		case "d":
+			// Show the selected agent's branch diff in place of the preview
+			if agent := m.sideMenu.SelectedAgent(); agent != nil && agent.Branch != "" {
+				return m, m.loadBranchDiff(agent)
+			}
+
+		case "y":
+			// Copy the selected agent's branch, worktree, session ID or preview
+			if agent := m.sideMenu.SelectedAgent(); agent != nil {
+				m.modal.Open(NewCopyMenuModal(agent, m.contentArea.previewContent, m.width, m.height))
+				return m, nil
+			}
+
+		case "u":
+			// Open the human's inbox to read and reply to messages
+			if m.messageService != nil {
+				messages, err := m.messageService.List(m.human, 0, inboxListLimit)
+				if err != nil {
+					return m, m.toast.Show("Inbox failed: " + err.Error())
 				}
+				m.modal.Open(NewInboxModal(messages, m.width, m.height))
+				return m, nil
+			}
+
+		case "f":
+			// Toggle focus mode on the selected agent
+			return m, m.toggleFocus()
+
+		case "pgup":
+			// Scroll back through the selected agent's output
+			return m, m.startScroll()
+
+		case "esc":
+			if m.focus {
+				return m, m.toggleFocus()
+			}
+
+		case "z":
+			// Browse the stashes crAIzy made before merges and kills
+			m.modal.Open(NewStashModal(m.width, m.height))
+			return m, m.loadStashes()
+
+		case "r":
+			// Retry an exited agent in a fresh session that picks up its work
+			if m.readOnly {
+				return m, m.readOnlyNotice("retry")
+			}
+			if agent := m.sideMenu.SelectedAgent(); agent != nil && agent.Status == domain.AgentStatusExited && m.agentService != nil {
+				return m, m.retryAgent(agent)
+			}
+
+		case "g":
+			// Commit the selected agent's uncommitted work on its branch
+			if m.readOnly {
+				return m, m.readOnlyNotice("commit")
+			}
+			if agent := m.sideMenu.SelectedAgent(); agent != nil && agent.Branch != "" && m.agentService != nil {
+				m.modal.Open(NewCommitWorkModal(agent, m.width, m.height))
+				return m, nil
+			}
+
+		case "m":
+			// Merge selected agent's branch, checking base branch protection first
+			if m.readOnly {
+				return m, m.readOnlyNotice("merge")
+			}
+			if agent := m.sideMenu.SelectedAgent(); agent != nil && m.agentService != nil {
+				return m, m.checkMergeProtection(agent)
 			}
 		}
 
 		// Forward arrow key navigation to side menu
 		if msg.String() == "up" || msg.String() == "down" {
+			if agent := m.sideMenu.SelectedAgent(); agent != nil {
+				m.catchUpDone(agent.ID)
+			}
 			var cmd tea.Cmd
 			m.sideMenu, cmd = m.sideMenu.Update(msg)
 			cmds = append(cmds, cmd)
 			// Update quick commands after navigation
-			m.quickCommands.SetAgentSelected(m.sideMenu.SelectedAgent() != nil)
+			m.quickCommands.SetSelectedAgent(m.sideMenu.SelectedAgent())
 			// Immediately capture preview for new selection
 			cmds = append(cmds, m.capturePreview())
 		}
@@ -295,10 +893,35 @@ func (m Model) View() string {
 		return "Loading..."
 	}
 
+	if m.tooSmall() {
+		return m.tooSmallView()
+	}
+
+	if m.modal.IsOpen() {
+		return m.withKeyBar(m.modal.View(), m.modal.KeyHints())
+	}
+
+	if m.linear {
+		return m.linearView()
+	}
+
+	if m.focus {
+		return m.withContentKeys(m.focusView())
+	}
+
 	// Render sections
 	sideView := m.sideMenu.View()
 	contentView := m.contentArea.View()
 	quickCommandsView := m.quickCommands.View()
+	if len(m.degraded) > 0 {
+		quickCommandsView = m.degradedBanner()
+	}
+	if m.progress.Active() {
+		quickCommandsView = m.progress.View()
+	}
+	if m.toast.Visible() {
+		quickCommandsView = m.toast.View()
+	}
 
 	// Join layout
 	// Top section: Side Menu + Content
@@ -307,17 +930,93 @@ func (m Model) View() string {
 	// Full layout: Top Section + Quick Commands
 	baseView := lipgloss.JoinVertical(lipgloss.Left, topSection, quickCommandsView)
 
-	if m.modal.IsOpen() {
-		return m.modal.View()
-	}
-
 	// Use lipgloss.Place to ensure the view fills the entire terminal,
 	// preventing previous terminal output from bleeding through.
-	return lipgloss.Place(
+	return m.withContentKeys(lipgloss.Place(
 		m.width, m.height,
 		lipgloss.Left, lipgloss.Top,
 		baseView,
-	)
+	))
+}
+
+// withKeyBar replaces the last line of a full-screen view with a bar of keys.
+func (m Model) withKeyBar(view string, bindings []keyBinding) string {
+	if len(bindings) == 0 {
+		return view
+	}
+	lines := strings.Split(view, "\n")
+	lines[len(lines)-1] = m.quickCommands.KeyBar(bindings)
+	return strings.Join(lines, "\n")
+}
+
+// checkMergeProtection returns a command that checks whether the agent's base branch is protected.
+func (m Model) checkMergeProtection(agent *domain.Agent) tea.Cmd {
+	agentID := agent.ID
+	agentName := agent.Name
+	baseBranch := agent.BaseBranch
+	return func() tea.Msg {
+		protected, _ := m.agentService.IsBaseProtected(agentID)
+		return MergeProtectionCheckedMsg{
+			AgentID:    agentID,
+			AgentName:  agentName,
+			BaseBranch: baseBranch,
+			Protected:  protected,
+		}
+	}
+}
+
+// createAgent returns a command that starts creating the agent. Its worktree
+// is checked out in the background, since that can take a while on a large
+// repo, and the agent is listed as starting until then.
+func (m Model) createAgent(msg AgentCreatedMsg) tea.Cmd {
+	return func() tea.Msg {
+		if m.agentService == nil {
+			return AgentCreateDoneMsg{}
+		}
+		opts := domain.CreateOptions{SparsePaths: msg.SparsePaths, Prompt: msg.Prompt, Env: msg.Agent.Env, Dir: msg.Agent.WorkDir}
+		agent, err := m.agentService.StartCreate(msg.Agent.Name, msg.CustomName, msg.Agent.Command, opts)
+		return AgentCreateDoneMsg{Agent: agent, Err: err}
+	}
+}
+
+// loadMergePreview returns a command that loads what merging the agent's branch would land.
+func (m Model) loadMergePreview(agentID, agentName string) tea.Cmd {
+	return func() tea.Msg {
+		preview, err := m.agentService.MergePreview(agentID)
+		return MergePreviewLoadedMsg{AgentID: agentID, AgentName: agentName, Preview: preview, Err: err}
+	}
+}
+
+// mergeAgent returns a command that merges the agent's branch locally with strategy.
+func (m Model) mergeAgent(agentID, agentName string, strategy domain.MergeStrategy) tea.Cmd {
+	return func() tea.Msg {
+		result, err := m.agentService.MergeAgentWithStrategy(agentID, strategy)
+		if err != nil {
+			return MergeResultMsg{
+				AgentName:   agentName,
+				AgentID:     agentID,
+				Success:     false,
+				ConflictErr: err,
+			}
+		}
+		return MergeResultMsg{
+			AgentName:     agentName,
+			AgentID:       result.AgentID,
+			Success:       result.Success,
+			Stashed:       result.Stashed,
+			ConflictErr:   result.ConflictErr,
+			ConflictFiles: result.ConflictFiles,
+			BaseBranch:    result.BaseBranch,
+		}
+	}
+}
+
+// openPullRequest returns a command that pushes the agent's branch and opens a pull request.
+func (m Model) openPullRequest(agentID, agentName string) tea.Cmd {
+	return func() tea.Msg {
+		url, err := m.agentService.OpenPullRequest(agentID)
+		return PullRequestResultMsg{AgentName: agentName, URL: url, Err: err}
+	}
 }
 
 // buildMergeConflictMessage creates an instructional message for the agent terminal.
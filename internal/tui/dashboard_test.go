@@ -1,11 +1,14 @@
 package tui
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
 	"github.com/TechnicallyShaun/crAIzy/internal/domain"
 )
 
@@ -110,6 +113,74 @@ func TestModel_Update_AgentsUpdatedMsg(t *testing.T) {
 	})
 }
 
+func TestModel_Update_AttentionSort(t *testing.T) {
+	agents := []*domain.Agent{
+		{ID: "busy", Name: "busy", Status: domain.AgentStatusActive},
+		{ID: "asking", Name: "asking", Status: domain.AgentStatusActive},
+	}
+	attention := map[string]domain.Attention{
+		"asking": {Score: 8, Reasons: []domain.AttentionReason{domain.AttentionQuestion}},
+	}
+
+	m := NewModel(nil, nil)
+	m.sideMenu.SetSize(30, 20)
+	newModel, _ := m.Update(AgentsUpdatedMsg{Agents: agents})
+	m = newModel.(Model)
+	if got := m.sideMenu.SelectedAgent().ID; got != "busy" {
+		t.Fatalf("selected %q, want busy", got)
+	}
+
+	newModel, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m = newModel.(Model)
+	if !m.sideMenu.AttentionSort() || cmd == nil {
+		t.Fatal("s should enable the attention sort and refresh")
+	}
+
+	newModel, _ = m.Update(AgentsUpdatedMsg{Agents: agents, Attention: attention})
+	m = newModel.(Model)
+	if got := m.sideMenu.agents[0].ID; got != "asking" {
+		t.Errorf("first agent %q, want asking", got)
+	}
+	if got := m.sideMenu.SelectedAgent().ID; got != "busy" {
+		t.Errorf("selection moved to %q, want it kept on busy", got)
+	}
+	if agents[0].ID != "busy" {
+		t.Error("sorting should not reorder the message's slice")
+	}
+}
+
+func TestModel_Update_GitStatusLoadedMsg(t *testing.T) {
+	agents := []*domain.Agent{
+		{ID: "auth", Name: "auth", AgentType: "claude", Status: domain.AgentStatusActive},
+		{ID: "docs", Name: "docs", AgentType: "codex", Status: domain.AgentStatusActive},
+	}
+
+	m := NewModel(nil, nil)
+	m.sideMenu.SetSize(30, 20)
+	newModel, _ := m.Update(AgentsUpdatedMsg{Agents: agents})
+	m = newModel.(Model)
+	newModel, _ = m.Update(GitStatusLoadedMsg{Statuses: map[string]domain.WorktreeStatus{
+		"auth": {Ahead: 2, Behind: 1, Files: 3, Insertions: 40, Deletions: 3, Dirty: true},
+		"docs": {},
+	}})
+	m = newModel.(Model)
+
+	items := m.sideMenu.list.Items()
+	if got := items[0].(AgentListItem).Description(); got != "● claude • ↑2 ↓1 +40 -3 *" {
+		t.Errorf("auth description = %q", got)
+	}
+	if got := items[1].(AgentListItem).Description(); got != "● codex" {
+		t.Errorf("docs description = %q, want no indicators for a clean worktree", got)
+	}
+
+	// Indicators survive the agent list being refreshed
+	newModel, _ = m.Update(AgentsUpdatedMsg{Agents: agents})
+	m = newModel.(Model)
+	if got := m.sideMenu.list.Items()[0].(AgentListItem).Description(); !strings.Contains(got, "↑2") {
+		t.Errorf("auth description = %q after refresh, want indicators kept", got)
+	}
+}
+
 func TestModel_Update_AgentDetachedMsg(t *testing.T) {
 	t.Run("clears ported in flag", func(t *testing.T) {
 		m := NewModel(nil, nil)
@@ -205,6 +276,41 @@ func TestModel_Update_WindowSizeMsg(t *testing.T) {
 			t.Errorf("content width = %d, want %d", model.contentArea.width, expectedContentWidth)
 		}
 	})
+
+	t.Run("caps the side menu on wide terminals", func(t *testing.T) {
+		newModel, _ := NewModel(nil, nil).Update(tea.WindowSizeMsg{Width: 400, Height: 40})
+
+		model := newModel.(Model)
+		if model.sideMenu.width != MaxSideMenuWidth || model.contentArea.width != 400-MaxSideMenuWidth {
+			t.Errorf("side menu width = %d, content width = %d, want the side menu capped", model.sideMenu.width, model.contentArea.width)
+		}
+	})
+
+	t.Run("resizes open modals", func(t *testing.T) {
+		m := NewModel(nil, nil)
+		m.width, m.height = 100, 40
+		m.layout()
+		m.modal.Open(NewInfoModal("Title", "message", m.width, m.height))
+
+		newModel, _ := m.Update(tea.WindowSizeMsg{Width: 300, Height: 30})
+
+		model := newModel.(Model)
+		if model.width != 300 || model.height != 30 {
+			t.Errorf("size = %dx%d, want 300x30 with a modal open", model.width, model.height)
+		}
+		info := model.modal.Top().(InfoModel)
+		if info.width != MaxModalWidth || info.height != 30 {
+			t.Errorf("modal size = %dx%d, want %dx30", info.width, info.height, MaxModalWidth)
+		}
+	})
+
+	t.Run("asks for a bigger terminal below the minimum", func(t *testing.T) {
+		newModel, _ := NewModel(nil, nil).Update(tea.WindowSizeMsg{Width: MinWidth - 1, Height: 20})
+
+		if view := newModel.View(); !strings.Contains(view, "Terminal too small") {
+			t.Errorf("View() = %q, want the too small screen", view)
+		}
+	})
 }
 
 func TestModel_Update_EnterKey(t *testing.T) {
@@ -294,3 +400,345 @@ func TestModel_View(t *testing.T) {
 		}
 	})
 }
+
+func TestModel_Update_MergeProtectionCheckedMsg(t *testing.T) {
+	t.Run("opens warning modal when protected", func(t *testing.T) {
+		m := NewModel(nil, nil)
+		m.width = 100
+		m.height = 40
+
+		msg := MergeProtectionCheckedMsg{AgentID: "a1", AgentName: "worker", BaseBranch: "main", Protected: true}
+		newModel, _ := m.Update(msg)
+
+		model := newModel.(Model)
+		if !model.modal.IsOpen() {
+			t.Fatal("modal should be open for protected base branch")
+		}
+		if _, ok := model.modal.Top().(ProtectedBranchModel); !ok {
+			t.Errorf("modal content = %T, want ProtectedBranchModel", model.modal.Top())
+		}
+	})
+
+	t.Run("previews the merge when unprotected", func(t *testing.T) {
+		m := NewModel(nil, nil)
+		m.width = 100
+		m.height = 40
+
+		msg := MergeProtectionCheckedMsg{AgentID: "a1", AgentName: "worker", BaseBranch: "main", Protected: false}
+		newModel, cmd := m.Update(msg)
+
+		model := newModel.(Model)
+		if model.modal.IsOpen() {
+			t.Error("modal should not be open for unprotected base branch")
+		}
+		if cmd == nil {
+			t.Error("should return merge preview command")
+		}
+	})
+
+	t.Run("confirms before merging", func(t *testing.T) {
+		m := NewModel(nil, nil)
+		m.width = 100
+		m.height = 40
+
+		preview := &domain.MergePreview{Branch: "craizy/worker", BaseBranch: "main", Strategy: domain.MergeStrategyMerge,
+			Commits: []domain.Commit{{Hash: "abc1234", Subject: "Add token refresh"}}}
+		newModel, _ := m.Update(MergePreviewLoadedMsg{AgentID: "a1", AgentName: "worker", Preview: preview})
+		model := newModel.(Model)
+		if _, ok := model.modal.Top().(MergeConfirmModel); !ok {
+			t.Fatalf("modal content = %T, want MergeConfirmModel", model.modal.Top())
+		}
+		if view := model.modal.View(); !strings.Contains(view, "abc1234") || !strings.Contains(view, "strategy: merge") {
+			t.Errorf("expected the commits and strategy in the preview, got:\n%s", view)
+		}
+
+		newModel, cmd := model.Update(MergeConfirmedMsg{AgentID: "a1", AgentName: "worker"})
+		model = newModel.(Model)
+		if model.modal.IsOpen() || cmd == nil {
+			t.Error("expected confirming to close the preview and merge")
+		}
+	})
+
+	t.Run("switches the strategy", func(t *testing.T) {
+		preview := &domain.MergePreview{Branch: "craizy/worker", BaseBranch: "main", Strategy: domain.MergeStrategyMerge}
+		modal := NewMergeConfirmModal("a1", "worker", preview, 100, 40)
+
+		updated, _ := modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+		modal = updated.(MergeConfirmModel)
+		if !strings.Contains(modal.View(), "strategy: squash") {
+			t.Errorf("expected squash after pressing s, got:\n%s", modal.View())
+		}
+
+		_, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if msg, ok := cmd().(MergeConfirmedMsg); !ok || msg.Strategy != domain.MergeStrategySquash {
+			t.Errorf("confirmed %#v, want a squash merge", cmd())
+		}
+	})
+}
+
+func TestModel_Update_ReconcileDoneMsg(t *testing.T) {
+	t.Run("clears loading and shows toast", func(t *testing.T) {
+		m := NewModel(nil, nil)
+		if !m.sideMenu.loading {
+			t.Fatal("side menu should start loading")
+		}
+
+		report := &domain.ReconcileReport{KilledSessions: []string{"craizy-proj-old"}}
+		newModel, cmd := m.Update(ReconcileDoneMsg{Report: report})
+
+		model := newModel.(Model)
+		if model.sideMenu.loading {
+			t.Error("loading should be cleared after reconcile")
+		}
+		if !model.toast.Visible() {
+			t.Error("toast should be visible when reconcile changed something")
+		}
+		if cmd == nil {
+			t.Error("should return refresh and toast expiry commands")
+		}
+	})
+
+	t.Run("no toast for empty report", func(t *testing.T) {
+		m := NewModel(nil, nil)
+
+		newModel, _ := m.Update(ReconcileDoneMsg{Report: &domain.ReconcileReport{}})
+
+		if newModel.(Model).toast.Visible() {
+			t.Error("toast should not be shown when nothing changed")
+		}
+	})
+}
+
+func TestToastModel_Expire(t *testing.T) {
+	var toast ToastModel
+	toast.Show("first")
+	toast.Show("second")
+
+	// The first toast's expiry must not hide the second
+	toast.Expire(1)
+	if !toast.Visible() {
+		t.Error("stale expiry should not hide newer toast")
+	}
+
+	toast.Expire(2)
+	if toast.Visible() {
+		t.Error("toast should be hidden after its own expiry")
+	}
+}
+
+func TestModel_Update_ReadOnly(t *testing.T) {
+	agent := &domain.Agent{ID: "craizy-proj-claude-auth", Name: "auth", Status: domain.AgentStatusActive}
+
+	for _, key := range []string{"n", "k", "m", "g"} {
+		t.Run("disables "+key, func(t *testing.T) {
+			m := NewModel(nil, nil)
+			m.SetReadOnly(true)
+			m.width = 100
+			m.height = 40
+			updated, _ := m.Update(AgentsUpdatedMsg{Agents: []*domain.Agent{agent}})
+			m = updated.(Model)
+
+			updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+			model := updated.(Model)
+			if model.modal.IsOpen() {
+				t.Error("expected no modal in read-only mode")
+			}
+			if !model.toast.Visible() || model.toast.text == "" {
+				t.Error("expected a toast explaining the action is disabled")
+			}
+		})
+	}
+
+	t.Run("closes retarget", func(t *testing.T) {
+		m := NewModel(nil, nil)
+		m.SetReadOnly(true)
+		updated, _ := m.Update(OpenRetargetMsg{Agent: agent})
+		model := updated.(Model)
+		if model.modal.IsOpen() {
+			t.Error("expected retarget modal not to open in read-only mode")
+		}
+	})
+
+	t.Run("marks disabled hints", func(t *testing.T) {
+		m := NewModel(nil, nil)
+		m.SetReadOnly(true)
+		m.quickCommands.SetSelectedAgent(&domain.Agent{ID: "a", Branch: "craizy/a"})
+		hints := m.quickCommands.Hints()
+		want := map[string]bool{
+			"n - new agent (disabled)":   true,
+			"enter - watch agent":        true,
+			"m - merge agent (disabled)": true,
+			"k - kill agent (disabled)":  true,
+		}
+		for _, hint := range hints {
+			delete(want, hint)
+		}
+		if len(want) > 0 {
+			t.Errorf("missing hints %v in %v", want, hints)
+		}
+	})
+}
+
+func TestModel_Update_CommitWorkResultMsg(t *testing.T) {
+	t.Run("toasts a commit", func(t *testing.T) {
+		m := NewModel(nil, nil)
+		updated, _ := m.Update(CommitWorkResultMsg{AgentName: "auth"})
+		if model := updated.(Model); model.toast.text != "Committed auth's work" {
+			t.Errorf("toast = %q", model.toast.text)
+		}
+	})
+
+	t.Run("toasts a clean worktree", func(t *testing.T) {
+		m := NewModel(nil, nil)
+		updated, _ := m.Update(CommitWorkResultMsg{AgentName: "auth", Err: fmt.Errorf("agent %q: %w", "a", domain.ErrNothingToCommit)})
+		model := updated.(Model)
+		if model.modal.IsOpen() || model.toast.text != "auth has nothing to commit" {
+			t.Errorf("toast = %q, modal open %v", model.toast.text, model.modal.IsOpen())
+		}
+	})
+
+	t.Run("shows failures", func(t *testing.T) {
+		m := NewModel(nil, nil)
+		updated, _ := m.Update(CommitWorkResultMsg{AgentName: "auth", Err: domain.ErrCommitSigning})
+		if model := updated.(Model); !model.modal.IsOpen() {
+			t.Error("expected an error modal")
+		}
+	})
+}
+
+func TestModel_Update_DiskCheckedMsg(t *testing.T) {
+	stopped := &domain.Agent{ID: "stopped", Status: domain.AgentStatusTerminated}
+	usage := &domain.DiskUsage{
+		Worktrees: []domain.WorktreeUsage{{Path: "/w/stopped", Bytes: 3 << 30, Agent: stopped}},
+		Total:     3 << 30,
+		Threshold: 2 << 30,
+	}
+
+	t.Run("warns and offers cleanup over the threshold", func(t *testing.T) {
+		m := NewModel(nil, nil)
+		updated, cmd := m.Update(DiskCheckedMsg{Usage: usage})
+		model := updated.(Model)
+
+		if !model.diskWarning || cmd == nil {
+			t.Fatal("expected a disk warning and follow-up commands")
+		}
+		if !strings.Contains(model.toast.text, "press c to free 3.0 GB") {
+			t.Errorf("unexpected toast %q", model.toast.text)
+		}
+		if hints := strings.Join(model.quickCommands.Hints(), ", "); !strings.Contains(hints, "c - clean worktrees") {
+			t.Errorf("expected cleanup hint, got %q", hints)
+		}
+	})
+
+	t.Run("stays quiet under the threshold", func(t *testing.T) {
+		m := NewModel(nil, nil)
+		under := *usage
+		under.Threshold = 4 << 30
+		updated, _ := m.Update(DiskCheckedMsg{Usage: &under})
+		model := updated.(Model)
+
+		if model.diskWarning || model.toast.Visible() {
+			t.Error("expected no warning under the threshold")
+		}
+	})
+}
+
+func TestQuickCommands_Keymap(t *testing.T) {
+	has := func(m QuickCommandsModel, hint string) bool {
+		for _, h := range m.Hints() {
+			if h == hint {
+				return true
+			}
+		}
+		return false
+	}
+
+	m := NewQuickCommands()
+	if has(m, "i - details") || has(m, "u - inbox") {
+		t.Errorf("expected only global keys without a selection, got %v", m.Hints())
+	}
+
+	m.SetSelectedAgent(&domain.Agent{ID: "a"})
+	if !has(m, "i - details") || has(m, "m - merge agent") || has(m, "l - commits") {
+		t.Errorf("expected no branch keys for an agent without a branch, got %v", m.Hints())
+	}
+
+	m.SetSelectedAgent(&domain.Agent{ID: "a", Branch: "craizy/a"})
+	m.SetUnread(2)
+	if !has(m, "m - merge agent") || !has(m, "l - commits") || !has(m, "u - inbox") {
+		t.Errorf("expected branch and inbox keys, got %v", m.Hints())
+	}
+	if has(m, "r - retry") {
+		t.Errorf("expected no retry for a running agent, got %v", m.Hints())
+	}
+
+	m.SetSelectedAgent(&domain.Agent{ID: "a", Status: domain.AgentStatusExited})
+	if !has(m, "r - retry") {
+		t.Errorf("expected retry for an exited agent, got %v", m.Hints())
+	}
+}
+
+func TestModel_View_ModalKeyBar(t *testing.T) {
+	m := NewModel(nil, nil)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = updated.(Model)
+	m.modal.Open(NewInfoModal("Done", "Merged", m.width, m.height))
+
+	lines := strings.Split(m.View(), "\n")
+	if last := lines[len(lines)-1]; !strings.Contains(last, "enter - close") {
+		t.Errorf("expected the modal's keys on the last line, got %q", last)
+	}
+}
+
+func TestModel_ModalStack(t *testing.T) {
+	m := NewModel(nil, nil)
+	agent := config.Agent{Name: "Claude", Command: "claude"}
+	m.modal.Open(NewAgentSelector([]config.Agent{agent}, 80, 24))
+
+	updated, _ := m.Update(AgentSelectedMsg{Agent: agent})
+	m = updated.(Model)
+	if _, ok := m.modal.Top().(NameInputModel); !ok {
+		t.Fatalf("top modal = %T, want NameInputModel", m.modal.Top())
+	}
+
+	updated, _ = m.Update(CloseModalMsg{})
+	m = updated.(Model)
+	if _, ok := m.modal.Top().(AgentSelectorModel); !ok {
+		t.Fatalf("top modal after esc = %T, want AgentSelectorModel", m.modal.Top())
+	}
+
+	updated, _ = m.Update(AgentSelectedMsg{Agent: agent})
+	m = updated.(Model)
+	updated, _ = m.Update(AgentCreatedMsg{Agent: agent, CustomName: "x"})
+	m = updated.(Model)
+	if m.modal.IsOpen() {
+		t.Errorf("expected the whole create flow to close, top = %T", m.modal.Top())
+	}
+}
+
+func TestModel_UnsavedInputConfirm(t *testing.T) {
+	m := NewModel(nil, nil)
+	editor := NewReplyEditor(&domain.Message{From: "auth", Content: "Which library?"}, 80, 24)
+	editor.editor.SetValue("Use the stdlib")
+	m.modal.Open(editor)
+
+	updated, _ := m.Update(CloseModalMsg{})
+	m = updated.(Model)
+	if _, ok := m.modal.Top().(DiscardConfirmModel); !ok {
+		t.Fatalf("top modal = %T, want DiscardConfirmModel", m.modal.Top())
+	}
+
+	updated, _ = m.Update(CloseModalMsg{})
+	m = updated.(Model)
+	if top, ok := m.modal.Top().(ReplyEditorModel); !ok || top.editor.Value() != "Use the stdlib" {
+		t.Fatalf("expected the reply kept after declining, top = %T", m.modal.Top())
+	}
+
+	updated, _ = m.Update(CloseModalMsg{})
+	m = updated.(Model)
+	updated, _ = m.Update(DiscardConfirmedMsg{})
+	m = updated.(Model)
+	if m.modal.IsOpen() {
+		t.Errorf("expected the editor closed after discarding, top = %T", m.modal.Top())
+	}
+}
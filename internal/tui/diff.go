@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// BranchDiffLoadedMsg carries the diff of an agent's branch against its base.
+type BranchDiffLoadedMsg struct {
+	Agent *domain.Agent
+	Diff  string
+	Err   error
+}
+
+// diffKeys are the keys shown while a branch diff replaces the preview.
+var diffKeys = []keyBinding{
+	{key: "↑/↓", desc: "scroll"},
+	{key: "pgup/pgdn", desc: "page"},
+	{key: "d/esc", desc: "close diff"},
+}
+
+// loadBranchDiff returns a command that diffs the agent's branch against its base.
+func (m Model) loadBranchDiff(agent *domain.Agent) tea.Cmd {
+	if m.agentService == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		diff, err := m.agentService.BranchDiff(agent.ID)
+		return BranchDiffLoadedMsg{Agent: agent, Diff: diff, Err: err}
+	}
+}
+
+// showBranchDiff shows a loaded branch diff in place of the preview.
+func (m *Model) showBranchDiff(msg BranchDiffLoadedMsg) tea.Cmd {
+	switch {
+	case msg.Err != nil:
+		return m.toast.Show("Diff failed: " + msg.Err.Error())
+	case strings.TrimSpace(msg.Diff) == "":
+		return m.toast.Show(msg.Agent.Name + " has no changes on " + msg.Agent.Branch)
+	}
+	m.contentArea.ShowDiff(msg.Agent.Branch+" against "+msg.Agent.BaseBranch, msg.Diff)
+	return nil
+}
+
+// updateDiff handles keys while a branch diff is shown: scrolling it, or
+// closing it to go back to the preview.
+func (m *Model) updateDiff(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c":
+		return tea.Quit
+	case "d", "esc", "q":
+		m.contentArea.CloseDiff()
+		return m.capturePreview()
+	}
+	return m.contentArea.ScrollDiff(msg)
+}
+
+// colorDiff colors a patch's file headers, hunk headers, and added and removed
+// lines, cutting each line to width.
+func colorDiff(diff string, width int) string {
+	lines := strings.Split(strings.TrimRight(diff, "\n"), "\n")
+	for i, line := range lines {
+		line = truncateLine(strings.ReplaceAll(line, "\t", "    "), width)
+		switch {
+		case isDiffHeader(line):
+			line = theme.DiffHeader.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			line = theme.DiffHunk.Render(line)
+		case strings.HasPrefix(line, "+"):
+			line = theme.DiffAdded.Render(line)
+		case strings.HasPrefix(line, "-"):
+			line = theme.DiffRemoved.Render(line)
+		}
+		lines[i] = line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// diffHeaderPrefixes start the lines git writes ahead of each file's hunks.
+var diffHeaderPrefixes = []string{"diff --git ", "index ", "--- ", "+++ ", "new file mode ", "deleted file mode ", "old mode ", "new mode ", "similarity index ", "rename from ", "rename to ", "Binary files "}
+
+// isDiffHeader reports whether line is one of a file's header lines in a patch.
+func isDiffHeader(line string) bool {
+	for _, prefix := range diffHeaderPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
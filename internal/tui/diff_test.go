@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestColorDiff(t *testing.T) {
+	got := colorDiff("diff --git a/f.go b/f.go\n@@ -1 +1 @@\n-\told\n+\tnew line that is too long\n", 16)
+	lines := strings.Split(got, "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want 4: %q", len(lines), got)
+	}
+	if !strings.Contains(lines[2], "-    old") {
+		t.Errorf("tab not expanded: %q", lines[2])
+	}
+	if strings.Contains(lines[3], "too long") {
+		t.Errorf("long line not cut to the width: %q", lines[3])
+	}
+}
+
+func TestIsDiffHeader(t *testing.T) {
+	for line, want := range map[string]bool{
+		"diff --git a/f.go b/f.go": true,
+		"--- a/f.go":               true,
+		"+++ b/f.go":               true,
+		"rename from old.go":       true,
+		"+++counter":               false,
+		"-- removed comment":       false,
+	} {
+		if got := isDiffHeader(line); got != want {
+			t.Errorf("isDiffHeader(%q) = %v, want %v", line, got, want)
+		}
+	}
+}
+
+func TestModel_BranchDiff(t *testing.T) {
+	agent := &domain.Agent{ID: "a", Name: "auth", Branch: "craizy/auth", BaseBranch: "main"}
+	m := NewModel(nil, nil)
+	model, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = model.(Model)
+
+	model, _ = m.Update(BranchDiffLoadedMsg{Agent: agent, Diff: "diff --git a/f b/f\n+added\n"})
+	m = model.(Model)
+	if !m.contentArea.DiffOpen() {
+		t.Fatal("diff not shown")
+	}
+	if view := m.View(); !strings.Contains(view, "craizy/auth against main") || !strings.Contains(view, "d/esc - close diff") {
+		t.Errorf("view missing the diff title or keys:\n%s", view)
+	}
+
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m = model.(Model)
+	if m.contentArea.DiffOpen() {
+		t.Error("d did not close the diff")
+	}
+
+	model, _ = m.Update(BranchDiffLoadedMsg{Agent: agent})
+	m = model.(Model)
+	if m.contentArea.DiffOpen() || !strings.Contains(m.View(), "auth has no changes") {
+		t.Error("empty diff shown rather than noted")
+	}
+}
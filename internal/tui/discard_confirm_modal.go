@@ -0,0 +1,66 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// unsavedInput is implemented by modals holding typed input that closing would lose.
+type unsavedInput interface {
+	HasUnsavedInput() bool
+}
+
+// DiscardConfirmedMsg is sent when the user agrees to discard a modal's unsaved input.
+type DiscardConfirmedMsg struct{}
+
+// DiscardConfirmModel asks before closing a modal with unsaved input.
+type DiscardConfirmModel struct {
+	width  int
+	height int
+}
+
+// NewDiscardConfirmModal creates a new discard confirmation modal.
+func NewDiscardConfirmModal(width, height int) DiscardConfirmModel {
+	return DiscardConfirmModel{width: width, height: height}
+}
+
+func (m DiscardConfirmModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m DiscardConfirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width, m.height = size.Width, size.Height
+		return m, nil
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "y", "enter":
+			return m, func() tea.Msg { return DiscardConfirmedMsg{} }
+		case "n", "esc":
+			return m, func() tea.Msg { return CloseModalMsg{} }
+		}
+	}
+	return m, nil
+}
+
+// KeyHints returns the discard confirmation's keys.
+func (m DiscardConfirmModel) KeyHints() []keyBinding {
+	return []keyBinding{{key: "y", desc: "discard"}, {key: "n", desc: "keep editing"}}
+}
+
+func (m DiscardConfirmModel) View() string {
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		theme.ModalTitle.Render("Discard Changes?"),
+		"",
+		theme.TextNormal.Render("What you typed will be lost."),
+	)
+
+	box := theme.ModalBorder.
+		Padding(1, 3).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// DiskCheckInterval is how often worktree disk usage is compared with the warning threshold.
+const DiskCheckInterval = 10 * time.Minute
+
+// diskTickMsg triggers a disk usage check.
+type diskTickMsg struct{}
+
+// DiskCheckedMsg reports worktree disk usage.
+type DiskCheckedMsg struct {
+	Usage *domain.DiskUsage
+	Err   error
+}
+
+// WorktreesCleanedMsg reports the result of removing stopped agents' worktrees.
+type WorktreesCleanedMsg struct {
+	Freed int64
+	Err   error
+}
+
+// checkDisk returns a command that measures worktree disk usage, if a warning
+// threshold is configured.
+func (m Model) checkDisk() tea.Cmd {
+	if m.agentService == nil || !m.agentService.HasDiskThreshold() {
+		return nil
+	}
+	return func() tea.Msg {
+		usage, err := m.agentService.DiskUsage()
+		return DiskCheckedMsg{Usage: usage, Err: err}
+	}
+}
+
+// pollDisk returns a command that ticks the next disk usage check.
+func (m Model) pollDisk() tea.Cmd {
+	return tea.Tick(DiskCheckInterval, func(time.Time) tea.Msg {
+		return diskTickMsg{}
+	})
+}
+
+// measureWorktree returns a command that measures an agent's worktree for the detail view.
+func (m Model) measureWorktree(agent *domain.Agent) tea.Cmd {
+	if m.agentService == nil || agent.WorkDir == "" {
+		return nil
+	}
+	agentID := agent.ID
+	return func() tea.Msg {
+		bytes, err := m.agentService.WorktreeSize(agentID)
+		return WorktreeSizeMsg{AgentID: agentID, Bytes: bytes, Err: err}
+	}
+}
+
+// cleanupWorktrees returns a command that removes stopped agents' worktrees.
+func (m Model) cleanupWorktrees() tea.Cmd {
+	return func() tea.Msg {
+		freed, err := m.agentService.CleanupWorktrees()
+		return WorktreesCleanedMsg{Freed: freed, Err: err}
+	}
+}
+
+// diskToast warns that worktrees are over the threshold.
+func diskToast(usage *domain.DiskUsage) string {
+	text := fmt.Sprintf("Worktrees use %s (warning at %s)",
+		domain.FormatBytes(usage.Total), domain.FormatBytes(usage.Threshold))
+	if reclaimable := usage.Reclaimable(); reclaimable > 0 {
+		text += fmt.Sprintf(" - press c to free %s from stopped agents", domain.FormatBytes(reclaimable))
+	}
+	return text
+}
+
+// cleanedToast describes the result of a worktree cleanup.
+func cleanedToast(msg WorktreesCleanedMsg) string {
+	if msg.Err != nil {
+		return fmt.Sprintf("Cleanup freed %s but failed: %v", domain.FormatBytes(msg.Freed), msg.Err)
+	}
+	return "Cleanup freed " + domain.FormatBytes(msg.Freed)
+}
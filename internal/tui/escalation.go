@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// EscalationCheckInterval is how often questions between agents are checked
+// for having gone unanswered past the escalation timeout.
+const EscalationCheckInterval = time.Minute
+
+// escalationTickMsg triggers an escalation check.
+type escalationTickMsg struct{}
+
+// pollEscalation returns a command that ticks the escalation check, if
+// escalation is configured. Read-only dashboards leave it to the main dashboard.
+func (m Model) pollEscalation() tea.Cmd {
+	if m.messageService == nil || m.readOnly || !m.messageService.HasEscalation() {
+		return nil
+	}
+	return tea.Tick(EscalationCheckInterval, func(time.Time) tea.Msg {
+		return escalationTickMsg{}
+	})
+}
+
+// escalateUnanswered returns a command that copies unanswered questions to the
+// human. The copies arrive in the inbox, whose next check announces them.
+func (m Model) escalateUnanswered() tea.Cmd {
+	return func() tea.Msg {
+		_, _ = m.messageService.EscalateUnanswered(time.Now())
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// ExitCheckInterval is how often active agents are checked for a dead session.
+const ExitCheckInterval = 5 * time.Second
+
+// exitTickMsg triggers an exit check.
+type exitTickMsg struct{}
+
+// ExitsCheckedMsg reports the agents an exit check found without a session.
+type ExitsCheckedMsg struct {
+	Report *domain.ExitReport
+}
+
+// pollExits returns a command that ticks the exit check. Read-only dashboards
+// leave it to the main dashboard.
+func (m Model) pollExits() tea.Cmd {
+	if m.agentService == nil || m.readOnly {
+		return nil
+	}
+	return tea.Tick(ExitCheckInterval, func(time.Time) tea.Msg {
+		return exitTickMsg{}
+	})
+}
+
+// detectExits returns a command that checks agents for a dead session,
+// relaunching crashed ones.
+func (m Model) detectExits() tea.Cmd {
+	return func() tea.Msg {
+		report, err := m.agentService.DetectExits()
+		if err != nil {
+			return nil
+		}
+		return ExitsCheckedMsg{Report: report}
+	}
+}
+
+// updateExits refreshes the agent list after an exit check found agents
+// without a session, ringing the bell and showing a toast.
+func (m Model) updateExits(msg ExitsCheckedMsg) tea.Cmd {
+	text := exitSummary(msg.Report)
+	if text == "" {
+		return nil
+	}
+	if !m.isPortedIn {
+		_, _ = io.WriteString(bellWriter, "\a")
+	}
+	return tea.Batch(m.refreshAgents(), m.toast.Show(text))
+}
+
+// exitSummary describes an exit report for a toast, or "" if it's empty.
+func exitSummary(report *domain.ExitReport) string {
+	if report == nil || report.Empty() {
+		return ""
+	}
+	var parts []string
+	if len(report.Exited) > 0 {
+		exits := make([]string, len(report.Exited))
+		for i, agent := range report.Exited {
+			code, _ := agent.ExitCode()
+			exits[i] = fmt.Sprintf("%s (%d)", agent.Name, code)
+		}
+		parts = append(parts, "Exited: "+strings.Join(exits, ", "))
+	}
+	if len(report.Restarted) > 0 {
+		parts = append(parts, "Restarted crashed: "+agentNames(report.Restarted))
+	}
+	if len(report.Terminated) > 0 {
+		parts = append(parts, "Session killed: "+agentNames(report.Terminated))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// agentNames joins the names of agents for a toast.
+func agentNames(agents []*domain.Agent) string {
+	names := make([]string, len(agents))
+	for i, agent := range agents {
+		names[i] = agent.Name
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,28 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestExitSummary(t *testing.T) {
+	auth := &domain.Agent{ID: "a", Name: "auth"}
+	api := &domain.Agent{ID: "b", Name: "api"}
+	done := &domain.Agent{ID: "c", Name: "docs", Status: domain.AgentStatusExited, Metadata: map[string]string{domain.MetadataExitCode: "0"}}
+
+	tests := []struct {
+		report *domain.ExitReport
+		want   string
+	}{
+		{nil, ""},
+		{&domain.ExitReport{}, ""},
+		{&domain.ExitReport{Restarted: []*domain.Agent{auth, api}}, "Restarted crashed: auth, api"},
+		{&domain.ExitReport{Exited: []*domain.Agent{done}, Terminated: []*domain.Agent{api}}, "Exited: docs (0); Session killed: api"},
+	}
+	for _, tt := range tests {
+		if got := exitSummary(tt.report); got != tt.want {
+			t.Errorf("exitSummary(%+v) = %q, want %q", tt.report, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// FocusPollInterval is how often the preview refreshes in focus mode, faster
+// than PreviewPollInterval as the human is supervising the one agent shown.
+const FocusPollInterval = 500 * time.Millisecond
+
+// focusKeys are the keys shown in focus mode's status line.
+var focusKeys = []keyBinding{
+	{key: "enter", desc: "port to agent"},
+	{key: "↑/↓", desc: "switch agent"},
+	{key: "pgup", desc: "history"},
+	{key: "f/esc", desc: "leave focus"},
+}
+
+// toggleFocus enters focus mode for the selected agent, expanding its preview
+// to the full screen, or leaves it. It returns the capture of the resized
+// preview.
+func (m *Model) toggleFocus() tea.Cmd {
+	if !m.focus && m.sideMenu.SelectedAgent() == nil {
+		return nil
+	}
+	m.focus = !m.focus
+	m.layout()
+	return tea.Batch(m.capturePreview(), m.pollPreview())
+}
+
+// previewInterval is how often the preview refreshes in the current mode.
+func (m Model) previewInterval() time.Duration {
+	if m.focus {
+		return FocusPollInterval
+	}
+	return PreviewPollInterval
+}
+
+// layout sizes the panes for the window: the side menu and preview over the
+// quick commands bar, or in focus mode the preview over a one-line status.
+func (m *Model) layout() {
+	m.modal.SetSize(m.width, m.height)
+	m.quickCommands.SetSize(m.width, 3)
+	m.toast.SetSize(m.width, 3)
+	m.progress.SetSize(m.width, 3)
+
+	if m.focus {
+		m.contentArea.SetSize(m.width, max(m.height-1, 0))
+		return
+	}
+
+	bottomHeight := 5 // 3 lines text + 2 border
+	mainHeight := max(m.height-bottomHeight, 0)
+	sideWidth := min(int(float64(m.width)*0.25), MaxSideMenuWidth)
+	m.sideMenu.SetSize(sideWidth, mainHeight)
+	m.contentArea.SetSize(m.width-sideWidth, mainHeight)
+}
+
+// focusView renders the selected agent's preview full screen over a status
+// line naming the agent, or a toast while one shows.
+func (m Model) focusView() string {
+	keys := make([]string, len(focusKeys))
+	for i, binding := range focusKeys {
+		keys[i] = binding.text()
+	}
+	label := ""
+	if agent := m.sideMenu.SelectedAgent(); agent != nil {
+		label = truncateLine(agent.Name+" ("+string(agent.Status)+")  ", m.width)
+	}
+	status := theme.QuickCommandKey.Render(label) +
+		theme.QuickCommandDesc.Render(truncateLine(strings.Join(keys, " • "), m.width-lipgloss.Width(label)))
+	if m.toast.Visible() {
+		status = theme.TextWarning.Render(truncateLine(m.toast.text, m.width))
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top,
+		lipgloss.JoinVertical(lipgloss.Left, m.contentArea.View(), status))
+}
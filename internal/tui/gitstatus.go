@@ -0,0 +1,79 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// GitStatusInterval is how often the agent list's git indicators are refreshed.
+const GitStatusInterval = 5 * time.Second
+
+// gitStatusTickMsg triggers a refresh of the git indicators.
+type gitStatusTickMsg struct{}
+
+// GitStatusLoadedMsg carries the git state of agent worktrees, keyed by agent ID.
+type GitStatusLoadedMsg struct {
+	Statuses map[string]domain.WorktreeStatus
+}
+
+// pollGitStatus returns a command that ticks the git indicator refresh.
+func (m Model) pollGitStatus() tea.Cmd {
+	if m.agentService == nil {
+		return nil
+	}
+	return tea.Tick(GitStatusInterval, func(time.Time) tea.Msg {
+		return gitStatusTickMsg{}
+	})
+}
+
+// loadGitStatus returns a command that collects every agent's git state in one batch.
+func (m Model) loadGitStatus() tea.Cmd {
+	if m.agentService == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return GitStatusLoadedMsg{Statuses: m.agentService.GitStatus()}
+	}
+}
+
+// gitIndicators summarizes a worktree for the agent list, e.g. "↑2 ↓1 +40 -3 *":
+// commits ahead of and behind the base, lines changed, and * for uncommitted changes.
+func gitIndicators(status domain.WorktreeStatus) string {
+	var parts []string
+	if status.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", status.Ahead))
+	}
+	if status.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", status.Behind))
+	}
+	if status.Files > 0 {
+		parts = append(parts, fmt.Sprintf("+%d -%d", status.Insertions, status.Deletions))
+	}
+	if status.Dirty {
+		parts = append(parts, "*")
+	}
+	return strings.Join(parts, " ")
+}
+
+// gitWords describes a worktree in words for the linear view, or "" if there's nothing to say.
+func gitWords(status domain.WorktreeStatus) string {
+	var parts []string
+	if status.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("%d ahead", status.Ahead))
+	}
+	if status.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("%d behind", status.Behind))
+	}
+	if status.Files > 0 {
+		parts = append(parts, fmt.Sprintf("%d files changed", status.Files))
+	}
+	if status.Dirty {
+		parts = append(parts, "uncommitted changes")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,67 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// Glyphs are the symbols marking agent states in the agent list.
+type Glyphs struct {
+	Active    string // running
+	Idle      string // no output for longer than the attention threshold
+	Failed    string // session exited, or command exited with a failure status
+	Done      string // command exited successfully
+	Paused    string // processes suspended
+	Attention string // waiting on the human, e.g. a question or merge conflict
+}
+
+// UnicodeGlyphs are the default glyphs.
+var UnicodeGlyphs = Glyphs{Active: "●", Idle: "○", Failed: "✗", Done: "✓", Paused: "⏸", Attention: "⚠"}
+
+// ASCIIGlyphs stand in for UnicodeGlyphs in fonts and terminals without them.
+var ASCIIGlyphs = Glyphs{Active: "*", Idle: "-", Failed: "x", Done: "+", Paused: "=", Attention: "!"}
+
+// UnicodeSupported guesses from the locale and terminal type, looked up with
+// getenv, whether symbols outside ASCII display. The Linux console's fonts lack
+// most of them even with a UTF-8 locale.
+func UnicodeSupported(getenv func(string) string) bool {
+	switch getenv("TERM") {
+	case "linux", "dumb", "vt100", "vt220":
+		return false
+	}
+	// The first locale variable set decides, as for the C library
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if value := strings.ToLower(getenv(name)); value != "" {
+			return strings.Contains(value, "utf-8") || strings.Contains(value, "utf8")
+		}
+	}
+	return false
+}
+
+// SetGlyphs sets the glyphs marking agent states in the agent list.
+func (m *Model) SetGlyphs(glyphs Glyphs) {
+	m.sideMenu.SetGlyphs(glyphs)
+}
+
+// statusGlyph picks the glyph for an agent in the list from its status and
+// any attention reasons, the most pressing first.
+func statusGlyph(glyphs Glyphs, agent *domain.Agent, reasons []domain.AttentionReason) string {
+	switch {
+	case agent.Status == domain.AgentStatusPaused:
+		return glyphs.Paused
+	case agent.Status == domain.AgentStatusExited:
+		if code, ok := agent.ExitCode(); ok && code == 0 {
+			return glyphs.Done
+		}
+		return glyphs.Failed
+	case slices.Contains(reasons, domain.AttentionExited):
+		return glyphs.Failed
+	case slices.Contains(reasons, domain.AttentionQuestion), slices.Contains(reasons, domain.AttentionConflict):
+		return glyphs.Attention
+	case slices.Contains(reasons, domain.AttentionIdle):
+		return glyphs.Idle
+	}
+	return glyphs.Active
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestUnicodeSupported(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{"utf-8 lang", map[string]string{"LANG": "en_US.UTF-8", "TERM": "xterm-256color"}, true},
+		{"utf8 spelling", map[string]string{"LANG": "C.utf8"}, true},
+		{"lc_all overrides lang", map[string]string{"LC_ALL": "C", "LANG": "en_US.UTF-8"}, false},
+		{"lc_ctype overrides lang", map[string]string{"LC_CTYPE": "en_US.UTF-8", "LANG": "C"}, true},
+		{"linux console", map[string]string{"LANG": "en_US.UTF-8", "TERM": "linux"}, false},
+		{"no locale", map[string]string{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getenv := func(name string) string { return tt.env[name] }
+			if got := UnicodeSupported(getenv); got != tt.want {
+				t.Errorf("UnicodeSupported() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStatusGlyph(t *testing.T) {
+	active := &domain.Agent{Status: domain.AgentStatusActive}
+	tests := []struct {
+		name    string
+		agent   *domain.Agent
+		reasons []domain.AttentionReason
+		want    string
+	}{
+		{"running", active, nil, ASCIIGlyphs.Active},
+		{"paused", &domain.Agent{Status: domain.AgentStatusPaused}, nil, ASCIIGlyphs.Paused},
+		{"exited", active, []domain.AttentionReason{domain.AttentionQuestion, domain.AttentionExited}, ASCIIGlyphs.Failed},
+		{"completed", &domain.Agent{Status: domain.AgentStatusExited, Metadata: map[string]string{domain.MetadataExitCode: "0"}}, nil, ASCIIGlyphs.Done},
+		{"failed", &domain.Agent{Status: domain.AgentStatusExited, Metadata: map[string]string{domain.MetadataExitCode: "1"}}, nil, ASCIIGlyphs.Failed},
+		{"question", active, []domain.AttentionReason{domain.AttentionIdle, domain.AttentionQuestion}, ASCIIGlyphs.Attention},
+		{"idle", active, []domain.AttentionReason{domain.AttentionIdle}, ASCIIGlyphs.Idle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := statusGlyph(ASCIIGlyphs, tt.agent, tt.reasons); got != tt.want {
+				t.Errorf("statusGlyph() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/exp/golden"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/muesli/termenv"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/infra"
+)
+
+// Golden view tests render the dashboard through a real tea.Program and compare
+// the final view against testdata/<test name>.golden. After an intentional UI
+// change, regenerate the snapshots with:
+//
+//	go test ./internal/tui -run TestGolden -update
+
+// goldenSizes are the terminal sizes every golden view is rendered at.
+var goldenSizes = []struct{ width, height int }{
+	{80, 24},
+	{120, 40},
+}
+
+// goldenTime is the fixed creation time of seeded agents so snapshots are stable.
+var goldenTime = time.Date(2025, 1, 2, 15, 4, 5, 0, time.UTC)
+
+// settledModel wraps the dashboard and records which messages it has processed,
+// so the harness can wait for background commands before snapshotting.
+type settledModel struct {
+	Model
+	mu   *sync.Mutex
+	seen map[string]bool
+}
+
+func (m settledModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.mu.Lock()
+	m.seen[fmt.Sprintf("%T", msg)] = true
+	m.mu.Unlock()
+
+	model, cmd := m.Model.Update(msg)
+	m.Model = model.(Model)
+	return m, cmd
+}
+
+func (m settledModel) hasSeen(msgTypes ...string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, t := range msgTypes {
+		if !m.seen[t] {
+			return false
+		}
+	}
+	return true
+}
+
+// newGoldenModel returns a dashboard backed by in-memory stores and the fake
+// session backend, seeded with the given agents and their pane output.
+func newGoldenModel(t *testing.T, agents map[*domain.Agent]string) Model {
+	t.Helper()
+	tmux := infra.NewFakeTmuxClient()
+	tmux.SetScript(func(string) []string { return nil })
+	store := infra.NewMemoryAgentStore()
+	for agent, output := range agents {
+		if err := tmux.CreateSession(agent.ID, agent.Command, agent.WorkDir, nil); err != nil {
+			t.Fatalf("CreateSession failed: %v", err)
+		}
+		if output != "" {
+			_ = tmux.SendKeys(agent.ID, output)
+		}
+		if err := store.Add(agent); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+
+	dispatcher := infra.NewEventDispatcher()
+	infra.WireAdapters(dispatcher, store, tmux, nil)
+	svc := domain.NewAgentService(tmux, store, dispatcher, nil, "proj", t.TempDir())
+	svc.SetEphemeral(true)
+	return NewModel(svc, nil)
+}
+
+// requireGoldenView runs m at every golden size, sends msgs once startup has
+// settled, and compares the final view with the golden file for each size.
+func requireGoldenView(t *testing.T, m Model, msgs ...tea.Msg) {
+	t.Helper()
+	lipgloss.SetColorProfile(termenv.Ascii)
+
+	for _, size := range goldenSizes {
+		t.Run(fmt.Sprintf("%dx%d", size.width, size.height), func(t *testing.T) {
+			settled := settledModel{Model: m, mu: &sync.Mutex{}, seen: map[string]bool{}}
+			tm := teatest.NewTestModel(t, settled, teatest.WithInitialTermSize(size.width, size.height))
+
+			waitFor := []string{"tui.ReconcileDoneMsg", "tui.AgentsUpdatedMsg"}
+			if m.agentService != nil && len(m.agentService.List()) > 0 {
+				waitFor = append(waitFor, "tui.PreviewUpdatedMsg")
+			}
+			teatest.WaitFor(t, tm.Output(), func([]byte) bool {
+				return settled.hasSeen(waitFor...)
+			}, teatest.WithDuration(3*time.Second))
+
+			for _, msg := range msgs {
+				tm.Send(msg)
+			}
+
+			if err := tm.Quit(); err != nil {
+				t.Fatalf("Quit failed: %v", err)
+			}
+			final := tm.FinalModel(t, teatest.WithFinalTimeout(3*time.Second))
+			golden.RequireEqual(t, []byte(final.View()))
+		})
+	}
+}
+
+// requireGoldenComponent renders a standalone component at every golden size.
+func requireGoldenComponent(t *testing.T, newView func(width, height int) tea.Model) {
+	t.Helper()
+	lipgloss.SetColorProfile(termenv.Ascii)
+
+	for _, size := range goldenSizes {
+		t.Run(fmt.Sprintf("%dx%d", size.width, size.height), func(t *testing.T) {
+			golden.RequireEqual(t, []byte(newView(size.width, size.height).View()))
+		})
+	}
+}
+
+func goldenAgents() map[*domain.Agent]string {
+	return map[*domain.Agent]string{
+		{
+			ID: "craizy-proj-claude-auth", Project: "proj", AgentType: "claude", Name: "auth",
+			Command: "claude", WorkDir: "/work/.craizy/worktrees/auth", Status: domain.AgentStatusActive,
+			Branch: "craizy/auth", BaseBranch: "main", CreatedAt: goldenTime,
+		}: "Reading internal/auth/session.go\nAdding token refresh\nAll tests pass",
+		{
+			ID: "craizy-proj-codex-docs", Project: "proj", AgentType: "codex", Name: "docs",
+			Command: "codex", WorkDir: "/work/.craizy/worktrees/docs", Status: domain.AgentStatusActive,
+			Branch: "craizy/docs", BaseBranch: "main", CreatedAt: goldenTime.Add(-time.Hour),
+		}: "Updating README",
+	}
+}
+
+func TestGolden(t *testing.T) {
+	detailNow = func() time.Time { return goldenTime.Add(90 * time.Minute) }
+	t.Cleanup(func() { detailNow = time.Now })
+
+	agent := &domain.Agent{
+		ID: "craizy-proj-claude-auth", Name: "auth", AgentType: "claude",
+		Branch: "craizy/auth", BaseBranch: "main", CreatedAt: goldenTime,
+	}
+
+	t.Run("dashboard_empty", func(t *testing.T) {
+		requireGoldenView(t, newGoldenModel(t, nil))
+	})
+
+	t.Run("dashboard_agents", func(t *testing.T) {
+		requireGoldenView(t, newGoldenModel(t, goldenAgents()))
+	})
+
+	t.Run("dashboard_focus", func(t *testing.T) {
+		requireGoldenView(t, newGoldenModel(t, goldenAgents()),
+			tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	})
+
+	t.Run("agent_detail_modal", func(t *testing.T) {
+		requireGoldenView(t, newGoldenModel(t, goldenAgents()),
+			tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	})
+
+	t.Run("name_input_modal", func(t *testing.T) {
+		requireGoldenView(t, newGoldenModel(t, nil),
+			AgentSelectedMsg{Agent: config.Agent{Name: "Claude", Command: "claude"}})
+	})
+
+	t.Run("merge_conflict_modal", func(t *testing.T) {
+		requireGoldenView(t, newGoldenModel(t, goldenAgents()), MergeResultMsg{
+			AgentName:     "auth",
+			AgentID:       agent.ID,
+			ConflictErr:   errors.New("merge conflict"),
+			ConflictFiles: []string{"internal/auth/session.go", "go.sum"},
+			BaseBranch:    "main",
+		})
+	})
+
+	t.Run("protected_branch_modal", func(t *testing.T) {
+		requireGoldenView(t, newGoldenModel(t, goldenAgents()), MergeProtectionCheckedMsg{
+			AgentID:    agent.ID,
+			AgentName:  "auth",
+			BaseBranch: "main",
+			Protected:  true,
+		})
+	})
+
+	t.Run("retarget_modal", func(t *testing.T) {
+		requireGoldenView(t, newGoldenModel(t, goldenAgents()), OpenRetargetMsg{Agent: agent})
+	})
+
+	t.Run("linear_empty", func(t *testing.T) {
+		m := newGoldenModel(t, nil)
+		m.SetLinear(true)
+		requireGoldenView(t, m)
+	})
+
+	t.Run("linear_agents", func(t *testing.T) {
+		m := newGoldenModel(t, goldenAgents())
+		m.SetLinear(true)
+		requireGoldenView(t, m)
+	})
+
+	t.Run("dashboard_read_only", func(t *testing.T) {
+		m := newGoldenModel(t, goldenAgents())
+		m.SetReadOnly(true)
+		requireGoldenView(t, m)
+	})
+
+	t.Run("linear_read_only", func(t *testing.T) {
+		m := newGoldenModel(t, goldenAgents())
+		m.SetLinear(true)
+		m.SetReadOnly(true)
+		requireGoldenView(t, m)
+	})
+
+	t.Run("copy_menu_modal", func(t *testing.T) {
+		requireGoldenView(t, newGoldenModel(t, goldenAgents()),
+			tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	})
+
+	t.Run("kill_confirm_modal", func(t *testing.T) {
+		requireGoldenComponent(t, func(width, height int) tea.Model {
+			return NewKillConfirmModal(agent.ID, agent.Name, width, height)
+		})
+	})
+
+	t.Run("error_modal", func(t *testing.T) {
+		requireGoldenComponent(t, func(width, height int) tea.Model {
+			return NewErrorModal("Merge Failed", domain.ErrCommitSigning, width, height)
+		})
+	})
+
+	t.Run("commit_log_modal", func(t *testing.T) {
+		requireGoldenComponent(t, func(width, height int) tea.Model {
+			m, _ := NewCommitLogModal(agent, width, height).Update(CommitLogLoadedMsg{Commits: []domain.Commit{
+				{Hash: "4f2c9e1", Subject: "Add token refresh"},
+				{Hash: "a81d03b", Subject: "Read session expiry from config"},
+			}})
+			return m
+		})
+	})
+
+	t.Run("merge_confirm_modal", func(t *testing.T) {
+		requireGoldenComponent(t, func(width, height int) tea.Model {
+			return NewMergeConfirmModal(agent.ID, agent.Name, &domain.MergePreview{
+				Branch:     agent.Branch,
+				BaseBranch: agent.BaseBranch,
+				Strategy:   domain.MergeStrategyMerge,
+				Commits: []domain.Commit{
+					{Hash: "4f2c9e1", Subject: "Add token refresh"},
+					{Hash: "a81d03b", Subject: "Read session expiry from config"},
+				},
+				DiffStat: " internal/auth/token.go | 42 ++++++++++++++++++++++++++++++++++++++++++\n 1 file changed, 42 insertions(+)",
+			}, width, height)
+		})
+	})
+
+	t.Run("stash_modal", func(t *testing.T) {
+		requireGoldenComponent(t, func(width, height int) tea.Model {
+			created := time.Date(2026, 3, 14, 9, 30, 0, 0, time.UTC)
+			m, _ := NewStashModal(width, height).Update(StashesLoadedMsg{Stashes: []domain.StashEntry{
+				{Ref: "stash@{0}", Branch: "craizy/auth", Message: domain.AutoStashMessage, CreatedAt: created},
+				{Ref: "stash@{1}", Branch: "main", Message: domain.AutoStashMessage, CreatedAt: created.Add(-time.Hour)},
+			}})
+			return m
+		})
+	})
+}
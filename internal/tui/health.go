@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// HealthCheckInterval is how often the dashboard checks for unresponsive dependencies.
+const HealthCheckInterval = 2 * time.Second
+
+// healthTickMsg triggers a check for unresponsive dependencies.
+type healthTickMsg struct{}
+
+// pollHealth returns a command that ticks the health check.
+func (m Model) pollHealth() tea.Cmd {
+	if m.agentService == nil {
+		return nil
+	}
+	return tea.Tick(HealthCheckInterval, func(time.Time) tea.Msg {
+		return healthTickMsg{}
+	})
+}
+
+// degradedText describes unresponsive dependencies, or returns "" when all are healthy.
+func (m Model) degradedText() string {
+	if len(m.degraded) == 0 {
+		return ""
+	}
+	return "Degraded: " + strings.Join(m.degraded, "; ")
+}
+
+// degradedBanner renders the degraded notice in place of the quick commands bar.
+func (m Model) degradedBanner() string {
+	textStyle := theme.TextError.
+		Bold(true).
+		Width(m.toast.width).
+		Align(lipgloss.Center)
+
+	containerStyle := lipgloss.NewStyle().
+		Width(m.toast.width).
+		Height(m.toast.height).
+		AlignVertical(lipgloss.Bottom)
+
+	return containerStyle.Render(textStyle.Render("⚠ " + m.degradedText()))
+}
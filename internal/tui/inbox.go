@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// InboxPollInterval is how often the human's unread messages are checked.
+const InboxPollInterval = 5 * time.Second
+
+// inboxToastPreview caps how much of a new message a toast shows.
+const inboxToastPreview = 60
+
+// inboxTickMsg triggers an inbox check.
+type inboxTickMsg struct{}
+
+// InboxCheckedMsg carries the human's unread messages, oldest first, and
+// everyone's unread counts for the agent list badges.
+type InboxCheckedMsg struct {
+	Unread []*domain.Message
+	Counts map[string]int // unread messages by recipient
+	Err    error
+}
+
+// SetHuman sets whose inbox the dashboard shows and whom replies come from: a
+// named human such as "human:alice", or the shared HumanParticipantID.
+func (m *Model) SetHuman(id string) {
+	m.human = id
+}
+
+// checkInbox returns a command that lists the human's unread messages.
+func (m Model) checkInbox() tea.Cmd {
+	if m.messageService == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		unread, err := m.messageService.ListUnread(m.human)
+		if err != nil {
+			return InboxCheckedMsg{Err: err}
+		}
+		counts, err := m.messageService.UnreadCounts()
+		return InboxCheckedMsg{Unread: unread, Counts: counts, Err: err}
+	}
+}
+
+// loadInboxPage returns a command that loads the requested page of the
+// human's received or sent messages.
+func (m Model) loadInboxPage(request InboxPageRequestMsg) tea.Cmd {
+	if m.messageService == nil {
+		return nil
+	}
+	list := m.messageService.List
+	if request.Sent {
+		list = m.messageService.ListSent
+	}
+	return func() tea.Msg {
+		messages, err := list(m.human, request.Before, inboxListLimit)
+		return InboxPageLoadedMsg{Sent: request.Sent, Messages: messages, Err: err}
+	}
+}
+
+// pollInbox returns a command that ticks the next inbox check.
+func (m Model) pollInbox() tea.Cmd {
+	if m.messageService == nil {
+		return nil
+	}
+	return tea.Tick(InboxPollInterval, func(time.Time) tea.Msg {
+		return inboxTickMsg{}
+	})
+}
+
+// updateInbox records an inbox check and returns a command updating the
+// terminal title and toasting messages that arrived since the last one, if
+// any. Messages already unread when the dashboard opened only count towards
+// the badge.
+func (m *Model) updateInbox(msg InboxCheckedMsg) tea.Cmd {
+	if msg.Err != nil {
+		return nil
+	}
+	m.quickCommands.SetUnread(len(msg.Unread))
+	m.sideMenu.SetUnread(msg.Counts)
+	m.titleUnread = len(msg.Unread)
+	title := m.updateTitle()
+
+	var arrived []*domain.Message
+	lastSeq := m.inboxSeq
+	for _, message := range msg.Unread {
+		if message.Seq > m.inboxSeq {
+			lastSeq = max(lastSeq, message.Seq)
+			// Muted messages still count towards the badge
+			if !m.mutedMessage(message) {
+				arrived = append(arrived, message)
+			}
+		}
+	}
+	m.inboxSeq = lastSeq
+
+	primed := m.inboxPrimed
+	m.inboxPrimed = true
+	if !primed || len(arrived) == 0 {
+		return title
+	}
+	return tea.Batch(title, m.toast.Show(inboxToast(arrived)))
+}
+
+// sendReply returns a command that sends a reply from the human.
+func (m Model) sendReply(reply ReplySubmittedMsg) tea.Cmd {
+	if m.messageService == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		sent, err := m.messageService.Send(m.human, reply.To, reply.Type, reply.Content, nil)
+		return ReplySentMsg{Message: sent, Err: err}
+	}
+}
+
+// inboxToast announces newly arrived messages.
+func inboxToast(arrived []*domain.Message) string {
+	if len(arrived) > 1 {
+		return fmt.Sprintf("✉ %d new messages - press u to read", len(arrived))
+	}
+	message := arrived[0]
+	content := []rune(strings.Join(strings.Fields(message.Content), " "))
+	if len(content) > inboxToastPreview {
+		content = append(content[:inboxToastPreview-3], []rune("...")...)
+	}
+	return fmt.Sprintf("✉ %s from %s (%s): %s - press u to read", message.Code(), message.From, message.Type, string(content))
+}
@@ -0,0 +1,342 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// inboxListLimit is how many messages the inbox loads at a time.
+const inboxListLimit = 20
+
+// MessageOpenedMsg is sent when a message is opened or marked read in the
+// inbox, so it can be marked read in the store.
+type MessageOpenedMsg struct {
+	MessageID string
+}
+
+// ReplyRequestMsg asks to open the reply editor for a message.
+type ReplyRequestMsg struct {
+	Message *domain.Message
+}
+
+// InboxPageRequestMsg asks for a page of the human's received or sent
+// messages older than Before, or the newest page if Before is 0.
+type InboxPageRequestMsg struct {
+	Sent   bool
+	Before int // Seq of the oldest message listed so far
+}
+
+// InboxPageLoadedMsg carries a page of messages for one of the inbox's tabs.
+type InboxPageLoadedMsg struct {
+	Sent     bool
+	Messages []*domain.Message
+	Err      error
+}
+
+// OpenFileRefMsg asks to open a message's file reference in the editor.
+type OpenFileRefMsg struct {
+	From string // sender, whose worktree the path is relative to
+	Ref  domain.FileRef
+}
+
+// Inbox tabs, indexing InboxModal.folders.
+const (
+	inboxReceived = iota
+	inboxSent
+)
+
+// inboxTabNames labels the inbox tabs.
+var inboxTabNames = [...]string{inboxReceived: "Received", inboxSent: "Sent"}
+
+// inboxFolder is one tab's messages, paged in from the store as the list scrolls.
+type inboxFolder struct {
+	messages []*domain.Message // newest first
+	cursor   int
+	offset   int  // first message shown in the list
+	more     bool // an older page may follow the messages loaded
+	loading  bool // waiting for the next page
+	loaded   bool // the first page has been given or requested
+}
+
+// InboxModal lists the human's recent received and sent messages and shows
+// the selected one.
+type InboxModal struct {
+	folders [2]inboxFolder
+	tab     int
+	reading bool // showing the selected message rather than the list
+	width   int
+	height  int
+}
+
+// NewInboxModal creates an inbox listing received messages, newest first. A
+// full page of inboxListLimit messages suggests older ones, loaded on
+// scrolling past the end. Sent messages load when their tab is first opened.
+func NewInboxModal(messages []*domain.Message, width, height int) InboxModal {
+	m := InboxModal{width: width, height: height}
+	m.folders[inboxReceived] = inboxFolder{
+		messages: messages,
+		more:     len(messages) >= inboxListLimit,
+		loaded:   true,
+	}
+	return m
+}
+
+func (m InboxModal) Init() tea.Cmd {
+	return nil
+}
+
+func (m InboxModal) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width, m.height = size.Width, size.Height
+		return m, nil
+	}
+	if page, ok := msg.(InboxPageLoadedMsg); ok {
+		folder := &m.folders[inboxReceived]
+		if page.Sent {
+			folder = &m.folders[inboxSent]
+		}
+		folder.loading = false
+		if page.Err != nil {
+			return m, nil
+		}
+		folder.messages = append(folder.messages, page.Messages...)
+		folder.more = len(page.Messages) >= inboxListLimit
+		return m, nil
+	}
+
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	folder := &m.folders[m.tab]
+	switch keyMsg.String() {
+	case "esc":
+		if m.reading {
+			m.reading = false
+			return m, nil
+		}
+		return m, func() tea.Msg { return CloseModalMsg{} }
+	case "u":
+		return m, func() tea.Msg { return CloseModalMsg{} }
+	case "tab":
+		if m.reading {
+			break
+		}
+		m.tab = (m.tab + 1) % len(m.folders)
+		if folder = &m.folders[m.tab]; !folder.loaded {
+			folder.loaded = true
+			return m, m.requestPage(m.tab)
+		}
+	case "up", "k":
+		if !m.reading && folder.cursor > 0 {
+			folder.cursor--
+			folder.offset = min(folder.offset, folder.cursor)
+		}
+	case "down", "j":
+		if m.reading {
+			break
+		}
+		if folder.cursor < len(folder.messages)-1 {
+			folder.cursor++
+			if rows := m.listRows(); folder.cursor >= folder.offset+rows {
+				folder.offset = folder.cursor - rows + 1
+			}
+		} else if folder.more && !folder.loading {
+			return m, m.requestPage(m.tab)
+		}
+	case "enter":
+		if selected := m.selected(); selected != nil && !m.reading {
+			m.reading = true
+			if m.tab == inboxSent {
+				// The recipient reads sent messages, not the human
+				return m, nil
+			}
+			selected.Read = true
+			return m, func() tea.Msg { return MessageOpenedMsg{MessageID: selected.ID} }
+		}
+	case "m":
+		// Mark read without opening, e.g. a notice seen in a toast
+		if selected := m.unreadSelected(); selected != nil {
+			selected.Read = true
+			return m, func() tea.Msg { return MessageOpenedMsg{MessageID: selected.ID} }
+		}
+	case "r":
+		if selected := m.selected(); selected != nil && !domain.IsHumanParticipant(selected.From) {
+			return m, func() tea.Msg { return ReplyRequestMsg{Message: selected} }
+		}
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		// Open a numbered file reference of the message being read
+		if selected := m.selected(); m.reading && selected != nil {
+			if i := int(keyMsg.String()[0] - '1'); i < len(selected.Refs) {
+				open := OpenFileRefMsg{From: selected.From, Ref: selected.Refs[i]}
+				return m, func() tea.Msg { return open }
+			}
+		}
+	}
+	return m, nil
+}
+
+// requestPage marks a tab loading and returns a command asking for the page
+// after its last message, or its first page if it has none.
+func (m *InboxModal) requestPage(tab int) tea.Cmd {
+	folder := &m.folders[tab]
+	folder.loading = true
+	request := InboxPageRequestMsg{Sent: tab == inboxSent}
+	if n := len(folder.messages); n > 0 {
+		request.Before = folder.messages[n-1].Seq
+	}
+	return func() tea.Msg { return request }
+}
+
+// selected returns the message under the cursor, or nil if the tab is empty.
+func (m InboxModal) selected() *domain.Message {
+	folder := m.folders[m.tab]
+	if folder.cursor < len(folder.messages) {
+		return folder.messages[folder.cursor]
+	}
+	return nil
+}
+
+// unreadSelected returns the received message under the cursor if it is
+// unread, or nil.
+func (m InboxModal) unreadSelected() *domain.Message {
+	if selected := m.selected(); m.tab == inboxReceived && selected != nil && !selected.Read {
+		return selected
+	}
+	return nil
+}
+
+// KeyHints returns the keys for the message list or the message being read.
+func (m InboxModal) KeyHints() []keyBinding {
+	reply := keyBinding{key: "r", desc: "reply"}
+	if !m.reading {
+		bindings := []keyBinding{{key: "↑/↓", desc: "select"}, {key: "enter", desc: "read"}, reply}
+		if m.unreadSelected() != nil {
+			bindings = append(bindings, keyBinding{key: "m", desc: "mark read"})
+		}
+		return append(bindings, keyBinding{key: "tab", desc: "received/sent"}, keyBinding{key: "esc", desc: "close"})
+	}
+	var bindings []keyBinding
+	if len(m.selected().Refs) > 0 {
+		bindings = append(bindings, keyBinding{key: "1-9", desc: "open file"})
+	}
+	return append(bindings, reply, keyBinding{key: "esc", desc: "back"})
+}
+
+func (m InboxModal) View() string {
+	title := theme.ModalTitle.Render("Inbox") + "  " + m.tabsView()
+	body := m.listView()
+	if m.reading {
+		selected := m.selected()
+		if m.tab == inboxSent {
+			title = theme.ModalTitle.Render(fmt.Sprintf("%s to %s", selected.Code(), selected.To))
+		} else {
+			title = theme.ModalTitle.Render(fmt.Sprintf("%s from %s", selected.Code(), selected.From))
+		}
+		body = m.messageView(selected)
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		body,
+	)
+
+	box := theme.ModalBorder.
+		Padding(1, 3).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// tabsView renders the tab names, the open one highlighted.
+func (m InboxModal) tabsView() string {
+	tabs := make([]string, len(inboxTabNames))
+	for i, name := range inboxTabNames {
+		if i == m.tab {
+			tabs[i] = theme.TextNormal.Bold(true).Underline(true).Render(name)
+		} else {
+			tabs[i] = theme.TextMuted.Render(name)
+		}
+	}
+	return strings.Join(tabs, " ")
+}
+
+// listView renders one line per message of the open tab. Received messages
+// show their sender, unread ones highlighted; sent ones show their recipient.
+func (m InboxModal) listView() string {
+	folder := m.folders[m.tab]
+	if len(folder.messages) == 0 {
+		if folder.loading {
+			return theme.TextMuted.Render("Loading...")
+		}
+		return theme.TextMuted.Render("No messages")
+	}
+	width := m.contentWidth()
+	end := min(folder.offset+m.listRows(), len(folder.messages))
+	lines := make([]string, 0, end-folder.offset+1)
+	for i := folder.offset; i < end; i++ {
+		msg := folder.messages[i]
+		marker := "  "
+		if i == folder.cursor {
+			marker = "> "
+		}
+		peer := msg.From
+		if m.tab == inboxSent {
+			peer = "→ " + msg.To
+		}
+		preview := strings.Join(strings.Fields(msg.Content), " ")
+		line := fmt.Sprintf("%s%-7s %-16s %-10s %s", marker, msg.Code(), truncateLine(peer, 16), msg.Type, preview)
+		line = truncateLine(line, width)
+
+		style := theme.TextMuted
+		if !msg.Read && m.tab == inboxReceived {
+			style = theme.TextNormal.Bold(true)
+		}
+		lines = append(lines, style.Render(line))
+	}
+	switch {
+	case folder.loading:
+		lines = append(lines, theme.TextMuted.Render("  Loading older messages..."))
+	case folder.more && end == len(folder.messages):
+		lines = append(lines, theme.TextMuted.Render("  ↓ older messages"))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// listRows is how many messages fit in the list, leaving room for the title,
+// the paging line and the modal's border and padding.
+func (m InboxModal) listRows() int {
+	return max(m.height-10, 5)
+}
+
+// messageView renders a message's details and full content as markdown.
+func (m InboxModal) messageView(msg *domain.Message) string {
+	header := []string{
+		theme.TextMuted.Render(fmt.Sprintf("To %s • %s • %s", msg.To, msg.Type, msg.CreatedAt.Format("2006-01-02 15:04"))),
+	}
+	if msg.RelatedWork != nil {
+		header = append(header, theme.TextMuted.Render("Related: "+*msg.RelatedWork))
+	}
+	for i, ref := range msg.Refs {
+		if i == 9 {
+			break
+		}
+		header = append(header, theme.QuickCommandKey.Render(strconv.Itoa(i+1))+" "+theme.TextNormal.Render(ref.String()))
+	}
+	content := renderMarkdown(msg.Content, m.contentWidth())
+	return lipgloss.JoinVertical(lipgloss.Left, append(header, "", content)...)
+}
+
+// contentWidth is the text width inside the modal's border and padding.
+func (m InboxModal) contentWidth() int {
+	return max(m.width*3/4-8, 20)
+}
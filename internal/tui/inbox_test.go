@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestModel_updateInbox(t *testing.T) {
+	waiting := &domain.Message{ID: "a", Seq: 1, From: "auth", Type: domain.MessageTypeQuestion, Content: "Which library?"}
+	arrived := &domain.Message{ID: "b", Seq: 2, From: "docs", Type: domain.MessageTypeCompletion, Content: "Docs\nupdated"}
+
+	m := NewModel(nil, nil)
+	if cmd := m.updateInbox(InboxCheckedMsg{Unread: []*domain.Message{waiting}}); cmd != nil || m.toast.Visible() {
+		t.Error("expected no toast for messages unread before the dashboard opened")
+	}
+	if got := m.quickCommands.Badge(); got != "✉ 1 unread" {
+		t.Errorf("Badge() = %q, want %q", got, "✉ 1 unread")
+	}
+
+	m.updateInbox(InboxCheckedMsg{Unread: []*domain.Message{waiting, arrived}})
+	if !m.toast.Visible() || !strings.Contains(m.toast.text, "M-2 from docs (completion): Docs updated") {
+		t.Errorf("toast = %q, want the new message", m.toast.text)
+	}
+	if got := m.quickCommands.Badge(); got != "✉ 2 unread" {
+		t.Errorf("Badge() = %q, want %q", got, "✉ 2 unread")
+	}
+
+	m.toast.text = ""
+	m.updateInbox(InboxCheckedMsg{Unread: []*domain.Message{arrived}})
+	if m.toast.Visible() {
+		t.Error("expected no toast when nothing new arrived")
+	}
+
+	m.updateInbox(InboxCheckedMsg{})
+	if got := m.quickCommands.Badge(); got != "" {
+		t.Errorf("Badge() = %q, want none once read", got)
+	}
+}
+
+func TestModel_updateInbox_AgentBadges(t *testing.T) {
+	agents := []*domain.Agent{
+		{ID: "auth", Name: "auth", AgentType: "claude", Status: domain.AgentStatusActive},
+		{ID: "docs", Name: "docs", AgentType: "codex", Status: domain.AgentStatusActive},
+	}
+
+	m := NewModel(nil, nil)
+	m.sideMenu.SetSize(30, 20)
+	newModel, _ := m.Update(AgentsUpdatedMsg{Agents: agents})
+	m = newModel.(Model)
+	m.updateInbox(InboxCheckedMsg{Counts: map[string]int{"auth": 3, domain.HumanParticipantID: 1}})
+
+	items := m.sideMenu.list.Items()
+	if got := items[0].(AgentListItem).Title(); got != "auth ✉3" {
+		t.Errorf("auth title = %q, want %q", got, "auth ✉3")
+	}
+	if got := items[1].(AgentListItem).Title(); got != "docs" {
+		t.Errorf("docs title = %q, want no badge", got)
+	}
+
+	m.updateInbox(InboxCheckedMsg{})
+	if got := m.sideMenu.list.Items()[0].(AgentListItem).Title(); got != "auth" {
+		t.Errorf("auth title = %q, want the badge cleared once read", got)
+	}
+}
+
+func TestInboxModal(t *testing.T) {
+	question := &domain.Message{ID: "q", Seq: 3, From: "auth", To: domain.HumanParticipantID, Type: domain.MessageTypeQuestion, Content: "Which library?"}
+	sent := &domain.Message{ID: "s", Seq: 2, From: domain.HumanParticipantID, To: "auth", Type: domain.MessageTypeInfo, Content: "Hi"}
+	m := NewInboxModal([]*domain.Message{question, sent}, 100, 40)
+
+	model, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = model.(InboxModal)
+	if opened, ok := cmd().(MessageOpenedMsg); !ok || opened.MessageID != "q" {
+		t.Errorf("enter sent %v, want MessageOpenedMsg for q", cmd())
+	}
+	if !m.reading || !strings.Contains(m.View(), "Which library?") {
+		t.Error("expected the message to be shown after enter")
+	}
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if reply, ok := cmd().(ReplyRequestMsg); !ok || reply.Message != question {
+		t.Errorf("r sent %v, want ReplyRequestMsg for the question", cmd())
+	}
+
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = model.(InboxModal)
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = model.(InboxModal)
+	if _, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}); cmd != nil {
+		t.Error("expected no reply to the human's own message")
+	}
+}
+
+func TestInboxModal_MarkRead(t *testing.T) {
+	question := &domain.Message{ID: "q", Seq: 3, From: "auth", To: domain.HumanParticipantID, Type: domain.MessageTypeQuestion, Content: "Which library?"}
+	var m tea.Model = NewInboxModal([]*domain.Message{question}, 100, 40)
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	if marked, ok := cmd().(MessageOpenedMsg); !ok || marked.MessageID != "q" {
+		t.Errorf("m sent %v, want MessageOpenedMsg for q", cmd())
+	}
+	if !question.Read || m.(InboxModal).reading {
+		t.Error("expected the message marked read without opening it")
+	}
+	if _, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")}); cmd != nil {
+		t.Error("expected no command for a message already read")
+	}
+}
+
+func TestInboxModal_Paging(t *testing.T) {
+	page := func(from, n int) []*domain.Message {
+		var msgs []*domain.Message
+		for seq := from; seq > from-n && seq > 0; seq-- {
+			msgs = append(msgs, &domain.Message{ID: fmt.Sprint(seq), Seq: seq, From: "auth", Type: domain.MessageTypeInfo, Content: "hi"})
+		}
+		return msgs
+	}
+
+	var m tea.Model = NewInboxModal(page(30, inboxListLimit), 120, 60)
+	for i := 0; i < inboxListLimit-1; i++ {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	if !strings.Contains(m.View(), "older messages") {
+		t.Error("expected a hint that older messages follow a full page")
+	}
+
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if cmd == nil {
+		t.Fatal("expected a page request on scrolling past the last message")
+	}
+	request, ok := cmd().(InboxPageRequestMsg)
+	if !ok || request.Before != 11 {
+		t.Fatalf("request = %#v, want messages before seq 11", request)
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyDown}); cmd != nil {
+		t.Error("expected no second request while a page is loading")
+	}
+
+	m, _ = m.Update(InboxPageLoadedMsg{Messages: page(10, inboxListLimit)})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if got := m.(InboxModal).selected(); got == nil || got.Seq != 10 {
+		t.Errorf("selected = %v, want the first message of the loaded page", got)
+	}
+	if strings.Contains(m.View(), "older messages") {
+		t.Error("expected no hint after a short page")
+	}
+}
+
+func TestInboxModal_SentTab(t *testing.T) {
+	received := &domain.Message{ID: "q", Seq: 3, From: "auth", To: domain.HumanParticipantID, Type: domain.MessageTypeQuestion, Content: "Which library?"}
+	sent := &domain.Message{ID: "s", Seq: 2, From: domain.HumanParticipantID, To: "docs", Type: domain.MessageTypeInfo, Content: "Update the README"}
+
+	var m tea.Model = NewInboxModal([]*domain.Message{received}, 100, 40)
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if cmd == nil {
+		t.Fatal("expected the sent tab to request its first page")
+	}
+	if request, ok := cmd().(InboxPageRequestMsg); !ok || !request.Sent || request.Before != 0 {
+		t.Fatalf("request = %#v, want the newest page of sent messages", request)
+	}
+
+	m, _ = m.Update(InboxPageLoadedMsg{Sent: true, Messages: []*domain.Message{sent}})
+	if view := m.View(); !strings.Contains(view, "→ docs") || strings.Contains(view, "Which library?") {
+		t.Errorf("expected the sent tab to list the message to docs only, got:\n%s", view)
+	}
+
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil {
+		t.Error("expected opening a sent message not to mark it read")
+	}
+	if !strings.Contains(m.View(), "M-2 to docs") {
+		t.Error("expected the sent message's recipient in the title")
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if cmd != nil || !strings.Contains(m.View(), "Which library?") {
+		t.Error("expected switching back to show the loaded received messages")
+	}
+}
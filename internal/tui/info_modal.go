@@ -0,0 +1,78 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// InfoModel is a simple modal that shows a title and message until dismissed.
+type InfoModel struct {
+	title   string
+	message string
+	isError bool
+	width   int
+	height  int
+}
+
+// NewInfoModal creates a new informational modal.
+func NewInfoModal(title, message string, width, height int) InfoModel {
+	return InfoModel{
+		title:   title,
+		message: message,
+		width:   width,
+		height:  height,
+	}
+}
+
+// NewErrorModal creates a new informational modal styled as an error.
+func NewErrorModal(title string, err error, width, height int) InfoModel {
+	m := NewInfoModal(title, err.Error(), width, height)
+	m.isError = true
+	return m
+}
+
+func (m InfoModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m InfoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter", "esc", " ":
+			return m, func() tea.Msg {
+				return CloseModalMsg{}
+			}
+		}
+	}
+	return m, nil
+}
+
+// KeyHints returns the info modal's keys.
+func (m InfoModel) KeyHints() []keyBinding {
+	return []keyBinding{{key: "enter", desc: "close"}}
+}
+
+func (m InfoModel) View() string {
+	titleStyle := theme.ModalTitle
+	if m.isError {
+		titleStyle = titleStyle.Foreground(theme.ColorError)
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		titleStyle.Render(m.title),
+		"",
+		theme.TextNormal.Render(m.message),
+	)
+
+	box := theme.ModalBorder.
+		Padding(1, 3).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -31,6 +31,9 @@ func (m KillConfirmModel) Init() tea.Cmd {
 
 func (m KillConfirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "left", "h":
@@ -66,6 +69,11 @@ func (m KillConfirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// KeyHints returns the kill confirmation's keys.
+func (m KillConfirmModel) KeyHints() []keyBinding {
+	return []keyBinding{{key: "←/→", desc: "select"}, {key: "enter", desc: "confirm"}, {key: "esc", desc: "cancel"}}
+}
+
 func (m KillConfirmModel) View() string {
 	titleStyle := lipgloss.NewStyle().
 		Bold(true).
@@ -108,18 +116,12 @@ func (m KillConfirmModel) View() string {
 
 	buttons := lipgloss.JoinHorizontal(lipgloss.Center, keepBtn, " ", discardBtn, " ", cancelBtn)
 
-	hint := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("245")).
-		Render("Use arrow keys to select, Enter to confirm")
-
 	content := lipgloss.JoinVertical(lipgloss.Center,
 		title,
 		"",
 		warning,
 		"",
 		buttons,
-		"",
-		hint,
 	)
 
 	box := lipgloss.NewStyle().
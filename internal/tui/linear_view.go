@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// statusWord returns a plain-language agent state for the linear view.
+func statusWord(status domain.AgentStatus) string {
+	switch status {
+	case domain.AgentStatusActive:
+		return "running"
+	case domain.AgentStatusIdle:
+		return "idle"
+	case domain.AgentStatusPaused:
+		return "paused"
+	case domain.AgentStatusStarting:
+		return "starting"
+	case domain.AgentStatusExited:
+		return "exited"
+	case domain.AgentStatusTerminated:
+		return "stopped"
+	}
+	return string(status)
+}
+
+// linearView renders the dashboard as plain top-to-bottom text with no boxes,
+// columns or colored indicators, for screen readers and basic terminals.
+func (m Model) linearView() string {
+	agents := m.sideMenu.agents
+	selected := m.sideMenu.SelectedAgent()
+
+	var header []string
+	switch {
+	case len(agents) == 0 && m.sideMenu.loading:
+		header = append(header, "Agents: loading")
+	case len(agents) == 0 && m.readOnly:
+		header = append(header, "Agents: none running.")
+	case len(agents) == 0:
+		header = append(header, "Agents: none running. Press n to create one.")
+	default:
+		title := fmt.Sprintf("Agents: %d", len(agents))
+		if m.sideMenu.loading {
+			title += " (syncing)"
+		}
+		header = append(header, title)
+		for i, agent := range agents {
+			line := fmt.Sprintf("%d. %s, %s, %s", i+1, agent.Name, agent.AgentType, statusWord(agent.Status))
+			if reasons := m.sideMenu.attention[agent.ID].Reasons; len(reasons) > 0 {
+				line += ", needs attention: " + joinReasons(reasons)
+			}
+			if n := m.sideMenu.unread[agent.ID]; n > 0 {
+				line += fmt.Sprintf(", %d unread", n)
+			}
+			if words := gitWords(m.sideMenu.git[agent.ID]); words != "" {
+				line += ", " + words
+			}
+			if selected != nil && agent.ID == selected.ID {
+				line = "> " + line + ", selected"
+			} else {
+				line = "  " + line
+			}
+			header = append(header, line)
+		}
+	}
+
+	hints := m.quickCommands.Hints()
+	if keys := m.contentKeys(); keys != nil {
+		hints = make([]string, len(keys))
+		for i, binding := range keys {
+			hints[i] = binding.text()
+		}
+	}
+	footer := []string{"", "Keys: " + strings.Join(hints, ", ")}
+	if m.quickCommands.unread > 0 {
+		footer = append(footer, fmt.Sprintf("Inbox: %d unread", m.quickCommands.unread))
+	}
+	if text := m.degradedText(); text != "" {
+		footer = append(footer, text)
+	}
+	if m.progress.Active() {
+		footer = append(footer, "Working: "+m.progress.Text())
+	}
+	if m.toast.Visible() {
+		footer = append(footer, "Notice: "+m.toast.text)
+	}
+
+	lines := header
+	switch {
+	case m.contentArea.DiffOpen():
+		lines = append(lines, "", "Diff of "+m.contentArea.diffTitle+":")
+		lines = append(lines, strings.Split(m.contentArea.diff.View(), "\n")...)
+	case m.contentArea.Scrolling() && selected != nil:
+		lines = append(lines, "", fmt.Sprintf("History of %s (%s):", selected.Name, m.contentArea.scrollPosition()))
+		lines = append(lines, strings.Split(m.contentArea.scroll.View(), "\n")...)
+	case selected != nil:
+		title := "Output of " + selected.Name + ":"
+		if n := m.contentArea.newLines; n > 0 {
+			title = fmt.Sprintf("Output of %s (%s):", selected.Name, newLinesSummary(n))
+		}
+		lines = append(lines, "", title)
+		budget := m.height - len(lines) - len(footer)
+		for _, line := range lastLines(m.contentArea.previewContent, budget) {
+			lines = append(lines, truncateLine(line, m.width))
+		}
+	}
+	lines = append(lines, footer...)
+
+	return strings.Join(lines, "\n")
+}
+
+// lastLines returns up to n trailing lines of s, ignoring trailing blank lines.
+func lastLines(s string, n int) []string {
+	s = strings.TrimRight(s, "\n ")
+	if s == "" || n <= 0 {
+		return nil
+	}
+	lines := strings.Split(s, "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// renderMarkdown renders message content as terminal markdown wrapped to
+// width, so agents' fenced code blocks and lists stay readable. Plain-text
+// terminals get the ASCII style. It falls back to the raw text on error.
+func renderMarkdown(text string, width int) string {
+	profile := lipgloss.ColorProfile()
+	style := styles.DarkStyle
+	if profile == termenv.Ascii {
+		style = styles.AsciiStyle
+	}
+
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle(style),
+		glamour.WithColorProfile(profile),
+		glamour.WithWordWrap(width),
+	)
+	if err != nil {
+		return text
+	}
+	out, err := renderer.Render(text)
+	if err != nil {
+		return text
+	}
+	return strings.Trim(out, "\n")
+}
@@ -0,0 +1,31 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	profile := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.Ascii)
+	defer lipgloss.SetColorProfile(profile)
+
+	got := renderMarkdown("Changed:\n\n- one\n- two\n\n```go\nfunc main() {}\n```\n", 40)
+
+	for _, want := range []string{"• one", "• two", "func main() {}"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("rendered markdown missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "```") || strings.Contains(got, "\x1b[") {
+		t.Errorf("expected fences and escape codes to be removed:\n%s", got)
+	}
+	for _, line := range strings.Split(got, "\n") {
+		if lipgloss.Width(line) > 40 {
+			t.Errorf("line wider than 40 columns: %q", line)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// MergeConfirmModel is a modal showing the commits and diffstat an agent's
+// merge would land on its base, merging only once confirmed. The merge strategy
+// starts at the preview's and can be switched before confirming.
+type MergeConfirmModel struct {
+	agentID   string
+	agentName string
+	preview   *domain.MergePreview
+	strategy  domain.MergeStrategy
+	body      viewport.Model
+	width     int
+	height    int
+}
+
+// NewMergeConfirmModal creates a merge confirmation for the given preview.
+func NewMergeConfirmModal(agentID, agentName string, preview *domain.MergePreview, width, height int) MergeConfirmModel {
+	body := viewport.New(0, 0)
+	body.SetContent(renderMergePreview(preview))
+
+	m := MergeConfirmModel{
+		agentID:   agentID,
+		agentName: agentName,
+		preview:   preview,
+		strategy:  preview.Strategy,
+		body:      body,
+		width:     width,
+		height:    height,
+	}
+	m.fitBody()
+	return m
+}
+
+// fitBody sizes the preview to the modal, shrinking it to the content so short
+// previews don't leave a tall empty box.
+func (m *MergeConfirmModel) fitBody() {
+	m.body.Width = max(m.width-10, 20)
+	m.body.Height = min(max(m.height-12, 5), m.body.TotalLineCount())
+	m.body.SetYOffset(m.body.YOffset)
+}
+
+func (m MergeConfirmModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m MergeConfirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width, m.height = size.Width, size.Height
+		m.fitBody()
+		return m, nil
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "enter":
+			return m, func() tea.Msg {
+				return MergeConfirmedMsg{AgentID: m.agentID, AgentName: m.agentName, Strategy: m.strategy}
+			}
+		case "s":
+			m.strategy = nextMergeStrategy(m.strategy)
+			return m, nil
+		case "esc":
+			return m, func() tea.Msg {
+				return CloseModalMsg{}
+			}
+		}
+	}
+	var cmd tea.Cmd
+	m.body, cmd = m.body.Update(msg)
+	return m, cmd
+}
+
+// KeyHints returns the merge confirmation's keys.
+func (m MergeConfirmModel) KeyHints() []keyBinding {
+	return []keyBinding{{key: "↑/↓", desc: "scroll"}, {key: "s", desc: "strategy"}, {key: "enter", desc: "merge"}, {key: "esc", desc: "cancel"}}
+}
+
+// nextMergeStrategy returns the strategy after strategy in domain.MergeStrategies.
+func nextMergeStrategy(strategy domain.MergeStrategy) domain.MergeStrategy {
+	for i, s := range domain.MergeStrategies {
+		if s == strategy {
+			return domain.MergeStrategies[(i+1)%len(domain.MergeStrategies)]
+		}
+	}
+	return domain.MergeStrategies[0]
+}
+
+func (m MergeConfirmModel) View() string {
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		theme.ModalTitle.Render("Merge: "+m.agentName),
+		theme.TextMuted.Render(fmt.Sprintf("%s into %s • strategy: %s", m.preview.Branch, m.preview.BaseBranch, m.strategy)),
+		"",
+		m.body.View(),
+	)
+
+	box := theme.ModalBorder.
+		Padding(1, 3).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// renderMergePreview lists the commits to land followed by the diffstat.
+func renderMergePreview(preview *domain.MergePreview) string {
+	if len(preview.Commits) == 0 {
+		return theme.SideMenuEmpty.Render("Nothing to merge: no commits ahead of " + preview.BaseBranch)
+	}
+
+	lines := []string{theme.TextNormal.Bold(true).Render(fmt.Sprintf("%d commit(s)", len(preview.Commits)))}
+	for _, c := range preview.Commits {
+		lines = append(lines, "  "+theme.TextMuted.Render(c.Hash)+"  "+theme.TextNormal.Render(c.Subject))
+	}
+	if preview.DiffStat != "" {
+		lines = append(lines, "", theme.TextNormal.Render(strings.TrimRight(preview.DiffStat, "\n")))
+	}
+	return strings.Join(lines, "\n")
+}
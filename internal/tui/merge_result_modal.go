@@ -44,6 +44,9 @@ func (m MergeResultModel) Init() tea.Cmd {
 
 func (m MergeResultModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
 	case tea.KeyMsg:
 		// For successful merges, just close on any key
 		if m.success {
@@ -93,8 +96,16 @@ func (m MergeResultModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// KeyHints returns the merge result's keys; conflicts offer a choice.
+func (m MergeResultModel) KeyHints() []keyBinding {
+	if m.success {
+		return []keyBinding{{key: "enter", desc: "close"}}
+	}
+	return []keyBinding{{key: "←/→", desc: "select"}, {key: "enter", desc: "confirm"}, {key: "esc", desc: "cancel"}}
+}
+
 func (m MergeResultModel) View() string {
-	var title, message, hint string
+	var title, message string
 
 	titleStyle := lipgloss.NewStyle().Bold(true)
 	messageStyle := lipgloss.NewStyle()
@@ -108,9 +119,6 @@ func (m MergeResultModel) View() string {
 				Foreground(lipgloss.Color("245")).
 				Render("(Your stashed changes have been restored)")
 		}
-		hint = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("245")).
-			Render("Press Enter to close")
 	} else {
 		titleStyle = titleStyle.Foreground(lipgloss.Color("196")) // Red
 		title = titleStyle.Render("Merge Failed")
@@ -171,18 +179,12 @@ func (m MergeResultModel) View() string {
 
 		buttons := lipgloss.JoinHorizontal(lipgloss.Center, sendBtn, "  ", cancelBtn)
 
-		hint = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("245")).
-			Render("Use ←/→ to select, Enter to confirm")
-
 		content := lipgloss.JoinVertical(lipgloss.Center,
 			title,
 			"",
 			message,
 			"",
 			buttons,
-			"",
-			hint,
 		)
 
 		box := lipgloss.NewStyle().
@@ -198,8 +200,6 @@ func (m MergeResultModel) View() string {
 		title,
 		"",
 		message,
-		"",
-		hint,
 	)
 
 	box := lipgloss.NewStyle().
@@ -17,13 +17,28 @@ type AgentSelectedMsg struct {
 
 // AgentCreatedMsg is sent when a user confirms agent creation with a custom name.
 type AgentCreatedMsg struct {
-	Agent      config.Agent
-	CustomName string
+	Agent       config.Agent
+	CustomName  string
+	SparsePaths []string
+	Prompt      string // startup prompt, from AGENTS.yml or the prompt library
+}
+
+// AgentCreateDoneMsg reports the result of creating an agent.
+type AgentCreateDoneMsg struct {
+	Agent *domain.Agent
+	Err   error
 }
 
 // AgentsUpdatedMsg signals that the agent list has changed and UI should refresh.
 type AgentsUpdatedMsg struct {
-	Agents []*domain.Agent
+	Agents    []*domain.Agent
+	Attention map[string]domain.Attention // Set only while sorting by attention
+}
+
+// attentionTickMsg re-scores attention while the attention sort is on.
+// seq identifies the tick loop so toggling the sort doesn't start a second one.
+type attentionTickMsg struct {
+	seq int
 }
 
 // PreviewTickMsg signals that it's time to poll for preview updates.
@@ -33,6 +48,7 @@ type PreviewTickMsg time.Time
 type PreviewUpdatedMsg struct {
 	SessionID string
 	Content   string
+	Lines     int // how many lines back the capture started
 }
 
 // KillConfirmChoice represents the user's choice in the kill confirmation modal.
@@ -76,3 +92,174 @@ type MergeConflictResultMsg struct {
 	ConflictFiles []string
 	Choice        MergeConflictChoice
 }
+
+// MergeProtectionCheckedMsg is sent after checking whether an agent's base branch is protected.
+type MergeProtectionCheckedMsg struct {
+	AgentID    string
+	AgentName  string
+	BaseBranch string
+	Protected  bool
+}
+
+// ProtectedMergeChoice represents the user's choice in the protected branch modal.
+type ProtectedMergeChoice int
+
+const (
+	ProtectedMergeCancel ProtectedMergeChoice = iota
+	ProtectedMergePullRequest
+	ProtectedMergeLocal
+)
+
+// ProtectedMergeResultMsg is sent when the user makes a choice in the protected branch modal.
+type ProtectedMergeResultMsg struct {
+	AgentID   string
+	AgentName string
+	Choice    ProtectedMergeChoice
+}
+
+// PullRequestResultMsg is sent when a push-and-PR operation completes.
+type PullRequestResultMsg struct {
+	AgentName string
+	URL       string
+	Err       error
+}
+
+// OpenRetargetMsg is sent from the detail view to start retargeting an agent.
+type OpenRetargetMsg struct {
+	Agent *domain.Agent
+}
+
+// RetargetConfirmedMsg is sent when the user enters a new base branch for an agent.
+type RetargetConfirmedMsg struct {
+	AgentID   string
+	AgentName string
+	NewBase   string
+}
+
+// RetargetResultMsg is sent when a retarget operation completes.
+type RetargetResultMsg struct {
+	AgentName string
+	NewBase   string
+	Err       error
+}
+
+// CommitWorkConfirmedMsg is sent when the user enters a message to commit an
+// agent's uncommitted work with.
+type CommitWorkConfirmedMsg struct {
+	AgentID   string
+	AgentName string
+	Message   string
+}
+
+// CommitWorkResultMsg is sent when committing an agent's work completes.
+type CommitWorkResultMsg struct {
+	AgentName string
+	Err       error
+}
+
+// ReconcileDoneMsg is sent when the background startup reconcile completes.
+type ReconcileDoneMsg struct {
+	Report *domain.ReconcileReport
+	Err    error
+}
+
+// ShowToastMsg asks the dashboard to show a transient notification.
+type ShowToastMsg struct {
+	Text string
+}
+
+// toastExpiredMsg hides the toast identified by seq.
+type toastExpiredMsg struct {
+	seq int
+}
+
+// CopyRequestMsg is sent from the copy menu with the value to copy.
+type CopyRequestMsg struct {
+	Label string
+	Text  string
+}
+
+// CopyResultMsg is sent when a clipboard copy completes.
+type CopyResultMsg struct {
+	Label string
+	Text  string
+	Err   error
+}
+
+// OpenFinishedMsg is sent when the open command for a worktree exits.
+type OpenFinishedMsg struct {
+	Path string
+	Err  error
+}
+
+// OpenURLMsg asks to open a link, such as an agent's pull request, in the browser.
+type OpenURLMsg struct {
+	URL string
+}
+
+// OpenCommitLogMsg is sent from the detail view to list an agent's branch commits.
+type OpenCommitLogMsg struct {
+	Agent *domain.Agent
+}
+
+// CommitLogLoadedMsg is sent when an agent's branch commits have been loaded.
+type CommitLogLoadedMsg struct {
+	Commits []domain.Commit
+	Err     error
+}
+
+// CommitDiffRequestMsg is sent from the commit log to show a single commit.
+type CommitDiffRequestMsg struct {
+	AgentID string
+	Hash    string
+}
+
+// CommitDiffLoadedMsg is sent when a commit's diff has been loaded.
+type CommitDiffLoadedMsg struct {
+	Hash string
+	Diff string
+	Err  error
+}
+
+// StashesLoadedMsg is sent when crAIzy's stashes have been listed.
+type StashesLoadedMsg struct {
+	Stashes []domain.StashEntry
+	Err     error
+}
+
+// StashAction is what to do with a stash chosen in the stash browser.
+type StashAction int
+
+const (
+	StashPop StashAction = iota
+	StashToBranch
+	StashDrop
+)
+
+// StashActionMsg is sent from the stash browser to pop, branch or drop a stash.
+type StashActionMsg struct {
+	Action StashAction
+	Ref    string
+	Branch string // new branch name for StashToBranch
+}
+
+// StashActionDoneMsg reports the result of a StashActionMsg.
+type StashActionDoneMsg struct {
+	Result string
+	Err    error
+}
+
+// MergePreviewLoadedMsg is sent when what a merge would land has been loaded.
+type MergePreviewLoadedMsg struct {
+	AgentID   string
+	AgentName string
+	Preview   *domain.MergePreview
+	Err       error
+}
+
+// MergeConfirmedMsg is sent when the user confirms a merge after reviewing its preview.
+type MergeConfirmedMsg struct {
+	AgentID   string
+	AgentName string
+	Strategy  domain.MergeStrategy
+}
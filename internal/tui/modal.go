@@ -7,40 +7,86 @@ import (
 
 // Modal is a generic wrapper for modal content.
 // It handles centering and overlaying content on top of the application.
+// Modals stack: opening one while another is open shows it on top, and
+// closing it returns to the one beneath, so nested flows can step back.
 type Modal struct {
-	content tea.Model
-	isOpen  bool
-	width   int
-	height  int
+	stack  []tea.Model
+	width  int
+	height int
 }
 
+// MaxModalWidth and MaxModalHeight cap the size modal content is laid out
+// for, so on huge terminals modals stay readable boxes centered on the screen.
+const (
+	MaxModalWidth  = 160
+	MaxModalHeight = 60
+)
+
 func NewModal() Modal {
 	return Modal{}
 }
 
+// SetSize sets the screen size, resizing every open modal to it.
 func (m *Modal) SetSize(w, h int) {
 	m.width = w
 	m.height = h
+	for i, content := range m.stack {
+		m.stack[i] = m.resize(content)
+	}
 }
 
+// Open shows content on top of any modal already open, sized to the screen.
 func (m *Modal) Open(content tea.Model) {
-	m.content = content
-	m.isOpen = true
+	m.stack = append(m.stack, m.resize(content))
+}
+
+// resize sends content a tea.WindowSizeMsg with the screen size, capped at
+// MaxModalWidth by MaxModalHeight. Until the screen size is known, content
+// keeps the size it was created with.
+func (m Modal) resize(content tea.Model) tea.Model {
+	if m.width == 0 {
+		return content
+	}
+	content, _ = content.Update(tea.WindowSizeMsg{Width: min(m.width, MaxModalWidth), Height: min(m.height, MaxModalHeight)})
+	return content
 }
 
+// Close closes the top modal, going back to the one beneath if any.
 func (m *Modal) Close() {
-	m.content = nil
-	m.isOpen = false
+	if len(m.stack) > 0 {
+		m.stack = m.stack[:len(m.stack)-1]
+	}
+}
+
+// CloseAll closes every open modal, ending the whole flow.
+func (m *Modal) CloseAll() {
+	m.stack = nil
 }
 
 func (m *Modal) IsOpen() bool {
-	return m.isOpen
+	return len(m.stack) > 0
+}
+
+// Top returns the modal being shown, or nil when none is open.
+func (m Modal) Top() tea.Model {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	return m.stack[len(m.stack)-1]
+}
+
+// KeyHints returns the open content's keys for the quick commands bar, if it declares any.
+func (m Modal) KeyHints() []keyBinding {
+	if hinter, ok := m.Top().(keyHinter); ok {
+		return hinter.KeyHints()
+	}
+	return nil
 }
 
 // Init initializes the modal content if it exists
 func (m *Modal) Init() tea.Cmd {
-	if m.content != nil {
-		return m.content.Init()
+	if top := m.Top(); top != nil {
+		return top.Init()
 	}
 	return nil
 }
@@ -48,12 +94,13 @@ func (m *Modal) Init() tea.Cmd {
 // Update handles messages for the modal content.
 // Returns a command and whether the message was handled/consumed.
 func (m *Modal) Update(msg tea.Msg) (tea.Cmd, bool) {
-	if !m.isOpen || m.content == nil {
+	if !m.IsOpen() {
 		return nil, false
 	}
 
 	var cmd tea.Cmd
-	m.content, cmd = m.content.Update(msg)
+	top := len(m.stack) - 1
+	m.stack[top], cmd = m.stack[top].Update(msg)
 	return cmd, true
 }
 
@@ -63,11 +110,11 @@ func (m *Modal) Update(msg tea.Msg) (tea.Cmd, bool) {
 // more complex string manipulation would be required, or simply accepting
 // that the background is hidden/blanked out is the standard TUI approach.
 func (m Modal) View() string {
-	if !m.isOpen || m.content == nil {
+	if !m.IsOpen() {
 		return ""
 	}
 
-	modalView := m.content.View()
+	modalView := m.Top().View()
 
 	// Create a centered box for the modal
 	return lipgloss.Place(
@@ -1,6 +1,8 @@
 package tui
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -11,21 +13,35 @@ import (
 
 type NameInputModel struct {
 	textInput     textinput.Model
+	sparseInput   textinput.Model
+	sparseDefault string // sparse paths pre-filled from AGENTS.yml
 	selectedAgent config.Agent
+	prompts       []config.Prompt // prompt library, see promptChoice
+	promptIndex   int             // 0 is the agent's own prompt, then prompts[promptIndex-1]
+	promptFocused bool
 	width         int
 	height        int
 }
 
-func NewNameInput(agent config.Agent, width, height int) NameInputModel {
+func NewNameInput(agent config.Agent, prompts []config.Prompt, width, height int) NameInputModel {
 	ti := textinput.New()
 	ti.Placeholder = "Enter a name for this session"
 	ti.Focus()
 	ti.CharLimit = 50
 	ti.Width = 30
 
+	// Optional sparse checkout directories, pre-filled from AGENTS.yml
+	si := textinput.New()
+	si.Placeholder = "Sparse paths (optional, comma separated)"
+	si.Width = 30
+	si.SetValue(strings.Join(agent.SparsePaths, ", "))
+
 	return NameInputModel{
 		textInput:     ti,
+		sparseInput:   si,
+		sparseDefault: si.Value(),
 		selectedAgent: agent,
+		prompts:       prompts,
 		width:         width,
 		height:        height,
 	}
@@ -39,41 +55,127 @@ func (m NameInputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
 	case tea.KeyMsg:
 		switch msg.Type {
 		case tea.KeyEnter:
 			return m, func() tea.Msg {
 				return AgentCreatedMsg{
-					Agent:      m.selectedAgent,
-					CustomName: m.textInput.Value(),
+					Agent:       m.selectedAgent,
+					CustomName:  m.textInput.Value(),
+					SparsePaths: parseSparsePaths(m.sparseInput.Value()),
+					Prompt:      m.promptBody(),
 				}
 			}
 		case tea.KeyEsc:
 			return m, func() tea.Msg {
 				return CloseModalMsg{}
 			}
+		case tea.KeyTab, tea.KeyShiftTab:
+			switch {
+			case m.textInput.Focused():
+				m.textInput.Blur()
+				return m, m.sparseInput.Focus()
+			case m.sparseInput.Focused() && len(m.prompts) > 0:
+				m.sparseInput.Blur()
+				m.promptFocused = true
+				return m, nil
+			}
+			m.sparseInput.Blur()
+			m.promptFocused = false
+			return m, m.textInput.Focus()
+		case tea.KeyLeft, tea.KeyRight:
+			if m.promptFocused {
+				step := 1
+				if msg.Type == tea.KeyLeft {
+					step = len(m.prompts)
+				}
+				m.promptIndex = (m.promptIndex + step) % (len(m.prompts) + 1)
+				return m, nil
+			}
 		}
 	}
 
+	if m.promptFocused {
+		return m, nil
+	}
+
+	if m.sparseInput.Focused() {
+		m.sparseInput, cmd = m.sparseInput.Update(msg)
+		return m, cmd
+	}
 	m.textInput, cmd = m.textInput.Update(msg)
 	return m, cmd
 }
 
+// HasUnsavedInput reports whether a name was typed or a default changed.
+func (m NameInputModel) HasUnsavedInput() bool {
+	return m.textInput.Value() != "" || m.sparseInput.Value() != m.sparseDefault || m.promptIndex != 0
+}
+
+// KeyHints returns the name input's keys; the prompt choice needs a prompt library.
+func (m NameInputModel) KeyHints() []keyBinding {
+	bindings := []keyBinding{{key: "tab", desc: "switch field"}}
+	if len(m.prompts) > 0 {
+		bindings = append(bindings, keyBinding{key: "←/→", desc: "choose prompt"})
+	}
+	return append(bindings, keyBinding{key: "enter", desc: "create"}, keyBinding{key: "esc", desc: "back"})
+}
+
 func (m NameInputModel) View() string {
 	title := theme.ModalTitle.
 		Render("Name your " + m.selectedAgent.Name + " Agent")
 
 	input := m.textInput.View()
+	sparse := m.sparseInput.View()
+
+	lines := []string{title, "\n", input, sparse}
+	if len(m.prompts) > 0 {
+		style := theme.TextMuted
+		if m.promptFocused {
+			style = theme.TextNormal
+		}
+		lines = append(lines, style.Render("Prompt: ‹ "+m.promptLabel()+" ›"))
+	}
 
 	box := theme.ModalBorder.
 		Padding(1, 2).
 		Render(
-			lipgloss.JoinVertical(lipgloss.Center,
-				title,
-				"\n",
-				input,
-			),
+			lipgloss.JoinVertical(lipgloss.Center, lines...),
 		)
 
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
 }
+
+// promptLabel names the chosen startup prompt.
+func (m NameInputModel) promptLabel() string {
+	if m.promptIndex > 0 {
+		return m.prompts[m.promptIndex-1].Name
+	}
+	if m.selectedAgent.Prompt != "" {
+		return "AGENTS.yml default"
+	}
+	return "none"
+}
+
+// promptBody returns the chosen startup prompt: the agent's own from AGENTS.yml
+// or one from the prompt library.
+func (m NameInputModel) promptBody() string {
+	if m.promptIndex > 0 {
+		return m.prompts[m.promptIndex-1].Body
+	}
+	return m.selectedAgent.Prompt
+}
+
+// parseSparsePaths splits a comma separated list of directories, dropping blanks.
+func parseSparsePaths(value string) []string {
+	var paths []string
+	for _, p := range strings.Split(value, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/config"
+)
+
+func TestParseSparsePaths(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []string
+	}{
+		{"", nil},
+		{"api", []string{"api"}},
+		{" api , web/app ,, ", []string{"api", "web/app"}},
+	}
+
+	for _, tt := range tests {
+		got := parseSparsePaths(tt.input)
+		if len(got) != len(tt.want) {
+			t.Errorf("parseSparsePaths(%q) = %v, want %v", tt.input, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("parseSparsePaths(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		}
+	}
+}
+
+func TestNameInputModel_Enter(t *testing.T) {
+	agent := config.Agent{Name: "Claude", Command: "claude", SparsePaths: []string{"api"}}
+	m := NewNameInput(agent, nil, 80, 24)
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected command on enter")
+	}
+
+	msg, ok := cmd().(AgentCreatedMsg)
+	if !ok {
+		t.Fatalf("expected AgentCreatedMsg, got %T", cmd())
+	}
+	if len(msg.SparsePaths) != 1 || msg.SparsePaths[0] != "api" {
+		t.Errorf("SparsePaths = %v, want pre-filled [api]", msg.SparsePaths)
+	}
+}
+
+func TestNameInputModel_PromptChoice(t *testing.T) {
+	agent := config.Agent{Name: "Claude", Command: "claude", Prompt: "default prompt"}
+	prompts := []config.Prompt{{Name: "review", Body: "review {branch}"}}
+	var m tea.Model = NewNameInput(agent, prompts, 80, 24)
+
+	created := func(m tea.Model) AgentCreatedMsg {
+		t.Helper()
+		_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		return cmd().(AgentCreatedMsg)
+	}
+
+	if got := created(m).Prompt; got != "default prompt" {
+		t.Errorf("Prompt = %q, want the AGENTS.yml prompt", got)
+	}
+
+	// Tab to the prompt field and pick the library prompt
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if got := created(m).Prompt; got != "review {branch}" {
+		t.Errorf("Prompt = %q, want the library prompt", got)
+	}
+
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if got := created(m).Prompt; got != "default prompt" {
+		t.Errorf("Prompt = %q, want to cycle back to the AGENTS.yml prompt", got)
+	}
+}
+
+func TestNameInputModel_HasUnsavedInput(t *testing.T) {
+	agent := config.Agent{Name: "Claude", Command: "claude", SparsePaths: []string{"api"}}
+	m := NewNameInput(agent, nil, 80, 24)
+	if m.HasUnsavedInput() {
+		t.Error("expected no unsaved input with the AGENTS.yml defaults")
+	}
+
+	m.textInput.SetValue("auth")
+	if !m.HasUnsavedInput() {
+		t.Error("expected a typed name to count as unsaved input")
+	}
+}
@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// IdleNotifyInterval is how often agents whose rules ask for it are checked
+// for going idle.
+const IdleNotifyInterval = 30 * time.Second
+
+// idleNotifyTickMsg triggers an idle check.
+type idleNotifyTickMsg struct{}
+
+// IdleCheckedMsg carries the agents that asked to be notified about and are idle.
+type IdleCheckedMsg struct {
+	Agents []*domain.Agent
+}
+
+// NotifyRulesRequestMsg asks to store an agent's notification rules.
+type NotifyRulesRequestMsg struct {
+	AgentID string
+	Rules   domain.NotifyRules
+}
+
+// NotifyRulesSavedMsg reports the result of storing an agent's notification rules.
+type NotifyRulesSavedMsg struct {
+	AgentID string
+	Rules   domain.NotifyRules
+	Err     error
+}
+
+// pollIdleNotify returns a command that ticks the idle check.
+func (m Model) pollIdleNotify() tea.Cmd {
+	if m.agentService == nil {
+		return nil
+	}
+	return tea.Tick(IdleNotifyInterval, func(time.Time) tea.Msg {
+		return idleNotifyTickMsg{}
+	})
+}
+
+// checkIdleNotify returns a command that lists idle agents to notify about.
+func (m Model) checkIdleNotify() tea.Cmd {
+	return func() tea.Msg {
+		return IdleCheckedMsg{Agents: m.agentService.IdleToNotify(time.Now())}
+	}
+}
+
+// updateIdleNotify rings the bell and shows a toast for agents that went idle
+// since the last check. Each is notified once per idle stretch.
+func (m *Model) updateIdleNotify(msg IdleCheckedMsg) tea.Cmd {
+	idle := make(map[string]bool, len(msg.Agents))
+	var names []string
+	for _, agent := range msg.Agents {
+		idle[agent.ID] = true
+		if !m.idleNotified[agent.ID] {
+			names = append(names, agent.Name)
+		}
+	}
+	m.idleNotified = idle
+	if len(names) == 0 || m.isPortedIn {
+		return nil
+	}
+	_, _ = io.WriteString(bellWriter, "\a")
+	return m.toast.Show("Idle: " + strings.Join(names, ", "))
+}
+
+// saveNotifyRules returns a command that stores an agent's notification rules.
+func (m Model) saveNotifyRules(request NotifyRulesRequestMsg) tea.Cmd {
+	if m.agentService == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		err := m.agentService.SetNotifyRules(request.AgentID, request.Rules)
+		if err != nil {
+			logging.Error(err, "agentID", request.AgentID, "action", "save notify rules")
+		}
+		return NotifyRulesSavedMsg{AgentID: request.AgentID, Rules: request.Rules, Err: err}
+	}
+}
+
+// mutedMessage reports whether the sender's notification rules mute a message.
+func (m Model) mutedMessage(message *domain.Message) bool {
+	for _, agent := range m.sideMenu.agents {
+		if agent.ID == message.From {
+			return agent.NotifyRules().Mutes(message.Type)
+		}
+	}
+	return false
+}
+
+// notifyRulesSummary describes an agent's notification rules for the detail view.
+func notifyRulesSummary(rules domain.NotifyRules) string {
+	var parts []string
+	if rules.Idle {
+		parts = append(parts, "when idle")
+	}
+	for _, t := range rules.Muted {
+		parts = append(parts, string(t)+" muted")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestModel_updateIdleNotify(t *testing.T) {
+	previous := bellWriter
+	bellWriter = io.Discard
+	t.Cleanup(func() { bellWriter = previous })
+	auth := &domain.Agent{ID: "a", Name: "auth"}
+
+	m := NewModel(nil, nil)
+	if cmd := m.updateIdleNotify(IdleCheckedMsg{Agents: []*domain.Agent{auth}}); cmd == nil || !strings.Contains(m.toast.text, "Idle: auth") {
+		t.Errorf("toast = %q, want auth reported idle", m.toast.text)
+	}
+
+	m.toast.text = ""
+	if cmd := m.updateIdleNotify(IdleCheckedMsg{Agents: []*domain.Agent{auth}}); cmd != nil {
+		t.Error("expected no second notification while auth stays idle")
+	}
+
+	m.updateIdleNotify(IdleCheckedMsg{})
+	if cmd := m.updateIdleNotify(IdleCheckedMsg{Agents: []*domain.Agent{auth}}); cmd == nil {
+		t.Error("expected a notification once auth goes idle again")
+	}
+}
+
+func TestModel_updateInbox_MutedMessages(t *testing.T) {
+	muted := &domain.Agent{ID: "auth", Name: "auth", Metadata: map[string]string{domain.MetadataNotifyMute: "status"}}
+	m := NewModel(nil, nil)
+	newModel, _ := m.Update(AgentsUpdatedMsg{Agents: []*domain.Agent{muted}})
+	m = newModel.(Model)
+	m.updateInbox(InboxCheckedMsg{})
+
+	status := &domain.Message{ID: "a", Seq: 1, From: "auth", Type: domain.MessageTypeStatus, Content: "Halfway"}
+	if cmd := m.updateInbox(InboxCheckedMsg{Unread: []*domain.Message{status}}); cmd != nil || m.toast.Visible() {
+		t.Error("expected no toast for a muted status message")
+	}
+	if got := m.quickCommands.Badge(); got != "✉ 1 unread" {
+		t.Errorf("Badge() = %q, want muted messages counted", got)
+	}
+
+	question := &domain.Message{ID: "b", Seq: 2, From: "auth", Type: domain.MessageTypeQuestion, Content: "Which library?"}
+	m.updateInbox(InboxCheckedMsg{Unread: []*domain.Message{status, question}})
+	if !strings.Contains(m.toast.text, "M-2 from auth") {
+		t.Errorf("toast = %q, want the unmuted question", m.toast.text)
+	}
+}
@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// pathPlaceholder is replaced with the worktree path in open command templates.
+const pathPlaceholder = "{path}"
+
+// linePlaceholder is replaced with the line number when opening a file reference.
+const linePlaceholder = "{line}"
+
+// plusLineEditors take "+N" before a file to open it at line N.
+var plusLineEditors = map[string]bool{
+	"vi": true, "vim": true, "nvim": true, "nano": true, "emacs": true, "micro": true, "kak": true,
+}
+
+// resolveOpenCommand returns the open command template to use: the configured
+// one, else $VISUAL, else $EDITOR. It returns "" when none is set.
+func resolveOpenCommand(configured string) string {
+	for _, candidate := range []string{configured, os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if strings.TrimSpace(candidate) != "" {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// buildOpenCmd expands template for path. Every {path} is replaced with the
+// path; if the template has no placeholder the path is appended as the last argument.
+func buildOpenCmd(template, path string) *exec.Cmd {
+	fields := strings.Fields(template)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	substituted := false
+	for i, field := range fields {
+		if strings.Contains(field, pathPlaceholder) {
+			fields[i] = strings.ReplaceAll(field, pathPlaceholder, path)
+			substituted = true
+		}
+	}
+	if !substituted {
+		fields = append(fields, path)
+	}
+
+	cmd := exec.Command(fields[0], fields[1:]...)
+	cmd.Dir = path
+	return cmd
+}
+
+// buildOpenFileCmd expands template to open a file reference relative to dir.
+// {path} is the file and {line} its first line; without {line}, editors known
+// to take "+N" get it before the file.
+func buildOpenFileCmd(template, dir string, ref domain.FileRef) *exec.Cmd {
+	fields := strings.Fields(template)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	path := ref.Path
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	line := strconv.Itoa(max(ref.Start, 1))
+
+	substituted, hasLine := false, false
+	for i, field := range fields {
+		hasLine = hasLine || strings.Contains(field, linePlaceholder)
+		if strings.Contains(field, pathPlaceholder) {
+			substituted = true
+		}
+		fields[i] = strings.NewReplacer(pathPlaceholder, path, linePlaceholder, line).Replace(field)
+	}
+	if !substituted {
+		if !hasLine && ref.Start > 0 && plusLineEditors[filepath.Base(fields[0])] {
+			fields = append(fields, "+"+line)
+		}
+		fields = append(fields, path)
+	}
+
+	cmd := exec.Command(fields[0], fields[1:]...)
+	cmd.Dir = dir
+	return cmd
+}
+
+// openFileRef returns a command that opens a file reference from a message in
+// the editor. Paths are relative to the sender's worktree, or the project
+// when the sender isn't a known agent.
+func (m *Model) openFileRef(from string, ref domain.FileRef) tea.Cmd {
+	dir, _ := os.Getwd()
+	for _, agent := range m.sideMenu.agents {
+		if agent.ID == from && agent.WorkDir != "" {
+			dir = agent.WorkDir
+		}
+	}
+
+	cmd := buildOpenFileCmd(resolveOpenCommand(m.openCommand), dir, ref)
+	if cmd == nil {
+		return m.toast.Show("File: " + ref.String() + " (set open_command or $EDITOR to open it)")
+	}
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return OpenFinishedMsg{Path: ref.Path, Err: err}
+	})
+}
+
+// openWorktree returns a command that opens path with the resolved open command,
+// handing it the terminal so terminal editors work. Without any configured
+// command it shows the path instead.
+func (m *Model) openWorktree(path string) tea.Cmd {
+	cmd := buildOpenCmd(resolveOpenCommand(m.openCommand), path)
+	if cmd == nil {
+		return m.toast.Show("Worktree: " + path + " (set open_command or $EDITOR to open it)")
+	}
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return OpenFinishedMsg{Path: path, Err: err}
+	})
+}
+
+// browserCommand returns the command that opens url in the browser: $BROWSER
+// if set, else the platform's opener. It returns nil when there is none.
+func browserCommand(url string) *exec.Cmd {
+	if browser := os.Getenv("BROWSER"); strings.TrimSpace(browser) != "" {
+		fields := strings.Fields(browser)
+		return exec.Command(fields[0], append(fields[1:], url)...)
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.Command("open", url)
+	case "windows":
+		return exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	}
+	if _, err := exec.LookPath("xdg-open"); err == nil {
+		return exec.Command("xdg-open", url)
+	}
+	return nil
+}
+
+// openURL returns a command that opens url in the browser without leaving the
+// dashboard. Without a browser to open it shows the link instead.
+func (m *Model) openURL(url string) tea.Cmd {
+	cmd := browserCommand(url)
+	if cmd == nil || cmd.Start() != nil {
+		return m.toast.Show("Link: " + url + " (set $BROWSER to open it)")
+	}
+	go func() { _ = cmd.Wait() }()
+	return m.toast.Show("Opened " + url)
+}
@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestResolveOpenCommand(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "vim")
+
+	if got := resolveOpenCommand("code {path}"); got != "code {path}" {
+		t.Errorf("configured command = %q, want code {path}", got)
+	}
+	if got := resolveOpenCommand(""); got != "vim" {
+		t.Errorf("fallback = %q, want $EDITOR", got)
+	}
+
+	t.Setenv("VISUAL", "nvim")
+	if got := resolveOpenCommand(""); got != "nvim" {
+		t.Errorf("fallback = %q, want $VISUAL before $EDITOR", got)
+	}
+
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+	if got := resolveOpenCommand(""); got != "" {
+		t.Errorf("no command = %q, want empty", got)
+	}
+}
+
+func TestBuildOpenCmd(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     []string
+	}{
+		{"appends path", "code -n", []string{"code", "-n", "/wt/auth"}},
+		{"replaces placeholder", "tmux new-window -c {path}", []string{"tmux", "new-window", "-c", "/wt/auth"}},
+		{"placeholder inside argument", "open --dir={path}", []string{"open", "--dir=/wt/auth"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := buildOpenCmd(tt.template, "/wt/auth")
+
+			if !reflect.DeepEqual(cmd.Args, tt.want) {
+				t.Errorf("Args = %v, want %v", cmd.Args, tt.want)
+			}
+			if cmd.Dir != "/wt/auth" {
+				t.Errorf("Dir = %q, want worktree path", cmd.Dir)
+			}
+		})
+	}
+
+	t.Run("empty template", func(t *testing.T) {
+		if buildOpenCmd("  ", "/wt/auth") != nil {
+			t.Error("empty template should not build a command")
+		}
+	})
+}
+
+func TestBuildOpenFileCmd(t *testing.T) {
+	ref := domain.FileRef{Path: "auth/token.go", Start: 40, End: 58}
+
+	tests := []struct {
+		name     string
+		template string
+		ref      domain.FileRef
+		want     []string
+	}{
+		{"plus line for vim", "vim", ref, []string{"vim", "+40", "/wt/auth/auth/token.go"}},
+		{"no line for whole file", "nvim", domain.FileRef{Path: "README.md"}, []string{"nvim", "/wt/auth/README.md"}},
+		{"unknown editor gets path only", "code -n", ref, []string{"code", "-n", "/wt/auth/auth/token.go"}},
+		{"line placeholder", "code -g {path}:{line}", ref, []string{"code", "-g", "/wt/auth/auth/token.go:40"}},
+		{"absolute path", "vim", domain.FileRef{Path: "/etc/hosts", Start: 2, End: 2}, []string{"vim", "+2", "/etc/hosts"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := buildOpenFileCmd(tt.template, "/wt/auth", tt.ref)
+
+			if !reflect.DeepEqual(cmd.Args, tt.want) {
+				t.Errorf("Args = %v, want %v", cmd.Args, tt.want)
+			}
+			if cmd.Dir != "/wt/auth" {
+				t.Errorf("Dir = %q, want worktree path", cmd.Dir)
+			}
+		})
+	}
+}
+
+func TestBrowserCommand(t *testing.T) {
+	t.Setenv("BROWSER", "firefox --new-tab")
+
+	cmd := browserCommand("https://example.com/pr/1")
+
+	if want := []string{"firefox", "--new-tab", "https://example.com/pr/1"}; cmd == nil || !reflect.DeepEqual(cmd.Args, want) {
+		t.Errorf("browserCommand = %v, want %v", cmd, want)
+	}
+}
+
+func TestAgentDetailModel_OpenLinks(t *testing.T) {
+	agent := &domain.Agent{ID: "agent-1", Name: "auth", Metadata: map[string]string{
+		domain.MetadataPRURL: "https://example.com/pr/1",
+	}}
+	modal := NewAgentDetailModal(agent, nil, 100, 40)
+
+	_, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if cmd == nil {
+		t.Fatal("o returned no command")
+	}
+	if msg, ok := cmd().(OpenURLMsg); !ok || msg.URL != "https://example.com/pr/1" {
+		t.Errorf("o sent %v, want OpenURLMsg for the PR", msg)
+	}
+	if _, cmd := modal.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("O")}); cmd != nil {
+		t.Error("O should do nothing without an issue link")
+	}
+	if view := modal.View(); !strings.Contains(view, "https://example.com/pr/1") {
+		t.Error("detail view should show the PR link")
+	}
+}
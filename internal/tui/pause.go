@@ -0,0 +1,30 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// togglePause pauses a running agent or resumes a paused one, reporting the
+// result in a toast.
+func (m *Model) togglePause(agent *domain.Agent) tea.Cmd {
+	if agent.Status == domain.AgentStatusPaused {
+		if err := m.agentService.Resume(agent.ID); err != nil {
+			return m.toast.Show("Resume failed: " + err.Error())
+		}
+		return tea.Batch(m.toast.Show("Resumed "+agent.Name), m.refreshAgents())
+	}
+	if err := m.agentService.Pause(agent.ID); err != nil {
+		return m.toast.Show("Pause failed: " + err.Error())
+	}
+	return tea.Batch(m.toast.Show("Paused "+agent.Name+", p to resume"), m.refreshAgents())
+}
+
+func agentRunning(c hintContext) bool {
+	return c.agent != nil && c.agent.Status == domain.AgentStatusActive
+}
+
+func agentPaused(c hintContext) bool {
+	return c.agent != nil && c.agent.Status == domain.AgentStatusPaused
+}
@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestAgentListItem_Paused(t *testing.T) {
+	agent := &domain.Agent{Name: "auth", AgentType: "claude", Status: domain.AgentStatusPaused}
+	item := AgentListItem{agent: agent, glyph: statusGlyph(UnicodeGlyphs, agent, nil)}
+
+	if got := item.Description(); got != "⏸ claude • paused" {
+		t.Errorf("Description() = %q, want it marked paused", got)
+	}
+}
+
+func TestDashboardKeymap_Pause(t *testing.T) {
+	hints := func(status domain.AgentStatus) []string {
+		var keys []string
+		for _, b := range dashboardKeymap {
+			if b.key == "p" && b.when(hintContext{agent: &domain.Agent{Status: status}}) {
+				keys = append(keys, b.desc)
+			}
+		}
+		return keys
+	}
+
+	if got := hints(domain.AgentStatusActive); len(got) != 1 || got[0] != "pause" {
+		t.Errorf("active agent hints = %v, want [pause]", got)
+	}
+	if got := hints(domain.AgentStatusPaused); len(got) != 1 || got[0] != "resume" {
+		t.Errorf("paused agent hints = %v, want [resume]", got)
+	}
+}
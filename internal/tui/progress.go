@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// progressBuffer is how many progress reports can wait for the dashboard
+// before new ones are dropped.
+const progressBuffer = 64
+
+// ProgressReporter forwards the stages of long git operations to the
+// dashboard. It implements domain.IProgressReporter.
+type ProgressReporter struct {
+	events chan domain.OperationProgress
+}
+
+// NewProgressReporter creates a reporter to pass to both the agent service and the dashboard.
+func NewProgressReporter() *ProgressReporter {
+	return &ProgressReporter{events: make(chan domain.OperationProgress, progressBuffer)}
+}
+
+// Report queues progress for the dashboard, dropping it if the dashboard is behind.
+func (r *ProgressReporter) Report(progress domain.OperationProgress) {
+	select {
+	case r.events <- progress:
+	default:
+	}
+}
+
+// ProgressMsg carries operation progress to the dashboard.
+type ProgressMsg struct {
+	Progress domain.OperationProgress
+}
+
+// operationVerbs describes running operations in the progress line.
+var operationVerbs = map[domain.Operation]string{
+	domain.OperationCreate: "Creating",
+	domain.OperationMerge:  "Merging",
+	domain.OperationPush:   "Pushing",
+}
+
+// ProgressModel shows running operations with a spinner in place of the quick commands bar.
+type ProgressModel struct {
+	spinner spinner.Model
+	active  []domain.OperationProgress // latest stage of each running operation, oldest first
+	width   int
+	height  int
+}
+
+func NewProgress() ProgressModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = theme.QuickCommandKey
+	return ProgressModel{spinner: s}
+}
+
+// Track records progress and returns a command to start the spinner if it was idle.
+func (m *ProgressModel) Track(progress domain.OperationProgress) tea.Cmd {
+	wasActive := m.Active()
+	for i, p := range m.active {
+		if p.Key() == progress.Key() {
+			if progress.Done {
+				m.active = append(m.active[:i], m.active[i+1:]...)
+			} else {
+				m.active[i] = progress
+			}
+			return nil
+		}
+	}
+	if progress.Done {
+		return nil
+	}
+	m.active = append(m.active, progress)
+	if !wasActive {
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// Active returns true while any operation is running.
+func (m ProgressModel) Active() bool {
+	return len(m.active) > 0
+}
+
+// Update advances the spinner, letting it stop once nothing is running.
+func (m ProgressModel) Update(msg tea.Msg) (ProgressModel, tea.Cmd) {
+	if !m.Active() {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return m, cmd
+}
+
+func (m *ProgressModel) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+// Text describes the oldest running operation and how many others are running.
+func (m ProgressModel) Text() string {
+	if !m.Active() {
+		return ""
+	}
+	p := m.active[0]
+	text := fmt.Sprintf("%s %s: %s…", operationVerbs[p.Operation], p.Subject, p.Stage)
+	if more := len(m.active) - 1; more > 0 {
+		text += fmt.Sprintf(" (+%d more)", more)
+	}
+	return strings.TrimSpace(text)
+}
+
+func (m ProgressModel) View() string {
+	line := m.spinner.View() + " " + theme.TextNormal.Render(m.Text())
+
+	return lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height).
+		Align(lipgloss.Center).
+		AlignVertical(lipgloss.Bottom).
+		Render(line)
+}
+
+// SetProgressReporter connects the dashboard to the reporter given to the agent service.
+func (m *Model) SetProgressReporter(reporter *ProgressReporter) {
+	m.progressEvents = reporter.events
+}
+
+// waitForProgress returns a command that delivers the next progress report.
+func (m Model) waitForProgress() tea.Cmd {
+	if m.progressEvents == nil {
+		return nil
+	}
+	events := m.progressEvents
+	return func() tea.Msg {
+		return ProgressMsg{Progress: <-events}
+	}
+}
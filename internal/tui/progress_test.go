@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestProgressModel_Track(t *testing.T) {
+	m := NewProgress()
+	create := domain.OperationProgress{Operation: domain.OperationCreate, Subject: "auth", Stage: "creating worktree"}
+	merge := domain.OperationProgress{Operation: domain.OperationMerge, Subject: "docs", Stage: "merging"}
+
+	if cmd := m.Track(create); cmd == nil {
+		t.Error("expected the spinner to start with the first operation")
+	}
+	if cmd := m.Track(merge); cmd != nil {
+		t.Error("expected the running spinner to be reused")
+	}
+	if got := m.Text(); got != "Creating auth: creating worktree… (+1 more)" {
+		t.Errorf("Text() = %q", got)
+	}
+
+	create.Stage = "starting session"
+	m.Track(create)
+	if got := m.Text(); !strings.HasPrefix(got, "Creating auth: starting session") {
+		t.Errorf("Text() = %q, want the latest stage", got)
+	}
+
+	m.Track(domain.OperationProgress{Operation: domain.OperationCreate, Subject: "auth", Done: true})
+	m.Track(domain.OperationProgress{Operation: domain.OperationMerge, Subject: "docs", Done: true})
+	if m.Active() {
+		t.Errorf("expected nothing running, got %q", m.Text())
+	}
+}
+
+func TestModel_ProgressReporter(t *testing.T) {
+	reporter := NewProgressReporter()
+	m := NewModel(nil, nil)
+	m.SetProgressReporter(reporter)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	m = updated.(Model)
+
+	reporter.Report(domain.OperationProgress{Operation: domain.OperationPush, Subject: "auth", Stage: "pushing craizy/auth"})
+	msg := m.waitForProgress()()
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+
+	if view := m.View(); !strings.Contains(view, "Pushing auth: pushing craizy/auth…") {
+		t.Errorf("expected the push in progress on screen, got:\n%s", view)
+	}
+}
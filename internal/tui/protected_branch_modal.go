@@ -0,0 +1,117 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// protectedBranchOptions are the button labels, in selection order.
+var protectedBranchOptions = []string{"Push & Open PR", "Merge Locally", "Cancel"}
+
+// ProtectedBranchModel is a modal that warns the base branch is protected
+// and offers to push and open a pull request instead of merging locally.
+type ProtectedBranchModel struct {
+	agentID    string
+	agentName  string
+	baseBranch string
+	width      int
+	height     int
+	selected   int // 0 = Push & PR, 1 = Merge Locally, 2 = Cancel
+}
+
+// NewProtectedBranchModal creates a new protected branch warning modal.
+func NewProtectedBranchModal(agentID, agentName, baseBranch string, width, height int) ProtectedBranchModel {
+	return ProtectedBranchModel{
+		agentID:    agentID,
+		agentName:  agentName,
+		baseBranch: baseBranch,
+		width:      width,
+		height:     height,
+		selected:   0, // Default to the safe remote flow
+	}
+}
+
+func (m ProtectedBranchModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ProtectedBranchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "left", "h", "shift+tab":
+			if m.selected > 0 {
+				m.selected--
+			}
+		case "right", "l", "tab":
+			if m.selected < len(protectedBranchOptions)-1 {
+				m.selected++
+			}
+		case "enter":
+			choice := ProtectedMergeCancel
+			switch m.selected {
+			case 0:
+				choice = ProtectedMergePullRequest
+			case 1:
+				choice = ProtectedMergeLocal
+			}
+			return m, func() tea.Msg {
+				return ProtectedMergeResultMsg{
+					AgentID:   m.agentID,
+					AgentName: m.agentName,
+					Choice:    choice,
+				}
+			}
+		case "esc":
+			return m, func() tea.Msg {
+				return CloseModalMsg{}
+			}
+		}
+	}
+	return m, nil
+}
+
+// KeyHints returns the protected branch choice's keys.
+func (m ProtectedBranchModel) KeyHints() []keyBinding {
+	return []keyBinding{{key: "←/→", desc: "select"}, {key: "enter", desc: "confirm"}, {key: "esc", desc: "cancel"}}
+}
+
+func (m ProtectedBranchModel) View() string {
+	title := theme.ModalTitle.Render("Merge Agent: " + m.agentName)
+	warning := theme.TextWarning.Render("Base branch " + m.baseBranch + " is protected.\nPushing and opening a pull request is recommended.")
+
+	buttonStyle := lipgloss.NewStyle().
+		Padding(0, 2).
+		Border(lipgloss.RoundedBorder())
+
+	buttons := make([]string, 0, len(protectedBranchOptions)*2)
+	for i, label := range protectedBranchOptions {
+		style := buttonStyle.BorderForeground(theme.ColorMuted).Foreground(theme.ColorMuted)
+		if i == m.selected {
+			style = buttonStyle.BorderForeground(theme.ColorPrimary).Bold(true)
+		}
+		if i > 0 {
+			buttons = append(buttons, " ")
+		}
+		buttons = append(buttons, style.Render(label))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Center,
+		title,
+		"",
+		warning,
+		"",
+		lipgloss.JoinHorizontal(lipgloss.Center, buttons...),
+	)
+
+	box := theme.ModalBorder.
+		Padding(1, 3).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// QueueCheckInterval is how often queued agents are checked for a free provider slot.
+const QueueCheckInterval = 5 * time.Second
+
+// queueTickMsg triggers a check for queued agents that can start.
+type queueTickMsg struct{}
+
+// QueuedStartedMsg reports queued agents that were started.
+type QueuedStartedMsg struct {
+	Agents []*domain.Agent
+}
+
+// pollQueue returns a command that ticks the queue check, if provider pools are
+// configured. Read-only dashboards leave queued agents to the main dashboard.
+func (m Model) pollQueue() tea.Cmd {
+	if m.agentService == nil || m.readOnly || !m.agentService.HasProviderPools() {
+		return nil
+	}
+	return tea.Tick(QueueCheckInterval, func(time.Time) tea.Msg {
+		return queueTickMsg{}
+	})
+}
+
+// startQueued returns a command that starts queued agents whose provider has room.
+func (m Model) startQueued() tea.Cmd {
+	return func() tea.Msg {
+		return QueuedStartedMsg{Agents: m.agentService.StartQueued(time.Now())}
+	}
+}
+
+// queuedStartedToast names the queued agents that were started.
+func queuedStartedToast(agents []*domain.Agent) string {
+	names := make([]string, 0, len(agents))
+	for _, agent := range agents {
+		names = append(names, agent.Name)
+	}
+	return "Started queued: " + strings.Join(names, ", ")
+}
@@ -1,16 +1,78 @@
 package tui
 
 import (
+	"fmt"
+	"strings"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
 	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
 )
 
 type QuickCommandsModel struct {
-	width         int
-	height        int
-	agentSelected bool
+	width    int
+	height   int
+	agent    *domain.Agent // selected agent, nil if none
+	readOnly bool
+	cleanup  bool
+	unread   int
+}
+
+// keyBinding is a key and what it does, shown in the quick commands bar.
+type keyBinding struct {
+	key      string
+	desc     string
+	mutating bool                   // changes agents, so disabled in read-only mode
+	when     func(hintContext) bool // shown only when this holds; nil for always
+}
+
+// text formats the binding as "key - desc".
+func (b keyBinding) text() string {
+	return b.key + " - " + b.desc
+}
+
+// hintContext is the dashboard state the keymap's conditions depend on.
+type hintContext struct {
+	agent    *domain.Agent
+	readOnly bool
+	cleanup  bool
+	unread   int
+}
+
+func agentSelected(c hintContext) bool  { return c.agent != nil }
+func agentHasBranch(c hintContext) bool { return c.agent != nil && c.agent.Branch != "" }
+
+// dashboardKeymap lists the dashboard's keys in display order.
+var dashboardKeymap = []keyBinding{
+	{key: "n", desc: "new agent", mutating: true},
+	{key: "enter", desc: "port to agent", when: func(c hintContext) bool { return c.agent != nil && !c.readOnly }},
+	{key: "enter", desc: "watch agent", when: func(c hintContext) bool { return c.agent != nil && c.readOnly }},
+	{key: "i", desc: "details", when: agentSelected},
+	{key: "s", desc: "sort", when: agentSelected},
+	{key: "o", desc: "open", when: agentSelected},
+	{key: "f", desc: "focus", when: agentSelected},
+	{key: "pgup", desc: "history", when: agentSelected},
+	{key: "y", desc: "copy", when: agentSelected},
+	{key: "l", desc: "commits", when: agentHasBranch},
+	{key: "d", desc: "diff", when: agentHasBranch},
+	{key: "g", desc: "commit work", mutating: true, when: agentHasBranch},
+	{key: "m", desc: "merge agent", mutating: true, when: agentHasBranch},
+	{key: "p", desc: "pause", mutating: true, when: agentRunning},
+	{key: "p", desc: "resume", mutating: true, when: agentPaused},
+	{key: "r", desc: "retry", mutating: true, when: agentExited},
+	{key: "k", desc: "kill agent", mutating: true, when: agentSelected},
+	{key: "c", desc: "clean worktrees", mutating: true, when: func(c hintContext) bool { return c.cleanup }},
+	{key: "u", desc: "inbox", when: func(c hintContext) bool { return c.unread > 0 }},
+	{key: "z", desc: "stashes"},
+	{key: "q", desc: "quit"},
+}
+
+// keyHinter is implemented by modal content with keys to show in the quick
+// commands bar while it's open.
+type keyHinter interface {
+	KeyHints() []keyBinding
 }
 
 func NewQuickCommands() QuickCommandsModel {
@@ -30,18 +92,76 @@ func (m *QuickCommandsModel) SetSize(w, h int) {
 	m.height = h
 }
 
-// SetAgentSelected updates whether an agent is currently selected.
-func (m *QuickCommandsModel) SetAgentSelected(selected bool) {
-	m.agentSelected = selected
+// SetSelectedAgent updates the selected agent, nil if none.
+func (m *QuickCommandsModel) SetSelectedAgent(agent *domain.Agent) {
+	m.agent = agent
+}
+
+// SetReadOnly marks actions that change agents as disabled.
+func (m *QuickCommandsModel) SetReadOnly(readOnly bool) {
+	m.readOnly = readOnly
+}
+
+// SetCleanup updates whether stopped agents' worktrees can be cleaned up.
+func (m *QuickCommandsModel) SetCleanup(cleanup bool) {
+	m.cleanup = cleanup
+}
+
+// SetUnread updates the count of the human's unread messages shown as a badge.
+func (m *QuickCommandsModel) SetUnread(unread int) {
+	m.unread = unread
+}
+
+// Badge returns the unread messages badge text, or "" when the inbox is empty.
+func (m QuickCommandsModel) Badge() string {
+	if m.unread == 0 {
+		return ""
+	}
+	return fmt.Sprintf("✉ %d unread", m.unread)
+}
+
+// bindings returns the dashboard keys that apply in the current context.
+func (m QuickCommandsModel) bindings() []keyBinding {
+	ctx := hintContext{agent: m.agent, readOnly: m.readOnly, cleanup: m.cleanup, unread: m.unread}
+	var bindings []keyBinding
+	for _, binding := range dashboardKeymap {
+		if binding.when == nil || binding.when(ctx) {
+			bindings = append(bindings, binding)
+		}
+	}
+	return bindings
+}
+
+// Hints returns the context-aware key hints as text, marking disabled ones.
+func (m QuickCommandsModel) Hints() []string {
+	var texts []string
+	for _, binding := range m.bindings() {
+		if m.readOnly && binding.mutating {
+			texts = append(texts, binding.text()+" (disabled)")
+			continue
+		}
+		texts = append(texts, binding.text())
+	}
+	return texts
 }
 
 func (m QuickCommandsModel) View() string {
-	// Build context-aware hints
-	hints := "n - new agent"
-	if m.agentSelected {
-		hints += " • enter - port to agent • m - merge agent • k - kill agent"
+	hints := strings.Join(m.Hints(), " • ")
+	if m.readOnly {
+		// Gray out the disabled actions rather than listing them as "(disabled)"
+		parts := []string{theme.QuickCommandKey.Render("read-only")}
+		for _, binding := range m.bindings() {
+			style := theme.QuickCommandDesc
+			if binding.mutating {
+				style = theme.QuickCommandDisabled
+			}
+			parts = append(parts, style.Render(binding.text()))
+		}
+		hints = strings.Join(parts, " • ")
+	}
+	if badge := m.Badge(); badge != "" {
+		hints = theme.QuickCommandKey.Render(badge) + " • " + hints
 	}
-	hints += " • q - quit"
 
 	// Style: no border, muted text, centered horizontally, aligned to bottom
 	textStyle := theme.QuickCommandDesc.
@@ -56,3 +176,15 @@ func (m QuickCommandsModel) View() string {
 
 	return containerStyle.Render(textStyle.Render(hints))
 }
+
+// KeyBar renders a modal's keys as a single line for the bottom of the screen.
+func (m QuickCommandsModel) KeyBar(bindings []keyBinding) string {
+	texts := make([]string, len(bindings))
+	for i, binding := range bindings {
+		texts[i] = binding.text()
+	}
+	return theme.QuickCommandDesc.
+		Width(m.width).
+		Align(lipgloss.Center).
+		Render(truncateLine(strings.Join(texts, " • "), m.width))
+}
@@ -0,0 +1,18 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// SetReadOnly puts the dashboard in observer mode: creating, killing, merging and
+// retargeting agents are disabled, attaching watches sessions without sending
+// keystrokes, and background upkeep (reconcile, warm pool, queue, budget) is left
+// to the main dashboard. Call it before the program starts.
+func (m *Model) SetReadOnly(readOnly bool) {
+	m.readOnly = readOnly
+	m.sideMenu.readOnly = readOnly
+	m.quickCommands.SetReadOnly(readOnly)
+}
+
+// readOnlyNotice returns a toast explaining that action is disabled.
+func (m *Model) readOnlyNotice(action string) tea.Cmd {
+	return m.toast.Show("Read-only mode: " + action + " is disabled")
+}
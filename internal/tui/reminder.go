@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// ReminderCheckInterval is how often the idle reminder checks for human inactivity.
+const ReminderCheckInterval = time.Minute
+
+// reminderTickMsg checks whether the idle reminder is due.
+type reminderTickMsg struct{}
+
+// bellWriter receives the terminal bell when a reminder fires. Tests replace it.
+var bellWriter io.Writer = os.Stdout
+
+// desktopNotifier sends a desktop notification. Tests replace it.
+var desktopNotifier = notifyDesktop
+
+// notifyDesktop shows a desktop notification using osascript on macOS or notify-send elsewhere.
+func notifyDesktop(title, body string) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "darwin" {
+		cmd = exec.Command("osascript", "-e", fmt.Sprintf("display notification %q with title %q", body, title))
+	} else {
+		cmd = exec.Command("notify-send", title, body)
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to send desktop notification: %w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// SetReminder enables a reminder that rings the bell, and optionally sends a desktop
+// notification, after the TUI has gone untouched for after while agents are running.
+// Zero disables it.
+func (m *Model) SetReminder(after time.Duration, desktop bool) {
+	m.reminderAfter = after
+	m.reminderDesktop = desktop
+	m.lastInteraction = time.Now()
+}
+
+// pollReminder returns a command that ticks the idle reminder check.
+func (m Model) pollReminder() tea.Cmd {
+	if m.reminderAfter <= 0 {
+		return nil
+	}
+	return tea.Tick(ReminderCheckInterval, func(time.Time) tea.Msg {
+		return reminderTickMsg{}
+	})
+}
+
+// noteInteraction records human activity, re-arming the reminder.
+func (m *Model) noteInteraction() {
+	m.lastInteraction = time.Now()
+	m.reminded = false
+}
+
+// reminderDue reports whether the reminder should fire: agents are running, the human
+// isn't ported into one of them, and nothing has been touched for the reminder period.
+// It fires once per idle stretch.
+func (m Model) reminderDue(now time.Time) bool {
+	return m.reminderAfter > 0 &&
+		!m.reminded &&
+		!m.isPortedIn &&
+		m.sideMenu.HasAgents() &&
+		now.Sub(m.lastInteraction) >= m.reminderAfter
+}
+
+// remind returns a command that rings the bell, sends the desktop notification if
+// enabled, and shows the agent summary as a toast.
+func (m Model) remind() tea.Cmd {
+	return func() tea.Msg {
+		if m.agentService == nil {
+			return nil
+		}
+		summary := reminderSummary(m.agentService.List(), m.agentService.Attention())
+		_, _ = io.WriteString(bellWriter, "\a")
+		if m.reminderDesktop {
+			if err := desktopNotifier("crAIzy", summary); err != nil {
+				logging.Error(err, "action", "reminder notification")
+			}
+		}
+		return ShowToastMsg{Text: "Reminder: " + summary}
+	}
+}
+
+// reminderSummary describes how many agents are running and which need attention.
+func reminderSummary(agents []*domain.Agent, scores map[string]domain.Attention) string {
+	noun := "agents"
+	if len(agents) == 1 {
+		noun = "agent"
+	}
+	summary := fmt.Sprintf("%d %s running", len(agents), noun)
+
+	var needs []string
+	for _, agent := range agents {
+		if a := scores[agent.ID]; a.Needed() {
+			needs = append(needs, agent.Name+" ("+joinReasons(a.Reasons)+")")
+		}
+	}
+	if len(needs) == 0 {
+		return summary + ", none need attention"
+	}
+	return summary + ", needs attention: " + strings.Join(needs, ", ")
+}
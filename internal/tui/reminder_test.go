@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestModel_reminderDue(t *testing.T) {
+	newReminderModel := func() Model {
+		m := NewModel(nil, nil)
+		m.SetReminder(10*time.Minute, false)
+		newModel, _ := m.Update(AgentsUpdatedMsg{Agents: []*domain.Agent{{ID: "a", Name: "auth"}}})
+		return newModel.(Model)
+	}
+	later := time.Now().Add(11 * time.Minute)
+
+	t.Run("fires after the idle period", func(t *testing.T) {
+		if m := newReminderModel(); !m.reminderDue(later) {
+			t.Error("expected reminder to be due")
+		}
+	})
+
+	t.Run("not before the idle period", func(t *testing.T) {
+		if m := newReminderModel(); m.reminderDue(time.Now().Add(5 * time.Minute)) {
+			t.Error("expected reminder not to be due yet")
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		m := newReminderModel()
+		m.SetReminder(0, false)
+		if m.reminderDue(later) {
+			t.Error("expected disabled reminder never to be due")
+		}
+	})
+
+	t.Run("not while ported in", func(t *testing.T) {
+		m := newReminderModel()
+		m.isPortedIn = true
+		if m.reminderDue(later) {
+			t.Error("expected no reminder while attached to an agent")
+		}
+	})
+
+	t.Run("once per idle stretch", func(t *testing.T) {
+		m := newReminderModel()
+		m.reminded = true
+		if m.reminderDue(later) {
+			t.Error("expected no second reminder")
+		}
+
+		newModel, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+		m = newModel.(Model)
+		if m.reminded {
+			t.Error("a key press should re-arm the reminder")
+		}
+	})
+
+	t.Run("not without agents", func(t *testing.T) {
+		m := NewModel(nil, nil)
+		m.SetReminder(10*time.Minute, false)
+		if m.reminderDue(later) {
+			t.Error("expected no reminder without agents")
+		}
+	})
+}
+
+func TestReminderSummary(t *testing.T) {
+	agents := []*domain.Agent{{ID: "a", Name: "auth"}, {ID: "d", Name: "docs"}}
+
+	got := reminderSummary(agents, map[string]domain.Attention{
+		"a": {Score: 8, Reasons: []domain.AttentionReason{domain.AttentionQuestion}},
+	})
+	if want := "2 agents running, needs attention: auth (question)"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	got = reminderSummary(agents[:1], nil)
+	if want := "1 agent running, none need attention"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,137 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// replyQuoteLines caps how much of the original message the reply editor shows.
+const replyQuoteLines = 4
+
+// ReplySubmittedMsg is sent when the user sends a reply from the editor.
+type ReplySubmittedMsg struct {
+	To      string
+	Type    domain.MessageType
+	Content string
+}
+
+// ReplySentMsg reports the result of sending a reply.
+type ReplySentMsg struct {
+	Message *domain.Message
+	Err     error
+}
+
+// ReplyEditorModel is a multi-line editor for replying to a message. Enter
+// inserts a newline; ctrl+s sends.
+type ReplyEditorModel struct {
+	original *domain.Message
+	editor   textarea.Model
+	width    int
+	height   int
+}
+
+// NewReplyEditor creates an editor replying to original.
+func NewReplyEditor(original *domain.Message, width, height int) ReplyEditorModel {
+	editor := textarea.New()
+	editor.Placeholder = "Write your reply..."
+	editor.ShowLineNumbers = false
+	editor.SetWidth(max(width/2, 40))
+	editor.SetHeight(max(height/3, 5))
+	editor.Focus()
+
+	return ReplyEditorModel{
+		original: original,
+		editor:   editor,
+		width:    width,
+		height:   height,
+	}
+}
+
+func (m ReplyEditorModel) Init() tea.Cmd {
+	return textarea.Blink
+}
+
+func (m ReplyEditorModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if size, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width, m.height = size.Width, size.Height
+		m.editor.SetWidth(max(m.width/2, 40))
+		m.editor.SetHeight(max(m.height/3, 5))
+		return m, nil
+	}
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case "esc":
+			return m, func() tea.Msg { return CloseModalMsg{} }
+		case "ctrl+s":
+			content := strings.TrimSpace(m.editor.Value())
+			if content == "" {
+				return m, nil
+			}
+			reply := ReplySubmittedMsg{To: m.original.From, Type: replyType(m.original), Content: content}
+			return m, func() tea.Msg { return reply }
+		}
+	}
+
+	var cmd tea.Cmd
+	m.editor, cmd = m.editor.Update(msg)
+	return m, cmd
+}
+
+// HasUnsavedInput reports whether any reply has been typed.
+func (m ReplyEditorModel) HasUnsavedInput() bool {
+	return strings.TrimSpace(m.editor.Value()) != ""
+}
+
+// KeyHints returns the reply editor's keys.
+func (m ReplyEditorModel) KeyHints() []keyBinding {
+	return []keyBinding{{key: "ctrl+s", desc: "send"}, {key: "enter", desc: "new line"}, {key: "esc", desc: "cancel"}}
+}
+
+func (m ReplyEditorModel) View() string {
+	title := theme.ModalTitle.Render(fmt.Sprintf("Reply to %s (%s)", m.original.From, m.original.Code()))
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		title,
+		"",
+		theme.TextMuted.Render(quoteLines(m.original.Content, replyQuoteLines, m.editor.Width())),
+		"",
+		m.editor.View(),
+	)
+
+	box := theme.ModalBorder.
+		Padding(1, 3).
+		Render(content)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// replyType answers questions and otherwise replies with info.
+func replyType(original *domain.Message) domain.MessageType {
+	if original.Type == domain.MessageTypeQuestion {
+		return domain.MessageTypeAnswer
+	}
+	return domain.MessageTypeInfo
+}
+
+// quoteLines prefixes up to n lines of text with "> ", noting any left out.
+func quoteLines(text string, n, width int) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	more := len(lines) - n
+	if more > 0 {
+		lines = lines[:n]
+	}
+	for i, line := range lines {
+		lines[i] = truncateLine("> "+line, width)
+	}
+	if more > 0 {
+		lines = append(lines, fmt.Sprintf("> (%d more lines)", more))
+	}
+	return strings.Join(lines, "\n")
+}
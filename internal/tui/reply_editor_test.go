@@ -0,0 +1,39 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestReplyEditorModel(t *testing.T) {
+	question := &domain.Message{ID: "q", Seq: 3, From: "auth", Type: domain.MessageTypeQuestion, Content: "Which library?"}
+	var m tea.Model = NewReplyEditor(question, 100, 40)
+
+	typeText := func(text string) {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text)})
+	}
+
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS}); cmd != nil {
+		t.Error("expected an empty reply not to be sent")
+	}
+
+	typeText("Use oauth2.")
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	typeText("It's already vendored.")
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if cmd == nil {
+		t.Fatal("expected ctrl+s to send the reply")
+	}
+	reply, ok := cmd().(ReplySubmittedMsg)
+	if !ok {
+		t.Fatalf("ctrl+s sent %T, want ReplySubmittedMsg", cmd())
+	}
+	want := ReplySubmittedMsg{To: "auth", Type: domain.MessageTypeAnswer, Content: "Use oauth2.\nIt's already vendored."}
+	if reply != want {
+		t.Errorf("reply = %+v, want %+v", reply, want)
+	}
+}
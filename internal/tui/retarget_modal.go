@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// RetargetModel is a modal that asks for a new base branch for an agent.
+type RetargetModel struct {
+	textInput textinput.Model
+	agent     *domain.Agent
+	width     int
+	height    int
+}
+
+// NewRetargetModal creates a new retarget modal for the given agent.
+func NewRetargetModal(agent *domain.Agent, width, height int) RetargetModel {
+	ti := textinput.New()
+	ti.Placeholder = "New base branch"
+	ti.Focus()
+	ti.CharLimit = 100
+	ti.Width = 30
+
+	return RetargetModel{
+		textInput: ti,
+		agent:     agent,
+		width:     width,
+		height:    height,
+	}
+}
+
+func (m RetargetModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m RetargetModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.Type {
+		case tea.KeyEnter:
+			newBase := strings.TrimSpace(m.textInput.Value())
+			if newBase == "" {
+				return m, nil
+			}
+			return m, func() tea.Msg {
+				return RetargetConfirmedMsg{
+					AgentID:   m.agent.ID,
+					AgentName: m.agent.Name,
+					NewBase:   newBase,
+				}
+			}
+		case tea.KeyEsc:
+			return m, func() tea.Msg {
+				return CloseModalMsg{}
+			}
+		}
+	}
+
+	m.textInput, cmd = m.textInput.Update(msg)
+	return m, cmd
+}
+
+// KeyHints returns the retarget input's keys.
+func (m RetargetModel) KeyHints() []keyBinding {
+	return []keyBinding{{key: "enter", desc: "retarget"}, {key: "esc", desc: "cancel"}}
+}
+
+func (m RetargetModel) View() string {
+	title := theme.ModalTitle.Render("Retarget " + m.agent.Name)
+	current := theme.TextMuted.Render("Currently based on " + m.agent.BaseBranch)
+
+	box := theme.ModalBorder.
+		Padding(1, 2).
+		Render(
+			lipgloss.JoinVertical(lipgloss.Center,
+				title,
+				current,
+				"",
+				m.textInput.View(),
+			),
+		)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
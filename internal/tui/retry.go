@@ -0,0 +1,48 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/logging"
+)
+
+// RetryDoneMsg is sent when retrying an exited agent completes.
+type RetryDoneMsg struct {
+	AgentName string
+	Err       error
+}
+
+// RetryOptionsFunc returns the options an agent's new session starts with
+// when it is retried: the environment, directory and startup prompt of its
+// type, as the store keeps none of them.
+type RetryOptionsFunc func(agent *domain.Agent) (domain.CreateOptions, error)
+
+// SetRetryOptions sets where retried agents get their session options. Without
+// one they retry with none.
+func (m *Model) SetRetryOptions(options RetryOptionsFunc) {
+	m.retryOptions = options
+}
+
+// retryAgent returns a command that relaunches an exited agent in a fresh
+// session seeded with its task and progress.
+func (m *Model) retryAgent(agent *domain.Agent) tea.Cmd {
+	options := m.retryOptions
+	retry := func() tea.Msg {
+		var opts domain.CreateOptions
+		if options != nil {
+			var err error
+			if opts, err = options(agent); err != nil {
+				logging.Error(err, "agentID", agent.ID, "action", "retry options")
+				return RetryDoneMsg{AgentName: agent.Name, Err: err}
+			}
+		}
+		err := m.agentService.Retry(agent.ID, opts)
+		return RetryDoneMsg{AgentName: agent.Name, Err: err}
+	}
+	return tea.Batch(m.toast.Show("Retrying "+agent.Name+"..."), retry)
+}
+
+func agentExited(c hintContext) bool {
+	return c.agent != nil && c.agent.Status == domain.AgentStatusExited
+}
@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ScheduledDeliveryInterval is how often scheduled messages that have come due
+// are delivered to their recipients' sessions.
+const ScheduledDeliveryInterval = 30 * time.Second
+
+// deliveryTickMsg triggers delivery of due scheduled messages.
+type deliveryTickMsg struct{}
+
+// ScheduledDeliveredMsg reports how many due scheduled messages were delivered.
+type ScheduledDeliveredMsg struct {
+	Delivered int
+	Err       error
+}
+
+// pollDelivery returns a command that ticks the next scheduled delivery.
+// Read-only dashboards leave delivery to the main dashboard.
+func (m Model) pollDelivery() tea.Cmd {
+	if m.messageService == nil || m.readOnly {
+		return nil
+	}
+	return tea.Tick(ScheduledDeliveryInterval, func(time.Time) tea.Msg {
+		return deliveryTickMsg{}
+	})
+}
+
+// deliverDue returns a command that delivers due scheduled messages.
+func (m Model) deliverDue() tea.Cmd {
+	if m.messageService == nil || m.readOnly {
+		return nil
+	}
+	return func() tea.Msg {
+		delivered, err := m.messageService.DeliverDue()
+		return ScheduledDeliveredMsg{Delivered: delivered, Err: err}
+	}
+}
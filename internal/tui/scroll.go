@@ -0,0 +1,62 @@
+package tui
+
+import tea "github.com/charmbracelet/bubbletea"
+
+// ScrollbackLines is how far back the preview captures while scrolling through
+// an agent's history. tmux keeps no more than its history-limit, 2000 lines by
+// default.
+const ScrollbackLines = 10000
+
+// scrollKeys are the keys shown while scrolling through an agent's history.
+var scrollKeys = []keyBinding{
+	{key: "↑/↓", desc: "scroll"},
+	{key: "pgup/pgdn", desc: "page"},
+	{key: "g/G", desc: "top/bottom"},
+	{key: "esc", desc: "back to live"},
+}
+
+// startScroll swaps the preview for the selected agent's scrollable history
+// and returns the capture that fills it.
+func (m *Model) startScroll() tea.Cmd {
+	if m.sideMenu.SelectedAgent() == nil {
+		return nil
+	}
+	m.contentArea.StartScroll()
+	return m.capturePreview()
+}
+
+// updateScroll handles keys while scrolling through an agent's history:
+// scrolling it, or going back to the live preview.
+func (m *Model) updateScroll(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "ctrl+c":
+		return tea.Quit
+	case "esc", "q":
+		m.contentArea.StopScroll()
+		return m.capturePreview()
+	}
+	return m.contentArea.ScrollPreview(msg)
+}
+
+// contentKeys returns the keys of a diff or history shown in place of the
+// preview, or nil while the preview shows.
+func (m Model) contentKeys() []keyBinding {
+	switch {
+	case m.contentArea.DiffOpen():
+		return diffKeys
+	case m.contentArea.Scrolling():
+		return scrollKeys
+	}
+	return nil
+}
+
+// withContentKeys replaces the key hints at the bottom of view with the keys
+// of a diff or history shown in place of the preview, unless a toast or
+// progress is showing there.
+func (m Model) withContentKeys(view string) string {
+	keys := m.contentKeys()
+	if keys == nil || m.toast.Visible() || m.progress.Active() {
+		return view
+	}
+	return m.withKeyBar(view, keys)
+}
@@ -0,0 +1,69 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestModel_ScrollHistory(t *testing.T) {
+	var history []string
+	for i := 1; i <= 100; i++ {
+		history = append(history, fmt.Sprintf("output line %d", i))
+	}
+	agent := &domain.Agent{ID: "a", Name: "auth", Status: domain.AgentStatusActive}
+	update := func(m Model, msg tea.Msg) Model {
+		t.Helper()
+		model, _ := m.Update(msg)
+		return model.(Model)
+	}
+
+	m := NewModel(nil, nil)
+	m = update(m, tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = update(m, AgentsUpdatedMsg{Agents: []*domain.Agent{agent}})
+	m = update(m, tea.KeyMsg{Type: tea.KeyPgUp})
+	if !m.contentArea.Scrolling() {
+		t.Fatal("pgup did not start scrolling")
+	}
+	if got := m.previewLines(agent.ID); got != ScrollbackLines {
+		t.Errorf("previewLines = %d while scrolling, want %d", got, ScrollbackLines)
+	}
+
+	// A shallow capture from before scrolling began is dropped
+	m = update(m, PreviewUpdatedMsg{SessionID: agent.ID, Content: "output line 100", Lines: 10})
+	m = update(m, PreviewUpdatedMsg{SessionID: agent.ID, Content: strings.Join(history, "\n"), Lines: ScrollbackLines})
+	view := m.View()
+	if strings.Contains(view, "output line 100") || !strings.Contains(view, "output line 80") {
+		t.Errorf("history not scrolled a page up from the bottom:\n%s", view)
+	}
+	if !strings.Contains(view, "esc - back to live") {
+		t.Errorf("scroll keys not shown:\n%s", view)
+	}
+
+	m = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	if view := m.View(); !strings.Contains(view, "output line 1\n") && !strings.Contains(view, "output line 1 ") {
+		t.Errorf("g did not scroll to the top:\n%s", view)
+	}
+
+	// New output keeps the place unless scrolled to the bottom
+	history = append(history, "output line 101")
+	m = update(m, PreviewUpdatedMsg{SessionID: agent.ID, Content: strings.Join(history, "\n"), Lines: ScrollbackLines})
+	if !m.contentArea.scroll.AtTop() {
+		t.Error("new output moved the scrolled history")
+	}
+	m = update(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("G")})
+	history = append(history, "output line 102")
+	m = update(m, PreviewUpdatedMsg{SessionID: agent.ID, Content: strings.Join(history, "\n"), Lines: ScrollbackLines})
+	if !strings.Contains(m.View(), "output line 102") {
+		t.Error("history scrolled to the bottom did not follow new output")
+	}
+
+	m = update(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.contentArea.Scrolling() {
+		t.Error("esc did not go back to the live preview")
+	}
+}
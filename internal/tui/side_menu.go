@@ -1,6 +1,10 @@
 package tui
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -11,31 +15,83 @@ import (
 
 // AgentListItem implements list.Item for domain.Agent
 type AgentListItem struct {
-	agent *domain.Agent
+	agent   *domain.Agent
+	reasons []domain.AttentionReason
+	git     string // worktree indicators, see gitIndicators
+	unread  int    // messages the agent hasn't read
+	typeClr string // the agent type's color, see Model.SetAgentColors
+	glyph   string // marks the agent's state, see statusGlyph
 }
 
 func (i AgentListItem) Title() string {
+	if i.unread > 0 {
+		return fmt.Sprintf("%s ✉%d", i.agent.Name, i.unread)
+	}
 	return i.agent.Name
 }
 
 func (i AgentListItem) Description() string {
-	return i.agent.AgentType
+	if i.agent.Status == domain.AgentStatusStarting {
+		return i.agent.AgentType + " • starting…"
+	}
+	parts := []string{i.glyph + " " + i.agent.AgentType}
+	if i.agent.Status == domain.AgentStatusPaused {
+		parts = append(parts, "paused")
+	}
+	reasons := i.reasons
+	if code, ok := i.agent.ExitCode(); ok {
+		// The exit code says more than the bare exited reason
+		parts = append(parts, fmt.Sprintf("exited %d", code))
+		reasons = slices.DeleteFunc(slices.Clone(reasons), func(r domain.AttentionReason) bool {
+			return r == domain.AttentionExited
+		})
+	}
+	if len(reasons) > 0 {
+		parts = append(parts, joinReasons(reasons))
+	}
+	if i.git != "" {
+		parts = append(parts, i.git)
+	}
+	return strings.Join(parts, " • ")
 }
 
 func (i AgentListItem) FilterValue() string {
 	return i.agent.Name
 }
 
+func (i AgentListItem) color() string {
+	return i.typeClr
+}
+
 type SideMenuModel struct {
-	width  int
-	height int
-	list   list.Model
-	agents []*domain.Agent
+	width   int
+	height  int
+	list    list.Model
+	agents  []*domain.Agent
+	loading bool
+
+	// readOnly drops the hint to create an agent
+	readOnly bool
+
+	// attentionSort orders agents needing human action first and shows why
+	attentionSort bool
+	attention     map[string]domain.Attention
+
+	// git holds each agent's worktree state, refreshed on its own tick
+	git map[string]domain.WorktreeStatus
+
+	// unread counts each agent's unread messages, refreshed with the inbox
+	unread map[string]int
+
+	// colors holds agent type colors by lowercased type name
+	colors map[string]string
+
+	glyphs Glyphs
 }
 
 func NewSideMenu() SideMenuModel {
 	// Create delegate with minimal styling
-	delegate := list.NewDefaultDelegate()
+	delegate := newColorDelegate()
 	delegate.ShowDescription = true
 	delegate.SetHeight(2)
 
@@ -48,6 +104,7 @@ func NewSideMenu() SideMenuModel {
 	return SideMenuModel{
 		list:   l,
 		agents: []*domain.Agent{},
+		glyphs: UnicodeGlyphs,
 	}
 }
 
@@ -58,12 +115,29 @@ func (m SideMenuModel) Init() tea.Cmd {
 func (m SideMenuModel) Update(msg tea.Msg) (SideMenuModel, tea.Cmd) {
 	switch msg := msg.(type) {
 	case AgentsUpdatedMsg:
+		var selectedID string
+		if agent := m.SelectedAgent(); agent != nil {
+			selectedID = agent.ID
+		}
+
 		m.agents = msg.Agents
-		items := make([]list.Item, len(m.agents))
+		m.attention = msg.Attention
+		if m.attentionSort {
+			m.agents = append([]*domain.Agent(nil), msg.Agents...)
+			domain.SortByAttention(m.agents, m.attention)
+		}
+		m.setItems()
+		// Keep the cursor on the same agent when the order changes
 		for i, agent := range m.agents {
-			items[i] = AgentListItem{agent: agent}
+			if agent.ID == selectedID {
+				m.list.Select(i)
+			}
 		}
-		m.list.SetItems(items)
+		return m, nil
+
+	case GitStatusLoadedMsg:
+		m.git = msg.Statuses
+		m.setItems()
 		return m, nil
 
 	case tea.KeyMsg:
@@ -84,6 +158,83 @@ func (m *SideMenuModel) SetSize(w, h int) {
 	m.list.SetHeight(h - 2)
 }
 
+// SetLoading toggles the loading indicator shown while startup reconcile runs.
+func (m *SideMenuModel) SetLoading(loading bool) {
+	m.loading = loading
+	m.updateTitle()
+}
+
+// SetAttentionSort toggles ordering agents that need human action first.
+// The new order applies from the next AgentsUpdatedMsg.
+func (m *SideMenuModel) SetAttentionSort(on bool) {
+	m.attentionSort = on
+	m.updateTitle()
+}
+
+// AttentionSort reports whether agents are ordered by attention.
+func (m SideMenuModel) AttentionSort() bool {
+	return m.attentionSort
+}
+
+// setItems rebuilds the list items from the agents, attention and git state.
+func (m *SideMenuModel) setItems() {
+	items := make([]list.Item, len(m.agents))
+	for i, agent := range m.agents {
+		reasons := m.attention[agent.ID].Reasons
+		item := AgentListItem{
+			agent:   agent,
+			reasons: reasons,
+			unread:  m.unread[agent.ID],
+			typeClr: typeColor(m.colors, agent),
+			glyph:   statusGlyph(m.glyphs, agent, reasons),
+		}
+		if status, ok := m.git[agent.ID]; ok {
+			item.git = gitIndicators(status)
+		}
+		items[i] = item
+	}
+	m.list.SetItems(items)
+}
+
+// SetUnread updates the unread message badges from counts by recipient.
+func (m *SideMenuModel) SetUnread(counts map[string]int) {
+	m.unread = counts
+	m.setItems()
+}
+
+// SetColors sets the agent type colors shown before agent names.
+func (m *SideMenuModel) SetColors(colors map[string]string) {
+	m.colors = colors
+	m.setItems()
+}
+
+// SetGlyphs sets the glyphs marking agent states.
+func (m *SideMenuModel) SetGlyphs(glyphs Glyphs) {
+	m.glyphs = glyphs
+	m.setItems()
+}
+
+// updateTitle reflects the loading and sort state in the list title.
+func (m *SideMenuModel) updateTitle() {
+	switch {
+	case m.loading:
+		m.list.Title = "Agents (syncing…)"
+	case m.attentionSort:
+		m.list.Title = "Agents (needs attention first)"
+	default:
+		m.list.Title = "Agents"
+	}
+}
+
+// joinReasons renders attention reasons as a comma separated list.
+func joinReasons(reasons []domain.AttentionReason) string {
+	words := make([]string, len(reasons))
+	for i, r := range reasons {
+		words[i] = string(r)
+	}
+	return strings.Join(words, ", ")
+}
+
 // SelectedAgent returns the currently selected agent, or nil if none selected.
 func (m SideMenuModel) SelectedAgent() *domain.Agent {
 	if len(m.agents) == 0 {
@@ -105,8 +256,16 @@ func (m SideMenuModel) View() string {
 		Width(m.width).
 		Height(m.height)
 
+	if len(m.agents) == 0 && m.loading {
+		emptyStyle := theme.SideMenuEmpty.Padding(1)
+		return style.Render(emptyStyle.Render("Loading agents…"))
+	}
+
 	if len(m.agents) == 0 {
 		emptyStyle := theme.SideMenuEmpty.Padding(1)
+		if m.readOnly {
+			return style.Render(emptyStyle.Render("No agents running"))
+		}
 		return style.Render(emptyStyle.Render("No agents running\n\nPress 'n' to create one"))
 	}
 
@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// MinWidth and MinHeight are the smallest terminal the dashboard lays out in.
+// Below them it asks for a bigger terminal rather than drawing broken panes.
+const (
+	MinWidth  = 40
+	MinHeight = 12
+)
+
+// MaxSideMenuWidth caps the agent list's width, which otherwise takes a
+// quarter of the terminal, so wide terminals give the room to the preview.
+const MaxSideMenuWidth = 50
+
+// tooSmall reports whether the terminal is below the minimum size. The linear
+// view is plain text that wraps at any size, so it is never too small.
+func (m Model) tooSmall() bool {
+	return !m.linear && (m.width < MinWidth || m.height < MinHeight)
+}
+
+// tooSmallView asks for a bigger terminal, showing the current and minimum sizes.
+func (m Model) tooSmallView() string {
+	text := lipgloss.JoinVertical(lipgloss.Center,
+		theme.TextWarning.Render("Terminal too small"),
+		theme.TextMuted.Render(fmt.Sprintf("%dx%d, need %dx%d", m.width, m.height, MinWidth, MinHeight)),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, text)
+}
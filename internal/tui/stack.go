@@ -0,0 +1,26 @@
+package tui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// WorktreeStackMsg reports the language and runtime detected in an agent's worktree.
+type WorktreeStackMsg struct {
+	AgentID string
+	Stack   domain.Stack
+	Err     error
+}
+
+// probeStack returns a command that detects an agent's stack for the detail view.
+func (m Model) probeStack(agent *domain.Agent) tea.Cmd {
+	if m.agentService == nil || agent.WorkDir == "" {
+		return nil
+	}
+	agentID := agent.ID
+	return func() tea.Msg {
+		stack, err := m.agentService.Stack(agentID)
+		return WorktreeStackMsg{AgentID: agentID, Stack: stack, Err: err}
+	}
+}
@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// loadStashes returns a command that lists crAIzy's stashes for the stash browser.
+func (m Model) loadStashes() tea.Cmd {
+	return func() tea.Msg {
+		if m.agentService == nil {
+			return StashesLoadedMsg{}
+		}
+		stashes, err := m.agentService.Stashes()
+		return StashesLoadedMsg{Stashes: stashes, Err: err}
+	}
+}
+
+// runStashAction returns a command that pops, branches or drops a stash.
+func (m Model) runStashAction(msg StashActionMsg) tea.Cmd {
+	if m.agentService == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		switch msg.Action {
+		case StashPop:
+			path, err := m.agentService.PopStash(msg.Ref)
+			return StashActionDoneMsg{Result: fmt.Sprintf("Popped %s into %s", msg.Ref, path), Err: err}
+		case StashToBranch:
+			err := m.agentService.StashToBranch(msg.Ref, msg.Branch)
+			return StashActionDoneMsg{Result: fmt.Sprintf("Applied %s to new branch %s", msg.Ref, msg.Branch), Err: err}
+		default:
+			err := m.agentService.DropStash(msg.Ref)
+			return StashActionDoneMsg{Result: "Dropped " + msg.Ref, Err: err}
+		}
+	}
+}
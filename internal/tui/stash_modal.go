@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// StashModel is a modal listing the stashes crAIzy created before merges and
+// kills, with actions to pop, move to a new branch, or drop them.
+type StashModel struct {
+	stashes  []domain.StashEntry
+	selected int
+	loading  bool
+	err      error
+
+	// naming is set while typing the branch for StashToBranch
+	naming      bool
+	branchInput textinput.Model
+	// confirmDrop is set while asking before dropping the selected stash
+	confirmDrop bool
+
+	width  int
+	height int
+}
+
+// NewStashModal creates a stash browser that waits for a StashesLoadedMsg.
+func NewStashModal(width, height int) StashModel {
+	ti := textinput.New()
+	ti.Placeholder = "New branch name"
+	ti.CharLimit = 100
+	ti.Width = 30
+
+	return StashModel{
+		loading:     true,
+		branchInput: ti,
+		width:       width,
+		height:      height,
+	}
+}
+
+func (m StashModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m StashModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+
+	case StashesLoadedMsg:
+		m.loading = false
+		m.stashes = msg.Stashes
+		m.err = msg.Err
+		m.selected = min(m.selected, max(len(m.stashes)-1, 0))
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case m.naming:
+			return m.updateNaming(msg)
+		case m.confirmDrop:
+			m.confirmDrop = false
+			if msg.String() == "y" {
+				return m, m.action(StashDrop, "")
+			}
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "esc", "z", "q":
+			return m, func() tea.Msg {
+				return CloseModalMsg{}
+			}
+		case "up", "k":
+			if m.selected > 0 {
+				m.selected--
+			}
+		case "down", "j":
+			if m.selected < len(m.stashes)-1 {
+				m.selected++
+			}
+		case "p":
+			return m, m.action(StashPop, "")
+		case "b":
+			if len(m.stashes) > 0 {
+				m.naming = true
+				m.branchInput.SetValue("")
+				return m, m.branchInput.Focus()
+			}
+		case "d":
+			m.confirmDrop = len(m.stashes) > 0
+		}
+	}
+	return m, nil
+}
+
+// updateNaming handles keys while the new branch name is typed.
+func (m StashModel) updateNaming(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.naming = false
+		m.branchInput.Blur()
+		return m, nil
+	case tea.KeyEnter:
+		branch := strings.TrimSpace(m.branchInput.Value())
+		if branch == "" {
+			return m, nil
+		}
+		m.naming = false
+		m.branchInput.Blur()
+		return m, m.action(StashToBranch, branch)
+	}
+	var cmd tea.Cmd
+	m.branchInput, cmd = m.branchInput.Update(msg)
+	return m, cmd
+}
+
+// action returns a command requesting action on the selected stash.
+func (m StashModel) action(action StashAction, branch string) tea.Cmd {
+	if len(m.stashes) == 0 {
+		return nil
+	}
+	ref := m.stashes[m.selected].Ref
+	return func() tea.Msg {
+		return StashActionMsg{Action: action, Ref: ref, Branch: branch}
+	}
+}
+
+// KeyHints returns the keys for the stash list, or for naming a branch or confirming a drop.
+func (m StashModel) KeyHints() []keyBinding {
+	switch {
+	case m.naming:
+		return []keyBinding{{key: "enter", desc: "create branch"}, {key: "esc", desc: "back"}}
+	case m.confirmDrop:
+		return []keyBinding{{key: "y", desc: "drop"}, {key: "n", desc: "keep"}}
+	case len(m.stashes) == 0:
+		return []keyBinding{{key: "esc", desc: "close"}}
+	}
+	return []keyBinding{
+		{key: "↑/↓", desc: "select"},
+		{key: "p", desc: "pop", mutating: true},
+		{key: "b", desc: "to branch", mutating: true},
+		{key: "d", desc: "drop", mutating: true},
+		{key: "esc", desc: "close"},
+	}
+}
+
+func (m StashModel) View() string {
+	lines := []string{
+		theme.ModalTitle.Render("Stashes"),
+		theme.TextMuted.Render("Changes crAIzy stashed before merges and kills"),
+		"",
+		m.renderStashes(),
+	}
+	switch {
+	case m.naming:
+		lines = append(lines, "", m.branchInput.View())
+	case m.confirmDrop:
+		lines = append(lines, "", theme.TextError.Render(fmt.Sprintf("Drop %s? Its changes will be lost.", m.stashes[m.selected].Ref)))
+	}
+
+	box := theme.ModalBorder.
+		Padding(1, 3).
+		Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// renderStashes renders one line per stash, highlighting the selection.
+func (m StashModel) renderStashes() string {
+	switch {
+	case m.loading:
+		return theme.SideMenuEmpty.Render("Loading stashes…")
+	case m.err != nil:
+		return theme.TextError.Render(m.err.Error())
+	case len(m.stashes) == 0:
+		return theme.SideMenuEmpty.Render("No stashes")
+	}
+
+	lines := make([]string, 0, len(m.stashes))
+	for i, s := range m.stashes {
+		created := s.CreatedAt.Format("2006-01-02 15:04")
+		line := "  " + theme.TextMuted.Render(s.Ref) + "  " + theme.TextNormal.Render(s.Branch) + "  " + theme.TextMuted.Render(created)
+		if i == m.selected {
+			line = theme.TextSuccess.Render("› " + s.Ref + "  " + s.Branch + "  " + created)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,43 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestStashModel(t *testing.T) {
+	key := func(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+	model, _ := NewStashModal(80, 24).Update(StashesLoadedMsg{Stashes: []domain.StashEntry{
+		{Ref: "stash@{0}", Branch: "craizy/auth"},
+		{Ref: "stash@{1}", Branch: "main"},
+	}})
+	m := model.(StashModel)
+
+	model, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = model.(StashModel)
+	if _, cmd := m.Update(key("p")); cmd == nil || cmd().(StashActionMsg) != (StashActionMsg{Action: StashPop, Ref: "stash@{1}"}) {
+		t.Error("expected p to pop the selected stash")
+	}
+
+	model, cmd := m.Update(key("d"))
+	m = model.(StashModel)
+	if cmd != nil || !m.confirmDrop {
+		t.Fatal("expected d to ask before dropping")
+	}
+	model, cmd = m.Update(key("y"))
+	m = model.(StashModel)
+	if cmd == nil || cmd().(StashActionMsg).Action != StashDrop {
+		t.Error("expected y to drop the stash")
+	}
+
+	model, _ = m.Update(key("b"))
+	m = model.(StashModel)
+	m.branchInput.SetValue("rescued")
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if got := cmd().(StashActionMsg); got.Action != StashToBranch || got.Branch != "rescued" {
+		t.Errorf("enter sent %+v, want StashToBranch to rescued", got)
+	}
+}
@@ -0,0 +1,37 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TemplateRefreshInterval is how often the template worktree new agents are
+// cloned from is brought up to date with the current branch.
+const TemplateRefreshInterval = 15 * time.Minute
+
+// templateTickMsg triggers a template worktree refresh.
+type templateTickMsg struct{}
+
+// pollTemplate returns a command that ticks the template refresh, if templates
+// are enabled. Read-only dashboards leave the template to the main dashboard.
+func (m Model) pollTemplate() tea.Cmd {
+	if m.agentService == nil || m.readOnly || !m.agentService.HasTemplate() {
+		return nil
+	}
+	return tea.Tick(TemplateRefreshInterval, func(time.Time) tea.Msg {
+		return templateTickMsg{}
+	})
+}
+
+// refreshTemplate returns a command that refreshes the template worktree in the
+// background. Failures only cost new agents a full checkout, so they are logged.
+func (m Model) refreshTemplate() tea.Cmd {
+	if m.agentService == nil || m.readOnly || !m.agentService.HasTemplate() {
+		return nil
+	}
+	return func() tea.Msg {
+		_ = m.agentService.RefreshTemplate()
+		return nil
+	}
+}
@@ -1,6 +1,11 @@
 package theme
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
 
 // Nord-inspired color palette for 256-color terminals.
 // See: https://www.nordtheme.com/
@@ -97,6 +102,25 @@ var (
 
 	ContentTagline = lipgloss.NewStyle().
 			Foreground(ColorForeground)
+
+	ContentNewLine = lipgloss.NewStyle().
+			Foreground(ColorWarning)
+)
+
+// Diff styles
+var (
+	DiffHeader = lipgloss.NewStyle().
+			Foreground(ColorForeground).
+			Bold(true)
+
+	DiffHunk = lipgloss.NewStyle().
+			Foreground(ColorSecondary)
+
+	DiffAdded = lipgloss.NewStyle().
+			Foreground(ColorSuccess)
+
+	DiffRemoved = lipgloss.NewStyle().
+			Foreground(ColorError)
 )
 
 // Modal styles
@@ -118,6 +142,18 @@ var (
 
 	QuickCommandDesc = lipgloss.NewStyle().
 				Foreground(ColorMuted)
+
+	QuickCommandDisabled = lipgloss.NewStyle().
+				Foreground(ColorMuted).
+				Faint(true).
+				Strikethrough(true)
+)
+
+// Toast notification styles
+var (
+	Toast = lipgloss.NewStyle().
+		Foreground(ColorWarning).
+		Bold(true)
 )
 
 // TmuxStatusBar contains color values for tmux status bar configuration.
@@ -137,3 +173,15 @@ var TmuxStatusBar = struct {
 	MutedColor:     "#4C566A", // Nord3 - muted gray
 	SeparatorColor: "#4C566A", // Nord3
 }
+
+// NoColorRequested reports whether the environment asks for colorless output,
+// following the NO_COLOR convention (https://no-color.org).
+func NoColorRequested() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// DisableColor renders all styles as plain text, for NO_COLOR and terminals
+// without 256-color support.
+func DisableColor() {
+	lipgloss.SetColorProfile(termenv.Ascii)
+}
@@ -55,6 +55,10 @@ func TestStylesAreInitialized(t *testing.T) {
 		{"ContentLogo", ContentLogo},
 		{"ContentVersion", ContentVersion},
 		{"ContentTagline", ContentTagline},
+		{"DiffHeader", DiffHeader},
+		{"DiffHunk", DiffHunk},
+		{"DiffAdded", DiffAdded},
+		{"DiffRemoved", DiffRemoved},
 		{"ModalTitle", ModalTitle},
 		{"ModalBorder", ModalBorder},
 		{"QuickCommandKey", QuickCommandKey},
@@ -152,3 +156,26 @@ func TestBorderStylesHaveBorders(t *testing.T) {
 		}
 	}
 }
+
+func TestNoColorRequested(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	if NoColorRequested() {
+		t.Error("empty NO_COLOR should not disable color")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if !NoColorRequested() {
+		t.Error("NO_COLOR=1 should disable color")
+	}
+}
+
+func TestDisableColor(t *testing.T) {
+	previous := lipgloss.ColorProfile()
+	defer lipgloss.SetColorProfile(previous)
+
+	DisableColor()
+
+	if got := TextError.Render("failed"); got != "failed" {
+		t.Errorf("Render() = %q, want plain text", got)
+	}
+}
@@ -0,0 +1,54 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+// SetProject sets the project named in the terminal title. The dashboard
+// leaves the title alone until it is set.
+func (m *Model) SetProject(name string) {
+	m.project = name
+}
+
+// windowTitle formats the terminal title, e.g. "crAIzy api — 3 active, 2 msgs".
+// Unread messages are only counted when there are some.
+func windowTitle(project string, active, unread int) string {
+	title := fmt.Sprintf("crAIzy %s — %d active", project, active)
+	switch {
+	case unread == 1:
+		title += ", 1 msg"
+	case unread > 1:
+		title += fmt.Sprintf(", %d msgs", unread)
+	}
+	return title
+}
+
+// activeAgents counts the running agents, leaving out paused and starting ones.
+func activeAgents(agents []*domain.Agent) int {
+	n := 0
+	for _, agent := range agents {
+		if agent.Status == domain.AgentStatusActive {
+			n++
+		}
+	}
+	return n
+}
+
+// updateTitle returns a command setting the terminal title from the latest
+// agent and inbox counts, or nil if it would not change. Inside tmux the title
+// names the pane, and the window of the craizy dash --tmux session with it.
+func (m *Model) updateTitle() tea.Cmd {
+	if m.project == "" {
+		return nil
+	}
+	title := windowTitle(m.project, m.titleActive, m.titleUnread)
+	if title == m.title {
+		return nil
+	}
+	m.title = title
+	return tea.SetWindowTitle(title)
+}
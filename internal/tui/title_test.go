@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/domain"
+)
+
+func TestWindowTitle(t *testing.T) {
+	tests := []struct {
+		active, unread int
+		want           string
+	}{
+		{0, 0, "crAIzy api — 0 active"},
+		{3, 1, "crAIzy api — 3 active, 1 msg"},
+		{3, 2, "crAIzy api — 3 active, 2 msgs"},
+	}
+	for _, tt := range tests {
+		if got := windowTitle("api", tt.active, tt.unread); got != tt.want {
+			t.Errorf("windowTitle(%d, %d) = %q, want %q", tt.active, tt.unread, got, tt.want)
+		}
+	}
+}
+
+func TestModel_updateTitle(t *testing.T) {
+	m := NewModel(nil, nil)
+	if cmd := m.updateTitle(); cmd != nil {
+		t.Error("title set without a project")
+	}
+
+	m.SetProject("api")
+	m.titleActive = activeAgents([]*domain.Agent{
+		{Status: domain.AgentStatusActive},
+		{Status: domain.AgentStatusPaused},
+		{Status: domain.AgentStatusActive},
+	})
+	if cmd := m.updateTitle(); cmd == nil || m.title != "crAIzy api — 2 active" {
+		t.Errorf("title = %q, want the active agents counted", m.title)
+	}
+	if cmd := m.updateTitle(); cmd != nil {
+		t.Error("unchanged title set again")
+	}
+
+	m.updateInbox(InboxCheckedMsg{Unread: []*domain.Message{{Seq: 1}, {Seq: 2}}})
+	if m.title != "crAIzy api — 2 active, 2 msgs" {
+		t.Errorf("title = %q, want the unread messages counted", m.title)
+	}
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/TechnicallyShaun/crAIzy/internal/tui/theme"
+)
+
+// ToastDuration is how long a toast notification stays on screen.
+const ToastDuration = 4 * time.Second
+
+// ToastModel is a transient one-line notification shown in place of the quick commands bar.
+type ToastModel struct {
+	text   string
+	seq    int
+	width  int
+	height int
+}
+
+// Show displays text and returns a command that expires it after ToastDuration.
+// A newer toast replaces an older one; the older one's expiry is then ignored.
+func (m *ToastModel) Show(text string) tea.Cmd {
+	m.seq++
+	m.text = text
+	seq := m.seq
+	return tea.Tick(ToastDuration, func(time.Time) tea.Msg {
+		return toastExpiredMsg{seq: seq}
+	})
+}
+
+// Expire hides the toast if seq still refers to the one on screen.
+func (m *ToastModel) Expire(seq int) {
+	if seq == m.seq {
+		m.text = ""
+	}
+}
+
+// Visible returns true while a toast is on screen.
+func (m ToastModel) Visible() bool {
+	return m.text != ""
+}
+
+func (m *ToastModel) SetSize(w, h int) {
+	m.width = w
+	m.height = h
+}
+
+func (m ToastModel) View() string {
+	textStyle := theme.Toast.
+		Width(m.width).
+		Align(lipgloss.Center)
+
+	containerStyle := lipgloss.NewStyle().
+		Width(m.width).
+		Height(m.height).
+		AlignVertical(lipgloss.Bottom)
+
+	return containerStyle.Render(textStyle.Render(m.text))
+}
@@ -0,0 +1,29 @@
+package version
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	oldCommit, oldDate := Commit, Date
+	t.Cleanup(func() { Commit, Date = oldCommit, oldDate })
+
+	Commit = "0123456789abcdef0123"
+	Date = "2025-01-02T03:04:05Z"
+
+	info := Get()
+
+	if info.Version != Version {
+		t.Errorf("Version = %q, want %q", info.Version, Version)
+	}
+	if info.Commit != "0123456789ab" {
+		t.Errorf("Commit = %q, want it shortened to 12 characters", info.Commit)
+	}
+	if info.Date != Date {
+		t.Errorf("Date = %q, want %q", info.Date, Date)
+	}
+	if info.GoVersion != runtime.Version() {
+		t.Errorf("GoVersion = %q, want %q", info.GoVersion, runtime.Version())
+	}
+}